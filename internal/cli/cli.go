@@ -1,116 +1,68 @@
 package cli
 
 import (
+	"bufio"
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"maps"
 	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
+	"syscall"
+	"time"
 
+	"github.com/charmbracelet/lipgloss"
 	"github.com/dpshade/pocket-prompt/internal/clipboard"
+	"github.com/dpshade/pocket-prompt/internal/export"
+	"github.com/dpshade/pocket-prompt/internal/gallery"
+	"github.com/dpshade/pocket-prompt/internal/i18n"
 	"github.com/dpshade/pocket-prompt/internal/importer"
 	"github.com/dpshade/pocket-prompt/internal/models"
 	"github.com/dpshade/pocket-prompt/internal/renderer"
+	"github.com/dpshade/pocket-prompt/internal/server"
 	"github.com/dpshade/pocket-prompt/internal/service"
+	"github.com/dpshade/pocket-prompt/internal/storage"
+	"github.com/dpshade/pocket-prompt/internal/ui"
+	"golang.org/x/term"
 )
 
 // CLI provides headless command-line interface functionality
 type CLI struct {
-	service *service.Service
+	service Library
+	plain   bool // Output without box-drawing or screen control
 }
 
 // NewCLI creates a new CLI instance
-func NewCLI(svc *service.Service) *CLI {
+func NewCLI(svc Library) *CLI {
 	return &CLI{service: svc}
 }
 
-// Simple boolean expression parser
+// SetPlain limits output to plain text for screen readers and dumb
+// terminals: trees are indented rather than drawn and watch prints each
+// version in turn instead of clearing the screen
+func (c *CLI) SetPlain(plain bool) {
+	c.plain = plain
+}
+
+// parseBooleanExpression parses a tag query with the shared boolean parser
 func parseBooleanExpression(expr string) (*models.BooleanExpression, error) {
-	expr = strings.TrimSpace(expr)
-	
-	// Handle parentheses by finding the innermost ones first
-	for {
-		start := -1
-		for i, r := range expr {
-			if r == '(' {
-				start = i
-			} else if r == ')' && start >= 0 {
-				// Found innermost parentheses
-				inner := expr[start+1 : i]
-				_, err := parseBooleanExpressionSimple(inner)
-				if err != nil {
-					return nil, err
-				}
-				// Replace the parentheses with a placeholder
-				// For simplicity, we'll just parse the simple case for now
-				return parseBooleanExpressionSimple(expr)
-			}
-		}
-		break
-	}
-	
-	return parseBooleanExpressionSimple(expr)
+	return models.ParseBooleanExpression(expr)
 }
 
-func parseBooleanExpressionSimple(expr string) (*models.BooleanExpression, error) {
-	expr = strings.TrimSpace(expr)
-	
-	// Handle NOT expressions
-	if strings.HasPrefix(strings.ToUpper(expr), "NOT ") {
-		inner := strings.TrimSpace(expr[4:])
-		innerExpr, err := parseBooleanExpressionSimple(inner)
-		if err != nil {
-			return nil, err
-		}
-		return models.NewNotExpression(innerExpr), nil
-	}
-	
-	// Handle OR expressions (lower precedence)
-	if orParts := strings.Split(expr, " OR "); len(orParts) > 1 {
-		var expressions []*models.BooleanExpression
-		for _, part := range orParts {
-			subExpr, err := parseBooleanExpressionSimple(strings.TrimSpace(part))
-			if err != nil {
-				return nil, err
-			}
-			expressions = append(expressions, subExpr)
-		}
-		return models.NewOrExpression(expressions...), nil
-	}
-	
-	// Handle AND expressions (higher precedence)
-	if andParts := strings.Split(expr, " AND "); len(andParts) > 1 {
-		var expressions []*models.BooleanExpression
-		for _, part := range andParts {
-			subExpr, err := parseBooleanExpressionSimple(strings.TrimSpace(part))
-			if err != nil {
-				return nil, err
-			}
-			expressions = append(expressions, subExpr)
-		}
-		return models.NewAndExpression(expressions...), nil
-	}
-	
-	// Handle XOR expressions
-	if xorParts := strings.Split(expr, " XOR "); len(xorParts) == 2 {
-		left, err := parseBooleanExpressionSimple(strings.TrimSpace(xorParts[0]))
-		if err != nil {
-			return nil, err
-		}
-		right, err := parseBooleanExpressionSimple(strings.TrimSpace(xorParts[1]))
-		if err != nil {
-			return nil, err
-		}
-		return models.NewXorExpression(left, right), nil
-	}
-	
-	// Remove parentheses if present
-	if strings.HasPrefix(expr, "(") && strings.HasSuffix(expr, ")") {
-		return parseBooleanExpressionSimple(expr[1 : len(expr)-1])
-	}
-	
-	// Single tag expression
-	return models.NewTagExpression(expr), nil
-}// ExecuteCommand processes a CLI command and returns the result
+// builtinCommands are the commands ExecuteCommand handles itself, which a
+// plugin of the same name cannot replace
+var builtinCommands = []string{"list", "ls", "search", "get", "show", "create", "new", "edit",
+	"delete", "rm", "copy", "duplicate", "clone", "render", "watch", "catalog", "locale", "templates", "template", "snippets", "snippet", "tags", "tag",
+	"archive", "search-saved", "boolean-search", "export", "import", "git", "backup", "restore",
+	"conflicts", "plugins", "lint", "dedupe", "rate", "attach", "secret", "pick", "tmux-popup", "serve", "query", "help"}
+
+// ExecuteCommand processes a CLI command and returns the result
 func (c *CLI) ExecuteCommand(args []string) error {
 	if len(args) == 0 {
 		return c.printUsage()
@@ -134,14 +86,26 @@ func (c *CLI) ExecuteCommand(args []string) error {
 		return c.deletePrompt(commandArgs)
 	case "copy":
 		return c.copyPrompt(commandArgs)
+	case "duplicate", "clone":
+		return c.duplicatePrompt(commandArgs)
 	case "render":
 		return c.renderPrompt(commandArgs)
+	case "watch":
+		return c.watchPrompt(commandArgs)
+	case "catalog":
+		return c.writeCatalog(commandArgs)
+	case "locale":
+		return c.handleLocale(commandArgs)
 	case "templates":
 		return c.handleTemplates(commandArgs)
 	case "template":
 		return c.handleTemplate(commandArgs)
+	case "snippets", "snippet":
+		return c.handleSnippets(commandArgs)
 	case "tags":
 		return c.handleTags(commandArgs)
+	case "tag":
+		return c.handleTag(commandArgs)
 	case "archive":
 		return c.handleArchive(commandArgs)
 	case "search-saved":
@@ -154,9 +118,38 @@ func (c *CLI) ExecuteCommand(args []string) error {
 		return c.handleImport(commandArgs)
 	case "git":
 		return c.handleGit(commandArgs)
+	case "backup":
+		return c.handleBackup(commandArgs)
+	case "restore":
+		return c.handleRestore(commandArgs)
+	case "conflicts":
+		return c.handleConflicts(commandArgs)
+	case "plugins":
+		return c.handlePlugins(commandArgs)
+	case "lint":
+		return c.lint(commandArgs)
+	case "dedupe":
+		return c.dedupe(commandArgs)
+	case "rate":
+		return c.ratePrompt(commandArgs)
+	case "attach":
+		return c.attachFile(commandArgs)
+	case "secret":
+		return c.handleSecret(commandArgs)
+	case "pick":
+		return c.pickPrompt(commandArgs)
+	case "tmux-popup":
+		return c.tmuxPopup(commandArgs)
+	case "serve":
+		return c.serve(commandArgs)
+	case "query":
+		return server.NewStdioServer(c.service).Query(os.Stdin, os.Stdout)
 	case "help":
 		return c.printHelp(commandArgs)
 	default:
+		if plugin, ok := c.service.FindPlugin(command); ok {
+			return c.service.RunPlugin(plugin, commandArgs, os.Stdout, os.Stderr)
+		}
 		return fmt.Errorf("unknown command: %s. Use 'help' for usage information", command)
 	}
 }
@@ -164,11 +157,21 @@ func (c *CLI) ExecuteCommand(args []string) error {
 // listPrompts lists all prompts
 func (c *CLI) listPrompts(args []string) error {
 	var format string
-	var tag string
-	var showArchived bool
+	var tags []string
+	var matchAny bool
+	var namespace string
+	var minRating int
+	var dates models.DateFilter
+	scope := service.ActiveOnly
 
 	// Parse flags
 	for i, arg := range args {
+		if isDate, err := parseDateFlag(args, i, &dates); isDate || err != nil {
+			if err != nil {
+				return err
+			}
+			continue
+		}
 		switch arg {
 		case "--format", "-f":
 			if i+1 < len(args) {
@@ -176,29 +179,83 @@ func (c *CLI) listPrompts(args []string) error {
 			}
 		case "--tag", "-t":
 			if i+1 < len(args) {
-				tag = args[i+1]
+				tags = append(tags, args[i+1])
+			}
+		case "--match":
+			if i+1 >= len(args) || (args[i+1] != "any" && args[i+1] != "all") {
+				return fmt.Errorf("--match requires any or all")
+			}
+			matchAny = args[i+1] == "any"
+		case "--namespace", "-n":
+			if i+1 < len(args) {
+				namespace = strings.Trim(args[i+1], "/")
+			}
+		case "--min-rating":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--min-rating requires a number")
+			}
+			rating, err := models.ParseRating(args[i+1])
+			if err != nil {
+				return err
 			}
-		case "--archived", "-a":
-			showArchived = true
+			minRating = rating
+		case "--archived", "--archived-only", "-a":
+			scope = service.ArchivedOnly
+		case "--include-archived":
+			scope = service.IncludeArchived
 		}
 	}
 
-	var prompts []*models.Prompt
-	var err error
+	prompts, err := c.service.ListPromptsInScope(scope)
+	if err != nil {
+		return fmt.Errorf("failed to list prompts: %w", err)
+	}
 
-	if showArchived {
-		prompts, err = c.service.ListArchivedPrompts()
-	} else if tag != "" {
-		prompts, err = c.service.FilterPromptsByTag(tag)
-	} else {
-		prompts, err = c.service.ListPrompts()
+	if len(tags) > 0 {
+		var filtered []*models.Prompt
+		for _, p := range prompts {
+			if p.HasTags(tags, matchAny) {
+				filtered = append(filtered, p)
+			}
+		}
+		prompts = filtered
 	}
 
-	if err != nil {
-		return fmt.Errorf("failed to list prompts: %w", err)
+	if namespace != "" {
+		var filtered []*models.Prompt
+		for _, p := range prompts {
+			if ns := p.Namespace(); ns == namespace || strings.HasPrefix(ns, namespace+"/") {
+				filtered = append(filtered, p)
+			}
+		}
+		prompts = filtered
 	}
 
-	return c.formatOutput(prompts, format)
+	prompts = models.FilterMinRating(prompts, minRating)
+	return c.formatOutput(dates.Filter(prompts), format)
+}
+
+// parseDateFlag applies args[i] to a date filter when it is one of
+// --created-after, --created-before, --updated-after or --updated-before.
+// It reports whether the argument was a date flag.
+func parseDateFlag(args []string, i int, dates *models.DateFilter) (bool, error) {
+	var field, op string
+	switch args[i] {
+	case "--created-after":
+		field, op = "created", ">="
+	case "--created-before":
+		field, op = "created", "<"
+	case "--updated-after":
+		field, op = "updated", ">="
+	case "--updated-before":
+		field, op = "updated", "<"
+	default:
+		return false, nil
+	}
+	if i+1 >= len(args) {
+		return true, fmt.Errorf("%s requires a date", args[i])
+	}
+	return true, dates.Apply(field, op+args[i+1])
 }
 
 // searchPrompts searches prompts using query or boolean expression
@@ -209,12 +266,29 @@ func (c *CLI) searchPrompts(args []string) error {
 
 	var format string
 	var boolean bool
+	var minRating int
+	var dates models.DateFilter
+	scope := service.ActiveOnly
+	sortBy := c.service.SearchSort()
 	query := strings.Join(args, " ")
 
 	// Parse flags from query
 	parts := strings.Fields(query)
 	var cleanedParts []string
+	skipNext := false
 	for i, part := range parts {
+		if skipNext {
+			skipNext = false
+			continue
+		}
+		isDate, err := parseDateFlag(parts, i, &dates)
+		if err != nil {
+			return err
+		}
+		if isDate {
+			skipNext = true
+			continue
+		}
 		switch part {
 		case "--format", "-f":
 			if i+1 < len(parts) {
@@ -222,6 +296,25 @@ func (c *CLI) searchPrompts(args []string) error {
 			}
 		case "--boolean", "-b":
 			boolean = true
+		case "--sort":
+			if i+1 < len(parts) {
+				sortBy = parts[i+1]
+				skipNext = true
+			}
+		case "--min-rating":
+			if i+1 >= len(parts) {
+				return fmt.Errorf("--min-rating requires a number")
+			}
+			rating, err := models.ParseRating(parts[i+1])
+			if err != nil {
+				return err
+			}
+			minRating = rating
+			skipNext = true
+		case "--include-archived":
+			scope = service.IncludeArchived
+		case "--archived-only":
+			scope = service.ArchivedOnly
 		default:
 			if i == 0 || (parts[i-1] != "--format" && parts[i-1] != "-f") {
 				cleanedParts = append(cleanedParts, part)
@@ -241,16 +334,20 @@ func (c *CLI) searchPrompts(args []string) error {
 			return fmt.Errorf("boolean search not fully implemented in CLI mode yet - use simple tag filtering instead")
 		}
 		// Treat as simple tag search for now
-		prompts, err = c.service.FilterPromptsByTag(query)
+		prompts, err = c.service.SearchPromptsByBooleanExpressionInScope(models.NewTagExpression(query), scope)
 	} else {
-		prompts, err = c.service.SearchPrompts(query)
+		prompts, err = c.service.SearchPromptsInScope(query, scope)
 	}
 
 	if err != nil {
 		return fmt.Errorf("search failed: %w", err)
 	}
 
-	return c.formatOutput(prompts, format)
+	prompts = models.FilterMinRating(dates.Filter(prompts), minRating)
+	if err := c.service.SortPrompts(prompts, sortBy); err != nil {
+		return err
+	}
+	return c.formatMatches(prompts, format, models.QueryTerms(query))
 }
 
 // showPrompt displays a specific prompt
@@ -263,6 +360,8 @@ func (c *CLI) showPrompt(args []string) error {
 	var format string
 	var render bool
 	var variables map[string]interface{}
+	var preset string
+	var fields []string
 
 	// Parse flags
 	for i := 1; i < len(args); i++ {
@@ -273,6 +372,15 @@ func (c *CLI) showPrompt(args []string) error {
 				format = args[i+1]
 				i++
 			}
+		case "--field":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--field requires a field name")
+			}
+			var err error
+			if fields, err = export.ParseFields(args[i+1]); err != nil {
+				return err
+			}
+			i++
 		case "--render", "-r":
 			render = true
 		case "--var":
@@ -286,6 +394,11 @@ func (c *CLI) showPrompt(args []string) error {
 				}
 				i++
 			}
+		case "--preset":
+			if i+1 < len(args) {
+				preset = args[i+1]
+				i++
+			}
 		}
 	}
 
@@ -300,8 +413,27 @@ func (c *CLI) showPrompt(args []string) error {
 			template, _ = c.service.GetTemplate(prompt.TemplateRef)
 		}
 
+		ctx, stop := interruptContext()
+		defer stop()
+
 		r := renderer.NewRenderer(prompt, template)
+		r.SetContext(ctx)
+		r.SetAttachmentLoader(c.service.LoadAttachment)
+		r.SetSnippetLoader(c.service.LoadSnippet)
+		r.SetSecretLoader(c.service.LoadSecret)
+		r.SetPreset(preset)
 		
+		// With --field, content is the rendered text
+		if fields != nil {
+			content, err := r.RenderText(variables)
+			if err != nil {
+				return fmt.Errorf("failed to render text: %w", err)
+			}
+			rendered := *prompt
+			rendered.Content = content
+			return export.WriteFields(os.Stdout, &rendered, fields)
+		}
+
 		switch format {
 		case "json":
 			content, err := r.RenderJSON(variables)
@@ -319,21 +451,32 @@ func (c *CLI) showPrompt(args []string) error {
 		return nil
 	}
 
+	if fields != nil {
+		return export.WriteFields(os.Stdout, prompt, fields)
+	}
 	return c.formatSinglePrompt(prompt, format)
 }
 
 // createPrompt creates a new prompt
 func (c *CLI) createPrompt(args []string) error {
 	if len(args) == 0 {
-		return fmt.Errorf("create requires a prompt ID")
+		return fmt.Errorf("create requires a prompt ID or --title")
 	}
 
-	id := args[0]
-	var title, description, content, template string
+	// Without an ID, one is generated from the title
+	var id string
+	if !strings.HasPrefix(args[0], "-") {
+		id, args = args[0], args[1:]
+	}
+	var title, description, content, template, notes string
 	var tags []string
+	var slots, defaults map[string]string
+	var metadata map[string]interface{}
+	var messages []models.Message
+	var encrypted, draft bool
 
 	// Parse flags
-	for i := 1; i < len(args); i++ {
+	for i := 0; i < len(args); i++ {
 		arg := args[i]
 		switch arg {
 		case "--title":
@@ -356,6 +499,11 @@ func (c *CLI) createPrompt(args []string) error {
 				template = args[i+1]
 				i++
 			}
+		case "--notes":
+			if i+1 < len(args) {
+				notes = args[i+1]
+				i++
+			}
 		case "--tags":
 			if i+1 < len(args) {
 				tags = strings.Split(args[i+1], ",")
@@ -365,19 +513,89 @@ func (c *CLI) createPrompt(args []string) error {
 				i++
 			}
 		case "--stdin":
-			// Read content from stdin
-			var buf strings.Builder
-			for {
-				var line string
-				n, err := fmt.Scanln(&line)
-				if n == 0 || err != nil {
-					break
+			data, err := readContent("-")
+			if err != nil {
+				return err
+			}
+			content = data
+		case "--file":
+			if i+1 < len(args) {
+				data, err := readContent(args[i+1])
+				if err != nil {
+					return err
+				}
+				content = data
+				i++
+			}
+		case "--clipboard", "--paste":
+			data, err := clipboard.Read()
+			if err != nil {
+				return fmt.Errorf("failed to read clipboard: %w", err)
+			}
+			if strings.TrimSpace(data) == "" {
+				return fmt.Errorf("the clipboard is empty")
+			}
+			content = data
+		case "--slot":
+			if i+1 < len(args) {
+				name, value, err := parseKeyValue(args[i+1])
+				if err != nil {
+					return err
+				}
+				if slots == nil {
+					slots = make(map[string]string)
+				}
+				slots[name] = value
+				i++
+			}
+		case "--default":
+			if i+1 < len(args) {
+				name, value, err := parseKeyValue(args[i+1])
+				if err != nil {
+					return err
+				}
+				if defaults == nil {
+					defaults = make(map[string]string)
+				}
+				defaults[name] = value
+				i++
+			}
+		case "--meta":
+			if i+1 < len(args) {
+				key, value, err := parseKeyValue(args[i+1])
+				if err != nil {
+					return err
+				}
+				if metadata == nil {
+					metadata = make(map[string]interface{})
+				}
+				metadata[key] = value
+				i++
+			}
+		case "--message":
+			if i+1 < len(args) {
+				message, err := parseMessage(args[i+1])
+				if err != nil {
+					return err
 				}
-				buf.WriteString(line + "\n")
+				messages = append(messages, message)
+				i++
 			}
-			content = buf.String()
+		case "--encrypt":
+			encrypted = true
+		case "--draft":
+			draft = true
 		}
 	}
+	if id == "" && strings.TrimSpace(title) == "" {
+		return fmt.Errorf("create requires a prompt ID or --title")
+	}
+	if len(messages) > 0 {
+		if content != "" {
+			return fmt.Errorf("use either --message or a content option, not both")
+		}
+		content = models.FormatMessages(messages)
+	}
 
 	prompt := &models.Prompt{
 		ID:          id,
@@ -387,16 +605,113 @@ func (c *CLI) createPrompt(args []string) error {
 		Content:     content,
 		Tags:        tags,
 		TemplateRef: template,
+		Slots:       slots,
+		Defaults:    defaults,
+		Metadata:    metadata,
+		Notes:       notes,
+		Encrypted:   encrypted,
+		Draft:       draft,
 	}
 
 	if err := c.service.CreatePrompt(prompt); err != nil {
 		return fmt.Errorf("failed to create prompt: %w", err)
 	}
 
-	fmt.Printf("Created prompt: %s\n", id)
+	fmt.Println(i18n.T("cli.create.created", prompt.ID))
+	c.printSlotWarnings(prompt)
 	return nil
 }
 
+// parseMessage parses a role=content argument, as --message takes
+func parseMessage(arg string) (models.Message, error) {
+	role, content, ok := strings.Cut(arg, "=")
+	role = strings.ToLower(strings.TrimSpace(role))
+	if !ok || !models.ValidRole(role) {
+		return models.Message{}, fmt.Errorf("invalid message %q: use role=content, e.g. system=\"You are a code reviewer\"", arg)
+	}
+	return models.Message{Role: role, Content: content}, nil
+}
+
+// parseKeyValue splits a name=value argument, as --slot and --meta take
+func parseKeyValue(arg string) (string, string, error) {
+	name, value, ok := strings.Cut(arg, "=")
+	if !ok || strings.TrimSpace(name) == "" {
+		return "", "", fmt.Errorf("invalid %q: use name=value", arg)
+	}
+	return strings.TrimSpace(name), value, nil
+}
+
+// printSlotWarnings prints the problems with a prompt's slot values that
+// don't stop it being saved
+func (c *CLI) printSlotWarnings(prompt *models.Prompt) {
+	warnings, _ := c.service.CheckSlots(prompt)
+	for _, warning := range warnings {
+		fmt.Fprintln(os.Stderr, i18n.T("cli.warning", warning))
+	}
+}
+
+// readContent reads prompt or template content from a file, or from stdin
+// when path is "-"
+func readContent(path string) (string, error) {
+	if path == "-" {
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return "", fmt.Errorf("failed to read stdin: %w", err)
+		}
+		return string(data), nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read content file: %w", err)
+	}
+	return string(data), nil
+}
+
+// handleSecret sets or deletes the value of a secret variable. Values are
+// read from the terminal without echo, or from stdin, never from the
+// command line, so they stay out of shell history.
+func (c *CLI) handleSecret(args []string) error {
+	if len(args) < 2 {
+		return fmt.Errorf("secret requires a subcommand (set, delete) and a variable name")
+	}
+	name := args[1]
+	switch args[0] {
+	case "set":
+		var value string
+		if term.IsTerminal(int(os.Stdin.Fd())) {
+			fmt.Fprint(os.Stderr, i18n.T("cli.secret.value", name))
+			data, err := term.ReadPassword(int(os.Stdin.Fd()))
+			fmt.Fprintln(os.Stderr)
+			if err != nil {
+				return fmt.Errorf("failed to read value: %w", err)
+			}
+			value = string(data)
+		} else {
+			data, err := readContent("-")
+			if err != nil {
+				return err
+			}
+			value = strings.TrimRight(data, "\r\n")
+		}
+		if value == "" {
+			return fmt.Errorf("secret value must not be empty")
+		}
+		if err := c.service.SetSecret(name, value); err != nil {
+			return err
+		}
+		fmt.Println(i18n.T("cli.secret.stored", name))
+		return nil
+	case "delete", "rm":
+		if err := c.service.DeleteSecret(name); err != nil {
+			return err
+		}
+		fmt.Println(i18n.T("cli.secret.deleted", name))
+		return nil
+	default:
+		return fmt.Errorf("unknown secret subcommand: %s", args[0])
+	}
+}
+
 // editPrompt edits an existing prompt
 func (c *CLI) editPrompt(args []string) error {
 	if len(args) == 0 {
@@ -409,10 +724,14 @@ func (c *CLI) editPrompt(args []string) error {
 		return fmt.Errorf("failed to get prompt: %w", err)
 	}
 
-	// Parse flags to update fields
+	// Parse flags to update fields. Without any, the prompt opens in $EDITOR.
+	useEditor := len(args) == 1
+	var messages []models.Message
 	for i := 1; i < len(args); i++ {
 		arg := args[i]
 		switch arg {
+		case "--open", "-o":
+			useEditor = true
 		case "--title":
 			if i+1 < len(args) {
 				prompt.Name = args[i+1]
@@ -433,6 +752,11 @@ func (c *CLI) editPrompt(args []string) error {
 				prompt.TemplateRef = args[i+1]
 				i++
 			}
+		case "--notes":
+			if i+1 < len(args) {
+				prompt.Notes = args[i+1]
+				i++
+			}
 		case "--tags":
 			if i+1 < len(args) {
 				tags := strings.Split(args[i+1], ",")
@@ -470,68 +794,319 @@ func (c *CLI) editPrompt(args []string) error {
 				prompt.Tags = newTags
 				i++
 			}
+		case "--encrypt":
+			prompt.Encrypted = true
+		case "--slot":
+			if i+1 < len(args) {
+				name, value, err := parseKeyValue(args[i+1])
+				if err != nil {
+					return err
+				}
+				if prompt.Slots == nil {
+					prompt.Slots = make(map[string]string)
+				}
+				prompt.Slots[name] = value
+				i++
+			}
+		case "--remove-slot":
+			if i+1 < len(args) {
+				delete(prompt.Slots, strings.TrimSpace(args[i+1]))
+				if len(prompt.Slots) == 0 {
+					prompt.Slots = nil
+				}
+				i++
+			}
+		case "--default":
+			if i+1 < len(args) {
+				name, value, err := parseKeyValue(args[i+1])
+				if err != nil {
+					return err
+				}
+				if prompt.Defaults == nil {
+					prompt.Defaults = make(map[string]string)
+				}
+				prompt.Defaults[name] = value
+				i++
+			}
+		case "--remove-default":
+			if i+1 < len(args) {
+				delete(prompt.Defaults, strings.TrimSpace(args[i+1]))
+				i++
+			}
+		case "--remove-preset":
+			if i+1 < len(args) {
+				delete(prompt.Presets, strings.TrimSpace(args[i+1]))
+				i++
+			}
+		case "--meta":
+			if i+1 < len(args) {
+				key, value, err := parseKeyValue(args[i+1])
+				if err != nil {
+					return err
+				}
+				if prompt.Metadata == nil {
+					prompt.Metadata = make(map[string]interface{})
+				}
+				prompt.Metadata[key] = value
+				i++
+			}
+		case "--remove-meta":
+			if i+1 < len(args) {
+				delete(prompt.Metadata, strings.TrimSpace(args[i+1]))
+				if len(prompt.Metadata) == 0 {
+					prompt.Metadata = nil
+				}
+				i++
+			}
+		case "--message":
+			if i+1 < len(args) {
+				message, err := parseMessage(args[i+1])
+				if err != nil {
+					return err
+				}
+				messages = append(messages, message)
+				i++
+			}
+		case "--decrypt":
+			prompt.Encrypted = false
+		case "--draft":
+			prompt.Draft = true
 		}
 	}
+	// Messages replace the body as a whole
+	if len(messages) > 0 {
+		prompt.Content = models.FormatMessages(messages)
+	}
+
+	if useEditor {
+		return c.editPromptInEditor(prompt)
+	}
 
 	if err := c.service.UpdatePrompt(prompt); err != nil {
 		return fmt.Errorf("failed to update prompt: %w", err)
 	}
 
-	fmt.Printf("Updated prompt: %s\n", id)
+	fmt.Println(i18n.T("cli.edit.updated", id))
+	c.printSlotWarnings(prompt)
 	return nil
 }
 
-// deletePrompt deletes a prompt
-func (c *CLI) deletePrompt(args []string) error {
-	if len(args) == 0 {
-		return fmt.Errorf("delete requires a prompt ID")
+// editPromptInEditor opens a prompt's markdown file in the user's editor
+// and saves the result as a new version. The file is edited as a temporary
+// copy, so it works with every storage backend and with encrypted prompts.
+func (c *CLI) editPromptInEditor(prompt *models.Prompt) error {
+	data, err := c.service.PromptSource(prompt)
+	if err != nil {
+		return err
 	}
 
-	id := args[0]
-	var force bool
+	tmp, err := os.CreateTemp("", "pocket-prompt-*.md")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write temporary file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write temporary file: %w", err)
+	}
 
-	// Parse flags
-	for _, arg := range args[1:] {
-		if arg == "--force" || arg == "-f" {
-			force = true
+	for {
+		if err := openEditor(tmp.Name()); err != nil {
+			return err
 		}
-	}
 
-	if !force {
-		fmt.Printf("Are you sure you want to delete prompt '%s'? (y/N): ", id)
+		edited, err := os.ReadFile(tmp.Name())
+		if err != nil {
+			return fmt.Errorf("failed to read edited file: %w", err)
+		}
+
+		updated, err := c.service.UpdatePromptFromSource(prompt, edited)
+		if err == nil {
+			if updated == nil {
+				fmt.Println(i18n.T("cli.edit.no_changes"))
+			} else {
+				fmt.Println(i18n.T("cli.edit.updated_version", updated.ID, updated.Version))
+				c.printSlotWarnings(updated)
+			}
+			return nil
+		}
+
+		fmt.Println(i18n.T("cli.error", err))
+		fmt.Print(i18n.T("cli.edit.again"))
 		var response string
 		fmt.Scanln(&response)
 		if strings.ToLower(response) != "y" && strings.ToLower(response) != "yes" {
-			fmt.Println("Cancelled")
-			return nil
+			return fmt.Errorf("prompt not updated")
 		}
 	}
+}
 
-	if err := c.service.DeletePrompt(id); err != nil {
-		return fmt.Errorf("failed to delete prompt: %w", err)
+// openEditor opens a file in $VISUAL or $EDITOR, falling back to vi
+func openEditor(path string) error {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
 	}
 
-	fmt.Printf("Deleted prompt: %s\n", id)
+	// The editor may carry arguments, e.g. "code --wait"
+	parts := strings.Fields(editor)
+	cmd := exec.Command(parts[0], append(parts[1:], path)...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to run editor %s: %w", editor, err)
+	}
 	return nil
 }
 
-// copyPrompt copies a prompt to clipboard
-func (c *CLI) copyPrompt(args []string) error {
+// deletePrompt deletes a prompt, or every prompt matched by several IDs,
+// a boolean tag query or a tag
+func (c *CLI) deletePrompt(args []string) error {
 	if len(args) == 0 {
-		return fmt.Errorf("copy requires a prompt ID")
+		return fmt.Errorf("delete requires a prompt ID")
 	}
 
-	id := args[0]
-	var format string
-	var variables map[string]interface{}
+	var ids []string
+	var query, tag string
+	var force, dryRun bool
 
 	// Parse flags
-	for i := 1; i < len(args); i++ {
+	for i := 0; i < len(args); i++ {
 		arg := args[i]
 		switch arg {
-		case "--format", "-f":
+		case "--force", "-f":
+			force = true
+		case "--query", "-q":
 			if i+1 < len(args) {
-				format = args[i+1]
+				query = args[i+1]
+				i++
+			}
+		case "--tag", "-t":
+			if i+1 < len(args) {
+				tag = args[i+1]
+				i++
+			}
+		case "--dry-run", "--preview":
+			dryRun = true
+		default:
+			if strings.HasPrefix(arg, "-") {
+				return fmt.Errorf("unknown delete option: %s", arg)
+			}
+			ids = append(ids, arg)
+		}
+	}
+
+	if len(ids) != 1 || query != "" || tag != "" || dryRun {
+		return c.deletePrompts(ids, query, tag, force, dryRun)
+	}
+
+	id := ids[0]
+	if !force {
+		fmt.Print(i18n.T("cli.delete.confirm", id))
+		var response string
+		fmt.Scanln(&response)
+		if strings.ToLower(response) != "y" && strings.ToLower(response) != "yes" {
+			fmt.Println(i18n.T("cli.cancelled"))
+			return nil
+		}
+	}
+
+	if err := c.service.DeletePrompt(id); err != nil {
+		return fmt.Errorf("failed to delete prompt: %w", err)
+	}
+
+	fmt.Println(i18n.T("cli.delete.deleted", id))
+	return nil
+}
+
+// deletePrompts deletes the prompts selected by IDs, a query and a tag
+// after showing them and asking for confirmation
+func (c *CLI) deletePrompts(ids []string, query, tag string, force, dryRun bool) error {
+	if len(ids) == 0 && strings.TrimSpace(query) == "" && strings.TrimSpace(tag) == "" {
+		return fmt.Errorf("select prompts by ID, --query or --tag")
+	}
+
+	prompts, err := c.selectPrompts(ids, query, "", tag)
+	if err != nil {
+		return err
+	}
+	if len(prompts) == 0 {
+		fmt.Println(i18n.T("cli.delete.no_matches"))
+		return nil
+	}
+
+	for _, p := range prompts {
+		fmt.Printf("  %s - %s\n", p.ID, p.Title())
+	}
+	if dryRun {
+		fmt.Println(i18n.T("cli.delete.would_delete", len(prompts)))
+		return nil
+	}
+
+	if !force {
+		fmt.Print(i18n.T("cli.delete.confirm_many", len(prompts)))
+		var response string
+		fmt.Scanln(&response)
+		if strings.ToLower(response) != "y" && strings.ToLower(response) != "yes" {
+			fmt.Println(i18n.T("cli.cancelled"))
+			return nil
+		}
+	}
+
+	deleted, err := c.service.DeletePrompts(prompts)
+	if err != nil {
+		return fmt.Errorf("deleted %d of %d prompts: %w", len(deleted), len(prompts), err)
+	}
+
+	fmt.Println(i18n.T("cli.delete.deleted_many", len(deleted)))
+	return nil
+}
+
+// duplicatePrompt clones a prompt under a new ID
+func (c *CLI) duplicatePrompt(args []string) error {
+	if len(args) == 0 || len(args) > 2 {
+		return fmt.Errorf("duplicate requires a prompt ID\n\nUsage: pocket-prompt duplicate <id> [new-id]")
+	}
+
+	var newID string
+	if len(args) == 2 {
+		newID = args[1]
+	}
+
+	duplicate, err := c.service.DuplicatePrompt(args[0], newID)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(i18n.T("cli.duplicate.done", duplicate.Metadata["copied_from"], duplicate.ID))
+	return nil
+}
+
+// copyPrompt copies a prompt to clipboard
+func (c *CLI) copyPrompt(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("copy requires a prompt ID")
+	}
+
+	id := args[0]
+	var format string
+	var variables map[string]interface{}
+	var preset string
+
+	// Parse flags
+	for i := 1; i < len(args); i++ {
+		arg := args[i]
+		switch arg {
+		case "--format", "-f":
+			if i+1 < len(args) {
+				format = args[i+1]
 				i++
 			}
 		case "--var":
@@ -545,6 +1120,11 @@ func (c *CLI) copyPrompt(args []string) error {
 				}
 				i++
 			}
+		case "--preset":
+			if i+1 < len(args) {
+				preset = args[i+1]
+				i++
+			}
 		}
 	}
 
@@ -558,12 +1138,24 @@ func (c *CLI) copyPrompt(args []string) error {
 		template, _ = c.service.GetTemplate(prompt.TemplateRef)
 	}
 
+	ctx, stop := interruptContext()
+	defer stop()
+
 	r := renderer.NewRenderer(prompt, template)
+	r.SetContext(ctx)
+	r.SetAttachmentLoader(c.service.LoadAttachment)
+	r.SetSnippetLoader(c.service.LoadSnippet)
+	r.SetSecretLoader(c.service.LoadSecret)
+	r.SetPreset(preset)
 	
-	var content string
+	var content, html string
 	switch format {
 	case "json":
 		content, err = r.RenderJSON(variables)
+	case "html", "rich":
+		if content, err = r.RenderText(variables); err == nil {
+			html, err = renderer.MarkdownToHTML(content)
+		}
 	default:
 		content, err = r.RenderText(variables)
 	}
@@ -572,13 +1164,23 @@ func (c *CLI) copyPrompt(args []string) error {
 		return fmt.Errorf("failed to render prompt: %w", err)
 	}
 
-	if statusMsg, err := clipboard.CopyWithFallback(content); err != nil {
+	copyContent := clipboard.CopyWithFallback
+	if html != "" {
+		copyContent = func(text string) (string, error) {
+			return clipboard.CopyHTMLWithFallback(html, text)
+		}
+	}
+	if statusMsg, err := copyContent(content); err != nil {
 		// Print the helpful error message and continue without failing
-		fmt.Printf("Warning: %v\n", err)
-		fmt.Printf("Content saved but not copied to clipboard.\n")
+		fmt.Println(i18n.T("cli.warning", err))
+		fmt.Println(i18n.T("cli.copy.not_copied"))
 	} else {
 		fmt.Printf("%s\n", statusMsg)
 	}
+
+	if err := c.service.RecordUsage(prompt.ID); err != nil {
+		fmt.Println(i18n.T("cli.warning.usage", err))
+	}
 	return nil
 }
 
@@ -591,6 +1193,7 @@ func (c *CLI) renderPrompt(args []string) error {
 	id := args[0]
 	var format string
 	var variables map[string]interface{}
+	var preset, savePreset string
 
 	// Parse flags
 	for i := 1; i < len(args); i++ {
@@ -612,6 +1215,16 @@ func (c *CLI) renderPrompt(args []string) error {
 				}
 				i++
 			}
+		case "--save-preset":
+			if i+1 < len(args) {
+				savePreset = args[i+1]
+				i++
+			}
+		case "--preset":
+			if i+1 < len(args) {
+				preset = args[i+1]
+				i++
+			}
 		}
 	}
 
@@ -625,313 +1238,1923 @@ func (c *CLI) renderPrompt(args []string) error {
 		template, _ = c.service.GetTemplate(prompt.TemplateRef)
 	}
 
+	ctx, stop := interruptContext()
+	defer stop()
+
+	content, err := c.renderAs(ctx, prompt, template, preset, format, variables)
+	if err != nil {
+		return err
+	}
+	fmt.Print(content)
+
+	// The values given on the command line become a preset once they render
+	if savePreset != "" {
+		values := make(map[string]string, len(variables))
+		for name, value := range variables {
+			values[name] = fmt.Sprint(value)
+		}
+		if _, err := c.service.SavePreset(prompt.ID, savePreset, values); err != nil {
+			return err
+		}
+		fmt.Fprintln(os.Stderr, i18n.T("cli.render.preset_saved", savePreset, prompt.ID))
+	}
+
+	if err := c.service.RecordUsage(prompt.ID); err != nil {
+		fmt.Fprintln(os.Stderr, i18n.T("cli.warning.usage", err))
+	}
+	return nil
+}
+
+// renderAs renders a prompt in a format: text (the default), json or html
+func (c *CLI) renderAs(ctx context.Context, prompt *models.Prompt, template *models.Template, preset, format string, variables map[string]interface{}) (string, error) {
 	r := renderer.NewRenderer(prompt, template)
-	
+	r.SetContext(ctx)
+	r.SetAttachmentLoader(c.service.LoadAttachment)
+	r.SetSnippetLoader(c.service.LoadSnippet)
+	r.SetSecretLoader(c.service.LoadSecret)
+	r.SetPreset(preset)
+
 	switch format {
 	case "json":
 		content, err := r.RenderJSON(variables)
 		if err != nil {
-			return fmt.Errorf("failed to render JSON: %w", err)
+			return "", fmt.Errorf("failed to render JSON: %w", err)
+		}
+		return content, nil
+	case "html":
+		content, err := r.RenderHTML(variables)
+		if err != nil {
+			return "", fmt.Errorf("failed to render HTML: %w", err)
 		}
-		fmt.Print(content)
+		return content, nil
 	default:
 		content, err := r.RenderText(variables)
 		if err != nil {
-			return fmt.Errorf("failed to render text: %w", err)
+			return "", fmt.Errorf("failed to render text: %w", err)
 		}
-		fmt.Print(content)
+		return content, nil
 	}
-
-	return nil
 }
 
-// formatOutput formats prompts for output
-func (c *CLI) formatOutput(prompts []*models.Prompt, format string) error {
-	switch format {
-	case "json":
-		return json.NewEncoder(os.Stdout).Encode(prompts)
-	case "ids":
-		for _, p := range prompts {
-			fmt.Println(p.ID)
-		}
-	case "table":
-		fmt.Printf("%-20s %-30s %-15s %s\n", "ID", "Title", "Version", "Updated")
-		fmt.Println(strings.Repeat("-", 80))
-		for _, p := range prompts {
-			title := p.Name
-			if len(title) > 30 {
-				title = title[:27] + "..."
-			}
-			fmt.Printf("%-20s %-30s %-15s %s\n", 
-				p.ID, title, p.Version, p.UpdatedAt.Format("2006-01-02"))
-		}
-	default:
-		for _, p := range prompts {
-			fmt.Printf("%s - %s\n", p.ID, p.Name)
-			if p.Summary != "" {
-				fmt.Printf("  %s\n", p.Summary)
+// writeCatalog writes a markdown index of the library for the front page of
+// the repository holding it
+func (c *CLI) writeCatalog(args []string) error {
+	var options export.CatalogOptions
+	var outputFile string
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--output", "-o":
+			if i+1 < len(args) {
+				outputFile = args[i+1]
+				i++
 			}
-			if len(p.Tags) > 0 {
-				fmt.Printf("  Tags: %s\n", strings.Join(p.Tags, ", "))
+		case "--title":
+			if i+1 < len(args) {
+				options.Title = args[i+1]
+				i++
 			}
-			fmt.Println()
+		default:
+			return fmt.Errorf("unknown catalog option: %s", args[i])
+		}
+	}
+
+	prompts, err := c.service.ListPrompts()
+	if err != nil {
+		return fmt.Errorf("failed to list prompts: %w", err)
+	}
+
+	if outputFile == "" {
+		return export.WriteCatalog(os.Stdout, prompts, options)
+	}
+
+	// Links are relative to the catalog, so they work wherever it is written
+	if abs, err := filepath.Abs(outputFile); err == nil {
+		if rel, err := filepath.Rel(filepath.Dir(abs), c.service.LibraryDir()); err == nil {
+			options.LinkPrefix = rel
 		}
 	}
+	file, err := os.Create(outputFile)
+	if err != nil {
+		return fmt.Errorf("failed to create output file: %w", err)
+	}
+	defer file.Close()
+	if err := export.WriteCatalog(file, prompts, options); err != nil {
+		return fmt.Errorf("failed to write catalog: %w", err)
+	}
+	fmt.Fprintln(os.Stderr, i18n.T("cli.catalog.written", outputFile))
 	return nil
 }
 
-// formatSinglePrompt formats a single prompt for output
-func (c *CLI) formatSinglePrompt(prompt *models.Prompt, format string) error {
-	switch format {
-	case "json":
-		return json.NewEncoder(os.Stdout).Encode(prompt)
+// handleLocale shows the language of the TUI and CLI, or writes its
+// messages as a start for a translation
+func (c *CLI) handleLocale(args []string) error {
+	if len(args) == 0 {
+		translated, total := i18n.Stats()
+		fmt.Println(i18n.T("cli.locale.current", i18n.Locale(), translated, total))
+		return nil
+	}
+	switch args[0] {
+	case "template":
+		return i18n.WriteTemplate(os.Stdout)
 	default:
-		fmt.Printf("ID: %s\n", prompt.ID)
-		fmt.Printf("Title: %s\n", prompt.Name)
-		fmt.Printf("Version: %s\n", prompt.Version)
-		if prompt.Summary != "" {
-			fmt.Printf("Description: %s\n", prompt.Summary)
-		}
-		if len(prompt.Tags) > 0 {
-			fmt.Printf("Tags: %s\n", strings.Join(prompt.Tags, ", "))
-		}
-		if prompt.TemplateRef != "" {
-			fmt.Printf("Template: %s\n", prompt.TemplateRef)
-		}
-		fmt.Printf("Created: %s\n", prompt.CreatedAt.Format("2006-01-02 15:04"))
-		fmt.Printf("Updated: %s\n", prompt.UpdatedAt.Format("2006-01-02 15:04"))
-		fmt.Printf("\nContent:\n%s\n", prompt.Content)
+		return fmt.Errorf("unknown locale command: %s", args[0])
 	}
-	return nil
 }
 
-// Additional command handlers would go here...
-// This is a simplified implementation focusing on core functionality
+// watchInterval is how often watch checks the prompt for changes
+const watchInterval = 300 * time.Millisecond
 
-func (c *CLI) handleTemplates(args []string) error {
+// watchPrompt renders a prompt, then renders it again every time its file
+// or its template changes, until interrupted. The output goes to stdout,
+// redrawn in place on a terminal, or to a file with --output.
+func (c *CLI) watchPrompt(args []string) error {
 	if len(args) == 0 {
-		// List templates
-		templates, err := c.service.ListTemplates()
-		if err != nil {
-			return fmt.Errorf("failed to list templates: %w", err)
-		}
+		return fmt.Errorf("watch requires a prompt ID")
+	}
 
-		for _, t := range templates {
-			fmt.Printf("%s - %s\n", t.ID, t.Name)
-			if t.Description != "" {
-				fmt.Printf("  %s\n", t.Description)
+	id := args[0]
+	var format, preset, output string
+	variables := make(map[string]interface{})
+	for i := 1; i < len(args); i++ {
+		switch args[i] {
+		case "--format", "-f":
+			if i+1 < len(args) {
+				format = args[i+1]
+				i++
 			}
-			fmt.Println()
+		case "--var":
+			if i+1 < len(args) {
+				if name, value, ok := strings.Cut(args[i+1], "="); ok {
+					variables[name] = value
+				}
+				i++
+			}
+		case "--preset":
+			if i+1 < len(args) {
+				preset = args[i+1]
+				i++
+			}
+		case "--output", "-o":
+			if i+1 < len(args) {
+				output = args[i+1]
+				i++
+			}
+		default:
+			return fmt.Errorf("unknown option: %s", args[i])
 		}
-		return nil
 	}
 
-	subcommand := args[0]
-	switch subcommand {
-	case "show":
-		if len(args) < 2 {
-			return fmt.Errorf("templates show requires a template ID")
-		}
-		template, err := c.service.GetTemplate(args[1])
-		if err != nil {
-			return fmt.Errorf("failed to get template: %w", err)
+	// Check the prompt exists before waiting for changes to it
+	if _, err := c.service.GetPrompt(id); err != nil {
+		return fmt.Errorf("failed to get prompt: %w", err)
+	}
+
+	ctx, stop := interruptContext()
+	defer stop()
+
+	redraw := output == "" && !c.plain && term.IsTerminal(int(os.Stdout.Fd()))
+	fmt.Fprintln(os.Stderr, i18n.T("cli.watch.watching", id))
+
+	ticker := time.NewTicker(watchInterval)
+	defer ticker.Stop()
+	var last string
+	for {
+		// Editors often replace the file on save, so a failed read is
+		// reported and retried rather than ending the watch
+		prompt, err := c.service.GetPrompt(id)
+		var template *models.Template
+		if err == nil && prompt.TemplateRef != "" {
+			template, _ = c.service.GetTemplate(prompt.TemplateRef)
 		}
-		
-		fmt.Printf("ID: %s\n", template.ID)
-		fmt.Printf("Name: %s\n", template.Name)
-		if template.Description != "" {
-			fmt.Printf("Description: %s\n", template.Description)
+		version := watchVersion(prompt, template, err)
+		if version != last {
+			last = version
+			c.renderWatched(ctx, prompt, template, err, preset, format, variables, output, redraw)
 		}
-		fmt.Printf("Created: %s\n", template.CreatedAt.Format("2006-01-02 15:04"))
-		fmt.Printf("Updated: %s\n", template.UpdatedAt.Format("2006-01-02 15:04"))
-		fmt.Printf("\nContent:\n%s\n", template.Content)
-		
-		if len(template.Slots) > 0 {
-			fmt.Println("\nSlots:")
-			for _, slot := range template.Slots {
-				fmt.Printf("  %s", slot.Name)
-				if slot.Required {
-					fmt.Print(" [required]")
-				}
-				if slot.Default != "" {
-					fmt.Printf(" [default: %s]", slot.Default)
-				}
-				if slot.Description != "" {
-					fmt.Printf(" - %s", slot.Description)
-				}
-				fmt.Println()
-			}
+
+		select {
+		case <-ctx.Done():
+			fmt.Fprintln(os.Stderr)
+			return nil
+		case <-ticker.C:
 		}
-		return nil
-	default:
-		return fmt.Errorf("unknown templates subcommand: %s", subcommand)
 	}
 }
 
-func (c *CLI) handleTags(args []string) error {
-	tags, err := c.service.GetAllTags()
+// watchVersion identifies what a watched render depends on, so it is only
+// redone when the prompt or its template changed
+func watchVersion(prompt *models.Prompt, template *models.Template, err error) string {
 	if err != nil {
-		return fmt.Errorf("failed to get tags: %w", err)
+		return "error: " + err.Error()
 	}
-
-	for _, tag := range tags {
-		fmt.Println(tag)
+	version := prompt.ContentHash
+	if template != nil {
+		data, _ := storage.MarshalTemplate(template)
+		version += "\x00" + string(data)
 	}
-	return nil
+	return version
 }
 
-func (c *CLI) handleArchive(args []string) error {
-	if len(args) == 0 {
-		// List archived prompts
+// renderWatched renders one version of a watched prompt to the terminal or
+// the output file. Errors are shown in place of the output.
+func (c *CLI) renderWatched(ctx context.Context, prompt *models.Prompt, template *models.Template, err error, preset, format string, variables map[string]interface{}, output string, redraw bool) {
+	var content string
+	if err == nil {
+		content, err = c.renderAs(ctx, prompt, template, preset, format, variables)
+	}
+	stamp := time.Now().Format("15:04:05")
+
+	if output != "" {
+		if err == nil {
+			err = os.WriteFile(output, []byte(content), 0644)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "[%s] %v\n", stamp, err)
+		} else {
+			fmt.Fprintln(os.Stderr, i18n.T("cli.watch.rendered", stamp, prompt.ID, output))
+		}
+		return
+	}
+
+	// Piped output keeps every version, each under the time it was made
+	if redraw {
+		fmt.Print("\033[H\033[2J")
+	} else {
+		fmt.Printf("--- %s ---\n", stamp)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "[%s] %v\n", stamp, err)
+		return
+	}
+	fmt.Print(content)
+	if !strings.HasSuffix(content, "\n") {
+		fmt.Println()
+	}
+}
+
+// pickPrompt opens a fuzzy picker on the terminal and prints the chosen
+// prompt's ID, or its rendered content with --render, so it can be used
+// from shell functions and editor mappings
+func (c *CLI) pickPrompt(args []string) error {
+	var render bool
+	var variables map[string]interface{}
+	var preset string
+	var query []string
+
+	// Parse flags
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch arg {
+		case "--render", "-r":
+			render = true
+		case "--var":
+			if i+1 < len(args) {
+				if variables == nil {
+					variables = make(map[string]interface{})
+				}
+				parts := strings.SplitN(args[i+1], "=", 2)
+				if len(parts) == 2 {
+					variables[parts[0]] = parts[1]
+				}
+				i++
+			}
+		case "--preset":
+			if i+1 < len(args) {
+				preset = args[i+1]
+				i++
+			}
+		default:
+			query = append(query, arg)
+		}
+	}
+
+	picked, err := ui.RunPicker(c.service, strings.Join(query, " "))
+	if err != nil {
+		return err
+	}
+	if !render {
+		fmt.Println(picked.ID)
+		return nil
+	}
+
+	prompt, err := c.service.GetPrompt(picked.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get prompt: %w", err)
+	}
+	ctx, stop := interruptContext()
+	defer stop()
+
+	content, err := c.promptRenderer(ctx, prompt, preset).RenderText(variables)
+	if err != nil {
+		return fmt.Errorf("failed to render text: %w", err)
+	}
+	fmt.Print(content)
+
+	if err := c.service.RecordUsage(prompt.ID); err != nil {
+		fmt.Fprintln(os.Stderr, i18n.T("cli.warning.usage", err))
+	}
+	return nil
+}
+
+// tmuxPopup picks a prompt, asks for its variables and copies the rendered
+// result, or types it into a tmux pane with --paste. It is meant to run in
+// tmux display-popup, which closes when it exits.
+func (c *CLI) tmuxPopup(args []string) error {
+	var paste bool
+	var pane, preset string
+	var query []string
+
+	// Parse flags
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch arg {
+		case "--paste", "-p":
+			paste = true
+		case "--copy", "-c":
+			paste = false
+		case "--pane", "-t":
+			if i+1 < len(args) {
+				pane = args[i+1]
+				i++
+			}
+		case "--preset":
+			if i+1 < len(args) {
+				preset = args[i+1]
+				i++
+			}
+		default:
+			query = append(query, arg)
+		}
+	}
+	if paste && os.Getenv("TMUX") == "" {
+		return fmt.Errorf("--paste needs to run inside tmux")
+	}
+
+	picked, err := ui.RunPicker(c.service, strings.Join(query, " "))
+	if err != nil {
+		return err
+	}
+	prompt, err := c.service.GetPrompt(picked.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get prompt: %w", err)
+	}
+	fields, err := c.service.PromptVariables(prompt, preset)
+	if err != nil {
+		return err
+	}
+	variables, err := askVariables(fields)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := interruptContext()
+	defer stop()
+
+	content, err := c.promptRenderer(ctx, prompt, preset).RenderText(variables)
+	if err != nil {
+		return fmt.Errorf("failed to render text: %w", err)
+	}
+	if paste {
+		if err := tmuxSendKeys(pane, content); err != nil {
+			return err
+		}
+	} else if _, err := clipboard.CopyWithFallback(content); err != nil {
+		return fmt.Errorf("failed to copy %s: %w", prompt.ID, err)
+	}
+
+	if err := c.service.RecordUsage(prompt.ID); err != nil {
+		fmt.Fprintln(os.Stderr, i18n.T("cli.warning.usage", err))
+	}
+	return nil
+}
+
+// askVariables asks for the value of each variable on the terminal. An
+// empty answer keeps the variable's default.
+func askVariables(fields []models.Slot) (map[string]interface{}, error) {
+	if len(fields) == 0 {
+		return nil, nil
+	}
+	tty, err := os.OpenFile("/dev/tty", os.O_RDWR, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open terminal for variables: %w", err)
+	}
+	defer tty.Close()
+
+	variables := make(map[string]interface{})
+	reader := bufio.NewReader(tty)
+	for _, field := range fields {
+		label := field.Name
+		if field.Description != "" {
+			label += " (" + field.Description + ")"
+		}
+		if field.Default != "" {
+			label += " [" + field.Default + "]"
+		}
+		fmt.Fprintf(tty, "%s: ", label)
+		line, err := reader.ReadString('\n')
+		if err != nil && line == "" {
+			return nil, fmt.Errorf("failed to read %s: %w", field.Name, err)
+		}
+		if value := strings.TrimRight(line, "\r\n"); value != "" {
+			variables[field.Name] = value
+		}
+	}
+	return variables, nil
+}
+
+// tmuxSendKeys types text into a tmux pane, or the pane the current client
+// is on when pane is empty. The text is sent literally, so newlines in it
+// are typed as Enter.
+func tmuxSendKeys(pane, text string) error {
+	args := []string{"send-keys"}
+	if pane != "" {
+		args = append(args, "-t", pane)
+	}
+	args = append(args, "-l", "--", text)
+	if output, err := exec.Command("tmux", args...).CombinedOutput(); err != nil {
+		return fmt.Errorf("tmux send-keys failed: %w: %s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// serve answers JSON-RPC requests on stdin and stdout for editor
+// extensions. The HTTP server is started with --url-server instead.
+func (c *CLI) serve(args []string) error {
+	if !slices.Contains(args, "--stdio") {
+		return fmt.Errorf("serve requires --stdio; use pocket-prompt --url-server for the HTTP server")
+	}
+	return server.NewStdioServer(c.service).Serve(os.Stdin, os.Stdout)
+}
+
+// interruptContext returns a context cancelled by Ctrl-C or SIGTERM, so a
+// render waiting on the keychain is stopped rather than left hanging
+func interruptContext() (context.Context, context.CancelFunc) {
+	return signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+}
+
+// promptRenderer creates a renderer for a prompt and its template that
+// loads attachments, snippets and secrets from the library until ctx is
+// cancelled
+func (c *CLI) promptRenderer(ctx context.Context, prompt *models.Prompt, preset string) *renderer.Renderer {
+	var template *models.Template
+	if prompt.TemplateRef != "" {
+		template, _ = c.service.GetTemplate(prompt.TemplateRef)
+	}
+
+	r := renderer.NewRenderer(prompt, template)
+	r.SetContext(ctx)
+	r.SetAttachmentLoader(c.service.LoadAttachment)
+	r.SetSnippetLoader(c.service.LoadSnippet)
+	r.SetSecretLoader(c.service.LoadSecret)
+	r.SetPreset(preset)
+	return r
+}
+
+// formatOutput formats prompts for output
+func (c *CLI) formatOutput(prompts []*models.Prompt, format string) error {
+	return c.formatMatches(prompts, format, nil)
+}
+
+// matchStyle marks the parts of search results that matched the query. It
+// renders plain text when stdout is not a terminal.
+var matchStyle = lipgloss.NewStyle().Bold(true).Underline(true)
+
+// highlightMatches marks the occurrences of the lower-case terms in text
+func highlightMatches(text string, terms []string) string {
+	ranges := models.MatchRanges(text, terms)
+	if len(ranges) == 0 {
+		return text
+	}
+	var b strings.Builder
+	last := 0
+	for _, r := range ranges {
+		b.WriteString(text[last:r[0]])
+		b.WriteString(matchStyle.Render(text[r[0]:r[1]]))
+		last = r[1]
+	}
+	b.WriteString(text[last:])
+	return b.String()
+}
+
+// formatMatches formats prompts for output like formatOutput, highlighting
+// the search terms in the text formats
+func (c *CLI) formatMatches(prompts []*models.Prompt, format string, terms []string) error {
+	switch format {
+	case "json":
+		return json.NewEncoder(os.Stdout).Encode(prompts)
+	case "alfred":
+		return export.WriteAlfred(os.Stdout, prompts)
+	case "raycast":
+		return export.WriteRaycast(os.Stdout, prompts)
+	case "ids":
+		for _, p := range prompts {
+			fmt.Println(p.ID)
+		}
+	case "table":
+		fmt.Printf("%-20s %-30s %-15s %s\n", i18n.T("cli.column.id"), i18n.T("cli.column.title"), i18n.T("cli.column.version"), i18n.T("cli.column.updated"))
+		fmt.Println(strings.Repeat("-", 80))
+		for _, p := range prompts {
+			title := p.Name
+			if len(title) > 30 {
+				title = title[:27] + "..."
+			}
+			fmt.Printf("%s %s %-15s %s\n",
+				highlightMatches(fmt.Sprintf("%-20s", p.ID), terms),
+				highlightMatches(fmt.Sprintf("%-30s", title), terms),
+				p.Version, p.UpdatedAt.Format("2006-01-02"))
+		}
+	default:
+		// Group prompts by directory namespace when there is more than one
+		grouped := make([]*models.Prompt, len(prompts))
+		copy(grouped, prompts)
+		models.SortByNamespace(grouped)
+		showGroups := len(grouped) > 0 && grouped[0].Namespace() != grouped[len(grouped)-1].Namespace()
+
+		currentNamespace := ""
+		for i, p := range grouped {
+			if showGroups && (i == 0 || p.Namespace() != currentNamespace) {
+				currentNamespace = p.Namespace()
+				if currentNamespace == "" {
+					fmt.Println(i18n.T("cli.list.top_level"))
+				} else {
+					fmt.Printf("[%s/]\n", currentNamespace)
+				}
+			}
+			fmt.Printf("%s - %s", highlightMatches(p.ID, terms), highlightMatches(p.Name, terms))
+			if p.Rating > 0 {
+				fmt.Printf(" %s", models.Stars(p.Rating))
+			}
+			fmt.Println()
+			if p.Summary != "" {
+				fmt.Printf("  %s\n", highlightMatches(p.Summary, terms))
+			}
+			if len(p.Tags) > 0 {
+				fmt.Printf("  %s\n", i18n.T("cli.label.tags", highlightMatches(strings.Join(p.Tags, ", "), terms)))
+			}
+			fmt.Println()
+		}
+	}
+	return nil
+}
+
+// formatSinglePrompt formats a single prompt for output
+func (c *CLI) formatSinglePrompt(prompt *models.Prompt, format string) error {
+	switch format {
+	case "json":
+		return json.NewEncoder(os.Stdout).Encode(prompt)
+	default:
+		fmt.Println(i18n.T("cli.label.id", prompt.ID))
+		fmt.Println(i18n.T("cli.label.title", prompt.Name))
+		fmt.Println(i18n.T("cli.label.version", prompt.Version))
+		if prompt.Summary != "" {
+			fmt.Println(i18n.T("cli.label.description", prompt.Summary))
+		}
+		if len(prompt.Tags) > 0 {
+			fmt.Println(i18n.T("cli.label.tags", strings.Join(prompt.Tags, ", ")))
+		}
+		if prompt.TemplateRef != "" {
+			fmt.Println(i18n.T("cli.label.template", prompt.TemplateRef))
+		}
+		if len(prompt.Slots) > 0 {
+			fmt.Println(i18n.T("cli.label.slots"))
+			for _, name := range slices.Sorted(maps.Keys(prompt.Slots)) {
+				fmt.Printf("  %s: %s\n", name, prompt.Slots[name])
+			}
+		}
+		if len(prompt.Variables) > 0 {
+			fmt.Println(i18n.T("cli.label.variables"))
+			for _, variable := range prompt.Variables {
+				fmt.Printf("  %s", variable.Name)
+				if variable.Secret {
+					fmt.Printf(" %s", i18n.T("cli.label.secret"))
+				}
+				if variable.Description != "" {
+					fmt.Printf(" - %s", variable.Description)
+				}
+				fmt.Println()
+			}
+		}
+		if len(prompt.Defaults) > 0 {
+			fmt.Println(i18n.T("cli.label.defaults"))
+			for _, name := range slices.Sorted(maps.Keys(prompt.Defaults)) {
+				fmt.Printf("  %s: %s\n", name, prompt.Defaults[name])
+			}
+		}
+		if len(prompt.Presets) > 0 {
+			fmt.Println(i18n.T("cli.label.presets"))
+			for _, preset := range prompt.PresetNames() {
+				values := prompt.Presets[preset]
+				var pairs []string
+				for _, name := range slices.Sorted(maps.Keys(values)) {
+					pairs = append(pairs, name+"="+values[name])
+				}
+				fmt.Printf("  %s: %s\n", preset, strings.Join(pairs, ", "))
+			}
+		}
+		if len(prompt.Attachments) > 0 {
+			fmt.Println(i18n.T("cli.label.attachments", strings.Join(prompt.Attachments, ", ")))
+		}
+		if len(prompt.Metadata) > 0 {
+			fmt.Println(i18n.T("cli.label.metadata"))
+			for _, key := range prompt.MetadataKeys() {
+				fmt.Printf("  %s: %v\n", key, prompt.Metadata[key])
+			}
+		}
+		if prompt.Encrypted {
+			fmt.Println(i18n.T("cli.label.encrypted"))
+		}
+		if prompt.Draft {
+			fmt.Println(i18n.T("cli.label.draft"))
+		}
+		if prompt.Rating > 0 {
+			fmt.Println(i18n.T("cli.label.rating", models.Stars(prompt.Rating), prompt.Rating, models.MaxRating))
+		}
+		fmt.Println(i18n.T("cli.label.created", prompt.CreatedAt.Format("2006-01-02 15:04")))
+		fmt.Println(i18n.T("cli.label.updated", prompt.UpdatedAt.Format("2006-01-02 15:04")))
+		if prompt.Notes != "" {
+			fmt.Printf("\n%s\n%s\n", i18n.T("cli.label.notes"), strings.TrimRight(prompt.Notes, "\n"))
+		}
+		fmt.Printf("\n%s\n%s\n", i18n.T("cli.label.content"), prompt.Content)
+	}
+	return nil
+}
+
+// Additional command handlers would go here...
+// This is a simplified implementation focusing on core functionality
+
+func (c *CLI) handleTemplates(args []string) error {
+	if len(args) == 0 {
+		// List templates
+		templates, err := c.service.ListTemplates()
+		if err != nil {
+			return fmt.Errorf("failed to list templates: %w", err)
+		}
+
+		for _, t := range templates {
+			fmt.Printf("%s - %s\n", t.ID, t.Name)
+			if t.Description != "" {
+				fmt.Printf("  %s\n", t.Description)
+			}
+			fmt.Println()
+		}
+		return nil
+	}
+
+	subcommand := args[0]
+	switch subcommand {
+	case "show":
+		if len(args) < 2 {
+			return fmt.Errorf("templates show requires a template ID")
+		}
+		template, err := c.service.GetTemplate(args[1])
+		if err != nil {
+			return fmt.Errorf("failed to get template: %w", err)
+		}
+		
+		fmt.Println(i18n.T("cli.label.id", template.ID))
+		fmt.Println(i18n.T("cli.label.name", template.Name))
+		if template.Description != "" {
+			fmt.Println(i18n.T("cli.label.description", template.Description))
+		}
+		fmt.Println(i18n.T("cli.label.created", template.CreatedAt.Format("2006-01-02 15:04")))
+		fmt.Println(i18n.T("cli.label.updated", template.UpdatedAt.Format("2006-01-02 15:04")))
+		fmt.Printf("\n%s\n%s\n", i18n.T("cli.label.content"), template.Content)
+		
+		if len(template.Slots) > 0 {
+			fmt.Printf("\n%s\n", i18n.T("cli.label.slots"))
+			for _, slot := range template.Slots {
+				fmt.Printf("  %s", slot.Name)
+				if slot.Required {
+					fmt.Printf(" %s", i18n.T("cli.label.required"))
+				}
+				if slot.Default != "" {
+					fmt.Printf(" %s", i18n.T("cli.label.default", slot.Default))
+				}
+				if slot.Secret {
+					fmt.Printf(" %s", i18n.T("cli.label.secret"))
+				}
+				if slot.Description != "" {
+					fmt.Printf(" - %s", slot.Description)
+				}
+				fmt.Println()
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown templates subcommand: %s", subcommand)
+	}
+}
+
+// handleSnippets lists snippets or runs a snippet subcommand
+func (c *CLI) handleSnippets(args []string) error {
+	if len(args) == 0 || args[0] == "list" || strings.HasPrefix(args[0], "-") {
+		if len(args) > 0 && args[0] == "list" {
+			args = args[1:]
+		}
+		return c.listSnippets(args)
+	}
+
+	subcommand := args[0]
+	switch subcommand {
+	case "show":
+		if len(args) < 2 {
+			return fmt.Errorf("snippets show requires a snippet name")
+		}
+		return c.showSnippet(args[1])
+	case "create", "edit":
+		if len(args) < 2 {
+			return fmt.Errorf("snippets %s requires a snippet name", subcommand)
+		}
+		return c.saveSnippet(args[1], args[2:], subcommand == "create")
+	case "delete", "rm":
+		if len(args) < 2 {
+			return fmt.Errorf("snippets delete requires a snippet name")
+		}
+		if err := c.service.DeleteSnippet(args[1]); err != nil {
+			return err
+		}
+		fmt.Println(i18n.T("cli.snippets.deleted", args[1]))
+		return nil
+	default:
+		return fmt.Errorf("unknown snippets subcommand: %s", subcommand)
+	}
+}
+
+// listSnippets prints each snippet with its description
+func (c *CLI) listSnippets(args []string) error {
+	format := ""
+	for i := 0; i < len(args); i++ {
+		if (args[i] == "--format" || args[i] == "-f") && i+1 < len(args) {
+			format = args[i+1]
+			i++
+		}
+	}
+
+	snippets, err := c.service.ListSnippets()
+	if err != nil {
+		return err
+	}
+	if format == "json" {
+		type snippetJSON struct {
+			Name        string `json:"name"`
+			Description string `json:"description,omitempty"`
+			Content     string `json:"content"`
+		}
+		out := []snippetJSON{}
+		for _, snippet := range snippets {
+			out = append(out, snippetJSON{snippet.Name, snippet.Description, snippet.Content})
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(out)
+	}
+
+	if len(snippets) == 0 {
+		fmt.Println(i18n.T("cli.snippets.none"))
+		return nil
+	}
+	for _, snippet := range snippets {
+		if snippet.Description != "" {
+			fmt.Printf("%s - %s\n", snippet.Name, snippet.Description)
+		} else {
+			fmt.Println(snippet.Name)
+		}
+	}
+	return nil
+}
+
+// showSnippet prints a snippet and where it is used
+func (c *CLI) showSnippet(name string) error {
+	snippet, err := c.service.GetSnippet(name)
+	if err != nil {
+		return err
+	}
+	fmt.Println(i18n.T("cli.label.name", snippet.Name))
+	if snippet.Description != "" {
+		fmt.Println(i18n.T("cli.label.description", snippet.Description))
+	}
+	fmt.Println(i18n.T("cli.snippets.reference", snippet.Name))
+
+	prompts, templates, err := c.service.SnippetUsers(name)
+	if err != nil {
+		return err
+	}
+	if len(prompts)+len(templates) > 0 {
+		fmt.Println(i18n.T("cli.snippets.used_by"))
+		for _, prompt := range prompts {
+			fmt.Printf("  %s\n", i18n.T("cli.snippets.used_by_prompt", prompt.ID))
+		}
+		for _, template := range templates {
+			fmt.Printf("  %s\n", i18n.T("cli.snippets.used_by_template", template.ID))
+		}
+	}
+	fmt.Printf("\n%s\n%s\n", i18n.T("cli.label.content"), snippet.Content)
+	return nil
+}
+
+// saveSnippet creates a snippet, or updates the description or content of
+// an existing one
+func (c *CLI) saveSnippet(name string, args []string, create bool) error {
+	snippet := &models.Snippet{Name: name}
+	if existing, err := c.service.GetSnippet(name); err == nil {
+		if create {
+			return fmt.Errorf("snippet %s already exists; use snippets edit", name)
+		}
+		snippet = existing
+	} else if !create {
+		return err
+	}
+
+	var content *string
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--description":
+			if i+1 < len(args) {
+				snippet.Description = args[i+1]
+				i++
+			}
+		case "--content":
+			if i+1 < len(args) {
+				content = &args[i+1]
+				i++
+			}
+		case "--stdin":
+			data, err := readContent("-")
+			if err != nil {
+				return err
+			}
+			content = &data
+		case "--file":
+			if i+1 < len(args) {
+				data, err := readContent(args[i+1])
+				if err != nil {
+					return err
+				}
+				content = &data
+				i++
+			}
+		}
+	}
+	if content != nil {
+		snippet.Content = *content
+	}
+	if strings.TrimSpace(snippet.Content) == "" {
+		return fmt.Errorf("snippet content is required: use --content, --file or --stdin")
+	}
+
+	if err := c.service.SaveSnippet(snippet); err != nil {
+		return fmt.Errorf("failed to save snippet: %w", err)
+	}
+	message := "cli.snippets.updated"
+	if create {
+		message = "cli.snippets.created"
+	}
+	fmt.Println(i18n.T(message, snippet.Name, snippet.Name))
+	return nil
+}
+
+func (c *CLI) handleTags(args []string) error {
+	if len(args) > 0 {
+		switch args[0] {
+		case "rename":
+			return c.renameTags(args[1:])
+		case "merge":
+			return c.mergeTags(args[1:])
+		case "--tree":
+			counts, err := c.service.GetTagCounts()
+			if err != nil {
+				return fmt.Errorf("failed to get tags: %w", err)
+			}
+			for _, node := range models.BuildTagTree(counts) {
+				fmt.Printf("%s (%d)\n", node.Name, node.Total)
+				c.printTagTree(node.Children, "")
+			}
+			return nil
+		default:
+			return fmt.Errorf("unknown tags subcommand: %s (use rename or merge)", args[0])
+		}
+	}
+
+	tags, err := c.service.GetAllTags()
+	if err != nil {
+		return fmt.Errorf("failed to get tags: %w", err)
+	}
+
+	for _, tag := range tags {
+		fmt.Println(tag)
+	}
+	return nil
+}
+
+// printTagTree prints the levels beneath a tag with their prompt counts
+func (c *CLI) printTagTree(nodes []*models.TagNode, indent string) {
+	for i, node := range nodes {
+		branch, childIndent := "├── ", "│   "
+		if c.plain {
+			branch, childIndent = "  ", "  "
+		} else if i == len(nodes)-1 {
+			branch, childIndent = "└── ", "    "
+		}
+		fmt.Printf("%s%s%s (%d)\n", indent, branch, node.Name, node.Total)
+		c.printTagTree(node.Children, indent+childIndent)
+	}
+}
+
+// renameTags renames a tag across prompts and saved searches
+func (c *CLI) renameTags(args []string) error {
+	var names []string
+	var dryRun bool
+	for _, arg := range args {
+		switch arg {
+		case "--dry-run", "--preview":
+			dryRun = true
+		default:
+			names = append(names, arg)
+		}
+	}
+	if len(names) != 2 {
+		return fmt.Errorf("tags rename requires the old and new tag\n\nUsage: pocket-prompt tags rename <old> <new> [--dry-run]")
+	}
+	return c.applyTagRename(names[:1], names[1], dryRun)
+}
+
+// mergeTags merges several tags into one across prompts and saved searches
+func (c *CLI) mergeTags(args []string) error {
+	var from []string
+	var into string
+	var dryRun bool
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch arg {
+		case "--into":
+			if i+1 < len(args) {
+				into = args[i+1]
+				i++
+			}
+		case "--dry-run", "--preview":
+			dryRun = true
+		default:
+			from = append(from, arg)
+		}
+	}
+	if len(from) == 0 || into == "" {
+		return fmt.Errorf("tags merge requires tags and a target\n\nUsage: pocket-prompt tags merge <tag> [<tag>...] --into <tag> [--dry-run]")
+	}
+	return c.applyTagRename(from, into, dryRun)
+}
+
+// applyTagRename runs a rename or merge and reports what changed
+func (c *CLI) applyTagRename(from []string, to string, dryRun bool) error {
+	prompts, searches, err := c.service.RenameTags(from, to, dryRun)
+	for _, p := range prompts {
+		fmt.Printf("  %s\n", p.ID)
+	}
+	if err != nil {
+		return err
+	}
+
+	message := "cli.tags.updated"
+	if dryRun {
+		message = "cli.tags.would_update"
+	}
+	fmt.Print(i18n.T(message, len(prompts)))
+	if len(searches) > 0 {
+		fmt.Print(i18n.T("cli.tags.searches_updated", strings.Join(searches, ", ")))
+	}
+	fmt.Println()
+	return nil
+}
+
+// handleTag handles tag operations on many prompts at once
+func (c *CLI) handleTag(args []string) error {
+	usage := "\n\nUsage: pocket-prompt tag <add|remove> <tag[,tag...]> [<id>...] [--query <expr>] [--search <text>] [--dry-run]"
+	if len(args) < 2 {
+		return fmt.Errorf("tag requires a subcommand and a tag" + usage)
+	}
+
+	subcommand := args[0]
+	var tags []string
+	for _, tag := range strings.Split(args[1], ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	if len(tags) == 0 {
+		return fmt.Errorf("tag requires a tag" + usage)
+	}
+
+	var ids []string
+	var query, search string
+	var dryRun bool
+
+	// Parse flags
+	for i := 2; i < len(args); i++ {
+		arg := args[i]
+		switch arg {
+		case "--query", "-q":
+			if i+1 < len(args) {
+				query = args[i+1]
+				i++
+			}
+		case "--search", "-s":
+			if i+1 < len(args) {
+				search = args[i+1]
+				i++
+			}
+		case "--dry-run", "--preview":
+			dryRun = true
+		default:
+			if strings.HasPrefix(arg, "-") {
+				return fmt.Errorf("unknown tag option: %s"+usage, arg)
+			}
+			ids = append(ids, arg)
+		}
+	}
+
+	prompts, err := c.selectPrompts(ids, query, search, "")
+	if err != nil {
+		return err
+	}
+
+	var add, remove []string
+	switch subcommand {
+	case "add":
+		add = tags
+	case "remove", "rm":
+		remove = tags
+	default:
+		return fmt.Errorf("unknown tag subcommand: %s"+usage, subcommand)
+	}
+
+	changed, err := c.service.RetagPrompts(prompts, add, remove, dryRun)
+	for _, p := range changed {
+		fmt.Printf("  %s\n", p.ID)
+	}
+	if err != nil {
+		return err
+	}
+
+	if dryRun {
+		fmt.Println(i18n.T("cli.tag.would_update", len(changed), len(prompts)))
+		return nil
+	}
+	fmt.Println(i18n.T("cli.tag.updated", len(changed), len(prompts)))
+	return nil
+}
+
+// selectPrompts returns the prompts a bulk command applies to: the given
+// IDs, or all prompts, narrowed by a boolean tag expression, a text search
+// and a tag. At least one selector is required.
+func (c *CLI) selectPrompts(ids []string, query, search, tag string) ([]*models.Prompt, error) {
+	if len(ids) == 0 && strings.TrimSpace(query) == "" && strings.TrimSpace(search) == "" && strings.TrimSpace(tag) == "" {
+		return nil, fmt.Errorf("select prompts by ID, --query or --search")
+	}
+
+	var prompts []*models.Prompt
+	if len(ids) > 0 {
+		for _, id := range ids {
+			prompt, err := c.service.GetPrompt(id)
+			if err != nil {
+				return nil, err
+			}
+			prompts = append(prompts, prompt)
+		}
+	} else {
+		all, err := c.service.ListPrompts()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list prompts: %w", err)
+		}
+		prompts = all
+	}
+
+	if strings.TrimSpace(query) != "" {
+		expr, err := parseBooleanExpression(query)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --query: %w", err)
+		}
+		var matching []*models.Prompt
+		for _, p := range prompts {
+			if expr.Evaluate(p.Tags) {
+				matching = append(matching, p)
+			}
+		}
+		prompts = matching
+	}
+
+	if strings.TrimSpace(search) != "" {
+		results, err := c.service.SearchPrompts(search)
+		if err != nil {
+			return nil, fmt.Errorf("search failed: %w", err)
+		}
+		found := make(map[string]bool, len(results))
+		for _, p := range results {
+			found[p.ID] = true
+		}
+		var matching []*models.Prompt
+		for _, p := range prompts {
+			if found[p.ID] {
+				matching = append(matching, p)
+			}
+		}
+		prompts = matching
+	}
+
+	if strings.TrimSpace(tag) != "" {
+		var matching []*models.Prompt
+		for _, p := range prompts {
+			if p.HasTag(tag) {
+				matching = append(matching, p)
+			}
+		}
+		prompts = matching
+	}
+
+	return prompts, nil
+}
+
+func (c *CLI) handleArchive(args []string) error {
+	if len(args) == 0 {
+		// List archived prompts
 		prompts, err := c.service.ListArchivedPrompts()
 		if err != nil {
 			return fmt.Errorf("failed to list archived prompts: %w", err)
 		}
 		return c.formatOutput(prompts, "")
 	}
-	return fmt.Errorf("archive subcommands not implemented")
+	return fmt.Errorf("archive subcommands not implemented")
+}
+
+func (c *CLI) handleSavedSearches(args []string) error {
+	if len(args) == 0 {
+		// List saved searches
+		searches, err := c.service.ListSavedSearches()
+		if err != nil {
+			return fmt.Errorf("failed to list saved searches: %w", err)
+		}
+
+		for _, search := range searches {
+			fmt.Printf("%s: %s\n", search.Name, search.Expression.String())
+		}
+		return nil
+	}
+
+	subcommand := args[0]
+	switch subcommand {
+	case "run":
+		if len(args) < 2 {
+			return fmt.Errorf("search-saved run requires a search name")
+		}
+		
+		searchName := args[1]
+		var textQuery string
+		var format string
+		
+		// Parse flags
+		for i := 2; i < len(args); i++ {
+			arg := args[i]
+			switch arg {
+			case "--text", "-t":
+				if i+1 < len(args) {
+					textQuery = args[i+1]
+					i++
+				}
+			case "--format", "-f":
+				if i+1 < len(args) {
+					format = args[i+1]
+					i++
+				}
+			}
+		}
+		
+		prompts, err := c.service.ExecuteSavedSearchWithText(searchName, textQuery)
+		if err != nil {
+			return fmt.Errorf("failed to execute saved search: %w", err)
+		}
+		return c.formatOutput(prompts, format)
+	default:
+		return fmt.Errorf("unknown search-saved subcommand: %s", subcommand)
+	}
+}
+
+func (c *CLI) handleGit(args []string) error {
+	if len(args) == 0 {
+		// Show git status
+		status, err := c.service.GetGitSyncStatus()
+		if err != nil {
+			return fmt.Errorf("failed to get git status: %w", err)
+		}
+		fmt.Println(i18n.T("cli.git.status", status))
+		return nil
+	}
+
+	subcommand := args[0]
+	switch subcommand {
+	case "setup":
+		return c.setupGit(args[1:])
+	case "enable":
+		c.service.EnableGitSync()
+		fmt.Println(i18n.T("cli.git.enabled"))
+		return nil
+	case "disable":
+		c.service.DisableGitSync()
+		fmt.Println(i18n.T("cli.git.disabled"))
+		return nil
+	case "status":
+		status, err := c.service.GetGitSyncStatus()
+		if err != nil {
+			return fmt.Errorf("failed to get git status: %w", err)
+		}
+		fmt.Println(status)
+		return nil
+	case "sync":
+		if err := c.service.SyncChanges("Manual sync from CLI"); err != nil {
+			return fmt.Errorf("failed to sync: %w", err)
+		}
+		fmt.Println(i18n.T("cli.git.synced"))
+		return nil
+	case "pull":
+		if err := c.service.PullGitChanges(); err != nil {
+			return fmt.Errorf("failed to pull changes: %w", err)
+		}
+		fmt.Println(i18n.T("cli.git.pulled"))
+		return nil
+	case "branch":
+		return c.gitBranch(args[1:])
+	case "drafts":
+		drafts, err := c.service.ListDrafts()
+		if err != nil {
+			return err
+		}
+		if len(drafts) == 0 {
+			fmt.Println(i18n.T("cli.git.no_drafts"))
+			return nil
+		}
+		fmt.Println(i18n.T("cli.git.drafts", c.service.DraftBranch()))
+		for _, prompt := range drafts {
+			fmt.Printf("  %-30s v%-8s %s\n", prompt.ID, prompt.Version, prompt.Title())
+		}
+		return nil
+	case "publish":
+		published, err := c.service.PublishDrafts(args[1:])
+		if err != nil {
+			return fmt.Errorf("failed to publish drafts: %w", err)
+		}
+		if len(published) == 0 {
+			fmt.Println(i18n.T("cli.git.nothing_to_publish"))
+			return nil
+		}
+		for _, prompt := range published {
+			fmt.Println(i18n.T("cli.git.published", prompt.ID))
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown git subcommand: %s", subcommand)
+	}
+}
+
+// gitBranch lists, creates, switches and deletes branches of the library
+func (c *CLI) gitBranch(args []string) error {
+	if len(args) == 0 || args[0] == "list" {
+		branches, err := c.service.ListGitBranches()
+		if err != nil {
+			return err
+		}
+		for _, branch := range branches {
+			marker := " "
+			if branch.Current {
+				marker = "*"
+			}
+			note := ""
+			if branch.Name == c.service.DraftBranch() {
+				note = " " + i18n.T("cli.git.drafts_note")
+			}
+			fmt.Printf("%s %s%s\n", marker, branch.Name, note)
+		}
+		return nil
+	}
+
+	usage := "Usage: pocket-prompt git branch [list | create <name> | switch <name> | delete <name> [--force]]"
+	if len(args) < 2 {
+		return fmt.Errorf("git branch %s requires a branch name\n\n%s", args[0], usage)
+	}
+	name := args[1]
+	switch args[0] {
+	case "create":
+		if err := c.service.CreateGitBranch(name); err != nil {
+			return err
+		}
+		fmt.Println(i18n.T("cli.git.branch_created", name))
+	case "switch":
+		if err := c.service.SwitchGitBranch(name); err != nil {
+			return err
+		}
+		fmt.Println(i18n.T("cli.git.branch_switched", name))
+	case "delete":
+		force := len(args) > 2 && (args[2] == "--force" || args[2] == "-f")
+		if err := c.service.DeleteGitBranch(name, force); err != nil {
+			return err
+		}
+		fmt.Println(i18n.T("cli.git.branch_deleted", name))
+	default:
+		return fmt.Errorf("unknown git branch subcommand: %s\n\n%s", args[0], usage)
+	}
+	return nil
+}
+
+// setupGit configures Git sync with an existing repository URL, or creates
+// a GitHub repository with --create
+func (c *CLI) setupGit(args []string) error {
+	var repoURL, create string
+	description := "My Pocket Prompt library"
+	private := true
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--create":
+			if i+1 < len(args) {
+				create = args[i+1]
+				i++
+			} else {
+				return fmt.Errorf("--create requires a repository name")
+			}
+		case "--private":
+			private = true
+		case "--public":
+			private = false
+		case "--description":
+			if i+1 < len(args) {
+				description = args[i+1]
+				i++
+			}
+		default:
+			repoURL = args[i]
+		}
+	}
+
+	switch {
+	case create != "" && repoURL != "":
+		return fmt.Errorf("git setup takes either a repository URL or --create <name>, not both")
+	case create != "":
+		if _, err := c.service.SetupGitHubRepository(create, description, private); err != nil {
+			return fmt.Errorf("failed to setup git repository: %w", err)
+		}
+	case repoURL != "":
+		if err := c.service.SetupGitRepository(repoURL); err != nil {
+			return fmt.Errorf("failed to setup git repository: %w", err)
+		}
+	default:
+		return fmt.Errorf("git setup requires a repository URL or --create <name>\n\nUsage: pocket-prompt git setup <repository-url>\n       pocket-prompt git setup --create <name> [--private|--public]\n\nExamples:\n  pocket-prompt git setup https://github.com/username/my-prompts.git\n  pocket-prompt git setup git@github.com:username/my-prompts.git\n  pocket-prompt git setup --create my-prompts --private")
+	}
+
+	fmt.Println(i18n.T("cli.git.configured"))
+	return nil
+}
+
+// handleBackup writes a timestamped backup of the library or lists backups
+func (c *CLI) handleBackup(args []string) error {
+	var keep int
+	var list bool
+
+	// Parse flags
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch arg {
+		case "--keep", "-k":
+			if i+1 < len(args) {
+				n, err := strconv.Atoi(args[i+1])
+				if err != nil || n < 1 {
+					return fmt.Errorf("--keep must be a positive number, got %q", args[i+1])
+				}
+				keep = n
+				i++
+			}
+		case "--list", "-l":
+			list = true
+		default:
+			return fmt.Errorf("unknown backup option: %s\n\nUsage: pocket-prompt backup [--keep <n>] [--list]", arg)
+		}
+	}
+
+	if list {
+		backups, err := c.service.ListBackups()
+		if err != nil {
+			return err
+		}
+		if len(backups) == 0 {
+			fmt.Println(i18n.T("cli.backup.none", c.service.BackupDir()))
+			return nil
+		}
+		fmt.Println(i18n.T("cli.backup.list", c.service.BackupDir()))
+		for _, backup := range backups {
+			fmt.Printf("  %-40s %s  %s\n", backup.Name, backup.CreatedAt.Format("2006-01-02 15:04:05"), formatSize(backup.Size))
+		}
+		return nil
+	}
+
+	backup, err := c.service.CreateBackup(keep)
+	if err != nil {
+		return err
+	}
+	fmt.Println(i18n.T("cli.backup.written", backup.Path, formatSize(backup.Size)))
+	return nil
 }
 
-func (c *CLI) handleSavedSearches(args []string) error {
+// handleRestore lists backups or restores the library from one, showing
+// what would change before applying it
+func (c *CLI) handleRestore(args []string) error {
 	if len(args) == 0 {
-		// List saved searches
-		searches, err := c.service.ListSavedSearches()
+		backups, err := c.service.ListBackups()
 		if err != nil {
-			return fmt.Errorf("failed to list saved searches: %w", err)
+			return err
+		}
+		if len(backups) == 0 {
+			fmt.Println(i18n.T("cli.backup.none", c.service.BackupDir()))
+			return nil
+		}
+		fmt.Println(i18n.T("cli.backup.list", c.service.BackupDir()))
+		for i, backup := range backups {
+			fmt.Printf("  %2d. %-40s %s  %s\n", i+1, backup.Name, backup.CreatedAt.Format("2006-01-02 15:04:05"), formatSize(backup.Size))
 		}
+		fmt.Printf("\n%s\n", i18n.T("cli.restore.hint"))
+		return nil
+	}
 
-		for _, search := range searches {
-			fmt.Printf("%s: %s\n", search.Name, search.Expression.String())
+	var ids []string
+	var preview, yes bool
+
+	// Parse flags
+	for i := 1; i < len(args); i++ {
+		arg := args[i]
+		switch arg {
+		case "--prompt", "-p":
+			if i+1 < len(args) {
+				for _, id := range strings.Split(args[i+1], ",") {
+					if id = strings.TrimSpace(id); id != "" {
+						ids = append(ids, id)
+					}
+				}
+				i++
+			}
+		case "--preview", "--dry-run":
+			preview = true
+		case "--yes", "-y":
+			yes = true
+		default:
+			return fmt.Errorf("unknown restore option: %s\n\nUsage: pocket-prompt restore <backup> [--prompt <id>] [--preview] [--yes]", arg)
+		}
+	}
+
+	backup, err := c.service.FindBackup(args[0])
+	if err != nil {
+		return err
+	}
+	plan, err := c.service.PlanRestore(backup.Path, ids)
+	if err != nil {
+		return err
+	}
+
+	heading := i18n.T("cli.restore.heading", backup.Name)
+	fmt.Println(heading)
+	fmt.Println(strings.Repeat("=", len(heading)))
+	fmt.Printf("%s\n\n", i18n.T("cli.label.created", plan.Manifest.CreatedAt.Local().Format("2006-01-02 15:04")))
+
+	counts := make(map[string]int)
+	for _, change := range plan.Changes {
+		counts[change.Action]++
+		switch change.Action {
+		case service.RestoreAdd:
+			fmt.Printf("  + %s %s (v%s)\n", change.Kind, change.ID, change.BackupVersion)
+		case service.RestoreUpdate:
+			fmt.Printf("  %s\n", i18n.T("cli.restore.update", change.Kind, change.ID, change.CurrentVersion, change.BackupVersion, strings.Join(change.Fields, ", ")))
+		}
+	}
+	if len(plan.NotInBackup) > 0 {
+		fmt.Printf("\n%s\n", i18n.T("cli.restore.not_in_backup", strings.Join(plan.NotInBackup, ", ")))
+	}
+	if len(plan.Missing) > 0 {
+		fmt.Printf("\n%s\n", i18n.T("cli.restore.missing", strings.Join(plan.Missing, ", ")))
+	}
+	if len(plan.Errors) > 0 {
+		fmt.Printf("\n%s\n", i18n.T("cli.errors", len(plan.Errors)))
+		for _, err := range plan.Errors {
+			fmt.Printf("  - %v\n", err)
 		}
+	}
+	fmt.Printf("\n%s\n", i18n.T("cli.restore.summary", counts[service.RestoreAdd], counts[service.RestoreUpdate], counts[service.RestoreUnchanged]))
+
+	if counts[service.RestoreAdd]+counts[service.RestoreUpdate] == 0 {
+		fmt.Println(i18n.T("cli.restore.nothing"))
+		return nil
+	}
+	if preview {
+		fmt.Printf("\n%s\n", i18n.T("cli.restore.preview_hint"))
 		return nil
 	}
+	if !yes {
+		fmt.Printf("\n%s", i18n.T("cli.restore.confirm"))
+		var response string
+		fmt.Scanln(&response)
+		if strings.ToLower(response) != "y" && strings.ToLower(response) != "yes" {
+			fmt.Println(i18n.T("cli.cancelled"))
+			return nil
+		}
+	}
 
-	subcommand := args[0]
-	switch subcommand {
-	case "run":
-		if len(args) < 2 {
-			return fmt.Errorf("search-saved run requires a search name")
+	result, err := c.service.ApplyRestore(plan)
+	if err != nil {
+		return err
+	}
+	fmt.Println(i18n.T("cli.restore.done", len(result.Prompts), len(result.Templates)))
+	if len(result.Errors) > 0 {
+		fmt.Printf("\n%s\n", i18n.T("cli.errors", len(result.Errors)))
+		for _, err := range result.Errors {
+			fmt.Printf("  - %v\n", err)
 		}
-		
-		searchName := args[1]
-		var textQuery string
-		var format string
-		
-		// Parse flags
-		for i := 2; i < len(args); i++ {
-			arg := args[i]
-			switch arg {
-			case "--text", "-t":
-				if i+1 < len(args) {
-					textQuery = args[i+1]
-					i++
-				}
-			case "--format", "-f":
-				if i+1 < len(args) {
-					format = args[i+1]
-					i++
+	}
+	return nil
+}
+
+// handleConflicts lists the conflict copies in the library or resolves
+// them
+func (c *CLI) handleConflicts(args []string) error {
+	conflicts, err := c.service.ListConflicts()
+	if err != nil {
+		return fmt.Errorf("failed to list conflicts: %w", err)
+	}
+
+	if len(args) == 0 || args[0] == "list" {
+		if len(conflicts) == 0 {
+			fmt.Println(i18n.T("cli.conflicts.none"))
+			return nil
+		}
+		fmt.Println(i18n.T("cli.conflicts.list", len(conflicts)))
+		for _, conflict := range conflicts {
+			fmt.Printf("  %s\n", i18n.T("cli.conflicts.entry", conflict.Path, conflict.Original, conflict.Source, conflict.ModTime.Format("2006-01-02 15:04")))
+		}
+		fmt.Printf("\n%s\n", i18n.T("cli.conflicts.hint"))
+		return nil
+	}
+	if args[0] != "resolve" {
+		return fmt.Errorf("unknown conflicts subcommand: %s\n\nUsage: pocket-prompt conflicts [list|resolve]", args[0])
+	}
+
+	var files []string
+	var all bool
+	resolution := ""
+	for i := 1; i < len(args); i++ {
+		arg := args[i]
+		switch arg {
+		case "--keep", "-k":
+			if i+1 < len(args) {
+				resolution = args[i+1]
+				i++
+			}
+		case "--merge", "-m":
+			resolution = service.MergeCopies
+		case "--all", "-a":
+			all = true
+		default:
+			if strings.HasPrefix(arg, "-") {
+				return fmt.Errorf("unknown conflicts option: %s", arg)
+			}
+			files = append(files, arg)
+		}
+	}
+	if resolution == "" {
+		return fmt.Errorf("choose a resolution with --keep newer|original|copy or --merge")
+	}
+	if !all && len(files) == 0 {
+		return fmt.Errorf("usage: pocket-prompt conflicts resolve <file>...|--all --keep newer|original|copy|--merge")
+	}
+
+	selected := conflicts
+	if !all {
+		selected = nil
+		for _, file := range files {
+			found := false
+			for _, conflict := range conflicts {
+				if conflict.Path == filepath.FromSlash(file) {
+					selected = append(selected, conflict)
+					found = true
+					break
 				}
 			}
+			if !found {
+				return fmt.Errorf("no conflict copy at %s", file)
+			}
 		}
-		
-		prompts, err := c.service.ExecuteSavedSearchWithText(searchName, textQuery)
+	}
+
+	for _, conflict := range selected {
+		applied, err := c.service.ResolveConflict(conflict, resolution)
 		if err != nil {
-			return fmt.Errorf("failed to execute saved search: %w", err)
+			return fmt.Errorf("failed to resolve %s: %w", conflict.Path, err)
 		}
-		return c.formatOutput(prompts, format)
+		switch applied {
+		case service.KeepOriginal:
+			fmt.Println(i18n.T("cli.conflicts.kept", conflict.Original, conflict.Path))
+		case service.KeepCopy:
+			fmt.Println(i18n.T("cli.conflicts.replaced", conflict.Original, conflict.Path))
+		case service.MergeCopies:
+			fmt.Println(i18n.T("cli.conflicts.merged", conflict.Path, conflict.Original))
+		}
+	}
+	return nil
+}
+
+// formatSize formats a byte count for display
+func formatSize(size int64) string {
+	switch {
+	case size >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(size)/(1<<20))
+	case size >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(size)/(1<<10))
 	default:
-		return fmt.Errorf("unknown search-saved subcommand: %s", subcommand)
+		return fmt.Sprintf("%d B", size)
 	}
 }
 
-func (c *CLI) handleGit(args []string) error {
-	if len(args) == 0 {
-		// Show git status
-		status, err := c.service.GetGitSyncStatus()
-		if err != nil {
-			return fmt.Errorf("failed to get git status: %w", err)
+func (c *CLI) printUsage() error {
+	fmt.Println(i18n.T("cli.usage"))
+
+	var names []string
+	for _, plugin := range c.service.ListPlugins() {
+		if !slices.Contains(builtinCommands, plugin.Name) {
+			names = append(names, plugin.Name)
 		}
-		fmt.Println("Git sync status:", status)
-		return nil
 	}
+	if len(names) > 0 {
+		fmt.Printf("\n%s\n  %s\n", i18n.T("cli.usage.plugins"), strings.Join(names, "\n  "))
+	}
+	return nil
+}
 
-	subcommand := args[0]
-	switch subcommand {
-	case "setup":
-		if len(args) < 2 {
-			return fmt.Errorf("git setup requires a repository URL\n\nUsage: pocket-prompt git setup <repository-url>\n\nExamples:\n  pocket-prompt git setup https://github.com/username/my-prompts.git\n  pocket-prompt git setup git@github.com:username/my-prompts.git")
+// lint checks the library and fails when it finds errors
+func (c *CLI) lint(args []string) error {
+	format := "text"
+	var strict bool
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--format", "-f":
+			if i+1 < len(args) {
+				format = args[i+1]
+				i++
+			}
+		case "--strict":
+			strict = true
+		default:
+			return fmt.Errorf("unknown lint option: %s", args[i])
 		}
-		repoURL := args[1]
-		if err := c.service.SetupGitRepository(repoURL); err != nil {
-			return fmt.Errorf("failed to setup git repository: %w", err)
+	}
+	if format != "text" && format != "json" {
+		return fmt.Errorf("unsupported lint format: %s (use text or json)", format)
+	}
+
+	issues, err := c.service.Lint()
+	if err != nil {
+		return fmt.Errorf("failed to lint library: %w", err)
+	}
+	var errorCount, warningCount int
+	for _, issue := range issues {
+		if issue.Severity == service.LintError {
+			errorCount++
+		} else {
+			warningCount++
 		}
-		fmt.Println("Git repository successfully configured!")
-		return nil
-	case "enable":
-		c.service.EnableGitSync()
-		fmt.Println("Git sync enabled")
+	}
+
+	if format == "json" {
+		if issues == nil {
+			issues = []service.LintIssue{}
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(issues); err != nil {
+			return err
+		}
+	} else {
+		for _, issue := range issues {
+			fmt.Println(issue)
+		}
+		if len(issues) == 0 {
+			fmt.Println(i18n.T("cli.lint.clean"))
+		} else {
+			fmt.Printf("\n%s\n", i18n.T("cli.lint.summary", errorCount, warningCount))
+		}
+	}
+
+	if errorCount > 0 || (strict && warningCount > 0) {
+		return fmt.Errorf("lint found %d errors and %d warnings", errorCount, warningCount)
+	}
+	return nil
+}
+
+// attachFile copies a file into the library's attachments and adds it to a
+// prompt
+func (c *CLI) attachFile(args []string) error {
+	var positional []string
+	var name string
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--name", "-n":
+			if i+1 < len(args) {
+				name = args[i+1]
+				i++
+			}
+		default:
+			positional = append(positional, args[i])
+		}
+	}
+	if len(positional) != 2 {
+		return fmt.Errorf("attach requires a prompt ID and a file")
+	}
+	id, file := positional[0], positional[1]
+	if name == "" {
+		name = filepath.Base(file)
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("failed to read attachment: %w", err)
+	}
+	prompt, err := c.service.AddAttachment(id, name, data)
+	if err != nil {
+		return fmt.Errorf("failed to attach %s: %w", file, err)
+	}
+	fmt.Println(i18n.T("cli.attach.done", name, prompt.ID, name))
+	return nil
+}
+
+// ratePrompt sets or clears a prompt's star rating
+func (c *CLI) ratePrompt(args []string) error {
+	if len(args) != 2 {
+		return fmt.Errorf("rate requires a prompt ID and a rating from 1 to %d", models.MaxRating)
+	}
+	rating, err := models.ParseRating(args[1])
+	if err != nil {
+		return err
+	}
+	prompt, err := c.service.SetRating(args[0], rating)
+	if err != nil {
+		return fmt.Errorf("failed to rate prompt: %w", err)
+	}
+	if rating == 0 {
+		fmt.Println(i18n.T("cli.rate.cleared", prompt.ID))
+	} else {
+		fmt.Println(i18n.T("cli.rate.rated", prompt.ID, models.Stars(rating)))
+	}
+	return nil
+}
+
+// dedupe reports groups of duplicate prompts, and merges or deletes them
+func (c *CLI) dedupe(args []string) error {
+	threshold := service.DefaultDuplicateThreshold
+	format := "text"
+	var merge, remove, dryRun, force bool
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--threshold", "-t":
+			if i+1 < len(args) {
+				value, err := strconv.ParseFloat(args[i+1], 64)
+				if err != nil {
+					return fmt.Errorf("invalid threshold: %s", args[i+1])
+				}
+				threshold = value
+				i++
+			}
+		case "--exact":
+			threshold = 1
+		case "--merge":
+			merge = true
+		case "--delete":
+			remove = true
+		case "--dry-run", "--preview":
+			dryRun = true
+		case "--force":
+			force = true
+		case "--format", "-f":
+			if i+1 < len(args) {
+				format = args[i+1]
+				i++
+			}
+		default:
+			return fmt.Errorf("unknown dedupe option: %s", args[i])
+		}
+	}
+	if merge && remove {
+		return fmt.Errorf("choose one of --merge and --delete")
+	}
+	if format != "text" && format != "json" {
+		return fmt.Errorf("unsupported dedupe format: %s (use text or json)", format)
+	}
+
+	groups, err := c.service.FindDuplicates(threshold)
+	if err != nil {
+		return fmt.Errorf("failed to find duplicates: %w", err)
+	}
+
+	if format == "json" {
+		type entry struct {
+			Keep       string   `json:"keep"`
+			Duplicates []string `json:"duplicates"`
+			Similarity float64  `json:"similarity"`
+		}
+		entries := []entry{}
+		for _, group := range groups {
+			e := entry{Keep: group.Keep().ID, Similarity: group.Similarity}
+			for _, duplicate := range group.Duplicates() {
+				e.Duplicates = append(e.Duplicates, duplicate.ID)
+			}
+			entries = append(entries, e)
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(entries); err != nil {
+			return err
+		}
+	} else {
+		if len(groups) == 0 {
+			fmt.Println(i18n.T("cli.dedupe.none"))
+			return nil
+		}
+		for _, group := range groups {
+			label := i18n.T("cli.dedupe.identical")
+			if group.Similarity < 1 {
+				label = i18n.T("cli.dedupe.similar", group.Similarity*100)
+			}
+			fmt.Printf("%s (%s)\n", group.Keep().ID, label)
+			for _, duplicate := range group.Duplicates() {
+				fmt.Printf("  %s - %s\n", duplicate.ID, duplicate.Title())
+			}
+		}
+	}
+
+	if (!merge && !remove) || len(groups) == 0 {
 		return nil
-	case "disable":
-		c.service.DisableGitSync()
-		fmt.Println("Git sync disabled")
+	}
+	count := 0
+	for _, group := range groups {
+		count += len(group.Duplicates())
+	}
+	preview, confirm, done := "cli.dedupe.would_delete", "cli.dedupe.confirm_delete", "cli.dedupe.deleted"
+	if merge {
+		preview, confirm, done = "cli.dedupe.would_merge", "cli.dedupe.confirm_merge", "cli.dedupe.merged"
+	}
+	if dryRun {
+		fmt.Println(i18n.T(preview, count, len(groups)))
 		return nil
-	case "status":
-		status, err := c.service.GetGitSyncStatus()
-		if err != nil {
-			return fmt.Errorf("failed to get git status: %w", err)
+	}
+	if !force {
+		fmt.Print(i18n.T(confirm, count))
+		var response string
+		fmt.Scanln(&response)
+		if strings.ToLower(response) != "y" && strings.ToLower(response) != "yes" {
+			fmt.Println(i18n.T("cli.cancelled"))
+			return nil
 		}
-		fmt.Println(status)
-		return nil
-	case "sync":
-		if err := c.service.SyncChanges("Manual sync from CLI"); err != nil {
-			return fmt.Errorf("failed to sync: %w", err)
+	}
+
+	for _, group := range groups {
+		if merge {
+			_, err = c.service.MergeDuplicates(group)
+		} else {
+			_, err = c.service.DeletePrompts(group.Duplicates())
 		}
-		fmt.Println("Successfully synced with remote repository")
-		return nil
-	case "pull":
-		if err := c.service.PullGitChanges(); err != nil {
-			return fmt.Errorf("failed to pull changes: %w", err)
+		if err != nil {
+			return fmt.Errorf("failed to dedupe %s: %w", group.Keep().ID, err)
 		}
-		fmt.Println("Successfully pulled changes from remote repository")
-		return nil
-	default:
-		return fmt.Errorf("unknown git subcommand: %s", subcommand)
 	}
+	fmt.Println(i18n.T(done, count))
+	return nil
 }
 
-func (c *CLI) printUsage() error {
-	fmt.Println(`pocket-prompt - Headless CLI mode
-
-Usage: pocket-prompt <command> [options]
-
-Commands:
-  list, ls              List all prompts
-  search <query>        Search prompts  
-  get, show <id>        Show a specific prompt
-  create, new <id>      Create a new prompt
-  edit <id>             Edit an existing prompt
-  delete, rm <id>       Delete a prompt
-  copy <id>             Copy prompt to clipboard
-  render <id>           Render prompt with variables
-  templates             List templates
-  template              Template management (create, edit, delete, show)
-  tags                  List all tags
-  archive               Manage archived prompts
-  search-saved          Manage saved searches
-  boolean-search        Boolean search operations (create, edit, delete, list, run)
-  export                Export prompts and templates
-  import                Import prompts and templates
-  git                   Git synchronization
-  help                  Show help
-
-Use 'pocket-prompt help <command>' for detailed help on a specific command.`)
+// handlePlugins lists the plugin executables found and the commands they
+// add
+func (c *CLI) handlePlugins(args []string) error {
+	if len(args) > 0 && args[0] != "list" {
+		return fmt.Errorf("unknown plugins subcommand: %s\n\nUsage: pocket-prompt plugins [list]", args[0])
+	}
+
+	plugins := c.service.ListPlugins()
+	if len(plugins) == 0 {
+		fmt.Println(i18n.T("cli.plugins.none", service.PluginPrefix, c.service.PluginDir()))
+		return nil
+	}
+	fmt.Println(i18n.T("cli.plugins.list", len(plugins)))
+	for _, plugin := range plugins {
+		note := ""
+		if slices.Contains(builtinCommands, plugin.Name) {
+			note = " " + i18n.T("cli.plugins.hidden")
+		}
+		fmt.Printf("  %-20s %s%s\n", plugin.Name, plugin.Path, note)
+	}
 	return nil
 }
 
 // handleTemplate handles individual template operations  
 func (c *CLI) handleTemplate(args []string) error {
 	if len(args) == 0 {
-		return fmt.Errorf("template command requires a subcommand (create, edit, delete, show)")
+		return fmt.Errorf("template command requires a subcommand (create, edit, delete, show, history, rollback)")
 	}
 
 	subcommand := args[0]
@@ -951,11 +3174,60 @@ func (c *CLI) handleTemplate(args []string) error {
 			return fmt.Errorf("failed to get template: %w", err)
 		}
 		return c.formatSingleTemplate(template, "")
+	case "history":
+		return c.templateHistory(args[1:])
+	case "rollback":
+		if len(args) < 3 {
+			return fmt.Errorf("template rollback requires a template ID and a version")
+		}
+		template, err := c.service.RollbackTemplate(args[1], strings.TrimPrefix(args[2], "v"))
+		if err != nil {
+			return fmt.Errorf("failed to roll back template: %w", err)
+		}
+		fmt.Println(i18n.T("cli.template.rolled_back", template.ID, strings.TrimPrefix(args[2], "v"), template.Version))
+		return nil
 	default:
 		return fmt.Errorf("unknown template subcommand: %s", subcommand)
 	}
 }
 
+// templateHistory lists the archived versions of a template
+func (c *CLI) templateHistory(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("template history requires a template ID")
+	}
+	format := ""
+	for i := 1; i < len(args); i++ {
+		if (args[i] == "--format" || args[i] == "-f") && i+1 < len(args) {
+			format = args[i+1]
+			i++
+		}
+	}
+
+	current, err := c.service.GetTemplate(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to get template: %w", err)
+	}
+	history, err := c.service.TemplateHistory(args[0])
+	if err != nil {
+		return err
+	}
+	if format == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(append([]*models.Template{current}, history...))
+	}
+
+	fmt.Println(i18n.T("cli.template.history_current", current.Version, current.UpdatedAt.Format("2006-01-02 15:04")))
+	for _, template := range history {
+		fmt.Printf("v%-10s %s\n", template.Version, template.UpdatedAt.Format("2006-01-02 15:04"))
+	}
+	if len(history) > 0 {
+		fmt.Printf("\n%s\n", i18n.T("cli.template.history_hint", current.ID))
+	}
+	return nil
+}
+
 // createTemplate creates a new template
 func (c *CLI) createTemplate(args []string) error {
 	if len(args) == 0 {
@@ -994,17 +3266,20 @@ func (c *CLI) createTemplate(args []string) error {
 				i++
 			}
 		case "--stdin":
-			// Read content from stdin
-			var buf strings.Builder
-			for {
-				var line string
-				n, err := fmt.Scanln(&line)
-				if n == 0 || err != nil {
-					break
+			data, err := readContent("-")
+			if err != nil {
+				return err
+			}
+			content = data
+		case "--file":
+			if i+1 < len(args) {
+				data, err := readContent(args[i+1])
+				if err != nil {
+					return err
 				}
-				buf.WriteString(line + "\n")
+				content = data
+				i++
 			}
-			content = buf.String()
 		}
 	}
 
@@ -1030,7 +3305,7 @@ func (c *CLI) createTemplate(args []string) error {
 		return fmt.Errorf("failed to create template: %w", err)
 	}
 
-	fmt.Printf("Created template: %s\n", id)
+	fmt.Println(i18n.T("cli.template.created", id))
 	return nil
 }
 
@@ -1086,7 +3361,7 @@ func (c *CLI) editTemplate(args []string) error {
 		return fmt.Errorf("failed to update template: %w", err)
 	}
 
-	fmt.Printf("Updated template: %s\n", id)
+	fmt.Println(i18n.T("cli.template.updated", id))
 	return nil
 }
 
@@ -1107,11 +3382,11 @@ func (c *CLI) deleteTemplate(args []string) error {
 	}
 
 	if !force {
-		fmt.Printf("Are you sure you want to delete template '%s'? (y/N): ", id)
+		fmt.Print(i18n.T("cli.template.confirm_delete", id))
 		var response string
 		fmt.Scanln(&response)
 		if strings.ToLower(response) != "y" && strings.ToLower(response) != "yes" {
-			fmt.Println("Cancelled")
+			fmt.Println(i18n.T("cli.cancelled"))
 			return nil
 		}
 	}
@@ -1120,7 +3395,7 @@ func (c *CLI) deleteTemplate(args []string) error {
 		return fmt.Errorf("failed to delete template: %w", err)
 	}
 
-	fmt.Printf("Deleted template: %s\n", id)
+	fmt.Println(i18n.T("cli.template.deleted", id))
 	return nil
 }
 
@@ -1130,24 +3405,27 @@ func (c *CLI) formatSingleTemplate(template *models.Template, format string) err
 	case "json":
 		return json.NewEncoder(os.Stdout).Encode(template)
 	default:
-		fmt.Printf("ID: %s\n", template.ID)
-		fmt.Printf("Name: %s\n", template.Name)
-		fmt.Printf("Version: %s\n", template.Version)
+		fmt.Println(i18n.T("cli.label.id", template.ID))
+		fmt.Println(i18n.T("cli.label.name", template.Name))
+		fmt.Println(i18n.T("cli.label.version", template.Version))
 		if template.Description != "" {
-			fmt.Printf("Description: %s\n", template.Description)
+			fmt.Println(i18n.T("cli.label.description", template.Description))
 		}
-		fmt.Printf("Created: %s\n", template.CreatedAt.Format("2006-01-02 15:04"))
-		fmt.Printf("Updated: %s\n", template.UpdatedAt.Format("2006-01-02 15:04"))
+		fmt.Println(i18n.T("cli.label.created", template.CreatedAt.Format("2006-01-02 15:04")))
+		fmt.Println(i18n.T("cli.label.updated", template.UpdatedAt.Format("2006-01-02 15:04")))
 		
 		if len(template.Slots) > 0 {
-			fmt.Println("\nSlots:")
+			fmt.Printf("\n%s\n", i18n.T("cli.label.slots"))
 			for _, slot := range template.Slots {
 				fmt.Printf("  %s", slot.Name)
 				if slot.Required {
-					fmt.Print(" [required]")
+					fmt.Printf(" %s", i18n.T("cli.label.required"))
 				}
 				if slot.Default != "" {
-					fmt.Printf(" [default: %s]", slot.Default)
+					fmt.Printf(" %s", i18n.T("cli.label.default", slot.Default))
+				}
+				if slot.Secret {
+					fmt.Printf(" %s", i18n.T("cli.label.secret"))
 				}
 				if slot.Description != "" {
 					fmt.Printf(" - %s", slot.Description)
@@ -1156,7 +3434,7 @@ func (c *CLI) formatSingleTemplate(template *models.Template, format string) err
 			}
 		}
 		
-		fmt.Printf("\nContent:\n%s\n", template.Content)
+		fmt.Printf("\n%s\n%s\n", i18n.T("cli.label.content"), template.Content)
 	}
 	return nil
 }
@@ -1164,7 +3442,7 @@ func (c *CLI) formatSingleTemplate(template *models.Template, format string) err
 // handleBooleanSearch handles boolean search operations
 func (c *CLI) handleBooleanSearch(args []string) error {
 	if len(args) == 0 {
-		return fmt.Errorf("boolean-search requires a subcommand (create, edit, delete, list, run)")
+		return fmt.Errorf("boolean-search requires a subcommand (create, edit, delete, list, run, pin, unpin)")
 	}
 
 	subcommand := args[0]
@@ -1179,6 +3457,8 @@ func (c *CLI) handleBooleanSearch(args []string) error {
 		return c.listBooleanSearches()
 	case "run":
 		return c.runBooleanSearch(args[1:])
+	case "pin", "unpin":
+		return c.pinBooleanSearch(args[1:], subcommand == "pin")
 	default:
 		return fmt.Errorf("unknown boolean-search subcommand: %s", subcommand)
 	}
@@ -1257,21 +3537,29 @@ func (c *CLI) editBooleanSearch(args []string) error {
 		return fmt.Errorf("invalid boolean expression: %w", err)
 	}
 
+	existing, err := c.service.GetSavedSearch(name)
+	if err != nil {
+		return err
+	}
+
 	// Delete old search
 	if err := c.service.DeleteSavedSearch(name); err != nil {
 		return fmt.Errorf("failed to delete old search: %w", err)
 	}
 
+	// Keep the text filter and pin of the old search
 	savedSearch := models.SavedSearch{
 		Name:       name,
 		Expression: expr,
+		TextQuery:  existing.TextQuery,
+		Pinned:     existing.Pinned,
 	}
 
 	if err := c.service.SaveBooleanSearch(savedSearch); err != nil {
 		return fmt.Errorf("failed to save updated boolean search: %w", err)
 	}
 
-	fmt.Printf("Updated boolean search: %s\n", name)
+	fmt.Println(i18n.T("cli.boolean.updated", name))
 	return nil
 }
 
@@ -1292,11 +3580,11 @@ func (c *CLI) deleteBooleanSearch(args []string) error {
 	}
 
 	if !force {
-		fmt.Printf("Are you sure you want to delete boolean search '%s'? (y/N): ", name)
+		fmt.Print(i18n.T("cli.boolean.confirm_delete", name))
 		var response string
 		fmt.Scanln(&response)
 		if strings.ToLower(response) != "y" && strings.ToLower(response) != "yes" {
-			fmt.Println("Cancelled")
+			fmt.Println(i18n.T("cli.cancelled"))
 			return nil
 		}
 	}
@@ -1305,7 +3593,7 @@ func (c *CLI) deleteBooleanSearch(args []string) error {
 		return fmt.Errorf("failed to delete boolean search: %w", err)
 	}
 
-	fmt.Printf("Deleted boolean search: %s\n", name)
+	fmt.Println(i18n.T("cli.boolean.deleted", name))
 	return nil
 }
 
@@ -1317,7 +3605,31 @@ func (c *CLI) listBooleanSearches() error {
 	}
 
 	for _, search := range searches {
-		fmt.Printf("%s: %s\n", search.Name, search.Expression.String())
+		pin := ""
+		if search.Pinned {
+			pin = " " + i18n.T("cli.boolean.pinned_note")
+		}
+		fmt.Printf("%s%s: %s\n", search.Name, pin, search.Expression.String())
+	}
+	return nil
+}
+
+// pinBooleanSearch pins a saved search to the top of the TUI library, or
+// unpins it
+func (c *CLI) pinBooleanSearch(args []string, pinned bool) error {
+	if len(args) == 0 {
+		return fmt.Errorf("pin and unpin require a saved search name")
+	}
+
+	name := args[0]
+	if err := c.service.SetSavedSearchPinned(name, pinned); err != nil {
+		return fmt.Errorf("failed to update boolean search: %w", err)
+	}
+
+	if pinned {
+		fmt.Println(i18n.T("cli.boolean.pinned", name))
+	} else {
+		fmt.Println(i18n.T("cli.boolean.unpinned", name))
 	}
 	return nil
 }
@@ -1331,6 +3643,7 @@ func (c *CLI) runBooleanSearch(args []string) error {
 	var format string
 	var expression string
 	var useSavedSearch bool
+	scope := service.ActiveOnly
 
 	// Check if first arg is --saved to use a saved search
 	if args[0] == "--saved" {
@@ -1353,6 +3666,10 @@ func (c *CLI) runBooleanSearch(args []string) error {
 			if i+1 < len(parts) {
 				format = parts[i+1]
 			}
+		case "--include-archived":
+			scope = service.IncludeArchived
+		case "--archived-only":
+			scope = service.ArchivedOnly
 		default:
 			if i == 0 || (parts[i-1] != "--format" && parts[i-1] != "-f") {
 				cleanedParts = append(cleanedParts, part)
@@ -1360,6 +3677,9 @@ func (c *CLI) runBooleanSearch(args []string) error {
 		}
 	}
 	expression = strings.Join(cleanedParts, " ")
+	if useSavedSearch && scope != service.ActiveOnly {
+		return fmt.Errorf("--include-archived and --archived-only cannot be used with --saved")
+	}
 
 	var prompts []*models.Prompt
 	var err error
@@ -1372,7 +3692,7 @@ func (c *CLI) runBooleanSearch(args []string) error {
 		if parseErr != nil {
 			return fmt.Errorf("invalid boolean expression: %w", parseErr)
 		}
-		prompts, err = c.service.SearchPromptsByBooleanExpression(expr)
+		prompts, err = c.service.SearchPromptsByBooleanExpressionInScope(expr, scope)
 	}
 
 	if err != nil {
@@ -1385,12 +3705,25 @@ func (c *CLI) runBooleanSearch(args []string) error {
 // handleExport handles export operations
 func (c *CLI) handleExport(args []string) error {
 	if len(args) == 0 {
-		return fmt.Errorf("export requires a subcommand (prompts, templates, all)")
+		return fmt.Errorf("export requires a subcommand (prompts, templates, all, bundle, html, jsonl, langchain)")
 	}
 
 	subcommand := args[0]
+	if subcommand == "langchain" {
+		return c.handleLangChainExport(args[1:])
+	}
+	if subcommand == "bundle" {
+		return c.handleBundleExport(args[1:])
+	}
+	if subcommand == "html" {
+		return c.handleHTMLExport(args[1:])
+	}
+	if subcommand == "jsonl" {
+		return c.handleJSONLExport(args[1:])
+	}
 	var format string
 	var outputFile string
+	var query string
 
 	// Parse flags
 	for i := 1; i < len(args); i++ {
@@ -1406,6 +3739,11 @@ func (c *CLI) handleExport(args []string) error {
 				outputFile = args[i+1]
 				i++
 			}
+		case "--query", "-q":
+			if i+1 < len(args) {
+				query = args[i+1]
+				i++
+			}
 		}
 	}
 
@@ -1413,36 +3751,381 @@ func (c *CLI) handleExport(args []string) error {
 		format = "json"
 	}
 
+	expr, err := parseExportQuery(query)
+	if err != nil {
+		return err
+	}
+
+	// With a query, templates are limited to those used by matching prompts
+	listTemplates := func(prompts []*models.Prompt) ([]*models.Template, error) {
+		if expr != nil {
+			return c.service.TemplatesUsedBy(prompts)
+		}
+		return c.service.ListTemplates()
+	}
+
 	switch subcommand {
 	case "prompts":
-		prompts, err := c.service.ListPrompts()
+		prompts, err := c.service.SearchPromptsByBooleanExpression(expr)
 		if err != nil {
 			return fmt.Errorf("failed to list prompts: %w", err)
 		}
+		prompts, err = c.service.WithContent(prompts)
+		if err != nil {
+			return err
+		}
 		return c.exportData(prompts, format, outputFile)
 	case "templates":
-		templates, err := c.service.ListTemplates()
+		prompts, err := c.service.SearchPromptsByBooleanExpression(expr)
+		if err != nil {
+			return fmt.Errorf("failed to list prompts: %w", err)
+		}
+		templates, err := listTemplates(prompts)
 		if err != nil {
 			return fmt.Errorf("failed to list templates: %w", err)
 		}
 		return c.exportData(templates, format, outputFile)
 	case "all":
-		prompts, err := c.service.ListPrompts()
+		prompts, err := c.service.SearchPromptsByBooleanExpression(expr)
+		if err != nil {
+			return fmt.Errorf("failed to list prompts: %w", err)
+		}
+		prompts, err = c.service.WithContent(prompts)
+		if err != nil {
+			return err
+		}
+		templates, err := listTemplates(prompts)
+		if err != nil {
+			return fmt.Errorf("failed to list templates: %w", err)
+		}
+		data := map[string]interface{}{
+			"prompts":   prompts,
+			"templates": templates,
+		}
+		return c.exportData(data, format, outputFile)
+	default:
+		return fmt.Errorf("unknown export subcommand: %s", subcommand)
+	}
+}
+
+// handleBundleExport writes the library to a zip bundle with a manifest
+func (c *CLI) handleBundleExport(args []string) error {
+	outputFile := fmt.Sprintf("pocket-prompt-%s.zip", time.Now().Format("2006-01-02"))
+	var query string
+
+	// Parse flags
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch arg {
+		case "--output", "-o":
+			if i+1 < len(args) {
+				outputFile = args[i+1]
+				i++
+			}
+		case "--query", "-q":
+			if i+1 < len(args) {
+				query = args[i+1]
+				i++
+			}
+		}
+	}
+
+	expr, err := parseExportQuery(query)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Create(outputFile)
+	if err != nil {
+		return fmt.Errorf("failed to create bundle: %w", err)
+	}
+
+	manifest, err := c.service.ExportBundle(file, expr)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(outputFile)
+		return err
+	}
+
+	fmt.Println(i18n.T("cli.export.bundle_done",
+		len(manifest.Prompts), len(manifest.Archived), len(manifest.Templates), len(manifest.Attachments), outputFile))
+	return nil
+}
+
+// handleHTMLExport generates a static HTML gallery of the library
+func (c *CLI) handleHTMLExport(args []string) error {
+	outputDir := "site"
+	options := gallery.Options{}
+	var query string
+
+	// Parse flags
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch arg {
+		case "--query", "-q":
+			if i+1 < len(args) {
+				query = args[i+1]
+				i++
+			}
+		case "--output", "-o":
+			if i+1 < len(args) {
+				outputDir = args[i+1]
+				i++
+			}
+		case "--title":
+			if i+1 < len(args) {
+				options.Title = args[i+1]
+				i++
+			}
+		}
+	}
+
+	expr, err := parseExportQuery(query)
+	if err != nil {
+		return err
+	}
+
+	prompts, err := c.service.SearchPromptsByBooleanExpression(expr)
+	if err != nil {
+		return fmt.Errorf("failed to list prompts: %w", err)
+	}
+	prompts, err = c.service.WithContent(prompts)
+	if err != nil {
+		return err
+	}
+
+	count, err := gallery.NewGenerator(outputDir, options).Generate(prompts)
+	if err != nil {
+		return fmt.Errorf("failed to generate gallery: %w", err)
+	}
+	if skipped := len(prompts) - count; skipped > 0 {
+		fmt.Fprintln(os.Stderr, i18n.T("cli.export.skipped_encrypted", skipped))
+	}
+
+	fmt.Println(i18n.T("cli.export.gallery", count, filepath.Join(outputDir, "index.html")))
+	return nil
+}
+
+// handleJSONLExport writes one JSON object per prompt for fine-tuning and
+// evaluation tools
+func (c *CLI) handleJSONLExport(args []string) error {
+	options := export.JSONLOptions{Schema: export.SchemaChat}
+	var tags []string
+	var namespace, query, outputFile string
+
+	// Parse flags
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch arg {
+		case "--schema", "-s":
+			if i+1 < len(args) {
+				options.Schema = args[i+1]
+				i++
+			}
+		case "--fields":
+			if i+1 < len(args) {
+				fields, err := export.ParseJSONLFields(args[i+1])
+				if err != nil {
+					return err
+				}
+				options.Fields = fields
+				options.Schema = export.SchemaFields
+				i++
+			}
+		case "--system":
+			if i+1 < len(args) {
+				options.System = args[i+1]
+				i++
+			}
+		case "--tag", "-t":
+			if i+1 < len(args) {
+				tags = append(tags, args[i+1])
+				i++
+			}
+		case "--namespace", "-n":
+			if i+1 < len(args) {
+				namespace = strings.Trim(args[i+1], "/")
+				i++
+			}
+		case "--query", "-q":
+			if i+1 < len(args) {
+				query = args[i+1]
+				i++
+			}
+		case "--output", "-o":
+			if i+1 < len(args) {
+				outputFile = args[i+1]
+				i++
+			}
+		}
+	}
+
+	expr, err := parseExportQuery(query)
+	if err != nil {
+		return err
+	}
+
+	prompts, err := c.service.SearchPromptsByBooleanExpression(expr)
+	if err != nil {
+		return fmt.Errorf("failed to list prompts: %w", err)
+	}
+
+	// Every --tag must match
+	var filtered []*models.Prompt
+	for _, p := range prompts {
+		if namespace != "" {
+			if ns := p.Namespace(); ns != namespace && !strings.HasPrefix(ns, namespace+"/") {
+				continue
+			}
+		}
+		matches := true
+		for _, tag := range tags {
+			if !p.HasTag(tag) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			filtered = append(filtered, p)
+		}
+	}
+
+	prompts, err = c.service.WithContent(filtered)
+	if err != nil {
+		return err
+	}
+
+	out := os.Stdout
+	if outputFile != "" {
+		file, err := os.Create(outputFile)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer file.Close()
+		out = file
+	}
+
+	if err := export.WriteJSONL(out, prompts, options); err != nil {
+		return err
+	}
+	if outputFile != "" {
+		fmt.Fprintln(os.Stderr, i18n.T("cli.export.done", len(prompts), outputFile))
+	}
+	return nil
+}
+
+// handleLangChainExport writes prompts as LangChain prompt template files.
+// A single prompt goes to stdout unless --output is given; several prompts
+// are written to one file each below the --output directory.
+func (c *CLI) handleLangChainExport(args []string) error {
+	var ids []string
+	format := "yaml"
+	var output, query string
+
+	// Parse flags
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch arg {
+		case "--format", "-f":
+			if i+1 < len(args) {
+				format = args[i+1]
+				i++
+			}
+		case "--output", "-o":
+			if i+1 < len(args) {
+				output = args[i+1]
+				i++
+			}
+		case "--query", "-q":
+			if i+1 < len(args) {
+				query = args[i+1]
+				i++
+			}
+		default:
+			if !strings.HasPrefix(arg, "-") {
+				ids = append(ids, arg)
+			}
+		}
+	}
+
+	if format != "yaml" && format != "json" {
+		return fmt.Errorf("unsupported LangChain format: %s (use yaml or json)", format)
+	}
+
+	var prompts []*models.Prompt
+	if len(ids) == 0 {
+		expr, err := parseExportQuery(query)
+		if err != nil {
+			return err
+		}
+		matching, err := c.service.SearchPromptsByBooleanExpression(expr)
 		if err != nil {
 			return fmt.Errorf("failed to list prompts: %w", err)
 		}
-		templates, err := c.service.ListTemplates()
+		prompts = matching
+	} else {
+		for _, id := range ids {
+			prompt, err := c.service.GetPrompt(id)
+			if err != nil {
+				return fmt.Errorf("prompt not found: %s", id)
+			}
+			prompts = append(prompts, prompt)
+		}
+	}
+	prompts, err := c.service.WithContent(prompts)
+	if err != nil {
+		return err
+	}
+
+	if len(prompts) == 1 && len(ids) == 1 {
+		data, err := importer.MarshalLangChainPrompt(importer.ToLangChainPrompt(prompts[0]), format)
+		if err != nil {
+			return fmt.Errorf("failed to marshal prompt: %w", err)
+		}
+		if output == "" {
+			fmt.Print(string(data))
+			return nil
+		}
+		if info, err := os.Stat(output); err != nil || !info.IsDir() {
+			return os.WriteFile(output, data, 0644)
+		}
+	}
+
+	if output == "" {
+		return fmt.Errorf("exporting several prompts requires --output <directory>")
+	}
+
+	for _, prompt := range prompts {
+		data, err := importer.MarshalLangChainPrompt(importer.ToLangChainPrompt(prompt), format)
 		if err != nil {
-			return fmt.Errorf("failed to list templates: %w", err)
+			return fmt.Errorf("failed to marshal prompt %s: %w", prompt.ID, err)
 		}
-		data := map[string]interface{}{
-			"prompts":   prompts,
-			"templates": templates,
+		path := filepath.Join(output, filepath.FromSlash(prompt.ID)+"."+format)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return fmt.Errorf("failed to create directory: %w", err)
 		}
-		return c.exportData(data, format, outputFile)
-	default:
-		return fmt.Errorf("unknown export subcommand: %s", subcommand)
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+	}
+
+	fmt.Println(i18n.T("cli.export.done", len(prompts), output))
+	return nil
+}
+
+// parseExportQuery parses the --query of an export command. An empty
+// query selects everything and yields a nil expression.
+func parseExportQuery(query string) (*models.BooleanExpression, error) {
+	if strings.TrimSpace(query) == "" {
+		return nil, nil
+	}
+	expr, err := parseBooleanExpression(query)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --query: %w", err)
 	}
+	return expr, nil
 }
 
 // exportData exports data in the specified format
@@ -1472,7 +4155,7 @@ func (c *CLI) exportData(data interface{}, format, outputFile string) error {
 // handleImport handles import operations
 func (c *CLI) handleImport(args []string) error {
 	if len(args) == 0 {
-		return fmt.Errorf("import requires a subcommand or file path\n\nUsage:\n  pocket-prompt import claude-code [options]  # Import from Claude Code\n  pocket-prompt import <file> [options]       # Import from JSON file")
+		return fmt.Errorf("import requires a subcommand or file path\n\nUsage:\n  pocket-prompt import claude-code [options]      # Import from Claude Code\n  pocket-prompt import obsidian <vault> [options] # Import from an Obsidian vault\n  pocket-prompt import notion <export> [options]  # Import from a Notion export\n  pocket-prompt import langchain <path> [options] # Import LangChain prompt templates\n  pocket-prompt import csv <file> [options]       # Import rows of a CSV file\n  pocket-prompt import bundle <zip> [options]     # Restore an export bundle\n  pocket-prompt import <file> [options]           # Import from JSON file")
 	}
 
 	subcommand := args[0]
@@ -1481,6 +4164,31 @@ func (c *CLI) handleImport(args []string) error {
 	if subcommand == "claude-code" {
 		return c.handleClaudeCodeImport(args[1:])
 	}
+
+	// Handle Obsidian vault import
+	if subcommand == "obsidian" {
+		return c.handleObsidianImport(args[1:])
+	}
+
+	// Handle Notion export import
+	if subcommand == "notion" {
+		return c.handleNotionImport(args[1:])
+	}
+
+	// Handle LangChain prompt template import
+	if subcommand == "langchain" {
+		return c.handleLangChainImport(args[1:])
+	}
+
+	// Handle spreadsheet import
+	if subcommand == "csv" {
+		return c.handleCSVImport(args[1:])
+	}
+
+	// Handle bundle import
+	if subcommand == "bundle" {
+		return c.handleBundleImport(args[1:])
+	}
 	
 	// Handle file import (existing functionality)
 	return c.handleFileImport(args)
@@ -1534,45 +4242,341 @@ func (c *CLI) handleClaudeCodeImport(args []string) error {
 	}
 
 	// Display results
+	heading := i18n.T("cli.import.complete", "Claude Code")
 	if options.DryRun {
-		fmt.Println("Claude Code Import Preview:")
-		fmt.Println("===========================")
-	} else {
-		fmt.Println("Claude Code Import Complete:")
-		fmt.Println("============================")
+		heading = i18n.T("cli.import.preview", "Claude Code")
 	}
+	fmt.Println(heading)
+	fmt.Println(strings.Repeat("=", len(heading)))
 
 	if len(result.Prompts) > 0 {
-		fmt.Printf("Prompts: %d\n", len(result.Prompts))
+		fmt.Println(i18n.T("cli.import.prompts", len(result.Prompts)))
 		for _, prompt := range result.Prompts {
 			fmt.Printf("  - %s (%s)\n", prompt.Name, prompt.ID)
 		}
 	}
 
 	if len(result.Workflows) > 0 {
-		fmt.Printf("Workflows: %d\n", len(result.Workflows))
+		fmt.Println(i18n.T("cli.import.workflows", len(result.Workflows)))
 		for _, wf := range result.Workflows {
 			fmt.Printf("  - %s (%s)\n", wf.Name, wf.ID)
 		}
 	}
 
 	if len(result.Errors) > 0 {
-		fmt.Printf("\nErrors encountered: %d\n", len(result.Errors))
+		fmt.Printf("\n%s\n", i18n.T("cli.errors", len(result.Errors)))
 		for _, err := range result.Errors {
 			fmt.Printf("  - %v\n", err)
 		}
 	}
 
 	if options.DryRun {
-		fmt.Printf("\nTo actually import these items, run the same command without --preview\n")
+		fmt.Printf("\n%s\n", i18n.T("cli.import.preview_hint", "items"))
 	} else {
 		total := len(result.Prompts) + len(result.Workflows)
-		fmt.Printf("\nSuccessfully imported %d items from Claude Code\n", total)
+		fmt.Printf("\n%s\n", i18n.T("cli.import.done", total, "items", "Claude Code"))
+	}
+
+	return nil
+}
+
+// handleObsidianImport handles importing notes from an Obsidian vault
+func (c *CLI) handleObsidianImport(args []string) error {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		return fmt.Errorf("obsidian import requires a vault path\n\nUsage: pocket-prompt import obsidian <vault-path> [--tag <tag>] [--folder <folder>]")
+	}
+
+	options := importer.ObsidianOptions{}
+	options.Path = args[0]
+
+	// Parse flags
+	for i := 1; i < len(args); i++ {
+		arg := args[i]
+		switch arg {
+		case "--tag":
+			if i+1 < len(args) {
+				options.Tag = args[i+1]
+				i++
+			}
+		case "--folder":
+			if i+1 < len(args) {
+				options.Folder = args[i+1]
+				i++
+			}
+		case "--preview", "--dry-run":
+			options.DryRun = true
+		case "--tags":
+			if i+1 < len(args) {
+				tags := strings.Split(args[i+1], ",")
+				for j := range tags {
+					tags[j] = strings.TrimSpace(tags[j])
+				}
+				options.Tags = tags
+				i++
+			}
+		case "--overwrite":
+			options.OverwriteExisting = true
+		case "--skip-existing":
+			options.SkipExisting = true
+		}
+	}
+
+	result, err := c.service.ImportFromObsidian(options)
+	if err != nil {
+		return err
+	}
+
+	c.printImportResult("Obsidian", "notes", result, options.DryRun)
+	return nil
+}
+
+// handleNotionImport handles importing pages from an unzipped Notion export
+func (c *CLI) handleNotionImport(args []string) error {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		return fmt.Errorf("notion import requires an export directory\n\nUsage: pocket-prompt import notion <export-dir> [--tag-property <name>]")
+	}
+
+	options := importer.NotionOptions{}
+	options.Path = args[0]
+
+	// Parse flags
+	for i := 1; i < len(args); i++ {
+		arg := args[i]
+		switch arg {
+		case "--tag-property":
+			if i+1 < len(args) {
+				options.TagProperties = append(options.TagProperties, args[i+1])
+				i++
+			}
+		case "--preview", "--dry-run":
+			options.DryRun = true
+		case "--tags":
+			if i+1 < len(args) {
+				tags := strings.Split(args[i+1], ",")
+				for j := range tags {
+					tags[j] = strings.TrimSpace(tags[j])
+				}
+				options.Tags = tags
+				i++
+			}
+		case "--overwrite":
+			options.OverwriteExisting = true
+		case "--skip-existing":
+			options.SkipExisting = true
+		}
+	}
+
+	result, err := c.service.ImportFromNotion(options)
+	if err != nil {
+		return err
+	}
+
+	c.printImportResult("Notion", "pages", result, options.DryRun)
+	return nil
+}
+
+// handleLangChainImport handles importing LangChain prompt template files
+func (c *CLI) handleLangChainImport(args []string) error {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		return fmt.Errorf("langchain import requires a file or directory\n\nUsage: pocket-prompt import langchain <file-or-dir> [--preview]")
+	}
+
+	options := importer.ImportOptions{Path: args[0]}
+
+	// Parse flags
+	for i := 1; i < len(args); i++ {
+		arg := args[i]
+		switch arg {
+		case "--preview", "--dry-run":
+			options.DryRun = true
+		case "--tags":
+			if i+1 < len(args) {
+				tags := strings.Split(args[i+1], ",")
+				for j := range tags {
+					tags[j] = strings.TrimSpace(tags[j])
+				}
+				options.Tags = tags
+				i++
+			}
+		case "--overwrite":
+			options.OverwriteExisting = true
+		case "--skip-existing":
+			options.SkipExisting = true
+		}
+	}
+
+	result, err := c.service.ImportFromLangChain(options)
+	if err != nil {
+		return err
+	}
+
+	c.printImportResult("LangChain", "templates", result, options.DryRun)
+	return nil
+}
+
+// handleCSVImport handles importing prompts from the rows of a CSV file
+func (c *CLI) handleCSVImport(args []string) error {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		return fmt.Errorf("csv import requires a file\n\nUsage: pocket-prompt import csv <file.csv> [--map title=col1,content=col2,tags=col3] [--preview]")
+	}
+
+	options := importer.CSVOptions{}
+	options.Path = args[0]
+
+	// Parse flags
+	for i := 1; i < len(args); i++ {
+		arg := args[i]
+		switch arg {
+		case "--map", "-m":
+			if i+1 < len(args) {
+				mapping, err := importer.ParseCSVMapping(args[i+1])
+				if err != nil {
+					return err
+				}
+				if options.Mapping == nil {
+					options.Mapping = mapping
+				} else {
+					for field, column := range mapping {
+						options.Mapping[field] = column
+					}
+				}
+				i++
+			}
+		case "--delimiter":
+			if i+1 < len(args) {
+				delimiter := args[i+1]
+				if delimiter == "\\t" || delimiter == "tab" {
+					delimiter = "\t"
+				}
+				runes := []rune(delimiter)
+				if len(runes) != 1 {
+					return fmt.Errorf("delimiter must be a single character: %q", args[i+1])
+				}
+				options.Delimiter = runes[0]
+				i++
+			}
+		case "--preview", "--dry-run":
+			options.DryRun = true
+		case "--tags":
+			if i+1 < len(args) {
+				tags := strings.Split(args[i+1], ",")
+				for j := range tags {
+					tags[j] = strings.TrimSpace(tags[j])
+				}
+				options.Tags = tags
+				i++
+			}
+		case "--overwrite":
+			options.OverwriteExisting = true
+		case "--skip-existing":
+			options.SkipExisting = true
+		}
+	}
+
+	result, err := c.service.ImportFromCSV(options)
+	if err != nil {
+		return err
+	}
+
+	c.printImportResult("CSV", "rows", result, options.DryRun)
+	return nil
+}
+
+// handleBundleImport restores a bundle written by export bundle
+func (c *CLI) handleBundleImport(args []string) error {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		return fmt.Errorf("bundle import requires a zip file\n\nUsage: pocket-prompt import bundle <bundle.zip> [--preview]")
+	}
+
+	options := importer.ImportOptions{Path: args[0]}
+
+	// Parse flags
+	for i := 1; i < len(args); i++ {
+		arg := args[i]
+		switch arg {
+		case "--preview", "--dry-run":
+			options.DryRun = true
+		case "--overwrite":
+			options.OverwriteExisting = true
+		case "--skip-existing":
+			options.SkipExisting = true
+		}
+	}
+
+	result, err := c.service.ImportBundle(options.Path, options)
+	if err != nil {
+		return err
+	}
+
+	heading := i18n.T("cli.import.complete", "Bundle")
+	if options.DryRun {
+		heading = i18n.T("cli.import.preview", "Bundle")
+	}
+	fmt.Println(heading)
+	fmt.Println(strings.Repeat("=", len(heading)))
+	fmt.Println(i18n.T("cli.label.created", result.Manifest.CreatedAt.Local().Format("2006-01-02 15:04")))
+
+	fmt.Println(i18n.T("cli.import.prompts", len(result.Prompts)))
+	for _, prompt := range result.Prompts {
+		fmt.Printf("  - %s (%s, v%s)\n", prompt.Name, prompt.ID, prompt.Version)
+	}
+	if len(result.Archived) > 0 {
+		fmt.Println(i18n.T("cli.import.archived", len(result.Archived)))
+	}
+	if len(result.Templates) > 0 {
+		fmt.Println(i18n.T("cli.import.templates", len(result.Templates)))
+		for _, template := range result.Templates {
+			fmt.Printf("  - %s (%s)\n", template.Name, template.ID)
+		}
+	}
+	if len(result.Attachments) > 0 {
+		fmt.Println(i18n.T("cli.import.attachments", len(result.Attachments)))
+		for _, name := range result.Attachments {
+			fmt.Printf("  - %s\n", name)
+		}
+	}
+
+	if len(result.Errors) > 0 {
+		fmt.Printf("\n%s\n", i18n.T("cli.errors", len(result.Errors)))
+		for _, err := range result.Errors {
+			fmt.Printf("  - %v\n", err)
+		}
 	}
 
+	if options.DryRun {
+		fmt.Printf("\n%s\n", i18n.T("cli.import.bundle_preview_hint"))
+	}
 	return nil
 }
 
+// printImportResult displays the outcome of an import
+func (c *CLI) printImportResult(source, items string, result *importer.ImportResult, dryRun bool) {
+	heading := i18n.T("cli.import.complete", source)
+	if dryRun {
+		heading = i18n.T("cli.import.preview", source)
+	}
+	fmt.Println(heading)
+	fmt.Println(strings.Repeat("=", len(heading)))
+
+	fmt.Println(i18n.T("cli.import.prompts", len(result.Prompts)))
+	for _, prompt := range result.Prompts {
+		fmt.Printf("  - %s (%s)\n", prompt.Name, prompt.ID)
+	}
+
+	if len(result.Errors) > 0 {
+		fmt.Printf("\n%s\n", i18n.T("cli.errors", len(result.Errors)))
+		for _, err := range result.Errors {
+			fmt.Printf("  - %v\n", err)
+		}
+	}
+
+	if dryRun {
+		fmt.Printf("\n%s\n", i18n.T("cli.import.preview_hint", items))
+	} else {
+		fmt.Printf("\n%s\n", i18n.T("cli.import.done", len(result.Prompts), items, source))
+	}
+}
+
 // handleFileImport handles importing from JSON files (existing functionality)
 func (c *CLI) handleFileImport(args []string) error {
 	if len(args) == 0 {
@@ -1617,10 +4621,10 @@ func (c *CLI) handleFileImport(args []string) error {
 			if json.Unmarshal(promptsJSON, &prompts) == nil {
 				for _, prompt := range prompts {
 					if err := c.service.SavePrompt(prompt); err != nil {
-						fmt.Printf("Warning: failed to import prompt %s: %v\n", prompt.ID, err)
+						fmt.Println(i18n.T("cli.import.prompt_failed", prompt.ID, err))
 					}
 				}
-				fmt.Printf("Imported %d prompts\n", len(prompts))
+				fmt.Println(i18n.T("cli.import.imported_prompts", len(prompts)))
 			}
 		}
 
@@ -1631,10 +4635,10 @@ func (c *CLI) handleFileImport(args []string) error {
 			if json.Unmarshal(templatesJSON, &templates) == nil {
 				for _, template := range templates {
 					if err := c.service.SaveTemplate(template); err != nil {
-						fmt.Printf("Warning: failed to import template %s: %v\n", template.ID, err)
+						fmt.Println(i18n.T("cli.import.template_failed", template.ID, err))
 					}
 				}
-				fmt.Printf("Imported %d templates\n", len(templates))
+				fmt.Println(i18n.T("cli.import.imported_templates", len(templates)))
 			}
 		}
 	default:
@@ -1652,185 +4656,106 @@ func (c *CLI) printHelp(args []string) error {
 	command := args[0]
 	switch command {
 	case "list", "ls":
-		fmt.Println(`list - List all prompts
-
-Usage: pocket-prompt list [options]
-
-Options:
-  --format, -f <format>  Output format (table, json, ids, default)
-  --tag, -t <tag>        Filter by tag
-  --archived, -a         Show archived prompts`)
+		fmt.Println(i18n.T("cli.help.list"))
 
 	case "search":
-		fmt.Println(`search - Search prompts
-
-Usage: pocket-prompt search <query> [options]
+		fmt.Println(i18n.T("cli.help.search"))
 
-Options:
-  --format, -f <format>  Output format (table, json, ids, default)
-  --boolean, -b          Use boolean expression search
-
-Examples:
-  pocket-prompt search "machine learning"
-  pocket-prompt search --boolean "(ai AND analysis) OR writing"`)
+	case "get", "show":
+		fmt.Println(i18n.T("cli.help.get"))
 
 	case "create", "new":
-		fmt.Println(`create - Create a new prompt
-
-Usage: pocket-prompt create <id> [options]
-
-Options:
-  --title <title>        Prompt title
-  --description <desc>   Prompt description
-  --content <content>    Prompt content
-  --template <id>        Template to use
-  --tags <tag1,tag2>     Comma-separated tags
-  --stdin                Read content from stdin
-
-Example:
-  pocket-prompt create my-prompt --title "My Prompt" --content "Hello world"`)
-
-	case "template":
-		fmt.Println(`template - Template management
+		fmt.Println(i18n.T("cli.help.create"))
 
-Usage: pocket-prompt template <subcommand> [options]
+	case "tags":
+		fmt.Println(i18n.T("cli.help.tags"))
 
-Subcommands:
-  create <id>     Create a new template
-  edit <id>       Edit an existing template
-  delete <id>     Delete a template
-  show <id>       Show template details
+	case "tag":
+		fmt.Println(i18n.T("cli.help.tag"))
 
-Create Options:
-  --name <name>           Template name
-  --description <desc>    Template description
-  --content <content>     Template content
-  --slots <slot1,slot2>   Comma-separated slot names
-  --stdin                 Read content from stdin
+	case "delete", "rm":
+		fmt.Println(i18n.T("cli.help.delete"))
 
-Edit Options:
-  --name <name>           Update template name
-  --description <desc>    Update template description
-  --content <content>     Update template content
-  --slots <slot1,slot2>   Update slot names
+	case "edit":
+		fmt.Println(i18n.T("cli.help.edit"))
 
-Delete Options:
-  --force, -f             Force deletion without confirmation
+	case "duplicate", "clone":
+		fmt.Println(i18n.T("cli.help.duplicate"))
 
-Examples:
-  pocket-prompt template create my-template --name "My Template" --content "Hello {{name}}"
-  pocket-prompt template edit my-template --content "Updated content"`)
+	case "template":
+		fmt.Println(i18n.T("cli.help.template"))
 
 	case "boolean-search":
-		fmt.Println(`boolean-search - Manage boolean searches
-
-Usage: pocket-prompt boolean-search <subcommand> [options]
-
-Subcommands:
-  create <name> <expression>  Create a new saved boolean search
-  edit <name> <expression>    Edit an existing saved boolean search  
-  delete <name>               Delete a saved boolean search
-  list                        List all saved boolean searches
-  run <expression>            Execute a boolean search expression
-  run --saved <name>          Execute a saved boolean search
-
-Delete Options:
-  --force, -f                 Force deletion without confirmation
-
-Examples:
-  pocket-prompt boolean-search create ai-search "(ai AND analysis) OR machine-learning"
-  pocket-prompt boolean-search run "(python AND tutorial) OR beginner"
-  pocket-prompt boolean-search run --saved ai-search`)
+		fmt.Println(i18n.T("cli.help.boolean-search"))
 
 	case "export":
-		fmt.Println(`export - Export prompts and templates
+		fmt.Println(i18n.T("cli.help.export"))
 
-Usage: pocket-prompt export <type> [options]
+	case "import":
+		fmt.Println(i18n.T("cli.help.import"))
 
-Types:
-  prompts     Export all prompts
-  templates   Export all templates
-  all         Export prompts and templates
+	case "render":
+		fmt.Println(i18n.T("cli.help.render"))
 
-Options:
-  --format, -f <format>   Export format (json)
-  --output, -o <file>     Output file (default: stdout)
+	case "watch":
+		fmt.Println(i18n.T("cli.help.watch"))
 
-Examples:
-  pocket-prompt export all --output backup.json
-  pocket-prompt export prompts --format json`)
+	case "catalog":
+		fmt.Println(i18n.T("cli.help.catalog"))
 
-	case "import":
-		fmt.Println(`import - Import prompts and templates
+	case "locale":
+		fmt.Println(i18n.T("cli.help.locale"))
 
-Usage: 
-  pocket-prompt import claude-code [options]  # Import from Claude Code
-  pocket-prompt import <file> [options]       # Import from JSON file
+	case "pick":
+		fmt.Println(i18n.T("cli.help.pick"))
 
-Claude Code Import Options:
-  --path <path>           Directory to import from (default: current dir + ~/.claude)
-  --user                  When used with --path, also import from ~/.claude
-  --commands-only         Import only command files (.claude/commands/ and .claude/agents/)
-  --workflows-only        Import only GitHub Actions workflows
-  --config-only           Import only configuration files (CLAUDE.md)
-  --preview, --dry-run    Preview what would be imported without importing
-  --tags <tag1,tag2>      Additional tags to apply to imported items
-  --overwrite             Overwrite existing prompts/templates with same ID
-  --skip-existing         Skip items that already exist (no conflict errors)
-  --deduplicate           Skip duplicates based on original file path
+	case "tmux-popup":
+		fmt.Println(i18n.T("cli.help.tmux-popup"))
 
-File Import Options:
-  --format, -f <format>   Import format (json)
+	case "serve":
+		fmt.Println(i18n.T("cli.help.serve"))
 
-Examples:
-  # Import from current project + ~/.claude/commands and ~/.claude/agents
-  pocket-prompt import claude-code
+	case "query":
+		fmt.Println(i18n.T("cli.help.query"))
 
-  # Preview what would be imported
-  pocket-prompt import claude-code --preview
+	case "copy":
+		fmt.Println(i18n.T("cli.help.copy"))
 
-  # Import from specific directory only (without ~/.claude)
-  pocket-prompt import claude-code --path /path/to/project
+	case "git":
+		fmt.Println(i18n.T("cli.help.git"))
 
-  # Import from specific directory + ~/.claude directories
-  pocket-prompt import claude-code --path /path/to/project --user
+	case "backup":
+		fmt.Println(i18n.T("cli.help.backup"))
 
-  # Import from JSON backup
-  pocket-prompt import backup.json --format json`)
+	case "restore":
+		fmt.Println(i18n.T("cli.help.restore"))
 
-	case "render":
-		fmt.Println(`render - Render prompt with variables
+	case "plugins":
+		fmt.Println(i18n.T("cli.help.plugins"))
 
-Usage: pocket-prompt render <id> [options]
+	case "lint":
+		fmt.Println(i18n.T("cli.help.lint"))
 
-Options:
-  --format, -f <format>  Output format (text, json)
-  --var <name=value>     Set variable value (can be used multiple times)
+	case "snippets", "snippet":
+		fmt.Println(i18n.T("cli.help.snippets"))
 
-Example:
-  pocket-prompt render my-prompt --var name=John --var age=30`)
+	case "secret":
+		fmt.Println(i18n.T("cli.help.secret"))
 
-	case "git":
-		fmt.Println(`git - Git synchronization
+	case "attach":
+		fmt.Println(i18n.T("cli.help.attach"))
 
-Usage: pocket-prompt git <subcommand>
+	case "rate":
+		fmt.Println(i18n.T("cli.help.rate"))
 
-Subcommands:
-  setup <url>     Setup Git repository (handles everything automatically)
-  status          Show git sync status
-  sync            Manual sync with remote repository  
-  pull            Pull changes from remote repository
-  enable          Enable git synchronization
-  disable         Disable git synchronization
+	case "dedupe":
+		fmt.Println(i18n.T("cli.help.dedupe"))
 
-Examples:
-  pocket-prompt git setup https://github.com/username/my-prompts.git
-  pocket-prompt git setup git@github.com:username/my-prompts.git
-  pocket-prompt git status
-  pocket-prompt git sync`)
+	case "conflicts":
+		fmt.Println(i18n.T("cli.help.conflicts"))
 
 	default:
-		fmt.Printf("No help available for command: %s\n", command)
+		fmt.Println(i18n.T("cli.help.unknown", command))
 	}
 
 	return nil
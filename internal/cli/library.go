@@ -0,0 +1,77 @@
+package cli
+
+import (
+	"io"
+
+	"github.com/dpshade/pocket-prompt/internal/git"
+	"github.com/dpshade/pocket-prompt/internal/importer"
+	"github.com/dpshade/pocket-prompt/internal/models"
+	"github.com/dpshade/pocket-prompt/internal/server"
+	"github.com/dpshade/pocket-prompt/internal/service"
+	"github.com/dpshade/pocket-prompt/internal/ui"
+)
+
+// Library is the part of the service the CLI uses. It includes what the
+// TUI picker and the stdio server need, since commands start both.
+// *service.Service implements it over any storage backend.
+type Library interface {
+	ui.Library
+	server.Library
+
+	AddAttachment(id, name string, data []byte) (*models.Prompt, error)
+	ApplyRestore(plan *service.RestorePlan) (*service.BundleImportResult, error)
+	BackupDir() string
+	CreateBackup(keep int) (*service.BackupInfo, error)
+	CreateGitBranch(name string) error
+	DeleteGitBranch(name string, force bool) error
+	DeleteSecret(name string) error
+	DeleteTemplate(id string) error
+	DisableGitSync()
+	DraftBranch() string
+	EnableGitSync()
+	ExecuteSavedSearch(name string) ([]*models.Prompt, error)
+	ExecuteSavedSearchWithText(name string, textQueryOverride string) ([]*models.Prompt, error)
+	ExportBundle(w io.Writer, expression *models.BooleanExpression) (*service.BundleManifest, error)
+	FindBackup(ref string) (*service.BackupInfo, error)
+	FindPlugin(name string) (service.Plugin, bool)
+	GetSavedSearch(name string) (*models.SavedSearch, error)
+	GetSnippet(name string) (*models.Snippet, error)
+	ImportBundle(bundlePath string, options importer.ImportOptions) (*service.BundleImportResult, error)
+	ImportFromCSV(options importer.CSVOptions) (*importer.ImportResult, error)
+	ImportFromClaudeCode(options importer.ImportOptions) (*importer.ImportResult, error)
+	ImportFromLangChain(options importer.ImportOptions) (*importer.ImportResult, error)
+	ImportFromNotion(options importer.NotionOptions) (*importer.ImportResult, error)
+	ImportFromObsidian(options importer.ObsidianOptions) (*importer.ImportResult, error)
+	LibraryDir() string
+	Lint() ([]service.LintIssue, error)
+	ListArchivedPrompts() ([]*models.Prompt, error)
+	ListBackups() ([]service.BackupInfo, error)
+	ListDrafts() ([]*models.Prompt, error)
+	ListGitBranches() ([]git.Branch, error)
+	ListPlugins() []service.Plugin
+	ListPromptsInScope(scope service.ArchiveScope) ([]*models.Prompt, error)
+	PlanRestore(backupPath string, ids []string) (*service.RestorePlan, error)
+	PluginDir() string
+	PublishDrafts(ids []string) ([]*models.Prompt, error)
+	PullGitChanges() error
+	RenameTags(from []string, to string, dryRun bool) ([]*models.Prompt, []string, error)
+	RetagPrompts(prompts []*models.Prompt, add, remove []string, dryRun bool) ([]*models.Prompt, error)
+	RollbackTemplate(id, version string) (*models.Template, error)
+	RunPlugin(plugin service.Plugin, args []string, stdout, stderr io.Writer) error
+	SavePreset(id, name string, values map[string]string) (*models.Prompt, error)
+	SaveSnippet(snippet *models.Snippet) error
+	SearchPromptsByBooleanExpressionInScope(expression *models.BooleanExpression, scope service.ArchiveScope) ([]*models.Prompt, error)
+	SearchPromptsInScope(query string, scope service.ArchiveScope) ([]*models.Prompt, error)
+	SearchSort() string
+	SetSecret(name, value string) error
+	SetupGitHubRepository(name, description string, private bool) (string, error)
+	SetupGitRepository(repoURL string) error
+	SnippetUsers(name string) ([]*models.Prompt, []*models.Template, error)
+	SortPrompts(prompts []*models.Prompt, by string) error
+	SwitchGitBranch(name string) error
+	TemplateHistory(id string) ([]*models.Template, error)
+	TemplatesUsedBy(prompts []*models.Prompt) ([]*models.Template, error)
+	UpdatePrompt(prompt *models.Prompt) error
+}
+
+var _ Library = (*service.Service)(nil)
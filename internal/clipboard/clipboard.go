@@ -3,11 +3,40 @@ package clipboard
 import (
 	"errors"
 	"fmt"
+	"os"
 	"os/exec"
 	"runtime"
 	"strings"
+	"unicode/utf16"
+
+	osc52 "github.com/aymanbagabas/go-osc52/v2"
+	"github.com/dpshade/pocket-prompt/internal/i18n"
+)
+
+// Environment variables overriding the configured clipboard commands
+const (
+	CopyCommandEnv  = "POCKET_PROMPT_CLIPBOARD_COPY"
+	PasteCommandEnv = "POCKET_PROMPT_CLIPBOARD_PASTE"
 )
 
+// Commands set in config.yaml in place of the detected tools
+var copyCommand, pasteCommand string
+
+// SetCommands replaces the detected clipboard tools with shell commands.
+// The copy command reads the text from stdin and the paste command prints
+// the clipboard. An empty command keeps the detected tool.
+func SetCommands(copy, paste string) {
+	copyCommand, pasteCommand = copy, paste
+}
+
+// customCommand returns the command from the environment or config.yaml
+func customCommand(env, configured string) string {
+	if command := os.Getenv(env); command != "" {
+		return command
+	}
+	return configured
+}
+
 // ClipboardError represents an error when no clipboard utility is available
 type ClipboardError struct {
 	OS      string
@@ -23,104 +52,203 @@ func NewClipboardError() *ClipboardError {
 	var msg string
 	switch runtime.GOOS {
 	case "linux":
-		msg = "no clipboard utility found. Install one of:\n" +
+		msg = "no clipboard utility found and no terminal to copy through. Install one of:\n" +
 			"  • Ubuntu/Debian: sudo apt install xclip\n" +
 			"  • Fedora/RHEL: sudo dnf install xclip\n" +
 			"  • Arch: sudo pacman -S xclip\n" +
-			"  • For Wayland: install wl-clipboard"
+			"  • For Wayland: install wl-clipboard\n" +
+			"  • Or set clipboard.copy_command in config.yaml"
 	case "darwin":
 		msg = "pbcopy not available (this should not happen on macOS)"
 	case "windows":
-		msg = "clip command not available (this should not happen on Windows)"
+		msg = "the Windows clipboard is not available"
 	default:
 		msg = fmt.Sprintf("clipboard not supported on %s", runtime.GOOS)
 	}
-	
+
 	return &ClipboardError{
 		OS:      runtime.GOOS,
 		Message: msg,
 	}
 }
 
-// Copy copies text to the system clipboard
+// tool is an external program that writes or reads the clipboard
+type tool struct {
+	name        string
+	args        []string
+	utf16       bool // Input is UTF-16 rather than UTF-8, as clip.exe expects
+	trimNewline bool // Output ends with a newline that isn't on the clipboard
+}
+
+// Copy copies text to the system clipboard with the configured command or,
+// without one, the detected tool. Windows is used directly; WSL goes
+// through win32yank.exe, PowerShell or clip.exe; other systems use pbcopy, wl-copy,
+// xclip, xsel or termux-clipboard-set, and without them ask the terminal
+// to copy through an OSC 52 escape sequence, which also works over SSH.
 func Copy(text string) error {
-	switch runtime.GOOS {
-	case "darwin":
-		return copyDarwin(text)
-	case "linux":
-		return copyLinux(text)
-	case "windows":
-		return copyWindows(text)
-	default:
-		return fmt.Errorf("unsupported platform: %s", runtime.GOOS)
+	if command := customCommand(CopyCommandEnv, copyCommand); command != "" {
+		_, err := runShell(command, &text)
+		return err
+	}
+
+	err := copyPlatform(text)
+	var clipErr *ClipboardError
+	if err == nil || !errors.As(err, &clipErr) {
+		return err
+	}
+	if termErr := copyTerminal(text); termErr == nil {
+		return nil
 	}
+	return err
 }
 
-// copyDarwin copies text to clipboard on macOS
-func copyDarwin(text string) error {
-	cmd := exec.Command("pbcopy")
-	cmd.Stdin = strings.NewReader(text)
-	return cmd.Run()
+// ErrRichTextUnsupported is returned by CopyHTML when no clipboard tool
+// available can hold HTML
+var ErrRichTextUnsupported = errors.New("copying rich text is not supported here")
+
+// CopyHTML places html on the clipboard as rich text, so pasting into
+// editors such as Google Docs or Notion keeps its formatting. Where the
+// platform allows, text is offered alongside for plain text pastes.
+func CopyHTML(html, text string) error {
+	if customCommand(CopyCommandEnv, copyCommand) != "" {
+		return ErrRichTextUnsupported
+	}
+	return copyHTMLPlatform(html, text)
 }
 
-// copyLinux copies text to clipboard on Linux
-func copyLinux(text string) error {
-	var lastErr error
-	
-	// Try xclip first
-	if isCommandAvailable("xclip") {
-		cmd := exec.Command("xclip", "-selection", "clipboard")
-		cmd.Stdin = strings.NewReader(text)
-		if err := cmd.Run(); err == nil {
-			return nil
-		} else {
-			lastErr = fmt.Errorf("xclip failed: %w", err)
+// CopyHTMLWithFallback copies html as rich text, or text as plain text
+// where rich text is not supported, and returns a message
+func CopyHTMLWithFallback(html, text string) (string, error) {
+	err := CopyHTML(html, text)
+	if errors.Is(err, ErrRichTextUnsupported) {
+		if _, err := CopyWithFallback(text); err != nil {
+			return "", err
 		}
+		return i18n.T("clipboard.plain_only"), nil
 	}
+	if err != nil {
+		return "", fmt.Errorf("failed to copy rich text: %w", err)
+	}
+	return i18n.T("clipboard.rich"), nil
+}
 
-	// Try xsel as fallback
-	if isCommandAvailable("xsel") {
-		cmd := exec.Command("xsel", "--clipboard", "--input")
-		cmd.Stdin = strings.NewReader(text)
-		if err := cmd.Run(); err == nil {
-			return nil
-		} else {
-			lastErr = fmt.Errorf("xsel failed: %w", err)
+// Read returns the text on the system clipboard
+func Read() (string, error) {
+	if command := customCommand(PasteCommandEnv, pasteCommand); command != "" {
+		return runShell(command, nil)
+	}
+	return readPlatform()
+}
+
+// runShell runs a configured clipboard command through the shell. Text
+// piped to clip is encoded as UTF-16, which it reads without mangling
+// non-ASCII characters.
+func runShell(command string, input *string) (string, error) {
+	shell := tool{name: "sh", args: []string{"-c", command}, utf16: isClipCommand(command)}
+	if runtime.GOOS == "windows" {
+		shell = tool{name: "cmd", args: []string{"/c", command}, utf16: isClipCommand(command)}
+	}
+	output, err := runTool(shell, input)
+	if err != nil {
+		return "", fmt.Errorf("clipboard command %q failed: %w", command, err)
+	}
+	return output, nil
+}
+
+// isClipCommand reports whether a configured copy command is the Windows
+// clip tool on its own, as clip, clip.exe or a path to one
+func isClipCommand(command string) bool {
+	fields := strings.Fields(command)
+	if len(fields) != 1 {
+		return false
+	}
+	name := strings.ToLower(fields[0])
+	name = name[strings.LastIndexAny(name, `/\`)+1:]
+	return name == "clip" || name == "clip.exe"
+}
+
+// runTools pipes text to the first available tool, or returns the output
+// of the first that succeeds when reading. It returns a ClipboardError when
+// none is installed, and otherwise the error of each one that failed.
+func runTools(tools []tool, input *string) (string, error) {
+	var failures []string
+	for _, t := range tools {
+		if !isCommandAvailable(t.name) {
+			continue
 		}
+		output, err := runTool(t, input)
+		if err == nil {
+			return output, nil
+		}
+		failures = append(failures, fmt.Sprintf("%s: %v", t.name, err))
+	}
+
+	if len(failures) > 0 {
+		return "", fmt.Errorf("clipboard utilities available but failed (%s)", strings.Join(failures, "; "))
 	}
+	return "", NewClipboardError()
+}
 
-	// Try wl-copy for Wayland
-	if isCommandAvailable("wl-copy") {
-		cmd := exec.Command("wl-copy")
-		cmd.Stdin = strings.NewReader(text)
-		if err := cmd.Run(); err == nil {
-			return nil
+// runTool runs a clipboard tool, returning its output or what it printed
+// on failure
+func runTool(t tool, input *string) (string, error) {
+	cmd := exec.Command(t.name, t.args...)
+	if input != nil {
+		if t.utf16 {
+			cmd.Stdin = strings.NewReader(encodeUTF16(*input))
 		} else {
-			lastErr = fmt.Errorf("wl-copy failed: %w", err)
+			cmd.Stdin = strings.NewReader(*input)
 		}
 	}
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		if detail := strings.TrimSpace(stderr.String()); detail != "" {
+			return "", errors.New(detail)
+		}
+		return "", err
+	}
+	text := string(output)
+	if t.trimNewline {
+		text = strings.TrimSuffix(strings.TrimSuffix(text, "\n"), "\r")
+	}
+	return text, nil
+}
 
-	if lastErr != nil {
-		return fmt.Errorf("clipboard utilities available but failed: %w", lastErr)
+// encodeUTF16 encodes text as little-endian UTF-16 with a byte order mark
+func encodeUTF16(text string) string {
+	units := utf16.Encode([]rune("\ufeff" + text))
+	buf := make([]byte, 0, len(units)*2)
+	for _, u := range units {
+		buf = append(buf, byte(u), byte(u>>8))
 	}
-	
-	return NewClipboardError()
+	return string(buf)
 }
 
-// copyWindows copies text to clipboard on Windows
-func copyWindows(text string) error {
-	cmd := exec.Command("cmd", "/c", "clip")
-	cmd.Stdin = strings.NewReader(text)
-	return cmd.Run()
+// copyTerminal sends text to the controlling terminal's clipboard with an
+// OSC 52 escape sequence. Whether the terminal honors it can't be checked.
+func copyTerminal(text string) error {
+	tty, err := os.OpenFile("/dev/tty", os.O_WRONLY, 0)
+	if err != nil {
+		return fmt.Errorf("no terminal to copy through: %w", err)
+	}
+	defer tty.Close()
+
+	seq := osc52.New(text)
+	if os.Getenv("TMUX") != "" {
+		seq = seq.Tmux()
+	} else if strings.HasPrefix(os.Getenv("TERM"), "screen") {
+		seq = seq.Screen()
+	}
+	_, err = seq.WriteTo(tty)
+	return err
 }
 
 // isCommandAvailable checks if a command is available in PATH
 func isCommandAvailable(name string) bool {
-	cmd := exec.Command("which", name)
-	if err := cmd.Run(); err != nil {
-		return false
-	}
-	return true
+	_, err := exec.LookPath(name)
+	return err == nil
 }
 
 // CopyWithFallback attempts to copy to clipboard and returns a message
@@ -136,21 +264,20 @@ func CopyWithFallback(text string) (string, error) {
 		// For other errors, provide a generic failure message
 		return "", fmt.Errorf("failed to copy to clipboard: %w", err)
 	}
-	return "Copied to clipboard!", nil
+	return i18n.T("clipboard.copied"), nil
 }
 
 // IsClipboardAvailable checks if clipboard functionality is available
 func IsClipboardAvailable() bool {
-	switch runtime.GOOS {
-	case "darwin":
-		return isCommandAvailable("pbcopy")
-	case "linux":
-		return isCommandAvailable("xclip") || isCommandAvailable("xsel") || isCommandAvailable("wl-copy")
-	case "windows":
-		return true // clip should always be available on Windows
-	default:
-		return false
+	if runtime.GOOS == "windows" || customCommand(CopyCommandEnv, copyCommand) != "" {
+		return true // The clipboard is part of Windows
+	}
+	for _, t := range copyTools() {
+		if isCommandAvailable(t.name) {
+			return true
+		}
 	}
+	return false
 }
 
 // GetInstallInstructions returns installation instructions for clipboard utilities
@@ -161,12 +288,13 @@ func GetInstallInstructions() string {
 			"  • Ubuntu/Debian: sudo apt install xclip\n" +
 			"  • Fedora/RHEL: sudo dnf install xclip\n" +
 			"  • Arch: sudo pacman -S xclip\n" +
-			"  • For Wayland: install wl-clipboard"
+			"  • For Wayland: install wl-clipboard\n" +
+			"  • Or set clipboard.copy_command in config.yaml, e.g. termux-clipboard-set"
 	case "darwin":
 		return "pbcopy should be available by default on macOS"
 	case "windows":
-		return "clip should be available by default on Windows"
+		return "The clipboard is used directly on Windows; nothing to install"
 	default:
 		return fmt.Sprintf("Clipboard not supported on %s", runtime.GOOS)
 	}
-}
\ No newline at end of file
+}
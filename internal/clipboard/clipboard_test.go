@@ -2,6 +2,7 @@ package clipboard
 
 import (
 	"errors"
+	"path/filepath"
 	"runtime"
 	"testing"
 )
@@ -108,4 +109,70 @@ func findSubstring(s, substr string) bool {
 		}
 	}
 	return false
-}
\ No newline at end of file
+}
+func TestCustomCommands(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+	file := filepath.Join(t.TempDir(), "clipboard")
+	SetCommands("cat > "+file, "cat "+file)
+	defer SetCommands("", "")
+
+	if err := Copy("héllo\nworld"); err != nil {
+		t.Fatalf("Copy failed: %v", err)
+	}
+	text, err := Read()
+	if err != nil || text != "héllo\nworld" {
+		t.Errorf("Expected the copied text back, got %q %v", text, err)
+	}
+
+	// The environment takes precedence over config.yaml
+	t.Setenv(CopyCommandEnv, "exit 3")
+	if err := Copy("x"); err == nil || !contains(err.Error(), "exit 3") {
+		t.Errorf("Expected the failing command to be reported, got %v", err)
+	}
+}
+
+func TestEncodeUTF16(t *testing.T) {
+	if got := encodeUTF16("é"); got != "\xff\xfe\xe9\x00" {
+		t.Errorf("Expected little-endian UTF-16 with a BOM, got %q", got)
+	}
+}
+
+func TestIsClipCommand(t *testing.T) {
+	for command, want := range map[string]bool{
+		"clip":                             true,
+		"clip.exe":                         true,
+		"CLIP.EXE":                         true,
+		"/mnt/c/Windows/System32/clip.exe": true,
+		`C:\Windows\System32\clip.exe`:     true,
+		"xclip":                            false,
+		"clip.exe | tee log":               false,
+		"termux-clipboard-set":             false,
+	} {
+		if got := isClipCommand(command); got != want {
+			t.Errorf("isClipCommand(%q) = %v, want %v", command, got, want)
+		}
+	}
+}
+
+func TestCopyHTMLFallsBackToPlainText(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+	file := filepath.Join(t.TempDir(), "clipboard")
+	SetCommands("cat > "+file, "cat "+file)
+	defer SetCommands("", "")
+
+	// A custom command only takes plain text
+	if err := CopyHTML("<p><strong>hi</strong></p>", "**hi**"); !errors.Is(err, ErrRichTextUnsupported) {
+		t.Errorf("Expected rich text to be unsupported, got %v", err)
+	}
+	statusMsg, err := CopyHTMLWithFallback("<p><strong>hi</strong></p>", "**hi**")
+	if err != nil || !contains(statusMsg, "plain text") {
+		t.Errorf("Expected a plain text copy, got %q %v", statusMsg, err)
+	}
+	if text, _ := Read(); text != "**hi**" {
+		t.Errorf("Expected the plain text on the clipboard, got %q", text)
+	}
+}
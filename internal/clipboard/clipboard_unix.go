@@ -0,0 +1,134 @@
+//go:build !windows
+
+package clipboard
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+var (
+	wslOnce sync.Once
+	wsl     bool
+)
+
+// isWSL reports whether this is Linux running under the Windows Subsystem
+// for Linux, where the Windows clipboard is reached through .exe tools
+func isWSL() bool {
+	wslOnce.Do(func() {
+		if runtime.GOOS != "linux" {
+			return
+		}
+		if os.Getenv("WSL_DISTRO_NAME") != "" || os.Getenv("WSL_INTEROP") != "" {
+			wsl = true
+			return
+		}
+		release, err := os.ReadFile("/proc/sys/kernel/osrelease")
+		wsl = err == nil && strings.Contains(strings.ToLower(string(release)), "microsoft")
+	})
+	return wsl
+}
+
+// copyTools returns the tools that can write the clipboard, in order of
+// preference
+func copyTools() []tool {
+	if runtime.GOOS == "darwin" {
+		return []tool{{name: "pbcopy"}}
+	}
+	var tools []tool
+	if isWSL() {
+		// clip.exe decodes its input with the console code page unless it
+		// starts with a UTF-16 byte order mark, which some versions paste
+		// too, so it is the last resort
+		tools = append(tools,
+			tool{name: "win32yank.exe", args: []string{"-i", "--crlf"}},
+			tool{name: "powershell.exe", args: []string{"-NoProfile", "-NonInteractive", "-Command",
+				"[Console]::InputEncoding = [Text.Encoding]::UTF8; Set-Clipboard -Value ([Console]::In.ReadToEnd())"}},
+			tool{name: "clip.exe", utf16: true})
+	}
+	if os.Getenv("WAYLAND_DISPLAY") != "" {
+		tools = append(tools, tool{name: "wl-copy"})
+	}
+	if os.Getenv("DISPLAY") != "" {
+		tools = append(tools,
+			tool{name: "xclip", args: []string{"-selection", "clipboard"}},
+			tool{name: "xsel", args: []string{"--clipboard", "--input"}})
+	}
+	// Termux on Android, with the Termux:API app
+	return append(tools, tool{name: "termux-clipboard-set"})
+}
+
+// pasteTools returns the tools that can read the clipboard, in order of
+// preference
+func pasteTools() []tool {
+	if runtime.GOOS == "darwin" {
+		return []tool{{name: "pbpaste"}}
+	}
+	var tools []tool
+	if isWSL() {
+		tools = append(tools,
+			tool{name: "win32yank.exe", args: []string{"-o", "--lf"}},
+			tool{name: "powershell.exe", args: []string{"-NoProfile", "-NonInteractive", "-Command",
+				"[Console]::OutputEncoding = [Text.Encoding]::UTF8; Get-Clipboard -Raw"}, trimNewline: true})
+	}
+	if os.Getenv("WAYLAND_DISPLAY") != "" {
+		tools = append(tools, tool{name: "wl-paste", args: []string{"--no-newline"}})
+	}
+	if os.Getenv("DISPLAY") != "" {
+		tools = append(tools,
+			tool{name: "xclip", args: []string{"-selection", "clipboard", "-out"}},
+			tool{name: "xsel", args: []string{"--clipboard", "--output"}})
+	}
+	return append(tools, tool{name: "termux-clipboard-get"})
+}
+
+// copyPlatform copies text with the first of the copy tools that works
+func copyPlatform(text string) error {
+	_, err := runTools(copyTools(), &text)
+	return err
+}
+
+// readPlatform reads the clipboard with the paste counterpart of the copy
+// tools. Windows line endings are normalized.
+func readPlatform() (string, error) {
+	text, err := runTools(pasteTools(), nil)
+	if err != nil {
+		return "", err
+	}
+	return strings.ReplaceAll(text, "\r\n", "\n"), nil
+}
+
+// copyHTMLPlatform copies html as rich text with AppleScript on macOS,
+// PowerShell under WSL, or wl-copy or xclip, which offer only the HTML
+func copyHTMLPlatform(html, text string) error {
+	var tools []tool
+	input := html
+	if runtime.GOOS == "darwin" {
+		// The charset keeps non-ASCII text from being read as Latin-1
+		tools = []tool{{name: "osascript", args: []string{"-"}}}
+		input = fmt.Sprintf("set the clipboard to {«class HTML»:«data HTML%X», «class utf8»:«data utf8%X»}",
+			`<meta charset="utf-8">`+html, text)
+	} else {
+		if isWSL() {
+			tools = append(tools, tool{name: "powershell.exe", args: []string{"-NoProfile", "-NonInteractive", "-Command",
+				"[Console]::InputEncoding = [Text.Encoding]::UTF8; Set-Clipboard -AsHtml -Value ([Console]::In.ReadToEnd())"}})
+		}
+		if os.Getenv("WAYLAND_DISPLAY") != "" {
+			tools = append(tools, tool{name: "wl-copy", args: []string{"--type", "text/html"}})
+		}
+		if os.Getenv("DISPLAY") != "" {
+			tools = append(tools, tool{name: "xclip", args: []string{"-selection", "clipboard", "-t", "text/html"}})
+		}
+	}
+
+	_, err := runTools(tools, &input)
+	var clipErr *ClipboardError
+	if errors.As(err, &clipErr) {
+		return ErrRichTextUnsupported
+	}
+	return err
+}
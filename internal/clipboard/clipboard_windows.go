@@ -0,0 +1,183 @@
+//go:build windows
+
+package clipboard
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"time"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+const (
+	cfUnicodeText = 13     // CF_UNICODETEXT
+	gmemMoveable  = 0x0002 // GMEM_MOVEABLE
+)
+
+var (
+	user32                      = windows.NewLazySystemDLL("user32.dll")
+	procOpenClipboard           = user32.NewProc("OpenClipboard")
+	procCloseClipboard          = user32.NewProc("CloseClipboard")
+	procEmptyClipboard          = user32.NewProc("EmptyClipboard")
+	procGetClipboardData        = user32.NewProc("GetClipboardData")
+	procSetClipboardData        = user32.NewProc("SetClipboardData")
+	procIsClipboardFormatAvail  = user32.NewProc("IsClipboardFormatAvailable")
+	procRegisterClipboardFormat = user32.NewProc("RegisterClipboardFormatW")
+
+	kernel32          = windows.NewLazySystemDLL("kernel32.dll")
+	procGlobalAlloc   = kernel32.NewProc("GlobalAlloc")
+	procGlobalFree    = kernel32.NewProc("GlobalFree")
+	procGlobalLock    = kernel32.NewProc("GlobalLock")
+	procGlobalUnlock  = kernel32.NewProc("GlobalUnlock")
+	procLstrlenW      = kernel32.NewProc("lstrlenW")
+	procRtlMoveMemory = kernel32.NewProc("RtlMoveMemory")
+)
+
+// copyTools returns no tools: Windows has its clipboard built in
+func copyTools() []tool {
+	return nil
+}
+
+// openClipboard opens the clipboard, waiting briefly while another program
+// has it open. The returned function closes it.
+func openClipboard() (func(), error) {
+	runtime.LockOSThread()
+	var err error
+	for i := 0; i < 10; i++ {
+		var r uintptr
+		if r, _, err = procOpenClipboard.Call(0); r != 0 {
+			return func() {
+				procCloseClipboard.Call()
+				runtime.UnlockOSThread()
+			}, nil
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	runtime.UnlockOSThread()
+	return nil, fmt.Errorf("failed to open the clipboard: %w", err)
+}
+
+// clipboardData is the content of the clipboard in one format
+type clipboardData struct {
+	format uintptr
+	data   []byte
+}
+
+// copyPlatform puts text on the Windows clipboard as Unicode text
+func copyPlatform(text string) error {
+	data, err := unicodeText(text)
+	if err != nil {
+		return err
+	}
+	return writeClipboard(data)
+}
+
+// copyHTMLPlatform puts html on the Windows clipboard in the HTML Format,
+// with text as Unicode text for plain text pastes
+func copyHTMLPlatform(html, text string) error {
+	format, _, err := procRegisterClipboardFormat.Call(uintptr(unsafe.Pointer(windows.StringToUTF16Ptr("HTML Format"))))
+	if format == 0 {
+		return fmt.Errorf("failed to register the HTML clipboard format: %w", err)
+	}
+	plain, err := unicodeText(text)
+	if err != nil {
+		return err
+	}
+	return writeClipboard(clipboardData{format: format, data: cfHTML(html)}, plain)
+}
+
+// unicodeText encodes text as NUL-terminated UTF-16 with Windows line
+// endings
+func unicodeText(text string) (clipboardData, error) {
+	units, err := windows.UTF16FromString(strings.ReplaceAll(text, "\n", "\r\n"))
+	if err != nil {
+		return clipboardData{}, fmt.Errorf("text contains a NUL character: %w", err)
+	}
+	data := unsafe.Slice((*byte)(unsafe.Pointer(&units[0])), len(units)*2)
+	return clipboardData{format: cfUnicodeText, data: data}, nil
+}
+
+// cfHTML wraps an HTML fragment in the header of the Windows HTML Format,
+// which gives the byte offsets of the document and the fragment
+func cfHTML(fragment string) []byte {
+	const header = "Version:0.9\r\nStartHTML:%010d\r\nEndHTML:%010d\r\nStartFragment:%010d\r\nEndFragment:%010d\r\n"
+	const prefix = "<html><body>\r\n<!--StartFragment-->"
+	const suffix = "<!--EndFragment-->\r\n</body></html>"
+
+	startHTML := len(fmt.Sprintf(header, 0, 0, 0, 0))
+	startFragment := startHTML + len(prefix)
+	endFragment := startFragment + len(fragment)
+	endHTML := endFragment + len(suffix)
+	doc := fmt.Sprintf(header, startHTML, endHTML, startFragment, endFragment) + prefix + fragment + suffix
+	return append([]byte(doc), 0)
+}
+
+// writeClipboard replaces the clipboard with data in one or more formats
+func writeClipboard(items ...clipboardData) error {
+	closeClipboard, err := openClipboard()
+	if err != nil {
+		return err
+	}
+	defer closeClipboard()
+
+	if r, _, err := procEmptyClipboard.Call(); r == 0 {
+		return fmt.Errorf("failed to empty the clipboard: %w", err)
+	}
+
+	for _, item := range items {
+		size := uintptr(len(item.data))
+		handle, _, err := procGlobalAlloc.Call(gmemMoveable, size)
+		if handle == 0 {
+			return fmt.Errorf("failed to allocate clipboard memory: %w", err)
+		}
+		ptr, _, err := procGlobalLock.Call(handle)
+		if ptr == 0 {
+			procGlobalFree.Call(handle)
+			return fmt.Errorf("failed to lock clipboard memory: %w", err)
+		}
+		procRtlMoveMemory.Call(ptr, uintptr(unsafe.Pointer(&item.data[0])), size)
+		procGlobalUnlock.Call(handle)
+
+		// The clipboard owns the memory once it is set
+		if r, _, err := procSetClipboardData.Call(item.format, handle); r == 0 {
+			procGlobalFree.Call(handle)
+			return fmt.Errorf("failed to set clipboard data: %w", err)
+		}
+	}
+	return nil
+}
+
+// readPlatform returns the Unicode text on the Windows clipboard, with
+// line endings normalized
+func readPlatform() (string, error) {
+	if r, _, _ := procIsClipboardFormatAvail.Call(cfUnicodeText); r == 0 {
+		return "", nil
+	}
+
+	closeClipboard, err := openClipboard()
+	if err != nil {
+		return "", err
+	}
+	defer closeClipboard()
+
+	handle, _, err := procGetClipboardData.Call(cfUnicodeText)
+	if handle == 0 {
+		return "", fmt.Errorf("failed to read clipboard data: %w", err)
+	}
+	ptr, _, err := procGlobalLock.Call(handle)
+	if ptr == 0 {
+		return "", fmt.Errorf("failed to lock clipboard memory: %w", err)
+	}
+	defer procGlobalUnlock.Call(handle)
+
+	n, _, _ := procLstrlenW.Call(ptr)
+	if n == 0 {
+		return "", nil
+	}
+	data := make([]uint16, n)
+	procRtlMoveMemory.Call(uintptr(unsafe.Pointer(&data[0])), ptr, n*2)
+	return strings.ReplaceAll(windows.UTF16ToString(data), "\r\n", "\n"), nil
+}
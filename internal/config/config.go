@@ -0,0 +1,240 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+const configFile = "config.yaml"
+
+// Config holds library-level settings read from config.yaml in the library root
+type Config struct {
+	Storage    StorageConfig    `yaml:"storage,omitempty"`
+	Encryption EncryptionConfig `yaml:"encryption,omitempty"`
+	Secrets    SecretsConfig    `yaml:"secrets,omitempty"`
+	Backup     BackupConfig     `yaml:"backup,omitempty"`
+	Tags       TagsConfig       `yaml:"tags,omitempty"`
+	Search     SearchConfig     `yaml:"search,omitempty"`
+	Git        GitConfig        `yaml:"git,omitempty"`
+	Sync       SyncConfig       `yaml:"sync,omitempty"`
+	Clipboard  ClipboardConfig  `yaml:"clipboard,omitempty"`
+	UI         UIConfig         `yaml:"ui,omitempty"`
+	// Locale selects the translation of the TUI and CLI in the library's
+	// locales directory, e.g. "de" or "pt_BR". When empty it comes from
+	// LANG; POCKET_PROMPT_LANG overrides it on one device.
+	Locale string `yaml:"locale,omitempty"`
+}
+
+// StorageConfig selects and configures the storage backend
+type StorageConfig struct {
+	// Backend is one of "files" (default), "sqlite", "s3" or "webdav"
+	Backend string `yaml:"backend,omitempty"`
+	// Frontmatter is the format new prompt and template files are written
+	// in: "yaml" (default), "toml" or "json". Existing files keep theirs.
+	Frontmatter string       `yaml:"frontmatter,omitempty"`
+	SQLite      SQLiteConfig `yaml:"sqlite,omitempty"`
+	S3          S3Config     `yaml:"s3,omitempty"`
+	WebDAV      WebDAVConfig `yaml:"webdav,omitempty"`
+}
+
+// SQLiteConfig configures the SQLite storage backend
+type SQLiteConfig struct {
+	// Path to the database file, relative to the library root
+	Path string `yaml:"path,omitempty"`
+}
+
+// S3Config configures the S3-compatible storage backend
+type S3Config struct {
+	// Endpoint defaults to AWS (https://s3.<region>.amazonaws.com); set it
+	// for MinIO, R2 and other S3-compatible services
+	Endpoint string `yaml:"endpoint,omitempty"`
+	Region   string `yaml:"region,omitempty"`
+	Bucket   string `yaml:"bucket,omitempty"`
+	// Prefix places the library under a key prefix inside the bucket
+	Prefix string `yaml:"prefix,omitempty"`
+	// Credentials fall back to AWS_ACCESS_KEY_ID / AWS_SECRET_ACCESS_KEY
+	AccessKeyID     string `yaml:"access_key_id,omitempty"`
+	SecretAccessKey string `yaml:"secret_access_key,omitempty"`
+	// PathStyle addresses the bucket as <endpoint>/<bucket> instead of a subdomain
+	PathStyle bool `yaml:"path_style,omitempty"`
+}
+
+// WebDAVConfig configures the WebDAV storage backend
+type WebDAVConfig struct {
+	// URL of the collection holding the library, e.g.
+	// https://cloud.example.com/remote.php/dav/files/me/pocket-prompt
+	URL      string `yaml:"url,omitempty"`
+	Username string `yaml:"username,omitempty"`
+	// Password falls back to POCKET_PROMPT_WEBDAV_PASSWORD; prefer an app password
+	Password string `yaml:"password,omitempty"`
+}
+
+// EncryptionConfig configures encryption of prompt bodies marked `encrypted: true`
+type EncryptionConfig struct {
+	// Tool is "age" or "gpg"
+	Tool string `yaml:"tool,omitempty"`
+	// Recipients are age public keys or gpg key IDs/emails
+	Recipients []string `yaml:"recipients,omitempty"`
+	// Identity is the age identity file used for decryption; falls back to
+	// POCKET_PROMPT_AGE_IDENTITY. gpg uses gpg-agent instead.
+	Identity string `yaml:"identity,omitempty"`
+}
+
+// SecretsConfig selects where the values of secret variables are kept
+type SecretsConfig struct {
+	// Store is "keychain" (default), the macOS keychain or the Secret
+	// Service through secret-tool, or "file", a file encrypted with the
+	// encryption settings
+	Store string `yaml:"store,omitempty"`
+	// File is the secrets file, relative to the library root (default
+	// .pocket-prompt/secrets.enc)
+	File string `yaml:"file,omitempty"`
+}
+
+// BackupConfig configures the archives written by `pocket-prompt backup`
+type BackupConfig struct {
+	// Dir holds the archives; relative paths are resolved against the
+	// library root (default .pocket-prompt/backups)
+	Dir string `yaml:"dir,omitempty"`
+	// Keep is the number of archives kept; older ones are removed (default 10)
+	Keep int `yaml:"keep,omitempty"`
+	// Auto backs the library up before destructive operations such as
+	// import --overwrite
+	Auto bool `yaml:"auto,omitempty"`
+}
+
+// TagsConfig configures how tags are displayed
+type TagsConfig struct {
+	// Colors maps tags to the colors of their badges in the TUI, as ANSI
+	// numbers ("33") or hex values ("#ff8800"). Tags beneath a colored tag,
+	// such as ai/agents beneath ai, inherit its color.
+	Colors map[string]string `yaml:"colors,omitempty"`
+}
+
+// SearchConfig configures the order of search results
+type SearchConfig struct {
+	// Sort is the default order: "relevance" (default), "updated" or "usage"
+	Sort    string        `yaml:"sort,omitempty"`
+	Weights SearchWeights `yaml:"weights,omitempty"`
+}
+
+// SearchWeights blend signals into the relevance order. When all are zero,
+// results are ranked by fuzzy match score alone.
+type SearchWeights struct {
+	// Relevance weighs how well the text matches the query
+	Relevance float64 `yaml:"relevance,omitempty"`
+	// Recency weighs how recently a prompt was updated
+	Recency float64 `yaml:"recency,omitempty"`
+	// Usage weighs how often a prompt has been copied or rendered
+	Usage float64 `yaml:"usage,omitempty"`
+}
+
+// GitConfig configures git sync
+type GitConfig struct {
+	// CommitMessage is the template for commit messages. {message} is
+	// replaced by a description of the change, such as "Update prompt
+	// code-review to v1.0.3", and {timestamp} by the time of the commit
+	// (default "{message} - {timestamp}").
+	CommitMessage string `yaml:"commit_message,omitempty"`
+	// SSHKey is the private key used for SSH remotes instead of the keys
+	// ssh picks itself, e.g. ~/.ssh/pocket_prompt
+	SSHKey string `yaml:"ssh_key,omitempty"`
+	// Username is sent with the token on HTTPS remotes (default
+	// x-access-token, which GitHub accepts; GitLab expects oauth2)
+	Username string `yaml:"username,omitempty"`
+	// TokenEnv names the environment variable holding a token for HTTPS
+	// remotes (default POCKET_PROMPT_GIT_TOKEN). Tokens are never read from
+	// this file, which is synced with the library.
+	TokenEnv string `yaml:"token_env,omitempty"`
+	// TokenCommand prints the token when the variable is unset, e.g.
+	// "security find-generic-password -s pocket-prompt -w" for the macOS
+	// keychain or "secret-tool lookup service pocket-prompt" on Linux
+	TokenCommand string `yaml:"token_command,omitempty"`
+	// DraftBranch receives the commits of prompts marked as drafts until
+	// they are published (default "drafts")
+	DraftBranch string `yaml:"draft_branch,omitempty"`
+}
+
+// SyncConfig selects how the URL server keeps the library in sync
+type SyncConfig struct {
+	// Provider is "git" (default), "rsync" or "rclone"
+	Provider string `yaml:"provider,omitempty"`
+	// Target is the rsync destination (a path or host:path) or the rclone
+	// remote (remote:path) holding the library
+	Target string `yaml:"target,omitempty"`
+	// Direction is "both" (default), "pull" or "push"
+	Direction string `yaml:"direction,omitempty"`
+	// Args are extra arguments passed to rsync or rclone
+	Args []string `yaml:"args,omitempty"`
+}
+
+// ClipboardConfig replaces the detected clipboard tools with commands run
+// through the shell. Since this file is synced with the library, the
+// POCKET_PROMPT_CLIPBOARD_COPY and POCKET_PROMPT_CLIPBOARD_PASTE variables
+// take precedence on a single device.
+type ClipboardConfig struct {
+	// CopyCommand reads the text to copy from stdin, e.g. "clip.exe" or
+	// "termux-clipboard-set"
+	CopyCommand string `yaml:"copy_command,omitempty"`
+	// PasteCommand prints the clipboard, e.g. "termux-clipboard-get"
+	PasteCommand string `yaml:"paste_command,omitempty"`
+}
+
+// UIConfig holds TUI layout preferences. The TUI saves them here when they
+// are changed with its keys.
+type UIConfig struct {
+	// HidePreview hides the preview pane beside the library list
+	HidePreview bool `yaml:"hide_preview,omitempty"`
+	// PreviewWidth is the preview pane's share of the width in percent
+	// (default 40)
+	PreviewWidth int `yaml:"preview_width,omitempty"`
+	// Theme is the palette: "light", "dark" or, by default, "auto" to
+	// follow the background the terminal reports
+	Theme string `yaml:"theme,omitempty"`
+}
+
+// Path returns the location of the config file for a library root
+func Path(baseDir string) string {
+	return filepath.Join(baseDir, configFile)
+}
+
+// Load reads the config file from the library root. A missing file yields
+// the default configuration.
+func Load(baseDir string) (*Config, error) {
+	cfg := &Config{}
+
+	data, err := os.ReadFile(Path(baseDir))
+	if os.IsNotExist(err) {
+		return cfg, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config file: %w", err)
+	}
+
+	return cfg, nil
+}
+
+// Save writes the config file to the library root
+func Save(baseDir string, cfg *Config) error {
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to marshal config: %w", err)
+	}
+
+	if err := os.MkdirAll(baseDir, 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	if err := os.WriteFile(Path(baseDir), data, 0644); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,124 @@
+package encryption
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/dpshade/pocket-prompt/internal/config"
+)
+
+// Armor headers written by the supported tools
+const (
+	gpgArmorHeader = "-----BEGIN PGP MESSAGE-----"
+	ageArmorHeader = "-----BEGIN AGE ENCRYPTED FILE-----"
+)
+
+// Encryptor encrypts and decrypts prompt bodies
+type Encryptor interface {
+	Encrypt(plaintext []byte) ([]byte, error)
+	Decrypt(ciphertext []byte) ([]byte, error)
+}
+
+// New returns the encryptor selected by the config, or nil when encryption
+// is not configured
+func New(cfg config.EncryptionConfig) (Encryptor, error) {
+	switch cfg.Tool {
+	case "":
+		return nil, nil
+	case "gpg":
+		if len(cfg.Recipients) == 0 {
+			return nil, fmt.Errorf("gpg encryption requires at least one recipient")
+		}
+		return &gpgEncryptor{recipients: cfg.Recipients}, nil
+	case "age":
+		if len(cfg.Recipients) == 0 {
+			return nil, fmt.Errorf("age encryption requires at least one recipient")
+		}
+		identity := cfg.Identity
+		if identity == "" {
+			identity = os.Getenv("POCKET_PROMPT_AGE_IDENTITY")
+		}
+		return &ageEncryptor{recipients: cfg.Recipients, identity: expandHome(identity)}, nil
+	default:
+		return nil, fmt.Errorf("unknown encryption tool: %s", cfg.Tool)
+	}
+}
+
+// IsEncrypted reports whether a body is an armored gpg or age message
+func IsEncrypted(body string) bool {
+	trimmed := strings.TrimSpace(body)
+	return strings.HasPrefix(trimmed, gpgArmorHeader) || strings.HasPrefix(trimmed, ageArmorHeader)
+}
+
+// gpgEncryptor shells out to gpg; passphrases are handled by gpg-agent
+type gpgEncryptor struct {
+	recipients []string
+}
+
+func (g *gpgEncryptor) Encrypt(plaintext []byte) ([]byte, error) {
+	args := []string{"--batch", "--yes", "--armor", "--trust-model", "always", "--encrypt"}
+	for _, r := range g.recipients {
+		args = append(args, "--recipient", r)
+	}
+	return run("gpg", args, plaintext)
+}
+
+func (g *gpgEncryptor) Decrypt(ciphertext []byte) ([]byte, error) {
+	return run("gpg", []string{"--batch", "--quiet", "--decrypt"}, ciphertext)
+}
+
+// ageEncryptor shells out to age; passphrase-protected identities prompt on the terminal
+type ageEncryptor struct {
+	recipients []string
+	identity   string
+}
+
+func (a *ageEncryptor) Encrypt(plaintext []byte) ([]byte, error) {
+	args := []string{"--encrypt", "--armor"}
+	for _, r := range a.recipients {
+		args = append(args, "--recipient", r)
+	}
+	return run("age", args, plaintext)
+}
+
+func (a *ageEncryptor) Decrypt(ciphertext []byte) ([]byte, error) {
+	if a.identity == "" {
+		return nil, fmt.Errorf("age decryption requires an identity file (encryption.identity or POCKET_PROMPT_AGE_IDENTITY)")
+	}
+	return run("age", []string{"--decrypt", "--identity", a.identity}, ciphertext)
+}
+
+// run executes tool with input on stdin and returns stdout
+func run(tool string, args []string, input []byte) ([]byte, error) {
+	if _, err := exec.LookPath(tool); err != nil {
+		return nil, fmt.Errorf("%s not found in PATH", tool)
+	}
+
+	cmd := exec.Command(tool, args...)
+	cmd.Stdin = bytes.NewReader(input)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			msg = err.Error()
+		}
+		return nil, fmt.Errorf("%s failed: %s", tool, msg)
+	}
+
+	return stdout.Bytes(), nil
+}
+
+func expandHome(path string) string {
+	if strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			return home + path[1:]
+		}
+	}
+	return path
+}
@@ -0,0 +1,176 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/dpshade/pocket-prompt/internal/models"
+)
+
+// untaggedHeading groups the prompts without tags in a catalog
+const untaggedHeading = "Untagged"
+
+// CatalogOptions configures a catalog
+type CatalogOptions struct {
+	// Title is the heading of the page, "Prompt Library" when empty
+	Title string
+	// LinkPrefix is joined to each prompt's file path in links, to make
+	// them relative to where the catalog is written, e.g. ".." for a
+	// catalog in docs/
+	LinkPrefix string
+}
+
+// WriteCatalog writes a markdown index of prompts grouped by tag, with
+// each prompt's title, description and a link to its file, for the front
+// page of the repository holding the library. The output depends only on
+// the prompts, so regenerating an unchanged library gives the same file.
+// Drafts are left out, as they live on another branch.
+func WriteCatalog(w io.Writer, prompts []*models.Prompt, options CatalogOptions) error {
+	title := options.Title
+	if title == "" {
+		title = "Prompt Library"
+	}
+
+	groups := make(map[string][]*models.Prompt)
+	count := 0
+	for _, p := range prompts {
+		if p.Draft {
+			continue
+		}
+		count++
+		if len(p.Tags) == 0 {
+			groups[untaggedHeading] = append(groups[untaggedHeading], p)
+			continue
+		}
+		for _, tag := range p.Tags {
+			groups[tag] = append(groups[tag], p)
+		}
+	}
+
+	headings := make([]string, 0, len(groups))
+	for heading := range groups {
+		headings = append(headings, heading)
+	}
+	sort.Slice(headings, func(i, j int) bool {
+		// Untagged prompts come last
+		if (headings[i] == untaggedHeading) != (headings[j] == untaggedHeading) {
+			return headings[j] == untaggedHeading
+		}
+		if li, lj := strings.ToLower(headings[i]), strings.ToLower(headings[j]); li != lj {
+			return li < lj
+		}
+		return headings[i] < headings[j]
+	})
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", title)
+	b.WriteString("<!-- Generated by pocket-prompt catalog; edits are overwritten. -->\n\n")
+	fmt.Fprintf(&b, "%s in %s.\n", plural(count, "prompt"), plural(len(headings), "group"))
+
+	if len(headings) > 0 {
+		anchors := newAnchors()
+		anchors.add(title)
+		b.WriteString("\n")
+		for _, heading := range headings {
+			fmt.Fprintf(&b, "- [%s](#%s) (%d)\n", escapeMarkdown(heading), anchors.add(heading), len(groups[heading]))
+		}
+
+		for _, heading := range headings {
+			group := groups[heading]
+			sort.SliceStable(group, func(i, j int) bool {
+				ti, tj := strings.ToLower(catalogTitle(group[i])), strings.ToLower(catalogTitle(group[j]))
+				if ti != tj {
+					return ti < tj
+				}
+				return group[i].ID < group[j].ID
+			})
+
+			fmt.Fprintf(&b, "\n## %s\n\n", escapeMarkdown(heading))
+			for _, p := range group {
+				fmt.Fprintf(&b, "- [%s](%s)", escapeMarkdown(catalogTitle(p)), catalogLink(p, options.LinkPrefix))
+				if summary := strings.Join(strings.Fields(p.Summary), " "); summary != "" {
+					fmt.Fprintf(&b, " - %s", escapeMarkdown(summary))
+				}
+				b.WriteString("\n")
+			}
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// catalogTitle is the title a prompt is listed under
+func catalogTitle(p *models.Prompt) string {
+	if title := strings.TrimSpace(p.Name); title != "" {
+		return title
+	}
+	return p.ID
+}
+
+// catalogLink is the URL of a prompt's file, with slashes on every platform
+// and the rest escaped so names with spaces still link
+func catalogLink(p *models.Prompt, prefix string) string {
+	target := path.Join(filepath.ToSlash(prefix), filepath.ToSlash(p.FilePath))
+	parts := strings.Split(target, "/")
+	for i, part := range parts {
+		parts[i] = url.PathEscape(part)
+	}
+	return strings.Join(parts, "/")
+}
+
+// escapeMarkdown escapes the characters that would turn text into links
+// or emphasis in a list item
+func escapeMarkdown(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if strings.ContainsRune("\\`*_[]<>|", r) {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// plural counts a noun
+func plural(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("1 %s", noun)
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
+
+// anchors makes the fragment IDs GitHub gives headings: lower case, spaces
+// as hyphens, other punctuation dropped, and repeats numbered
+type anchors map[string]int
+
+// newAnchors starts the fragment IDs of a page
+func newAnchors() anchors {
+	return make(anchors)
+}
+
+// add returns the fragment ID of the next heading
+func (a anchors) add(heading string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(heading) {
+		switch {
+		case unicode.IsLetter(r), unicode.IsDigit(r), r == '-', r == '_':
+			b.WriteRune(r)
+		case r == ' ':
+			b.WriteByte('-')
+		}
+	}
+	id := b.String()
+	n := a[id]
+	a[id] = n + 1
+	if n > 0 {
+		return fmt.Sprintf("%s-%d", id, n)
+	}
+	return id
+}
@@ -0,0 +1,53 @@
+package export
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/dpshade/pocket-prompt/internal/models"
+)
+
+func TestWriteCatalog(t *testing.T) {
+	prompts := []*models.Prompt{
+		{ID: "review", Name: "Code Review", Summary: "Review a diff\n  for bugs", Tags: []string{"code", "eval"}, FilePath: "prompts/review.md"},
+		{ID: "team/launch", Name: "Launch [v2]", Tags: []string{"Code"}, FilePath: "prompts/team/launch notes.md"},
+		{ID: "scratch", FilePath: "prompts/scratch.md"},
+		{ID: "wip", Name: "WIP", Tags: []string{"code"}, Draft: true, FilePath: "prompts/wip.md"},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteCatalog(&buf, prompts, CatalogOptions{Title: "Team Prompts", LinkPrefix: ".."}); err != nil {
+		t.Fatalf("WriteCatalog failed: %v", err)
+	}
+
+	want := `# Team Prompts
+
+<!-- Generated by pocket-prompt catalog; edits are overwritten. -->
+
+3 prompts in 4 groups.
+
+- [Code](#code) (1)
+- [code](#code-1) (1)
+- [eval](#eval) (1)
+- [Untagged](#untagged) (1)
+
+## Code
+
+- [Launch \[v2\]](../prompts/team/launch%20notes.md)
+
+## code
+
+- [Code Review](../prompts/review.md) - Review a diff for bugs
+
+## eval
+
+- [Code Review](../prompts/review.md) - Review a diff for bugs
+
+## Untagged
+
+- [scratch](../prompts/scratch.md)
+`
+	if buf.String() != want {
+		t.Errorf("Unexpected catalog:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
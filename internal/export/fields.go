@@ -0,0 +1,69 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/dpshade/pocket-prompt/internal/models"
+)
+
+// ParseFields splits a comma separated --field value and checks every
+// field is known. The fields are those of a JSONL export, without renames.
+func ParseFields(spec string) ([]string, error) {
+	var fields []string
+	for _, f := range strings.Split(spec, ",") {
+		f = strings.TrimSpace(f)
+		if f == "" {
+			continue
+		}
+		if _, ok := fieldValue(&models.Prompt{}, f); !ok {
+			return nil, unknownField(f)
+		}
+		fields = append(fields, f)
+	}
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("no fields given")
+	}
+	return fields, nil
+}
+
+// WriteFields writes fields of a prompt as bare text for scripts, in the
+// order given, each ending in a newline. Tags are joined with commas, or one
+// per line when they are the only field, and maps are written as YAML. A
+// field without a value is an empty line. Content, notes and maps can span
+// several lines, so only single-line fields are one line each.
+func WriteFields(w io.Writer, prompt *models.Prompt, fields []string) error {
+	var b strings.Builder
+	for _, name := range fields {
+		value, _ := fieldValue(prompt, name)
+		var text string
+		switch v := value.(type) {
+		case nil:
+		case string:
+			text = v
+		case []string:
+			separator := ", "
+			if len(fields) == 1 {
+				separator = "\n"
+			}
+			text = strings.Join(v, separator)
+		case map[string]interface{}:
+			if len(v) > 0 {
+				data, err := yaml.Marshal(v)
+				if err != nil {
+					return fmt.Errorf("failed to write %s: %w", name, err)
+				}
+				text = string(data)
+			}
+		default:
+			text = fmt.Sprint(v)
+		}
+		b.WriteString(strings.TrimSuffix(text, "\n"))
+		b.WriteString("\n")
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,54 @@
+package export
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/dpshade/pocket-prompt/internal/models"
+)
+
+func TestWriteFields(t *testing.T) {
+	prompt := &models.Prompt{
+		ID:          "review",
+		Name:        "Code Review",
+		Content:     "Review this diff.\n\nBe brief.\n",
+		Tags:        []string{"code", "review"},
+		Metadata:    map[string]interface{}{"owner": "eng"},
+		TemplateRef: "checklist",
+		Rating:      4,
+		Notes:       "Works best on small diffs",
+		Draft:       true,
+	}
+
+	tests := []struct {
+		spec string
+		want string
+	}{
+		{"content", "Review this diff.\n\nBe brief.\n"},
+		{"tags", "code\nreview\n"},
+		{"title, tags", "Code Review\ncode, review\n"},
+		{"metadata.owner,description,id", "eng\n\nreview\n"},
+		{"metadata", "owner: eng\n"},
+		{"template,rating,notes,draft", "checklist\n4\nWorks best on small diffs\ntrue\n"},
+	}
+	for _, tt := range tests {
+		fields, err := ParseFields(tt.spec)
+		if err != nil {
+			t.Fatalf("ParseFields(%q) failed: %v", tt.spec, err)
+		}
+		var buf bytes.Buffer
+		if err := WriteFields(&buf, prompt, fields); err != nil {
+			t.Fatalf("WriteFields(%q) failed: %v", tt.spec, err)
+		}
+		if buf.String() != tt.want {
+			t.Errorf("WriteFields(%q) = %q, want %q", tt.spec, buf.String(), tt.want)
+		}
+	}
+
+	if _, err := ParseFields("title,body"); err == nil {
+		t.Error("Expected an unknown field to be rejected")
+	}
+	if _, err := ParseFields("content=input"); err == nil {
+		t.Error("Expected a renamed field to be rejected")
+	}
+}
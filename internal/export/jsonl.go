@@ -0,0 +1,225 @@
+// Package export writes prompts in formats consumed by other tools
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/dpshade/pocket-prompt/internal/models"
+)
+
+// JSONL schemas
+const (
+	// SchemaChat writes OpenAI chat fine-tuning records:
+	// {"messages": [{"role": "system", ...}, {"role": "user", ...}, {"role": "assistant", ...}]}
+	SchemaChat = "chat"
+	// SchemaCompletion writes {"prompt": ..., "completion": ...} records
+	SchemaCompletion = "completion"
+	// SchemaFields writes the fields selected with JSONLOptions.Fields
+	SchemaFields = "fields"
+)
+
+// DefaultJSONLFields are written by SchemaFields when no fields are selected
+var DefaultJSONLFields = []string{"id", "title", "description", "content", "tags", "version"}
+
+// responseKeys are the metadata keys holding an expected response, used
+// for the assistant message and completion
+var responseKeys = []string{"completion", "response", "expected_output", "ideal"}
+
+// JSONLOptions configures a JSONL export
+type JSONLOptions struct {
+	Schema string
+	// System is the system message for SchemaChat. When empty, a prompt's
+	// "system" metadata is used.
+	System string
+	// Fields selects the keys written by SchemaFields as "field" or
+	// "field=key" to rename it, e.g. "content=input". Metadata values are
+	// selected as "metadata.name".
+	Fields []string
+}
+
+// ParseJSONLFields splits a comma separated --fields value and checks
+// every field is known
+func ParseJSONLFields(spec string) ([]string, error) {
+	var fields []string
+	for _, f := range strings.Split(spec, ",") {
+		f = strings.TrimSpace(f)
+		if f == "" {
+			continue
+		}
+		name, _, _ := strings.Cut(f, "=")
+		if _, ok := fieldValue(&models.Prompt{}, name); !ok {
+			return nil, unknownField(name)
+		}
+		fields = append(fields, f)
+	}
+	return fields, nil
+}
+
+// WriteJSONL writes one JSON object per prompt. Prompts must have their
+// content loaded.
+func WriteJSONL(w io.Writer, prompts []*models.Prompt, options JSONLOptions) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+
+	for _, prompt := range prompts {
+		var record interface{}
+		switch options.Schema {
+		case "", SchemaChat:
+			record = chatRecord(prompt, options.System)
+		case SchemaCompletion:
+			record = map[string]string{
+				"prompt":     prompt.Content,
+				"completion": metadataString(prompt, responseKeys...),
+			}
+		case SchemaFields:
+			fields := options.Fields
+			if len(fields) == 0 {
+				fields = DefaultJSONLFields
+			}
+			record = fieldsRecord(prompt, fields)
+		default:
+			return fmt.Errorf("unknown JSONL schema: %s (use chat, completion or fields)", options.Schema)
+		}
+
+		if err := encoder.Encode(record); err != nil {
+			return fmt.Errorf("failed to write prompt %s: %w", prompt.ID, err)
+		}
+	}
+	return nil
+}
+
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+func chatRecord(prompt *models.Prompt, system string) map[string][]chatMessage {
+	var messages []chatMessage
+	if system == "" {
+		system = metadataString(prompt, "system")
+	}
+	if system != "" {
+		messages = append(messages, chatMessage{Role: "system", Content: system})
+	}
+	messages = append(messages, chatMessage{Role: "user", Content: prompt.Content})
+	if response := metadataString(prompt, responseKeys...); response != "" {
+		messages = append(messages, chatMessage{Role: "assistant", Content: response})
+	}
+	return map[string][]chatMessage{"messages": messages}
+}
+
+// fieldsRecord builds an object with the selected fields in order
+func fieldsRecord(prompt *models.Prompt, fields []string) orderedRecord {
+	record := make(orderedRecord, 0, len(fields))
+	for _, f := range fields {
+		name, key, renamed := strings.Cut(f, "=")
+		if !renamed {
+			key = name
+		}
+		value, _ := fieldValue(prompt, name)
+		record = append(record, recordField{key, value})
+	}
+	return record
+}
+
+// unknownField reports a field that can't be selected
+func unknownField(name string) error {
+	return fmt.Errorf("unknown field %q (valid fields: id, title, description, content, tags, version, namespace, template, rating, notes, draft, created_at, updated_at, metadata, metadata.<key>)", name)
+}
+
+// fieldValue returns the value of a selectable prompt field
+func fieldValue(prompt *models.Prompt, name string) (interface{}, bool) {
+	if key, ok := strings.CutPrefix(name, "metadata."); ok && key != "" {
+		return prompt.Metadata[key], true
+	}
+	switch name {
+	case "id":
+		return prompt.ID, true
+	case "title", "name":
+		return prompt.Name, true
+	case "description", "summary":
+		return prompt.Summary, true
+	case "content":
+		return prompt.Content, true
+	case "tags":
+		if prompt.Tags == nil {
+			return []string{}, true
+		}
+		return prompt.Tags, true
+	case "version":
+		return prompt.Version, true
+	case "namespace":
+		return prompt.Namespace(), true
+	case "template":
+		return prompt.TemplateRef, true
+	case "rating":
+		return prompt.Rating, true
+	case "notes":
+		return prompt.Notes, true
+	case "draft":
+		return prompt.Draft, true
+	case "created_at":
+		return formatTime(prompt.CreatedAt), true
+	case "updated_at":
+		return formatTime(prompt.UpdatedAt), true
+	case "metadata":
+		return prompt.Metadata, true
+	}
+	return nil, false
+}
+
+func metadataString(prompt *models.Prompt, keys ...string) string {
+	for _, key := range keys {
+		if s, ok := prompt.Metadata[key].(string); ok && s != "" {
+			return s
+		}
+	}
+	return ""
+}
+
+func formatTime(t time.Time) interface{} {
+	if t.IsZero() {
+		return nil
+	}
+	return t.Format(time.RFC3339)
+}
+
+// orderedRecord marshals as a JSON object keeping the field order
+type orderedRecord []recordField
+
+type recordField struct {
+	key   string
+	value interface{}
+}
+
+func (r orderedRecord) MarshalJSON() ([]byte, error) {
+	// Encode without HTML escaping, like the rest of the line
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i, field := range r {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		for j, v := range []interface{}{field.key, field.value} {
+			buf.Reset()
+			if err := encoder.Encode(v); err != nil {
+				return nil, err
+			}
+			if j == 1 {
+				b.WriteByte(':')
+			}
+			b.Write(bytes.TrimSuffix(buf.Bytes(), []byte("\n")))
+		}
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
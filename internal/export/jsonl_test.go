@@ -0,0 +1,74 @@
+package export
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/dpshade/pocket-prompt/internal/models"
+)
+
+func testPrompts() []*models.Prompt {
+	return []*models.Prompt{
+		{
+			ID:       "team/review",
+			Name:     "Review",
+			Content:  "Review <diff>",
+			Tags:     []string{"eval"},
+			Metadata: map[string]interface{}{"system": "You are terse.", "ideal": "LGTM"},
+		},
+		{ID: "plain", Name: "Plain", Content: "Hello"},
+	}
+}
+
+func TestWriteJSONLChat(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteJSONL(&buf, testPrompts(), JSONLOptions{}); err != nil {
+		t.Fatalf("WriteJSONL failed: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	want := []string{
+		`{"messages":[{"role":"system","content":"You are terse."},{"role":"user","content":"Review <diff>"},{"role":"assistant","content":"LGTM"}]}`,
+		`{"messages":[{"role":"user","content":"Hello"}]}`,
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("Expected %d lines, got:\n%s", len(want), buf.String())
+	}
+	for i := range want {
+		if lines[i] != want[i] {
+			t.Errorf("Line %d:\n got %s\nwant %s", i+1, lines[i], want[i])
+		}
+	}
+
+	buf.Reset()
+	if err := WriteJSONL(&buf, testPrompts()[1:], JSONLOptions{System: "Override"}); err != nil {
+		t.Fatalf("WriteJSONL failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), `{"role":"system","content":"Override"}`) {
+		t.Errorf("Expected system override, got %s", buf.String())
+	}
+}
+
+func TestWriteJSONLFields(t *testing.T) {
+	fields, err := ParseJSONLFields("id,content=input,metadata.ideal=expected,namespace,tags")
+	if err != nil {
+		t.Fatalf("ParseJSONLFields failed: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteJSONL(&buf, testPrompts()[:1], JSONLOptions{Schema: SchemaFields, Fields: fields}); err != nil {
+		t.Fatalf("WriteJSONL failed: %v", err)
+	}
+	want := `{"id":"team/review","input":"Review <diff>","expected":"LGTM","namespace":"team","tags":["eval"]}`
+	if got := strings.TrimSpace(buf.String()); got != want {
+		t.Errorf("Unexpected record:\n got %s\nwant %s", got, want)
+	}
+
+	if _, err := ParseJSONLFields("id,colour"); err == nil {
+		t.Error("Expected an error for an unknown field")
+	}
+	if err := WriteJSONL(&buf, testPrompts(), JSONLOptions{Schema: "xml"}); err == nil {
+		t.Error("Expected an error for an unknown schema")
+	}
+}
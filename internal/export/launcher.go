@@ -0,0 +1,91 @@
+package export
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/dpshade/pocket-prompt/internal/models"
+)
+
+// alfredItem is an item of an Alfred Script Filter result
+type alfredItem struct {
+	UID          string            `json:"uid"`
+	Title        string            `json:"title"`
+	Subtitle     string            `json:"subtitle"`
+	Arg          string            `json:"arg"`
+	Autocomplete string            `json:"autocomplete"`
+	Match        string            `json:"match"`
+	Variables    map[string]string `json:"variables"`
+}
+
+// raycastItem is an item of a list for a Raycast script or extension
+type raycastItem struct {
+	ID        string            `json:"id"`
+	Title     string            `json:"title"`
+	Subtitle  string            `json:"subtitle"`
+	Arg       string            `json:"arg"`
+	Keywords  []string          `json:"keywords"`
+	Variables map[string]string `json:"variables"`
+}
+
+// WriteAlfred writes prompts as the JSON an Alfred Script Filter returns,
+// {"items": [...]}. Each item's arg is the prompt ID, to pass on to
+// pocket-prompt copy or render.
+func WriteAlfred(w io.Writer, prompts []*models.Prompt) error {
+	items := make([]alfredItem, 0, len(prompts))
+	for _, p := range prompts {
+		items = append(items, alfredItem{
+			UID:          p.ID,
+			Title:        launcherTitle(p),
+			Subtitle:     launcherSubtitle(p),
+			Arg:          p.ID,
+			Autocomplete: launcherTitle(p),
+			Match:        strings.Join(strings.Fields(strings.Join(append([]string{p.Name, p.ID, p.Summary}, p.Tags...), " ")), " "),
+			Variables:    launcherVariables(p),
+		})
+	}
+	return json.NewEncoder(w).Encode(map[string][]alfredItem{"items": items})
+}
+
+// WriteRaycast writes prompts as {"items": [...]} with the fields a Raycast
+// list item takes, keywords being the prompt's ID and tags
+func WriteRaycast(w io.Writer, prompts []*models.Prompt) error {
+	items := make([]raycastItem, 0, len(prompts))
+	for _, p := range prompts {
+		items = append(items, raycastItem{
+			ID:        p.ID,
+			Title:     launcherTitle(p),
+			Subtitle:  launcherSubtitle(p),
+			Arg:       p.ID,
+			Keywords:  append([]string{p.ID}, p.Tags...),
+			Variables: launcherVariables(p),
+		})
+	}
+	return json.NewEncoder(w).Encode(map[string][]raycastItem{"items": items})
+}
+
+// launcherTitle is the prompt's title, or its ID without one
+func launcherTitle(p *models.Prompt) string {
+	if p.Name == "" {
+		return p.ID
+	}
+	return p.Name
+}
+
+// launcherSubtitle is the prompt's description, or its tags without one
+func launcherSubtitle(p *models.Prompt) string {
+	if p.Summary != "" {
+		return p.Summary
+	}
+	return strings.Join(p.Tags, ", ")
+}
+
+// launcherVariables are passed on to the next step of a launcher workflow
+func launcherVariables(p *models.Prompt) map[string]string {
+	return map[string]string{
+		"id":    p.ID,
+		"title": launcherTitle(p),
+		"tags":  strings.Join(p.Tags, ","),
+	}
+}
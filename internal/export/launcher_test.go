@@ -0,0 +1,44 @@
+package export
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/dpshade/pocket-prompt/internal/models"
+)
+
+func TestWriteAlfred(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteAlfred(&buf, testPrompts()); err != nil {
+		t.Fatalf("WriteAlfred failed: %v", err)
+	}
+
+	want := `{"items":[` +
+		`{"uid":"team/review","title":"Review","subtitle":"eval","arg":"team/review","autocomplete":"Review","match":"Review team/review eval","variables":{"id":"team/review","tags":"eval","title":"Review"}},` +
+		`{"uid":"plain","title":"Plain","subtitle":"","arg":"plain","autocomplete":"Plain","match":"Plain plain","variables":{"id":"plain","tags":"","title":"Plain"}}]}`
+	if got := strings.TrimSpace(buf.String()); got != want {
+		t.Errorf("Unexpected Alfred output:\n got %s\nwant %s", got, want)
+	}
+}
+
+func TestWriteRaycast(t *testing.T) {
+	var buf bytes.Buffer
+	prompts := []*models.Prompt{{ID: "untitled", Summary: "No title", Tags: []string{"a", "b"}}}
+	if err := WriteRaycast(&buf, prompts); err != nil {
+		t.Fatalf("WriteRaycast failed: %v", err)
+	}
+
+	want := `{"items":[{"id":"untitled","title":"untitled","subtitle":"No title","arg":"untitled","keywords":["untitled","a","b"],"variables":{"id":"untitled","tags":"a,b","title":"untitled"}}]}`
+	if got := strings.TrimSpace(buf.String()); got != want {
+		t.Errorf("Unexpected Raycast output:\n got %s\nwant %s", got, want)
+	}
+
+	buf.Reset()
+	if err := WriteRaycast(&buf, nil); err != nil {
+		t.Fatalf("WriteRaycast failed: %v", err)
+	}
+	if got := strings.TrimSpace(buf.String()); got != `{"items":[]}` {
+		t.Errorf("Expected an empty item list, got %s", got)
+	}
+}
@@ -0,0 +1,181 @@
+// Package gallery renders a prompt library as a static HTML site
+package gallery
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dpshade/pocket-prompt/internal/models"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+)
+
+// Options configures the generated site
+type Options struct {
+	Title string // Site title, shown on every page
+}
+
+// Generator writes a browsable static site: an index of all prompts, one
+// page per tag and one page per prompt with its rendered markdown
+type Generator struct {
+	outputDir string
+	options   Options
+	markdown  goldmark.Markdown
+}
+
+// NewGenerator creates a generator writing to outputDir
+func NewGenerator(outputDir string, options Options) *Generator {
+	if options.Title == "" {
+		options.Title = "Prompt Library"
+	}
+	return &Generator{
+		outputDir: outputDir,
+		options:   options,
+		// Raw HTML in prompts is omitted rather than passed through
+		markdown: goldmark.New(goldmark.WithExtensions(extension.GFM)),
+	}
+}
+
+// promptPage is a prompt as shown on the index, tag and prompt pages
+type promptPage struct {
+	*models.Prompt
+	Path     string // Site-relative path of the prompt page
+	HTML     template.HTML
+	TagPages []*tagPage
+}
+
+// tagPage groups the prompts carrying a tag
+type tagPage struct {
+	Name    string
+	Path    string
+	Prompts []*promptPage
+}
+
+// pageData is passed to every page template. Root is the relative path
+// back to the site root, e.g. "../../" for prompts/team/launch.html.
+type pageData struct {
+	Title     string
+	Root      string
+	Generated time.Time
+	Prompts   []*promptPage
+	Tags      []*tagPage
+	Tag       *tagPage
+	Prompt    *promptPage
+}
+
+var slugRe = regexp.MustCompile(`[^a-z0-9]+`)
+
+// Generate renders the site for prompts. Encrypted prompts are skipped so
+// their bodies are never published.
+func (g *Generator) Generate(prompts []*models.Prompt) (int, error) {
+	var pages []*promptPage
+	tags := make(map[string]*tagPage)
+	slugs := make(map[string]bool)
+	for _, p := range prompts {
+		if p.Encrypted {
+			continue
+		}
+		var buf bytes.Buffer
+		if err := g.markdown.Convert([]byte(p.Content), &buf); err != nil {
+			return 0, fmt.Errorf("failed to render prompt %s: %w", p.ID, err)
+		}
+		page := &promptPage{
+			Prompt: p,
+			Path:   "prompts/" + p.ID + ".html",
+			HTML:   template.HTML(buf.String()),
+		}
+		pages = append(pages, page)
+
+		for _, tag := range p.Tags {
+			tp, ok := tags[tag]
+			if !ok {
+				// Tags that differ only in punctuation or case get numbered pages
+				slug := tagSlug(tag)
+				for n := 2; slugs[slug]; n++ {
+					slug = fmt.Sprintf("%s-%d", tagSlug(tag), n)
+				}
+				slugs[slug] = true
+				tp = &tagPage{Name: tag, Path: "tags/" + slug + ".html"}
+				tags[tag] = tp
+			}
+			tp.Prompts = append(tp.Prompts, page)
+			page.TagPages = append(page.TagPages, tp)
+		}
+	}
+	sort.SliceStable(pages, func(i, j int) bool { return pages[i].ID < pages[j].ID })
+
+	tagList := make([]*tagPage, 0, len(tags))
+	for _, tp := range tags {
+		tagList = append(tagList, tp)
+	}
+	sort.Slice(tagList, func(i, j int) bool { return tagList[i].Name < tagList[j].Name })
+
+	data := pageData{
+		Title:     g.options.Title,
+		Generated: time.Now(),
+		Prompts:   pages,
+		Tags:      tagList,
+	}
+
+	if err := g.writeFile("style.css", []byte(styleCSS)); err != nil {
+		return 0, err
+	}
+	if err := g.render("index.html", "index", data); err != nil {
+		return 0, err
+	}
+	for _, tp := range tagList {
+		d := data
+		d.Tag = tp
+		d.Prompts = tp.Prompts
+		if err := g.render(tp.Path, "tag", d); err != nil {
+			return 0, err
+		}
+	}
+	for _, page := range pages {
+		d := data
+		d.Prompt = page
+		if err := g.render(page.Path, "prompt", d); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(pages), nil
+}
+
+// render executes a page template into a site-relative path
+func (g *Generator) render(path, name string, data pageData) error {
+	data.Root = strings.Repeat("../", strings.Count(path, "/"))
+
+	var buf bytes.Buffer
+	if err := pageTemplates.ExecuteTemplate(&buf, name, data); err != nil {
+		return fmt.Errorf("failed to render %s: %w", path, err)
+	}
+	return g.writeFile(path, buf.Bytes())
+}
+
+func (g *Generator) writeFile(path string, data []byte) error {
+	fullPath := filepath.Join(g.outputDir, filepath.FromSlash(path))
+	if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	if err := os.WriteFile(fullPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// tagSlug turns a tag into a file name; tags without ASCII letters or
+// digits fall back to a hex encoding so every tag gets a distinct page
+func tagSlug(tag string) string {
+	if slug := strings.Trim(slugRe.ReplaceAllString(strings.ToLower(tag), "-"), "-"); slug != "" {
+		return slug
+	}
+	return fmt.Sprintf("tag-%x", tag)
+}
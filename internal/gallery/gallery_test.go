@@ -0,0 +1,62 @@
+package gallery
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dpshade/pocket-prompt/internal/models"
+)
+
+func TestGenerate(t *testing.T) {
+	dir := t.TempDir()
+	prompts := []*models.Prompt{
+		{ID: "team/launch", Name: "Launch <beta>", Tags: []string{"marketing", "C++"}, Content: "# Plan\n\nAnnounce **{{product}}**\n\n<script>alert(1)</script>"},
+		{ID: "review", Name: "Review", Tags: []string{"c"}, Content: "Review the diff"},
+		{ID: "secret", Name: "Secret", Encrypted: true, Content: "ciphertext"},
+	}
+
+	count, err := NewGenerator(dir, Options{Title: "Team"}).Generate(prompts)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("Expected encrypted prompt skipped, got %d pages", count)
+	}
+
+	read := func(path string) string {
+		data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(path)))
+		if err != nil {
+			t.Fatalf("Expected %s: %v", path, err)
+		}
+		return string(data)
+	}
+
+	index := read("index.html")
+	if !strings.Contains(index, `href="prompts/team/launch.html"`) || strings.Contains(index, "Secret") {
+		t.Errorf("Unexpected index:\n%s", index)
+	}
+
+	page := read("prompts/team/launch.html")
+	for _, want := range []string{
+		`href="../../style.css"`,
+		`href="../../tags/marketing.html"`,
+		"<h1>Plan</h1>",
+		"<strong>{{product}}</strong>",
+		"Launch &lt;beta&gt;",
+		`id="copy"`,
+	} {
+		if !strings.Contains(page, want) {
+			t.Errorf("Expected %q in prompt page:\n%s", want, page)
+		}
+	}
+	if strings.Contains(page, "<script>alert(1)</script>") {
+		t.Error("Raw HTML in prompt content should not be passed through")
+	}
+
+	// "C++" and "c" share a slug and must not overwrite each other
+	if !strings.Contains(read("tags/c.html"), "Launch") || !strings.Contains(read("tags/c-2.html"), "Review") {
+		t.Error("Expected distinct pages for tags with the same slug")
+	}
+}
@@ -0,0 +1,92 @@
+package gallery
+
+import "html/template"
+
+var pageTemplates = template.Must(template.New("gallery").Parse(`
+{{define "header"}}<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{if .Prompt}}{{.Prompt.Name}} · {{else if .Tag}}#{{.Tag.Name}} · {{end}}{{.Title}}</title>
+<link rel="stylesheet" href="{{.Root}}style.css">
+</head>
+<body>
+<header><a class="home" href="{{.Root}}index.html">{{.Title}}</a></header>
+<main>
+{{end}}
+
+{{define "footer"}}</main>
+<footer>Generated {{.Generated.Format "2006-01-02 15:04"}} by pocket-prompt</footer>
+</body>
+</html>
+{{end}}
+
+{{define "tags"}}{{$root := .Root}}{{range .Tags}}<a class="tag" href="{{$root}}{{.Path}}">{{.Name}} <span>{{len .Prompts}}</span></a> {{end}}{{end}}
+
+{{define "list"}}<ul class="prompts">
+{{range .Prompts}}<li data-search="{{.ID}} {{.Name}} {{.Summary}} {{range .Tags}}{{.}} {{end}}">
+<a href="{{$.Root}}{{.Path}}">{{.Name}}</a> <code>{{.ID}}</code>
+{{if .Summary}}<p>{{.Summary}}</p>{{end}}
+</li>
+{{end}}</ul>
+{{end}}
+
+{{define "index"}}{{template "header" .}}
+<h1>{{.Title}}</h1>
+<input id="filter" type="search" placeholder="Filter {{len .Prompts}} prompts…" autofocus>
+<section class="tag-index">{{template "tags" .}}</section>
+{{template "list" .}}
+<script>
+document.getElementById("filter").addEventListener("input", function (e) {
+  var q = e.target.value.toLowerCase();
+  document.querySelectorAll("ul.prompts li").forEach(function (li) {
+    li.hidden = q !== "" && li.dataset.search.toLowerCase().indexOf(q) < 0;
+  });
+});
+</script>
+{{template "footer" .}}{{end}}
+
+{{define "tag"}}{{template "header" .}}
+<h1>#{{.Tag.Name}}</h1>
+{{template "list" .}}
+{{template "footer" .}}{{end}}
+
+{{define "prompt"}}{{template "header" .}}{{$root := .Root}}
+<h1>{{.Prompt.Name}}</h1>
+<p class="meta"><code>{{.Prompt.ID}}</code>{{if .Prompt.Version}} · v{{.Prompt.Version}}{{end}}{{if not .Prompt.UpdatedAt.IsZero}} · updated {{.Prompt.UpdatedAt.Format "2006-01-02"}}{{end}}</p>
+{{if .Prompt.Summary}}<p class="summary">{{.Prompt.Summary}}</p>{{end}}
+<p>{{range .Prompt.TagPages}}<a class="tag" href="{{$root}}{{.Path}}">{{.Name}}</a> {{end}}</p>
+<button id="copy" type="button">Copy prompt</button>
+<textarea id="raw" hidden readonly>{{.Prompt.Content}}</textarea>
+<article>{{.Prompt.HTML}}</article>
+<script>
+document.getElementById("copy").addEventListener("click", function () {
+  var button = this, text = document.getElementById("raw").value;
+  navigator.clipboard.writeText(text).then(function () {
+    button.textContent = "Copied!";
+    setTimeout(function () { button.textContent = "Copy prompt"; }, 1500);
+  });
+});
+</script>
+{{template "footer" .}}{{end}}
+`))
+
+const styleCSS = `body { font-family: system-ui, sans-serif; max-width: 52rem; margin: 0 auto; padding: 0 1rem; color: #222; line-height: 1.5; }
+header { padding: 1rem 0; border-bottom: 1px solid #ddd; }
+header .home { font-weight: 600; text-decoration: none; color: inherit; }
+footer { margin: 3rem 0 1rem; color: #888; font-size: 0.85rem; }
+a { color: #7c3aed; }
+code { background: #f4f4f5; padding: 0 0.25rem; border-radius: 3px; font-size: 0.9em; }
+pre { background: #f4f4f5; padding: 0.75rem; border-radius: 6px; overflow-x: auto; }
+pre code { padding: 0; }
+#filter { width: 100%; padding: 0.5rem; font-size: 1rem; margin-bottom: 1rem; box-sizing: border-box; }
+.tag { display: inline-block; background: #ede9fe; color: #5b21b6; border-radius: 999px; padding: 0 0.6rem; margin: 0 0.2rem 0.4rem 0; text-decoration: none; font-size: 0.85rem; }
+.tag span { color: #8b5cf6; }
+ul.prompts { list-style: none; padding: 0; }
+ul.prompts li { padding: 0.6rem 0; border-bottom: 1px solid #eee; }
+ul.prompts li p { margin: 0.2rem 0 0; color: #555; }
+.meta { color: #666; }
+#copy { padding: 0.4rem 0.9rem; border: 1px solid #7c3aed; background: #7c3aed; color: white; border-radius: 6px; cursor: pointer; }
+article { margin-top: 1.5rem; }
+`
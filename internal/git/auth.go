@@ -0,0 +1,226 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// DefaultTokenEnv is the environment variable read for an HTTPS token when
+// no other is configured
+const DefaultTokenEnv = "POCKET_PROMPT_GIT_TOKEN"
+
+// defaultTokenUsername is the username sent with a token. GitHub accepts
+// any username with a token.
+const defaultTokenUsername = "x-access-token"
+
+// credentialHelper answers git's credential requests from the environment,
+// so the token never appears in arguments or on disk
+const credentialHelper = `!f() { test "$1" = get && echo "username=$POCKET_PROMPT_GIT_USERNAME" && echo "password=$POCKET_PROMPT_GIT_PASSWORD"; }; f`
+
+// Auth configures how git authenticates with the remote
+type Auth struct {
+	// SSHKey is the private key used for SSH remotes
+	SSHKey string
+	// Username is sent with the token on HTTPS remotes
+	Username string
+	// TokenEnv names the environment variable holding an HTTPS token
+	// (default DefaultTokenEnv)
+	TokenEnv string
+	// TokenCommand prints an HTTPS token, e.g. read from the system keychain.
+	// It is used when the environment variable is empty.
+	TokenCommand string
+}
+
+// AuthError reports that the remote rejected git's credentials
+type AuthError struct {
+	Command string // The git subcommand, e.g. "push"
+	Detail  string // git's explanation
+	Hint    string // What to change
+}
+
+func (e *AuthError) Error() string {
+	return fmt.Sprintf("authentication failed for git %s: %s. %s", e.Command, e.Detail, e.Hint)
+}
+
+// IsAuthError reports whether err is caused by rejected credentials
+func IsAuthError(err error) bool {
+	var authErr *AuthError
+	return errors.As(err, &authErr)
+}
+
+// SetAuth sets how git authenticates with the remote
+func (g *GitSync) SetAuth(auth Auth) {
+	g.auth = auth
+	g.token, g.tokenErr, g.tokenRead = "", nil, false
+}
+
+// remoteCommands are the git subcommands that talk to the remote
+var remoteCommands = map[string]bool{
+	"fetch":     true,
+	"pull":      true,
+	"push":      true,
+	"ls-remote": true,
+}
+
+// authCommand applies the configured credentials to a git command that
+// talks to the remote. It returns the arguments and environment to run
+// git with.
+func (g *GitSync) authCommand(args []string) ([]string, []string, error) {
+	env := append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	if len(args) == 0 || !remoteCommands[args[0]] {
+		return args, env, nil
+	}
+
+	if g.auth.SSHKey != "" {
+		key := expandHome(g.auth.SSHKey)
+		if _, err := os.Stat(key); err != nil {
+			return nil, nil, fmt.Errorf("SSH key %s from git.ssh_key not found", g.auth.SSHKey)
+		}
+		env = append(env, "GIT_SSH_COMMAND=ssh -i "+shellQuote(key)+" -o IdentitiesOnly=yes")
+	}
+
+	token, err := g.readToken()
+	if err != nil {
+		return nil, nil, err
+	}
+	if token != "" {
+		username := g.auth.Username
+		if username == "" {
+			username = defaultTokenUsername
+		}
+		env = append(env,
+			"POCKET_PROMPT_GIT_USERNAME="+username,
+			"POCKET_PROMPT_GIT_PASSWORD="+token,
+		)
+		// The empty helper drops helpers configured elsewhere
+		args = append([]string{"-c", "credential.helper=", "-c", "credential.helper=" + credentialHelper}, args...)
+	}
+	return args, env, nil
+}
+
+// readToken returns the HTTPS token, running the token command at most once
+func (g *GitSync) readToken() (string, error) {
+	envName := g.auth.TokenEnv
+	if envName == "" {
+		envName = DefaultTokenEnv
+	}
+	if token := strings.TrimSpace(os.Getenv(envName)); token != "" {
+		return token, nil
+	}
+	if g.auth.TokenCommand == "" {
+		return "", nil
+	}
+
+	g.tokenMu.Lock()
+	defer g.tokenMu.Unlock()
+	if !g.tokenRead {
+		g.token, g.tokenErr = runTokenCommand(g.auth.TokenCommand)
+		g.tokenRead = true
+	}
+	return g.token, g.tokenErr
+}
+
+// runTokenCommand runs a token command through the shell and returns its
+// output
+func runTokenCommand(command string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	output, err := exec.CommandContext(ctx, "sh", "-c", command).Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+			return "", fmt.Errorf("git.token_command failed: %s", strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return "", fmt.Errorf("git.token_command failed: %w", err)
+	}
+	token := strings.TrimSpace(string(output))
+	if token == "" {
+		return "", fmt.Errorf("git.token_command printed no token")
+	}
+	return token, nil
+}
+
+// sshFailures and httpsFailures are git and ssh messages meaning the remote
+// rejected the credentials
+var (
+	sshFailures = []string{
+		"Permission denied (publickey",
+		"Host key verification failed",
+	}
+	httpsFailures = []string{
+		"could not read Username",
+		"could not read Password",
+		"terminal prompts disabled",
+		"Authentication failed",
+		"Invalid username or password",
+		"The requested URL returned error: 401",
+		"The requested URL returned error: 403",
+	}
+)
+
+// authFailure turns the output of a failed remote command into an
+// AuthError when the remote rejected the credentials
+func (g *GitSync) authFailure(command, output string) *AuthError {
+	for _, line := range strings.Split(output, "\n") {
+		for _, failure := range sshFailures {
+			if strings.Contains(line, failure) {
+				return &AuthError{Command: command, Detail: authDetail(line), Hint: g.sshHint(failure)}
+			}
+		}
+		for _, failure := range httpsFailures {
+			if strings.Contains(line, failure) {
+				return &AuthError{Command: command, Detail: authDetail(line), Hint: g.httpsHint()}
+			}
+		}
+	}
+	return nil
+}
+
+// authDetail trims git's prefixes from a line of its output
+func authDetail(line string) string {
+	line = strings.TrimSpace(line)
+	for _, prefix := range []string{"fatal: ", "remote: ", "error: "} {
+		line = strings.TrimPrefix(line, prefix)
+	}
+	return strings.TrimSuffix(line, ".")
+}
+
+func (g *GitSync) sshHint(failure string) string {
+	if strings.HasPrefix(failure, "Host key") {
+		return "The remote's host key is not trusted yet; connect once with ssh to accept it"
+	}
+	if g.auth.SSHKey != "" {
+		return fmt.Sprintf("Add the public key of %s to your account on the git host", g.auth.SSHKey)
+	}
+	return "Add your SSH public key to your account on the git host, or set git.ssh_key in config.yaml"
+}
+
+func (g *GitSync) httpsHint() string {
+	if token, err := g.readToken(); err == nil && token != "" {
+		return "The token was rejected; check that it is valid and can write to the repository"
+	}
+	envName := g.auth.TokenEnv
+	if envName == "" {
+		envName = DefaultTokenEnv
+	}
+	return fmt.Sprintf("Set a token in %s or git.token_command in config.yaml, or use an SSH remote", envName)
+}
+
+// shellQuote quotes a string for the shell git runs GIT_SSH_COMMAND with
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+func expandHome(path string) string {
+	if strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			return home + path[1:]
+		}
+	}
+	return path
+}
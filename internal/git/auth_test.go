@@ -0,0 +1,80 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func envValue(env []string, name string) string {
+	value := ""
+	for _, entry := range env {
+		if v, ok := strings.CutPrefix(entry, name+"="); ok {
+			value = v
+		}
+	}
+	return value
+}
+
+func TestAuthCommand(t *testing.T) {
+	key := filepath.Join(t.TempDir(), "id key")
+	if err := os.WriteFile(key, []byte("key"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("TEST_GIT_TOKEN", "")
+
+	g := NewGitSync(t.TempDir())
+	g.SetAuth(Auth{SSHKey: key, TokenEnv: "TEST_GIT_TOKEN", TokenCommand: "echo from-keychain"})
+
+	args, env, err := g.authCommand([]string{"push", "origin", "master"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := envValue(env, "GIT_SSH_COMMAND"); got != "ssh -i '"+key+"' -o IdentitiesOnly=yes" {
+		t.Errorf("Unexpected GIT_SSH_COMMAND %q", got)
+	}
+	if got := envValue(env, "POCKET_PROMPT_GIT_PASSWORD"); got != "from-keychain" {
+		t.Errorf("Expected the token from the command, got %q", got)
+	}
+	if args[len(args)-3] != "push" || strings.Contains(strings.Join(args, " "), "from-keychain") {
+		t.Errorf("Unexpected arguments %q", args)
+	}
+
+	t.Setenv("TEST_GIT_TOKEN", "from-env")
+	if _, env, _ := g.authCommand([]string{"fetch"}); envValue(env, "POCKET_PROMPT_GIT_PASSWORD") != "from-env" {
+		t.Error("Expected the environment variable to take precedence")
+	}
+
+	// Local commands are left alone
+	if args, env, _ := g.authCommand([]string{"add", "-A"}); len(args) != 2 || envValue(env, "GIT_SSH_COMMAND") != "" {
+		t.Errorf("Expected no credentials for git add, got %q", args)
+	}
+
+	g.SetAuth(Auth{SSHKey: filepath.Join(t.TempDir(), "missing")})
+	if _, _, err := g.authCommand([]string{"push"}); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("Expected a missing key error, got %v", err)
+	}
+}
+
+func TestAuthFailure(t *testing.T) {
+	t.Setenv(DefaultTokenEnv, "")
+	g := NewGitSync(t.TempDir())
+
+	err := g.authFailure("push", "git@github.com: Permission denied (publickey).\nfatal: Could not read from remote repository.\n")
+	if err == nil || err.Detail != "git@github.com: Permission denied (publickey)" || !strings.Contains(err.Hint, "git.ssh_key") {
+		t.Errorf("Unexpected SSH failure %+v", err)
+	}
+	if !IsAuthError(err) {
+		t.Error("Expected IsAuthError to recognize the failure")
+	}
+
+	err = g.authFailure("fetch", "fatal: could not read Username for 'https://github.com': terminal prompts disabled\n")
+	if err == nil || !strings.Contains(err.Hint, DefaultTokenEnv) {
+		t.Errorf("Unexpected HTTPS failure %+v", err)
+	}
+
+	if err := g.authFailure("pull", "fatal: couldn't find remote ref master\n"); err != nil {
+		t.Errorf("Expected no auth failure, got %v", err)
+	}
+}
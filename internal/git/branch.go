@@ -0,0 +1,227 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Branch is a local branch of the library repository
+type Branch struct {
+	Name    string
+	Current bool
+}
+
+// ListBranches returns the local branches
+func (g *GitSync) ListBranches() ([]Branch, error) {
+	if !g.isGitInitialized() {
+		return nil, fmt.Errorf("git is not set up; run 'pocket-prompt git setup' first")
+	}
+	output, err := g.gitOutput(nil, "branch", "--format=%(HEAD) %(refname:short)")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list branches: %w", err)
+	}
+	var branches []Branch
+	for _, line := range strings.Split(output, "\n") {
+		if len(line) < 3 {
+			continue
+		}
+		branches = append(branches, Branch{Name: line[2:], Current: line[0] == '*'})
+	}
+	return branches, nil
+}
+
+// CurrentBranch returns the checked out branch
+func (g *GitSync) CurrentBranch() string {
+	return g.getCurrentBranch()
+}
+
+// CreateBranch creates a branch from the current commit without switching
+// to it
+func (g *GitSync) CreateBranch(name string) error {
+	if err := g.runGitCommand("branch", name); err != nil {
+		return fmt.Errorf("failed to create branch %s: %w", name, err)
+	}
+	return nil
+}
+
+// SwitchBranch checks a branch out in the library
+func (g *GitSync) SwitchBranch(name string) error {
+	if err := g.runGitCommand("checkout", name); err != nil {
+		return fmt.Errorf("failed to switch to branch %s: %w", name, err)
+	}
+	return nil
+}
+
+// DeleteBranch deletes a local branch. Unless force is set, branches with
+// commits that are not merged are kept.
+func (g *GitSync) DeleteBranch(name string, force bool) error {
+	flag := "-d"
+	if force {
+		flag = "-D"
+	}
+	if err := g.runGitCommand("branch", flag, name); err != nil {
+		return fmt.Errorf("failed to delete branch %s: %w", name, err)
+	}
+	return nil
+}
+
+// SetExcludedPaths sets a function returning paths that SyncChanges leaves
+// out of the current branch, such as drafts committed to a branch of their
+// own
+func (g *GitSync) SetExcludedPaths(paths func() []string) {
+	g.excludedPaths = paths
+}
+
+// SyncToBranch commits the changes to paths onto branch, without checking
+// it out, and pushes the branch. A missing branch starts at the current
+// commit.
+func (g *GitSync) SyncToBranch(branch, message string, paths ...string) error {
+	if !g.IsEnabled() {
+		return nil // Silently skip if not enabled
+	}
+
+	committed, err := g.commitToBranch(branch, message, paths)
+	if err != nil || !committed {
+		return err
+	}
+
+	// Push changes (best effort - don't fail if push fails)
+	if err := g.runGitCommand("push", "origin", branch); err != nil {
+		return fmt.Errorf("committed locally but failed to push: %w", err)
+	}
+	return nil
+}
+
+// MergeBranch commits the changes to paths on the current branch as a merge
+// of branch, whose commits are expected to touch only those paths, and then
+// moves branch to the merge so it starts over from there
+func (g *GitSync) MergeBranch(branch, message string, paths ...string) error {
+	if !g.IsEnabled() {
+		return nil // Silently skip if not enabled
+	}
+
+	tip, err := g.gitOutput(nil, "rev-parse", "--verify", "-q", "refs/heads/"+branch)
+	if err != nil {
+		// Nothing was ever committed to the branch
+		return g.SyncPaths(message, paths...)
+	}
+
+	head, err := g.gitOutput(nil, "rev-parse", "HEAD")
+	if err != nil {
+		return fmt.Errorf("failed to read current commit: %w", err)
+	}
+
+	// Stage the paths in the real index so the merge commit matches it
+	if paths = g.knownPaths(paths); len(paths) > 0 {
+		if err := g.runGitCommand(append([]string{"add", "-A", "--"}, paths...)...); err != nil {
+			return fmt.Errorf("failed to stage changes: %w", err)
+		}
+	}
+	tree, err := g.gitOutput(nil, "write-tree")
+	if err != nil {
+		return fmt.Errorf("failed to write tree: %w", err)
+	}
+	commit, err := g.gitOutput(nil, "commit-tree", tree, "-p", head, "-p", tip, "-m", g.commitMessage(message, time.Now()))
+	if err != nil {
+		return fmt.Errorf("failed to commit merge: %w", err)
+	}
+	if err := g.runGitCommand("update-ref", "-m", "merge "+branch, "HEAD", commit, head); err != nil {
+		return fmt.Errorf("failed to commit merge: %w", err)
+	}
+	if err := g.runGitCommand("update-ref", "refs/heads/"+branch, commit, tip); err != nil {
+		return fmt.Errorf("failed to reset branch %s: %w", branch, err)
+	}
+
+	// Push changes (best effort - don't fail if push fails)
+	if err := g.runGitCommand("push", "origin", g.getCurrentBranch(), branch); err != nil {
+		return fmt.Errorf("committed locally but failed to push: %w", err)
+	}
+	return nil
+}
+
+// commitToBranch commits paths onto branch using an index of its own, so
+// the working tree and the current branch are left alone. It reports
+// whether anything was committed.
+func (g *GitSync) commitToBranch(branch, message string, paths []string) (bool, error) {
+	parent, err := g.gitOutput(nil, "rev-parse", "--verify", "-q", "refs/heads/"+branch)
+	if err != nil {
+		if parent, err = g.gitOutput(nil, "rev-parse", "HEAD"); err != nil {
+			return false, fmt.Errorf("failed to read current commit: %w", err)
+		}
+	}
+
+	gitDir, err := g.gitOutput(nil, "rev-parse", "--absolute-git-dir")
+	if err != nil {
+		return false, fmt.Errorf("failed to locate git directory: %w", err)
+	}
+	index := filepath.Join(gitDir, "pocket-prompt-branch-index")
+	defer os.Remove(index)
+	env := []string{"GIT_INDEX_FILE=" + index}
+
+	if _, err := g.gitOutput(env, "read-tree", parent); err != nil {
+		return false, fmt.Errorf("failed to read branch %s: %w", branch, err)
+	}
+	var existing, removed []string
+	for _, path := range paths {
+		if _, err := os.Lstat(filepath.Join(g.baseDir, path)); err == nil {
+			existing = append(existing, path)
+		} else {
+			removed = append(removed, path)
+		}
+	}
+	if len(existing) > 0 {
+		if _, err := g.gitOutput(env, append([]string{"add", "-A", "--"}, existing...)...); err != nil {
+			return false, fmt.Errorf("failed to stage changes: %w", err)
+		}
+	}
+	if len(removed) > 0 {
+		if _, err := g.gitOutput(env, append([]string{"rm", "-q", "--cached", "--ignore-unmatch", "--"}, removed...)...); err != nil {
+			return false, fmt.Errorf("failed to stage changes: %w", err)
+		}
+	}
+
+	tree, err := g.gitOutput(env, "write-tree")
+	if err != nil {
+		return false, fmt.Errorf("failed to write tree: %w", err)
+	}
+	parentTree, err := g.gitOutput(nil, "rev-parse", parent+"^{tree}")
+	if err != nil {
+		return false, fmt.Errorf("failed to read branch %s: %w", branch, err)
+	}
+	if tree == parentTree {
+		return false, nil // No changes to sync
+	}
+
+	commit, err := g.gitOutput(nil, "commit-tree", tree, "-p", parent, "-m", g.commitMessage(message, time.Now()))
+	if err != nil {
+		return false, fmt.Errorf("failed to commit changes: %w", err)
+	}
+	if err := g.runGitCommand("update-ref", "refs/heads/"+branch, commit); err != nil {
+		return false, fmt.Errorf("failed to update branch %s: %w", branch, err)
+	}
+	return true, nil
+}
+
+// gitOutput runs a local git command with extra environment variables and
+// returns its trimmed output
+func (g *GitSync) gitOutput(env []string, args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = g.baseDir
+	cmd.Env = append(os.Environ(), env...)
+	output, err := cmd.Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok && len(exitErr.Stderr) > 0 {
+			return "", fmt.Errorf("git %s failed: %s", args[0], strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return "", fmt.Errorf("git %s failed: %w", args[0], err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
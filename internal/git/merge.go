@@ -0,0 +1,94 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// FileMerger combines the two sides of a file both changed since base, which
+// is nil when the file did not exist there. clean is false when the result
+// still contains conflict markers to be edited by hand.
+type FileMerger func(path string, base, ours, theirs []byte) (merged []byte, clean bool, err error)
+
+// SetFileMerger sets how conflicting changes to markdown files are merged
+// when pulling. Without one, or when it fails, the remote version is kept.
+func (g *GitSync) SetFileMerger(merger FileMerger) {
+	g.fileMerger = merger
+}
+
+// MergeText runs a line-based three-way merge of ours and theirs, leaving
+// conflict markers labelled local and remote where both changed the same
+// lines
+func MergeText(base, ours, theirs string) (merged string, clean bool, err error) {
+	dir, err := os.MkdirTemp("", "pocket-prompt-merge-")
+	if err != nil {
+		return "", false, fmt.Errorf("failed to create merge directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	files := make([]string, 3)
+	for i, content := range []string{ours, base, theirs} {
+		files[i] = filepath.Join(dir, fmt.Sprintf("%d", i))
+		if err := os.WriteFile(files[i], []byte(content), 0600); err != nil {
+			return "", false, fmt.Errorf("failed to write merge input: %w", err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, "git", "merge-file", "-p", "-L", "local", "-L", "base", "-L", "remote", files[0], files[1], files[2])
+	output, err := cmd.Output()
+	if err != nil {
+		// A positive exit code below 128 is the number of conflicts
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() > 0 && exitErr.ExitCode() < 128 {
+			return string(output), false, nil
+		}
+		return "", false, fmt.Errorf("git merge-file failed: %w", err)
+	}
+	return string(output), true, nil
+}
+
+// mergeConflictedFile merges a file left conflicted by a pull with the file
+// merger, reporting whether it could
+func (g *GitSync) mergeConflictedFile(file string) (clean, merged bool) {
+	if g.fileMerger == nil || !strings.HasSuffix(file, ".md") {
+		return false, false
+	}
+
+	// Stage 1 is the common ancestor, 2 the local and 3 the remote version
+	base, _ := g.indexStage(1, file)
+	ours, err := g.indexStage(2, file)
+	if err != nil {
+		return false, false
+	}
+	theirs, err := g.indexStage(3, file)
+	if err != nil {
+		return false, false
+	}
+
+	content, clean, err := g.fileMerger(file, base, ours, theirs)
+	if err != nil {
+		slog.Warn("Could not merge file, keeping the remote version", "file", file, "error", err)
+		return false, false
+	}
+	if err := os.WriteFile(filepath.Join(g.baseDir, file), content, 0644); err != nil {
+		slog.Warn("Could not write merged file, keeping the remote version", "file", file, "error", err)
+		return false, false
+	}
+	return clean, true
+}
+
+// indexStage returns a version of a conflicted file from the index
+func (g *GitSync) indexStage(stage int, file string) ([]byte, error) {
+	cmd := exec.Command("git", "show", fmt.Sprintf(":%d:%s", stage, file))
+	cmd.Dir = g.baseDir
+	return cmd.Output()
+}
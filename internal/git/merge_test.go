@@ -0,0 +1,96 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMergeText(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	base := "one\ntwo\nthree\n"
+
+	merged, clean, err := MergeText(base, "ONE\ntwo\nthree\n", "one\ntwo\nTHREE\n")
+	if err != nil || !clean || merged != "ONE\ntwo\nTHREE\n" {
+		t.Errorf("Expected a clean merge, got %q %v %v", merged, clean, err)
+	}
+
+	merged, clean, err = MergeText(base, "uno\ntwo\nthree\n", "eins\ntwo\nthree\n")
+	if err != nil || clean {
+		t.Fatalf("Expected a conflict, got %q %v %v", merged, clean, err)
+	}
+	if !strings.Contains(merged, "<<<<<<< local\nuno\n=======\neins\n>>>>>>> remote\n") {
+		t.Errorf("Unexpected conflict markers %q", merged)
+	}
+}
+
+func TestPullChangesMergesConflictingEdits(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	for _, name := range []string{"GIT_AUTHOR_NAME", "GIT_COMMITTER_NAME"} {
+		t.Setenv(name, "Test")
+	}
+	for _, name := range []string{"GIT_AUTHOR_EMAIL", "GIT_COMMITTER_EMAIL"} {
+		t.Setenv(name, "test@example.com")
+	}
+	remote := filepath.Join(t.TempDir(), "remote.git")
+	local := filepath.Join(t.TempDir(), "local")
+	other := filepath.Join(t.TempDir(), "other")
+	git := func(dir string, args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %s: %v\n%s", strings.Join(args, " "), err, output)
+		}
+	}
+	write := func(dir, content string) {
+		os.MkdirAll(filepath.Join(dir, "prompts"), 0755)
+		if err := os.WriteFile(filepath.Join(dir, "prompts", "a.md"), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	git(".", "init", "-q", "--bare", remote)
+	git(".", "clone", "-q", remote, local)
+	write(local, "one\ntwo\nthree\n")
+	git(local, "add", "-A")
+	git(local, "commit", "-q", "-m", "Create a")
+	git(local, "push", "-q", "-u", "origin", "HEAD")
+	git(".", "clone", "-q", remote, other)
+
+	write(other, "uno\ntwo\nthree\n")
+	git(other, "commit", "-q", "-am", "Translate to Spanish")
+	git(other, "push", "-q")
+	write(local, "eins\ntwo\nthree\n")
+	git(local, "commit", "-q", "-am", "Translate to German")
+
+	g := NewGitSync(local)
+	g.enabled = true
+	var mergedPath string
+	g.SetFileMerger(func(path string, base, ours, theirs []byte) ([]byte, bool, error) {
+		mergedPath = path
+		if string(base) != "one\ntwo\nthree\n" || !strings.HasPrefix(string(ours), "eins") || !strings.HasPrefix(string(theirs), "uno") {
+			t.Errorf("Unexpected merge inputs %q %q %q", base, ours, theirs)
+		}
+		return []byte("eins/uno\ntwo\nthree\n"), true, nil
+	})
+	if err := g.PullChanges(); err != nil {
+		t.Fatalf("PullChanges failed: %v", err)
+	}
+
+	if mergedPath != "prompts/a.md" {
+		t.Errorf("Expected the merger to be called for prompts/a.md, got %q", mergedPath)
+	}
+	data, err := os.ReadFile(filepath.Join(local, "prompts", "a.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "eins/uno\ntwo\nthree\n" {
+		t.Errorf("Expected the merged file, got %q", data)
+	}
+}
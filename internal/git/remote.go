@@ -0,0 +1,240 @@
+package git
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// LocalStatePatterns are library files that belong to one device and are
+// never synced
+var LocalStatePatterns = []string{
+	".pocket-prompt/cache/",
+	".pocket-prompt/backups/",
+	".pocket-prompt/remote/",
+	".pocket-prompt/usage.json",
+}
+
+const defaultGitignore = `# OS files
+.DS_Store
+Thumbs.db
+
+# Editor files
+.idea
+.vscode
+*.swp
+*.swo
+*~
+
+# Temporary files
+*.tmp
+*.bak
+
+# Local state of this device
+`
+
+// githubAPI is the base URL of the GitHub REST API
+var githubAPI = "https://api.github.com"
+
+// EnsureGitignore writes a .gitignore that keeps caches, backups and other
+// local state out of the repository. An existing .gitignore only gains the
+// local state entries it is missing. It reports whether the file changed.
+func (g *GitSync) EnsureGitignore() (bool, error) {
+	path := filepath.Join(g.baseDir, ".gitignore")
+	existing, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return false, fmt.Errorf("failed to read .gitignore: %w", err)
+	}
+
+	present := make(map[string]bool)
+	for _, line := range strings.Split(string(existing), "\n") {
+		present[strings.TrimSpace(line)] = true
+	}
+
+	var b strings.Builder
+	if len(existing) == 0 {
+		b.WriteString(defaultGitignore)
+	} else if !bytes.HasSuffix(existing, []byte("\n")) {
+		b.WriteString("\n")
+	}
+	for _, pattern := range LocalStatePatterns {
+		if !present[pattern] {
+			b.WriteString(pattern + "\n")
+		}
+	}
+	if b.Len() == 0 {
+		return false, nil
+	}
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return false, fmt.Errorf("failed to write .gitignore: %w", err)
+	}
+	if _, err := f.WriteString(b.String()); err != nil {
+		f.Close()
+		return false, fmt.Errorf("failed to write .gitignore: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return false, fmt.Errorf("failed to write .gitignore: %w", err)
+	}
+	return true, nil
+}
+
+// CreateGitHubRepository creates a GitHub repository and returns its clone
+// URL. name is "repo" for the signed-in user or "owner/repo". It uses the gh
+// CLI when installed and otherwise the API with the token in GITHUB_TOKEN or
+// GH_TOKEN.
+func CreateGitHubRepository(name, description string, private bool) (string, error) {
+	if name == "" {
+		return "", fmt.Errorf("repository name cannot be empty")
+	}
+	if _, err := exec.LookPath("gh"); err == nil {
+		return createWithGH(name, description, private)
+	}
+
+	token := os.Getenv("GITHUB_TOKEN")
+	if token == "" {
+		token = os.Getenv("GH_TOKEN")
+	}
+	if token == "" {
+		return "", fmt.Errorf("creating a repository needs the gh CLI (https://cli.github.com) or a token in GITHUB_TOKEN")
+	}
+	return createWithAPI(token, name, description, private)
+}
+
+// createWithGH creates a repository with the gh CLI, using the git
+// protocol configured in gh for the clone URL
+func createWithGH(name, description string, private bool) (string, error) {
+	visibility := "--public"
+	if private {
+		visibility = "--private"
+	}
+	args := []string{"repo", "create", name, visibility}
+	if description != "" {
+		args = append(args, "--description", description)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	output, err := exec.CommandContext(ctx, "gh", args...).CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("gh repo create failed: %s", strings.TrimSpace(string(output)))
+	}
+
+	webURL := ""
+	for _, line := range strings.Split(string(output), "\n") {
+		if line = strings.TrimSpace(line); strings.HasPrefix(line, "https://") {
+			webURL = line
+		}
+	}
+	if webURL == "" {
+		return "", fmt.Errorf("gh repo create did not report the repository URL")
+	}
+
+	protocol, _ := exec.Command("gh", "config", "get", "git_protocol").Output()
+	return cloneURL(webURL, strings.TrimSpace(string(protocol)) == "ssh"), nil
+}
+
+// cloneURL turns a repository web URL into an HTTPS or SSH clone URL
+func cloneURL(webURL string, ssh bool) string {
+	webURL = strings.TrimSuffix(webURL, "/")
+	if ssh {
+		if rest, ok := strings.CutPrefix(webURL, "https://"); ok {
+			if host, path, ok := strings.Cut(rest, "/"); ok {
+				return fmt.Sprintf("git@%s:%s.git", host, path)
+			}
+		}
+	}
+	return webURL + ".git"
+}
+
+// createWithAPI creates a repository through the GitHub REST API
+func createWithAPI(token, name, description string, private bool) (string, error) {
+	client := &http.Client{Timeout: 30 * time.Second}
+
+	endpoint := githubAPI + "/user/repos"
+	if owner, repo, ok := strings.Cut(name, "/"); ok {
+		login, err := githubLogin(client, token)
+		if err != nil {
+			return "", err
+		}
+		if !strings.EqualFold(owner, login) {
+			endpoint = githubAPI + "/orgs/" + owner + "/repos"
+		}
+		name = repo
+	}
+
+	body, err := json.Marshal(map[string]interface{}{
+		"name":        name,
+		"description": description,
+		"private":     private,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	var created struct {
+		CloneURL string `json:"clone_url"`
+	}
+	if err := githubRequest(client, token, http.MethodPost, endpoint, body, &created); err != nil {
+		return "", fmt.Errorf("failed to create repository: %w", err)
+	}
+	if created.CloneURL == "" {
+		return "", fmt.Errorf("failed to create repository: GitHub returned no clone URL")
+	}
+	return created.CloneURL, nil
+}
+
+// githubLogin returns the login of the token's user
+func githubLogin(client *http.Client, token string) (string, error) {
+	var user struct {
+		Login string `json:"login"`
+	}
+	if err := githubRequest(client, token, http.MethodGet, githubAPI+"/user", nil, &user); err != nil {
+		return "", fmt.Errorf("failed to look up GitHub user: %w", err)
+	}
+	return user.Login, nil
+}
+
+// githubRequest sends an authenticated API request and decodes the JSON
+// response into out
+func githubRequest(client *http.Client, token, method, url string, body []byte, out interface{}) error {
+	req, err := http.NewRequest(method, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Accept", "application/vnd.github+json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode >= 300 {
+		var apiErr struct {
+			Message string `json:"message"`
+		}
+		if json.Unmarshal(data, &apiErr) == nil && apiErr.Message != "" {
+			return fmt.Errorf("GitHub API %s: %s", resp.Status, apiErr.Message)
+		}
+		return fmt.Errorf("GitHub API %s", resp.Status)
+	}
+	return json.Unmarshal(data, out)
+}
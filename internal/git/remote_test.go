@@ -0,0 +1,89 @@
+package git
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestEnsureGitignore(t *testing.T) {
+	dir := t.TempDir()
+	g := NewGitSync(dir)
+
+	changed, err := g.EnsureGitignore()
+	if err != nil || !changed {
+		t.Fatalf("Expected a new .gitignore, got %v %v", changed, err)
+	}
+	if changed, _ := g.EnsureGitignore(); changed {
+		t.Error("Expected a complete .gitignore to be left alone")
+	}
+
+	path := filepath.Join(dir, ".gitignore")
+	if err := os.WriteFile(path, []byte("node_modules\n.pocket-prompt/cache/"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := g.EnsureGitignore(); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(path)
+	content := string(data)
+	if !strings.HasPrefix(content, "node_modules\n.pocket-prompt/cache/\n") ||
+		strings.Count(content, ".pocket-prompt/cache/") != 1 ||
+		!strings.Contains(content, ".pocket-prompt/usage.json\n") {
+		t.Errorf("Unexpected .gitignore:\n%s", content)
+	}
+}
+
+func TestCreateWithAPI(t *testing.T) {
+	var created map[string]interface{}
+	var createdPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/user":
+			w.Write([]byte(`{"login":"ana"}`))
+		case r.Method == http.MethodPost:
+			createdPath = r.URL.Path
+			json.NewDecoder(r.Body).Decode(&created)
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"clone_url":"https://github.com/team/prompts.git"}`))
+		}
+	}))
+	defer server.Close()
+	defer func(api string) { githubAPI = api }(githubAPI)
+	githubAPI = server.URL
+
+	url, err := createWithAPI("secret", "team/prompts", "Shared prompts", true)
+	if err != nil {
+		t.Fatalf("createWithAPI failed: %v", err)
+	}
+	if url != "https://github.com/team/prompts.git" || createdPath != "/orgs/team/repos" {
+		t.Errorf("Unexpected result %s via %s", url, createdPath)
+	}
+	if created["name"] != "prompts" || created["private"] != true {
+		t.Errorf("Unexpected request body %v", created)
+	}
+
+	if _, err := createWithAPI("secret", "ana/prompts", "", true); err != nil || createdPath != "/user/repos" {
+		t.Errorf("Expected the user's own repository to use /user/repos, got %s %v", createdPath, err)
+	}
+	if _, err := createWithAPI("wrong", "prompts", "", true); err == nil {
+		t.Error("Expected an error for a rejected token")
+	}
+}
+
+func TestCloneURL(t *testing.T) {
+	if got := cloneURL("https://github.com/ana/prompts", false); got != "https://github.com/ana/prompts.git" {
+		t.Errorf("Unexpected HTTPS URL %s", got)
+	}
+	if got := cloneURL("https://github.com/ana/prompts", true); got != "git@github.com:ana/prompts.git" {
+		t.Errorf("Unexpected SSH URL %s", got)
+	}
+}
@@ -3,17 +3,32 @@ package git
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 )
 
+// DefaultCommitMessage is the commit message template used when none is
+// configured
+const DefaultCommitMessage = "{message} - {timestamp}"
+
 // GitSync handles automatic git synchronization
 type GitSync struct {
-	baseDir string
-	enabled bool
+	baseDir         string
+	enabled         bool
+	messageTemplate string
+	auth            Auth
+	excludedPaths   func() []string
+	fileMerger      FileMerger
+
+	tokenMu   sync.Mutex
+	token     string
+	tokenErr  error
+	tokenRead bool
 }
 
 // NewGitSync creates a new GitSync instance
@@ -63,10 +78,15 @@ func (g *GitSync) SetupRepository(repoURL string) error {
 		// Set default branch name to master
 		if err := g.runGitCommand("branch", "-M", "master"); err != nil {
 			// Not critical if this fails, some git versions don't support it
-			fmt.Printf("Note: Could not set default branch to 'master': %v\n", err)
+			slog.Info("Could not set default branch to 'master'", "error", err)
 		}
 	}
 	
+	// Keep caches and backups out of the repository
+	if _, err := g.EnsureGitignore(); err != nil {
+		slog.Warn("Could not write .gitignore", "error", err)
+	}
+	
 	// Check if remote already exists
 	if g.hasRemote() {
 		// Update the remote URL if different
@@ -92,7 +112,7 @@ func (g *GitSync) SetupRepository(repoURL string) error {
 		if _, err := os.Stat(readmePath); os.IsNotExist(err) {
 			readmeContent := []byte("# Pocket Prompt Library\n\nThis repository contains your synchronized prompt library.\n")
 			if err := os.WriteFile(readmePath, readmeContent, 0644); err != nil {
-				fmt.Printf("Warning: Could not create README: %v\n", err)
+				slog.Warn("Could not create README", "error", err)
 			}
 		}
 		
@@ -113,22 +133,21 @@ func (g *GitSync) SetupRepository(repoURL string) error {
 	// Try to fetch from remote to check if it exists and is accessible
 	fetchErr := g.runGitCommand("fetch", "origin")
 	if fetchErr != nil {
-		if strings.Contains(fetchErr.Error(), "could not read Username") || 
-		   strings.Contains(fetchErr.Error(), "Authentication failed") ||
-		   strings.Contains(fetchErr.Error(), "Permission denied") {
+		if IsAuthError(fetchErr) {
 			fmt.Printf("\n⚠️  Authentication required for: %s\n", repoURL)
 			fmt.Println("\nFor GitHub repositories, you have two options:")
 			fmt.Println("\n1. Use HTTPS with a Personal Access Token:")
 			fmt.Println("   - Create a token at: https://github.com/settings/tokens")
-			fmt.Println("   - Use format: https://YOUR_TOKEN@github.com/username/repo.git")
+			fmt.Printf("   - Export it as %s, or set git.token_command in config.yaml\n", DefaultTokenEnv)
 			fmt.Println("\n2. Use SSH (recommended):")
 			fmt.Println("   - Setup SSH key: https://docs.github.com/en/authentication/connecting-to-github-with-ssh")
 			fmt.Println("   - Use format: git@github.com:username/repo.git")
-			return fmt.Errorf("authentication required for remote repository")
+			fmt.Println("   - Set git.ssh_key in config.yaml to use a key of its own")
+			return fetchErr
 		}
 		// For new repositories, fetch might fail which is okay
 		if !strings.Contains(fetchErr.Error(), "couldn't find remote ref") {
-			fmt.Printf("Warning: Could not fetch from remote (this is normal for new repositories): %v\n", fetchErr)
+			slog.Warn("Could not fetch from remote (this is normal for new repositories)", "error", fetchErr)
 		}
 	} else {
 		// Successfully fetched - override local with remote content for clean sync
@@ -137,7 +156,7 @@ func (g *GitSync) SetupRepository(repoURL string) error {
 		// First, determine which branch exists on remote
 		remoteBranches, err := g.getRemoteBranches()
 		if err != nil {
-			fmt.Printf("Warning: Could not determine remote branches: %v\n", err)
+			slog.Warn("Could not determine remote branches", "error", err)
 			remoteBranches = []string{"master"} // fallback
 		}
 		
@@ -157,7 +176,7 @@ func (g *GitSync) SetupRepository(repoURL string) error {
 		
 		// Switch to match the remote branch
 		if err := g.runGitCommand("checkout", "-B", remoteBranch); err != nil {
-			fmt.Printf("Warning: Could not create/switch to branch %s: %v\n", remoteBranch, err)
+			slog.Warn("Could not create or switch to branch", "branch", remoteBranch, "error", err)
 		}
 		
 		// Pull with merge strategy, preferring remote content
@@ -166,7 +185,7 @@ func (g *GitSync) SetupRepository(repoURL string) error {
 			// If that fails, try a more aggressive approach - reset to remote
 			fmt.Printf("Pull failed, resetting to match remote repository...\n")
 			if resetErr := g.runGitCommand("reset", "--hard", fmt.Sprintf("origin/%s", remoteBranch)); resetErr != nil {
-				fmt.Printf("Warning: Could not sync with remote: %v\n", pullErr)
+				slog.Warn("Could not sync with remote", "error", pullErr)
 			} else {
 				fmt.Println("✅ Successfully synced with remote repository")
 			}
@@ -183,12 +202,11 @@ func (g *GitSync) SetupRepository(repoURL string) error {
 	
 	pushErr := g.runGitCommand("push", "-u", "origin", currentBranch)
 	if pushErr != nil {
-		if strings.Contains(pushErr.Error(), "could not read Username") || 
-		   strings.Contains(pushErr.Error(), "Authentication failed") {
-			return fmt.Errorf("authentication failed: please check your repository URL and credentials")
+		if IsAuthError(pushErr) {
+			return pushErr
 		}
 		// Non-fatal push error
-		fmt.Printf("Warning: Push failed (you can push manually later): %v\n", pushErr)
+		slog.Warn("Push failed (you can push manually later)", "error", pushErr)
 	} else {
 		fmt.Println("✅ Successfully pushed to remote repository")
 	}
@@ -303,19 +321,61 @@ func (g *GitSync) hasRemoteQuick() bool {
 	return len(strings.TrimSpace(string(output))) > 0
 }
 
-// SyncChanges commits and pushes changes to git
+// SetCommitMessage sets the template for commit messages, see
+// DefaultCommitMessage. An empty template restores the default.
+func (g *GitSync) SetCommitMessage(template string) {
+	g.messageTemplate = template
+}
+
+// commitMessage fills the commit message template in for a change
+func (g *GitSync) commitMessage(message string, now time.Time) string {
+	template := g.messageTemplate
+	if template == "" {
+		template = DefaultCommitMessage
+	}
+	return strings.NewReplacer(
+		"{message}", message,
+		"{timestamp}", now.Format("2006-01-02 15:04:05"),
+	).Replace(template)
+}
+
+// SyncChanges commits and pushes all changes in the library to git, except
+// the excluded paths (see SetExcludedPaths)
 func (g *GitSync) SyncChanges(message string) error {
+	return g.SyncPaths(message)
+}
+
+// SyncPaths commits and pushes the changes to the given files, relative to
+// the library root, so each operation gets a commit of its own. Without
+// paths every change in the library is committed.
+func (g *GitSync) SyncPaths(message string, paths ...string) error {
 	if !g.IsEnabled() {
 		return nil // Silently skip if not enabled
 	}
 
-	// Stage all changes
-	if err := g.runGitCommand("add", "-A"); err != nil {
+	// Stage the changes. Paths that neither exist nor are tracked would make
+	// git add fail, so they are left out.
+	args := []string{"add", "-A"}
+	if len(paths) > 0 {
+		paths = g.knownPaths(paths)
+		if len(paths) == 0 {
+			return nil
+		}
+		args = append(append(args, "--"), paths...)
+	}
+	if err := g.runGitCommand(args...); err != nil {
 		return fmt.Errorf("failed to stage changes: %w", err)
 	}
+	if len(paths) == 0 && g.excludedPaths != nil {
+		if excluded := g.excludedPaths(); len(excluded) > 0 {
+			if err := g.runGitCommand(append([]string{"reset", "-q", "--"}, excluded...)...); err != nil {
+				return fmt.Errorf("failed to unstage excluded files: %w", err)
+			}
+		}
+	}
 
 	// Check if there are changes to commit
-	hasChanges, err := g.hasChangesToCommit()
+	hasChanges, err := g.hasChangesToCommit(paths...)
 	if err != nil {
 		return fmt.Errorf("failed to check for changes: %w", err)
 	}
@@ -325,8 +385,11 @@ func (g *GitSync) SyncChanges(message string) error {
 	}
 
 	// Commit changes
-	commitMessage := fmt.Sprintf("%s - %s", message, time.Now().Format("2006-01-02 15:04:05"))
-	if err := g.runGitCommand("commit", "-m", commitMessage); err != nil {
+	args = []string{"commit", "-m", g.commitMessage(message, time.Now())}
+	if len(paths) > 0 {
+		args = append(append(args, "--"), paths...)
+	}
+	if err := g.runGitCommand(args...); err != nil {
 		return fmt.Errorf("failed to commit changes: %w", err)
 	}
 
@@ -340,9 +403,30 @@ func (g *GitSync) SyncChanges(message string) error {
 	return nil
 }
 
-// hasChangesToCommit checks if there are staged changes ready to commit
-func (g *GitSync) hasChangesToCommit() (bool, error) {
-	cmd := exec.Command("git", "diff", "--cached", "--quiet")
+// knownPaths returns the paths that exist in the library or are tracked
+// by git
+func (g *GitSync) knownPaths(paths []string) []string {
+	var known []string
+	for _, path := range paths {
+		if _, err := os.Lstat(filepath.Join(g.baseDir, path)); err == nil {
+			known = append(known, path)
+			continue
+		}
+		if g.runGitCommand("ls-files", "--error-unmatch", "--", path) == nil {
+			known = append(known, path)
+		}
+	}
+	return known
+}
+
+// hasChangesToCommit checks if there are staged changes ready to commit,
+// limited to paths when given
+func (g *GitSync) hasChangesToCommit(paths ...string) (bool, error) {
+	args := []string{"diff", "--cached", "--quiet"}
+	if len(paths) > 0 {
+		args = append(append(args, "--"), paths...)
+	}
+	cmd := exec.Command("git", args...)
 	cmd.Dir = g.baseDir
 	err := cmd.Run()
 	if err != nil {
@@ -361,23 +445,42 @@ func (g *GitSync) hasChangesToCommit() (bool, error) {
 
 // runGitCommand executes a git command in the base directory with timeout
 func (g *GitSync) runGitCommand(args ...string) error {
-	return g.runGitCommandWithTimeout(10*time.Second, args...)
+	return g.runGitCommandContext(context.Background(), args...)
+}
+
+// runGitCommandContext executes a git command with timeout, killing it when
+// ctx is cancelled
+func (g *GitSync) runGitCommandContext(parent context.Context, args ...string) error {
+	return g.runGitCommandWithTimeout(parent, 10*time.Second, args...)
 }
 
 // runGitCommandWithTimeout executes a git command with custom timeout
-func (g *GitSync) runGitCommandWithTimeout(timeout time.Duration, args ...string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+func (g *GitSync) runGitCommandWithTimeout(parent context.Context, timeout time.Duration, args ...string) error {
+	ctx, cancel := context.WithTimeout(parent, timeout)
 	defer cancel()
 	
-	cmd := exec.CommandContext(ctx, "git", args...)
+	gitArgs, env, err := g.authCommand(args)
+	if err != nil {
+		return err
+	}
+	cmd := exec.CommandContext(ctx, "git", gitArgs...)
 	cmd.Dir = g.baseDir
+	cmd.Env = env
 	
 	// Capture both stdout and stderr for better error messages
+	start := time.Now()
 	output, err := cmd.CombinedOutput()
+	slog.Debug("Ran git", "args", strings.Join(args, " "), "duration", time.Since(start), "error", err)
 	if err != nil {
+		if err := parent.Err(); err != nil {
+			return fmt.Errorf("git %s cancelled: %w", strings.Join(args, " "), err)
+		}
 		if ctx.Err() == context.DeadlineExceeded {
 			return fmt.Errorf("git %s timed out after %v", strings.Join(args, " "), timeout)
 		}
+		if authErr := g.authFailure(args[0], string(output)); authErr != nil {
+			return authErr
+		}
 		return fmt.Errorf("git %s failed: %s", strings.Join(args, " "), string(output))
 	}
 	
@@ -441,12 +544,18 @@ func (g *GitSync) getDetailedStatus() (string, error) {
 
 // PullChanges pulls changes from the remote repository with conflict resolution
 func (g *GitSync) PullChanges() error {
+	return g.PullChangesContext(context.Background())
+}
+
+// PullChangesContext pulls changes from the remote repository, killing git
+// when ctx is cancelled
+func (g *GitSync) PullChangesContext(ctx context.Context) error {
 	if !g.IsEnabled() {
 		return nil // Silently skip if not enabled
 	}
 
 	// First, fetch the latest changes from remote
-	if err := g.runGitCommand("fetch", "origin"); err != nil {
+	if err := g.runGitCommandContext(ctx, "fetch", "origin"); err != nil {
 		return fmt.Errorf("failed to fetch from remote: %w", err)
 	}
 
@@ -457,14 +566,18 @@ func (g *GitSync) PullChanges() error {
 	}
 
 	if !behind {
-		return nil // Already up to date
+		slog.Debug("Already up to date with remote")
+		return nil
 	}
 
-	// Try to pull with merge strategy
-	err = g.runGitCommand("pull", "origin", g.getCurrentBranch())
+	// Merge rather than rebase, so conflicting edits can be merged per file
+	err = g.runGitCommandContext(ctx, "pull", "--no-rebase", "--no-edit", "origin", g.getCurrentBranch())
 	if err != nil {
+		if ctx.Err() != nil {
+			return err
+		}
 		// If pull failed, likely due to conflicts or divergent branches
-		return g.handlePullConflict(err)
+		return g.handlePullConflict(ctx, err)
 	}
 
 	return nil
@@ -485,10 +598,10 @@ func (g *GitSync) BackgroundSync(ctx context.Context, interval time.Duration) {
 			return
 		case <-ticker.C:
 			// Silently pull changes in background
-			if err := g.PullChanges(); err != nil {
+			if err := g.PullChangesContext(ctx); err != nil {
 				// Log but don't spam - only log once per error type
-				if !strings.Contains(err.Error(), "timeout") {
-					fmt.Printf("Background sync warning: %v\n", err)
+				if ctx.Err() == nil && !strings.Contains(err.Error(), "timeout") {
+					slog.Warn("Background sync failed", "error", err)
 				}
 			}
 		}
@@ -512,7 +625,8 @@ func (g *GitSync) getCurrentBranch() string {
 	return branch
 }
 
-// isBehindRemote checks if local branch is behind remote
+// isBehindRemote checks if the remote branch has commits the local branch
+// lacks, including when both have diverged
 func (g *GitSync) isBehindRemote() (bool, error) {
 	branch := g.getCurrentBranch()
 	
@@ -537,45 +651,45 @@ func (g *GitSync) isBehindRemote() (bool, error) {
 	
 	// If hashes are different, check if we're behind
 	if remoteHash != localHash {
-		// Check if remote hash is reachable from local (i.e., we're behind)
-		mergeBaseCmd := exec.Command("git", "merge-base", "--is-ancestor", localHash, remoteHash)
+		// Check if remote hash is reachable from local (i.e., we're not behind)
+		mergeBaseCmd := exec.Command("git", "merge-base", "--is-ancestor", remoteHash, localHash)
 		mergeBaseCmd.Dir = g.baseDir
 		err := mergeBaseCmd.Run()
-		return err == nil, nil // If no error, we're behind
+		return err != nil, nil // If no error, we already have the remote commits
 	}
 	
 	return false, nil // Up to date
 }
 
 // handlePullConflict handles pull conflicts by attempting automatic resolution
-func (g *GitSync) handlePullConflict(pullErr error) error {
+func (g *GitSync) handlePullConflict(ctx context.Context, pullErr error) error {
 	errStr := pullErr.Error()
 	
 	// Handle divergent branches
 	if strings.Contains(errStr, "divergent") || strings.Contains(errStr, "hint: You have divergent branches") {
-		fmt.Printf("Detected divergent branches, attempting merge strategy...\n")
+		slog.Info("Detected divergent branches, attempting merge strategy")
 		
 		// Try merge strategy
-		err := g.runGitCommand("pull", "--strategy=recursive", "--strategy-option=theirs", "origin", g.getCurrentBranch())
+		err := g.runGitCommandContext(ctx, "pull", "--strategy=recursive", "--strategy-option=theirs", "origin", g.getCurrentBranch())
 		if err == nil {
 			return nil // Merge successful
 		}
 		
 		// If merge failed, try rebase
-		fmt.Printf("Merge failed, attempting rebase...\n")
-		err = g.runGitCommand("pull", "--rebase", "origin", g.getCurrentBranch())
+		slog.Info("Merge failed, attempting rebase")
+		err = g.runGitCommandContext(ctx, "pull", "--rebase", "origin", g.getCurrentBranch())
 		if err == nil {
 			return nil // Rebase successful
 		}
 		
 		// If both failed, warn user but don't reset automatically
-		fmt.Printf("Both merge and rebase failed. Manual intervention may be required.\n")
+		slog.Warn("Both merge and rebase failed; manual intervention may be required")
 		return fmt.Errorf("automatic conflict resolution failed: %w", pullErr)
 	}
 	
 	// Handle merge conflicts
 	if strings.Contains(errStr, "conflict") || strings.Contains(errStr, "CONFLICT") {
-		fmt.Printf("Detected merge conflicts, preferring remote version for safety...\n")
+		slog.Info("Detected merge conflicts, merging the conflicting files")
 		return g.resolveConflictsAutomatically()
 	}
 	
@@ -597,14 +711,20 @@ func (g *GitSync) resolveConflictsAutomatically() error {
 		return fmt.Errorf("no conflicted files found")
 	}
 	
-	// For each conflicted file, prefer remote version (safer for prompt files)
+	// Merge each conflicted file field by field where possible, otherwise
+	// prefer the remote version (safer for prompt files)
+	var withMarkers []string
 	for _, file := range conflictedFiles {
 		if file == "" {
 			continue
 		}
 		
-		// Accept remote version
-		if err := g.runGitCommand("checkout", "--theirs", file); err != nil {
+		if clean, merged := g.mergeConflictedFile(file); merged {
+			if !clean {
+				withMarkers = append(withMarkers, file)
+			}
+		} else if err := g.runGitCommand("checkout", "--theirs", file); err != nil {
+			// Accept remote version
 			return fmt.Errorf("failed to resolve conflict in %s: %w", file, err)
 		}
 		
@@ -619,6 +739,9 @@ func (g *GitSync) resolveConflictsAutomatically() error {
 		return fmt.Errorf("failed to complete merge: %w", err)
 	}
 	
-	fmt.Printf("Successfully resolved conflicts in %d files\n", len(conflictedFiles))
+	slog.Info("Resolved merge conflicts", "files", len(conflictedFiles))
+	for _, file := range withMarkers {
+		slog.Warn("Both versions of the conflicting lines were kept between conflict markers; edit the file to choose", "file", file)
+	}
 	return nil
 }
\ No newline at end of file
@@ -0,0 +1,65 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCommitMessage(t *testing.T) {
+	g := NewGitSync(t.TempDir())
+	now := time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC)
+
+	if got := g.commitMessage("Update prompt code-review to v1.0.3", now); got != "Update prompt code-review to v1.0.3 - 2024-03-01 09:30:00" {
+		t.Errorf("Unexpected default message %q", got)
+	}
+
+	g.SetCommitMessage("prompts: {message}")
+	if got := g.commitMessage("Delete prompt old", now); got != "prompts: Delete prompt old" {
+		t.Errorf("Unexpected templated message %q", got)
+	}
+}
+
+func TestSyncPathsCommitsOnlyGivenPaths(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	dir := t.TempDir()
+	git := func(args ...string) string {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %s: %v\n%s", strings.Join(args, " "), err, output)
+		}
+		return strings.TrimSpace(string(output))
+	}
+	git("init", "-q")
+	git("config", "user.email", "test@example.com")
+	git("config", "user.name", "Test")
+
+	for _, name := range []string{"prompts/a.md", "prompts/b.md"} {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		os.MkdirAll(filepath.Dir(path), 0755)
+		if err := os.WriteFile(path, []byte(name), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	g := NewGitSync(dir)
+	g.enabled = true
+	g.SetCommitMessage("{message}")
+
+	// There is no remote, so the push fails after the commit
+	g.SyncPaths("Create prompt a", "prompts/a.md", "prompts/missing.md")
+
+	if subject := git("log", "-1", "--format=%s"); subject != "Create prompt a" {
+		t.Errorf("Unexpected commit subject %q", subject)
+	}
+	if files := git("show", "--name-only", "--format=", "HEAD"); files != "prompts/a.md" {
+		t.Errorf("Expected only prompts/a.md to be committed, got %q", files)
+	}
+}
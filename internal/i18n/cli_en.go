@@ -0,0 +1,1158 @@
+package i18n
+
+// cliMessages are the English usage, help and messages of the CLI commands,
+// by ID
+var cliMessages = map[string]string{
+	"cli.usage": `pocket-prompt - Headless CLI mode
+
+Usage: pocket-prompt <command> [options]
+
+Commands:
+  list, ls              List all prompts
+  search <query>        Search prompts  
+  get, show <id>        Show a specific prompt
+  create, new <id>      Create a new prompt
+  edit <id>             Edit an existing prompt (opens $EDITOR without flags)
+  delete, rm <id>...    Delete prompts by ID, --query or --tag
+  copy <id>             Copy prompt to clipboard
+  duplicate <id> [new]  Clone a prompt under a new ID
+  render <id>           Render prompt with variables
+  watch <id>            Re-render a prompt whenever its file changes
+  catalog               Write a markdown index of the library, grouped by tag
+  locale                Show the language of messages, or start a translation
+  templates             List templates
+  template              Template management (create, edit, delete, show)
+  snippets              Reusable fragments included with {{snippet:name}}
+  tags                  List all tags (rename, merge)
+  tag                   Add or remove tags on many prompts (add, remove)
+  archive               Manage archived prompts
+  search-saved          Manage saved searches
+  boolean-search        Boolean search operations (create, edit, delete, list, run)
+  export                Export prompts and templates
+  import                Import prompts and templates
+  git                   Git synchronization
+  backup                Back up the library to a timestamped archive
+  restore [backup]      List backups or restore the library from one
+  conflicts             List or resolve conflict copies left by file sync services
+  plugins               List plugin commands
+  lint                  Check prompts and templates for problems
+  dedupe                Find prompts with duplicate content, and merge or delete them
+  rate <id> <1-5>       Rate how well a prompt works (0 clears the rating)
+  attach <id> <file>    Add an example or schema file to a prompt
+  secret set <name>     Store the value of a secret variable in the keychain
+  pick [query]          Pick a prompt interactively and print its ID or content
+  tmux-popup [query]    Pick, fill and copy or paste a prompt from a tmux popup
+  serve --stdio         Answer JSON-RPC requests on stdin for editor extensions
+  query                 Answer one JSON request on stdin, for editor plugins
+  help                  Show help
+
+Use 'pocket-prompt help <command>' for detailed help on a specific command.`,
+
+	"cli.usage.plugins": "Plugins:",
+
+	"cli.help.list": `list - List all prompts
+
+Usage: pocket-prompt list [options]
+
+Options:
+  --format, -f <format>  Output format (table, json, ids, alfred, raycast, default)
+  --tag, -t <tag>        Filter by tag ("ai/*" includes nested tags such as ai/agents;
+                         "*" and "?" are wildcards); repeatable
+  --match <any|all>      With several --tag, whether a prompt needs one of the
+                         tags or all of them (default: all)
+  --namespace, -n <dir>  Only prompts under a directory namespace (e.g. team/marketing)
+  --min-rating <n>       Only prompts rated at least n stars (see rate)
+  --archived-only, -a    Show only archived prompts (also --archived)
+  --include-archived     Show archived versions alongside active prompts
+  --created-after <date>, --created-before <date>
+  --updated-after <date>, --updated-before <date>
+                         Filter by date (YYYY-MM-DD or RFC 3339); after
+                         includes the date, before excludes it
+
+The alfred format is the JSON of an Alfred Script Filter, and raycast the
+same items for a Raycast list; each item's arg is the prompt ID.
+
+Examples:
+  pocket-prompt list --tag code --tag review          # Tagged both
+  pocket-prompt list --tag code --tag eval --match any  # Tagged either
+
+Prompts in subdirectories of prompts/ get namespaced IDs such as
+team/marketing/launch. Commands that take an ID also accept the end of a
+namespaced ID (e.g. launch) when it matches only one prompt.`,
+
+	"cli.help.search": `search - Search prompts
+
+Usage: pocket-prompt search <query> [options]
+
+Options:
+  --format, -f <format>  Output format (table, json, ids, alfred, raycast, default)
+  --boolean, -b          Use boolean expression search
+  --sort <order>         relevance (default), updated or usage
+  --min-rating <n>       Only prompts rated at least n stars (see rate)
+  --include-archived     Also search archived versions
+  --archived-only        Search only archived versions
+  --created-after <date>, --created-before <date>
+  --updated-after <date>, --updated-before <date>
+                         Filter by date (YYYY-MM-DD or RFC 3339)
+
+Prefix a term with - or NOT to exclude prompts containing it, e.g.
+agent -draft -test or agent NOT "first draft". The query can also filter by
+date with created: and updated: terms, using >, >=, <, <= or a bare date
+for that day: updated:>2024-01-01.
+
+Examples:
+  pocket-prompt search "machine learning"
+  pocket-prompt search agent -draft -test
+  pocket-prompt search summarize --sort usage
+  pocket-prompt search review --min-rating 4
+  pocket-prompt search "review updated:>=2024-06-01 updated:<2024-07-01"
+  pocket-prompt list --updated-after 2024-06-01 --format table
+  pocket-prompt search --boolean "(ai AND analysis) OR writing"`,
+
+	"cli.help.get": `get, show - Show a prompt
+
+Usage: pocket-prompt get <id> [options]
+
+Options:
+  --format, -f <format>  Output format (json, or the default text)
+  --field <f1,f2>        Print only these fields, bare, in order: id, title,
+                         description, content, tags, version, namespace,
+                         template, rating, notes, draft, created_at,
+                         updated_at, metadata, metadata.<key>
+  --render, -r           Render the prompt through its template
+  --var <name=value>     Value for a template variable (repeatable)
+  --preset <name>        Start from one of the prompt's saved presets
+
+With --field, each field ends with a newline and a field without a value
+prints an empty line. Tags are one per line when they are the only field and
+joined with commas otherwise; content, notes and metadata can span several
+lines. With --render, content is the rendered text.
+
+Examples:
+  pocket-prompt get code-review --field content > prompt.txt
+  pocket-prompt get code-review --field tags | grep -x review
+  pocket-prompt get code-review --field title,version
+  pocket-prompt get code-review --render --var lang=go --field content`,
+
+	"cli.help.create": `create - Create a new prompt
+
+Usage: pocket-prompt create [<id>] [options]
+
+Without an ID, one is made from the title: "Code Review!" becomes
+code-review, or code-review-2 when that is taken.
+
+A prompt using a template must fill each required slot that has no
+default, with --slot or the slots field of its frontmatter. Values for
+slots the template doesn't declare are saved with a warning.
+
+Options:
+  --title <title>        Prompt title
+  --description <desc>   Prompt description
+  --content <content>    Prompt content
+  --template <id>        Template to use
+  --slot <name=value>    Fill a template slot (repeatable)
+  --default <name=value> Default value of a variable (repeatable)
+  --meta <key=value>     Set a custom metadata field (repeatable)
+  --notes <text>         Usage tips or caveats, shown with the prompt but never
+                         rendered or copied
+  --message <role=text>  Add a message to a multi-message prompt (repeatable;
+                         roles such as system, user and assistant)
+  --tags <tag1,tag2>     Comma-separated tags
+  --stdin                Read content from stdin
+  --file <path>          Read content from a file ("-" for stdin)
+  --clipboard, --paste   Read content from the clipboard
+  --encrypt              Store the body encrypted (see encryption in config.yaml)
+  --draft                Commit to the drafts branch until published (see git publish)
+
+Example:
+  pocket-prompt create my-prompt --title "My Prompt" --content "Hello world"
+  pocket-prompt create --title "Weekly Report" --file report.md     # ID weekly-report
+  pocket-prompt create review --title "Code Review" --file review.md
+  git diff | pocket-prompt create diff-summary --stdin
+  pocket-prompt create snippet --title "Snippet" --clipboard
+  pocket-prompt create pr-review --template review --slot language=Go --file pr.md
+  pocket-prompt create classify --title "Classify" --message system="Reply with one word" \
+    --message user="Great product!" --message assistant=positive --message user="{{text}}"
+  pocket-prompt create team/marketing/launch --title "Launch"   # saved in prompts/team/marketing/`,
+
+	"cli.help.tags": `tags - List, rename and merge tags
+
+Usage:
+  pocket-prompt tags                                   # List all tags
+  pocket-prompt tags --tree                            # Show nested tags (ai/agents) as a tree
+  pocket-prompt tags rename <old> <new> [--dry-run]
+  pocket-prompt tags merge <tag> [<tag>...] --into <tag> [--dry-run]
+
+Rename and merge rewrite the tags in every prompt's frontmatter and in
+saved searches that reference them. Tags match case-insensitively, so
+rename can also fix capitalization. Versions are kept and archived
+prompts are left unchanged.
+
+Examples:
+  pocket-prompt tags rename ml machine-learning
+  pocket-prompt tags merge llm gpt --into ai --dry-run`,
+
+	"cli.help.tag": `tag - Add or remove tags on many prompts
+
+Usage: pocket-prompt tag <add|remove> <tag[,tag...]> [<id>...] [options]
+
+Prompts are selected by ID, by a boolean tag expression, by a text search,
+or a combination, which narrows the selection. Retagging keeps versions and
+does not archive the previous tags.
+
+Options:
+  --query, -q <expr>     Prompts matching a boolean tag expression
+  --search, -s <text>    Prompts matching a fuzzy text search
+  --dry-run, --preview   List the prompts that would change
+
+Examples:
+  pocket-prompt tag add reviewed --query "(ai AND production) NOT draft"
+  pocket-prompt tag remove draft,wip --query "release"
+  pocket-prompt tag add marketing --search "launch" --dry-run
+  pocket-prompt tag add favorite summarize translate`,
+
+	"cli.help.delete": `delete - Delete prompts
+
+Usage: pocket-prompt delete <id>... [options]
+
+Deletes one or more prompts. Prompts can also be selected with a boolean
+tag expression or a tag, which narrow the IDs when both are given. When
+deleting several prompts, the matches are listed before confirming.
+
+Options:
+  --query, -q <expr>     Prompts matching a boolean tag expression
+  --tag, -t <tag>        Prompts with a tag ("ai/*" includes nested tags)
+  --dry-run, --preview   List the prompts that would be deleted
+  --force, -f            Delete without confirmation
+
+Examples:
+  pocket-prompt delete old-draft
+  pocket-prompt delete draft-1 draft-2 draft-3
+  pocket-prompt delete --tag scratch --dry-run
+  pocket-prompt delete --query "deprecated AND NOT keep" --force`,
+
+	"cli.help.edit": `edit - Edit a prompt
+
+Usage: pocket-prompt edit <id> [options]
+
+Without options, the prompt's markdown file opens in $VISUAL or $EDITOR
+(vi if neither is set). Field options update the prompt directly; add
+--open to review the result in the editor before saving. Saving archives
+the previous version and bumps the version. An invalid file can be
+corrected in the editor or discarded.
+
+Options:
+  --open, -o             Open the prompt in $EDITOR
+  --title <title>        Prompt title
+  --description <desc>   Prompt description
+  --content <content>    Prompt content
+  --template <id>        Template to use
+  --slot <name=value>    Fill a template slot (repeatable)
+  --remove-slot <name>   Clear a template slot
+  --default <name=value> Set the default value of a variable (repeatable)
+  --remove-default <name>
+                         Remove a variable's default value
+  --remove-preset <name> Remove a saved preset
+  --meta <key=value>     Set a custom metadata field (repeatable)
+  --remove-meta <key>    Remove a metadata field
+  --notes <text>         Replace the notes ("" clears them)
+  --message <role=text>  Replace the body with messages (repeatable)
+  --tags <tag1,tag2>     Replace the tags
+  --add-tag <tag>        Add a tag
+  --remove-tag <tag>     Remove a tag
+  --encrypt, --decrypt   Change whether the body is stored encrypted
+  --draft                Mark as a draft (publish with git publish)
+
+Examples:
+  pocket-prompt edit summarize
+  EDITOR="code --wait" pocket-prompt edit summarize
+  pocket-prompt edit summarize --add-tag reviewed`,
+
+	"cli.help.duplicate": `duplicate - Clone a prompt
+
+Usage: pocket-prompt duplicate <id> [new-id]
+
+Copies the prompt's content, tags and metadata to a new prompt at version
+1.0.0. The copy's metadata records the original in copied_from and
+copied_from_version. Without a new ID, "<id>-copy" is used.
+
+Examples:
+  pocket-prompt duplicate summarize
+  pocket-prompt duplicate summarize summarize-terse
+  pocket-prompt duplicate team/launch team/launch-b2b`,
+
+	"cli.help.template": `template - Template management
+
+Usage: pocket-prompt template <subcommand> [options]
+
+Subcommands:
+  create <id>     Create a new template
+  edit <id>       Edit an existing template
+  delete <id>     Delete a template
+  show <id>       Show template details
+  history <id>    List the archived versions of a template
+  rollback <id> <version>
+                  Restore an archived version, saved as a new version
+
+Saving a changed template archives the previous version to
+archive/templates/<id>-v<version>.md and bumps the version.
+
+Create Options:
+  --name <name>           Template name
+  --description <desc>    Template description
+  --content <content>     Template content
+  --slots <slot1,slot2>   Comma-separated slot names
+  --stdin                 Read content from stdin
+  --file <path>           Read content from a file ("-" for stdin)
+
+Edit Options:
+  --name <name>           Update template name
+  --description <desc>    Update template description
+  --content <content>     Update template content
+  --slots <slot1,slot2>   Update slot names
+
+Delete Options:
+  --force, -f             Force deletion without confirmation
+
+Examples:
+  pocket-prompt template create my-template --name "My Template" --content "Hello {{name}}"
+  pocket-prompt template edit my-template --content "Updated content"
+  pocket-prompt template history my-template
+  pocket-prompt template rollback my-template 1.0.0`,
+
+	"cli.help.boolean-search": `boolean-search - Manage boolean searches
+
+Usage: pocket-prompt boolean-search <subcommand> [options]
+
+Subcommands:
+  create <name> <expression>  Create a new saved boolean search
+  edit <name> <expression>    Edit an existing saved boolean search  
+  delete <name>               Delete a saved boolean search
+  list                        List all saved boolean searches
+  run <expression>            Execute a boolean search expression
+  run --saved <name>          Execute a saved boolean search
+  pin <name>                  Show a saved search as a folder at the top of
+                              the TUI library
+  unpin <name>                Remove a saved search from the library
+
+Run Options:
+  --include-archived          Also search archived versions
+  --archived-only             Search only archived versions
+
+Delete Options:
+  --force, -f                 Force deletion without confirmation
+
+Tags can be nested with slashes (ai/agents, ai/rag). A pattern ending in
+"/*" matches the tag and every tag beneath it, e.g. "tag:ai/* AND NOT draft".
+"*" matches any run of characters and "?" a single character, so "ai*"
+matches ai-tools and ai/agents and "*-draft" matches blog-draft.
+
+Examples:
+  pocket-prompt boolean-search create ai-search "(ai AND analysis) OR machine-learning"
+  pocket-prompt boolean-search run "(python AND tutorial) OR beginner"
+  pocket-prompt boolean-search run --saved ai-search
+  pocket-prompt boolean-search pin ai-search`,
+
+	"cli.help.export": `export - Export prompts and templates
+
+Usage: pocket-prompt export <type> [options]
+
+Types:
+  prompts     Export all prompts
+  templates   Export all templates
+  all         Export prompts and templates
+  bundle      Export the library as a zip of markdown files with a manifest
+  html        Generate a static HTML gallery of the library
+  jsonl       Export one JSON object per prompt (OpenAI chat format by default)
+  langchain   Export prompts as LangChain prompt templates
+
+Options:
+  --format, -f <format>   Export format (json; yaml or json for langchain)
+  --output, -o <file>     Output file (default: stdout)
+  --query, -q <expr>      Only prompts matching a boolean tag expression,
+                          e.g. "(ai AND production) NOT draft"; templates
+                          are limited to those the matching prompts use.
+                          Works with every export type.
+
+Bundle Export:
+  pocket-prompt export bundle [--output <file.zip>] [--query <expr>]
+
+  The zip holds the prompt, archive and template files as stored, plus a
+  manifest.json with their IDs, versions and SHA-256 hashes. Restore it
+  with "pocket-prompt import bundle <file.zip>".
+  (default output: pocket-prompt-YYYY-MM-DD.zip)
+
+HTML Export:
+  pocket-prompt export html [--output <dir>] [--title <title>]
+
+  Writes index.html with every prompt and a tag index, one page per tag
+  and one page per prompt with its rendered markdown and a copy button.
+  Encrypted prompts are left out. (default output: ./site)
+
+JSONL Export:
+  pocket-prompt export jsonl [options]
+
+  --schema, -s <schema>   chat: {"messages": [system, user, assistant]} (default)
+                          completion: {"prompt": ..., "completion": ...}
+                          fields: the fields chosen with --fields
+  --fields <f1,f2=key>    Fields to write, optionally renamed: id, title,
+                          description, content, tags, version, namespace,
+                          template, rating, notes, draft, created_at,
+                          updated_at, metadata, metadata.<key>
+  --system <text>         System message (default: the prompt's "system" metadata)
+  --tag, -t <tag>         Only prompts with this tag; repeatable, all must match
+  --namespace, -n <ns>    Only prompts in this namespace
+  --query, -q <expr>      Only prompts matching this boolean tag expression
+  --output, -o <file>     Output file (default: stdout)
+
+  The assistant message and completion come from the prompt's completion,
+  response, expected_output or ideal metadata when present.
+
+LangChain Export:
+  pocket-prompt export langchain [<id>...] [--format yaml|json] [--output <path>]
+
+  A single prompt is written to stdout or --output. Without IDs, every
+  prompt is written to one file per prompt under the --output directory.
+  {{var}} placeholders become f-string {var} fields and input_variables.
+
+Examples:
+  pocket-prompt export all --output backup.json
+  pocket-prompt export prompts --format json
+  pocket-prompt export all --query "(ai AND production) NOT draft"
+  pocket-prompt export bundle --query "team AND NOT draft" --output team.zip
+  pocket-prompt export bundle --output prompts.zip
+  pocket-prompt export html --output ./site --title "Team Prompts"
+  pocket-prompt export jsonl --tag eval --output train.jsonl
+  pocket-prompt export jsonl --fields id,content=input,metadata.ideal=ideal
+  pocket-prompt export langchain summarize --output summarize.yaml
+  pocket-prompt export langchain --format json --output langchain/`,
+
+	"cli.help.import": `import - Import prompts and templates
+
+Usage: 
+  pocket-prompt import claude-code [options]      # Import from Claude Code
+  pocket-prompt import obsidian <vault> [options] # Import from an Obsidian vault
+  pocket-prompt import notion <export> [options]  # Import from a Notion export
+  pocket-prompt import langchain <path> [options] # Import LangChain prompt templates
+  pocket-prompt import csv <file> [options]       # Import rows of a CSV file
+  pocket-prompt import bundle <zip> [options]     # Restore an export bundle
+  pocket-prompt import <file> [options]           # Import from JSON file
+
+Claude Code Import Options:
+  --path <path>           Directory to import from (default: current dir + ~/.claude)
+  --user                  When used with --path, also import from ~/.claude
+  --commands-only         Import only command files (.claude/commands/ and .claude/agents/)
+  --workflows-only        Import only GitHub Actions workflows
+  --config-only           Import only configuration files (CLAUDE.md)
+  --preview, --dry-run    Preview what would be imported without importing
+  --tags <tag1,tag2>      Additional tags to apply to imported items
+  --overwrite             Overwrite existing prompts/templates with same ID
+  --skip-existing         Skip items that already exist (no conflict errors)
+  --deduplicate           Skip duplicates based on original file path
+
+Obsidian Import Options:
+  --tag <tag>             Import notes tagged with this tag (default: prompt)
+  --folder <folder>       Import notes under this vault folder; with --tag, notes must match both
+  --preview, --dry-run    Preview what would be imported without importing
+  --tags <tag1,tag2>      Additional tags to apply to imported prompts
+  --overwrite             Overwrite existing prompts with same ID
+  --skip-existing         Skip prompts that already exist
+
+  Subfolders become ID namespaces, unmapped frontmatter fields are kept as
+  metadata, and [[wikilinks]] stay in the content with the linked prompts
+  recorded under metadata.references.
+
+Notion Import Options (export as "Markdown & CSV" and unzip first):
+  --tag-property <name>   Property whose values become tags; repeatable
+                          (default: Tags, Tag, Category, Categories, Labels)
+  --preview, --dry-run    Preview what would be imported without importing
+  --tags <tag1,tag2>      Additional tags to apply to imported prompts
+  --overwrite             Overwrite existing prompts with same ID
+  --skip-existing         Skip prompts that already exist
+
+  Notion's ID suffixes are removed from names, database properties are
+  merged into each page, and other properties are kept as metadata.
+
+LangChain Import Options (a .yaml/.json file or a directory of them):
+  --preview, --dry-run    Preview what would be imported without importing
+  --tags <tag1,tag2>      Additional tags to apply to imported prompts
+  --overwrite             Overwrite existing prompts with same ID
+  --skip-existing         Skip prompts that already exist
+
+  f-string {var}, mustache and jinja2 {{ var }} placeholders are converted
+  to {{var}}. Both load_prompt files and LangChain Hub JSON are accepted.
+
+CSV Import Options:
+  --map <field=column,...>  Map prompt fields to columns by header name or
+                            position (col1, col2, ...). Fields: id, title,
+                            content, description, tags, version
+  --delimiter <char>        Field delimiter (default: ",", "tab" for TSV)
+  --preview, --dry-run      Preview what would be imported without importing
+  --tags <tag1,tag2>        Additional tags to apply to imported prompts
+  --overwrite               Overwrite existing prompts with same ID
+  --skip-existing           Skip prompts that already exist
+
+  Unmapped fields use a column with the same header (or Name, Prompt,
+  Summary). Tags cells are split on commas or semicolons, IDs default to
+  the slugified title, and remaining columns are kept as metadata.
+
+Bundle Import Options:
+  --preview, --dry-run      Verify the bundle and list its contents without importing
+  --overwrite               Overwrite existing prompts/templates with same ID
+  --skip-existing           Skip items that already exist
+
+  Every file is checked against its manifest hash before anything is saved.
+
+File Import Options:
+  --format, -f <format>   Import format (json)
+
+Examples:
+  # Import from current project + ~/.claude/commands and ~/.claude/agents
+  pocket-prompt import claude-code
+
+  # Preview what would be imported
+  pocket-prompt import claude-code --preview
+
+  # Import from specific directory only (without ~/.claude)
+  pocket-prompt import claude-code --path /path/to/project
+
+  # Import from specific directory + ~/.claude directories
+  pocket-prompt import claude-code --path /path/to/project --user
+
+  # Import notes from the Prompts folder of an Obsidian vault
+  pocket-prompt import obsidian ~/Notes --folder Prompts
+
+  # Import a Notion prompt database
+  pocket-prompt import notion ~/Downloads/Export-1234 --tag-property Type
+
+  # Import a LangChain prompt saved with PromptTemplate.save()
+  pocket-prompt import langchain ./prompts/summarize.yaml
+
+  # Preview a spreadsheet import with an explicit column mapping
+  pocket-prompt import csv prompts.csv --map title=col1,content=col2,tags=col3 --preview
+
+  # Import from JSON backup
+  pocket-prompt import backup.json --format json`,
+
+	"cli.help.render": `render - Render prompt with variables
+
+Usage: pocket-prompt render <id> [options]
+
+Options:
+  --format, -f <format>  Output format (text, json, html)
+  --var <name=value>     Set variable value (can be used multiple times)
+  --preset <name>        Start from one of the prompt's saved presets
+  --save-preset <name>   Save the --var values as a preset of the prompt
+
+Variables not given fall back to the preset, then to the prompt's defaults
+field.
+
+Examples:
+  pocket-prompt render my-prompt --var name=John --var age=30
+  pocket-prompt render weekly-report --var team=platform --save-preset platform
+  pocket-prompt render weekly-report --preset platform`,
+
+	"cli.help.watch": `watch - Re-render a prompt whenever its file changes
+
+Usage: pocket-prompt watch <id> [options]
+
+Renders the prompt, then renders it again each time its file or its
+template is saved, so the output can be checked while the prompt is edited
+in another window. On a terminal the output is redrawn in place; piped, each
+version follows a line with the time it was made. Changes to snippets and
+attachments are picked up with the next change to the prompt. Press Ctrl+C
+to stop.
+
+Options:
+  --format, -f <format>  Output format (text, json, html)
+  --var <name=value>     Set variable value (can be used multiple times)
+  --preset <name>        Start from one of the prompt's saved presets
+  --output, -o <file>    Write each version to a file instead of stdout
+
+Examples:
+  pocket-prompt watch weekly-report --var team=platform
+  pocket-prompt watch review --format json -o review.json`,
+
+	"cli.help.catalog": `catalog - Write a markdown index of the library
+
+Usage: pocket-prompt catalog [options]
+
+Lists every prompt under each of its tags, with its title, description and
+a link to its file, as a front page for the git repository holding the
+library. The output only changes when the prompts do, so it can be
+regenerated before each commit. Drafts are left out.
+
+Options:
+  --output, -o <file>   Write to a file instead of stdout; links are made
+                        relative to it
+  --title <title>       Heading of the page (default: Prompt Library)
+
+Examples:
+  pocket-prompt catalog --output README.md
+  pocket-prompt catalog --title "Team Prompts" -o docs/catalog.md`,
+
+	"cli.help.locale": `locale - Show or translate the language of messages
+
+Usage: pocket-prompt locale [template]
+
+Without arguments, prints the locale and how many of the messages of the
+TUI and CLI it translates. The locale is POCKET_PROMPT_LANG, then locale in
+config.yaml, then LC_ALL, LC_MESSAGES or LANG. Translations are YAML files
+in the library's locales directory, such as locales/de.yaml, mapping
+message IDs to text; pt_BR falls back to pt.yaml, and any message left out
+stays in English. Placeholders such as %s and %d must be kept in order.
+
+Commands:
+  template   Print every message, translated where it already is, as the
+             start of a translation file
+
+Examples:
+  pocket-prompt locale
+  pocket-prompt locale template > ~/.pocket-prompt/locales/de.yaml`,
+
+	"cli.locale.current": "Locale: %s (%d of %d messages translated)",
+
+	"cli.help.pick": `pick - Pick a prompt interactively for scripts
+
+Usage: pocket-prompt pick [query] [options]
+
+Opens a small fuzzy picker on the terminal, even when stdout is piped, and
+prints the ID of the prompt chosen. Type to narrow the list, use the arrow
+keys (or ctrl+n/ctrl+p) to move, enter to pick and esc to cancel. Exits with
+an error when nothing is picked.
+
+Options:
+  --render, -r           Print the rendered content instead of the ID
+  --var <name=value>     Set variable value when rendering (can be repeated)
+  --preset <name>        Start from one of the prompt's saved presets
+
+Examples:
+  pocket-prompt pick
+  pocket-prompt pick review --render | pbcopy
+  pocket-prompt show "$(pocket-prompt pick)"
+  :r !pocket-prompt pick --render          (insert a prompt in vim)`,
+
+	"cli.help.tmux-popup": `tmux-popup - Pick and fill a prompt in a tmux popup
+
+Usage: pocket-prompt tmux-popup [query] [options]
+
+Picks a prompt, asks for each of its variables (enter keeps the value shown
+in brackets) and copies the result to the clipboard, or types it into a tmux
+pane with --paste. The picker fits short popups. Secret variables are read
+from the keychain.
+
+Options:
+  --copy, -c             Copy the result to the clipboard (default)
+  --paste, -p            Type the result into a pane with tmux send-keys
+  --pane, -t <pane>      Pane to paste into (default: the active pane)
+  --preset <name>        Start from one of the prompt's saved presets
+
+Examples (in ~/.tmux.conf):
+  bind-key P display-popup -E -w 80% -h 50% "pocket-prompt tmux-popup --paste --pane '#{pane_id}'"
+  bind-key Y display-popup -E -w 80% -h 50% "pocket-prompt tmux-popup"`,
+
+	"cli.help.serve": `serve - JSON-RPC over stdin and stdout for editor integrations
+
+Usage: pocket-prompt serve --stdio
+
+Reads JSON-RPC 2.0 requests, one per line, and writes one response line
+each until stdin is closed. Requests without an id are notifications and get
+no response. Logs go to stderr.
+
+Methods:
+  list     {"tag": "code", "limit": 20}             Prompts, optionally filtered
+  search   {"query": "review", "tag": ..., "limit": ...}
+  get      {"id": "code-review"}                    A prompt with its content
+  render   {"id": "code-review", "variables": {"lang": "Go"}, "preset": ...,
+            "format": "messages"}                   {"id", "content", "messages"}
+
+Example:
+  echo '{"jsonrpc": "2.0", "id": 1, "method": "search", "params": {"query": "review"}}' | pocket-prompt serve --stdio`,
+
+	"cli.help.query": `query - Answer one JSON request from stdin
+
+Usage: echo '<request>' | pocket-prompt query
+
+Reads a JSON object naming a method and its parameters, as serve --stdio
+takes them, and writes {"result": ...} or {"error": {"code", "message"}}
+to stdout, exiting with status 1 on errors. Results of list and search
+include each prompt's content unless "content" is false, so a plugin such
+as a Telescope picker needs one call per action.
+
+Examples:
+  echo '{"method": "search", "query": "review", "limit": 20}' | pocket-prompt query
+  echo '{"method": "list", "tag": "code", "content": false}' | pocket-prompt query
+  echo '{"method": "render", "id": "code-review", "variables": {"lang": "Go"}}' | pocket-prompt query`,
+
+	"cli.help.copy": `copy - Copy a rendered prompt to the clipboard
+
+Usage: pocket-prompt copy <id> [options]
+
+Options:
+  --format, -f <format>  text (default), json (messages for LLM APIs) or html
+                         (rich text that keeps formatting when pasted into
+                         Google Docs, Notion and similar editors)
+  --var <name=value>     Set variable value (can be used multiple times)
+  --preset <name>        Start from one of the prompt's saved presets
+
+Examples:
+  pocket-prompt copy code-review
+  pocket-prompt copy launch-email --format html`,
+
+	"cli.help.git": `git - Git synchronization
+
+Usage: pocket-prompt git <subcommand>
+
+Subcommands:
+  setup <url>     Setup Git repository (handles everything automatically)
+  setup --create <name>
+                  Create a GitHub repository and set up sync with it
+  status          Show git sync status
+  sync            Manual sync with remote repository  
+  pull            Pull changes from remote repository
+  enable          Enable git synchronization
+  disable         Disable git synchronization
+  branch [list]   List branches
+  branch create|switch|delete <name>
+                  Create, check out or delete a branch (delete --force
+                  deletes unmerged branches)
+  drafts          List prompts marked as drafts
+  publish [id...] Publish drafts, or all drafts, to the current branch
+
+Setup Options:
+  --create <name>       Repository to create, "name" or "owner/name"
+  --private             Make the new repository private (default)
+  --public              Make the new repository public
+  --description <text>  Description of the new repository
+
+setup initializes the library as a git repository, adds a .gitignore that
+keeps caches, backups and usage data local, makes the initial commit and
+pushes it. --create makes the repository with the gh CLI when installed,
+or otherwise through the GitHub API with the token in GITHUB_TOKEN.
+
+Drafts: prompts created or edited with --draft are committed to the drafts
+branch (git.draft_branch in config.yaml) instead of the current branch,
+without checking it out. publish clears the draft mark and commits them to
+the current branch; publishing the last drafts merges the drafts branch.
+
+Examples:
+  pocket-prompt git setup https://github.com/username/my-prompts.git
+  pocket-prompt git setup git@github.com:username/my-prompts.git
+  pocket-prompt git setup --create my-prompts --private
+  pocket-prompt git status
+  pocket-prompt git sync
+  pocket-prompt git branch
+  pocket-prompt git publish code-review`,
+
+	"cli.help.backup": `backup - Back up the library
+
+Usage: pocket-prompt backup [options]
+
+Writes the prompts, archived versions and templates to a timestamped zip
+bundle in .pocket-prompt/backups and removes the oldest backups beyond the
+number kept. Restore one with "pocket-prompt restore <backup>".
+
+Options:
+  --keep, -k <n>   Number of backups to keep (default: backup.keep or 10)
+  --list, -l       List existing backups, newest first
+
+Configuration (config.yaml):
+  backup:
+    dir: backups   # Backup directory, relative to the library root
+    keep: 20       # Number of backups to keep
+    auto: true     # Back up before destructive operations such as import --overwrite
+
+Examples:
+  pocket-prompt backup
+  pocket-prompt backup --keep 5
+  pocket-prompt backup --list`,
+
+	"cli.help.restore": `restore - Restore the library from a backup
+
+Usage: pocket-prompt restore [<backup>] [options]
+
+Without a backup, lists the available backups. A backup is its number in
+that list (1 is the newest), "latest", its file name or a path to a bundle.
+The prompts and templates that would be added or updated are shown before
+anything is changed; prompts that differ are archived before being
+replaced. Prompts that are not in the backup are kept.
+
+Options:
+  --prompt, -p <id,...>   Only restore these prompts (repeatable)
+  --preview, --dry-run    Show the changes without applying them
+  --yes, -y               Apply without asking for confirmation
+
+Examples:
+  pocket-prompt restore
+  pocket-prompt restore latest --preview
+  pocket-prompt restore 2 --prompt team/launch`,
+
+	"cli.help.plugins": `plugins - List plugin commands
+
+Usage: pocket-prompt plugins [list]
+
+Any executable named pocket-prompt-<name> in the plugins directory of the
+library or on your PATH adds <name> as a command, the way git and kubectl
+plugins do. Built-in commands take precedence, and the plugins directory
+comes before PATH.
+
+A plugin gets the arguments after its name, and on stdin a JSON object:
+  version    Input format version, currently 1
+  command    The plugin's name
+  args       Its arguments
+  library    Path of the library
+  prompts    Every prompt, with its content
+  templates  Every template
+
+POCKET_PROMPT_DIR holds the library path and POCKET_PROMPT_BIN the
+pocket-prompt executable, to run other commands with.
+
+Examples:
+  pocket-prompt plugins
+  pocket-prompt word-count --tag writing      # Runs pocket-prompt-word-count`,
+
+	"cli.help.lint": `lint - Check prompts and templates for problems
+
+Usage: pocket-prompt lint [options]
+
+Checks every active prompt and template. Errors:
+  invalid-file         The file doesn't load, or has invalid frontmatter
+  empty-content        No content
+  invalid-version      The version isn't major.minor.patch
+  duplicate-id         Another file has the same ID
+  unknown-template     The prompt's template doesn't exist
+  missing-slot         A required slot of the template has no value
+Warnings:
+  missing-title        No title (name for templates)
+  unknown-slot         A slot value the template doesn't declare
+  undeclared-variable  A template uses a variable that isn't one of its
+                       slots, or a prompt one its template doesn't declare
+
+Exits with status 1 when there are errors, or warnings with --strict.
+
+Options:
+  --format, -f <fmt>   Output format: text or json
+  --strict             Fail on warnings too
+
+Examples:
+  pocket-prompt lint
+  pocket-prompt lint --format json | jq '.[] | select(.severity == "error")'`,
+
+	"cli.help.snippets": `snippets - Manage reusable prompt fragments
+
+Usage: pocket-prompt snippets [subcommand] [options]
+
+Snippets are fragments such as personas, output format blocks and style
+guides, kept as markdown files in the library's snippets/ directory.
+Prompts and templates include one by name when rendered:
+
+  {{snippet:personas/reviewer}}
+
+A snippet can use variables and include other snippets. lint reports
+references to snippets that don't exist.
+
+Subcommands:
+  list                    List snippets (the default)
+  show <name>             Show a snippet and the prompts that use it
+  create <name>           Create a snippet
+  edit <name>             Change a snippet's description or content
+  delete <name>           Delete a snippet
+
+Options:
+  --description <desc>    Snippet description
+  --content <content>     Snippet content
+  --file <path>           Read content from a file ("-" for stdin)
+  --stdin                 Read content from stdin
+  --format, -f json       List snippets as JSON
+
+Examples:
+  pocket-prompt snippets create personas/reviewer --content "You are a meticulous senior reviewer."
+  pocket-prompt snippets create formats/json --file json-output.md --description "Strict JSON output"
+  pocket-prompt snippets show personas/reviewer`,
+
+	"cli.help.secret": `secret - Store values of secret variables
+
+Usage: pocket-prompt secret <set|delete> <name>
+
+Variables declared with secret: true, in a prompt's variables or a
+template's slots, are filled from the OS keychain when the prompt is
+rendered or copied, so API keys and client names never appear in prompt
+files or shell history:
+
+  variables:
+    - name: api_key
+      secret: true
+
+set reads the value from the terminal without echoing it, or from stdin
+when piped. A --var given on the command line still takes precedence.
+
+Secrets are kept in the macOS keychain or the Secret Service (secret-tool)
+by default. Set secrets.store to file in config.yaml to keep them in a file
+encrypted with the library's encryption settings instead. The HTTP server
+never fills secret variables.
+
+Examples:
+  pocket-prompt secret set api_key
+  op read op://vault/openai/key | pocket-prompt secret set api_key
+  pocket-prompt secret delete api_key`,
+
+	"cli.help.attach": `attach - Add an auxiliary file to a prompt
+
+Usage: pocket-prompt attach <id> <file> [--name <name>]
+
+Copies a file, such as a few-shot example or a JSON schema, into the
+library's attachments/ directory and lists it in the prompt's attachments
+field. The prompt's content can then inline it when rendered:
+
+  {{attach "examples/good-review.md"}}
+
+Attachments are included in bundle exports with the prompts that use them,
+and restored by bundle imports. lint reports attachments that are missing.
+
+Options:
+  --name, -n <name>      Path within attachments/ (default: the file name)
+
+Examples:
+  pocket-prompt attach code-review good-review.md --name examples/good-review.md
+  pocket-prompt attach extract-invoice invoice.schema.json`,
+
+	"cli.help.rate": `rate - Rate how well a prompt works
+
+Usage: pocket-prompt rate <id> <rating>
+
+The rating is a number of stars from 1 to 5, saved in the rating field of
+the prompt's frontmatter; 0 clears it. Rating a prompt doesn't create a new
+version. Filter by rating with list --min-rating and search --min-rating,
+or press 1-5 while viewing a prompt in the TUI.
+
+Examples:
+  pocket-prompt rate code-review 5
+  pocket-prompt rate old-summary 0
+  pocket-prompt list --min-rating 4`,
+
+	"cli.help.dedupe": `dedupe - Find prompts with duplicate content
+
+Usage: pocket-prompt dedupe [options]
+
+Groups the prompts whose bodies are identical, ignoring whitespace, or
+near-identical: at least the threshold share of their adjacent word pairs
+is the same. The oldest prompt of each group is kept. Encrypted prompts
+are skipped.
+
+Options:
+  --threshold, -t <n>  Similarity from 0 to 1 (default 0.9); 1 finds
+                       identical bodies only
+  --exact              Same as --threshold 1
+  --merge              Add the tags and metadata of the duplicates to the
+                       kept prompt, then delete the duplicates
+  --delete             Delete the duplicates
+  --dry-run, --preview List the groups without changing anything
+  --force              Merge or delete without confirmation
+  --format, -f <fmt>   Output format for the report: text or json
+
+Examples:
+  pocket-prompt dedupe
+  pocket-prompt dedupe --exact --merge
+  pocket-prompt dedupe --threshold 0.8 --format json`,
+
+	"cli.help.conflicts": `conflicts - List or resolve sync conflicts
+
+Usage: pocket-prompt conflicts [list]
+       pocket-prompt conflicts resolve <file>...|--all --keep <which>|--merge
+
+When the library is in a Dropbox, iCloud Drive or Syncthing folder, edits
+made on two devices at once leave "conflicted copy" files next to the
+original. They are skipped when loading prompts and listed here.
+
+Options:
+  --keep, -k <which>  newer (by updated_at, else modification time),
+                      original (delete the copy) or copy (replace the original)
+  --merge, -m         Combine both prompts: tags and metadata are joined and
+                      differing bodies are kept between conflict markers
+  --all, -a           Resolve every conflict
+
+Examples:
+  pocket-prompt conflicts
+  pocket-prompt conflicts resolve --all --keep newer
+  pocket-prompt conflicts resolve "prompts/review (conflicted copy 2024-01-02).md" --merge`,
+
+	"cli.help.unknown": "No help available for command: %s",
+
+	"cli.warning":   "Warning: %v",
+	"cli.error":     "Error: %v",
+	"cli.cancelled": "Cancelled",
+	"cli.errors":    "Errors encountered: %d",
+
+	"cli.create.created": "Created prompt: %s",
+
+	"cli.secret.value":   "Value for %s: ",
+	"cli.secret.stored":  "Stored secret %s",
+	"cli.secret.deleted": "Deleted secret %s",
+
+	"cli.edit.updated":         "Updated prompt: %s",
+	"cli.edit.no_changes":      "No changes",
+	"cli.edit.updated_version": "Updated prompt: %s (v%s)",
+	"cli.edit.again":           "Edit again? (y/N): ",
+
+	"cli.delete.confirm":      "Are you sure you want to delete prompt '%s'? (y/N): ",
+	"cli.delete.deleted":      "Deleted prompt: %s",
+	"cli.delete.no_matches":   "No matching prompts",
+	"cli.delete.would_delete": "Would delete %d prompts",
+	"cli.delete.confirm_many": "Delete these %d prompts? (y/N): ",
+	"cli.delete.deleted_many": "Deleted %d prompts",
+
+	"cli.duplicate.done": "Duplicated %s as %s",
+
+	"cli.copy.not_copied": "Content saved but not copied to clipboard.",
+
+	"cli.warning.usage": "Warning: failed to record usage: %v",
+
+	"cli.render.preset_saved": "Saved preset %s of %s",
+
+	"cli.catalog.written": "Wrote catalog to %s",
+
+	"cli.watch.watching": "Watching %s, press Ctrl+C to stop",
+	"cli.watch.rendered": "[%s] Rendered %s to %s",
+
+	"cli.column.id":      "ID",
+	"cli.column.title":   "Title",
+	"cli.column.version": "Version",
+	"cli.column.updated": "Updated",
+
+	"cli.list.top_level": "[top level]",
+
+	"cli.label.tags":        "Tags: %s",
+	"cli.label.id":          "ID: %s",
+	"cli.label.title":       "Title: %s",
+	"cli.label.version":     "Version: %s",
+	"cli.label.description": "Description: %s",
+	"cli.label.template":    "Template: %s",
+	"cli.label.slots":       "Slots:",
+	"cli.label.variables":   "Variables:",
+	"cli.label.secret":      "[secret]",
+	"cli.label.defaults":    "Defaults:",
+	"cli.label.presets":     "Presets:",
+	"cli.label.attachments": "Attachments: %s",
+	"cli.label.metadata":    "Metadata:",
+	"cli.label.encrypted":   "Encrypted: yes",
+	"cli.label.draft":       "Draft: yes",
+	"cli.label.rating":      "Rating: %s (%d/%d)",
+	"cli.label.created":     "Created: %s",
+	"cli.label.updated":     "Updated: %s",
+	"cli.label.notes":       "Notes:",
+	"cli.label.content":     "Content:",
+	"cli.label.name":        "Name: %s",
+	"cli.label.required":    "[required]",
+	"cli.label.default":     "[default: %s]",
+
+	"cli.snippets.deleted":          "Deleted snippet %s",
+	"cli.snippets.none":             "No snippets. Create one with: pocket-prompt snippets create <name> --content <text>",
+	"cli.snippets.reference":        "Reference: {{snippet:%s}}",
+	"cli.snippets.used_by":          "Used by:",
+	"cli.snippets.used_by_prompt":   "%s (prompt)",
+	"cli.snippets.used_by_template": "%s (template)",
+	"cli.snippets.updated":          "Updated snippet %s - include it with {{snippet:%s}}",
+	"cli.snippets.created":          "Created snippet %s - include it with {{snippet:%s}}",
+
+	"cli.tags.updated":          "Updated %d prompts",
+	"cli.tags.would_update":     "Would update %d prompts",
+	"cli.tags.searches_updated": " and saved searches: %s",
+
+	"cli.tag.would_update": "Would update %d of %d matching prompts",
+	"cli.tag.updated":      "Updated %d of %d matching prompts",
+
+	"cli.git.status":             "Git sync status: %s",
+	"cli.git.enabled":            "Git sync enabled",
+	"cli.git.disabled":           "Git sync disabled",
+	"cli.git.synced":             "Successfully synced with remote repository",
+	"cli.git.pulled":             "Successfully pulled changes from remote repository",
+	"cli.git.no_drafts":          "No drafts",
+	"cli.git.drafts":             "Drafts (committed to branch %s):",
+	"cli.git.nothing_to_publish": "No drafts to publish",
+	"cli.git.published":          "Published %s",
+	"cli.git.drafts_note":        "(drafts)",
+	"cli.git.branch_created":     "Created branch %s",
+	"cli.git.branch_switched":    "Switched to branch %s",
+	"cli.git.branch_deleted":     "Deleted branch %s",
+	"cli.git.configured":         "Git repository successfully configured!",
+
+	"cli.backup.none":    "No backups in %s",
+	"cli.backup.list":    "Backups in %s:",
+	"cli.backup.written": "Backup written to %s (%s)",
+
+	"cli.restore.hint":          "Restore one with: pocket-prompt restore <number|name|latest>",
+	"cli.restore.heading":       "Restore from %s:",
+	"cli.restore.update":        "~ %s %s (current v%s, backup v%s; changed: %s)",
+	"cli.restore.not_in_backup": "Not in backup, kept as is: %s",
+	"cli.restore.missing":       "Not found in backup: %s",
+	"cli.restore.summary":       "%d to add, %d to update, %d unchanged",
+	"cli.restore.nothing":       "Nothing to restore",
+	"cli.restore.preview_hint":  "To restore, run the same command without --preview",
+	"cli.restore.confirm":       "Apply this restore? Changed prompts are archived first. (y/N): ",
+	"cli.restore.done":          "Restored %d prompts and %d templates",
+
+	"cli.conflicts.none":     "No sync conflicts",
+	"cli.conflicts.list":     "Sync conflicts (%d):",
+	"cli.conflicts.entry":    "%s\n    copy of %s by %s, %s",
+	"cli.conflicts.hint":     "Resolve with: pocket-prompt conflicts resolve <file>... --keep newer|original|copy, or --merge",
+	"cli.conflicts.kept":     "Kept %s, removed %s",
+	"cli.conflicts.replaced": "Replaced %s with %s",
+	"cli.conflicts.merged":   "Merged %s into %s",
+
+	"cli.lint.clean":   "No problems found",
+	"cli.lint.summary": "%d errors, %d warnings",
+
+	"cli.attach.done": "Attached %s to %s; inline it with {{attach %q}}",
+
+	"cli.rate.cleared": "Cleared rating of %s",
+	"cli.rate.rated":   "Rated %s %s",
+
+	"cli.dedupe.none":           "No duplicate prompts",
+	"cli.dedupe.identical":      "identical",
+	"cli.dedupe.similar":        "%.0f%% similar",
+	"cli.dedupe.would_delete":   "Would delete %d duplicates in %d groups",
+	"cli.dedupe.confirm_delete": "Delete %d duplicates, keeping the first prompt of each group? (y/N): ",
+	"cli.dedupe.deleted":        "Deleted %d duplicates",
+	"cli.dedupe.would_merge":    "Would merge %d duplicates in %d groups",
+	"cli.dedupe.confirm_merge":  "Merge %d duplicates, keeping the first prompt of each group? (y/N): ",
+	"cli.dedupe.merged":         "Merged %d duplicates",
+
+	"cli.plugins.none":   "No plugins found. Add executables named %s<name> to %s or your PATH",
+	"cli.plugins.list":   "Plugins (%d):",
+	"cli.plugins.hidden": "(hidden by the built-in command)",
+
+	"cli.template.rolled_back":     "Restored %s v%s as v%s",
+	"cli.template.history_current": "v%-10s %s  (current)",
+	"cli.template.history_hint":    "Restore one with: pocket-prompt template rollback %s <version>",
+	"cli.template.created":         "Created template: %s",
+	"cli.template.updated":         "Updated template: %s",
+	"cli.template.confirm_delete":  "Are you sure you want to delete template '%s'? (y/N): ",
+	"cli.template.deleted":         "Deleted template: %s",
+
+	"cli.boolean.updated":        "Updated boolean search: %s",
+	"cli.boolean.confirm_delete": "Are you sure you want to delete boolean search '%s'? (y/N): ",
+	"cli.boolean.deleted":        "Deleted boolean search: %s",
+	"cli.boolean.pinned_note":    "(pinned)",
+	"cli.boolean.pinned":         "Pinned boolean search: %s",
+	"cli.boolean.unpinned":       "Unpinned boolean search: %s",
+
+	"cli.export.bundle_done":       "Exported %d prompts, %d archived versions, %d templates and %d attachments to %s",
+	"cli.export.skipped_encrypted": "Warning: skipped %d encrypted prompts",
+	"cli.export.gallery":           "Generated gallery of %d prompts in %s",
+	"cli.export.done":              "Exported %d prompts to %s",
+
+	"cli.import.complete":            "%s Import Complete:",
+	"cli.import.preview":             "%s Import Preview:",
+	"cli.import.prompts":             "Prompts: %d",
+	"cli.import.workflows":           "Workflows: %d",
+	"cli.import.preview_hint":        "To actually import these %s, run the same command without --preview",
+	"cli.import.done":                "Successfully imported %d %s from %s",
+	"cli.import.archived":            "Archived versions: %d",
+	"cli.import.templates":           "Templates: %d",
+	"cli.import.attachments":         "Attachments: %d",
+	"cli.import.bundle_preview_hint": "To actually restore this bundle, run the same command without --preview",
+	"cli.import.prompt_failed":       "Warning: failed to import prompt %s: %v",
+	"cli.import.imported_prompts":    "Imported %d prompts",
+	"cli.import.template_failed":     "Warning: failed to import template %s: %v",
+	"cli.import.imported_templates":  "Imported %d templates",
+}
@@ -0,0 +1,198 @@
+// Package i18n translates the messages of the TUI and CLI. Messages are
+// looked up by ID in the catalog of the current locale and fall back to
+// English, so a partial translation still leaves every message readable.
+//
+// Translations are YAML files in the library's locales directory, named
+// after the locale (de.yaml, pt_BR.yaml) and mapping message IDs to text.
+// Since they are synced with the library, a team shares its translations.
+package i18n
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultLocale is the locale of the built-in messages
+const DefaultLocale = "en"
+
+// Dir is the directory of the library holding translations
+const Dir = "locales"
+
+// EnvLocale overrides the locale of config.yaml on one device
+const EnvLocale = "POCKET_PROMPT_LANG"
+
+// english holds the built-in messages by ID
+var english = merge(tuiMessages, cliMessages)
+
+// merge combines catalogs of messages
+func merge(catalogs ...map[string]string) map[string]string {
+	merged := make(map[string]string)
+	for _, catalog := range catalogs {
+		for id, text := range catalog {
+			if _, ok := merged[id]; ok {
+				panic("i18n: duplicate message " + id)
+			}
+			merged[id] = text
+		}
+	}
+	return merged
+}
+
+var (
+	mu          sync.RWMutex
+	locale      = DefaultLocale
+	translation map[string]string // Messages of the current locale by ID
+)
+
+// T returns the message with the given ID in the current locale, formatted
+// with args like fmt.Sprintf. An unknown ID is returned as it is.
+func T(id string, args ...interface{}) string {
+	mu.RLock()
+	text, ok := translation[id]
+	mu.RUnlock()
+	if !ok {
+		if text, ok = english[id]; !ok {
+			text = id
+		}
+	}
+	if len(args) == 0 {
+		return text
+	}
+	return fmt.Sprintf(text, args...)
+}
+
+// Locale returns the current locale
+func Locale() string {
+	mu.RLock()
+	defer mu.RUnlock()
+	return locale
+}
+
+// Resolve picks the locale: POCKET_PROMPT_LANG, then the configured one,
+// then the usual LC_ALL, LC_MESSAGES and LANG variables
+func Resolve(configured string) string {
+	for _, value := range []string{os.Getenv(EnvLocale), configured,
+		os.Getenv("LC_ALL"), os.Getenv("LC_MESSAGES"), os.Getenv("LANG")} {
+		if value != "" {
+			return Normalize(value)
+		}
+	}
+	return DefaultLocale
+}
+
+// Normalize turns a locale as found in LANG, such as de_DE.UTF-8 or
+// sr_RS@latin, into the name of its catalog, de_DE. C and POSIX are
+// English.
+func Normalize(value string) string {
+	value, _, _ = strings.Cut(value, ".")
+	value, _, _ = strings.Cut(value, "@")
+	value = strings.ReplaceAll(value, "-", "_")
+	if value == "" || value == "C" || value == "POSIX" {
+		return DefaultLocale
+	}
+	return value
+}
+
+// Load makes locale current, with the translation from dir. A locale such
+// as pt_BR uses pt.yaml when there is no pt_BR.yaml. Without a translation
+// the English messages are used. Messages that can't be used, because
+// their ID is unknown or their placeholders differ from the English ones,
+// are left out and reported in the returned error along with the rest.
+func Load(dir, name string) error {
+	messages, path, err := readTranslation(dir, name)
+	var problems []string
+	for id, text := range messages {
+		if problem := checkMessage(id, text); problem != "" {
+			problems = append(problems, problem)
+			delete(messages, id)
+		}
+	}
+
+	mu.Lock()
+	locale = name
+	translation = messages
+	mu.Unlock()
+
+	if err != nil {
+		return err
+	}
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return fmt.Errorf("%s: %s", path, strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// readTranslation reads the catalog of a locale, or of its language
+func readTranslation(dir, name string) (map[string]string, string, error) {
+	candidates := []string{name}
+	if language, _, ok := strings.Cut(name, "_"); ok {
+		candidates = append(candidates, language)
+	}
+	for _, candidate := range candidates {
+		if candidate == DefaultLocale {
+			return nil, "", nil
+		}
+		path := filepath.Join(dir, candidate+".yaml")
+		data, err := os.ReadFile(path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, path, fmt.Errorf("failed to read translation: %w", err)
+		}
+		var messages map[string]string
+		if err := yaml.Unmarshal(data, &messages); err != nil {
+			return nil, path, fmt.Errorf("failed to parse translation %s: %w", path, err)
+		}
+		return messages, path, nil
+	}
+	return nil, "", nil
+}
+
+// verbPattern matches the fmt verbs of a message
+var verbPattern = regexp.MustCompile(`%[-+# 0]*[0-9]*(\.[0-9]+)?[a-zA-Z%]`)
+
+// checkMessage reports why a translated message can't be used
+func checkMessage(id, text string) string {
+	source, ok := english[id]
+	if !ok {
+		return fmt.Sprintf("unknown message %q", id)
+	}
+	if !slices.Equal(verbPattern.FindAllString(source, -1), verbPattern.FindAllString(text, -1)) {
+		return fmt.Sprintf("message %q must keep the placeholders %v", id, verbPattern.FindAllString(source, -1))
+	}
+	return ""
+}
+
+// Stats counts the messages of the current locale that are translated
+func Stats() (translated, total int) {
+	mu.RLock()
+	defer mu.RUnlock()
+	return len(translation), len(english)
+}
+
+// WriteTemplate writes every message as YAML, in the current locale where
+// it is translated and in English otherwise, as a start for a translation
+func WriteTemplate(w io.Writer) error {
+	messages := make(map[string]string, len(english))
+	for id := range english {
+		messages[id] = T(id)
+	}
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(messages); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
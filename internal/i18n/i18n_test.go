@@ -0,0 +1,98 @@
+package i18n
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	translation := "clipboard.copied: In die Zwischenablage kopiert!\n" +
+		"search.found: \"%d Prompts gefunden\"\n" +
+		"status.updated_version: \"%s aktualisiert\"\n" +
+		"no.such.message: Nichts\n"
+	if err := os.WriteFile(filepath.Join(dir, "de.yaml"), []byte(translation), 0644); err != nil {
+		t.Fatalf("Failed to write translation: %v", err)
+	}
+	t.Cleanup(func() { Load(dir, DefaultLocale) })
+
+	// de_AT falls back to the language's file
+	err := Load(dir, "de_AT")
+	if err == nil || !strings.Contains(err.Error(), "no.such.message") || !strings.Contains(err.Error(), "status.updated_version") {
+		t.Errorf("Expected the unusable messages reported, got %v", err)
+	}
+	if Locale() != "de_AT" {
+		t.Errorf("Expected locale de_AT, got %s", Locale())
+	}
+	if got := T("clipboard.copied"); got != "In die Zwischenablage kopiert!" {
+		t.Errorf("Expected the translation, got %q", got)
+	}
+	if got := T("search.found", 3); got != "3 Prompts gefunden" {
+		t.Errorf("Expected the formatted translation, got %q", got)
+	}
+	if got := T("status.updated_version", "review", "1.0.1"); got != "Updated review to v1.0.1" {
+		t.Errorf("Expected English for a translation missing a placeholder, got %q", got)
+	}
+	if got := T("status.cancelled"); got != "Cancelled" {
+		t.Errorf("Expected English for an untranslated message, got %q", got)
+	}
+	if translated, total := Stats(); translated != 2 || total != len(english) {
+		t.Errorf("Expected 2 of %d messages translated, got %d of %d", len(english), translated, total)
+	}
+
+	if err := Load(dir, "fr"); err != nil {
+		t.Errorf("Expected a locale without a translation to load, got %v", err)
+	}
+	if got := T("clipboard.copied"); got != "Copied to clipboard!" {
+		t.Errorf("Expected English, got %q", got)
+	}
+}
+
+func TestResolve(t *testing.T) {
+	t.Setenv(EnvLocale, "")
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_MESSAGES", "")
+	t.Setenv("LANG", "pt_BR.UTF-8")
+
+	if got := Resolve(""); got != "pt_BR" {
+		t.Errorf("Expected the locale from LANG, got %s", got)
+	}
+	if got := Resolve("de"); got != "de" {
+		t.Errorf("Expected the configured locale to win over LANG, got %s", got)
+	}
+	t.Setenv(EnvLocale, "sr_RS@latin")
+	if got := Resolve("de"); got != "sr_RS" {
+		t.Errorf("Expected %s to win, got %s", EnvLocale, got)
+	}
+	t.Setenv(EnvLocale, "")
+	t.Setenv("LANG", "C")
+	if got := Resolve(""); got != DefaultLocale {
+		t.Errorf("Expected English for the C locale, got %s", got)
+	}
+}
+
+// TestMessagesExist checks every message the TUI and CLI ask for is in the
+// English catalog
+func TestMessagesExist(t *testing.T) {
+	call := regexp.MustCompile(`i18n\.T\("([^"]+)"`)
+	for _, pattern := range []string{"../ui/*.go", "../cli/*.go", "../clipboard/*.go"} {
+		files, err := filepath.Glob(pattern)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, file := range files {
+			data, err := os.ReadFile(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, m := range call.FindAllStringSubmatch(string(data), -1) {
+				if _, ok := english[m[1]]; !ok {
+					t.Errorf("%s: unknown message %q", file, m[1])
+				}
+			}
+		}
+	}
+}
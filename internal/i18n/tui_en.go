@@ -0,0 +1,314 @@
+package i18n
+
+// tuiMessages are the English messages of the TUI, by ID
+var tuiMessages = map[string]string{
+	"boolean.placeholder":        "Enter boolean search (tag1 AND tag2 OR tag3, NOT tag4)",
+	"boolean.text_placeholder":   "Optional: text search within boolean results",
+	"boolean.title":              "Boolean Tag Search",
+	"boolean.edit_title":         "Edit Search: %s",
+	"boolean.available_tags":     "Available tags: ",
+	"boolean.expression":         "Expression: ",
+	"boolean.results":            "Results (%d):",
+	"boolean.searching":          "Searching...",
+	"boolean.no_results":         "No results found",
+	"boolean.help":               "Tab: cycle focus • Enter: search • Esc: close",
+	"boolean.examples":           "Examples:",
+	"boolean.text_help":          "Text filter searches within boolean results using fuzzy matching",
+	"boolean.more_help_expanded": "↑/↓: navigate results • Ctrl+s: save search • Ctrl+g: less help",
+	"boolean.more_help":          "Ctrl+g: more help • ",
+
+	"search.expression_label":  "Boolean Expression:",
+	"search.text_label":        "Text Filter (optional):",
+	"search.autocomplete_help": "Ctrl+Space/→: accept suggestion • ↑/↓: navigate suggestions",
+	"search.found":             "Found %d prompts",
+	"search.cleared":           "Search cleared - showing all prompts",
+
+	"status.cancelled":                   "Cancelled",
+	"status.no_changes":                  "No changes",
+	"status.updated_version":             "Updated %s to v%s",
+	"status.git":                         "Git: ",
+	"status.boolean":                     "Boolean: ",
+	"status.git_unknown":                 "Git status unknown",
+	"status.synced":                      "Synced with remote repository",
+	"status.prompt_updated":              "Prompt updated! Previous version archived.",
+	"status.prompt_saved":                "Prompt saved successfully!",
+	"status.template_saved":              "Template saved successfully!",
+	"status.prompt_deleted":              "Prompt deleted successfully!",
+	"status.template_delete_unsupported": "Template deletion not yet implemented",
+	"status.duplicated":                  "Duplicated as %s - press e to edit",
+	"status.rating_cleared":              "Rating cleared",
+	"status.rated":                       "Rated ",
+	"status.copied_json":                 "Copied as JSON messages!",
+	"status.created":                     "Prompt created as %s",
+
+	"confirm.cancel":              "Cancel",
+	"confirm.help":                "y confirm • n/Esc cancel • ←/→ select • Enter choose",
+	"confirm.delete":              "Delete",
+	"confirm.delete_prompt_title": "Delete prompt?",
+	"confirm.delete_prompt":       "Delete the prompt %s? This can't be undone.",
+	"confirm.delete_search_title": "Delete saved search?",
+	"confirm.delete_search":       "Delete the saved search '%s'?",
+
+	"conflicts.confirm_title": "Replace with conflict copy?",
+	"conflicts.confirm":       "Overwrite %s with %s?",
+	"conflicts.overwrite":     "Overwrite",
+	"conflicts.kept":          "Kept %s",
+	"conflicts.replaced":      "Replaced %s with its conflict copy",
+	"conflicts.merged":        "Merged into %s; check it for conflict markers",
+	"conflicts.title":         "Sync conflicts (%d)",
+	"conflicts.help":          "n keep newer • m merge • o keep original • c keep copy • ↑/↓ select • ESC to close",
+
+	"error.resolve_conflict":       "Failed to resolve conflict",
+	"error.find_duplicates":        "Failed to find duplicates",
+	"error.remove_duplicates":      "Failed to remove duplicates",
+	"error.open_editor":            "Failed to open editor",
+	"error.editor":                 "Editor failed",
+	"error.read_edited":            "Failed to read edited file",
+	"error.edit_not_saved":         "Edit not saved",
+	"error.load_variables":         "Failed to load variables",
+	"error.copy":                   "Copy failed",
+	"error.search":                 "Search failed",
+	"error.delete":                 "Delete failed",
+	"error.save_layout":            "Failed to save layout",
+	"error.list_snippets":          "Failed to list snippets",
+	"error.delete_snippet":         "Failed to delete snippet",
+	"error.load_tags":              "Failed to load tags",
+	"error.warning":                "Warning",
+	"error.sync":                   "Sync failed",
+	"error.delete_original_search": "Failed to delete original search",
+	"error.save_updated_search":    "Failed to save updated search",
+	"error.save_search":            "Failed to save search",
+	"error.save":                   "Save failed",
+	"error.refresh":                "Failed to refresh list",
+	"error.duplicate":              "Duplicate failed",
+	"error.load_saved_searches":    "Failed to load saved searches",
+	"error.pin":                    "Pin failed",
+	"error.rate":                   "Rating failed",
+	"error.copy_json":              "JSON copy failed",
+	"error.copy_rich":              "Rich text copy failed",
+
+	"duplicates.none":          "No duplicate prompts",
+	"duplicates.confirm_title": "Delete duplicates?",
+	"duplicates.confirm":       "Delete %d duplicates of %s, keeping only %s?",
+	"duplicates.merged":        "Merged %d duplicates into %s",
+	"duplicates.deleted":       "Deleted %d duplicates of %s",
+	"duplicates.title":         "Duplicate prompts (%d groups)",
+	"duplicates.identical":     "identical",
+	"duplicates.help":          "m merge into the first • d delete the others • ↑/↓ select • ESC to close",
+	"duplicates.similar":       "%.0f%% similar",
+
+	"fill.title":     "Fill in %s (%d of %d)",
+	"fill.required":  "Required",
+	"fill.help":      "enter next • shift+tab back • Esc cancel",
+	"fill.help_last": "enter copy • shift+tab back • Esc cancel",
+
+	"form.id_placeholder":          "prompt-id (blank: from title)",
+	"form.title_placeholder":       "Prompt Title",
+	"form.description_placeholder": "Brief description of the prompt",
+	"form.tags_placeholder":        "ai, prompt-engineering, productivity (comma-separated)",
+	"form.template_placeholder":    "template-id (optional)",
+	"form.metadata_placeholder":    "author=jane, model=gpt-4o (optional)",
+	"form.content_placeholder":     "Enter your prompt content here...",
+	"form.matching_tags":           "Matching tags: %s (→ to accept, ctrl+n/ctrl+p to cycle)",
+	"form.nothing_to_preview":      "Nothing to preview yet",
+	"form.unavailable":             "No form available",
+	"form.version":                 "Version:",
+	"form.title":                   "Title:",
+	"form.description":             "Description:",
+	"form.tags":                    "Tags:",
+	"form.tags_help":               "Use comma-separated values for organization and discovery",
+	"form.template":                "Template Ref:",
+	"form.metadata":                "Metadata:",
+	"form.metadata_help":           "Custom fields as comma-separated key=value pairs",
+	"form.content":                 "Content:",
+	"form.content_help":            "Ctrl+r starts a message (system, user, assistant) for a multi-message prompt • Ctrl+o preview",
+	"form.help":                    "Tab next field • Ctrl+s save • Esc cancel",
+	"form.name":                    "Name:",
+	"form.slots":                   "Slots:",
+
+	"template_form.slots_placeholder":       "name:description:required:default, ...",
+	"template_form.name_placeholder":        "Template Name",
+	"template_form.description_placeholder": "Brief description of the template",
+	"template_form.content_placeholder":     "Enter template content with {{slots}}...",
+
+	"create.scratch":                   "Create from scratch",
+	"create.scratch_description":       "Start with a blank prompt",
+	"create.template":                  "Use a template",
+	"create.template_description":      "Start from an existing template",
+	"create.new_template":              "Create new template",
+	"create.new_template_description":  "Start with a blank template",
+	"create.title":                     "Create New Prompt",
+	"create.no_options":                "No options available",
+	"create.scratch_title":             "Create from Scratch",
+	"create.from_template_title":       "Create from Template",
+	"create.from_template_placeholder": "Template creation form will go here...\n\nPress Esc to go back",
+
+	"issues.title": "Problems in prompt files",
+	"issues.help":  "Fix the files in your editor, then press r to check again • ESC or ! to close",
+
+	"picker.placeholder": "Search prompts",
+	"picker.help":        "  %d/%d • enter pick • esc cancel",
+
+	"pinned.description": "Saved search • ",
+	"pinned.found":       "'%s': Found %d prompts",
+	"pinned.unpinned":    "Unpinned '%s' from the library",
+	"pinned.pinned":      "Pinned '%s' to the library",
+
+	"saved.deleted":     "Search '%s' deleted!",
+	"saved.updated":     "Search '%s' updated successfully!",
+	"saved.saved":       "Search '%s' saved successfully!",
+	"saved.none":        "No saved searches found. Create one with 'b' for boolean search.",
+	"saved.title":       "Saved Boolean Searches",
+	"saved.no_searches": "No saved searches available",
+	"saved.help":        "↑/↓ navigate • enter execute • e edit",
+	"saved.help_more":   "p pin/unpin • Ctrl+d delete • Esc back",
+
+	"preview.too_narrow": "Preview needs a wider window",
+	"preview.shown":      "Preview shown",
+	"preview.hidden":     "Preview hidden",
+
+	"save_search.name_placeholder":       "Enter search name",
+	"save_search.expression_placeholder": "Enter boolean expression (tag1 AND tag2 OR tag3)",
+	"save_search.text_placeholder":       "Optional: text filter",
+	"save_search.matches":                "✓ %d matches",
+	"save_search.invalid":                "Invalid expression",
+	"save_search.title":                  "Save Boolean Search",
+	"save_search.edit_title":             "Edit Boolean Search",
+	"save_search.name_label":             "Name:",
+	"save_search.help":                   "Tab: next field • Enter: save • Esc: cancel",
+	"save_search.edit_help":              "Tab: next field • Enter: update • Esc: cancel",
+
+	"snippets.none":          "No snippets - create one with: pocket-prompt snippets create <name>",
+	"snippets.copied":        "Copied {{snippet:%s}} - paste it into a prompt",
+	"snippets.confirm_title": "Delete snippet?",
+	"snippets.confirm":       "Delete the snippet %s? Prompts referencing it keep the reference.",
+	"snippets.deleted":       "Deleted snippet %s",
+	"snippets.title":         "Snippets (%d)",
+	"snippets.help":          "c/enter copy reference • d delete • ↑/↓ select • ESC to close",
+
+	"help.more":             "Ctrl+g for more",
+	"help.overview":         "Overview",
+	"help.title":            "Pocket Prompt - Help",
+	"help.overview1":        "A fast, keyboard-driven terminal app for managing AI prompts and templates.",
+	"help.overview2":        "Store, organize, search, and copy prompts with powerful tagging and templates.",
+	"help.navigation":       "Navigation & Basic Commands",
+	"help.key_navigate":     "Navigate lists and prompts",
+	"help.key_select":       "Select item / View prompt details",
+	"help.key_back":         "Go back to the previous screen / Close modals",
+	"help.key_forward":      "Go forward again after going back",
+	"help.key_quit":         "Quit application",
+	"help.key_help":         "Toggle this help modal",
+	"help.key_preview":      "Show or hide the preview pane beside the library",
+	"help.key_resize":       "Narrow or widen the preview pane",
+	"help.prompts":          "Prompt Management",
+	"help.key_new":          "Create new prompt (from scratch or template)",
+	"help.key_edit":         "Edit selected prompt",
+	"help.key_editor":       "Open the prompt being viewed in $EDITOR, saving it as a new version",
+	"help.key_duplicate":    "Duplicate selected prompt",
+	"help.key_copy":         "Copy prompt as plain text",
+	"help.key_copy_json":    "Copy prompt as JSON messages for LLM APIs",
+	"help.key_copy_rich":    "Copy prompt as rich text for Google Docs, Notion and the like",
+	"help.key_fill":         "Fill in the prompt's variables, then copy it rendered",
+	"help.key_save":         "Save prompt when editing",
+	"help.key_form_preview": "Preview the content as markdown when editing",
+	"help.key_delete":       "Delete prompt (asks to confirm)",
+	"help.key_sync":         "Commit and push changes with git sync",
+	"help.key_conflicts":    "Resolve conflicted copies left by Dropbox, iCloud or Syncthing",
+	"help.key_duplicates":   "Find prompts with duplicate content to merge or delete",
+	"help.key_snippets":     "Browse snippets and copy a {{snippet:name}} reference",
+	"help.key_rate":         "Rate the prompt being viewed (0 clears the rating)",
+	"help.search":           "Search & Discovery",
+	"help.key_search":       "Start fuzzy search (type to filter prompts)",
+	"help.key_tag_filter":   "Filter the library by a tag, alongside the search",
+	"help.key_boolean":      "Advanced boolean search with tags",
+	"help.key_saved":        "View and execute saved searches",
+	"help.key_pin":          "Pin a saved search as a library folder",
+	"help.key_tab":          "Switch focus in boolean search",
+	"help.key_save_search":  "Save current boolean search",
+	"help.templates":        "Templates",
+	"help.templates1":       "Templates are reusable prompt scaffolds with variable slots",
+	"help.templates2":       "Use {{variable_name}} syntax for substitution",
+	"help.boolean_examples": "Boolean Search Examples",
+	"help.files":            "File Organization",
+	"help.files_storage":    "Storage: ~/.pocket-prompt/ (or POCKET_PROMPT_DIR, --dir)",
+	"help.files_prompts":    "Prompts: Stored as Markdown files with YAML frontmatter",
+	"help.files_templates":  "Templates: Reusable scaffolds in templates/ directory",
+	"help.files_archives":   "Archives: Old versions kept in archive/ for history",
+	"help.files_sync":       "Sync: Optional Git integration for backup and collaboration",
+	"help.tips":             "Pro Tips",
+	"help.tip_tags":         "• Use descriptive tags for better organization and search",
+	"help.tip_templates":    "• Templates save time for similar prompt structures",
+	"help.tip_boolean":      "• Boolean search is powerful for large prompt libraries",
+	"help.tip_json":         "• JSON copy format works directly with LLM API calls",
+	"help.tip_keyboard":     "• All operations are keyboard-driven for speed",
+	"help.tip_history":      "• Version history preserved when editing prompts",
+	"help.close":            "Press c to copy • ↑/↓ to scroll • ESC or ? to close",
+	"help.key_templates":    "Manage templates (create, edit, view)",
+	"help.example_and":      "Find prompts tagged with both 'ai' and 'writing'",
+	"help.example_or":       "Find prompts with either 'code' or 'python' tags",
+	"help.example_not":      "Exclude prompts tagged as 'draft'",
+	"help.example_group":    "Complex expressions with parentheses",
+
+	"detail.tags":          "Tags: ",
+	"detail.no_prompt":     "No prompt selected",
+	"detail.id_version":    "ID: %s • Version: %s",
+	"detail.last_edited":   " • Last edited: %s",
+	"detail.help":          "c copy • C fill in & copy • e edit",
+	"detail.help_more":     "y copy JSON • r copy rich text • E open in $EDITOR • x export • D duplicate • 1-5 rate • Esc back",
+	"detail.template_help": "e edit",
+
+	"tags.filter_placeholder": "type to narrow tags",
+	"tags.none":               "No tags yet - add some when editing a prompt",
+	"tags.filter_cleared":     "Tag filter cleared",
+	"tags.filtered":           "Showing prompts tagged %s",
+	"tags.filter_title":       "Filter by tag",
+	"tags.no_match":           "No matching tags",
+
+	"clipboard.plain_only": "Copied as plain text; rich text isn't supported here",
+	"clipboard.rich":       "Copied as rich text!",
+	"clipboard.copied":     "Copied to clipboard!",
+
+	"templates.none":         "No templates available",
+	"templates.select_title": "Select Template",
+	"templates.title":        "Template Management",
+
+	"view.unknown": "Unknown view mode",
+
+	"library.title":        "Pocket Prompt Library",
+	"library.help_loading": "Loading prompts... • q quit",
+	"library.help":         "enter view • e edit • n create",
+	"library.help_search":  "Ctrl+f modify search • Esc all prompts • q quit",
+	"library.help_more":    "/ search • t templates • s snippets • f saved searches • D duplicate",
+	"library.help_more2":   "# tag filter • v preview • </> resize preview • Ctrl+f boolean search • S sync • ? help • q quit",
+	"library.problems":     "⚠ %d problem(s) in prompt files • ! for details",
+	"library.conflicts":    "⚠ %d sync conflict(s) • C to resolve",
+
+	"menu.help":      "↑/↓ navigate • enter select",
+	"menu.help_back": "Esc back",
+
+	"edit.title": "Edit Prompt",
+	"edit.help":  "Tab next field • Ctrl+s save • Ctrl+d delete • Esc cancel",
+
+	"template_edit.title": "Edit Template",
+	"template_edit.help":  "Tab next field • arrows navigate • Ctrl+s save • Esc cancel",
+
+	"template.no_template": "No template selected",
+
+	"sync_info.title":           "GitHub Sync Information",
+	"sync_info.overview1":       "Backup and sync your personal prompt library with GitHub.",
+	"sync_info.overview2":       "This creates a separate repository for YOUR prompts and templates.",
+	"sync_info.setup":           "Setup",
+	"sync_info.create":          "Create a private GitHub repo and sync with it in one step:",
+	"sync_info.create_note":     "This uses the gh CLI, or GITHUB_TOKEN when gh is not installed.",
+	"sync_info.existing":        "Or use a repository you already created:",
+	"sync_info.usage":           "Usage",
+	"sync_info.prompts_dir":     "• YOUR prompts are stored in ~/.pocket-prompt/prompts/",
+	"sync_info.templates_dir":   "• YOUR templates are stored in ~/.pocket-prompt/templates/",
+	"sync_info.sync":            "• Sync your prompt library to GitHub:",
+	"sync_info.benefits":        "Benefits",
+	"sync_info.benefit_history": "✓ Version history for all prompts",
+	"sync_info.benefit_team":    "✓ Collaborate with team members",
+	"sync_info.benefit_backup":  "✓ Backup and restore capability",
+	"sync_info.benefit_review":  "✓ Review changes before committing",
+	"sync_info.help":            "Press c to copy • ESC or ? to close",
+}
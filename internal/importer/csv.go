@@ -0,0 +1,282 @@
+package importer
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dpshade/pocket-prompt/internal/models"
+)
+
+// CSVOptions configures a CSV import
+type CSVOptions struct {
+	ImportOptions
+
+	// Mapping maps prompt fields (id, title, content, description, tags,
+	// version) to columns, given by header name or 1-based position
+	// ("3" or "col3"). Fields left out are matched to a column of the same
+	// name; columns not mapped to a field are kept as metadata.
+	Mapping map[string]string
+	// Delimiter separates fields; defaults to a comma
+	Delimiter rune
+}
+
+// CSVFields are the prompt fields a column can be mapped to
+var CSVFields = []string{"id", "title", "content", "description", "tags", "version"}
+
+// csvFieldAliases lets common header names map without --map
+var csvFieldAliases = map[string]string{
+	"name":    "title",
+	"prompt":  "content",
+	"body":    "content",
+	"text":    "content",
+	"summary": "description",
+	"tag":     "tags",
+}
+
+var csvColumnRe = regexp.MustCompile(`^(?i:col)?(\d+)$`)
+
+// CSVImporter converts the rows of a spreadsheet export into prompts
+type CSVImporter struct{}
+
+// NewCSVImporter creates a new CSV importer
+func NewCSVImporter() *CSVImporter {
+	return &CSVImporter{}
+}
+
+// ParseCSVMapping parses "title=col1,content=Prompt,tags=3" into a mapping
+func ParseCSVMapping(spec string) (map[string]string, error) {
+	mapping := make(map[string]string)
+	for _, pair := range strings.Split(spec, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		field, column, ok := strings.Cut(pair, "=")
+		field = strings.ToLower(strings.TrimSpace(field))
+		column = strings.TrimSpace(column)
+		if !ok || field == "" || column == "" {
+			return nil, fmt.Errorf("invalid mapping %q (expected field=column)", pair)
+		}
+		if alias, ok := csvFieldAliases[field]; ok {
+			field = alias
+		}
+		if !isCSVField(field) {
+			return nil, fmt.Errorf("unknown field %q (valid fields: %s)", field, strings.Join(CSVFields, ", "))
+		}
+		mapping[field] = column
+	}
+	return mapping, nil
+}
+
+func isCSVField(field string) bool {
+	for _, f := range CSVFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// Import reads the CSV file and converts every row into a prompt
+func (c *CSVImporter) Import(options CSVOptions) (*ImportResult, error) {
+	result := &ImportResult{
+		Prompts:   []*models.Prompt{},
+		Workflows: []*models.Prompt{},
+		Errors:    []error{},
+	}
+
+	file, err := os.Open(options.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open CSV file: %w", err)
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	if options.Delimiter != 0 {
+		reader.Comma = options.Delimiter
+	}
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CSV file: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("CSV file is empty")
+	}
+
+	header := records[0]
+	for i := range header {
+		header[i] = strings.TrimSpace(strings.TrimPrefix(header[i], "\ufeff"))
+	}
+
+	columns, err := resolveCSVColumns(header, options.Mapping)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := columns["content"]; !ok {
+		return nil, fmt.Errorf("no content column found; map one with --map content=<column>")
+	}
+	if _, ok := columns["title"]; !ok {
+		if _, ok := columns["id"]; !ok {
+			return nil, fmt.Errorf("no title or id column found; map one with --map title=<column>")
+		}
+	}
+
+	used := make(map[int]bool)
+	for _, col := range columns {
+		used[col] = true
+	}
+
+	info, _ := file.Stat()
+	now := time.Now()
+	if info != nil {
+		now = info.ModTime()
+	}
+
+	seen := make(map[string]int)
+	for n, record := range records[1:] {
+		line := n + 2 // 1-based, after the header
+		value := func(field string) string {
+			if col, ok := columns[field]; ok && col < len(record) {
+				return strings.TrimSpace(record[col])
+			}
+			return ""
+		}
+
+		if strings.TrimSpace(strings.Join(record, "")) == "" {
+			continue // Blank rows are common at the end of spreadsheet exports
+		}
+
+		title := value("title")
+		id := value("id")
+		if id == "" {
+			id = slugify(title)
+		}
+		if id == "" {
+			result.Errors = append(result.Errors, fmt.Errorf("row %d: no id or title", line))
+			continue
+		}
+		if title == "" {
+			title = id
+		}
+
+		// Rows with the same title get numbered IDs rather than overwriting each other
+		seen[id]++
+		if seen[id] > 1 {
+			id = fmt.Sprintf("%s-%d", id, seen[id])
+		}
+
+		content := value("content")
+		if content == "" {
+			result.Errors = append(result.Errors, fmt.Errorf("row %d (%s): empty content", line, id))
+			continue
+		}
+
+		version := value("version")
+		if version == "" {
+			version = "1.0.0"
+		}
+
+		tags := []string{"csv"}
+		tags = append(tags, strings.FieldsFunc(value("tags"), func(r rune) bool { return r == ',' || r == ';' })...)
+		tags = append(tags, options.Tags...)
+		tags = (&ClaudeCodeImporter{}).cleanTags(tags)
+
+		metadata := map[string]interface{}{
+			"source":        "csv",
+			"original_path": filepath.Base(options.Path),
+			"csv_row":       line,
+		}
+		for i, name := range header {
+			if used[i] || i >= len(record) || strings.TrimSpace(record[i]) == "" {
+				continue
+			}
+			if key := propertyKey(name); key != "" {
+				metadata[key] = strings.TrimSpace(record[i])
+			}
+		}
+
+		result.Prompts = append(result.Prompts, &models.Prompt{
+			ID:        id,
+			Version:   version,
+			Name:      title,
+			Summary:   value("description"),
+			Content:   strings.ReplaceAll(content, "\r\n", "\n"),
+			Tags:      tags,
+			CreatedAt: now,
+			UpdatedAt: now,
+			FilePath:  filepath.Join("prompts", filepath.FromSlash(id)+".md"),
+			Metadata:  metadata,
+		})
+	}
+
+	return result, nil
+}
+
+// resolveCSVColumns maps each prompt field to a column index
+func resolveCSVColumns(header []string, mapping map[string]string) (map[string]int, error) {
+	columns := make(map[string]int)
+
+	byName := make(map[string]int, len(header))
+	for i, name := range header {
+		if _, ok := byName[strings.ToLower(name)]; !ok {
+			byName[strings.ToLower(name)] = i
+		}
+	}
+
+	fields := make([]string, 0, len(mapping))
+	for field := range mapping {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		column := mapping[field]
+		if i, ok := byName[strings.ToLower(column)]; ok {
+			columns[field] = i
+			continue
+		}
+		if m := csvColumnRe.FindStringSubmatch(column); m != nil {
+			n, _ := strconv.Atoi(m[1])
+			if n < 1 || n > len(header) {
+				return nil, fmt.Errorf("column %s for %s is out of range (file has %d columns)", column, field, len(header))
+			}
+			columns[field] = n - 1
+			continue
+		}
+		return nil, fmt.Errorf("column %q for %s not found (columns: %s)", column, field, strings.Join(header, ", "))
+	}
+
+	used := make(map[int]bool, len(columns))
+	for _, i := range columns {
+		used[i] = true
+	}
+
+	// Unmapped fields fall back to an unused column with a matching header
+	for i, name := range header {
+		if used[i] {
+			continue
+		}
+		field := strings.ToLower(name)
+		if alias, ok := csvFieldAliases[field]; ok {
+			field = alias
+		}
+		if !isCSVField(field) {
+			continue
+		}
+		if _, mapped := mapping[field]; mapped {
+			continue
+		}
+		if _, taken := columns[field]; !taken {
+			columns[field] = i
+			used[i] = true
+		}
+	}
+
+	return columns, nil
+}
@@ -0,0 +1,315 @@
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/dpshade/pocket-prompt/internal/models"
+	"gopkg.in/yaml.v3"
+)
+
+// LangChainPrompt is a LangChain prompt template file as written by
+// PromptTemplate.save() and read by load_prompt()
+type LangChainPrompt struct {
+	Type           string                 `yaml:"_type" json:"_type"`
+	InputVariables []string               `yaml:"input_variables" json:"input_variables"`
+	Template       string                 `yaml:"template,omitempty" json:"template,omitempty"`
+	TemplatePath   string                 `yaml:"template_path,omitempty" json:"template_path,omitempty"`
+	TemplateFormat string                 `yaml:"template_format,omitempty" json:"template_format,omitempty"`
+	Tags           []string               `yaml:"tags,omitempty" json:"tags,omitempty"`
+	Metadata       map[string]interface{} `yaml:"metadata,omitempty" json:"metadata,omitempty"`
+}
+
+// langChainSerialized is the newer serialization used by LangChain Hub, e.g.
+// {"lc": 1, "type": "constructor", "id": [..., "PromptTemplate"], "kwargs": {...}}
+type langChainSerialized struct {
+	LC     int             `json:"lc"`
+	Type   string          `json:"type"`
+	ID     []string        `json:"id"`
+	Kwargs LangChainPrompt `json:"kwargs"`
+}
+
+// LangChainImporter converts LangChain prompt template files into prompts
+type LangChainImporter struct{}
+
+// NewLangChainImporter creates a new LangChain importer
+func NewLangChainImporter() *LangChainImporter {
+	return &LangChainImporter{}
+}
+
+// Import reads a LangChain prompt file, or every .yaml/.yml/.json file in a directory
+func (l *LangChainImporter) Import(options ImportOptions) (*ImportResult, error) {
+	result := &ImportResult{
+		Prompts:   []*models.Prompt{},
+		Workflows: []*models.Prompt{},
+		Errors:    []error{},
+	}
+
+	info, err := os.Stat(options.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", options.Path, err)
+	}
+
+	var files []string
+	if info.IsDir() {
+		err := filepath.Walk(options.Path, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			switch strings.ToLower(filepath.Ext(path)) {
+			case ".yaml", ".yml", ".json":
+				if !info.IsDir() {
+					files = append(files, path)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan %s: %w", options.Path, err)
+		}
+	} else {
+		files = []string{options.Path}
+	}
+
+	for _, path := range files {
+		prompt, err := l.importFile(path, options)
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Errorf("failed to import %s: %w", path, err))
+			continue
+		}
+		result.Prompts = append(result.Prompts, prompt)
+	}
+
+	return result, nil
+}
+
+func (l *LangChainImporter) importFile(path string, options ImportOptions) (*models.Prompt, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	lc, err := ParseLangChainPrompt(data)
+	if err != nil {
+		return nil, err
+	}
+
+	// template_path is relative to the file that references it
+	if lc.Template == "" && lc.TemplatePath != "" {
+		templatePath := lc.TemplatePath
+		if !filepath.IsAbs(templatePath) {
+			templatePath = filepath.Join(filepath.Dir(path), templatePath)
+		}
+		content, err := os.ReadFile(templatePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read template_path: %w", err)
+		}
+		lc.Template = string(content)
+	}
+
+	content, err := FromLangChainTemplate(lc.Template, lc.TemplateFormat)
+	if err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	id := slugify(name)
+	title := name
+	var summary string
+	metadata := map[string]interface{}{
+		"source":        "langchain",
+		"original_path": path,
+	}
+	if len(lc.InputVariables) > 0 {
+		metadata["input_variables"] = lc.InputVariables
+	}
+
+	// Metadata written by ToLangChainPrompt round-trips to the same prompt
+	for key, value := range lc.Metadata {
+		s, _ := value.(string)
+		switch key {
+		case "id":
+			if s != "" {
+				id = s
+			}
+		case "title":
+			if s != "" {
+				title = s
+			}
+		case "description":
+			summary = s
+		default:
+			metadata[key] = value
+		}
+	}
+
+	tags := append([]string{"langchain"}, lc.Tags...)
+	tags = append(tags, options.Tags...)
+	tags = (&ClaudeCodeImporter{}).cleanTags(tags)
+
+	now := time.Now()
+	return &models.Prompt{
+		ID:        id,
+		Version:   "1.0.0",
+		Name:      title,
+		Summary:   summary,
+		Content:   content,
+		Tags:      tags,
+		CreatedAt: now,
+		UpdatedAt: now,
+		FilePath:  filepath.Join("prompts", filepath.FromSlash(id)+".md"),
+		Metadata:  metadata,
+	}, nil
+}
+
+// ParseLangChainPrompt reads a LangChain prompt template in YAML or JSON,
+// in either the classic load_prompt format or the LangChain Hub serialization
+func ParseLangChainPrompt(data []byte) (*LangChainPrompt, error) {
+	var serialized langChainSerialized
+	if json.Unmarshal(data, &serialized) == nil && serialized.LC > 0 {
+		kind := ""
+		if len(serialized.ID) > 0 {
+			kind = serialized.ID[len(serialized.ID)-1]
+		}
+		if kind != "PromptTemplate" {
+			return nil, fmt.Errorf("unsupported LangChain object %q (only PromptTemplate can be imported)", kind)
+		}
+		return &serialized.Kwargs, nil
+	}
+
+	// YAML is a superset of JSON, so this covers both classic formats
+	var lc LangChainPrompt
+	if err := yaml.Unmarshal(data, &lc); err != nil {
+		return nil, fmt.Errorf("failed to parse LangChain prompt: %w", err)
+	}
+	if lc.Type != "" && lc.Type != "prompt" {
+		return nil, fmt.Errorf("unsupported LangChain prompt type %q (only \"prompt\" can be imported)", lc.Type)
+	}
+	if lc.Template == "" && lc.TemplatePath == "" {
+		return nil, fmt.Errorf("not a LangChain prompt: no template or template_path")
+	}
+	return &lc, nil
+}
+
+var (
+	// {name}, {name:>10}, {name!r} in Python format strings
+	fstringFieldRe = regexp.MustCompile(`^\{([A-Za-z_][A-Za-z0-9_.\[\]]*)(?:![rsa])?(?::[^{}]*)?\}`)
+	// {{ name }} and {{{ name }}} in jinja2 and mustache
+	doubleBraceVarRe = regexp.MustCompile(`\{\{\{?\s*([A-Za-z_][A-Za-z0-9_.]*)\s*\}?\}\}`)
+	// {{name}}, {{.name}} and {{ name }} in prompt content
+	promptVarRe = regexp.MustCompile(`\{\{\s*\.?([A-Za-z_][A-Za-z0-9_]*)\s*\}\}`)
+)
+
+// FromLangChainTemplate converts a LangChain template into {{var}} syntax
+func FromLangChainTemplate(template, format string) (string, error) {
+	switch format {
+	case "", "f-string":
+		var b strings.Builder
+		for i := 0; i < len(template); {
+			switch {
+			case strings.HasPrefix(template[i:], "{{"):
+				b.WriteByte('{') // Escaped literal brace
+				i += 2
+			case strings.HasPrefix(template[i:], "}}"):
+				b.WriteByte('}')
+				i += 2
+			case template[i] == '{':
+				m := fstringFieldRe.FindStringSubmatch(template[i:])
+				if m == nil {
+					return "", fmt.Errorf("invalid f-string template near %q", truncate(template[i:], 20))
+				}
+				b.WriteString("{{" + m[1] + "}}")
+				i += len(m[0])
+			default:
+				b.WriteByte(template[i])
+				i++
+			}
+		}
+		return b.String(), nil
+	case "mustache", "jinja2":
+		return doubleBraceVarRe.ReplaceAllString(template, "{{$1}}"), nil
+	default:
+		return "", fmt.Errorf("unsupported template_format %q", format)
+	}
+}
+
+// ToLangChainTemplate converts {{var}} content into an f-string template,
+// escaping literal braces, and returns the variables in order of appearance
+func ToLangChainTemplate(content string) (string, []string) {
+	var b strings.Builder
+	var variables []string
+	seen := make(map[string]bool)
+
+	escape := func(s string) string {
+		s = strings.ReplaceAll(s, "{", "{{")
+		return strings.ReplaceAll(s, "}", "}}")
+	}
+
+	last := 0
+	for _, loc := range promptVarRe.FindAllStringSubmatchIndex(content, -1) {
+		b.WriteString(escape(content[last:loc[0]]))
+		name := content[loc[2]:loc[3]]
+		b.WriteString("{" + name + "}")
+		if !seen[name] {
+			seen[name] = true
+			variables = append(variables, name)
+		}
+		last = loc[1]
+	}
+	b.WriteString(escape(content[last:]))
+
+	if variables == nil {
+		variables = []string{}
+	}
+	return b.String(), variables
+}
+
+// ToLangChainPrompt converts a prompt into a LangChain prompt template
+func ToLangChainPrompt(prompt *models.Prompt) *LangChainPrompt {
+	template, variables := ToLangChainTemplate(prompt.Content)
+
+	metadata := map[string]interface{}{"id": prompt.ID}
+	if prompt.Name != "" {
+		metadata["title"] = prompt.Name
+	}
+	if prompt.Summary != "" {
+		metadata["description"] = prompt.Summary
+	}
+
+	return &LangChainPrompt{
+		Type:           "prompt",
+		InputVariables: variables,
+		Template:       template,
+		TemplateFormat: "f-string",
+		Tags:           prompt.Tags,
+		Metadata:       metadata,
+	}
+}
+
+// MarshalLangChainPrompt encodes a LangChain prompt as "yaml" or "json"
+func MarshalLangChainPrompt(lc *LangChainPrompt, format string) ([]byte, error) {
+	switch format {
+	case "", "yaml", "yml":
+		return yaml.Marshal(lc)
+	case "json":
+		data, err := json.MarshalIndent(lc, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return append(data, '\n'), nil
+	default:
+		return nil, fmt.Errorf("unsupported LangChain file format: %s (use yaml or json)", format)
+	}
+}
+
+func truncate(s string, n int) string {
+	if len(s) > n {
+		return s[:n] + "..."
+	}
+	return s
+}
@@ -0,0 +1,331 @@
+package importer
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dpshade/pocket-prompt/internal/models"
+)
+
+// NotionOptions configures a Notion export import
+type NotionOptions struct {
+	ImportOptions
+
+	// TagProperties lists the page properties whose values become tags.
+	// Defaults to Tags, Tag, Category, Categories and Labels.
+	TagProperties []string
+}
+
+// NotionImporter converts an unzipped Notion "Markdown & CSV" export into prompts
+type NotionImporter struct{}
+
+// NewNotionImporter creates a new Notion importer
+func NewNotionImporter() *NotionImporter {
+	return &NotionImporter{}
+}
+
+var (
+	// Notion appends a 32 character hex ID to every exported file and folder
+	notionIDRe = regexp.MustCompile(`^(.*?)\s+([0-9a-f]{32})$`)
+	// The top level folder of a downloaded export, e.g. Export-0f1e...
+	notionExportDirRe = regexp.MustCompile(`^Export-[0-9a-f-]{36}$`)
+	// A "Property: value" line in the block under a page title
+	notionPropertyRe = regexp.MustCompile(`^([^:\n]{1,60}):\s(.*)$`)
+)
+
+var defaultNotionTagProperties = []string{"Tags", "Tag", "Category", "Categories", "Labels"}
+
+// notionTimeLayouts are the date formats Notion writes to exports
+var notionTimeLayouts = []string{
+	"January 2, 2006 3:04 PM",
+	"January 2, 2006",
+	"2006/01/02 15:04",
+	"2006/01/02",
+	"2006-01-02",
+	time.RFC3339,
+}
+
+// splitNotionName removes Notion's ID suffix from a file or folder name
+func splitNotionName(name string) (title, notionID string) {
+	if m := notionIDRe.FindStringSubmatch(name); m != nil {
+		return m[1], m[2]
+	}
+	return name, ""
+}
+
+// Import reads every page of the export, merging database CSV rows into
+// the matching pages
+func (n *NotionImporter) Import(options NotionOptions) (*ImportResult, error) {
+	result := &ImportResult{
+		Prompts:   []*models.Prompt{},
+		Workflows: []*models.Prompt{},
+		Errors:    []error{},
+	}
+
+	root := options.Path
+	if root == "" {
+		return nil, fmt.Errorf("export path is required")
+	}
+	if info, err := os.Stat(root); err != nil {
+		return nil, fmt.Errorf("failed to open export: %w", err)
+	} else if !info.IsDir() {
+		return nil, fmt.Errorf("export path is not a directory (unzip the export first): %s", root)
+	}
+
+	tagProperties := options.TagProperties
+	if len(tagProperties) == 0 {
+		tagProperties = defaultNotionTagProperties
+	}
+
+	var pages []string
+	rows := make(map[string]map[string]string) // Database folder + title -> properties
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".md":
+			pages = append(pages, path)
+		case ".csv":
+			// Newer exports write both "DB.csv" (current view) and "DB_all.csv"
+			base := strings.TrimSuffix(path, filepath.Ext(path))
+			if !strings.HasSuffix(base, "_all") {
+				if _, err := os.Stat(base + "_all.csv"); err == nil {
+					return nil
+				}
+			}
+			if err := n.readDatabase(path, rows); err != nil {
+				result.Errors = append(result.Errors, fmt.Errorf("failed to read database %s: %w", path, err))
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan export: %w", err)
+	}
+
+	for _, path := range pages {
+		prompt, err := n.importPage(root, path, rows, tagProperties, options)
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Errorf("failed to import page %s: %w", path, err))
+			continue
+		}
+		result.Prompts = append(result.Prompts, prompt)
+	}
+
+	return result, nil
+}
+
+// readDatabase loads the rows of an exported database. Rows are keyed by
+// the folder holding the database's pages and the row title.
+func (n *NotionImporter) readDatabase(path string, rows map[string]map[string]string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return err
+	}
+	if len(records) < 2 {
+		return nil
+	}
+
+	header := records[0]
+	header[0] = strings.TrimPrefix(header[0], "\ufeff") // Notion writes a UTF-8 BOM
+
+	// Pages of a database live in a folder named like the CSV, minus "_all"
+	dbName := strings.TrimSuffix(strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)), "_all")
+	dbKey := filepath.Join(filepath.Dir(path), dbName)
+
+	for _, record := range records[1:] {
+		props := make(map[string]string, len(header))
+		for i, name := range header {
+			if i < len(record) {
+				props[strings.TrimSpace(name)] = strings.TrimSpace(record[i])
+			}
+		}
+		// The title property is always the first column; it becomes the prompt title
+		titleColumn := strings.TrimSpace(header[0])
+		title := props[titleColumn]
+		delete(props, titleColumn)
+		rows[dbKey+"\x00"+title] = props
+	}
+	return nil
+}
+
+// importPage converts a single exported page
+func (n *NotionImporter) importPage(root, path string, rows map[string]map[string]string, tagProperties []string, options NotionOptions) (*models.Prompt, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	title, notionID := splitNotionName(strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)))
+	heading, props, body := parseNotionPage(strings.ReplaceAll(string(data), "\r\n", "\n"))
+	if heading != "" {
+		title = heading
+	}
+
+	// Database rows hold every property, including ones left off the page
+	if row, ok := rows[filepath.Dir(path)+"\x00"+title]; ok {
+		for key, value := range row {
+			props[key] = value
+		}
+	}
+
+	// Folders become namespaces, without Notion's IDs
+	rel, _ := filepath.Rel(root, filepath.Dir(path))
+	var parts []string
+	for _, dir := range strings.Split(filepath.ToSlash(rel), "/") {
+		if dir == "." || dir == "" || notionExportDirRe.MatchString(dir) {
+			continue
+		}
+		name, _ := splitNotionName(dir)
+		if slug := slugify(name); slug != "" {
+			parts = append(parts, slug)
+		}
+	}
+	if slug := slugify(title); slug != "" {
+		parts = append(parts, slug)
+	} else {
+		parts = append(parts, notionID)
+	}
+	id := strings.Join(parts, "/")
+
+	isTagProperty := make(map[string]bool, len(tagProperties))
+	for _, p := range tagProperties {
+		isTagProperty[strings.ToLower(p)] = true
+	}
+
+	tags := []string{"notion"}
+	metadata := map[string]interface{}{
+		"source":        "notion",
+		"original_path": filepath.ToSlash(filepath.Join(rel, filepath.Base(path))),
+	}
+	if notionID != "" {
+		metadata["notion_id"] = notionID
+	}
+
+	keys := make([]string, 0, len(props))
+	for key := range props {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var summary string
+	created, updated := info.ModTime(), info.ModTime()
+	for _, key := range keys {
+		value := props[key]
+		lower := strings.ToLower(key)
+		switch {
+		case value == "":
+			continue
+		case isTagProperty[lower]:
+			for _, tag := range strings.Split(value, ",") {
+				tags = append(tags, strings.TrimSpace(tag))
+			}
+		case lower == "description" || lower == "summary":
+			summary = value
+		case lower == "created" || lower == "created time":
+			if t, ok := parseNotionTime(value); ok {
+				created = t
+			}
+		case lower == "updated" || lower == "last edited time" || lower == "last edited":
+			if t, ok := parseNotionTime(value); ok {
+				updated = t
+			}
+		default:
+			metadata[propertyKey(key)] = value
+		}
+	}
+
+	tags = append(tags, options.Tags...)
+	tags = (&ClaudeCodeImporter{}).cleanTags(tags)
+
+	return &models.Prompt{
+		ID:        id,
+		Version:   "1.0.0",
+		Name:      title,
+		Summary:   summary,
+		Content:   body,
+		Tags:      tags,
+		CreatedAt: created,
+		UpdatedAt: updated,
+		FilePath:  filepath.Join("prompts", filepath.FromSlash(id)+".md"),
+		Metadata:  metadata,
+	}, nil
+}
+
+// parseNotionPage splits an exported page into its title heading, the
+// "Property: value" block Notion writes under it, and the remaining body
+func parseNotionPage(content string) (heading string, props map[string]string, body string) {
+	props = make(map[string]string)
+	lines := strings.Split(content, "\n")
+
+	i := 0
+	for i < len(lines) && strings.TrimSpace(lines[i]) == "" {
+		i++
+	}
+	if i < len(lines) && strings.HasPrefix(lines[i], "# ") {
+		heading = strings.TrimSpace(lines[i][2:])
+		i++
+
+		// Properties follow the title after one blank line, up to the next blank line
+		j := i
+		for j < len(lines) && strings.TrimSpace(lines[j]) == "" {
+			j++
+		}
+		k := j
+		for k < len(lines) && strings.TrimSpace(lines[k]) != "" {
+			if !notionPropertyRe.MatchString(lines[k]) {
+				break
+			}
+			k++
+		}
+		if k > j && (k == len(lines) || strings.TrimSpace(lines[k]) == "") {
+			for _, line := range lines[j:k] {
+				m := notionPropertyRe.FindStringSubmatch(line)
+				props[strings.TrimSpace(m[1])] = strings.TrimSpace(m[2])
+			}
+			i = k
+		}
+	}
+
+	body = strings.TrimSpace(strings.Join(lines[i:], "\n"))
+	return heading, props, body
+}
+
+// propertyKey converts a Notion property name into a metadata key
+func propertyKey(name string) string {
+	return strings.ReplaceAll(slugify(name), "-", "_")
+}
+
+func parseNotionTime(value string) (time.Time, bool) {
+	// Date ranges are exported as "start → end"
+	value = strings.TrimSpace(strings.Split(value, "→")[0])
+	for _, layout := range notionTimeLayouts {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,351 @@
+package importer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dpshade/pocket-prompt/internal/models"
+)
+
+// ObsidianOptions configures an Obsidian vault import
+type ObsidianOptions struct {
+	ImportOptions
+
+	// Tag selects notes carrying this tag, in frontmatter or inline (#tag).
+	// Nested tags such as #prompt/writing also match "prompt".
+	Tag string
+	// Folder selects notes under this folder, relative to the vault root
+	Folder string
+}
+
+// DefaultObsidianTag is used when neither a tag nor a folder is given
+const DefaultObsidianTag = "prompt"
+
+// ObsidianImporter converts notes from an Obsidian vault into prompts
+type ObsidianImporter struct{}
+
+// NewObsidianImporter creates a new Obsidian importer
+func NewObsidianImporter() *ObsidianImporter {
+	return &ObsidianImporter{}
+}
+
+var (
+	// [[Target]], [[Target|Alias]], [[Target#Heading]] and ![[Embed]]
+	wikilinkRe = regexp.MustCompile(`!?\[\[([^\]|#^]*)(?:[#^][^\]|]*)?(?:\|[^\]]*)?\]\]`)
+	// Inline #tags; must not be preceded by a word character (URLs, headings)
+	inlineTagRe  = regexp.MustCompile(`(?m)(?:^|[^\w&#/])#([\p{L}\p{N}_/-]*[\p{L}_/-][\p{L}\p{N}_/-]*)`)
+	codeFenceRe  = regexp.MustCompile("(?s)```.*?```")
+	inlineCodeRe = regexp.MustCompile("`[^`\n]*`")
+	slugRe       = regexp.MustCompile(`[^a-z0-9]+`)
+)
+
+// obsidianNote is a selected note before conversion
+type obsidianNote struct {
+	path        string // Absolute path on disk
+	relPath     string // Path relative to the vault root, slash separated
+	frontmatter map[string]interface{}
+	body        string
+	tags        []string
+	modTime     time.Time
+}
+
+// Import scans the vault and converts every selected note into a prompt
+func (i *ObsidianImporter) Import(options ObsidianOptions) (*ImportResult, error) {
+	result := &ImportResult{
+		Prompts:   []*models.Prompt{},
+		Workflows: []*models.Prompt{},
+		Errors:    []error{},
+	}
+
+	vault := options.Path
+	if vault == "" {
+		return nil, fmt.Errorf("vault path is required")
+	}
+	if info, err := os.Stat(vault); err != nil {
+		return nil, fmt.Errorf("failed to open vault: %w", err)
+	} else if !info.IsDir() {
+		return nil, fmt.Errorf("vault path is not a directory: %s", vault)
+	}
+
+	tag := strings.TrimPrefix(strings.TrimSpace(options.Tag), "#")
+	folder := strings.Trim(filepath.ToSlash(strings.TrimSpace(options.Folder)), "/")
+	if tag == "" && folder == "" {
+		tag = DefaultObsidianTag
+	}
+
+	var notes []*obsidianNote
+	err := filepath.Walk(vault, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		name := info.Name()
+		if info.IsDir() {
+			// Skip vault settings, trash and hidden folders
+			if path != vault && (strings.HasPrefix(name, ".") || name == "node_modules") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.EqualFold(filepath.Ext(name), ".md") {
+			return nil
+		}
+
+		rel, _ := filepath.Rel(vault, path)
+		rel = filepath.ToSlash(rel)
+		if folder != "" && !strings.HasPrefix(strings.ToLower(rel), strings.ToLower(folder)+"/") {
+			return nil
+		}
+
+		note, err := i.readNote(path, rel)
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Errorf("failed to read note %s: %w", rel, err))
+			return nil
+		}
+		if tag != "" && !hasObsidianTag(note.tags, tag) {
+			return nil
+		}
+		notes = append(notes, note)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan vault: %w", err)
+	}
+
+	// Assign IDs first so links between imported notes can be resolved
+	ids := make(map[*obsidianNote]string, len(notes))
+	byName := make(map[string][]string)
+	byPath := make(map[string]string)
+	for _, note := range notes {
+		id := i.noteID(note, folder)
+		ids[note] = id
+		noExt := strings.ToLower(strings.TrimSuffix(note.relPath, filepath.Ext(note.relPath)))
+		byPath[noExt] = id
+		base := noExt[strings.LastIndex(noExt, "/")+1:]
+		byName[base] = append(byName[base], id)
+	}
+
+	for _, note := range notes {
+		prompt := i.convert(note, ids[note], options)
+
+		// Wikilinks stay in the content untouched; the linked notes are
+		// recorded so they can be followed after migration
+		var links, references []string
+		seen := make(map[string]bool)
+		for _, m := range wikilinkRe.FindAllStringSubmatch(note.body, -1) {
+			target := strings.TrimSpace(m[1])
+			if target == "" || seen[target] {
+				continue
+			}
+			seen[target] = true
+			links = append(links, target)
+
+			key := strings.ToLower(strings.TrimSuffix(target, ".md"))
+			if id, ok := byPath[key]; ok {
+				references = append(references, id)
+			} else if matches := byName[key[strings.LastIndex(key, "/")+1:]]; len(matches) == 1 {
+				references = append(references, matches[0])
+			}
+		}
+		if len(links) > 0 {
+			prompt.Metadata["wikilinks"] = links
+		}
+		if len(references) > 0 {
+			prompt.Metadata["references"] = references
+		}
+
+		result.Prompts = append(result.Prompts, prompt)
+	}
+
+	return result, nil
+}
+
+// readNote parses a note's frontmatter and collects its tags
+func (i *ObsidianImporter) readNote(path, relPath string) (*obsidianNote, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	frontmatter, body := (&ClaudeCodeImporter{}).parseFrontmatter(content)
+	note := &obsidianNote{
+		path:        path,
+		relPath:     relPath,
+		frontmatter: frontmatter,
+		body:        body,
+		modTime:     info.ModTime(),
+	}
+
+	note.tags = append(note.tags, stringList(frontmatter["tags"])...)
+	note.tags = append(note.tags, stringList(frontmatter["tag"])...)
+
+	// Inline tags, ignoring anything inside code
+	text := codeFenceRe.ReplaceAllString(body, "")
+	text = inlineCodeRe.ReplaceAllString(text, "")
+	for _, m := range inlineTagRe.FindAllStringSubmatch(text, -1) {
+		note.tags = append(note.tags, m[1])
+	}
+
+	for j, t := range note.tags {
+		note.tags[j] = strings.TrimPrefix(strings.TrimSpace(t), "#")
+	}
+	return note, nil
+}
+
+// noteID derives a namespaced prompt ID from the note's path below the
+// selected folder, e.g. "Writing/Blog Outline.md" becomes writing/blog-outline
+func (i *ObsidianImporter) noteID(note *obsidianNote, folder string) string {
+	if id, ok := note.frontmatter["id"].(string); ok && strings.TrimSpace(id) != "" {
+		return strings.TrimSpace(id)
+	}
+
+	rel := strings.TrimSuffix(note.relPath, filepath.Ext(note.relPath))
+	if folder != "" && len(rel) > len(folder) {
+		rel = rel[len(folder)+1:]
+	}
+
+	var parts []string
+	for _, part := range strings.Split(rel, "/") {
+		if slug := slugify(part); slug != "" {
+			parts = append(parts, slug)
+		}
+	}
+	return strings.Join(parts, "/")
+}
+
+// convert maps Obsidian frontmatter onto prompt fields. Fields without a
+// direct equivalent are kept in the prompt metadata.
+func (i *ObsidianImporter) convert(note *obsidianNote, id string, options ObsidianOptions) *models.Prompt {
+	fm := note.frontmatter
+
+	title := firstString(fm, "title")
+	if title == "" {
+		title = strings.TrimSuffix(filepath.Base(note.relPath), filepath.Ext(note.relPath))
+	}
+
+	created := firstTime(fm, "created", "date", "created_at")
+	if created.IsZero() {
+		created = note.modTime
+	}
+	updated := firstTime(fm, "updated", "modified", "updated_at")
+	if updated.IsZero() {
+		updated = note.modTime
+	}
+
+	version := firstString(fm, "version")
+	if version == "" {
+		version = "1.0.0"
+	}
+
+	tags := append([]string{"obsidian"}, note.tags...)
+	tags = append(tags, options.Tags...)
+	tags = (&ClaudeCodeImporter{}).cleanTags(tags)
+
+	metadata := map[string]interface{}{
+		"source":        "obsidian",
+		"original_path": note.relPath,
+	}
+	mapped := map[string]bool{
+		"id": true, "title": true, "description": true, "summary": true, "tags": true, "tag": true,
+		"version": true, "created": true, "date": true, "created_at": true,
+		"updated": true, "modified": true, "updated_at": true,
+	}
+	keys := make([]string, 0, len(fm))
+	for key := range fm {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if !mapped[key] {
+			if _, taken := metadata[key]; !taken {
+				metadata[key] = fm[key]
+			}
+		}
+	}
+
+	return &models.Prompt{
+		ID:        id,
+		Version:   version,
+		Name:      title,
+		Summary:   firstString(fm, "description", "summary"),
+		Content:   note.body,
+		Tags:      tags,
+		CreatedAt: created,
+		UpdatedAt: updated,
+		FilePath:  filepath.Join("prompts", filepath.FromSlash(id)+".md"),
+		Metadata:  metadata,
+	}
+}
+
+// hasObsidianTag reports whether tags contain tag or a nested tag below it
+func hasObsidianTag(tags []string, tag string) bool {
+	tag = strings.ToLower(tag)
+	for _, t := range tags {
+		t = strings.ToLower(t)
+		if t == tag || strings.HasPrefix(t, tag+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// stringList accepts YAML lists as well as comma or space separated strings
+func stringList(value interface{}) []string {
+	switch v := value.(type) {
+	case []interface{}:
+		var result []string
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				result = append(result, s)
+			}
+		}
+		return result
+	case string:
+		return strings.FieldsFunc(v, func(r rune) bool { return r == ',' || r == ' ' })
+	default:
+		return nil
+	}
+}
+
+func firstString(fm map[string]interface{}, keys ...string) string {
+	for _, key := range keys {
+		if s, ok := fm[key].(string); ok && strings.TrimSpace(s) != "" {
+			return strings.TrimSpace(s)
+		}
+	}
+	return ""
+}
+
+func firstTime(fm map[string]interface{}, keys ...string) time.Time {
+	layouts := []string{time.RFC3339, "2006-01-02T15:04", "2006-01-02 15:04", "2006-01-02"}
+	for _, key := range keys {
+		switch v := fm[key].(type) {
+		case time.Time:
+			return v
+		case string:
+			for _, layout := range layouts {
+				if t, err := time.ParseInLocation(layout, strings.TrimSpace(v), time.Local); err == nil {
+					return t
+				}
+			}
+		}
+	}
+	return time.Time{}
+}
+
+// slugify lowercases a name and joins its words with hyphens
+func slugify(name string) string {
+	if slug := strings.Trim(slugRe.ReplaceAllString(strings.ToLower(name), "-"), "-"); slug != "" {
+		return slug
+	}
+	// Names without any ASCII letters or digits keep their characters
+	return strings.ToLower(strings.Join(strings.Fields(name), "-"))
+}
@@ -0,0 +1,109 @@
+package logging
+
+import (
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"os/exec"
+	"strings"
+	"syscall"
+)
+
+// maxShortError is the longest error message shown in a status bar
+const maxShortError = 120
+
+// debug adds the chain of wrapped errors to error output
+var debug bool
+
+// SetDebug turns the detailed error output of debug mode on or off
+func SetDebug(on bool) {
+	debug = on
+}
+
+// Debug reports whether debug mode is on
+func Debug() bool {
+	return debug
+}
+
+// ShortError returns the first line of err's message, shortened to fit on a
+// status line
+func ShortError(err error) string {
+	msg, _, _ := strings.Cut(err.Error(), "\n")
+	msg = strings.TrimSpace(msg)
+	if runes := []rune(msg); len(runes) > maxShortError {
+		msg = string(runes[:maxShortError-1]) + "…"
+	}
+	return msg
+}
+
+// ErrorChain describes err and each error it wraps, outermost first: the
+// part of the message the error adds, its type, and the file path, exit
+// status or errno it carries
+func ErrorChain(err error) []string {
+	var chain []string
+	describeChain(err, "", &chain)
+	return chain
+}
+
+func describeChain(err error, indent string, chain *[]string) {
+	for err != nil {
+		var inner error
+		var joined []error
+		switch u := err.(type) {
+		case interface{ Unwrap() error }:
+			inner = u.Unwrap()
+		case interface{ Unwrap() []error }:
+			joined = u.Unwrap()
+		}
+
+		// Wrapping errors repeat the message they wrap; show only their part
+		msg := err.Error()
+		if inner != nil {
+			msg = strings.TrimSuffix(strings.TrimSuffix(msg, inner.Error()), ": ")
+		}
+		line := fmt.Sprintf("%s%s (%T", indent, msg, err)
+		for _, detail := range errorDetails(err) {
+			line += " " + detail
+		}
+		*chain = append(*chain, line+")")
+
+		for _, e := range joined {
+			describeChain(e, indent+"  ", chain)
+		}
+		err = inner
+	}
+}
+
+// errorDetails returns the fields of well-known error types that locate the
+// failure
+func errorDetails(err error) []string {
+	switch e := err.(type) {
+	case *fs.PathError:
+		return []string{"op=" + e.Op, "path=" + e.Path}
+	case *os.LinkError:
+		return []string{"op=" + e.Op, "old=" + e.Old, "new=" + e.New}
+	case *exec.Error:
+		return []string{"name=" + e.Name}
+	case *exec.ExitError:
+		details := []string{fmt.Sprintf("exit=%d", e.ExitCode())}
+		if stderr := strings.TrimSpace(string(e.Stderr)); stderr != "" {
+			details = append(details, fmt.Sprintf("stderr=%q", stderr))
+		}
+		return details
+	case syscall.Errno:
+		return []string{fmt.Sprintf("errno=%d", uintptr(e))}
+	}
+	return nil
+}
+
+// Err returns an attribute logging err, with its whole chain in debug mode
+func Err(err error) slog.Attr {
+	if !debug || err == nil {
+		return slog.Any("error", err)
+	}
+	return slog.Group("error",
+		slog.String("message", err.Error()),
+		slog.Any("chain", ErrorChain(err)),
+	)
+}
@@ -0,0 +1,99 @@
+// Package logging configures the structured log shared by the application.
+// Packages log through the default slog logger, which Setup replaces.
+package logging
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+)
+
+// Log formats
+const (
+	FormatText = "text" // key=value pairs, one entry per line
+	FormatJSON = "json" // one JSON object per line
+)
+
+// Options chooses what is logged and where
+type Options struct {
+	// Level is the least severe level logged: debug, info, warn or error
+	Level string
+
+	// Format is text or json
+	Format string
+
+	// File is appended to instead of the default output when set
+	File string
+
+	// Timestamps adds the time to entries written to the default output;
+	// entries written to a file always have it
+	Timestamps bool
+}
+
+// ParseLevel parses a level name, which may be empty for warn
+func ParseLevel(name string) (slog.Level, error) {
+	switch strings.ToLower(name) {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "info":
+		return slog.LevelInfo, nil
+	case "", "warn", "warning":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	default:
+		return 0, fmt.Errorf("unknown log level %q: use debug, info, warn or error", name)
+	}
+}
+
+// NewHandler returns a handler writing entries at level and above to w in
+// the given format. Timestamps can be left out for output read as it
+// happens, such as a terminal.
+func NewHandler(w io.Writer, format string, level slog.Leveler, timestamps bool) (slog.Handler, error) {
+	opts := &slog.HandlerOptions{Level: level}
+	if !timestamps {
+		opts.ReplaceAttr = func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey && len(groups) == 0 {
+				return slog.Attr{}
+			}
+			return a
+		}
+	}
+
+	switch format {
+	case "", FormatText:
+		return slog.NewTextHandler(w, opts), nil
+	case FormatJSON:
+		return slog.NewJSONHandler(w, opts), nil
+	default:
+		return nil, fmt.Errorf("unknown log format %q: use text or json", format)
+	}
+}
+
+// Setup makes the logger described by opts the default, writing to output
+// unless a file is given, and returns a function that closes the file
+func Setup(opts Options, output io.Writer) (func() error, error) {
+	level, err := ParseLevel(opts.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	closeFile := func() error { return nil }
+	if opts.File != "" {
+		file, err := os.OpenFile(opts.File, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open log file: %w", err)
+		}
+		output, closeFile = file, file.Close
+	}
+
+	handler, err := NewHandler(output, opts.Format, level, opts.Timestamps || opts.File != "")
+	if err != nil {
+		closeFile()
+		return nil, err
+	}
+	slog.SetDefault(slog.New(handler))
+	return closeFile, nil
+}
@@ -0,0 +1,79 @@
+package logging
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSetup(t *testing.T) {
+	defer slog.SetDefault(slog.Default())
+
+	path := filepath.Join(t.TempDir(), "pocket-prompt.log")
+	closeLog, err := Setup(Options{Level: "info", Format: FormatJSON, File: path}, io.Discard)
+	if err != nil {
+		t.Fatalf("Failed to set up logging: %v", err)
+	}
+	slog.Debug("hidden")
+	slog.Info("shown", "count", 2)
+	if err := closeLog(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "hidden") {
+		t.Error("Expected debug entries to be left out at info level")
+	}
+	if !strings.Contains(string(data), `"msg":"shown","count":2`) || !strings.Contains(string(data), `"time"`) {
+		t.Errorf("Expected a timestamped JSON entry, got %q", data)
+	}
+
+	if _, err := Setup(Options{Level: "loud"}, io.Discard); err == nil {
+		t.Error("Expected an unknown level to be rejected")
+	}
+	if _, err := Setup(Options{Format: "xml"}, io.Discard); err == nil {
+		t.Error("Expected an unknown format to be rejected")
+	}
+}
+
+func TestErrorChain(t *testing.T) {
+	_, statErr := os.Stat(filepath.Join(t.TempDir(), "missing.md"))
+	err := fmt.Errorf("failed to save prompt: %w", statErr)
+
+	chain := ErrorChain(err)
+	if len(chain) != 3 {
+		t.Fatalf("Expected the wrapper, path error and errno, got %q", chain)
+	}
+	if chain[0] != "failed to save prompt (*fmt.wrapError)" {
+		t.Errorf("Expected only the wrapper's own message, got %q", chain[0])
+	}
+	if !strings.Contains(chain[1], "*fs.PathError op=stat path=") || !strings.Contains(chain[1], "missing.md") {
+		t.Errorf("Expected the path of the failed operation, got %q", chain[1])
+	}
+	if !strings.HasPrefix(chain[2], "no such file or directory (syscall.Errno errno=") {
+		t.Errorf("Expected the errno, got %q", chain[2])
+	}
+
+	joined := ErrorChain(errors.Join(errors.New("first"), errors.New("second")))
+	if len(joined) != 3 || joined[1] != "  first (*errors.errorString)" {
+		t.Errorf("Expected joined errors indented under the join, got %q", joined)
+	}
+}
+
+func TestShortError(t *testing.T) {
+	err := errors.New("git pull failed: fatal: could not read\nhint: check your credentials")
+	if got := ShortError(err); got != "git pull failed: fatal: could not read" {
+		t.Errorf("Expected the first line, got %q", got)
+	}
+	if got := ShortError(errors.New(strings.Repeat("x", 200))); len([]rune(got)) != maxShortError {
+		t.Errorf("Expected a message shortened to %d characters, got %d", maxShortError, len([]rune(got)))
+	}
+}
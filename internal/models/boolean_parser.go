@@ -0,0 +1,220 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParseBooleanExpression parses a tag query such as
+// "(ai AND production) NOT draft" into an expression.
+//
+// Operators are AND, OR, XOR and NOT, in upper case. AND binds tighter
+// than XOR, which binds tighter than OR. NOT is a prefix operator, and
+// "a NOT b" is short for "a AND NOT b". Words that are not operators
+// make up tag names, so "machine learning" is a single tag; tags can also
+// be quoted. A "tag:" prefix is optional, "ai/*" matches ai and every tag
+// beneath it, and "*" and "?" are wildcards, as in "ai*" or "*-draft".
+func ParseBooleanExpression(query string) (*BooleanExpression, error) {
+	tokens, err := tokenizeBoolean(query)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("empty boolean expression")
+	}
+
+	p := &booleanParser{tokens: tokens}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if !p.done() {
+		return nil, fmt.Errorf("unexpected %q in boolean expression", p.peek().text)
+	}
+	return expr, nil
+}
+
+type booleanTokenKind int
+
+const (
+	tokenWord booleanTokenKind = iota
+	tokenOperator
+	tokenOpen
+	tokenClose
+)
+
+type booleanToken struct {
+	kind booleanTokenKind
+	text string
+}
+
+// tokenizeBoolean splits a query into words, operators and parentheses.
+// Consecutive words are joined into one multi-word tag.
+func tokenizeBoolean(query string) ([]booleanToken, error) {
+	var tokens []booleanToken
+	addWord := func(word string, quoted bool) {
+		if !quoted {
+			switch word {
+			case "AND", "OR", "XOR", "NOT":
+				tokens = append(tokens, booleanToken{tokenOperator, word})
+				return
+			}
+			word = strings.TrimPrefix(word, "tag:")
+		}
+		if n := len(tokens); n > 0 && tokens[n-1].kind == tokenWord && !quoted {
+			tokens[n-1].text += " " + word
+			return
+		}
+		tokens = append(tokens, booleanToken{tokenWord, word})
+	}
+
+	for i := 0; i < len(query); {
+		switch c := query[i]; {
+		case c == ' ' || c == '\t' || c == '\n':
+			i++
+		case c == '(':
+			tokens = append(tokens, booleanToken{tokenOpen, "("})
+			i++
+		case c == ')':
+			tokens = append(tokens, booleanToken{tokenClose, ")"})
+			i++
+		case c == '"':
+			end := strings.IndexByte(query[i+1:], '"')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated quote in boolean expression")
+			}
+			addWord(query[i+1:i+1+end], true)
+			i += end + 2
+		default:
+			start := i
+			for i < len(query) && !strings.ContainsRune(" \t\n()\"", rune(query[i])) {
+				i++
+			}
+			addWord(query[start:i], false)
+		}
+	}
+	return tokens, nil
+}
+
+type booleanParser struct {
+	tokens []booleanToken
+	pos    int
+}
+
+func (p *booleanParser) done() bool {
+	return p.pos >= len(p.tokens)
+}
+
+func (p *booleanParser) peek() booleanToken {
+	return p.tokens[p.pos]
+}
+
+func (p *booleanParser) acceptOperator(op string) bool {
+	if !p.done() && p.peek().kind == tokenOperator && p.peek().text == op {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *booleanParser) parseOr() (*BooleanExpression, error) {
+	left, err := p.parseXor()
+	if err != nil {
+		return nil, err
+	}
+	operands := []*BooleanExpression{left}
+	for p.acceptOperator("OR") {
+		right, err := p.parseXor()
+		if err != nil {
+			return nil, err
+		}
+		operands = append(operands, right)
+	}
+	if len(operands) == 1 {
+		return left, nil
+	}
+	return NewOrExpression(operands...), nil
+}
+
+func (p *booleanParser) parseXor() (*BooleanExpression, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.acceptOperator("XOR") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = NewXorExpression(left, right)
+	}
+	return left, nil
+}
+
+func (p *booleanParser) parseAnd() (*BooleanExpression, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	operands := []*BooleanExpression{left}
+	for {
+		if p.acceptOperator("AND") {
+			right, err := p.parseUnary()
+			if err != nil {
+				return nil, err
+			}
+			operands = append(operands, right)
+			continue
+		}
+		// "a NOT b" excludes b from a
+		if !p.done() && p.peek().kind == tokenOperator && p.peek().text == "NOT" {
+			right, err := p.parseUnary()
+			if err != nil {
+				return nil, err
+			}
+			operands = append(operands, right)
+			continue
+		}
+		break
+	}
+	if len(operands) == 1 {
+		return left, nil
+	}
+	return NewAndExpression(operands...), nil
+}
+
+func (p *booleanParser) parseUnary() (*BooleanExpression, error) {
+	if p.done() {
+		return nil, fmt.Errorf("boolean expression ends with an operator")
+	}
+
+	token := p.peek()
+	switch token.kind {
+	case tokenOperator:
+		if token.text != "NOT" {
+			return nil, fmt.Errorf("expected a tag before %s", token.text)
+		}
+		p.pos++
+		inner, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return NewNotExpression(inner), nil
+	case tokenOpen:
+		p.pos++
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.done() || p.peek().kind != tokenClose {
+			return nil, fmt.Errorf("missing closing parenthesis in boolean expression")
+		}
+		p.pos++
+		return inner, nil
+	case tokenClose:
+		return nil, fmt.Errorf("unexpected closing parenthesis in boolean expression")
+	default:
+		p.pos++
+		return NewTagExpression(token.text), nil
+	}
+}
@@ -0,0 +1,59 @@
+package models
+
+import "testing"
+
+func TestParseBooleanExpression(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"ai", "[ai]"},
+		{"machine learning", "[machine learning]"},
+		{"ai AND production", "([ai] AND [production])"},
+		{"(ai AND production) NOT draft", "(([ai] AND [production]) AND NOT [draft])"},
+		{"a OR b AND c", "([a] OR ([b] AND [c]))"},
+		{"a XOR b OR c", "(([a] XOR [b]) OR [c])"},
+		{"NOT (a OR b)", "NOT ([a] OR [b])"},
+		{`"AND" OR x`, "([AND] OR [x])"},
+		{"tag:ai/* NOT draft", "([ai/*] AND NOT [draft])"},
+	}
+	for _, tt := range tests {
+		expr, err := ParseBooleanExpression(tt.query)
+		if err != nil {
+			t.Errorf("%q: %v", tt.query, err)
+			continue
+		}
+		if got := expr.String(); got != tt.want {
+			t.Errorf("%q: got %s, want %s", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestParseBooleanExpressionEvaluate(t *testing.T) {
+	expr, err := ParseBooleanExpression("(ai AND production) NOT draft")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !expr.Evaluate([]string{"ai", "production"}) {
+		t.Error("Expected match without draft")
+	}
+	if expr.Evaluate([]string{"ai", "production", "draft"}) {
+		t.Error("Expected draft excluded")
+	}
+
+	nested, err := ParseBooleanExpression("tag:ai/* AND production")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !nested.Evaluate([]string{"ai/agents", "production"}) {
+		t.Error("Expected ai/* to match a nested tag")
+	}
+}
+
+func TestParseBooleanExpressionErrors(t *testing.T) {
+	for _, query := range []string{"", "ai AND", "(ai OR b", "ai)", "OR ai", `"open`} {
+		if _, err := ParseBooleanExpression(query); err == nil {
+			t.Errorf("Expected an error for %q", query)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DateFilter limits prompts to a range of creation and update times. Zero
+// bounds are open. After bounds are inclusive and Before bounds exclusive.
+type DateFilter struct {
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	UpdatedAfter  time.Time
+	UpdatedBefore time.Time
+}
+
+// IsZero reports whether the filter has no bounds
+func (f DateFilter) IsZero() bool {
+	return f == DateFilter{}
+}
+
+// Match reports whether a prompt falls within the filter
+func (f DateFilter) Match(p *Prompt) bool {
+	return inRange(p.CreatedAt, f.CreatedAfter, f.CreatedBefore) &&
+		inRange(p.UpdatedAt, f.UpdatedAfter, f.UpdatedBefore)
+}
+
+// Filter returns the prompts that match the filter
+func (f DateFilter) Filter(prompts []*Prompt) []*Prompt {
+	if f.IsZero() {
+		return prompts
+	}
+	var matching []*Prompt
+	for _, p := range prompts {
+		if f.Match(p) {
+			matching = append(matching, p)
+		}
+	}
+	return matching
+}
+
+func inRange(t, after, before time.Time) bool {
+	if !after.IsZero() && t.Before(after) {
+		return false
+	}
+	if !before.IsZero() && !t.Before(before) {
+		return false
+	}
+	return true
+}
+
+// ParseDate parses a YYYY-MM-DD date, in local time, or an RFC 3339
+// timestamp. It reports whether the value was a whole day.
+func ParseDate(value string) (time.Time, bool, error) {
+	if t, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return t, true, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, false, nil
+	}
+	return time.Time{}, false, fmt.Errorf("invalid date %q: use YYYY-MM-DD or an RFC 3339 timestamp", value)
+}
+
+// Apply narrows the filter with a comparison such as ">2024-01-01" on the
+// created or updated field. A bare date matches that whole day; with a
+// whole day, ">" starts after it and "<=" includes it.
+func (f *DateFilter) Apply(field, comparison string) error {
+	var after, before *time.Time
+	switch field {
+	case "created":
+		after, before = &f.CreatedAfter, &f.CreatedBefore
+	case "updated":
+		after, before = &f.UpdatedAfter, &f.UpdatedBefore
+	default:
+		return fmt.Errorf("unknown date field %q: use created or updated", field)
+	}
+
+	date := strings.TrimLeft(comparison, "<>=")
+	op := comparison[:len(comparison)-len(date)]
+	t, day, err := ParseDate(date)
+	if err != nil {
+		return err
+	}
+	next := t
+	if day {
+		next = t.AddDate(0, 0, 1)
+	}
+
+	switch op {
+	case ">":
+		narrowAfter(after, next)
+	case ">=":
+		narrowAfter(after, t)
+	case "<":
+		narrowBefore(before, t)
+	case "<=":
+		narrowBefore(before, next)
+	case "", "=":
+		narrowAfter(after, t)
+		narrowBefore(before, next)
+	default:
+		return fmt.Errorf("invalid date comparison %q", op)
+	}
+	return nil
+}
+
+func narrowAfter(bound *time.Time, t time.Time) {
+	if bound.IsZero() || t.After(*bound) {
+		*bound = t
+	}
+}
+
+func narrowBefore(bound *time.Time, t time.Time) {
+	if bound.IsZero() || t.Before(*bound) {
+		*bound = t
+	}
+}
+
+// ExtractDateFilters removes created: and updated: terms such as
+// "updated:>2024-01-01" from a search query. It returns the rest of the
+// query and the filter the terms describe.
+func ExtractDateFilters(query string) (string, DateFilter, error) {
+	var filter DateFilter
+	var rest []string
+	for _, word := range strings.Fields(query) {
+		field, comparison, ok := strings.Cut(word, ":")
+		field = strings.ToLower(field)
+		if !ok || (field != "created" && field != "updated") || comparison == "" {
+			rest = append(rest, word)
+			continue
+		}
+		if err := filter.Apply(field, comparison); err != nil {
+			return "", DateFilter{}, err
+		}
+	}
+	return strings.Join(rest, " "), filter, nil
+}
@@ -0,0 +1,56 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestExtractDateFilters(t *testing.T) {
+	day := func(s string) time.Time {
+		d, _, err := ParseDate(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return d
+	}
+
+	rest, filter, err := ExtractDateFilters("release notes updated:>2024-01-01 created:<=2024-03-31")
+	if err != nil {
+		t.Fatalf("ExtractDateFilters failed: %v", err)
+	}
+	if rest != "release notes" {
+		t.Errorf("Unexpected remaining query %q", rest)
+	}
+	want := DateFilter{UpdatedAfter: day("2024-01-02"), CreatedBefore: day("2024-04-01")}
+	if filter != want {
+		t.Errorf("Unexpected filter %+v", filter)
+	}
+
+	if _, _, err := ExtractDateFilters("updated:>yesterday"); err == nil {
+		t.Error("Expected an invalid date to be rejected")
+	}
+	if rest, filter, _ := ExtractDateFilters("meeting:notes"); rest != "meeting:notes" || !filter.IsZero() {
+		t.Errorf("Expected other prefixes to be left alone, got %q %+v", rest, filter)
+	}
+}
+
+func TestDateFilterMatch(t *testing.T) {
+	var filter DateFilter
+	if err := filter.Apply("updated", "2024-05-10"); err != nil {
+		t.Fatal(err)
+	}
+
+	at := func(s string) *Prompt {
+		ts, err := time.ParseInLocation("2006-01-02 15:04", s, time.Local)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &Prompt{UpdatedAt: ts}
+	}
+	if !filter.Match(at("2024-05-10 00:00")) || !filter.Match(at("2024-05-10 23:59")) {
+		t.Error("Expected prompts updated that day to match")
+	}
+	if filter.Match(at("2024-05-09 23:59")) || filter.Match(at("2024-05-11 00:00")) {
+		t.Error("Expected prompts updated on other days not to match")
+	}
+}
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// charsPerToken is the rough number of characters in a token of English
+// text for common LLM tokenizers
+const charsPerToken = 4
+
+// TextLength measures text for prompt length budgets
+type TextLength struct {
+	Words      int
+	Characters int
+	Tokens     int // An estimate; tokenizers differ between models
+}
+
+// MeasureText counts the words and characters of text and estimates its
+// token count from the characters, or the words for text of short words
+func MeasureText(text string) TextLength {
+	length := TextLength{
+		Words:      len(strings.Fields(text)),
+		Characters: utf8.RuneCountInString(text),
+	}
+	length.Tokens = max((length.Characters+charsPerToken-1)/charsPerToken, length.Words)
+	return length
+}
@@ -0,0 +1,21 @@
+package models
+
+import "testing"
+
+func TestMeasureText(t *testing.T) {
+	tests := []struct {
+		text string
+		want TextLength
+	}{
+		{"", TextLength{}},
+		{"Review this code", TextLength{Words: 3, Characters: 16, Tokens: 4}},
+		{"a b c d e", TextLength{Words: 5, Characters: 9, Tokens: 5}},
+		{"héllo wörld", TextLength{Words: 2, Characters: 11, Tokens: 3}},
+	}
+
+	for _, tt := range tests {
+		if got := MeasureText(tt.text); got != tt.want {
+			t.Errorf("MeasureText(%q) = %+v, want %+v", tt.text, got, tt.want)
+		}
+	}
+}
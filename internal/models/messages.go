@@ -0,0 +1,90 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Message roles of chat APIs
+const (
+	RoleSystem    = "system"
+	RoleUser      = "user"
+	RoleAssistant = "assistant"
+)
+
+// Message is one turn of a multi-message prompt
+type Message struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// roleMarkerRe matches the lines that start each message of a multi-message
+// prompt body, such as <!-- role: system -->. Being HTML comments, they
+// don't show when the markdown is previewed.
+var roleMarkerRe = regexp.MustCompile(`(?m)^[ \t]*<!--[ \t]*role:[ \t]*([A-Za-z_][A-Za-z0-9_-]*)[ \t]*-->[ \t]*\r?$`)
+
+// roleNameRe matches the role names markers accept
+var roleNameRe = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+
+// ParseMessages splits a body into messages at its role markers. It returns
+// nil for a body without markers, which is a single user message. Text
+// before the first marker is a user message of its own.
+func ParseMessages(content string) []Message {
+	markers := roleMarkerRe.FindAllStringSubmatchIndex(content, -1)
+	if len(markers) == 0 {
+		return nil
+	}
+
+	var messages []Message
+	if lead := strings.TrimSpace(content[:markers[0][0]]); lead != "" {
+		messages = append(messages, Message{Role: RoleUser, Content: lead})
+	}
+	for i, m := range markers {
+		end := len(content)
+		if i+1 < len(markers) {
+			end = markers[i+1][0]
+		}
+		messages = append(messages, Message{
+			Role:    strings.ToLower(content[m[2]:m[3]]),
+			Content: strings.TrimSpace(content[m[1]:end]),
+		})
+	}
+	return messages
+}
+
+// FormatMessages writes messages as a body with role markers, the inverse
+// of ParseMessages
+func FormatMessages(messages []Message) string {
+	var b strings.Builder
+	for i, message := range messages {
+		if i > 0 {
+			b.WriteString("\n\n")
+		}
+		fmt.Fprintf(&b, "<!-- role: %s -->\n%s", message.Role, strings.TrimSpace(message.Content))
+	}
+	return b.String()
+}
+
+// FormatTranscript writes messages as a readable chat transcript, each
+// headed by its role
+func FormatTranscript(messages []Message) string {
+	var b strings.Builder
+	for i, message := range messages {
+		if i > 0 {
+			b.WriteString("\n\n")
+		}
+		role := message.Role
+		if role != "" {
+			role = strings.ToUpper(role[:1]) + role[1:]
+		}
+		fmt.Fprintf(&b, "%s:\n%s", role, message.Content)
+	}
+	return b.String()
+}
+
+// ValidRole reports whether role can name a message: a word of letters,
+// digits, _ and -
+func ValidRole(role string) bool {
+	return roleNameRe.MatchString(role)
+}
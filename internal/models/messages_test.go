@@ -0,0 +1,31 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseMessages(t *testing.T) {
+	if messages := ParseMessages("Just one block\n<!-- a comment -->"); messages != nil {
+		t.Errorf("Expected a body without role markers to have no messages, got %v", messages)
+	}
+
+	body := "Intro\n\n<!-- role: system -->\nBe brief.\n\n<!--role:User-->\r\nHi\n<!-- role: assistant -->\nHello"
+	want := []Message{
+		{Role: RoleUser, Content: "Intro"},
+		{Role: RoleSystem, Content: "Be brief."},
+		{Role: RoleUser, Content: "Hi"},
+		{Role: RoleAssistant, Content: "Hello"},
+	}
+	messages := ParseMessages(body)
+	if !reflect.DeepEqual(messages, want) {
+		t.Fatalf("ParseMessages = %+v, want %+v", messages, want)
+	}
+
+	if again := ParseMessages(FormatMessages(messages)); !reflect.DeepEqual(again, want) {
+		t.Errorf("Expected FormatMessages to round-trip, got %+v", again)
+	}
+	if transcript := FormatTranscript(want[1:3]); transcript != "System:\nBe brief.\n\nUser:\nHi" {
+		t.Errorf("Unexpected transcript %q", transcript)
+	}
+}
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// PresetNames returns the names of the prompt's variable presets, sorted
+func (p Prompt) PresetNames() []string {
+	return slices.Sorted(maps.Keys(p.Presets))
+}
+
+// VariableValues returns the prompt's default variable values overlaid
+// with those of a preset, which may be empty for the defaults alone
+func (p Prompt) VariableValues(preset string) (map[string]string, error) {
+	values := maps.Clone(p.Defaults)
+	if preset == "" {
+		return values, nil
+	}
+	presetValues, ok := p.Presets[preset]
+	if !ok {
+		if len(p.Presets) == 0 {
+			return nil, fmt.Errorf("prompt %s has no presets", p.ID)
+		}
+		return nil, fmt.Errorf("prompt %s has no preset %q (presets: %s)", p.ID, preset, strings.Join(p.PresetNames(), ", "))
+	}
+	if values == nil {
+		values = make(map[string]string, len(presetValues))
+	}
+	maps.Copy(values, presetValues)
+	return values, nil
+}
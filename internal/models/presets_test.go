@@ -0,0 +1,36 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestVariableValues(t *testing.T) {
+	prompt := Prompt{
+		ID:       "weekly-report",
+		Defaults: map[string]string{"tone": "friendly", "team": "Everyone"},
+		Presets: map[string]map[string]string{
+			"sales":    {"team": "Sales", "tone": "upbeat"},
+			"platform": {"team": "Platform"},
+		},
+	}
+
+	values, err := prompt.VariableValues("")
+	if err != nil || values["tone"] != "friendly" || values["team"] != "Everyone" {
+		t.Errorf("Expected the defaults, got %v, %v", values, err)
+	}
+	values, err = prompt.VariableValues("platform")
+	if err != nil || values["tone"] != "friendly" || values["team"] != "Platform" {
+		t.Errorf("Expected the preset over the defaults, got %v, %v", values, err)
+	}
+	if prompt.Defaults["team"] != "Everyone" {
+		t.Error("Expected the defaults to be left unchanged")
+	}
+
+	if _, err := prompt.VariableValues("marketing"); err == nil || !strings.Contains(err.Error(), "platform, sales") {
+		t.Errorf("Expected an unknown preset to list the others, got %v", err)
+	}
+	if _, err := (Prompt{ID: "plain"}).VariableValues("sales"); err == nil {
+		t.Error("Expected a preset of a prompt without presets to fail")
+	}
+}
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"sort"
 	"strings"
 	"time"
 )
@@ -8,15 +9,24 @@ import (
 // Prompt represents a prompt artifact with YAML frontmatter and markdown content
 type Prompt struct {
 	// Frontmatter fields
-	ID           string                 `yaml:"id"`
-	Version      string                 `yaml:"version"`
-	Name         string                 `yaml:"title"`
-	Summary      string                 `yaml:"description"`
-	Tags         []string               `yaml:"tags"`
-	TemplateRef  string                 `yaml:"template,omitempty"`
-	Metadata     map[string]interface{} `yaml:"metadata,omitempty"`
-	CreatedAt    time.Time              `yaml:"created_at"`
-	UpdatedAt    time.Time              `yaml:"updated_at"`
+	ID          string                       `yaml:"id"`
+	Version     string                       `yaml:"version"`
+	Name        string                       `yaml:"title"`
+	Summary     string                       `yaml:"description"`
+	Tags        []string                     `yaml:"tags"`
+	TemplateRef string                       `yaml:"template,omitempty"`
+	Slots       map[string]string            `yaml:"slots,omitempty"`     // Values for the template's slots
+	Variables   []Slot                       `yaml:"variables,omitempty"` // Declared variables of the body, e.g. secret ones
+	Defaults    map[string]string            `yaml:"defaults,omitempty"`  // Variable values used unless given
+	Presets     map[string]map[string]string `yaml:"presets,omitempty"`   // Named sets of variable values
+	Metadata    map[string]interface{}       `yaml:"metadata,omitempty"`
+	Attachments []string                     `yaml:"attachments,omitempty"` // Files in the library's attachments directory
+	Encrypted   bool                         `yaml:"encrypted,omitempty"`   // Body is stored encrypted at rest
+	Draft       bool                         `yaml:"draft,omitempty"`       // Committed to the drafts branch until published
+	Rating      int                          `yaml:"rating,omitempty"`      // Stars from 1 to MaxRating, 0 when unrated
+	Notes       string                       `yaml:"notes,omitempty"`       // Usage tips and caveats, never rendered or copied
+	CreatedAt   time.Time                    `yaml:"created_at"`
+	UpdatedAt   time.Time                    `yaml:"updated_at"`
 
 	// Content fields
 	Content     string `yaml:"-"` // The markdown content after frontmatter
@@ -27,17 +37,68 @@ type Prompt struct {
 
 // Implement list.Item interface for bubbles list component
 
-// FilterValue returns the value used for filtering in lists
+// FilterValue returns the value used for filtering in lists. It is the
+// title, so the list can highlight matches at the same positions.
 func (p Prompt) FilterValue() string {
-	return cleanString(p.Name)
+	return p.Title()
 }
 
 // Title satisfies the list.Item interface
 func (p Prompt) Title() string {
-	if p.Name != "" {
-		return cleanString(p.Name)
+	title := p.Name
+	if title == "" {
+		title = p.ID
+	} else if ns := p.Namespace(); ns != "" {
+		title = ns + " › " + title
 	}
-	return cleanString(p.ID)
+	return cleanString(title)
+}
+
+// Namespace returns the directory part of a namespaced ID, e.g.
+// "team/marketing" for "team/marketing/launch", or "" for top-level prompts
+func (p Prompt) Namespace() string {
+	if i := strings.LastIndex(p.ID, "/"); i >= 0 {
+		return p.ID[:i]
+	}
+	return ""
+}
+
+// MetadataKeys returns the keys of the prompt's custom metadata, sorted
+func (p Prompt) MetadataKeys() []string {
+	keys := make([]string, 0, len(p.Metadata))
+	for key := range p.Metadata {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// HasTag reports whether the prompt carries a tag matching the pattern
+// (see MatchTag)
+func (p Prompt) HasTag(tag string) bool {
+	return containsTag(p.Tags, tag)
+}
+
+// HasTags reports whether the prompt carries a tag matching every pattern,
+// or with matchAny at least one of them. No patterns match every prompt.
+func (p Prompt) HasTags(patterns []string, matchAny bool) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	for _, pattern := range patterns {
+		if containsTag(p.Tags, pattern) == matchAny {
+			return matchAny
+		}
+	}
+	return !matchAny
+}
+
+// SortByNamespace groups prompts by namespace, top-level prompts first,
+// keeping their existing order within each group
+func SortByNamespace(prompts []*Prompt) {
+	sort.SliceStable(prompts, func(i, j int) bool {
+		return prompts[i].Namespace() < prompts[j].Namespace()
+	})
 }
 
 // Description satisfies the list.Item interface  
@@ -57,6 +118,18 @@ func (p Prompt) Description() string {
 		}
 	}
 	
+	if p.Encrypted {
+		parts = append(parts, "Encrypted")
+	}
+	
+	if p.Draft {
+		parts = append(parts, "Draft")
+	}
+	
+	if p.Rating > 0 {
+		parts = append(parts, Stars(p.Rating))
+	}
+	
 	// Add last edited info
 	if !p.UpdatedAt.IsZero() {
 		parts = append(parts, "Last edited: " + p.UpdatedAt.Format("2006-01-02 15:04"))
@@ -98,22 +171,30 @@ func cleanString(s string) string {
 		return ""
 	}
 	
-	// Remove any control characters, newlines, tabs that could break rendering
-	cleaned := ""
+	// Remove any control characters, newlines, tabs that could break
+	// rendering, collapsing runs of spaces. This runs for every prompt each
+	// time the library is filtered, so it makes a single pass.
+	var b strings.Builder
+	b.Grow(len(s))
+	space := false
 	for _, r := range s {
 		if r == '\n' || r == '\r' || r == '\t' {
-			cleaned += " "
-		} else if r >= 32 && r != 127 { // Keep printable ASCII + unicode
-			cleaned += string(r)
+			r = ' '
+		} else if r < 32 || r == 127 { // Keep printable ASCII + unicode
+			continue
 		}
+		if r == ' ' {
+			if space {
+				continue
+			}
+			space = true
+		} else {
+			space = false
+		}
+		b.WriteRune(r)
 	}
 	
-	// Collapse multiple spaces
-	for cleaned != strings.ReplaceAll(cleaned, "  ", " ") {
-		cleaned = strings.ReplaceAll(cleaned, "  ", " ")
-	}
-	
-	return strings.TrimSpace(cleaned)
+	return strings.TrimSpace(b.String())
 }
 
 func joinTags(tags []string) string {
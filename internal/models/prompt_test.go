@@ -0,0 +1,17 @@
+package models
+
+import "testing"
+
+func TestTitleCleansWhitespace(t *testing.T) {
+	tests := map[string]string{
+		"Code Review":                "Code Review",
+		"  Code\n\tReview  ":         "Code Review",
+		"Code \x01 \r\n  Review\x7f": "Code Review",
+		"Résumé   ✨":                 "Résumé ✨",
+	}
+	for name, want := range tests {
+		if got := (Prompt{ID: "p", Name: name}).Title(); got != want {
+			t.Errorf("Title of %q: expected %q, got %q", name, want, got)
+		}
+	}
+}
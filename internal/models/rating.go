@@ -0,0 +1,45 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// MaxRating is the highest star rating a prompt can have. Unrated prompts
+// have a rating of 0.
+const MaxRating = 5
+
+// ParseRating parses a star rating from 1 to MaxRating, or 0 for none
+func ParseRating(s string) (int, error) {
+	rating, err := strconv.Atoi(strings.TrimSpace(s))
+	if err != nil || rating < 0 || rating > MaxRating {
+		return 0, fmt.Errorf("invalid rating %q: use a number from 1 to %d, or 0 to clear it", s, MaxRating)
+	}
+	return rating, nil
+}
+
+// Stars renders a rating as filled and empty stars, e.g. ★★★☆☆, or "" for
+// an unrated prompt
+func Stars(rating int) string {
+	if rating <= 0 {
+		return ""
+	}
+	rating = min(rating, MaxRating)
+	return strings.Repeat("★", rating) + strings.Repeat("☆", MaxRating-rating)
+}
+
+// FilterMinRating returns the prompts rated at least min stars. Unrated
+// prompts are left out unless min is 0.
+func FilterMinRating(prompts []*Prompt, min int) []*Prompt {
+	if min <= 0 {
+		return prompts
+	}
+	var rated []*Prompt
+	for _, p := range prompts {
+		if p.Rating >= min {
+			rated = append(rated, p)
+		}
+	}
+	return rated
+}
@@ -29,6 +29,7 @@ type SavedSearch struct {
 	Description string             `json:"description,omitempty"`
 	Expression  *BooleanExpression `json:"expression"`
 	TextQuery   string             `json:"text_query,omitempty"` // Optional text search filter
+	Pinned      bool               `json:"pinned,omitempty"`     // Shown as a folder at the top of the library
 	CreatedAt   string             `json:"created_at"`
 	UpdatedAt   string             `json:"updated_at"`
 }
@@ -192,11 +193,41 @@ func (be *BooleanExpression) String() string {
 	}
 }
 
-// containsTag checks if a tag is present in the tags slice (case-insensitive)
+// RenameTags replaces tag names in the expression. Keys of rename are
+// lower case, since tags match case-insensitively. It reports whether
+// anything changed.
+func (be *BooleanExpression) RenameTags(rename map[string]string) bool {
+	if be == nil {
+		return false
+	}
+
+	if be.Type == ExpressionTag {
+		tagName, ok := be.Value.(string)
+		if !ok {
+			return false
+		}
+		if to, ok := rename[strings.ToLower(tagName)]; ok && to != tagName {
+			be.Value = to
+			return true
+		}
+		return false
+	}
+
+	changed := false
+	if expressions, ok := be.Value.([]*BooleanExpression); ok {
+		for _, expr := range expressions {
+			if expr.RenameTags(rename) {
+				changed = true
+			}
+		}
+	}
+	return changed
+}
+
+// containsTag checks if any tag matches the target pattern (case-insensitive)
 func containsTag(tags []string, target string) bool {
-	targetLower := strings.ToLower(target)
 	for _, tag := range tags {
-		if strings.ToLower(tag) == targetLower {
+		if MatchTag(tag, target) {
 			return true
 		}
 	}
@@ -0,0 +1,41 @@
+package models
+
+import (
+	"regexp"
+	"slices"
+)
+
+// Snippet is a reusable fragment, such as a persona or an output format
+// block, that prompts and templates include with {{snippet:name}}
+type Snippet struct {
+	Name        string `yaml:"-"` // Path within the snippets directory, without .md
+	Description string `yaml:"description,omitempty"`
+	Content     string `yaml:"-"`
+	FilePath    string `yaml:"-"`
+}
+
+// SnippetNamePattern matches snippet names: slash-separated segments of
+// letters, digits, hyphens and underscores, e.g. "personas/reviewer"
+const SnippetNamePattern = `[A-Za-z0-9_-]+(?:/[A-Za-z0-9_-]+)*`
+
+// SnippetRefRe matches the {{snippet:name}} references expanded when a
+// prompt is rendered
+var SnippetRefRe = regexp.MustCompile(`\{\{\s*snippet:(` + SnippetNamePattern + `)\s*\}\}`)
+
+var snippetNameRe = regexp.MustCompile(`^` + SnippetNamePattern + `$`)
+
+// ValidSnippetName reports whether name can be used for a snippet
+func ValidSnippetName(name string) bool {
+	return snippetNameRe.MatchString(name)
+}
+
+// SnippetRefs returns the snippets content references, in order of use
+func SnippetRefs(content string) []string {
+	var names []string
+	for _, match := range SnippetRefRe.FindAllStringSubmatch(content, -1) {
+		if !slices.Contains(names, match[1]) {
+			names = append(names, match[1])
+		}
+	}
+	return names
+}
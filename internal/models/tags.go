@@ -0,0 +1,121 @@
+package models
+
+import (
+	"sort"
+	"strings"
+)
+
+// TagSeparator separates the levels of a hierarchical tag such as ai/agents
+const TagSeparator = "/"
+
+// MatchTag reports whether a tag matches a pattern, ignoring case. A
+// pattern ending in "/*" matches the tag before it and every tag beneath
+// it, so "ai/*" matches ai, ai/agents and ai/rag/eval. Otherwise "*"
+// matches any run of characters, slashes included, and "?" any single
+// character, so "ai*" matches ai-tools and "*-draft" matches blog-draft.
+func MatchTag(tag, pattern string) bool {
+	if prefix, ok := strings.CutSuffix(pattern, TagSeparator+"*"); ok && !strings.ContainsAny(prefix, "*?") {
+		return strings.EqualFold(tag, prefix) ||
+			(len(tag) > len(prefix) && strings.EqualFold(tag[:len(prefix)+1], prefix+TagSeparator))
+	}
+	if strings.ContainsAny(pattern, "*?") {
+		return matchWildcard([]rune(strings.ToLower(tag)), []rune(strings.ToLower(pattern)))
+	}
+	return strings.EqualFold(tag, pattern)
+}
+
+// matchWildcard matches text against a pattern of literal runes, "*" and
+// "?". After a mismatch it retries from the most recent "*", letting that
+// star swallow one more rune.
+func matchWildcard(text, pattern []rune) bool {
+	t, p := 0, 0
+	star, retry := -1, 0
+	for t < len(text) {
+		switch {
+		case p < len(pattern) && (pattern[p] == '?' || pattern[p] == text[t]):
+			t++
+			p++
+		case p < len(pattern) && pattern[p] == '*':
+			star, retry = p, t
+			p++
+		case star >= 0:
+			retry++
+			t, p = retry, star+1
+		default:
+			return false
+		}
+	}
+	for p < len(pattern) && pattern[p] == '*' {
+		p++
+	}
+	return p == len(pattern)
+}
+
+// TagColor returns the color configured for a tag, ignoring case. A tag
+// without a color of its own takes the color of its nearest ancestor, so a
+// color for "ai" also applies to ai/agents. It returns "" when none applies.
+func TagColor(tag string, colors map[string]string) string {
+	for path := tag; path != ""; {
+		for pattern, color := range colors {
+			if strings.EqualFold(pattern, path) {
+				return color
+			}
+		}
+		i := strings.LastIndex(path, TagSeparator)
+		if i < 0 {
+			break
+		}
+		path = path[:i]
+	}
+	return ""
+}
+
+// TagNode is one level of the tag hierarchy
+type TagNode struct {
+	Name     string // Last segment, e.g. "agents"
+	Path     string // Full tag, e.g. "ai/agents"
+	Count    int    // Prompts tagged with exactly this tag
+	Total    int    // Uses of this tag and the tags beneath it
+	Children []*TagNode
+}
+
+// BuildTagTree arranges tags into a tree by their slash-delimited segments.
+// counts maps each tag to the number of prompts carrying it. Intermediate
+// levels that are not tags themselves get a Count of zero.
+func BuildTagTree(counts map[string]int) []*TagNode {
+	root := &TagNode{}
+	index := make(map[string]*TagNode)
+
+	for tag, count := range counts {
+		parent := root
+		var path string
+		for _, segment := range strings.Split(tag, TagSeparator) {
+			if path == "" {
+				path = segment
+			} else {
+				path += TagSeparator + segment
+			}
+			node, ok := index[path]
+			if !ok {
+				node = &TagNode{Name: segment, Path: path}
+				index[path] = node
+				parent.Children = append(parent.Children, node)
+			}
+			node.Total += count
+			parent = node
+		}
+		parent.Count += count
+	}
+
+	sortTagNodes(root.Children)
+	return root.Children
+}
+
+func sortTagNodes(nodes []*TagNode) {
+	sort.Slice(nodes, func(i, j int) bool {
+		return strings.ToLower(nodes[i].Name) < strings.ToLower(nodes[j].Name)
+	})
+	for _, node := range nodes {
+		sortTagNodes(node.Children)
+	}
+}
@@ -0,0 +1,92 @@
+package models
+
+import "testing"
+
+func TestMatchTag(t *testing.T) {
+	tests := []struct {
+		tag, pattern string
+		want         bool
+	}{
+		{"ai", "ai", true},
+		{"AI", "ai", true},
+		{"ai/agents", "ai", false},
+		{"ai/agents", "ai/*", true},
+		{"ai", "ai/*", true},
+		{"ai/rag/eval", "AI/*", true},
+		{"aid", "ai/*", false},
+		{"ai/rag", "ai/rag/*", true},
+		{"ai/ragtime", "ai/rag/*", false},
+		{"ai-tools", "ai*", true},
+		{"AI/agents", "ai*", true},
+		{"bai", "ai*", false},
+		{"blog-draft", "*-DRAFT", true},
+		{"blog-drafts", "*-draft", false},
+		{"v1", "v?", true},
+		{"v10", "v?", false},
+		{"prompt-v2-final", "*v?*", true},
+		{"ai/rag/eval", "ai/*/eval", true},
+		{"ai/eval", "ai/*/eval", false},
+	}
+	for _, tt := range tests {
+		if got := MatchTag(tt.tag, tt.pattern); got != tt.want {
+			t.Errorf("MatchTag(%q, %q) = %v, want %v", tt.tag, tt.pattern, got, tt.want)
+		}
+	}
+}
+
+func TestHasTags(t *testing.T) {
+	p := Prompt{Tags: []string{"ai/agents", "writing"}}
+	tests := []struct {
+		patterns []string
+		matchAny bool
+		want     bool
+	}{
+		{nil, false, true},
+		{nil, true, true},
+		{[]string{"ai/*", "writing"}, false, true},
+		{[]string{"ai/*", "code"}, false, false},
+		{[]string{"ai/*", "code"}, true, true},
+		{[]string{"code", "eval"}, true, false},
+	}
+	for _, tt := range tests {
+		if got := p.HasTags(tt.patterns, tt.matchAny); got != tt.want {
+			t.Errorf("HasTags(%q, %v) = %v, want %v", tt.patterns, tt.matchAny, got, tt.want)
+		}
+	}
+}
+
+func TestBuildTagTree(t *testing.T) {
+	tree := BuildTagTree(map[string]int{"writing": 1, "ai/rag": 2, "ai/agents": 1, "ai/rag/eval": 1})
+	if len(tree) != 2 || tree[0].Path != "ai" || tree[1].Path != "writing" {
+		t.Fatalf("Unexpected roots: %+v", tree)
+	}
+	ai := tree[0]
+	if ai.Count != 0 || ai.Total != 4 || len(ai.Children) != 2 {
+		t.Errorf("Unexpected ai node: %+v", ai)
+	}
+	rag := ai.Children[1]
+	if rag.Path != "ai/rag" || rag.Count != 2 || rag.Total != 3 || rag.Children[0].Path != "ai/rag/eval" {
+		t.Errorf("Unexpected ai/rag node: %+v", rag)
+	}
+}
+
+func TestTagColor(t *testing.T) {
+	colors := map[string]string{"AI": "33", "ai/rag": "#ff8800", "work": "10"}
+
+	tests := []struct {
+		tag  string
+		want string
+	}{
+		{"ai", "33"},
+		{"ai/agents", "33"},
+		{"ai/rag/eval", "#ff8800"},
+		{"Work", "10"},
+		{"personal", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := TagColor(tt.tag, colors); got != tt.want {
+			t.Errorf("TagColor(%q) = %q, want %q", tt.tag, got, tt.want)
+		}
+	}
+}
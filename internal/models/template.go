@@ -26,6 +26,7 @@ type Slot struct {
 	Description string `yaml:"description,omitempty"`
 	Required    bool   `yaml:"required"`
 	Default     string `yaml:"default,omitempty"`
+	Secret      bool   `yaml:"secret,omitempty"` // Value is read from the secrets store when rendering
 }
 
 // TemplateRules defines validation constraints for templates
@@ -0,0 +1,134 @@
+package models
+
+import "strings"
+
+// ExtractExcludedTerms removes negated terms from a text search query:
+// -draft, -"first draft", NOT test and NOT "first draft". It returns the
+// rest of the query and the excluded terms in lower case.
+func ExtractExcludedTerms(query string) (string, []string) {
+	var rest, excluded []string
+	negateNext := false
+	for _, word := range splitQueryWords(query) {
+		switch {
+		case negateNext:
+			excluded = append(excluded, strings.ToLower(unquote(word)))
+			negateNext = false
+		case word == "NOT":
+			negateNext = true
+		case len(word) > 1 && word[0] == '-':
+			excluded = append(excluded, strings.ToLower(unquote(word[1:])))
+		default:
+			rest = append(rest, word)
+		}
+	}
+	if negateNext {
+		// A trailing NOT has nothing to negate, so search for it instead
+		rest = append(rest, "NOT")
+	}
+	return strings.Join(rest, " "), excluded
+}
+
+// ContainsAnyTerm reports whether text contains any of the lower-case
+// terms, ignoring case
+func ContainsAnyTerm(text string, terms []string) bool {
+	if len(terms) == 0 {
+		return false
+	}
+	text = strings.ToLower(text)
+	for _, term := range terms {
+		if term != "" && strings.Contains(text, term) {
+			return true
+		}
+	}
+	return false
+}
+
+// QueryTerms returns the lower-case words and phrases a text search query
+// looks for, leaving out negated terms and created:/updated: filters
+func QueryTerms(query string) []string {
+	rest, _ := ExtractExcludedTerms(query)
+	var terms []string
+	for _, word := range splitQueryWords(rest) {
+		field, _, ok := strings.Cut(strings.ToLower(word), ":")
+		if ok && (field == "created" || field == "updated") {
+			continue
+		}
+		if term := strings.ToLower(unquote(word)); term != "" {
+			terms = append(terms, term)
+		}
+	}
+	return terms
+}
+
+// MatchRanges returns the byte ranges of text that contain any of the
+// lower-case terms, ignoring case. Overlapping ranges are merged.
+func MatchRanges(text string, terms []string) [][2]int {
+	lower := strings.ToLower(text)
+	if len(lower) != len(text) {
+		// Lowering changed the byte offsets, so they cannot be mapped back
+		return nil
+	}
+	covered := make([]bool, len(text))
+	for _, term := range terms {
+		if term == "" {
+			continue
+		}
+		for start := 0; ; {
+			i := strings.Index(lower[start:], term)
+			if i < 0 {
+				break
+			}
+			for j := start + i; j < start+i+len(term); j++ {
+				covered[j] = true
+			}
+			start += i + 1
+		}
+	}
+
+	var ranges [][2]int
+	for i := 0; i < len(covered); i++ {
+		if !covered[i] {
+			continue
+		}
+		j := i
+		for j < len(covered) && covered[j] {
+			j++
+		}
+		ranges = append(ranges, [2]int{i, j})
+		i = j
+	}
+	return ranges
+}
+
+// splitQueryWords splits a query on whitespace, keeping double-quoted
+// phrases, quotes included, as single words
+func splitQueryWords(query string) []string {
+	var words []string
+	var current strings.Builder
+	quoted := false
+	for _, r := range query {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			current.WriteRune(r)
+		case !quoted && (r == ' ' || r == '\t' || r == '\n'):
+			if current.Len() > 0 {
+				words = append(words, current.String())
+				current.Reset()
+			}
+		default:
+			current.WriteRune(r)
+		}
+	}
+	if current.Len() > 0 {
+		words = append(words, current.String())
+	}
+	return words
+}
+
+func unquote(word string) string {
+	if len(word) >= 2 && word[0] == '"' && word[len(word)-1] == '"' {
+		return word[1 : len(word)-1]
+	}
+	return word
+}
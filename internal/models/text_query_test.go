@@ -0,0 +1,54 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExtractExcludedTerms(t *testing.T) {
+	tests := []struct {
+		query    string
+		rest     string
+		excluded []string
+	}{
+		{"agent -draft -test", "agent", []string{"draft", "test"}},
+		{`agent NOT "First Draft"`, "agent", []string{"first draft"}},
+		{`-"work in progress" "code review"`, `"code review"`, []string{"work in progress"}},
+		{"e-mail - summary", "e-mail - summary", nil},
+		{"agent NOT", "agent NOT", nil},
+	}
+	for _, tt := range tests {
+		rest, excluded := ExtractExcludedTerms(tt.query)
+		if rest != tt.rest || !reflect.DeepEqual(excluded, tt.excluded) {
+			t.Errorf("%q: got %q %q, want %q %q", tt.query, rest, excluded, tt.rest, tt.excluded)
+		}
+	}
+}
+
+func TestContainsAnyTerm(t *testing.T) {
+	if !ContainsAnyTerm("Agent Draft v2", []string{"draft"}) {
+		t.Error("Expected a case-insensitive match")
+	}
+	if ContainsAnyTerm("Agent", []string{"draft", ""}) {
+		t.Error("Expected no match")
+	}
+}
+
+func TestQueryTerms(t *testing.T) {
+	got := QueryTerms(`Code "Pull Request" -draft updated:>2024-01-01`)
+	want := []string{"code", "pull request"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestMatchRanges(t *testing.T) {
+	got := MatchRanges("Code review for codecs", []string{"code", "dec"})
+	want := [][2]int{{0, 4}, {16, 21}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if got := MatchRanges("Summary", nil); got != nil {
+		t.Errorf("Expected no ranges without terms, got %v", got)
+	}
+}
@@ -0,0 +1,9 @@
+package models
+
+import "time"
+
+// PromptUsage records how often a prompt has been copied or rendered
+type PromptUsage struct {
+	Count    int       `json:"count"`
+	LastUsed time.Time `json:"last_used"`
+}
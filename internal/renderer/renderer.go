@@ -2,32 +2,273 @@ package renderer
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"text/template"
 
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+
 	"github.com/dpshade/pocket-prompt/internal/models"
 )
 
 // Renderer handles prompt rendering with variable substitution
 type Renderer struct {
-	prompt   *models.Prompt
-	template *models.Template
+	prompt      *models.Prompt
+	template    *models.Template
+	ctx         context.Context
+	attachments AttachmentLoader
+	snippets    SnippetLoader
+	secrets     SecretLoader
+	preset      string
+}
+
+// AttachmentLoader returns the contents of a file in the library's
+// attachments directory
+type AttachmentLoader func(ctx context.Context, name string) (string, error)
+
+// AttachmentError reports an attachment that could not be inlined
+type AttachmentError struct {
+	Name string
+	Err  error
+}
+
+func (e *AttachmentError) Error() string {
+	return fmt.Sprintf("attachment %s: %v", e.Name, e.Err)
+}
+
+func (e *AttachmentError) Unwrap() error {
+	return e.Err
+}
+
+// SnippetLoader returns the content of a snippet from the library's
+// snippets directory
+type SnippetLoader func(ctx context.Context, name string) (string, error)
+
+// SnippetError reports a snippet reference that could not be expanded
+type SnippetError struct {
+	Name string
+	Err  error
 }
 
+func (e *SnippetError) Error() string {
+	return fmt.Sprintf("snippet %s: %v", e.Name, e.Err)
+}
+
+func (e *SnippetError) Unwrap() error {
+	return e.Err
+}
+
+// SecretLoader returns the value of a secret variable from the secrets
+// store
+type SecretLoader func(ctx context.Context, name string) (string, error)
+
+// maxSnippetDepth limits how deeply snippets can include other snippets
+const maxSnippetDepth = 8
+
 // NewRenderer creates a new renderer instance
 func NewRenderer(prompt *models.Prompt, tmpl *models.Template) *Renderer {
 	return &Renderer{
 		prompt:   prompt,
 		template: tmpl,
+		ctx:      context.Background(),
 	}
 }
 
-// RenderText renders the prompt as plain text with variables substituted
+// SetContext sets the context passed to the loaders, so a render stops
+// loading attachments, snippets and secrets once it is cancelled
+func (r *Renderer) SetContext(ctx context.Context) {
+	r.ctx = ctx
+}
+
+// SetAttachmentLoader lets content inline attachments with
+// {{attach "examples/review.md"}}
+func (r *Renderer) SetAttachmentLoader(load AttachmentLoader) {
+	r.attachments = load
+}
+
+// SetSnippetLoader lets content include snippets with {{snippet:name}}
+func (r *Renderer) SetSnippetLoader(load SnippetLoader) {
+	r.snippets = load
+}
+
+// SetSecretLoader lets variables declared secret be filled from the
+// secrets store. Without a loader, rendering a prompt that uses one fails.
+func (r *Renderer) SetSecretLoader(load SecretLoader) {
+	r.secrets = load
+}
+
+// SetPreset fills variables from one of the prompt's named presets, over
+// its defaults. Rendering fails if the prompt has no such preset.
+func (r *Renderer) SetPreset(name string) {
+	r.preset = name
+}
+
+// withDefaults returns variables over the prompt's default values and
+// those of the selected preset
+func (r *Renderer) withDefaults(variables map[string]interface{}) (map[string]interface{}, error) {
+	values, err := r.prompt.VariableValues(r.preset)
+	if err != nil {
+		return nil, err
+	}
+	if len(values) == 0 {
+		return variables, nil
+	}
+	filled := make(map[string]interface{}, len(values)+len(variables))
+	for k, v := range values {
+		filled[k] = v
+	}
+	for k, v := range variables {
+		filled[k] = v
+	}
+	return filled, nil
+}
+
+// SecretVariables returns the names of the prompt's variables and the
+// template's slots that are declared secret
+func (r *Renderer) SecretVariables() []string {
+	var names []string
+	for _, variable := range r.prompt.Variables {
+		if variable.Secret && !slices.Contains(names, variable.Name) {
+			names = append(names, variable.Name)
+		}
+	}
+	if r.template != nil {
+		for _, slot := range r.template.Slots {
+			if slot.Secret && !slices.Contains(names, slot.Name) {
+				names = append(names, slot.Name)
+			}
+		}
+	}
+	return names
+}
+
+// withSecrets returns variables with the secret variables it lacks filled
+// from the secrets store. Values given explicitly take precedence.
+func (r *Renderer) withSecrets(variables map[string]interface{}) (map[string]interface{}, error) {
+	names := r.SecretVariables()
+	if len(names) == 0 {
+		return variables, nil
+	}
+	filled := make(map[string]interface{}, len(variables)+len(names))
+	for k, v := range variables {
+		filled[k] = v
+	}
+	for _, name := range names {
+		if _, ok := filled[name]; ok {
+			continue
+		}
+		if r.secrets == nil {
+			return nil, fmt.Errorf("secret variable %s is not available here", name)
+		}
+		value, err := r.secrets(r.ctx, name)
+		if err != nil {
+			return nil, fmt.Errorf("secret variable %s: %w", name, err)
+		}
+		filled[name] = value
+	}
+	return filled, nil
+}
+
+// expandSnippets replaces {{snippet:name}} references with the snippets'
+// content, which may reference further snippets. Expansion happens before
+// the content is parsed, so snippets can use variables and directives.
+func (r *Renderer) expandSnippets(content string, stack []string) (string, error) {
+	var expandErr error
+	expanded := models.SnippetRefRe.ReplaceAllStringFunc(content, func(ref string) string {
+		if expandErr != nil {
+			return ref
+		}
+		name := models.SnippetRefRe.FindStringSubmatch(ref)[1]
+		switch {
+		case r.snippets == nil:
+			expandErr = &SnippetError{Name: name, Err: fmt.Errorf("snippets are not available here")}
+		case slices.Contains(stack, name):
+			expandErr = &SnippetError{Name: name, Err: fmt.Errorf("includes itself via %s", strings.Join(append(stack, name), " -> "))}
+		case len(stack) >= maxSnippetDepth:
+			expandErr = &SnippetError{Name: name, Err: fmt.Errorf("nested more than %d deep", maxSnippetDepth)}
+		}
+		if expandErr != nil {
+			return ref
+		}
+
+		snippet, err := r.snippets(r.ctx, name)
+		if err != nil {
+			expandErr = &SnippetError{Name: name, Err: err}
+			return ref
+		}
+		snippet, err = r.expandSnippets(snippet, append(stack[:len(stack):len(stack)], name))
+		if err != nil {
+			expandErr = err
+			return ref
+		}
+		return snippet
+	})
+	if expandErr != nil {
+		return "", expandErr
+	}
+	return expanded, nil
+}
+
+// funcs returns the functions available to prompt and template content
+func (r *Renderer) funcs() template.FuncMap {
+	return template.FuncMap{
+		"attach": func(name string) (string, error) {
+			if r.attachments == nil {
+				return "", &AttachmentError{Name: name, Err: fmt.Errorf("attachments are not available here")}
+			}
+			content, err := r.attachments(r.ctx, name)
+			if err != nil {
+				return "", &AttachmentError{Name: name, Err: err}
+			}
+			return content, nil
+		},
+	}
+}
+
+// RenderText renders the prompt as plain text with variables substituted.
+// A multi-message prompt is rendered as a transcript headed by each role.
 func (r *Renderer) RenderText(variables map[string]interface{}) (string, error) {
-	// Start with the prompt content
-	content := r.prompt.Content
+	rendered, err := r.render(variables)
+	if err != nil {
+		return "", err
+	}
+	if messages := models.ParseMessages(rendered); messages != nil {
+		return models.FormatTranscript(messages), nil
+	}
+	return rendered, nil
+}
+
+// RenderMessages renders the prompt as chat messages: one per role marker
+// of a multi-message prompt, or a single user message
+func (r *Renderer) RenderMessages(variables map[string]interface{}) ([]Message, error) {
+	rendered, err := r.render(variables)
+	if err != nil {
+		return nil, err
+	}
+	if messages := models.ParseMessages(rendered); messages != nil {
+		return messages, nil
+	}
+	return []Message{{Role: models.RoleUser, Content: rendered}}, nil
+}
+
+// render applies the template and variables to the prompt content
+func (r *Renderer) render(variables map[string]interface{}) (string, error) {
+	// Start with the prompt content, with snippets included
+	content, err := r.expandSnippets(r.prompt.Content, nil)
+	if err != nil {
+		return "", err
+	}
+	if variables, err = r.withDefaults(variables); err != nil {
+		return "", err
+	}
+	if variables, err = r.withSecrets(variables); err != nil {
+		return "", err
+	}
 
 	// If there's a template, apply it first
 	if r.template != nil {
@@ -49,20 +290,11 @@ func (r *Renderer) RenderText(variables map[string]interface{}) (string, error)
 
 // RenderJSON renders the prompt as a JSON message array for LLM APIs
 func (r *Renderer) RenderJSON(variables map[string]interface{}) (string, error) {
-	// First render as text
-	text, err := r.RenderText(variables)
+	messages, err := r.RenderMessages(variables)
 	if err != nil {
 		return "", err
 	}
 
-	// Create message structure
-	messages := []Message{
-		{
-			Role:    "user",
-			Content: text,
-		},
-	}
-
 	// Marshal to JSON
 	jsonBytes, err := json.MarshalIndent(messages, "", "  ")
 	if err != nil {
@@ -72,12 +304,29 @@ func (r *Renderer) RenderJSON(variables map[string]interface{}) (string, error)
 	return string(jsonBytes), nil
 }
 
-// Message represents a chat message for LLM APIs
-type Message struct {
-	Role    string `json:"role"`
-	Content string `json:"content"`
+// RenderHTML renders the prompt's markdown as an HTML fragment, for pasting
+// into editors that keep formatting
+func (r *Renderer) RenderHTML(variables map[string]interface{}) (string, error) {
+	text, err := r.RenderText(variables)
+	if err != nil {
+		return "", err
+	}
+	return MarkdownToHTML(text)
 }
 
+// MarkdownToHTML converts GitHub-flavored markdown to an HTML fragment
+func MarkdownToHTML(markdown string) (string, error) {
+	var buf bytes.Buffer
+	md := goldmark.New(goldmark.WithExtensions(extension.GFM))
+	if err := md.Convert([]byte(markdown), &buf); err != nil {
+		return "", fmt.Errorf("failed to convert markdown to HTML: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// Message represents a chat message for LLM APIs
+type Message = models.Message
+
 // applyTemplate applies a template to the prompt content
 func (r *Renderer) applyTemplate(content string, variables map[string]interface{}) (string, error) {
 	if r.template == nil {
@@ -85,7 +334,11 @@ func (r *Renderer) applyTemplate(content string, variables map[string]interface{
 	}
 
 	// Parse template content
-	tmpl, err := template.New("prompt").Parse(r.template.Content)
+	templateContent, err := r.expandSnippets(r.template.Content, nil)
+	if err != nil {
+		return "", err
+	}
+	tmpl, err := template.New("prompt").Funcs(r.funcs()).Parse(templateContent)
 	if err != nil {
 		return "", fmt.Errorf("failed to parse template: %w", err)
 	}
@@ -93,10 +346,12 @@ func (r *Renderer) applyTemplate(content string, variables map[string]interface{
 	// Prepare template data
 	data := make(map[string]interface{})
 	
-	// Add slot values from variables
+	// Add slot values from variables, then the prompt's own slot values
 	for _, slot := range r.template.Slots {
 		if val, ok := variables[slot.Name]; ok {
 			data[slot.Name] = val
+		} else if val, ok := r.prompt.Slots[slot.Name]; ok {
+			data[slot.Name] = val
 		} else if slot.Default != "" {
 			data[slot.Name] = slot.Default
 		} else if slot.Required {
@@ -129,7 +384,7 @@ func (r *Renderer) substituteVariables(content string, variables map[string]inte
 	}
 
 	// Simple variable substitution using template syntax
-	tmpl, err := template.New("content").Parse(content)
+	tmpl, err := template.New("content").Funcs(r.funcs()).Parse(content)
 	if err != nil {
 		// If template parsing fails, try simple string replacement
 		result := content
@@ -146,6 +401,11 @@ func (r *Renderer) substituteVariables(content string, variables map[string]inte
 	// Execute template with variables
 	var buf bytes.Buffer
 	if err := tmpl.Execute(&buf, allVars); err != nil {
+		// A missing attachment would leave part of the prompt out
+		var attachErr *AttachmentError
+		if errors.As(err, &attachErr) {
+			return "", attachErr
+		}
 		// Fall back to simple replacement if template execution fails
 		result := content
 		for k, v := range allVars {
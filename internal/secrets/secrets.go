@@ -0,0 +1,269 @@
+// Package secrets keeps the values of secret variables, such as API keys,
+// outside the library's prompt files: in the OS keychain or in a file
+// encrypted with the library's encryption settings.
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/dpshade/pocket-prompt/internal/config"
+	"github.com/dpshade/pocket-prompt/internal/encryption"
+)
+
+// Service is the keychain service secrets are filed under
+const Service = "pocket-prompt"
+
+// DefaultFile is where the file store keeps secrets, relative to the
+// library root
+const DefaultFile = ".pocket-prompt/secrets.enc"
+
+// ErrNotFound is returned for a secret that has not been set
+var ErrNotFound = errors.New("secret not set")
+
+// Store reads and writes secret values by variable name. Get stops when
+// ctx is cancelled, killing a keychain lookup in progress.
+type Store interface {
+	Get(ctx context.Context, name string) (string, error)
+	Set(name, value string) error
+	Delete(name string) error
+}
+
+// New returns the store selected by the config: the OS keychain by
+// default, or an encrypted file, which needs encryption to be configured
+func New(cfg config.SecretsConfig, baseDir string, enc encryption.Encryptor) (Store, error) {
+	switch cfg.Store {
+	case "", "keychain":
+		return keychain{}, nil
+	case "file":
+		if enc == nil {
+			return nil, fmt.Errorf("the secrets file needs encryption to be configured (encryption.tool)")
+		}
+		path := cfg.File
+		if path == "" {
+			path = DefaultFile
+		}
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(baseDir, path)
+		}
+		return &fileStore{path: path, enc: enc}, nil
+	default:
+		return nil, fmt.Errorf("unknown secrets store: %s", cfg.Store)
+	}
+}
+
+// keychain keeps secrets in the macOS keychain through security, or in the
+// Secret Service (GNOME Keyring, KWallet) through secret-tool
+type keychain struct{}
+
+func (keychain) Get(ctx context.Context, name string) (string, error) {
+	var out []byte
+	var err error
+	switch runtime.GOOS {
+	case "darwin":
+		out, err = run(ctx, "security", nil, "find-generic-password", "-s", Service, "-a", name, "-w")
+	case "windows":
+		return "", errNoKeychain
+	default:
+		out, err = run(ctx, "secret-tool", nil, "lookup", "service", Service, "variable", name)
+	}
+	if err != nil || len(out) == 0 {
+		// Both tools fail without a message when the item doesn't exist
+		var exitErr *exec.ExitError
+		if err == nil || (ctx.Err() == nil && errors.As(err, &exitErr)) {
+			return "", ErrNotFound
+		}
+		return "", err
+	}
+	return strings.TrimSuffix(string(out), "\n"), nil
+}
+
+func (keychain) Set(name, value string) error {
+	switch runtime.GOOS {
+	case "darwin":
+		// Passing the command on stdin keeps the value out of the process list
+		command := fmt.Sprintf("add-generic-password -U -s %s -a %s -w %s\n", quote(Service), quote(name), quote(value))
+		_, err := run(context.Background(), "security", []byte(command), "-i")
+		return err
+	case "windows":
+		return errNoKeychain
+	default:
+		_, err := run(context.Background(), "secret-tool", []byte(value), "store", "--label", Service+": "+name, "service", Service, "variable", name)
+		return err
+	}
+}
+
+func (keychain) Delete(name string) error {
+	switch runtime.GOOS {
+	case "darwin":
+		_, err := run(context.Background(), "security", nil, "delete-generic-password", "-s", Service, "-a", name)
+		return err
+	case "windows":
+		return errNoKeychain
+	default:
+		_, err := run(context.Background(), "secret-tool", nil, "clear", "service", Service, "variable", name)
+		return err
+	}
+}
+
+var errNoKeychain = errors.New("the keychain is not supported on Windows; set secrets.store to file in config.yaml")
+
+// quote single-quotes s for the command line security -i reads
+func quote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'"'"'`) + "'"
+}
+
+// run executes tool with input on stdin and returns stdout, killing it
+// when ctx is cancelled
+func run(ctx context.Context, tool string, input []byte, args ...string) ([]byte, error) {
+	if _, err := exec.LookPath(tool); err != nil {
+		return nil, fmt.Errorf("%s not found in PATH", tool)
+	}
+	cmd := exec.CommandContext(ctx, tool, args...)
+	cmd.Stdin = bytes.NewReader(input)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("%s cancelled: %w", tool, err)
+		}
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%s failed: %s: %w", tool, msg, err)
+		}
+		return nil, fmt.Errorf("%s failed: %w", tool, err)
+	}
+	return out, nil
+}
+
+// fileStore keeps secrets as an encrypted YAML map, so it can be synced
+// with the library
+type fileStore struct {
+	path string
+	enc  encryption.Encryptor
+}
+
+func (f *fileStore) load() (map[string]string, error) {
+	secrets := make(map[string]string)
+	data, err := os.ReadFile(f.path)
+	if os.IsNotExist(err) {
+		return secrets, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read secrets file: %w", err)
+	}
+	plaintext, err := f.enc.Decrypt(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt secrets file: %w", err)
+	}
+	if err := yaml.Unmarshal(plaintext, &secrets); err != nil {
+		return nil, fmt.Errorf("failed to parse secrets file: %w", err)
+	}
+	return secrets, nil
+}
+
+func (f *fileStore) save(secrets map[string]string) error {
+	plaintext, err := yaml.Marshal(secrets)
+	if err != nil {
+		return fmt.Errorf("failed to encode secrets: %w", err)
+	}
+	data, err := f.enc.Encrypt(plaintext)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt secrets file: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(f.path), 0700); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	if err := os.WriteFile(f.path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write secrets file: %w", err)
+	}
+	return nil
+}
+
+func (f *fileStore) Get(ctx context.Context, name string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	secrets, err := f.load()
+	if err != nil {
+		return "", err
+	}
+	value, ok := secrets[name]
+	if !ok {
+		return "", ErrNotFound
+	}
+	return value, nil
+}
+
+func (f *fileStore) Set(name, value string) error {
+	secrets, err := f.load()
+	if err != nil {
+		return err
+	}
+	secrets[name] = value
+	return f.save(secrets)
+}
+
+func (f *fileStore) Delete(name string) error {
+	secrets, err := f.load()
+	if err != nil {
+		return err
+	}
+	if _, ok := secrets[name]; !ok {
+		return ErrNotFound
+	}
+	delete(secrets, name)
+	return f.save(secrets)
+}
+
+// memoryStore keeps secrets in memory for the life of the process
+type memoryStore struct {
+	mu      sync.Mutex
+	secrets map[string]string
+}
+
+// NewMemoryStore returns a store that keeps secrets in memory only, for
+// tests and libraries that aren't on disk
+func NewMemoryStore() Store {
+	return &memoryStore{secrets: make(map[string]string)}
+}
+
+func (m *memoryStore) Get(ctx context.Context, name string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	value, ok := m.secrets[name]
+	if !ok {
+		return "", ErrNotFound
+	}
+	return value, nil
+}
+
+func (m *memoryStore) Set(name, value string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.secrets[name] = value
+	return nil
+}
+
+func (m *memoryStore) Delete(name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.secrets[name]; !ok {
+		return ErrNotFound
+	}
+	delete(m.secrets, name)
+	return nil
+}
@@ -0,0 +1,87 @@
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dpshade/pocket-prompt/internal/config"
+)
+
+// reverser stands in for age or gpg
+type reverser struct{}
+
+func (reverser) Encrypt(plaintext []byte) ([]byte, error)  { return reverse(plaintext), nil }
+func (reverser) Decrypt(ciphertext []byte) ([]byte, error) { return reverse(ciphertext), nil }
+
+func reverse(b []byte) []byte {
+	out := make([]byte, len(b))
+	for i := range b {
+		out[len(b)-1-i] = b[i]
+	}
+	return out
+}
+
+func TestFileStore(t *testing.T) {
+	library := t.TempDir()
+	if _, err := New(config.SecretsConfig{Store: "file"}, library, nil); err == nil {
+		t.Error("Expected the file store to require encryption")
+	}
+	store, err := New(config.SecretsConfig{Store: "file"}, library, reverser{})
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+
+	if _, err := store.Get(context.Background(), "api_key"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound before the secret is set, got %v", err)
+	}
+	if err := store.Set("api_key", "sk-12345"); err != nil {
+		t.Fatalf("Failed to set secret: %v", err)
+	}
+	if value, err := store.Get(context.Background(), "api_key"); err != nil || value != "sk-12345" {
+		t.Errorf("Expected the stored value, got %q, %v", value, err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(library, DefaultFile))
+	if err != nil {
+		t.Fatalf("Failed to read secrets file: %v", err)
+	}
+	if bytes.Contains(data, []byte("sk-12345")) {
+		t.Error("Expected the secrets file to be encrypted")
+	}
+
+	if err := store.Delete("api_key"); err != nil {
+		t.Fatalf("Failed to delete secret: %v", err)
+	}
+	if _, err := store.Get(context.Background(), "api_key"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound after deleting, got %v", err)
+	}
+}
+
+func TestRunCancelled(t *testing.T) {
+	if _, err := exec.LookPath("sleep"); err != nil {
+		t.Skip("sleep not available")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := run(ctx, "sleep", nil, "5")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the lookup to be cancelled, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("Expected the command to be killed, it ran for %v", elapsed)
+	}
+}
+
+func TestQuote(t *testing.T) {
+	if got := quote("it's"); got != `'it'"'"'s'` {
+		t.Errorf("Unexpected quoting: %s", got)
+	}
+}
@@ -0,0 +1,134 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// x-callback-url parameters. Shortcuts and other iOS automation apps pass
+// them to be sent back to themselves with the outcome of a request.
+const (
+	callbackSuccess = "x-success"
+	callbackError   = "x-error"
+)
+
+// callbackRecorder holds a response back so it can be turned into a
+// redirect to a callback URL
+type callbackRecorder struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (r *callbackRecorder) Header() http.Header {
+	return r.header
+}
+
+func (r *callbackRecorder) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+	}
+}
+
+func (r *callbackRecorder) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	return r.body.Write(b)
+}
+
+// isCallbackParam reports whether a query parameter belongs to the
+// x-callback-url protocol rather than being a prompt variable
+func isCallbackParam(key string) bool {
+	return strings.HasPrefix(key, "x-")
+}
+
+// withCallbacks runs a handler, then redirects to the request's x-success
+// URL with the response content as its result parameter, or to its x-error
+// URL with errorCode and errorMessage parameters when the handler failed.
+// Without callback parameters, or without one for the outcome, the
+// response is sent as is.
+func withCallbacks(w http.ResponseWriter, r *http.Request, handle func(http.ResponseWriter, *http.Request)) {
+	query := r.URL.Query()
+	success, failure := query.Get(callbackSuccess), query.Get(callbackError)
+	if success == "" && failure == "" {
+		handle(w, r)
+		return
+	}
+	for _, callback := range []string{success, failure} {
+		if callback == "" {
+			continue
+		}
+		if err := validCallback(callback); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	rec := &callbackRecorder{header: make(http.Header)}
+	handle(rec, r)
+	if rec.status == 0 {
+		rec.status = http.StatusOK
+	}
+
+	if rec.status < http.StatusBadRequest && success != "" {
+		http.Redirect(w, r, callbackURL(success, url.Values{"result": {rec.body.String()}}), http.StatusFound)
+		return
+	}
+	if rec.status >= http.StatusBadRequest && failure != "" {
+		params := url.Values{
+			"errorCode":    {strconv.Itoa(rec.status)},
+			"errorMessage": {errorMessage(rec)},
+		}
+		http.Redirect(w, r, callbackURL(failure, params), http.StatusFound)
+		return
+	}
+
+	for key, values := range rec.header {
+		w.Header()[key] = values
+	}
+	w.WriteHeader(rec.status)
+	w.Write(rec.body.Bytes())
+}
+
+// validCallback checks a callback is an absolute URL an app can be opened
+// with, refusing schemes that would run script in a browser
+func validCallback(callback string) error {
+	u, err := url.Parse(callback)
+	if err != nil || u.Scheme == "" {
+		return fmt.Errorf("invalid callback URL %q", callback)
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "javascript", "data", "vbscript":
+		return fmt.Errorf("callback URL scheme %s is not allowed", u.Scheme)
+	}
+	return nil
+}
+
+// callbackURL adds parameters to a callback URL, keeping those it has
+func callbackURL(callback string, params url.Values) string {
+	u, _ := url.Parse(callback)
+	query := u.Query()
+	for key, values := range params {
+		query[key] = values
+	}
+	u.RawQuery = strings.ReplaceAll(query.Encode(), "+", "%20")
+	return u.String()
+}
+
+// errorMessage extracts the message of a failed response, which is a JSON
+// error object or plain text
+func errorMessage(rec *callbackRecorder) string {
+	var body struct {
+		Error string `json:"error"`
+	}
+	if json.Unmarshal(rec.body.Bytes(), &body) == nil && body.Error != "" {
+		return body.Error
+	}
+	return strings.TrimSpace(rec.body.String())
+}
@@ -0,0 +1,58 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/dpshade/pocket-prompt/internal/models"
+	"github.com/dpshade/pocket-prompt/internal/service"
+)
+
+func TestCallbacks(t *testing.T) {
+	t.Setenv("POCKET_PROMPT_DIR", t.TempDir())
+	svc, err := service.NewService()
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+	if err := svc.CreatePrompt(&models.Prompt{ID: "greet", Name: "Greet", Content: "Hello {{.name}}"}); err != nil {
+		t.Fatalf("Failed to create prompt: %v", err)
+	}
+	s := NewURLServer(svc, 0)
+
+	success := url.QueryEscape("shortcuts://x-callback-url/run-shortcut?name=Ask AI")
+	failure := url.QueryEscape("shortcuts://x-callback-url/run-shortcut?name=Failed")
+
+	req := httptest.NewRequest(http.MethodGet, "/pocket-prompt/render/greet?name=Ada&x-success="+success+"&x-error="+failure, nil)
+	rec := httptest.NewRecorder()
+	s.handlePocketPrompt(rec, req)
+	expected := "shortcuts://x-callback-url/run-shortcut?name=Ask%20AI&result=Hello%20Ada"
+	if rec.Code != http.StatusFound || rec.Header().Get("Location") != expected {
+		t.Errorf("Expected a redirect to %s, got %d %s", expected, rec.Code, rec.Header().Get("Location"))
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/pocket-prompt/render/missing?x-success="+success+"&x-error="+failure, nil)
+	rec = httptest.NewRecorder()
+	s.handlePocketPrompt(rec, req)
+	location, _ := url.Parse(rec.Header().Get("Location"))
+	if rec.Code != http.StatusFound || location.Query().Get("name") != "Failed" || location.Query().Get("errorCode") != "404" ||
+		location.Query().Get("errorMessage") != "Failed to get prompt: prompt not found: missing" {
+		t.Errorf("Expected a redirect to x-error, got %d %s", rec.Code, rec.Header().Get("Location"))
+	}
+
+	// Without a callback for the outcome the error is returned as usual
+	req = httptest.NewRequest(http.MethodGet, "/pocket-prompt/render/missing?x-success="+success, nil)
+	rec = httptest.NewRecorder()
+	s.handlePocketPrompt(rec, req)
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("Expected the 404 itself, got %d", rec.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/pocket-prompt/render/greet?x-success="+url.QueryEscape("javascript:alert(1)"), nil)
+	rec = httptest.NewRecorder()
+	s.handlePocketPrompt(rec, req)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected a javascript: callback to be refused, got %d", rec.Code)
+	}
+}
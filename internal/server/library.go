@@ -0,0 +1,39 @@
+package server
+
+import (
+	"context"
+
+	"github.com/dpshade/pocket-prompt/internal/models"
+	"github.com/dpshade/pocket-prompt/internal/service"
+)
+
+// Library is the part of the service the HTTP and stdio servers use.
+// *service.Service implements it over any storage backend; handlers can be
+// tested against a stub.
+type Library interface {
+	GetPrompt(id string) (*models.Prompt, error)
+	ListPrompts() ([]*models.Prompt, error)
+	ListPromptsInScopeContext(ctx context.Context, scope service.ArchiveScope) ([]*models.Prompt, error)
+	SearchPrompts(query string) ([]*models.Prompt, error)
+	SearchPromptsInScopeContext(ctx context.Context, query string, scope service.ArchiveScope) ([]*models.Prompt, error)
+	SearchPromptsByBooleanExpressionInScopeContext(ctx context.Context, expression *models.BooleanExpression, scope service.ArchiveScope) ([]*models.Prompt, error)
+	FilterPromptsByTag(tag string) ([]*models.Prompt, error)
+	GetAllTags() ([]string, error)
+	WithContent(prompts []*models.Prompt) ([]*models.Prompt, error)
+	ReloadPrompts() error
+
+	GetTemplate(id string) (*models.Template, error)
+	ListTemplates() ([]*models.Template, error)
+
+	ListSavedSearches() ([]models.SavedSearch, error)
+	ExecuteSavedSearchWithTextContext(ctx context.Context, name string, textQueryOverride string) ([]*models.Prompt, error)
+
+	// Loaders for the renderer
+	LoadAttachment(ctx context.Context, name string) (string, error)
+	LoadSnippet(ctx context.Context, name string) (string, error)
+	LoadSecret(ctx context.Context, name string) (string, error)
+
+	RecordUsage(id string) error
+}
+
+var _ Library = (*service.Service)(nil)
@@ -0,0 +1,76 @@
+package server
+
+import (
+	"log/slog"
+	"net"
+	"net/http"
+	"time"
+)
+
+// SetLogger sets where the server writes its access and sync logs in
+// place of the default slog logger
+func (s *URLServer) SetLogger(logger *slog.Logger) {
+	s.logger = logger
+}
+
+// statusRecorder remembers the status and size of a response
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+	bytes  int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *statusRecorder) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	n, err := r.ResponseWriter.Write(b)
+	r.bytes += n
+	return n, err
+}
+
+// logRequests writes an access log entry for each request next handles.
+// Query strings are left out as they can carry prompt variables.
+func (s *URLServer) logRequests(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w}
+		next.ServeHTTP(rec, r)
+		if rec.status == 0 {
+			rec.status = http.StatusOK
+		}
+
+		attrs := []any{
+			"method", r.Method,
+			"path", r.URL.Path,
+			"status", rec.status,
+			"duration_ms", float64(time.Since(start).Microseconds()) / 1000,
+			"bytes", rec.bytes,
+			"client", clientAddr(r),
+		}
+		if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+			attrs = append(attrs, "forwarded_for", forwarded)
+		}
+		if message := rec.Header().Get("X-Message"); message != "" {
+			attrs = append(attrs, "message", message)
+		}
+		s.logger.Info("request", attrs...)
+	})
+}
+
+// clientAddr returns the host a request came from, which is empty for
+// requests over a unix socket
+func clientAddr(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
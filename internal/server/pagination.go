@@ -0,0 +1,126 @@
+package server
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/dpshade/pocket-prompt/internal/models"
+)
+
+// page is one page of a listing, written as the JSON envelope of /list,
+// /search and /boolean when a page is asked for
+type page struct {
+	Prompts []*models.Prompt `json:"prompts"`
+	// Total counts the matching prompts across all pages
+	Total  int `json:"total"`
+	Offset int `json:"offset"`
+	// Limit is 0 when the page runs to the end
+	Limit int `json:"limit,omitempty"`
+	// NextCursor continues after this page, empty on the last one
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// paginate cuts prompts to the page asked for with the limit, offset and
+// cursor parameters. It reports whether offset or cursor was given, in
+// which case JSON responses are wrapped in the envelope; limit alone keeps
+// the bare array earlier clients expect.
+//
+// A cursor names the last prompt of the previous page as well as where it
+// ended, so the next page starts after that prompt even when prompts were
+// added or removed before it in the meantime.
+func paginate(prompts []*models.Prompt, query url.Values) (page, bool, error) {
+	p := page{Total: len(prompts)}
+
+	// An invalid limit has always meant no limit
+	if limit, err := strconv.Atoi(query.Get("limit")); err == nil && limit > 0 {
+		p.Limit = limit
+	}
+
+	offsetParam, cursor := query.Get("offset"), query.Get("cursor")
+	switch {
+	case offsetParam != "" && cursor != "":
+		return page{}, false, fmt.Errorf("use either offset or cursor, not both")
+	case offsetParam != "":
+		offset, err := strconv.Atoi(offsetParam)
+		if err != nil || offset < 0 {
+			return page{}, false, fmt.Errorf("invalid offset %q", offsetParam)
+		}
+		p.Offset = offset
+	case cursor != "":
+		offset, err := resumeCursor(prompts, cursor)
+		if err != nil {
+			return page{}, false, err
+		}
+		p.Offset = offset
+	}
+
+	start := min(p.Offset, len(prompts))
+	end := len(prompts)
+	if p.Limit > 0 {
+		end = min(start+p.Limit, end)
+	}
+	p.Prompts = prompts[start:end]
+	if p.Prompts == nil {
+		p.Prompts = []*models.Prompt{}
+	}
+	if end < len(prompts) && end > 0 {
+		p.NextCursor = encodeCursor(end, prompts[end-1])
+	}
+	return p, offsetParam != "" || cursor != "", nil
+}
+
+// promptKey identifies a prompt in a cursor; archived versions share their
+// prompt's ID
+func promptKey(p *models.Prompt) string {
+	return p.ID + "@" + p.Version
+}
+
+// encodeCursor makes the cursor for the page starting at offset, after last
+func encodeCursor(offset int, last *models.Prompt) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(offset) + ":" + promptKey(last)))
+}
+
+// resumeCursor returns where the page after a cursor starts: after the
+// prompt it names, wherever that is now, or at its offset when the prompt
+// is gone
+func resumeCursor(prompts []*models.Prompt, cursor string) (int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, fmt.Errorf("invalid cursor")
+	}
+	offsetText, key, ok := strings.Cut(string(data), ":")
+	offset, err := strconv.Atoi(offsetText)
+	if !ok || err != nil || offset < 1 {
+		return 0, fmt.Errorf("invalid cursor")
+	}
+	if offset <= len(prompts) && promptKey(prompts[offset-1]) == key {
+		return offset, nil
+	}
+	for i, p := range prompts {
+		if promptKey(p) == key {
+			return i + 1, nil
+		}
+	}
+	return offset, nil
+}
+
+// writePage sends a page of prompts: the JSON envelope when a page was
+// asked for in JSON, and otherwise the prompts in the format with the
+// total and next cursor in headers
+func (s *URLServer) writePage(w http.ResponseWriter, p page, paged bool, format, message string) {
+	w.Header().Set("X-Total-Count", strconv.Itoa(p.Total))
+	if p.NextCursor != "" {
+		w.Header().Set("X-Next-Cursor", p.NextCursor)
+	}
+	if paged && format == "json" {
+		data, _ := json.MarshalIndent(p, "", "  ")
+		s.writeContentResponse(w, string(data), message)
+		return
+	}
+	s.writeContentResponse(w, s.formatPrompts(p.Prompts, format), message)
+}
@@ -0,0 +1,88 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dpshade/pocket-prompt/internal/models"
+	"github.com/dpshade/pocket-prompt/internal/service"
+)
+
+func TestListPagination(t *testing.T) {
+	t.Setenv("POCKET_PROMPT_DIR", t.TempDir())
+	svc, err := service.NewService()
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+	for i := 1; i <= 5; i++ {
+		prompt := &models.Prompt{ID: fmt.Sprintf("p%d", i), Name: fmt.Sprintf("Prompt %d", i), Content: "x", Tags: []string{"ai"}}
+		if err := svc.CreatePrompt(prompt); err != nil {
+			t.Fatalf("Failed to create prompt: %v", err)
+		}
+	}
+	s := NewURLServer(svc, 0)
+
+	get := func(target string) (page, *httptest.ResponseRecorder) {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		s.handlePocketPrompt(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		var p page
+		if rec.Code == http.StatusOK {
+			if err := json.Unmarshal(rec.Body.Bytes(), &p); err != nil {
+				t.Fatalf("%s: expected an envelope, got %q", target, rec.Body.String())
+			}
+		}
+		return p, rec
+	}
+	ids := func(p page) []string {
+		var ids []string
+		for _, prompt := range p.Prompts {
+			ids = append(ids, prompt.ID)
+		}
+		return ids
+	}
+
+	first, rec := get("/pocket-prompt/list?format=json&limit=2&offset=0")
+	if first.Total != 5 || len(first.Prompts) != 2 || first.NextCursor == "" {
+		t.Fatalf("Unexpected first page: %+v", first)
+	}
+	if rec.Header().Get("X-Total-Count") != "5" || rec.Header().Get("X-Next-Cursor") != first.NextCursor {
+		t.Errorf("Expected the total and cursor in headers, got %v", rec.Header())
+	}
+
+	// A prompt deleted before the cursor doesn't shift the next page
+	if err := svc.DeletePrompt(first.Prompts[0].ID); err != nil {
+		t.Fatalf("Failed to delete prompt: %v", err)
+	}
+	second, _ := get("/pocket-prompt/list?format=json&limit=2&cursor=" + first.NextCursor)
+	all, _ := get("/pocket-prompt/list?format=json&offset=0")
+	if second.Total != 4 || fmt.Sprint(ids(second)) != fmt.Sprint(ids(all)[1:3]) {
+		t.Errorf("Expected the page after %s, got %v of %v", first.Prompts[1].ID, ids(second), ids(all))
+	}
+
+	searched, _ := get("/pocket-prompt/search?q=Prompt&format=json&limit=3&offset=1")
+	if searched.Total != 4 || len(searched.Prompts) != 3 || searched.NextCursor != "" {
+		t.Errorf("Expected the rest of the search results, got %+v", searched)
+	}
+	last, _ := get("/pocket-prompt/boolean?expr=ai&format=json&offset=3&limit=2")
+	if len(last.Prompts) != 1 || last.NextCursor != "" {
+		t.Errorf("Expected the last page without a cursor, got %+v", last)
+	}
+
+	// Without offset or cursor, JSON stays a bare array
+	rec = httptest.NewRecorder()
+	s.handlePocketPrompt(rec, httptest.NewRequest(http.MethodGet, "/pocket-prompt/list?format=json&limit=2", nil))
+	var bare []*models.Prompt
+	if err := json.Unmarshal(rec.Body.Bytes(), &bare); err != nil || len(bare) != 2 {
+		t.Errorf("Expected a bare array of 2, got %q", rec.Body.String())
+	}
+
+	for _, target := range []string{"/pocket-prompt/list?offset=-1", "/pocket-prompt/list?cursor=nope", "/pocket-prompt/list?offset=1&cursor=" + first.NextCursor} {
+		if _, rec := get(target); rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", target, rec.Code)
+		}
+	}
+}
@@ -1,72 +1,222 @@
 package server
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"log"
+	"io"
+	"log/slog"
+	"net"
 	"net/http"
 	"net/url"
+	"os"
+	"os/signal"
+	"slices"
 	"strconv"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/dpshade/pocket-prompt/internal/models"
 	"github.com/dpshade/pocket-prompt/internal/renderer"
 	"github.com/dpshade/pocket-prompt/internal/service"
+	"github.com/dpshade/pocket-prompt/internal/syncer"
 )
 
+// shutdownTimeout bounds how long requests in flight may take once the
+// server is asked to stop
+const shutdownTimeout = 10 * time.Second
+
+// requestTimeout bounds a single request, e.g. a search of a large library
+// on a slow remote backend. Its context is cancelled when the time is up.
+const requestTimeout = 30 * time.Second
+
+// DefaultBindAddress keeps the server reachable from this machine only
+const DefaultBindAddress = "127.0.0.1"
+
+// unixPrefix marks a bind address as a unix socket path
+const unixPrefix = "unix:"
+
 // URLServer provides HTTP endpoints for iOS Shortcuts integration
 type URLServer struct {
-	service    *service.Service
+	service    Library
 	port       int
+	bind       string
+	logger     *slog.Logger
 	syncInterval time.Duration
-	gitSync    bool
+	syncer     syncer.Syncer // nil disables periodic sync
 }
 
 // NewURLServer creates a new URL server instance
-func NewURLServer(svc *service.Service, port int) *URLServer {
+func NewURLServer(svc Library, port int) *URLServer {
 	return &URLServer{
 		service:      svc,
 		port:         port,
+		bind:         DefaultBindAddress,
+		logger:       slog.Default(),
 		syncInterval: 5 * time.Minute, // Default: sync every 5 minutes
-		gitSync:      true,             // Enable git sync by default
 	}
 }
 
-// SetSyncInterval configures how often to pull git changes
+// SetBindAddress sets the interface the server listens on: a host name or
+// IP address such as 0.0.0.0 for all interfaces, or "unix:" followed by the
+// path of a unix socket for a reverse proxy
+func (s *URLServer) SetBindAddress(bind string) {
+	if bind == "" {
+		bind = DefaultBindAddress
+	}
+	s.bind = bind
+}
+
+// listen opens the listener for the bind address. A socket file left by a
+// server that didn't shut down cleanly is replaced.
+func (s *URLServer) listen() (net.Listener, error) {
+	if path, ok := strings.CutPrefix(s.bind, unixPrefix); ok {
+		if info, err := os.Lstat(path); err == nil {
+			if info.Mode()&os.ModeSocket == 0 {
+				return nil, fmt.Errorf("%s exists and is not a socket", path)
+			}
+			if conn, err := net.Dial("unix", path); err == nil {
+				conn.Close()
+				return nil, fmt.Errorf("another server is listening on %s", path)
+			}
+			os.Remove(path)
+		}
+		return net.Listen("unix", path)
+	}
+	return net.Listen("tcp", net.JoinHostPort(s.bind, strconv.Itoa(s.port)))
+}
+
+// baseURL is the address clients on this machine reach the server at
+func (s *URLServer) baseURL() string {
+	host := s.bind
+	if ip := net.ParseIP(host); ip != nil && (ip.IsLoopback() || ip.IsUnspecified()) {
+		host = "localhost"
+	}
+	return "http://" + net.JoinHostPort(host, strconv.Itoa(s.port))
+}
+
+// SetSyncInterval configures how often to sync the library
 func (s *URLServer) SetSyncInterval(interval time.Duration) {
 	s.syncInterval = interval
 }
 
-// SetGitSync enables or disables periodic git synchronization
-func (s *URLServer) SetGitSync(enabled bool) {
-	s.gitSync = enabled
+// SetSyncer sets the provider the library is periodically synced with; nil
+// disables periodic sync
+func (s *URLServer) SetSyncer(syncer syncer.Syncer) {
+	s.syncer = syncer
+}
+
+// syncName names the sync provider for status output
+func (s *URLServer) syncName() string {
+	if s.syncer == nil {
+		return "off"
+	}
+	return s.syncer.Name()
 }
 
-// Start begins serving HTTP requests
+// Start serves HTTP requests until the process receives SIGINT or SIGTERM,
+// then shuts down gracefully
 func (s *URLServer) Start() error {
-	http.HandleFunc("/pocket-prompt/", s.handlePocketPrompt)
-	http.HandleFunc("/health", s.handleHealth)
-	http.HandleFunc("/help", s.handleAPIHelp)
-	http.HandleFunc("/api", s.handleAPIHelp) // Alternative endpoint
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	return s.Run(ctx)
+}
+
+// Run serves HTTP requests until ctx is done. Requests in flight then get
+// shutdownTimeout to finish, a running sync is waited for and the library
+// is synced once more so changes since the last sync aren't left behind.
+func (s *URLServer) Run(ctx context.Context) error {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/pocket-prompt/", s.handlePocketPrompt)
+	mux.HandleFunc("/health", s.handleHealth)
+	mux.HandleFunc("/help", s.handleAPIHelp)
+	mux.HandleFunc("/api", s.handleAPIHelp) // Alternative endpoint
 	
-	addr := fmt.Sprintf(":%d", s.port)
-	log.Printf("URL server starting on http://localhost%s", addr)
-	log.Printf("iOS Shortcuts can now call URLs like:")
-	log.Printf("  http://localhost%s/pocket-prompt/render/my-prompt-id", addr)
-	log.Printf("  http://localhost%s/pocket-prompt/search?q=AI", addr)
-	log.Printf("  http://localhost%s/pocket-prompt/boolean?expr=ai+AND+analysis", addr)
-	log.Printf("  http://localhost%s/help - API documentation", addr)
+	// A socket from systemd socket activation takes the place of --bind
+	listener, err := systemdListener()
+	if err != nil {
+		return err
+	}
+	if listener != nil {
+		s.logger.Info("URL server listening on socket from systemd", "addr", listener.Addr().String())
+	} else if listener, err = s.listen(); err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", s.bind, err)
+	} else if path, ok := strings.CutPrefix(s.bind, unixPrefix); ok {
+		s.logger.Info("URL server listening on unix socket", "path", path)
+	} else {
+		base := s.baseURL()
+		s.logger.Info("URL server starting", "url", base)
+		s.logger.Info("iOS Shortcuts can now call URLs like",
+			"render", base+"/pocket-prompt/render/my-prompt-id",
+			"search", base+"/pocket-prompt/search?q=AI",
+			"boolean", base+"/pocket-prompt/boolean?expr=ai+AND+analysis",
+			"help", base+"/help")
+		if ip := net.ParseIP(s.bind); ip != nil && ip.IsLoopback() {
+			s.logger.Info("Only reachable from this machine; use --bind 0.0.0.0 for other devices")
+		} else if ip != nil && ip.IsUnspecified() {
+			s.logger.Warn("Listening on all interfaces; anyone on the network can read your prompts")
+		}
+	}
 	
-	// Start periodic git sync if enabled
-	if s.gitSync {
-		log.Printf("Git sync enabled: pulling changes every %v", s.syncInterval)
-		go s.startPeriodicSync()
+	// Start periodic sync if enabled
+	syncCtx, stopSync := context.WithCancel(ctx)
+	defer stopSync()
+	syncDone := make(chan struct{})
+	if s.syncer != nil {
+		s.logger.Info("Sync enabled", "provider", s.syncer.Name(), "interval", s.syncInterval.String())
+		go func() {
+			defer close(syncDone)
+			s.startPeriodicSync(syncCtx)
+		}()
 	} else {
-		log.Printf("Git sync disabled")
+		s.logger.Info("Sync disabled")
+		close(syncDone)
+	}
+	
+	// Requests still running when shutdown gives up are cancelled
+	requestCtx, cancelRequests := context.WithCancel(context.Background())
+	defer cancelRequests()
+	srv := &http.Server{
+		Handler:     s.logRequests(http.TimeoutHandler(mux, requestTimeout, "Request timed out")),
+		ErrorLog:    slog.NewLogLogger(s.logger.Handler(), slog.LevelError),
+		BaseContext: func(net.Listener) context.Context { return requestCtx },
 	}
+	served := make(chan error, 1)
+	go func() {
+		served <- srv.Serve(listener)
+	}()
 	
-	return http.ListenAndServe(addr, nil)
+	if err := sdNotify("READY=1"); err != nil {
+		s.logger.Warn("Could not notify systemd", "error", err)
+	}
+	if interval := watchdogInterval(); interval > 0 {
+		go s.pingWatchdog(syncCtx, interval)
+	}
+	
+	select {
+	case err := <-served:
+		return err
+	case <-ctx.Done():
+	}
+	
+	s.logger.Info("Shutting down URL server")
+	sdNotify("STOPPING=1")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		s.logger.Warn("Requests still running at shutdown, cancelling them", "error", err)
+		cancelRequests()
+	}
+	
+	stopSync()
+	<-syncDone
+	if s.syncer != nil {
+		s.flushSync()
+	}
+	s.logger.Info("URL server stopped")
+	return nil
 }
 
 // handleHealth provides a simple health check endpoint
@@ -88,7 +238,11 @@ func (s *URLServer) handlePocketPrompt(w http.ResponseWriter, r *http.Request) {
 	if r.Method == "OPTIONS" {
 		return
 	}
+	withCallbacks(w, r, s.routePocketPrompt)
+}
 
+// routePocketPrompt runs the operation a pocket-prompt URL names
+func (s *URLServer) routePocketPrompt(w http.ResponseWriter, r *http.Request) {
 	path := strings.TrimPrefix(r.URL.Path, "/pocket-prompt/")
 	parts := strings.Split(path, "/")
 	
@@ -102,6 +256,8 @@ func (s *URLServer) handlePocketPrompt(w http.ResponseWriter, r *http.Request) {
 	switch operation {
 	case "render":
 		s.handleRender(w, r, parts[1:])
+	case "text":
+		s.handleText(w, r, parts[1:])
 	case "get":
 		s.handleGet(w, r, parts[1:])
 	case "list":
@@ -127,14 +283,19 @@ func (s *URLServer) handlePocketPrompt(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// handleRender renders a prompt with variables
+// maxRenderBody bounds the JSON variables of a POST render request
+const maxRenderBody = 1 << 20
+
+// handleRender renders a prompt with variables from the query string or,
+// for POST requests, a JSON object in the body, which can carry multi-line
+// and structured values. Body variables override query ones.
 func (s *URLServer) handleRender(w http.ResponseWriter, r *http.Request, parts []string) {
 	if len(parts) == 0 {
 		s.writeError(w, "Render requires a prompt ID", http.StatusBadRequest)
 		return
 	}
 
-	promptID := parts[0]
+	promptID := strings.Join(parts, "/") // Namespaced IDs contain slashes
 	format := r.URL.Query().Get("format")
 	if format == "" {
 		format = "text"
@@ -148,17 +309,16 @@ func (s *URLServer) handleRender(w http.ResponseWriter, r *http.Request, parts [
 	}
 
 	// Parse variables from query parameters
-	variables := make(map[string]interface{})
-	for key, values := range r.URL.Query() {
-		if key != "format" && len(values) > 0 {
-			// Try to parse as number, fallback to string
-			if num, err := strconv.ParseFloat(values[0], 64); err == nil {
-				variables[key] = num
-			} else if values[0] == "true" || values[0] == "false" {
-				variables[key] = values[0] == "true"
-			} else {
-				variables[key] = values[0]
-			}
+	variables := queryVariables(r, "format", "preset")
+	if r.Method == http.MethodPost {
+		var body map[string]interface{}
+		decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRenderBody))
+		if err := decoder.Decode(&body); err != nil && err != io.EOF {
+			s.writeError(w, fmt.Sprintf("Invalid JSON variables: %v", err), http.StatusBadRequest)
+			return
+		}
+		for key, value := range body {
+			variables[key] = value
 		}
 	}
 
@@ -170,6 +330,10 @@ func (s *URLServer) handleRender(w http.ResponseWriter, r *http.Request, parts [
 
 	// Render prompt
 	renderer := renderer.NewRenderer(prompt, template)
+	renderer.SetContext(r.Context())
+	renderer.SetAttachmentLoader(s.service.LoadAttachment)
+	renderer.SetSnippetLoader(s.service.LoadSnippet)
+	renderer.SetPreset(r.URL.Query().Get("preset"))
 	
 	var content string
 	switch format {
@@ -184,9 +348,80 @@ func (s *URLServer) handleRender(w http.ResponseWriter, r *http.Request, parts [
 		return
 	}
 
+	if err := s.service.RecordUsage(prompt.ID); err != nil {
+		s.logger.Warn("Failed to record usage", "prompt", prompt.ID, "error", err)
+	}
+
 	s.writeContentResponse(w, content, fmt.Sprintf("Rendered prompt: %s", promptID))
 }
 
+// queryVariables returns the prompt variables in the query string, all
+// parameters but the ones named and x-callback-url ones. Numbers and
+// booleans are converted.
+func queryVariables(r *http.Request, reserved ...string) map[string]interface{} {
+	variables := make(map[string]interface{})
+	for key, values := range r.URL.Query() {
+		if len(values) == 0 || slices.Contains(reserved, key) || isCallbackParam(key) {
+			continue
+		}
+		// Try to parse as number, fallback to string
+		if num, err := strconv.ParseFloat(values[0], 64); err == nil {
+			variables[key] = num
+		} else if values[0] == "true" || values[0] == "false" {
+			variables[key] = values[0] == "true"
+		} else {
+			variables[key] = values[0]
+		}
+	}
+	return variables
+}
+
+// handleText renders a prompt as plain text, without a JSON envelope even
+// for errors, for browser extensions that insert it into a text box.
+// Variables come from the query string, and preset picks a saved preset.
+func (s *URLServer) handleText(w http.ResponseWriter, r *http.Request, parts []string) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "Text endpoint only supports GET", http.StatusMethodNotAllowed)
+		return
+	}
+	if len(parts) == 0 || parts[0] == "" {
+		http.Error(w, "Text requires a prompt ID", http.StatusBadRequest)
+		return
+	}
+
+	promptID := strings.Join(parts, "/") // Namespaced IDs contain slashes
+	prompt, err := s.service.GetPrompt(promptID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to get prompt: %v", err), http.StatusNotFound)
+		return
+	}
+
+	var template *models.Template
+	if prompt.TemplateRef != "" {
+		template, _ = s.service.GetTemplate(prompt.TemplateRef)
+	}
+
+	renderer := renderer.NewRenderer(prompt, template)
+	renderer.SetContext(r.Context())
+	renderer.SetAttachmentLoader(s.service.LoadAttachment)
+	renderer.SetSnippetLoader(s.service.LoadSnippet)
+	renderer.SetPreset(r.URL.Query().Get("preset"))
+
+	content, err := renderer.RenderText(queryVariables(r, "preset"))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to render prompt: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	if err := s.service.RecordUsage(prompt.ID); err != nil {
+		s.logger.Warn("Failed to record usage", "prompt", prompt.ID, "error", err)
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Write([]byte(content))
+}
+
 // handleGet retrieves a specific prompt
 func (s *URLServer) handleGet(w http.ResponseWriter, r *http.Request, parts []string) {
 	if len(parts) == 0 {
@@ -194,7 +429,7 @@ func (s *URLServer) handleGet(w http.ResponseWriter, r *http.Request, parts []st
 		return
 	}
 
-	promptID := parts[0]
+	promptID := strings.Join(parts, "/") // Namespaced IDs contain slashes
 	format := r.URL.Query().Get("format")
 
 	prompt, err := s.service.GetPrompt(promptID)
@@ -221,31 +456,29 @@ func (s *URLServer) handleGet(w http.ResponseWriter, r *http.Request, parts []st
 func (s *URLServer) handleList(w http.ResponseWriter, r *http.Request) {
 	format := r.URL.Query().Get("format")
 	tag := r.URL.Query().Get("tag")
-	limitStr := r.URL.Query().Get("limit")
 	
-	var prompts []*models.Prompt
-	var err error
-
-	if tag != "" {
-		prompts, err = s.service.FilterPromptsByTag(tag)
-	} else {
-		prompts, err = s.service.ListPrompts()
-	}
-
+	prompts, err := s.service.ListPromptsInScopeContext(r.Context(), archiveScope(r))
 	if err != nil {
 		s.writeError(w, fmt.Sprintf("Failed to list prompts: %v", err), http.StatusInternalServerError)
 		return
 	}
 
-	// Apply limit if specified
-	if limitStr != "" {
-		if limit, err := strconv.Atoi(limitStr); err == nil && limit > 0 && limit < len(prompts) {
-			prompts = prompts[:limit]
+	if tag != "" {
+		var filtered []*models.Prompt
+		for _, p := range prompts {
+			if p.HasTag(tag) {
+				filtered = append(filtered, p)
+			}
 		}
+		prompts = filtered
 	}
 
-	content := s.formatPrompts(prompts, format)
-	s.writeContentResponse(w, content, fmt.Sprintf("Listed %d prompts", len(prompts)))
+	p, paged, err := paginate(prompts, r.URL.Query())
+	if err != nil {
+		s.writeError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	s.writePage(w, p, paged, format, fmt.Sprintf("Listed %d prompts", len(p.Prompts)))
 }
 
 // handleSearch performs fuzzy text search
@@ -257,10 +490,9 @@ func (s *URLServer) handleSearch(w http.ResponseWriter, r *http.Request) {
 	}
 
 	format := r.URL.Query().Get("format")
-	limitStr := r.URL.Query().Get("limit")
 	tag := r.URL.Query().Get("tag")
 
-	prompts, err := s.service.SearchPrompts(query)
+	prompts, err := s.service.SearchPromptsInScopeContext(r.Context(), query, archiveScope(r))
 	if err != nil {
 		s.writeError(w, fmt.Sprintf("Search failed: %v", err), http.StatusInternalServerError)
 		return
@@ -270,25 +502,19 @@ func (s *URLServer) handleSearch(w http.ResponseWriter, r *http.Request) {
 	if tag != "" {
 		var filtered []*models.Prompt
 		for _, p := range prompts {
-			for _, t := range p.Tags {
-				if strings.EqualFold(t, tag) {
-					filtered = append(filtered, p)
-					break
-				}
+			if p.HasTag(tag) {
+				filtered = append(filtered, p)
 			}
 		}
 		prompts = filtered
 	}
 
-	// Apply limit if specified
-	if limitStr != "" {
-		if limit, err := strconv.Atoi(limitStr); err == nil && limit > 0 && limit < len(prompts) {
-			prompts = prompts[:limit]
-		}
+	p, paged, err := paginate(prompts, r.URL.Query())
+	if err != nil {
+		s.writeError(w, err.Error(), http.StatusBadRequest)
+		return
 	}
-
-	content := s.formatPrompts(prompts, format)
-	s.writeContentResponse(w, content, fmt.Sprintf("Found %d prompts for '%s'", len(prompts), query))
+	s.writePage(w, p, paged, format, fmt.Sprintf("Found %d prompts for '%s'", len(p.Prompts), query))
 }
 
 // handleBooleanSearch performs boolean expression search
@@ -316,14 +542,35 @@ func (s *URLServer) handleBooleanSearch(w http.ResponseWriter, r *http.Request)
 	}
 
 	// Execute search
-	prompts, err := s.service.SearchPromptsByBooleanExpression(boolExpr)
+	prompts, err := s.service.SearchPromptsByBooleanExpressionInScopeContext(r.Context(), boolExpr, archiveScope(r))
 	if err != nil {
 		s.writeError(w, fmt.Sprintf("Boolean search failed: %v", err), http.StatusInternalServerError)
 		return
 	}
 
-	content := s.formatPrompts(prompts, format)
-	s.writeContentResponse(w, content, fmt.Sprintf("Boolean search found %d prompts", len(prompts)))
+	p, paged, err := paginate(prompts, r.URL.Query())
+	if err != nil {
+		s.writeError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	s.writePage(w, p, paged, format, fmt.Sprintf("Boolean search found %d prompts", len(p.Prompts)))
+}
+
+// archiveScope reads the include_archived and archived_only query parameters
+func archiveScope(r *http.Request) service.ArchiveScope {
+	if isTrue(r.URL.Query().Get("archived_only")) {
+		return service.ArchivedOnly
+	}
+	if isTrue(r.URL.Query().Get("include_archived")) {
+		return service.IncludeArchived
+	}
+	return service.ActiveOnly
+}
+
+// isTrue reports whether a query parameter value is set to true
+func isTrue(value string) bool {
+	enabled, err := strconv.ParseBool(value)
+	return err == nil && enabled
 }
 
 // handleSavedSearch executes a saved search
@@ -337,7 +584,7 @@ func (s *URLServer) handleSavedSearch(w http.ResponseWriter, r *http.Request, pa
 	format := r.URL.Query().Get("format")
 	textQuery := r.URL.Query().Get("q")
 
-	prompts, err := s.service.ExecuteSavedSearchWithText(searchName, textQuery)
+	prompts, err := s.service.ExecuteSavedSearchWithTextContext(r.Context(), searchName, textQuery)
 	if err != nil {
 		s.writeError(w, fmt.Sprintf("Failed to execute saved search: %v", err), http.StatusNotFound)
 		return
@@ -546,8 +793,6 @@ func (s *URLServer) writeContentResponse(w http.ResponseWriter, content, message
 	
 	// Write content directly to response
 	w.Write([]byte(content))
-	
-	log.Printf("API: %s (returned %d bytes)", message, len(content))
 }
 
 // writeError sends an error response
@@ -561,98 +806,77 @@ func (s *URLServer) writeError(w http.ResponseWriter, message string, statusCode
 }
 
 // parseBooleanExpression parses a boolean search expression
-// This is a simplified implementation - could be enhanced with a proper parser
 func (s *URLServer) parseBooleanExpression(expr string) (*models.BooleanExpression, error) {
-	expr = strings.TrimSpace(expr)
-	
-	// Handle NOT expressions
-	if strings.HasPrefix(strings.ToUpper(expr), "NOT ") {
-		inner := strings.TrimSpace(expr[4:])
-		innerExpr, err := s.parseBooleanExpression(inner)
-		if err != nil {
-			return nil, err
-		}
-		return models.NewNotExpression(innerExpr), nil
-	}
-	
-	// Handle OR expressions (lower precedence)
-	if orParts := strings.Split(expr, " OR "); len(orParts) > 1 {
-		var expressions []*models.BooleanExpression
-		for _, part := range orParts {
-			subExpr, err := s.parseBooleanExpression(strings.TrimSpace(part))
-			if err != nil {
-				return nil, err
-			}
-			expressions = append(expressions, subExpr)
-		}
-		return models.NewOrExpression(expressions...), nil
-	}
-	
-	// Handle AND expressions (higher precedence)
-	if andParts := strings.Split(expr, " AND "); len(andParts) > 1 {
-		var expressions []*models.BooleanExpression
-		for _, part := range andParts {
-			subExpr, err := s.parseBooleanExpression(strings.TrimSpace(part))
-			if err != nil {
-				return nil, err
-			}
-			expressions = append(expressions, subExpr)
-		}
-		return models.NewAndExpression(expressions...), nil
-	}
-	
-	// Remove parentheses if present
-	if strings.HasPrefix(expr, "(") && strings.HasSuffix(expr, ")") {
-		return s.parseBooleanExpression(expr[1 : len(expr)-1])
-	}
-	
-	// Single tag expression
-	return models.NewTagExpression(expr), nil
+	return models.ParseBooleanExpression(expr)
 }
 
-// startPeriodicSync runs git pull operations at regular intervals
-func (s *URLServer) startPeriodicSync() {
+// startPeriodicSync syncs the library at regular intervals until ctx is done
+func (s *URLServer) startPeriodicSync(ctx context.Context) {
 	ticker := time.NewTicker(s.syncInterval)
 	defer ticker.Stop()
 	
 	// Perform initial sync
-	s.performGitSync()
+	s.performSync(ctx)
 	
 	for {
 		select {
 		case <-ticker.C:
-			s.performGitSync()
+			s.performSync(ctx)
+		case <-ctx.Done():
+			return
 		}
 	}
 }
 
-// performGitSync pulls changes from git and refreshes the service
-func (s *URLServer) performGitSync() {
-	log.Printf("Performing git sync...")
+// flushSync syncs the library a last time before the server exits
+func (s *URLServer) flushSync() {
+	name := s.syncer.Name()
+	s.logger.Info("Flushing sync before exit", "provider", name)
+	if err := s.syncer.Sync(context.Background()); err != nil && !errors.Is(err, syncer.ErrNotConfigured) {
+		s.logger.Warn("Final sync failed", "provider", name, "error", err)
+	}
+}
+
+// pingWatchdog tells the systemd watchdog the server is alive until ctx is
+// done
+func (s *URLServer) pingWatchdog(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
 	
-	// Check if git sync is available
-	status, err := s.service.GetGitSyncStatus()
-	if err != nil {
-		log.Printf("Git sync not available: %v", err)
-		return
+	for {
+		select {
+		case <-ticker.C:
+			sdNotify("WATCHDOG=1")
+		case <-ctx.Done():
+			return
+		}
 	}
+}
+
+// performSync syncs the library and reloads the prompts. A sync still
+// running when the server shuts down is cancelled.
+func (s *URLServer) performSync(ctx context.Context) {
+	name := s.syncer.Name()
+	s.logger.Info("Performing sync", "provider", name)
 	
-	if status == "Git sync not configured" {
-		log.Printf("Git sync not configured, skipping...")
+	if err := s.syncer.Sync(ctx); err != nil {
+		if ctx.Err() != nil {
+			s.logger.Info("Sync cancelled", "provider", name)
+		} else if errors.Is(err, syncer.ErrNotConfigured) {
+			s.logger.Info("Sync not configured, skipping", "provider", name)
+		} else {
+			s.logger.Error("Sync failed", "provider", name, "error", err)
+		}
 		return
 	}
 	
-	// Attempt to pull changes
-	err = s.service.PullGitChanges()
-	if err != nil {
-		log.Printf("Git pull failed: %v", err)
+	// Pick up prompts the sync added or changed
+	if err := s.service.ReloadPrompts(); err != nil {
+		s.logger.Error("Failed to reload prompts after sync", "error", err)
 		return
 	}
 	
-	// Note: The service automatically reloads prompts when needed
-	// No explicit refresh required as storage operations handle updates
-	
-	log.Printf("Git sync completed successfully")
+	s.logger.Info("Sync completed successfully", "provider", name)
 }
 
 // handleAPIHelp provides comprehensive API documentation
@@ -673,6 +897,17 @@ GET /pocket-prompt/render/{id}?var1=value&var2=test&format=text
 - Variables: Pass as query parameters (var1=value&var2=test)
 - Format: text (default), json
 
+POST /pocket-prompt/render/{id}?format=json
+- Same as GET, with variables in a JSON object body, for multi-line text,
+  numbers and lists: {"code": "line 1\nline 2", "depth": 3, "langs": ["go", "rust"]}
+- Body variables override query parameters
+
+#### Plain Text
+GET /pocket-prompt/text/{id}?var1=value&preset=name
+- Renders a prompt like render, always as text/plain with no JSON envelope,
+  errors included, for browser extensions that insert it into a text box
+- Variables: Pass as query parameters; preset picks a saved preset
+
 #### Get Prompt Details  
 GET /pocket-prompt/get/{id}?format=text
 - Retrieves prompt metadata and content
@@ -684,7 +919,10 @@ GET /pocket-prompt/list?format=text&limit=10&tag=ai
 - Parameters:
   - format: text (default), json, ids, table
   - limit: maximum number of results
+  - offset / cursor: start of the page (see Paging)
   - tag: filter by specific tag
+  - include_archived=true: include archived versions
+  - archived_only=true: list only archived versions
 
 ### Search Operations
 
@@ -695,7 +933,9 @@ GET /pocket-prompt/search?q=machine+learning&format=text&limit=5
   - q: search query (required)
   - format: text (default), json, ids, table
   - limit: maximum results
+  - offset / cursor: start of the page (see Paging)
   - tag: filter by tag
+  - include_archived=true / archived_only=true: search archived versions
 
 #### Boolean Search
 GET /pocket-prompt/boolean?expr=ai+AND+analysis
@@ -703,8 +943,23 @@ GET /pocket-prompt/boolean?expr=ai+AND+analysis
 - Parameters:
   - expr: boolean expression (required)
   - format: text (default), json, ids, table
+  - limit, offset / cursor: page through the results (see Paging)
+  - include_archived=true / archived_only=true: search archived versions
 - Operators: AND, OR, NOT, parentheses for grouping
 
+#### Paging
+GET /pocket-prompt/list?format=json&limit=50&offset=0
+- List, search and boolean results come in a stable order, so they can be
+  read a page at a time with limit and either offset or cursor
+- With offset or cursor, format=json returns an envelope:
+  {"prompts": [...], "total": 120, "offset": 50, "limit": 50, "next_cursor": "..."}
+  Without them it stays a bare array
+- Pass next_cursor back as cursor for the next page; it continues after
+  the last prompt seen even if prompts were added or removed before it.
+  It is left out on the last page
+- Every format gets the X-Total-Count header, and X-Next-Cursor while
+  there are more pages
+
 #### Saved Searches
 GET /pocket-prompt/saved-search/{name}
 - Execute a previously saved boolean search
@@ -749,6 +1004,14 @@ GET /help or GET /api
 - Returns this documentation
 - Add ?format=json for JSON response
 
+## x-callback-url
+
+Add x-success and/or x-error to any /pocket-prompt/ URL to be sent back to
+an app with the outcome, e.g. from iOS Shortcuts:
+- x-success: redirected to with the response content as ?result=...
+- x-error: redirected to with ?errorCode=404&errorMessage=...
+- shortcuts://x-callback-url/run-shortcut?name=Paste works as either
+
 ## Response Formats
 
 All endpoints support these format options via ?format= parameter:
@@ -798,14 +1061,15 @@ Perfect for iOS Shortcuts automation:
 
 Current settings:
 - Port: ` + fmt.Sprintf("%d", s.port) + `
-- Git Sync: ` + fmt.Sprintf("%t", s.gitSync) + `
+- Sync: ` + s.syncName() + `
 - Sync Interval: ` + s.syncInterval.String() + `
 
 ## Need Help?
 
 - Start server: pocket-prompt --url-server
 - Custom port: pocket-prompt --url-server --port 9000
-- Disable git sync: pocket-prompt --url-server --no-git-sync
+- Disable sync: pocket-prompt --url-server --no-git-sync
+- Sync with rsync or rclone: set sync.provider in config.yaml
 - Custom sync interval: pocket-prompt --url-server --sync-interval 1
 
 For more information: https://github.com/dpshade/pocket-prompt
@@ -818,6 +1082,8 @@ For more information: https://github.com/dpshade/pocket-prompt
 			"endpoints": map[string]interface{}{
 				"prompts": map[string]string{
 					"render": "/pocket-prompt/render/{id}?var1=value&format=text",
+					"render_post": "POST /pocket-prompt/render/{id}?format=text with a JSON object of variables",
+					"text":        "/pocket-prompt/text/{id}?var1=value&preset=name",
 					"get":    "/pocket-prompt/get/{id}?format=text",
 					"list":   "/pocket-prompt/list?format=text&limit=10&tag=ai",
 				},
@@ -842,7 +1108,9 @@ For more information: https://github.com/dpshade/pocket-prompt
 			"formats": []string{"text", "json", "ids", "table"},
 			"config": map[string]interface{}{
 				"port":          s.port,
-				"git_sync":      s.gitSync,
+				"bind":          s.bind,
+				"git_sync":      s.syncName() == "git",
+				"sync":          s.syncName(),
 				"sync_interval": s.syncInterval.String(),
 			},
 		}
@@ -0,0 +1,240 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dpshade/pocket-prompt/internal/logging"
+	"github.com/dpshade/pocket-prompt/internal/models"
+	"github.com/dpshade/pocket-prompt/internal/service"
+	"github.com/dpshade/pocket-prompt/internal/syncer"
+)
+
+func TestRenderWithJSONBody(t *testing.T) {
+	t.Setenv("POCKET_PROMPT_DIR", t.TempDir())
+	svc, err := service.NewService()
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+	prompt := &models.Prompt{
+		ID:      "review",
+		Name:    "Review",
+		Content: "Review in {{.lang}} at depth {{.depth}}:\n{{.code}}\n{{range .focus}}- {{.}}\n{{end}}",
+	}
+	if err := svc.CreatePrompt(prompt); err != nil {
+		t.Fatalf("Failed to create prompt: %v", err)
+	}
+	s := NewURLServer(svc, 0)
+
+	body := `{"code": "func a() {\n}", "depth": 3, "focus": ["naming", "errors"]}`
+	req := httptest.NewRequest(http.MethodPost, "/pocket-prompt/render/review?lang=go&depth=1", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	s.handlePocketPrompt(rec, req)
+
+	expected := "Review in go at depth 3:\nfunc a() {\n}\n- naming\n- errors\n"
+	if rec.Code != http.StatusOK || rec.Body.String() != expected {
+		t.Errorf("Expected %q, got %d %q", expected, rec.Code, rec.Body.String())
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/pocket-prompt/render/review", strings.NewReader(`["not", "an", "object"]`))
+	rec = httptest.NewRecorder()
+	s.handlePocketPrompt(rec, req)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected a bad request for a non-object body, got %d", rec.Code)
+	}
+}
+
+func TestListenUnixSocket(t *testing.T) {
+	// Socket paths are limited to about 100 bytes, too few for t.TempDir
+	dir, err := os.MkdirTemp("", "pp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "api.sock")
+
+	s := &URLServer{}
+	s.SetBindAddress("unix:" + path)
+	listener, err := s.listen()
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	if _, err := s.listen(); err == nil {
+		t.Error("Expected an error while another server is listening")
+	}
+
+	// Leave the socket file behind as a crashed server would
+	listener.(*net.UnixListener).SetUnlinkOnClose(false)
+	listener.Close()
+	listener, err = s.listen()
+	if err != nil {
+		t.Fatalf("Failed to replace stale socket: %v", err)
+	}
+	listener.Close()
+
+	if err := os.WriteFile(path, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.listen(); err == nil {
+		t.Error("Expected an error for a file that isn't a socket")
+	}
+}
+
+func TestRunShutsDownGracefully(t *testing.T) {
+	t.Setenv("POCKET_PROMPT_DIR", t.TempDir())
+	svc, err := service.NewService()
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+	dir, err := os.MkdirTemp("", "pp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	notify, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: filepath.Join(dir, "notify"), Net: "unixgram"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer notify.Close()
+	t.Setenv("NOTIFY_SOCKET", filepath.Join(dir, "notify"))
+	expectNotify := func(state string) {
+		t.Helper()
+		buf := make([]byte, 64)
+		notify.SetReadDeadline(time.Now().Add(5 * time.Second))
+		n, err := notify.Read(buf)
+		if err != nil || string(buf[:n]) != state {
+			t.Fatalf("Expected %s from sd_notify, got %q %v", state, buf[:n], err)
+		}
+	}
+
+	var syncs atomic.Int32
+	socket := filepath.Join(dir, "api.sock")
+	s := NewURLServer(svc, 0)
+	s.SetBindAddress("unix:" + socket)
+	s.SetSyncer(syncer.NewFunc("test", func(context.Context) error {
+		syncs.Add(1)
+		return nil
+	}))
+	s.SetSyncInterval(time.Hour)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- s.Run(ctx)
+	}()
+	expectNotify("READY=1")
+
+	client := &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, "unix", socket)
+		},
+	}}
+	resp, err := client.Get("http://pocket-prompt/health")
+	if err != nil {
+		t.Fatalf("Failed to reach server: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected 200 from health check, got %d", resp.StatusCode)
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("Expected clean shutdown, got %v", err)
+	}
+	expectNotify("STOPPING=1")
+	if n := syncs.Load(); n != 2 {
+		t.Errorf("Expected the initial sync and a final one, got %d syncs", n)
+	}
+}
+
+func TestAccessLog(t *testing.T) {
+	var out bytes.Buffer
+	handler, err := logging.NewHandler(&out, logging.FormatJSON, slog.LevelInfo, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := &URLServer{logger: slog.New(handler)}
+	logged := s.logRequests(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.writeContentResponse(w, "hello", "Rendered prompt: greeting")
+	}))
+	req := httptest.NewRequest(http.MethodGet, "/pocket-prompt/render/greeting?secret=1", nil)
+	req.RemoteAddr = "192.0.2.7:5123"
+	logged.ServeHTTP(httptest.NewRecorder(), req)
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(out.Bytes(), &entry); err != nil {
+		t.Fatalf("Expected one JSON entry, got %q: %v", out.String(), err)
+	}
+	expected := map[string]interface{}{
+		"msg":     "request",
+		"method":  "GET",
+		"path":    "/pocket-prompt/render/greeting",
+		"status":  float64(200),
+		"bytes":   float64(5),
+		"client":  "192.0.2.7",
+		"message": "Rendered prompt: greeting",
+	}
+	for key, value := range expected {
+		if entry[key] != value {
+			t.Errorf("Expected %s %v, got %v", key, value, entry[key])
+		}
+	}
+	if _, ok := entry["duration_ms"]; !ok {
+		t.Error("Expected the request duration")
+	}
+	if strings.Contains(out.String(), "secret") {
+		t.Error("Expected the query string to be left out")
+	}
+}
+
+func TestTextEndpoint(t *testing.T) {
+	t.Setenv("POCKET_PROMPT_DIR", t.TempDir())
+	svc, err := service.NewService()
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+	prompt := &models.Prompt{
+		ID:       "team/greet",
+		Name:     "Greet",
+		Content:  `{"greeting": "Hello {{.name}}", "count": {{.count}}}`,
+		Defaults: map[string]string{"count": "1"},
+		Presets:  map[string]map[string]string{"crowd": {"count": "100"}},
+	}
+	if err := svc.CreatePrompt(prompt); err != nil {
+		t.Fatalf("Failed to create prompt: %v", err)
+	}
+	s := NewURLServer(svc, 0)
+
+	req := httptest.NewRequest(http.MethodGet, "/pocket-prompt/text/team/greet?name=Ada&preset=crowd", nil)
+	rec := httptest.NewRecorder()
+	s.handlePocketPrompt(rec, req)
+
+	expected := `{"greeting": "Hello Ada", "count": 100}`
+	if rec.Code != http.StatusOK || rec.Body.String() != expected {
+		t.Errorf("Expected %q, got %d %q", expected, rec.Code, rec.Body.String())
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "text/plain; charset=utf-8" {
+		t.Errorf("Expected plain text even for JSON-like content, got %q", ct)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/pocket-prompt/text/missing", nil)
+	rec = httptest.NewRecorder()
+	s.handlePocketPrompt(rec, req)
+	if rec.Code != http.StatusNotFound || !strings.HasPrefix(rec.Header().Get("Content-Type"), "text/plain") {
+		t.Errorf("Expected a plain text 404, got %d %q", rec.Code, rec.Header().Get("Content-Type"))
+	}
+}
@@ -0,0 +1,293 @@
+package server
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+
+	"github.com/dpshade/pocket-prompt/internal/models"
+	"github.com/dpshade/pocket-prompt/internal/renderer"
+)
+
+// maxStdioRequest bounds the size of one JSON-RPC request line
+const maxStdioRequest = 10 << 20
+
+// JSON-RPC 2.0 error codes
+const (
+	rpcParseError     = -32700
+	rpcInvalidRequest = -32600
+	rpcMethodNotFound = -32601
+	rpcInvalidParams  = -32602
+	rpcServerError    = -32000
+)
+
+// rpcRequest is a JSON-RPC 2.0 request. Requests without an ID are
+// notifications and get no response.
+type rpcRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+// rpcResponse is a JSON-RPC 2.0 response carrying a result or an error
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+// rpcError is the error of a failed JSON-RPC request
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *rpcError) Error() string {
+	return e.Message
+}
+
+// rpcParams are the parameters of every method; each uses the ones it
+// needs
+type rpcParams struct {
+	ID        string                 `json:"id"`
+	Query     string                 `json:"query"`
+	Tag       string                 `json:"tag"`
+	Limit     int                    `json:"limit"`
+	Variables map[string]interface{} `json:"variables"`
+	Preset    string                 `json:"preset"`
+	Format    string                 `json:"format"`
+	Content   bool                   `json:"content"` // list and search include prompt bodies
+}
+
+// renderResult is the result of the render method
+type renderResult struct {
+	ID       string             `json:"id"`
+	Content  string             `json:"content"`
+	Messages []renderer.Message `json:"messages,omitempty"`
+}
+
+// StdioServer answers JSON-RPC 2.0 requests read one per line, for editor
+// extensions that run the binary instead of talking HTTP
+type StdioServer struct {
+	service Library
+	logger  *slog.Logger
+}
+
+// NewStdioServer creates a JSON-RPC server for the library
+func NewStdioServer(svc Library) *StdioServer {
+	return &StdioServer{service: svc, logger: slog.Default()}
+}
+
+// Serve reads requests from in and writes a response line for each to out
+// until in is closed. The methods are list, search, get and render.
+func (s *StdioServer) Serve(in io.Reader, out io.Writer) error {
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 64*1024), maxStdioRequest)
+	encoder := json.NewEncoder(out)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		response := s.handle([]byte(line))
+		if response == nil {
+			continue
+		}
+		if err := encoder.Encode(response); err != nil {
+			return fmt.Errorf("failed to write response: %w", err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read request: %w", err)
+	}
+	return nil
+}
+
+// handle answers one request line, returning nil for notifications
+func (s *StdioServer) handle(line []byte) *rpcResponse {
+	var request rpcRequest
+	if err := json.Unmarshal(line, &request); err != nil {
+		return &rpcResponse{JSONRPC: "2.0", ID: json.RawMessage("null"),
+			Error: &rpcError{Code: rpcParseError, Message: fmt.Sprintf("invalid JSON: %v", err)}}
+	}
+
+	id := request.ID
+	if id == nil {
+		id = json.RawMessage("null")
+	}
+	response := &rpcResponse{JSONRPC: "2.0", ID: id}
+	if request.JSONRPC != "2.0" || request.Method == "" {
+		response.Error = &rpcError{Code: rpcInvalidRequest, Message: `requests need "jsonrpc": "2.0" and a method`}
+		return response
+	}
+
+	result, err := s.call(request.Method, request.Params)
+	if request.ID == nil {
+		return nil
+	}
+	if err != nil {
+		rpcErr, ok := err.(*rpcError)
+		if !ok {
+			rpcErr = &rpcError{Code: rpcServerError, Message: err.Error()}
+		}
+		s.logger.Debug("JSON-RPC request failed", "method", request.Method, "error", err)
+		response.Error = rpcErr
+		return response
+	}
+	response.Result = result
+	return response
+}
+
+// call decodes a method's parameters and runs it
+func (s *StdioServer) call(method string, rawParams json.RawMessage) (interface{}, error) {
+	var params rpcParams
+	if len(rawParams) > 0 && string(rawParams) != "null" {
+		if err := json.Unmarshal(rawParams, &params); err != nil {
+			return nil, &rpcError{Code: rpcInvalidParams, Message: fmt.Sprintf("invalid params: %v", err)}
+		}
+	}
+	return s.dispatch(method, params)
+}
+
+// dispatch runs a method with its parameters
+func (s *StdioServer) dispatch(method string, params rpcParams) (interface{}, error) {
+	switch method {
+	case "list":
+		prompts, err := s.service.ListPrompts()
+		if err != nil {
+			return nil, err
+		}
+		return s.promptResults(prompts, params)
+	case "search":
+		if params.Query == "" {
+			return nil, &rpcError{Code: rpcInvalidParams, Message: "search requires a query"}
+		}
+		prompts, err := s.service.SearchPrompts(params.Query)
+		if err != nil {
+			return nil, err
+		}
+		return s.promptResults(prompts, params)
+	case "get":
+		if params.ID == "" {
+			return nil, &rpcError{Code: rpcInvalidParams, Message: "get requires an id"}
+		}
+		return s.service.GetPrompt(params.ID)
+	case "render":
+		if params.ID == "" {
+			return nil, &rpcError{Code: rpcInvalidParams, Message: "render requires an id"}
+		}
+		return s.render(params)
+	default:
+		return nil, &rpcError{Code: rpcMethodNotFound, Message: fmt.Sprintf("unknown method %q (methods: list, search, get, render)", method)}
+	}
+}
+
+// render renders a prompt as text, or also as chat messages with format
+// "messages"
+func (s *StdioServer) render(params rpcParams) (*renderResult, error) {
+	prompt, err := s.service.GetPrompt(params.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var template *models.Template
+	if prompt.TemplateRef != "" {
+		template, _ = s.service.GetTemplate(prompt.TemplateRef)
+	}
+
+	r := renderer.NewRenderer(prompt, template)
+	r.SetAttachmentLoader(s.service.LoadAttachment)
+	r.SetSnippetLoader(s.service.LoadSnippet)
+	r.SetSecretLoader(s.service.LoadSecret)
+	r.SetPreset(params.Preset)
+
+	result := &renderResult{ID: prompt.ID}
+	if result.Content, err = r.RenderText(params.Variables); err != nil {
+		return nil, fmt.Errorf("failed to render prompt: %w", err)
+	}
+	if params.Format == "messages" {
+		if result.Messages, err = r.RenderMessages(params.Variables); err != nil {
+			return nil, fmt.Errorf("failed to render prompt: %w", err)
+		}
+	}
+
+	if err := s.service.RecordUsage(prompt.ID); err != nil {
+		s.logger.Warn("Failed to record usage", "prompt", prompt.ID, "error", err)
+	}
+	return result, nil
+}
+
+// promptResults filters prompts by the parameters, loading their bodies
+// when asked to
+func (s *StdioServer) promptResults(prompts []*models.Prompt, params rpcParams) ([]*models.Prompt, error) {
+	prompts = filterPrompts(prompts, params)
+	if !params.Content {
+		return prompts, nil
+	}
+	return s.service.WithContent(prompts)
+}
+
+// queryRequest is the request of a single-shot query: the method and its
+// parameters in one object, e.g. {"method": "search", "query": "review"}
+type queryRequest struct {
+	Method string `json:"method"`
+	rpcParams
+}
+
+// queryResponse carries a query's result or its error
+type queryResponse struct {
+	Result interface{} `json:"result,omitempty"`
+	Error  *rpcError   `json:"error,omitempty"`
+}
+
+// Query answers a single request read from in with one response written to
+// out, for plugins that run the binary once per action. It takes the same
+// methods and parameters as Serve, but list and search include prompt
+// bodies unless "content" is false. The error is returned after it is
+// written.
+func (s *StdioServer) Query(in io.Reader, out io.Writer) error {
+	request := queryRequest{rpcParams: rpcParams{Content: true}}
+	var result interface{}
+	var err error
+	if decodeErr := json.NewDecoder(io.LimitReader(in, maxStdioRequest)).Decode(&request); decodeErr != nil {
+		err = &rpcError{Code: rpcParseError, Message: fmt.Sprintf("invalid JSON request: %v", decodeErr)}
+	} else if request.Method == "" {
+		err = &rpcError{Code: rpcInvalidRequest, Message: "the request needs a method: list, search, get or render"}
+	} else {
+		result, err = s.dispatch(request.Method, request.rpcParams)
+	}
+
+	response := queryResponse{Result: result}
+	if err != nil {
+		rpcErr, ok := err.(*rpcError)
+		if !ok {
+			rpcErr = &rpcError{Code: rpcServerError, Message: err.Error()}
+		}
+		response = queryResponse{Error: rpcErr}
+	}
+	if writeErr := json.NewEncoder(out).Encode(response); writeErr != nil {
+		return fmt.Errorf("failed to write response: %w", writeErr)
+	}
+	return err
+}
+
+// filterPrompts applies the tag and limit parameters, always returning a
+// list so an empty result encodes as []
+func filterPrompts(prompts []*models.Prompt, params rpcParams) []*models.Prompt {
+	filtered := make([]*models.Prompt, 0, len(prompts))
+	for _, p := range prompts {
+		if params.Tag == "" || p.HasTag(params.Tag) {
+			filtered = append(filtered, p)
+		}
+	}
+	if params.Limit > 0 && params.Limit < len(filtered) {
+		filtered = filtered[:params.Limit]
+	}
+	return filtered
+}
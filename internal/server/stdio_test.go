@@ -0,0 +1,127 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/dpshade/pocket-prompt/internal/models"
+	"github.com/dpshade/pocket-prompt/internal/service"
+	"github.com/dpshade/pocket-prompt/internal/storage"
+)
+
+func TestStdioServer(t *testing.T) {
+	svc := service.NewServiceWithBackend(storage.NewMemoryStorage(""))
+	for _, prompt := range []*models.Prompt{
+		{ID: "review", Name: "Code Review", Version: "1.0.0", Tags: []string{"code"}, Content: "Review this {{.lang}} code"},
+		{ID: "weekly", Name: "Weekly Report", Version: "1.0.0", Tags: []string{"writing"}, Content: "Summarize the week"},
+	} {
+		if err := svc.CreatePrompt(prompt); err != nil {
+			t.Fatalf("Failed to create prompt: %v", err)
+		}
+	}
+
+	requests := strings.Join([]string{
+		`{"jsonrpc": "2.0", "id": 1, "method": "list", "params": {"tag": "code"}}`,
+		`{"jsonrpc": "2.0", "id": 2, "method": "search", "params": {"query": "weekly"}}`,
+		`{"jsonrpc": "2.0", "id": 3, "method": "get", "params": {"id": "weekly"}}`,
+		`{"jsonrpc": "2.0", "id": "r", "method": "render", "params": {"id": "review", "variables": {"lang": "Go"}}}`,
+		`{"jsonrpc": "2.0", "method": "render", "params": {"id": "review"}}`,
+		`{"jsonrpc": "2.0", "id": 5, "method": "delete"}`,
+		`{"jsonrpc": "2.0", "id": 6, "method": "get", "params": {"id": "missing"}}`,
+		`not json`,
+	}, "\n")
+
+	var out bytes.Buffer
+	if err := NewStdioServer(svc).Serve(strings.NewReader(requests), &out); err != nil {
+		t.Fatalf("Serve failed: %v", err)
+	}
+
+	type rpcReply struct {
+		ID     json.RawMessage `json:"id"`
+		Result json.RawMessage `json:"result"`
+		Error  *rpcError       `json:"error"`
+	}
+	var responses []rpcReply
+	decoder := json.NewDecoder(&out)
+	for decoder.More() {
+		var response rpcReply
+		if err := decoder.Decode(&response); err != nil {
+			t.Fatalf("Invalid response: %v", err)
+		}
+		responses = append(responses, response)
+	}
+	if len(responses) != 7 {
+		t.Fatalf("Expected 7 responses (none for the notification), got %d", len(responses))
+	}
+
+	var listed []*models.Prompt
+	json.Unmarshal(responses[0].Result, &listed)
+	if len(listed) != 1 || listed[0].ID != "review" {
+		t.Errorf("Expected list to return only review, got %s", responses[0].Result)
+	}
+
+	var found []*models.Prompt
+	json.Unmarshal(responses[1].Result, &found)
+	if len(found) != 1 || found[0].ID != "weekly" {
+		t.Errorf("Expected search to find weekly, got %s", responses[1].Result)
+	}
+
+	var got models.Prompt
+	json.Unmarshal(responses[2].Result, &got)
+	if got.ID != "weekly" || got.Content != "Summarize the week" {
+		t.Errorf("Expected get to return weekly with its content, got %s", responses[2].Result)
+	}
+
+	var rendered renderResult
+	json.Unmarshal(responses[3].Result, &rendered)
+	if string(responses[3].ID) != `"r"` || rendered.Content != "Review this Go code" {
+		t.Errorf("Expected render to fill the variable, got %s %s", responses[3].ID, responses[3].Result)
+	}
+
+	if responses[4].Error == nil || responses[4].Error.Code != rpcMethodNotFound {
+		t.Errorf("Expected method not found, got %+v", responses[4].Error)
+	}
+	if responses[5].Error == nil || responses[5].Error.Code != rpcServerError {
+		t.Errorf("Expected an error for a missing prompt, got %+v", responses[5].Error)
+	}
+	if responses[6].Error == nil || responses[6].Error.Code != rpcParseError || string(responses[6].ID) != "null" {
+		t.Errorf("Expected a parse error with a null id, got %s %+v", responses[6].ID, responses[6].Error)
+	}
+}
+
+func TestQuery(t *testing.T) {
+	t.Setenv("POCKET_PROMPT_DIR", t.TempDir())
+	svc, err := service.NewService()
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+	prompt := &models.Prompt{ID: "review", Name: "Code Review", Version: "1.0.0", Content: "Review this code"}
+	if err := svc.CreatePrompt(prompt); err != nil {
+		t.Fatalf("Failed to create prompt: %v", err)
+	}
+	s := NewStdioServer(svc)
+
+	var out bytes.Buffer
+	if err := s.Query(strings.NewReader(`{"method": "search", "query": "review"}`), &out); err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	var response struct {
+		Result []*models.Prompt `json:"result"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &response); err != nil {
+		t.Fatalf("Invalid response %s: %v", out.String(), err)
+	}
+	if len(response.Result) != 1 || response.Result[0].Content != "Review this code" {
+		t.Errorf("Expected the search result with its content, got %s", out.String())
+	}
+
+	out.Reset()
+	if err := s.Query(strings.NewReader(`{"method": "get"}`), &out); err == nil {
+		t.Error("Expected get without an id to fail")
+	}
+	if !strings.Contains(out.String(), `"code":-32602`) {
+		t.Errorf("Expected an invalid params error, got %s", out.String())
+	}
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/dpshade/pocket-prompt/internal/models"
+)
+
+func TestSearchInArchiveScope(t *testing.T) {
+	t.Setenv("POCKET_PROMPT_DIR", t.TempDir())
+
+	service, err := NewService()
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+	if err := service.CreatePrompt(&models.Prompt{ID: "hello", Version: "1.0.0", Name: "Hello", Content: "v1", Tags: []string{"ai"}}); err != nil {
+		t.Fatalf("Failed to create prompt: %v", err)
+	}
+	if err := service.UpdatePrompt(&models.Prompt{ID: "hello", Name: "Hello", Content: "v2", Tags: []string{"ai"}}); err != nil {
+		t.Fatalf("Failed to update prompt: %v", err)
+	}
+
+	tests := []struct {
+		scope ArchiveScope
+		want  []string
+	}{
+		{ActiveOnly, []string{"1.0.1"}},
+		{IncludeArchived, []string{"1.0.1", "1.0.0"}},
+		{ArchivedOnly, []string{"1.0.0"}},
+	}
+	for _, tt := range tests {
+		results, err := service.SearchPromptsByBooleanExpressionInScope(models.NewTagExpression("ai"), tt.scope)
+		if err != nil {
+			t.Fatalf("Search failed: %v", err)
+		}
+		var versions []string
+		for _, p := range results {
+			versions = append(versions, p.Version)
+		}
+		if len(versions) != len(tt.want) || (len(versions) > 0 && versions[0] != tt.want[0]) {
+			t.Errorf("Scope %d: expected versions %v, got %v", tt.scope, tt.want, versions)
+		}
+
+		fuzzy, err := service.SearchPromptsInScope("hello", tt.scope)
+		if err != nil {
+			t.Fatalf("Search failed: %v", err)
+		}
+		if len(fuzzy) != len(tt.want) {
+			t.Errorf("Scope %d: expected %d fuzzy results, got %d", tt.scope, len(tt.want), len(fuzzy))
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"regexp"
+	"slices"
+
+	"github.com/dpshade/pocket-prompt/internal/models"
+	"github.com/dpshade/pocket-prompt/internal/storage"
+)
+
+// attachDirectiveRe matches the {{attach "name"}} directives that inline
+// attachments when a prompt is rendered
+var attachDirectiveRe = regexp.MustCompile(`\{\{-?\s*attach\s+"([^"]+)"\s*-?\}\}`)
+
+// LoadAttachment returns the contents of an attachment, for
+// renderer.Renderer.SetAttachmentLoader
+func (s *Service) LoadAttachment(ctx context.Context, name string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	data, err := s.storage.LoadAttachment(name)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// ListAttachments returns the names of all attachments in the library
+func (s *Service) ListAttachments() ([]string, error) {
+	return s.storage.ListAttachments()
+}
+
+// PromptAttachments returns the attachments a prompt lists in its
+// frontmatter followed by any others its content inlines
+func PromptAttachments(prompt *models.Prompt) []string {
+	names := append([]string(nil), prompt.Attachments...)
+	for _, name := range attachDirectives(prompt.Content) {
+		if !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// attachDirectives returns the attachments content inlines, in order of use
+func attachDirectives(content string) []string {
+	var names []string
+	for _, match := range attachDirectiveRe.FindAllStringSubmatch(content, -1) {
+		if !slices.Contains(names, match[1]) {
+			names = append(names, match[1])
+		}
+	}
+	return names
+}
+
+// AddAttachment saves data as an attachment and adds it to a prompt's
+// attachments. An existing attachment of the same name is replaced.
+func (s *Service) AddAttachment(id, name string, data []byte) (*models.Prompt, error) {
+	if err := storage.ValidateAttachmentName(name); err != nil {
+		return nil, err
+	}
+	prompt, err := s.GetPrompt(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.storage.SaveAttachment(name, data); err != nil {
+		return nil, err
+	}
+	if s.gitSync.IsEnabled() {
+		message := fmt.Sprintf("Add attachment %s", name)
+		if err := s.syncPrompt(prompt, message, filepath.Join(storage.AttachmentsDir, filepath.FromSlash(name))); err != nil {
+			slog.Warn("Git sync failed after adding attachment", "error", err)
+		}
+	}
+
+	if slices.Contains(prompt.Attachments, name) {
+		return prompt, nil
+	}
+	prompt.Attachments = append(prompt.Attachments, name)
+	if err := s.UpdatePrompt(prompt); err != nil {
+		return nil, fmt.Errorf("failed to update prompt: %w", err)
+	}
+	return prompt, nil
+}
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dpshade/pocket-prompt/internal/importer"
+	"github.com/dpshade/pocket-prompt/internal/models"
+	"github.com/dpshade/pocket-prompt/internal/renderer"
+)
+
+func TestAttachments(t *testing.T) {
+	library := t.TempDir()
+	t.Setenv("POCKET_PROMPT_DIR", library)
+	if err := os.MkdirAll(filepath.Join(library, "templates"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	service, err := NewService()
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+
+	for _, prompt := range []*models.Prompt{
+		{ID: "review", Name: "Review", Version: "1.0.0", Tags: []string{"code"}, Content: "Review like this:\n{{attach \"examples/good.md\"}}"},
+		{ID: "other", Name: "Other", Version: "1.0.0", Content: "No attachments"},
+	} {
+		if err := service.CreatePrompt(prompt); err != nil {
+			t.Fatalf("Failed to create prompt: %v", err)
+		}
+	}
+
+	if _, err := service.AddAttachment("review", "../escape.md", []byte("x")); err == nil {
+		t.Error("Expected an attachment outside the attachments directory to be rejected")
+	}
+	prompt, err := service.AddAttachment("review", "examples/good.md", []byte("Looks good {{to me}}"))
+	if err != nil {
+		t.Fatalf("Failed to add attachment: %v", err)
+	}
+	if len(prompt.Attachments) != 1 || prompt.Attachments[0] != "examples/good.md" {
+		t.Errorf("Expected the attachment to be listed, got %v", prompt.Attachments)
+	}
+	if _, err := service.AddAttachment("other", "unused.json", []byte("{}")); err != nil {
+		t.Fatalf("Failed to add attachment: %v", err)
+	}
+
+	r := renderer.NewRenderer(prompt, nil)
+	r.SetAttachmentLoader(service.LoadAttachment)
+	rendered, err := r.RenderText(nil)
+	if err != nil {
+		t.Fatalf("Failed to render: %v", err)
+	}
+	if rendered != "Review like this:\nLooks good {{to me}}" {
+		t.Errorf("Expected the attachment to be inlined as is, got %q", rendered)
+	}
+
+	missing := &models.Prompt{ID: "missing", Content: `{{attach "nope.md"}}`}
+	r = renderer.NewRenderer(missing, nil)
+	r.SetAttachmentLoader(service.LoadAttachment)
+	if _, err := r.RenderText(nil); err == nil || !strings.Contains(err.Error(), "nope.md") {
+		t.Errorf("Expected a missing attachment to fail rendering, got %v", err)
+	}
+
+	// A selection exports only the attachments its prompts use
+	var buf bytes.Buffer
+	manifest, err := service.ExportBundle(&buf, models.NewTagExpression("code"))
+	if err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	if len(manifest.Attachments) != 1 || manifest.Attachments[0].Path != "attachments/examples/good.md" {
+		t.Fatalf("Unexpected attachments in manifest: %+v", manifest.Attachments)
+	}
+	bundlePath := filepath.Join(t.TempDir(), "bundle.zip")
+	if err := os.WriteFile(bundlePath, buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("POCKET_PROMPT_DIR", t.TempDir())
+	restored, err := NewService()
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+	result, err := restored.ImportBundle(bundlePath, importer.ImportOptions{})
+	if err != nil || len(result.Errors) > 0 {
+		t.Fatalf("Import failed: %v %v", err, result.Errors)
+	}
+	if content, err := restored.LoadAttachment(context.Background(), "examples/good.md"); err != nil || content != "Looks good {{to me}}" {
+		t.Errorf("Expected the attachment to be imported, got %q, %v", content, err)
+	}
+}
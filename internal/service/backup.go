@@ -0,0 +1,476 @@
+package service
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dpshade/pocket-prompt/internal/importer"
+	"github.com/dpshade/pocket-prompt/internal/models"
+	"github.com/dpshade/pocket-prompt/internal/storage"
+)
+
+// DefaultBackupKeep is the number of backups kept when config.yaml does not
+// set backup.keep
+const DefaultBackupKeep = 10
+
+const (
+	backupPrefix     = "pocket-prompt-"
+	backupExt        = ".zip"
+	backupTimeFormat = "20060102-150405.000"
+)
+
+// BackupInfo describes one backup archive
+type BackupInfo struct {
+	Name      string
+	Path      string
+	CreatedAt time.Time
+	Size      int64
+}
+
+// BackupDir returns the directory backups are written to
+func (s *Service) BackupDir() string {
+	dir := s.backup.Dir
+	if dir == "" {
+		return filepath.Join(s.storage.GetBaseDir(), ".pocket-prompt", "backups")
+	}
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(s.storage.GetBaseDir(), dir)
+	}
+	return dir
+}
+
+// backupKeep returns the configured number of backups to keep
+func (s *Service) backupKeep() int {
+	if s.backup.Keep > 0 {
+		return s.backup.Keep
+	}
+	return DefaultBackupKeep
+}
+
+// CreateBackup writes a timestamped bundle of the whole library to the
+// backup directory and removes the oldest backups beyond keep. A keep of
+// zero uses the configured value.
+func (s *Service) CreateBackup(keep int) (*BackupInfo, error) {
+	if keep <= 0 {
+		keep = s.backupKeep()
+	}
+
+	dir := s.BackupDir()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create backup directory: %w", err)
+	}
+
+	now := time.Now()
+	name := backupPrefix + now.Format(backupTimeFormat) + backupExt
+	path := filepath.Join(dir, name)
+
+	// Write to a temporary file so a failed backup never looks complete
+	tmp, err := os.CreateTemp(dir, ".backup-*.tmp")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create backup: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := s.ExportBundle(tmp, nil); err != nil {
+		tmp.Close()
+		return nil, err
+	}
+	if err := tmp.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write backup: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return nil, fmt.Errorf("failed to write backup: %w", err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write backup: %w", err)
+	}
+
+	if err := s.rotateBackups(keep); err != nil {
+		return nil, err
+	}
+
+	return &BackupInfo{Name: name, Path: path, CreatedAt: now, Size: info.Size()}, nil
+}
+
+// ListBackups returns the backups in the backup directory, newest first
+func (s *Service) ListBackups() ([]BackupInfo, error) {
+	dir := s.BackupDir()
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read backup directory: %w", err)
+	}
+
+	var backups []BackupInfo
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, backupPrefix) || !strings.HasSuffix(name, backupExt) {
+			continue
+		}
+		stamp := strings.TrimSuffix(strings.TrimPrefix(name, backupPrefix), backupExt)
+		created, err := time.ParseInLocation(backupTimeFormat, stamp, time.Local)
+		if err != nil {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		backups = append(backups, BackupInfo{
+			Name:      name,
+			Path:      filepath.Join(dir, name),
+			CreatedAt: created,
+			Size:      info.Size(),
+		})
+	}
+
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].CreatedAt.After(backups[j].CreatedAt)
+	})
+	return backups, nil
+}
+
+// rotateBackups removes all but the newest keep backups
+func (s *Service) rotateBackups(keep int) error {
+	backups, err := s.ListBackups()
+	if err != nil {
+		return err
+	}
+	for i := keep; i < len(backups); i++ {
+		if err := os.Remove(backups[i].Path); err != nil {
+			return fmt.Errorf("failed to remove old backup %s: %w", backups[i].Name, err)
+		}
+	}
+	return nil
+}
+
+// backupBeforeOverwrite backs the library up before an import that may
+// overwrite existing prompts, when backup.auto is enabled
+func (s *Service) backupBeforeOverwrite(options importer.ImportOptions) error {
+	if !options.OverwriteExisting || options.DryRun {
+		return nil
+	}
+	return s.autoBackup()
+}
+
+// autoBackup backs the library up before a destructive operation when
+// backup.auto is enabled
+func (s *Service) autoBackup() error {
+	if !s.backup.Auto {
+		return nil
+	}
+	if _, err := s.CreateBackup(0); err != nil {
+		return fmt.Errorf("failed to back up library: %w", err)
+	}
+	return nil
+}
+
+// FindBackup resolves a backup reference: "latest", a position in
+// ListBackups (1 is the newest), a file name in the backup directory or a
+// path to a bundle
+func (s *Service) FindBackup(ref string) (*BackupInfo, error) {
+	if info, err := os.Stat(ref); err == nil && !info.IsDir() {
+		return &BackupInfo{Name: filepath.Base(ref), Path: ref, CreatedAt: info.ModTime(), Size: info.Size()}, nil
+	}
+
+	backups, err := s.ListBackups()
+	if err != nil {
+		return nil, err
+	}
+	if ref == "latest" {
+		if len(backups) == 0 {
+			return nil, fmt.Errorf("no backups in %s", s.BackupDir())
+		}
+		return &backups[0], nil
+	}
+	if n, err := strconv.Atoi(ref); err == nil {
+		if n < 1 || n > len(backups) {
+			return nil, fmt.Errorf("backup %d not found: %d backups in %s", n, len(backups), s.BackupDir())
+		}
+		return &backups[n-1], nil
+	}
+	for i := range backups {
+		if backups[i].Name == ref || backups[i].Name == ref+backupExt {
+			return &backups[i], nil
+		}
+	}
+	return nil, fmt.Errorf("backup not found: %s", ref)
+}
+
+// Restore actions
+const (
+	RestoreAdd       = "add"
+	RestoreUpdate    = "update"
+	RestoreUnchanged = "unchanged"
+)
+
+// RestoreChange describes what restoring one prompt or template does
+type RestoreChange struct {
+	Kind           string // "prompt" or "template"
+	ID             string
+	Action         string
+	CurrentVersion string
+	BackupVersion  string
+	Fields         []string // Fields that differ, for updates
+}
+
+// RestorePlan summarizes the differences between a backup and the library
+type RestorePlan struct {
+	Manifest *BundleManifest
+	Changes  []RestoreChange
+	// NotInBackup lists library prompts the backup does not contain; they
+	// are left as they are
+	NotInBackup []string
+	// Missing lists selected IDs the backup does not contain
+	Missing []string
+	Errors  []error
+
+	contents *bundleContents
+}
+
+// PlanRestore compares a backup with the library. ids limits the restore to
+// those prompts; when empty, every prompt and template is restored.
+func (s *Service) PlanRestore(backupPath string, ids []string) (*RestorePlan, error) {
+	contents, err := readBundle(backupPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var missing []string
+	if len(ids) > 0 {
+		selected := make(map[string]bool, len(ids))
+		for _, id := range ids {
+			selected[id] = true
+		}
+		found := make(map[string]bool)
+		filter := func(prompts []*models.Prompt) []*models.Prompt {
+			var kept []*models.Prompt
+			for _, prompt := range prompts {
+				if selected[prompt.ID] {
+					kept = append(kept, prompt)
+					found[prompt.ID] = true
+				}
+			}
+			return kept
+		}
+		contents.prompts = filter(contents.prompts)
+		contents.archived = filter(contents.archived)
+		contents.templates = nil
+		for _, id := range ids {
+			if !found[id] {
+				missing = append(missing, id)
+			}
+		}
+	}
+
+	plan := &RestorePlan{Manifest: &contents.manifest, Missing: missing, Errors: contents.errors, contents: contents}
+	if err := s.diffRestore(plan, len(ids) == 0); err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
+// diffRestore fills in the changes of a plan
+func (s *Service) diffRestore(plan *RestorePlan, full bool) error {
+	current, err := s.ListPrompts()
+	if err != nil {
+		return fmt.Errorf("failed to list prompts: %w", err)
+	}
+	byID := make(map[string]*models.Prompt, len(current))
+	for _, prompt := range current {
+		byID[prompt.ID] = prompt
+	}
+
+	inBackup := make(map[string]bool)
+	for _, prompt := range plan.contents.prompts {
+		inBackup[prompt.ID] = true
+		change := RestoreChange{Kind: "prompt", ID: prompt.ID, BackupVersion: prompt.Version, Action: RestoreAdd}
+		if existing, ok := byID[prompt.ID]; ok {
+			change.CurrentVersion = existing.Version
+			stored, err := s.storedPrompt(existing)
+			if err != nil {
+				return err
+			}
+			change.Fields = promptDiff(stored, prompt)
+			change.Action = RestoreUpdate
+			if len(change.Fields) == 0 {
+				change.Action = RestoreUnchanged
+			}
+		}
+		plan.Changes = append(plan.Changes, change)
+	}
+
+	for _, template := range plan.contents.templates {
+		change := RestoreChange{Kind: "template", ID: template.ID, BackupVersion: template.Version, Action: RestoreAdd}
+		if existing, err := s.GetTemplate(template.ID); err == nil {
+			change.CurrentVersion = existing.Version
+			change.Fields = templateDiff(existing, template)
+			change.Action = RestoreUpdate
+			if len(change.Fields) == 0 {
+				change.Action = RestoreUnchanged
+			}
+		}
+		plan.Changes = append(plan.Changes, change)
+	}
+
+	if full {
+		for _, prompt := range current {
+			if !inBackup[prompt.ID] {
+				plan.NotInBackup = append(plan.NotInBackup, prompt.ID)
+			}
+		}
+	}
+	return nil
+}
+
+// storedPrompt returns a prompt as stored on disk, so encrypted bodies
+// compare with the encrypted bodies in a bundle
+func (s *Service) storedPrompt(prompt *models.Prompt) (*models.Prompt, error) {
+	data, err := s.rawFile(prompt.FilePath, func() ([]byte, error) {
+		full, err := s.storage.LoadPrompt(prompt.FilePath)
+		if err != nil {
+			return nil, err
+		}
+		return storage.MarshalPrompt(full)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read prompt %s: %w", prompt.ID, err)
+	}
+	return storage.UnmarshalPrompt(data, prompt.FilePath)
+}
+
+// promptDiff lists the fields that differ between two prompts
+func promptDiff(a, b *models.Prompt) []string {
+	var fields []string
+	if a.Name != b.Name {
+		fields = append(fields, "title")
+	}
+	if a.Summary != b.Summary {
+		fields = append(fields, "description")
+	}
+	if !equalStringSlices(a.Tags, b.Tags) {
+		fields = append(fields, "tags")
+	}
+	if a.TemplateRef != b.TemplateRef {
+		fields = append(fields, "template")
+	}
+	if !maps.Equal(a.Slots, b.Slots) {
+		fields = append(fields, "slots")
+	}
+	if !equalTemplateSlots(a.Variables, b.Variables) {
+		fields = append(fields, "variables")
+	}
+	if !maps.Equal(a.Defaults, b.Defaults) {
+		fields = append(fields, "defaults")
+	}
+	if !maps.EqualFunc(a.Presets, b.Presets, maps.Equal) {
+		fields = append(fields, "presets")
+	}
+	if !equalMetadata(a.Metadata, b.Metadata) {
+		fields = append(fields, "metadata")
+	}
+	if !equalStringSlices(a.Attachments, b.Attachments) {
+		fields = append(fields, "attachments")
+	}
+	if a.Rating != b.Rating {
+		fields = append(fields, "rating")
+	}
+	if a.Notes != b.Notes {
+		fields = append(fields, "notes")
+	}
+	if a.Content != b.Content {
+		fields = append(fields, "content")
+	}
+	return fields
+}
+
+// templateDiff lists the fields that differ between two templates
+func templateDiff(a, b *models.Template) []string {
+	var fields []string
+	if a.Name != b.Name {
+		fields = append(fields, "name")
+	}
+	if a.Description != b.Description {
+		fields = append(fields, "description")
+	}
+	if !equalTemplateSlots(a.Slots, b.Slots) {
+		fields = append(fields, "slots")
+	}
+	if a.Content != b.Content {
+		fields = append(fields, "content")
+	}
+	return fields
+}
+
+// ApplyRestore restores the prompts and templates a plan adds or updates,
+// exactly as they are in the backup. Current versions are archived first,
+// so a restore can be undone.
+func (s *Service) ApplyRestore(plan *RestorePlan) (*BundleImportResult, error) {
+	options := importer.ImportOptions{OverwriteExisting: true}
+	if err := s.backupBeforeOverwrite(options); err != nil {
+		return nil, err
+	}
+
+	restored := make(map[string]bool)
+	for _, change := range plan.Changes {
+		if change.Action != RestoreUnchanged {
+			restored[change.Kind+":"+change.ID] = true
+		}
+	}
+	contents := *plan.contents
+	contents.prompts, contents.templates = nil, nil
+	for _, prompt := range plan.contents.prompts {
+		if restored["prompt:"+prompt.ID] {
+			contents.prompts = append(contents.prompts, prompt)
+		}
+	}
+	for _, template := range plan.contents.templates {
+		if restored["template:"+template.ID] {
+			contents.templates = append(contents.templates, template)
+		}
+	}
+
+	return s.writeBundle(&contents, options, "Restore backup", s.restorePrompt, s.restoreTemplate), nil
+}
+
+// restorePrompt archives the current version of a prompt, if any, and
+// writes the backed up one in its place
+func (s *Service) restorePrompt(prompt *models.Prompt) error {
+	if existing, err := s.findPrompt(prompt.ID, false); err == nil {
+		if err := s.archivePromptByTag(existing); err != nil {
+			return fmt.Errorf("failed to archive current version: %w", err)
+		}
+		if existing.FilePath != prompt.FilePath {
+			if err := s.storage.DeletePrompt(existing); err != nil {
+				return fmt.Errorf("failed to remove current version: %w", err)
+			}
+		}
+	}
+	return s.storage.SavePrompt(prompt)
+}
+
+// restoreTemplate archives the current version of a template, if any, and
+// writes the backed up one in its place
+func (s *Service) restoreTemplate(template *models.Template) error {
+	if existing, err := s.GetTemplate(template.ID); err == nil {
+		if err := s.archiveTemplate(existing); err != nil {
+			return fmt.Errorf("failed to archive current version: %w", err)
+		}
+		if existing.FilePath != template.FilePath {
+			if err := s.storage.DeleteTemplate(existing); err != nil {
+				return fmt.Errorf("failed to remove current version: %w", err)
+			}
+		}
+	}
+	return s.storage.SaveTemplate(template)
+}
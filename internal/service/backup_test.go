@@ -0,0 +1,240 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dpshade/pocket-prompt/internal/importer"
+	"github.com/dpshade/pocket-prompt/internal/models"
+)
+
+func TestCreateBackupRotates(t *testing.T) {
+	t.Setenv("POCKET_PROMPT_DIR", t.TempDir())
+
+	service, err := NewService()
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+	if err := service.InitLibrary(); err != nil {
+		t.Fatalf("Failed to init library: %v", err)
+	}
+	if err := service.CreatePrompt(&models.Prompt{ID: "hello", Name: "Hello", Content: "Hi"}); err != nil {
+		t.Fatalf("Failed to create prompt: %v", err)
+	}
+
+	var names []string
+	for i := 0; i < 3; i++ {
+		backup, err := service.CreateBackup(2)
+		if err != nil {
+			t.Fatalf("Backup failed: %v", err)
+		}
+		names = append(names, backup.Name)
+		time.Sleep(2 * time.Millisecond)
+	}
+
+	backups, err := service.ListBackups()
+	if err != nil {
+		t.Fatalf("ListBackups failed: %v", err)
+	}
+	if len(backups) != 2 || backups[0].Name != names[2] || backups[1].Name != names[1] {
+		t.Fatalf("Expected the two newest backups, got %+v", backups)
+	}
+
+	restoreDir := t.TempDir()
+	t.Setenv("POCKET_PROMPT_DIR", restoreDir)
+	restored, err := NewService()
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+	if err := restored.InitLibrary(); err != nil {
+		t.Fatalf("Failed to init library: %v", err)
+	}
+	result, err := restored.ImportBundle(backups[0].Path, importer.ImportOptions{})
+	if err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+	if len(result.Prompts) != 1 || result.Prompts[0].ID != "hello" {
+		t.Errorf("Unexpected restore result: %+v", result)
+	}
+}
+
+func TestAutoBackupBeforeOverwrite(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("POCKET_PROMPT_DIR", dir)
+	if err := os.WriteFile(filepath.Join(dir, "config.yaml"), []byte("backup:\n  dir: snapshots\n  auto: true\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	service, err := NewService()
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+	if err := service.InitLibrary(); err != nil {
+		t.Fatalf("Failed to init library: %v", err)
+	}
+	if service.BackupDir() != filepath.Join(dir, "snapshots") {
+		t.Errorf("Unexpected backup dir %s", service.BackupDir())
+	}
+
+	source := filepath.Join(t.TempDir(), "prompts.csv")
+	if err := os.WriteFile(source, []byte("id,content\nhello,Hi\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	options := importer.CSVOptions{ImportOptions: importer.ImportOptions{Path: source}}
+	if _, err := service.ImportFromCSV(options); err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	if backups, _ := service.ListBackups(); len(backups) != 0 {
+		t.Errorf("Expected no backup without --overwrite, got %d", len(backups))
+	}
+
+	options.OverwriteExisting = true
+	if _, err := service.ImportFromCSV(options); err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	if backups, _ := service.ListBackups(); len(backups) != 1 {
+		t.Errorf("Expected one backup before overwrite, got %d", len(backups))
+	}
+}
+
+func TestRestoreBackup(t *testing.T) {
+	t.Setenv("POCKET_PROMPT_DIR", t.TempDir())
+
+	service, err := NewService()
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+	if err := service.InitLibrary(); err != nil {
+		t.Fatalf("Failed to init library: %v", err)
+	}
+	if err := service.CreatePrompt(&models.Prompt{ID: "hello", Version: "1.0.0", Name: "Hello", Content: "Hi"}); err != nil {
+		t.Fatalf("Failed to create prompt: %v", err)
+	}
+	backup, err := service.CreateBackup(0)
+	if err != nil {
+		t.Fatalf("Backup failed: %v", err)
+	}
+
+	if err := service.UpdatePrompt(&models.Prompt{ID: "hello", Name: "Hello", Content: "Hi there"}); err != nil {
+		t.Fatalf("Failed to update prompt: %v", err)
+	}
+	if err := service.CreatePrompt(&models.Prompt{ID: "later", Name: "Later", Content: "New"}); err != nil {
+		t.Fatalf("Failed to create prompt: %v", err)
+	}
+
+	plan, err := service.PlanRestore(backup.Path, nil)
+	if err != nil {
+		t.Fatalf("PlanRestore failed: %v", err)
+	}
+	if len(plan.Changes) != 1 || plan.Changes[0].Action != RestoreUpdate || plan.Changes[0].Fields[0] != "content" {
+		t.Fatalf("Unexpected changes: %+v", plan.Changes)
+	}
+	if len(plan.NotInBackup) != 1 || plan.NotInBackup[0] != "later" {
+		t.Errorf("Expected later to be reported as not in backup, got %v", plan.NotInBackup)
+	}
+
+	selective, err := service.PlanRestore(backup.Path, []string{"hello", "gone"})
+	if err != nil {
+		t.Fatalf("PlanRestore failed: %v", err)
+	}
+	if len(selective.Changes) != 1 || len(selective.Missing) != 1 || selective.Missing[0] != "gone" {
+		t.Errorf("Unexpected selective plan: %+v", selective)
+	}
+
+	result, err := service.ApplyRestore(plan)
+	if err != nil {
+		t.Fatalf("ApplyRestore failed: %v", err)
+	}
+	if len(result.Errors) > 0 {
+		t.Fatalf("Restore errors: %v", result.Errors)
+	}
+	restored, err := service.GetPrompt("hello")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if restored.Content != "Hi" {
+		t.Errorf("Expected restored content, got %q", restored.Content)
+	}
+	if _, err := service.GetPrompt("later"); err != nil {
+		t.Errorf("Expected later to be kept: %v", err)
+	}
+}
+
+func TestRestoreTitleOnly(t *testing.T) {
+	t.Setenv("POCKET_PROMPT_DIR", t.TempDir())
+
+	service, err := NewService()
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+	if err := service.InitLibrary(); err != nil {
+		t.Fatalf("Failed to init library: %v", err)
+	}
+	for _, id := range []string{"summary", "other"} {
+		if err := service.CreatePrompt(&models.Prompt{ID: id, Version: "1.0.0", Name: "Original", Summary: "First", Content: "Hi"}); err != nil {
+			t.Fatalf("Failed to create prompt: %v", err)
+		}
+	}
+	backup, err := service.CreateBackup(0)
+	if err != nil {
+		t.Fatalf("Backup failed: %v", err)
+	}
+
+	if err := service.UpdatePrompt(&models.Prompt{ID: "summary", Name: "Changed", Summary: "Second", Content: "Hi"}); err != nil {
+		t.Fatalf("Failed to update prompt: %v", err)
+	}
+	changed, err := service.GetPrompt("summary")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	plan, err := service.PlanRestore(backup.Path, nil)
+	if err != nil {
+		t.Fatalf("PlanRestore failed: %v", err)
+	}
+	result, err := service.ApplyRestore(plan)
+	if err != nil {
+		t.Fatalf("ApplyRestore failed: %v", err)
+	}
+	if len(result.Errors) > 0 {
+		t.Fatalf("Restore errors: %v", result.Errors)
+	}
+	if len(result.Prompts) != 1 || result.Prompts[0].ID != "summary" {
+		t.Errorf("Expected only summary to be restored, got %d prompts", len(result.Prompts))
+	}
+
+	restored, err := service.GetPrompt("summary")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if restored.Name != "Original" || restored.Summary != "First" || restored.Version != "1.0.0" {
+		t.Errorf("Expected the backed up prompt, got %q %q v%s", restored.Name, restored.Summary, restored.Version)
+	}
+
+	archived, err := service.ListArchivedPrompts()
+	if err != nil {
+		t.Fatal(err)
+	}
+	found := false
+	for _, prompt := range archived {
+		if prompt.ID == "summary" && prompt.Version == changed.Version && prompt.Name == "Changed" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected v%s to be archived before restoring", changed.Version)
+	}
+
+	again, err := service.PlanRestore(backup.Path, nil)
+	if err != nil {
+		t.Fatalf("PlanRestore failed: %v", err)
+	}
+	for _, change := range again.Changes {
+		if change.Action != RestoreUnchanged {
+			t.Errorf("Expected nothing left to restore, got %+v", change)
+		}
+	}
+}
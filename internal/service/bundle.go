@@ -0,0 +1,464 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/dpshade/pocket-prompt/internal/importer"
+	"github.com/dpshade/pocket-prompt/internal/models"
+	"github.com/dpshade/pocket-prompt/internal/storage"
+)
+
+// BundleManifestFile is the name of the manifest inside a bundle
+const BundleManifestFile = "manifest.json"
+
+// bundleFormatVersion is bumped when the bundle layout changes incompatibly
+const bundleFormatVersion = 1
+
+// BundleManifest lists every file in an export bundle. Files keep their
+// library paths (prompts/..., archive/..., templates/..., attachments/...)
+// inside the zip.
+type BundleManifest struct {
+	FormatVersion int           `json:"format_version"`
+	CreatedAt     time.Time     `json:"created_at"`
+	Prompts       []BundleEntry `json:"prompts"`
+	Archived      []BundleEntry `json:"archived,omitempty"`
+	Templates     []BundleEntry `json:"templates,omitempty"`
+	Attachments   []BundleEntry `json:"attachments,omitempty"`
+}
+
+// BundleEntry describes one file in a bundle. Attachments have their name
+// as ID and no version.
+type BundleEntry struct {
+	ID      string `json:"id"`
+	Version string `json:"version"`
+	Path    string `json:"path"`
+	SHA256  string `json:"sha256"`
+}
+
+// BundleImportResult reports what was restored from a bundle
+type BundleImportResult struct {
+	Manifest    *BundleManifest
+	Prompts     []*models.Prompt
+	Archived    []*models.Prompt
+	Templates   []*models.Template
+	Attachments []string
+	Errors      []error
+}
+
+// ExportBundle writes the library as a zip of its markdown files and
+// attachments plus a manifest. Files are copied byte for byte where they
+// exist on disk, so encrypted bodies stay encrypted. A non-nil expression
+// limits the bundle to matching prompts, their archived versions and the
+// templates and attachments they use.
+func (s *Service) ExportBundle(w io.Writer, expression *models.BooleanExpression) (*BundleManifest, error) {
+	// Read beneath the encryption layer so bodies are exported as stored
+	backend := s.storage
+	if encrypted, ok := backend.(*storage.EncryptedBackend); ok {
+		backend = encrypted.Backend
+	}
+
+	manifest := &BundleManifest{
+		FormatVersion: bundleFormatVersion,
+		CreatedAt:     time.Now().UTC(),
+		Prompts:       []BundleEntry{},
+	}
+	zw := zip.NewWriter(w)
+
+	addFile := func(relPath string, data []byte, modified time.Time) (string, error) {
+		name := filepath.ToSlash(relPath)
+		header := &zip.FileHeader{Name: name, Method: zip.Deflate, Modified: modified}
+		fw, err := zw.CreateHeader(header)
+		if err != nil {
+			return "", fmt.Errorf("failed to add %s to bundle: %w", name, err)
+		}
+		if _, err := fw.Write(data); err != nil {
+			return "", fmt.Errorf("failed to add %s to bundle: %w", name, err)
+		}
+		sum := sha256.Sum256(data)
+		return hex.EncodeToString(sum[:]), nil
+	}
+
+	addPrompt := func(prompt *models.Prompt) (BundleEntry, error) {
+		data, err := s.rawFile(prompt.FilePath, func() ([]byte, error) {
+			full, err := backend.LoadPrompt(prompt.FilePath)
+			if err != nil {
+				return nil, err
+			}
+			return storage.MarshalPrompt(full)
+		})
+		if err != nil {
+			return BundleEntry{}, fmt.Errorf("failed to read prompt %s: %w", prompt.ID, err)
+		}
+		hash, err := addFile(prompt.FilePath, data, prompt.UpdatedAt)
+		if err != nil {
+			return BundleEntry{}, err
+		}
+		return BundleEntry{ID: prompt.ID, Version: prompt.Version, Path: filepath.ToSlash(prompt.FilePath), SHA256: hash}, nil
+	}
+
+	prompts, err := backend.ListPrompts(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list prompts: %w", err)
+	}
+	selected := make(map[string]bool)
+	usedTemplates := make(map[string]bool)
+	var usedAttachments []string
+	for _, prompt := range prompts {
+		if s.isArchived(prompt) || !expression.Evaluate(prompt.Tags) {
+			continue
+		}
+		selected[prompt.ID] = true
+		usedTemplates[prompt.TemplateRef] = true
+		entry, err := addPrompt(prompt)
+		if err != nil {
+			return nil, err
+		}
+		manifest.Prompts = append(manifest.Prompts, entry)
+		if expression != nil {
+			if full, err := backend.LoadPrompt(prompt.FilePath); err == nil {
+				usedAttachments = append(usedAttachments, PromptAttachments(full)...)
+			}
+		}
+	}
+
+	archived, err := backend.ListArchivedPrompts(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list archived prompts: %w", err)
+	}
+	for _, prompt := range archived {
+		if expression != nil && !selected[prompt.ID] {
+			continue
+		}
+		entry, err := addPrompt(prompt)
+		if err != nil {
+			return nil, err
+		}
+		manifest.Archived = append(manifest.Archived, entry)
+	}
+
+	templates, err := backend.ListTemplates()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list templates: %w", err)
+	}
+	for _, template := range templates {
+		if expression != nil && !usedTemplates[template.ID] {
+			continue
+		}
+		data, err := s.rawFile(template.FilePath, func() ([]byte, error) {
+			return storage.MarshalTemplate(template)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to read template %s: %w", template.ID, err)
+		}
+		hash, err := addFile(template.FilePath, data, template.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		manifest.Templates = append(manifest.Templates, BundleEntry{
+			ID:      template.ID,
+			Version: template.Version,
+			Path:    filepath.ToSlash(template.FilePath),
+			SHA256:  hash,
+		})
+		usedAttachments = append(usedAttachments, attachDirectives(template.Content)...)
+	}
+
+	// The whole library takes every attachment; a selection only those used
+	attachments := usedAttachments
+	if expression == nil {
+		if attachments, err = backend.ListAttachments(); err != nil {
+			return nil, err
+		}
+	}
+	slices.Sort(attachments)
+	for _, name := range slices.Compact(attachments) {
+		data, err := backend.LoadAttachment(name)
+		if err != nil {
+			// Lint reports attachments that are referenced but missing
+			slog.Warn("Skipping attachment missing from the library", "name", name, "error", err)
+			continue
+		}
+		relPath := path.Join(storage.AttachmentsDir, name)
+		hash, err := addFile(relPath, data, manifest.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		manifest.Attachments = append(manifest.Attachments, BundleEntry{ID: name, Path: relPath, SHA256: hash})
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+	if _, err := addFile(BundleManifestFile, data, manifest.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write bundle: %w", err)
+	}
+	return manifest, nil
+}
+
+// rawFile returns a library file as stored on disk, or the output of
+// fallback for backends that do not keep files under the base directory
+func (s *Service) rawFile(relPath string, fallback func() ([]byte, error)) ([]byte, error) {
+	data, err := os.ReadFile(filepath.Join(s.storage.GetBaseDir(), relPath))
+	if err == nil {
+		return data, nil
+	}
+	return fallback()
+}
+
+// ImportBundle restores the prompts, archived versions and templates of a
+// bundle written by ExportBundle. Every file is checked against the
+// manifest hash first; existing items follow the usual conflict options.
+func (s *Service) ImportBundle(bundlePath string, options importer.ImportOptions) (*BundleImportResult, error) {
+	contents, err := readBundle(bundlePath)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.backupBeforeOverwrite(options); err != nil {
+		return nil, err
+	}
+
+	return s.applyBundle(contents, options, "Import bundle"), nil
+}
+
+// bundleContents holds the verified and parsed files of a bundle
+type bundleContents struct {
+	manifest    BundleManifest
+	prompts     []*models.Prompt
+	archived    []*models.Prompt
+	templates   []*models.Template
+	attachments []bundleAttachment
+	errors      []error
+}
+
+// bundleAttachment is an attachment read from a bundle
+type bundleAttachment struct {
+	name string
+	data []byte
+}
+
+// readBundle opens a bundle and parses every file listed in its manifest.
+// Files that are missing, fail their hash check or do not parse are
+// recorded as errors.
+func readBundle(bundlePath string) (*bundleContents, error) {
+	zr, err := zip.OpenReader(bundlePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open bundle: %w", err)
+	}
+	defer zr.Close()
+
+	files := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+
+	manifestFile, ok := files[BundleManifestFile]
+	if !ok {
+		return nil, fmt.Errorf("not a pocket-prompt bundle: %s is missing", BundleManifestFile)
+	}
+	manifestData, err := readZipFile(manifestFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+	contents := &bundleContents{}
+	if err := json.Unmarshal(manifestData, &contents.manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest: %w", err)
+	}
+	manifest := &contents.manifest
+	if manifest.FormatVersion > bundleFormatVersion {
+		return nil, fmt.Errorf("bundle format version %d is newer than supported (%d)", manifest.FormatVersion, bundleFormatVersion)
+	}
+
+	// read verifies an entry against the manifest before it is used
+	read := func(entry BundleEntry, dir string) ([]byte, error) {
+		clean := path.Clean(entry.Path)
+		if clean != entry.Path || !strings.HasPrefix(clean, dir+"/") {
+			return nil, fmt.Errorf("invalid path %q in manifest", entry.Path)
+		}
+		f, ok := files[entry.Path]
+		if !ok {
+			return nil, fmt.Errorf("%s is listed in the manifest but missing from the bundle", entry.Path)
+		}
+		data, err := readZipFile(f)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", entry.Path, err)
+		}
+		sum := sha256.Sum256(data)
+		if hex.EncodeToString(sum[:]) != entry.SHA256 {
+			return nil, fmt.Errorf("%s does not match its manifest hash", entry.Path)
+		}
+		return data, nil
+	}
+
+	readPrompts := func(entries []BundleEntry, dir string) []*models.Prompt {
+		var prompts []*models.Prompt
+		for _, entry := range entries {
+			data, err := read(entry, dir)
+			if err != nil {
+				contents.errors = append(contents.errors, err)
+				continue
+			}
+			prompt, err := storage.UnmarshalPrompt(data, filepath.FromSlash(entry.Path))
+			if err != nil {
+				contents.errors = append(contents.errors, fmt.Errorf("%s: %w", entry.Path, err))
+				continue
+			}
+			prompts = append(prompts, prompt)
+		}
+		return prompts
+	}
+	contents.prompts = readPrompts(manifest.Prompts, "prompts")
+	contents.archived = readPrompts(manifest.Archived, "archive")
+
+	for _, entry := range manifest.Templates {
+		data, err := read(entry, "templates")
+		if err != nil {
+			contents.errors = append(contents.errors, err)
+			continue
+		}
+		template, err := storage.UnmarshalTemplate(data, filepath.FromSlash(entry.Path))
+		if err != nil {
+			contents.errors = append(contents.errors, fmt.Errorf("%s: %w", entry.Path, err))
+			continue
+		}
+		contents.templates = append(contents.templates, template)
+	}
+
+	for _, entry := range manifest.Attachments {
+		data, err := read(entry, storage.AttachmentsDir)
+		if err != nil {
+			contents.errors = append(contents.errors, err)
+			continue
+		}
+		name := strings.TrimPrefix(entry.Path, storage.AttachmentsDir+"/")
+		if err := storage.ValidateAttachmentName(name); err != nil {
+			contents.errors = append(contents.errors, err)
+			continue
+		}
+		contents.attachments = append(contents.attachments, bundleAttachment{name: name, data: data})
+	}
+
+	return contents, nil
+}
+
+// applyBundle saves the contents of a bundle. commitPrefix starts the git
+// commit message.
+func (s *Service) applyBundle(contents *bundleContents, options importer.ImportOptions, commitPrefix string) *BundleImportResult {
+	savePrompt := func(prompt *models.Prompt) error {
+		return s.savePromptWithConflictResolution(prompt, options)
+	}
+	saveTemplate := func(template *models.Template) error {
+		return s.saveTemplateWithConflictResolution(template, options)
+	}
+	return s.writeBundle(contents, options, commitPrefix, savePrompt, saveTemplate)
+}
+
+// writeBundle writes a bundle's contents, saving prompts and templates with
+// the given functions
+func (s *Service) writeBundle(contents *bundleContents, options importer.ImportOptions, commitPrefix string, savePrompt func(*models.Prompt) error, saveTemplate func(*models.Template) error) *BundleImportResult {
+	result := &BundleImportResult{Manifest: &contents.manifest}
+	result.Errors = append(result.Errors, contents.errors...)
+
+	for _, prompt := range contents.prompts {
+		if !options.DryRun {
+			if err := savePrompt(prompt); err != nil {
+				result.Errors = append(result.Errors, fmt.Errorf("failed to save prompt %s: %w", prompt.ID, err))
+				continue
+			}
+		}
+		result.Prompts = append(result.Prompts, prompt)
+	}
+
+	// Archived versions are immutable, so existing ones are left alone
+	existingArchive := make(map[string]bool)
+	if archived, err := s.storage.ListArchivedPrompts(context.Background()); err == nil {
+		for _, prompt := range archived {
+			existingArchive[filepath.ToSlash(prompt.FilePath)] = true
+		}
+	}
+	for _, prompt := range contents.archived {
+		if existingArchive[filepath.ToSlash(prompt.FilePath)] {
+			continue
+		}
+		if !options.DryRun {
+			if err := s.storage.SavePrompt(prompt); err != nil {
+				result.Errors = append(result.Errors, fmt.Errorf("failed to save archived prompt %s: %w", prompt.ID, err))
+				continue
+			}
+		}
+		result.Archived = append(result.Archived, prompt)
+	}
+
+	for _, template := range contents.templates {
+		if !options.DryRun {
+			if err := saveTemplate(template); err != nil {
+				result.Errors = append(result.Errors, fmt.Errorf("failed to save template %s: %w", template.ID, err))
+				continue
+			}
+		}
+		result.Templates = append(result.Templates, template)
+	}
+
+	for _, attachment := range contents.attachments {
+		if existing, err := s.storage.LoadAttachment(attachment.name); err == nil {
+			if bytes.Equal(existing, attachment.data) || options.SkipExisting {
+				continue
+			}
+			if !options.OverwriteExisting {
+				result.Errors = append(result.Errors, fmt.Errorf("attachment %s already exists (use --overwrite to overwrite or --skip-existing to skip)", attachment.name))
+				continue
+			}
+		}
+		if !options.DryRun {
+			if err := s.storage.SaveAttachment(attachment.name, attachment.data); err != nil {
+				result.Errors = append(result.Errors, fmt.Errorf("failed to save attachment %s: %w", attachment.name, err))
+				continue
+			}
+		}
+		result.Attachments = append(result.Attachments, attachment.name)
+	}
+
+	if options.DryRun {
+		return result
+	}
+
+	if err := s.loadPrompts(); err != nil {
+		result.Errors = append(result.Errors, fmt.Errorf("failed to refresh prompts cache: %w", err))
+	}
+	if s.gitSync.IsEnabled() && len(result.Errors) == 0 {
+		message := fmt.Sprintf("%s: %d prompts, %d templates", commitPrefix, len(result.Prompts), len(result.Templates))
+		if err := s.gitSync.SyncChanges(message); err != nil {
+			result.Errors = append(result.Errors, fmt.Errorf("git sync failed after import: %w", err))
+		}
+	}
+
+	return result
+}
+
+func readZipFile(f *zip.File) ([]byte, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return io.ReadAll(rc)
+}
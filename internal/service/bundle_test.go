@@ -0,0 +1,170 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dpshade/pocket-prompt/internal/importer"
+	"github.com/dpshade/pocket-prompt/internal/models"
+)
+
+func TestBundleRoundTrip(t *testing.T) {
+	source := t.TempDir()
+	t.Setenv("POCKET_PROMPT_DIR", source)
+
+	files := map[string]string{
+		"prompts/team/launch.md":        "+++\nid = \"launch\"\nversion = \"1.0.1\"\ntitle = \"Launch\"\ntags = [\"marketing\"]\n+++\n\nAnnounce {{product}}\n",
+		"archive/team/launch-v1.0.0.md": "---\nid: launch\nversion: 1.0.0\ntitle: Launch\ntags: [marketing, archive]\n---\n\nOld body\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(source, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	service, err := NewService()
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+	template := &models.Template{ID: "tmpl", Version: "2", Name: "Template", Content: "Hi {{name}}", Slots: []models.Slot{{Name: "name", Required: true}}}
+	if err := service.SaveTemplate(template); err != nil {
+		t.Fatalf("Failed to save template: %v", err)
+	}
+
+	var buf bytes.Buffer
+	manifest, err := service.ExportBundle(&buf, nil)
+	if err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	if len(manifest.Prompts) != 1 || len(manifest.Archived) != 1 || len(manifest.Templates) != 1 {
+		t.Fatalf("Unexpected manifest: %+v", manifest)
+	}
+	if manifest.Prompts[0].ID != "team/launch" || manifest.Prompts[0].Version != "1.0.1" || manifest.Prompts[0].SHA256 == "" {
+		t.Errorf("Unexpected manifest entry: %+v", manifest.Prompts[0])
+	}
+
+	bundlePath := filepath.Join(t.TempDir(), "bundle.zip")
+	if err := os.WriteFile(bundlePath, buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	target := t.TempDir()
+	t.Setenv("POCKET_PROMPT_DIR", target)
+	restored, err := NewService()
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+	result, err := restored.ImportBundle(bundlePath, importer.ImportOptions{})
+	if err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	if len(result.Errors) > 0 {
+		t.Fatalf("Unexpected import errors: %v", result.Errors)
+	}
+
+	prompt, err := restored.GetPrompt("team/launch")
+	if err != nil {
+		t.Fatalf("Expected restored prompt: %v", err)
+	}
+	if prompt.Version != "1.0.1" || prompt.Content != "Announce {{product}}" || len(prompt.Tags) != 1 {
+		t.Errorf("Restored prompt differs: %+v", prompt)
+	}
+	if _, err := os.Stat(filepath.Join(target, "archive", "team", "launch-v1.0.0.md")); err != nil {
+		t.Errorf("Expected archived version restored: %v", err)
+	}
+	if got, err := restored.GetTemplate("tmpl"); err != nil || got.Content != "Hi {{name}}" || len(got.Slots) != 1 {
+		t.Errorf("Expected template restored, got %+v, %v", got, err)
+	}
+}
+
+func TestImportBundleRejectsTamperedFiles(t *testing.T) {
+	t.Setenv("POCKET_PROMPT_DIR", t.TempDir())
+
+	bundlePath := filepath.Join(t.TempDir(), "bundle.zip")
+	file, err := os.Create(bundlePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	zw := zip.NewWriter(file)
+	entries := map[string]string{
+		"manifest.json":   `{"format_version": 1, "prompts": [{"id": "x", "path": "prompts/x.md", "sha256": "0000"}, {"id": "y", "path": "prompts/../../y.md", "sha256": ""}]}`,
+		"prompts/x.md":    "---\nid: x\n---\n\nChanged\n",
+		"prompts/../../y": "escape",
+	}
+	for name, content := range entries {
+		w, _ := zw.Create(name)
+		w.Write([]byte(content))
+	}
+	zw.Close()
+	file.Close()
+
+	service, err := NewService()
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+	if err := service.InitLibrary(); err != nil {
+		t.Fatalf("Failed to init library: %v", err)
+	}
+	result, err := service.ImportBundle(bundlePath, importer.ImportOptions{})
+	if err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	if len(result.Prompts) != 0 || len(result.Errors) != 2 {
+		t.Errorf("Expected both entries rejected, got %d prompts, errors %v", len(result.Prompts), result.Errors)
+	}
+}
+
+func TestExportBundleWithQuery(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("POCKET_PROMPT_DIR", dir)
+
+	files := map[string]string{
+		"prompts/ship.md":       "---\nid: ship\ntitle: Ship\ntags: [ai, production]\ntemplate: tmpl\n---\n\nShip it\n",
+		"prompts/draft.md":      "---\nid: draft\ntitle: Draft\ntags: [ai, production, draft]\n---\n\nNot yet\n",
+		"prompts/other.md":      "---\nid: other\ntitle: Other\ntags: [misc]\n---\n\nOther\n",
+		"archive/ship-v0.9.md":  "---\nid: ship\nversion: 0.9\ntitle: Ship\ntags: [archive]\n---\n\nOld\n",
+		"archive/other-v0.1.md": "---\nid: other\nversion: 0.1\ntitle: Other\ntags: [archive]\n---\n\nOld\n",
+		"templates/tmpl.md":     "---\nid: tmpl\nname: Template\n---\n\nHi\n",
+		"templates/unused.md":   "---\nid: unused\nname: Unused\n---\n\nBye\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	service, err := NewService()
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+	expr, err := models.ParseBooleanExpression("(ai AND production) NOT draft")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	manifest, err := service.ExportBundle(&buf, expr)
+	if err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	if len(manifest.Prompts) != 1 || manifest.Prompts[0].ID != "ship" {
+		t.Errorf("Expected only ship, got %+v", manifest.Prompts)
+	}
+	if len(manifest.Archived) != 1 || manifest.Archived[0].ID != "ship" {
+		t.Errorf("Expected only ship's archived version, got %+v", manifest.Archived)
+	}
+	if len(manifest.Templates) != 1 || manifest.Templates[0].ID != "tmpl" {
+		t.Errorf("Expected only the used template, got %+v", manifest.Templates)
+	}
+}
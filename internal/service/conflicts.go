@@ -0,0 +1,171 @@
+package service
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/dpshade/pocket-prompt/internal/storage"
+)
+
+// Conflict resolutions
+const (
+	KeepNewer    = "newer"    // Keep whichever file was updated last
+	KeepOriginal = "original" // Discard the conflict copy
+	KeepCopy     = "copy"     // Replace the original with the copy
+	MergeCopies  = "merge"    // Combine both into the original
+)
+
+// ListConflicts returns the conflict copies file sync services left in the
+// library
+func (s *Service) ListConflicts() ([]storage.ConflictCopy, error) {
+	backend := s.storage
+	if encrypted, ok := backend.(*storage.EncryptedBackend); ok {
+		backend = encrypted.Backend
+	}
+	lister, ok := backend.(storage.ConflictLister)
+	if !ok {
+		return nil, nil
+	}
+	return lister.ListConflicts()
+}
+
+// ResolveConflict settles a conflict copy with one of the resolutions and
+// removes the copy. Merging combines the tags and metadata of both prompts
+// and, when the bodies differ, keeps both between conflict markers to be
+// edited by hand. Templates cannot be merged. It returns the resolution
+// applied, which for KeepNewer is KeepOriginal or KeepCopy.
+func (s *Service) ResolveConflict(conflict storage.ConflictCopy, resolution string) (string, error) {
+	isTemplate := strings.HasPrefix(filepath.ToSlash(conflict.Path), "templates/")
+
+	if resolution == KeepNewer {
+		copyNewer, err := s.conflictCopyIsNewer(conflict, isTemplate)
+		if err != nil {
+			return "", err
+		}
+		resolution = KeepOriginal
+		if copyNewer {
+			resolution = KeepCopy
+		}
+	}
+
+	root := s.storage.GetBaseDir()
+	copyPath := filepath.Join(root, conflict.Path)
+	switch resolution {
+	case KeepOriginal:
+		if err := os.Remove(copyPath); err != nil {
+			return "", fmt.Errorf("failed to remove conflict copy: %w", err)
+		}
+	case KeepCopy:
+		if err := os.Rename(copyPath, filepath.Join(root, conflict.Original)); err != nil {
+			return "", fmt.Errorf("failed to replace %s with its conflict copy: %w", conflict.Original, err)
+		}
+	case MergeCopies:
+		if isTemplate {
+			return "", fmt.Errorf("templates cannot be merged; keep the newer, original or copy instead")
+		}
+		if err := s.mergeConflict(conflict); err != nil {
+			return "", err
+		}
+		if err := os.Remove(copyPath); err != nil {
+			return "", fmt.Errorf("failed to remove conflict copy: %w", err)
+		}
+	default:
+		return "", fmt.Errorf("unknown conflict resolution %q: use newer, original, copy or merge", resolution)
+	}
+
+	if s.gitSync.IsEnabled() {
+		message := fmt.Sprintf("Resolve sync conflict in %s", filepath.ToSlash(conflict.Original))
+		if err := s.gitSync.SyncPaths(message, conflict.Original, conflict.Path); err != nil {
+			slog.Warn("Git sync failed after resolving conflict", "error", err)
+		}
+	}
+
+	return resolution, s.loadPrompts()
+}
+
+// conflictCopyIsNewer reports whether the copy was updated after the
+// original, by their updated_at fields or else their modification times
+func (s *Service) conflictCopyIsNewer(conflict storage.ConflictCopy, isTemplate bool) (bool, error) {
+	if !isTemplate {
+		original, err := s.storage.LoadPromptMetadata(conflict.Original)
+		if err != nil {
+			// A broken original loses to any copy
+			return true, nil
+		}
+		copy, err := s.storage.LoadPromptMetadata(conflict.Path)
+		if err != nil {
+			return false, nil
+		}
+		if !original.UpdatedAt.Equal(copy.UpdatedAt) {
+			return copy.UpdatedAt.After(original.UpdatedAt), nil
+		}
+	}
+
+	root := s.storage.GetBaseDir()
+	originalInfo, err := os.Stat(filepath.Join(root, conflict.Original))
+	if err != nil {
+		return true, nil
+	}
+	copyInfo, err := os.Stat(filepath.Join(root, conflict.Path))
+	if err != nil {
+		return false, fmt.Errorf("failed to read conflict copy: %w", err)
+	}
+	return copyInfo.ModTime().After(originalInfo.ModTime()), nil
+}
+
+// mergeConflict writes the combination of a prompt and its conflict copy
+// to the original file
+func (s *Service) mergeConflict(conflict storage.ConflictCopy) error {
+	original, err := s.storage.LoadPrompt(conflict.Original)
+	if err != nil {
+		return fmt.Errorf("failed to load %s: %w", conflict.Original, err)
+	}
+	copy, err := s.storage.LoadPrompt(conflict.Path)
+	if err != nil {
+		return fmt.Errorf("failed to load %s: %w", conflict.Path, err)
+	}
+
+	newer, older := original, copy
+	if copy.UpdatedAt.After(original.UpdatedAt) {
+		newer, older = copy, original
+	}
+
+	merged := *newer
+	merged.FilePath = conflict.Original
+	merged.Tags = append([]string(nil), newer.Tags...)
+	for _, tag := range older.Tags {
+		if !slices.Contains(merged.Tags, tag) {
+			merged.Tags = append(merged.Tags, tag)
+		}
+	}
+	merged.Metadata = make(map[string]interface{}, len(newer.Metadata)+len(older.Metadata))
+	for k, v := range older.Metadata {
+		merged.Metadata[k] = v
+	}
+	for k, v := range newer.Metadata {
+		merged.Metadata[k] = v
+	}
+	if len(merged.Metadata) == 0 {
+		merged.Metadata = nil
+	}
+	merged.Encrypted = original.Encrypted || copy.Encrypted
+	if original.Content != copy.Content {
+		merged.Content = fmt.Sprintf("<<<<<<< %s\n%s\n=======\n%s\n>>>>>>> %s\n",
+			filepath.ToSlash(conflict.Original), strings.TrimRight(original.Content, "\n"),
+			strings.TrimRight(copy.Content, "\n"), filepath.ToSlash(conflict.Path))
+	}
+	if merged.Version, err = s.incrementVersion(newer.Version); err != nil {
+		return fmt.Errorf("failed to increment version: %w", err)
+	}
+	merged.UpdatedAt = time.Now()
+
+	if err := s.storage.SavePrompt(&merged); err != nil {
+		return fmt.Errorf("failed to save merged prompt: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dpshade/pocket-prompt/internal/models"
+)
+
+func TestResolveConflict(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("POCKET_PROMPT_DIR", dir)
+
+	service, err := NewService()
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+	now := time.Now()
+	original := &models.Prompt{
+		ID:        "review",
+		Version:   "1.0.0",
+		Name:      "Review",
+		Content:   "Review this code",
+		Tags:      []string{"code"},
+		UpdatedAt: now,
+		FilePath:  filepath.Join("prompts", "review.md"),
+	}
+	copy := *original
+	copy.Content = "Review this pull request"
+	copy.Tags = []string{"code", "github"}
+	copy.Metadata = map[string]interface{}{"owner": "ana"}
+	copy.UpdatedAt = now.Add(time.Minute)
+	copy.FilePath = filepath.Join("prompts", "review (conflicted copy 2024-01-02).md")
+	for _, prompt := range []*models.Prompt{original, &copy} {
+		if err := service.storage.SavePrompt(prompt); err != nil {
+			t.Fatalf("Failed to save prompt: %v", err)
+		}
+	}
+
+	conflicts, err := service.ListConflicts()
+	if err != nil || len(conflicts) != 1 {
+		t.Fatalf("Expected one conflict, got %+v %v", conflicts, err)
+	}
+
+	if _, err := service.ResolveConflict(conflicts[0], "sideways"); err == nil {
+		t.Error("Expected an unknown resolution to be rejected")
+	}
+
+	applied, err := service.ResolveConflict(conflicts[0], MergeCopies)
+	if err != nil || applied != MergeCopies {
+		t.Fatalf("Failed to merge: %s %v", applied, err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, copy.FilePath)); !os.IsNotExist(err) {
+		t.Error("Expected the conflict copy to be removed")
+	}
+	merged, err := service.GetPrompt("review")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if merged.Version != "1.0.1" || len(merged.Tags) != 2 || merged.Metadata["owner"] != "ana" {
+		t.Errorf("Unexpected merged prompt: %+v", merged)
+	}
+	if !strings.Contains(merged.Content, "<<<<<<< prompts/review.md\nReview this code\n=======\nReview this pull request\n>>>>>>>") {
+		t.Errorf("Expected both bodies between conflict markers, got %q", merged.Content)
+	}
+
+	// Keeping the newer file picks the copy by its updated_at
+	copy.UpdatedAt = merged.UpdatedAt.Add(time.Minute)
+	copy.Content = "Newest"
+	if err := service.storage.SavePrompt(&copy); err != nil {
+		t.Fatal(err)
+	}
+	conflicts, _ = service.ListConflicts()
+	if applied, err := service.ResolveConflict(conflicts[0], KeepNewer); err != nil || applied != KeepCopy {
+		t.Fatalf("Expected the copy to be kept, got %s %v", applied, err)
+	}
+	if prompt, _ := service.GetPrompt("review"); prompt == nil || prompt.Content != "Newest" {
+		t.Errorf("Expected the copy to replace the original, got %+v", prompt)
+	}
+	if conflicts, _ := service.ListConflicts(); len(conflicts) != 0 {
+		t.Errorf("Expected no conflicts left, got %+v", conflicts)
+	}
+}
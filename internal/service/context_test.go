@@ -0,0 +1,38 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/dpshade/pocket-prompt/internal/models"
+	"github.com/dpshade/pocket-prompt/internal/storage"
+)
+
+func TestSearchStopsWhenCancelled(t *testing.T) {
+	svc := NewServiceWithBackend(storage.NewMemoryStorage(""))
+	if err := svc.CreatePrompt(&models.Prompt{ID: "review", Name: "Review", Version: "1.0.0", Tags: []string{"code"}, Content: "Review"}); err != nil {
+		t.Fatalf("Failed to create prompt: %v", err)
+	}
+	if err := svc.SaveBooleanSearch(models.SavedSearch{Name: "code", Expression: models.NewTagExpression("code"), TextQuery: "review"}); err != nil {
+		t.Fatalf("Failed to save search: %v", err)
+	}
+	svc.prompts = nil // As if the library were never listed
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := svc.SearchPromptsInScopeContext(ctx, "review", ActiveOnly); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the search to stop, got %v", err)
+	}
+	if _, err := svc.ExecuteSavedSearchWithTextContext(ctx, "code", ""); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the saved search to stop, got %v", err)
+	}
+	if _, err := svc.WithContentContext(ctx, []*models.Prompt{{ID: "review", FilePath: "prompts/review.md"}}); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected loading bodies to stop, got %v", err)
+	}
+
+	results, err := svc.ExecuteSavedSearchWithTextContext(context.Background(), "code", "")
+	if err != nil || len(results) != 1 {
+		t.Errorf("Expected the saved search to find the prompt, got %v, %v", results, err)
+	}
+}
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dpshade/pocket-prompt/internal/importer"
+)
+
+func TestImportFromCSV(t *testing.T) {
+	t.Setenv("POCKET_PROMPT_DIR", t.TempDir())
+	path := filepath.Join(t.TempDir(), "prompts.csv")
+	csv := "Label,Text,Keywords,Owner\n" +
+		"Code Review,\"Review this diff:\n{{diff}}\",\"coding; review\",ana\n" +
+		"Code Review,Second review prompt,coding,ben\n" +
+		",,,\n" +
+		"Empty,,,\n"
+	if err := os.WriteFile(path, []byte(csv), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	service, err := NewService()
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+
+	mapping, err := importer.ParseCSVMapping("title=col1,content=Text,tags=3")
+	if err != nil {
+		t.Fatalf("Failed to parse mapping: %v", err)
+	}
+	options := importer.CSVOptions{Mapping: mapping}
+	options.Path = path
+
+	// A dry run converts rows without saving them
+	options.DryRun = true
+	result, err := service.ImportFromCSV(options)
+	if err != nil {
+		t.Fatalf("Preview failed: %v", err)
+	}
+	if len(result.Prompts) != 2 || len(result.Errors) != 1 {
+		t.Fatalf("Expected 2 prompts and 1 empty-content error, got %d prompts, errors %v", len(result.Prompts), result.Errors)
+	}
+	if _, err := service.GetPrompt("code-review"); err == nil {
+		t.Fatal("Preview should not save prompts")
+	}
+
+	options.DryRun = false
+	if _, err := service.ImportFromCSV(options); err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+
+	review, err := service.GetPrompt("code-review")
+	if err != nil {
+		t.Fatalf("Expected prompt code-review: %v", err)
+	}
+	if review.Name != "Code Review" || review.Content != "Review this diff:\n{{diff}}" {
+		t.Errorf("Unexpected prompt: %q %q", review.Name, review.Content)
+	}
+	tags := make(map[string]bool)
+	for _, tag := range review.Tags {
+		tags[tag] = true
+	}
+	if !tags["coding"] || !tags["review"] || !tags["csv"] {
+		t.Errorf("Expected tags split on semicolons, got %v", review.Tags)
+	}
+	if review.Metadata["owner"] != "ana" {
+		t.Errorf("Expected unmapped column kept as metadata, got %v", review.Metadata)
+	}
+	if _, err := service.GetPrompt("code-review-2"); err != nil {
+		t.Errorf("Expected duplicate title numbered: %v", err)
+	}
+}
+
+func TestParseCSVMappingErrors(t *testing.T) {
+	for _, spec := range []string{"title", "colour=col1", "title="} {
+		if _, err := importer.ParseCSVMapping(spec); err == nil {
+			t.Errorf("Expected an error for %q", spec)
+		}
+	}
+}
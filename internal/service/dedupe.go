@@ -0,0 +1,210 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/dpshade/pocket-prompt/internal/models"
+)
+
+// DefaultDuplicateThreshold is the similarity at which two bodies count as
+// near-identical
+const DefaultDuplicateThreshold = 0.9
+
+// DuplicateGroup is a set of prompts with identical or near-identical
+// bodies, oldest first. The first prompt is the one merging keeps.
+type DuplicateGroup struct {
+	Prompts []*models.Prompt
+
+	// Similarity is the lowest similarity of a prompt to the first, 1 when
+	// the bodies are identical apart from whitespace
+	Similarity float64
+}
+
+// Keep returns the prompt a merge keeps
+func (g DuplicateGroup) Keep() *models.Prompt {
+	return g.Prompts[0]
+}
+
+// Duplicates returns the prompts a merge or delete removes
+func (g DuplicateGroup) Duplicates() []*models.Prompt {
+	return g.Prompts[1:]
+}
+
+// FindDuplicates groups the active prompts whose bodies are identical, or
+// whose word pairs overlap by at least threshold (0 to 1). A threshold of 1
+// finds identical bodies only. Encrypted and empty prompts are skipped.
+func (s *Service) FindDuplicates(threshold float64) ([]DuplicateGroup, error) {
+	if threshold <= 0 || threshold > 1 {
+		return nil, fmt.Errorf("similarity threshold must be above 0 and at most 1, got %g", threshold)
+	}
+	prompts, err := s.ListPrompts()
+	if err != nil {
+		return nil, err
+	}
+	if prompts, err = s.WithContent(prompts); err != nil {
+		return nil, err
+	}
+
+	// Group identical bodies by hash first. ContentHash can't be used as it
+	// covers the frontmatter too, where duplicates differ at least by ID.
+	byHash := make(map[string][]*models.Prompt)
+	var hashes []string
+	for _, prompt := range prompts {
+		body := normalizeBody(prompt.Content)
+		if prompt.Encrypted || body == "" {
+			continue
+		}
+		sum := sha256.Sum256([]byte(body))
+		hash := hex.EncodeToString(sum[:])
+		if _, ok := byHash[hash]; !ok {
+			hashes = append(hashes, hash)
+		}
+		byHash[hash] = append(byHash[hash], prompt)
+	}
+
+	// Then join groups whose bodies are near-identical
+	parent := make([]int, len(hashes))
+	for i := range parent {
+		parent[i] = i
+	}
+	find := func(i int) int {
+		for parent[i] != i {
+			parent[i] = parent[parent[i]]
+			i = parent[i]
+		}
+		return i
+	}
+	if threshold < 1 {
+		shingles := make([]map[string]bool, len(hashes))
+		for i, hash := range hashes {
+			shingles[i] = wordPairs(byHash[hash][0].Content)
+		}
+		for i := range hashes {
+			for j := i + 1; j < len(hashes); j++ {
+				// Sets too different in size can't reach the threshold
+				small, large := len(shingles[i]), len(shingles[j])
+				if small > large {
+					small, large = large, small
+				}
+				if float64(small) < threshold*float64(large) {
+					continue
+				}
+				if jaccard(shingles[i], shingles[j]) >= threshold {
+					parent[find(j)] = find(i)
+				}
+			}
+		}
+	}
+
+	members := make(map[int][]*models.Prompt)
+	var roots []int
+	for i, hash := range hashes {
+		root := find(i)
+		if _, ok := members[root]; !ok {
+			roots = append(roots, root)
+		}
+		members[root] = append(members[root], byHash[hash]...)
+	}
+
+	var groups []DuplicateGroup
+	for _, root := range roots {
+		group := members[root]
+		if len(group) < 2 {
+			continue
+		}
+		sort.SliceStable(group, func(i, j int) bool {
+			return group[i].CreatedAt.Before(group[j].CreatedAt)
+		})
+		similarity := 1.0
+		keep := wordPairs(group[0].Content)
+		for _, prompt := range group[1:] {
+			if normalizeBody(prompt.Content) != normalizeBody(group[0].Content) {
+				similarity = min(similarity, jaccard(keep, wordPairs(prompt.Content)))
+			}
+		}
+		groups = append(groups, DuplicateGroup{Prompts: group, Similarity: similarity})
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		return groups[i].Similarity > groups[j].Similarity
+	})
+	return groups, nil
+}
+
+// MergeDuplicates keeps the first prompt of a group with the tags and
+// metadata of the others added, and deletes the others. The kept prompt's
+// body is unchanged.
+func (s *Service) MergeDuplicates(group DuplicateGroup) (*models.Prompt, error) {
+	keep := *group.Keep()
+	keep.Tags = append([]string(nil), keep.Tags...)
+	keep.Metadata = make(map[string]interface{}, len(keep.Metadata))
+	for k, v := range group.Keep().Metadata {
+		keep.Metadata[k] = v
+	}
+	for _, duplicate := range group.Duplicates() {
+		for _, tag := range duplicate.Tags {
+			if !slices.Contains(keep.Tags, tag) {
+				keep.Tags = append(keep.Tags, tag)
+			}
+		}
+		for k, v := range duplicate.Metadata {
+			if _, ok := keep.Metadata[k]; !ok {
+				keep.Metadata[k] = v
+			}
+		}
+	}
+	if len(keep.Metadata) == 0 {
+		keep.Metadata = nil
+	}
+
+	if len(promptDiff(group.Keep(), &keep)) > 0 {
+		if err := s.UpdatePrompt(&keep); err != nil {
+			return nil, fmt.Errorf("failed to update %s: %w", keep.ID, err)
+		}
+	}
+	if _, err := s.DeletePrompts(group.Duplicates()); err != nil {
+		return nil, err
+	}
+	return &keep, nil
+}
+
+// normalizeBody collapses whitespace so reformatted copies still match
+func normalizeBody(content string) string {
+	return strings.Join(strings.Fields(content), " ")
+}
+
+// wordPairs returns the set of adjacent word pairs in content, or its
+// single word, ignoring case and punctuation
+func wordPairs(content string) map[string]bool {
+	words := strings.FieldsFunc(strings.ToLower(content), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+	pairs := make(map[string]bool, len(words))
+	if len(words) == 1 {
+		pairs[words[0]] = true
+	}
+	for i := 1; i < len(words); i++ {
+		pairs[words[i-1]+" "+words[i]] = true
+	}
+	return pairs
+}
+
+// jaccard returns the overlap of two sets: their intersection over their
+// union
+func jaccard(a, b map[string]bool) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 1
+	}
+	shared := 0
+	for key := range a {
+		if b[key] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dpshade/pocket-prompt/internal/models"
+)
+
+func TestFindDuplicates(t *testing.T) {
+	t.Setenv("POCKET_PROMPT_DIR", t.TempDir())
+	service, err := NewService()
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+
+	body := "Summarize the following article in three bullet points for a busy executive audience"
+	for _, prompt := range []*models.Prompt{
+		{ID: "original", Name: "Original", Tags: []string{"writing"}, Content: body},
+		{ID: "reformatted", Name: "Reformatted", Tags: []string{"summary"}, Content: "  " + body + "\n\n"},
+		{ID: "edited", Name: "Edited", Content: body + ", please"},
+		{ID: "other", Name: "Other", Content: "Translate this text into French"},
+	} {
+		if err := service.CreatePrompt(prompt); err != nil {
+			t.Fatalf("Failed to create prompt: %v", err)
+		}
+		// Creation order decides which prompt is kept
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	groups, err := service.FindDuplicates(1)
+	if err != nil {
+		t.Fatalf("Failed to find duplicates: %v", err)
+	}
+	if len(groups) != 1 || len(groups[0].Prompts) != 2 || groups[0].Similarity != 1 {
+		t.Fatalf("Expected one identical pair, got %+v", groups)
+	}
+	if groups[0].Keep().ID != "original" {
+		t.Errorf("Expected the oldest prompt to be kept, got %s", groups[0].Keep().ID)
+	}
+
+	groups, err = service.FindDuplicates(0.8)
+	if err != nil {
+		t.Fatalf("Failed to find duplicates: %v", err)
+	}
+	if len(groups) != 1 || len(groups[0].Prompts) != 3 {
+		t.Fatalf("Expected the edited copy to join the group, got %+v", groups)
+	}
+	if groups[0].Similarity >= 1 || groups[0].Similarity < 0.8 {
+		t.Errorf("Expected a similarity between 0.8 and 1, got %g", groups[0].Similarity)
+	}
+
+	kept, err := service.MergeDuplicates(groups[0])
+	if err != nil {
+		t.Fatalf("Failed to merge duplicates: %v", err)
+	}
+	if kept.ID != "original" || len(kept.Tags) != 2 {
+		t.Errorf("Expected original with both tags, got %s %v", kept.ID, kept.Tags)
+	}
+	prompts, _ := service.ListPrompts()
+	if len(prompts) != 2 {
+		t.Errorf("Expected the duplicates to be deleted, got %d prompts", len(prompts))
+	}
+
+	if _, err := service.FindDuplicates(0); err == nil {
+		t.Error("Expected an error for a threshold of 0")
+	}
+}
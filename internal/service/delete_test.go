@@ -0,0 +1,46 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/dpshade/pocket-prompt/internal/models"
+)
+
+func TestDeletePrompts(t *testing.T) {
+	t.Setenv("POCKET_PROMPT_DIR", t.TempDir())
+
+	service, err := NewService()
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+	for _, id := range []string{"one", "two", "three"} {
+		if err := service.CreatePrompt(&models.Prompt{ID: id, Name: id, Content: "x"}); err != nil {
+			t.Fatalf("Failed to create prompt: %v", err)
+		}
+	}
+
+	var selected []*models.Prompt
+	for _, id := range []string{"one", "three"} {
+		prompt, err := service.GetPrompt(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		selected = append(selected, prompt)
+	}
+
+	deleted, err := service.DeletePrompts(selected)
+	if err != nil {
+		t.Fatalf("DeletePrompts failed: %v", err)
+	}
+	if len(deleted) != 2 {
+		t.Errorf("Expected 2 deleted prompts, got %d", len(deleted))
+	}
+
+	remaining, err := service.ListPrompts()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(remaining) != 1 || remaining[0].ID != "two" {
+		t.Errorf("Expected only two to remain, got %v", remaining)
+	}
+}
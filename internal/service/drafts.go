@@ -0,0 +1,171 @@
+package service
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/dpshade/pocket-prompt/internal/git"
+	"github.com/dpshade/pocket-prompt/internal/models"
+)
+
+// DefaultDraftBranch is the branch drafts are committed to when config.yaml
+// does not set git.draft_branch
+const DefaultDraftBranch = "drafts"
+
+// DraftBranch returns the branch drafts are committed to
+func (s *Service) DraftBranch() string {
+	if s.draftBranch != "" {
+		return s.draftBranch
+	}
+	return DefaultDraftBranch
+}
+
+// syncPrompt commits the files a change to a prompt touched. Drafts are
+// committed to the draft branch, other prompts to the current branch.
+func (s *Service) syncPrompt(prompt *models.Prompt, message string, paths ...string) error {
+	if prompt.Draft {
+		return s.gitSync.SyncToBranch(s.DraftBranch(), message, paths...)
+	}
+	return s.gitSync.SyncPaths(message, paths...)
+}
+
+// draftPaths returns the files of drafts and of their archived versions,
+// which stay out of the current branch until they are published
+func (s *Service) draftPaths() []string {
+	prompts, err := s.ListPromptsInScope(IncludeArchived)
+	if err != nil {
+		return nil
+	}
+	var paths []string
+	for _, prompt := range prompts {
+		if prompt.Draft {
+			paths = append(paths, prompt.FilePath)
+		}
+	}
+	return paths
+}
+
+// ListDrafts returns the prompts marked as drafts
+func (s *Service) ListDrafts() ([]*models.Prompt, error) {
+	prompts, err := s.ListPrompts()
+	if err != nil {
+		return nil, err
+	}
+	var drafts []*models.Prompt
+	for _, prompt := range prompts {
+		if prompt.Draft {
+			drafts = append(drafts, prompt)
+		}
+	}
+	return drafts, nil
+}
+
+// PublishDrafts clears the draft mark of the given drafts, or of all drafts
+// when ids is empty, and commits them to the current branch. Publishing the
+// last drafts merges the draft branch, which then starts over from the
+// merge. The version is left alone. It returns the published prompts.
+func (s *Service) PublishDrafts(ids []string) ([]*models.Prompt, error) {
+	drafts, err := s.ListDrafts()
+	if err != nil {
+		return nil, err
+	}
+
+	selected := drafts
+	if len(ids) > 0 {
+		selected = nil
+		for _, id := range ids {
+			prompt, err := s.GetPrompt(id)
+			if err != nil {
+				return nil, err
+			}
+			if !prompt.Draft {
+				return nil, fmt.Errorf("prompt %s is not a draft", prompt.ID)
+			}
+			selected = append(selected, prompt)
+		}
+	}
+	if len(selected) == 0 {
+		return nil, nil
+	}
+
+	archived, err := s.ListArchivedPrompts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list archived prompts: %w", err)
+	}
+
+	var published []*models.Prompt
+	var paths []string
+	for _, draft := range selected {
+		prompt, err := s.storage.LoadPrompt(draft.FilePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load prompt %s: %w", draft.ID, err)
+		}
+		prompt.Draft = false
+		if err := s.storage.SavePrompt(prompt); err != nil {
+			return nil, fmt.Errorf("failed to publish prompt %s: %w", prompt.ID, err)
+		}
+		published = append(published, prompt)
+		paths = append(paths, prompt.FilePath)
+
+		// Earlier versions written while drafting are published with it
+		for _, old := range archived {
+			if old.ID != prompt.ID || !old.Draft {
+				continue
+			}
+			version, err := s.storage.LoadPrompt(old.FilePath)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load archived prompt %s: %w", old.FilePath, err)
+			}
+			version.Draft = false
+			if err := s.storage.SavePrompt(version); err != nil {
+				return nil, fmt.Errorf("failed to publish archived prompt %s: %w", old.FilePath, err)
+			}
+			paths = append(paths, old.FilePath)
+		}
+	}
+
+	if s.gitSync.IsEnabled() {
+		message := fmt.Sprintf("Publish draft %s", published[0].ID)
+		if len(published) > 1 {
+			message = fmt.Sprintf("Publish %d drafts", len(published))
+		}
+		var syncErr error
+		if len(published) == len(drafts) {
+			syncErr = s.gitSync.MergeBranch(s.DraftBranch(), message, paths...)
+		} else {
+			syncErr = s.gitSync.SyncPaths(message, paths...)
+		}
+		if syncErr != nil {
+			slog.Warn("Git sync failed after publishing drafts", "error", syncErr)
+		}
+	}
+
+	return published, s.loadPrompts()
+}
+
+// ListGitBranches returns the local branches of the library repository
+func (s *Service) ListGitBranches() ([]git.Branch, error) {
+	return s.gitSync.ListBranches()
+}
+
+// CreateGitBranch creates a branch from the current commit
+func (s *Service) CreateGitBranch(name string) error {
+	return s.gitSync.CreateBranch(name)
+}
+
+// SwitchGitBranch checks a branch out and reloads the library from it
+func (s *Service) SwitchGitBranch(name string) error {
+	if err := s.gitSync.SwitchBranch(name); err != nil {
+		return err
+	}
+	return s.loadPrompts()
+}
+
+// DeleteGitBranch deletes a local branch. Unmerged branches are only
+// deleted with force.
+func (s *Service) DeleteGitBranch(name string, force bool) error {
+	if name == s.gitSync.CurrentBranch() {
+		return fmt.Errorf("cannot delete the current branch %s", name)
+	}
+	return s.gitSync.DeleteBranch(name, force)
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dpshade/pocket-prompt/internal/models"
+)
+
+func TestDraftsAreCommittedToTheDraftBranch(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	dir := t.TempDir()
+	remote := filepath.Join(t.TempDir(), "remote.git")
+	for _, name := range []string{"GIT_AUTHOR_NAME", "GIT_COMMITTER_NAME"} {
+		t.Setenv(name, "Test")
+	}
+	for _, name := range []string{"GIT_AUTHOR_EMAIL", "GIT_COMMITTER_EMAIL"} {
+		t.Setenv(name, "test@example.com")
+	}
+	git := func(args ...string) string {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %s: %v\n%s", strings.Join(args, " "), err, output)
+		}
+		return strings.TrimSpace(string(output))
+	}
+	git("init", "-q", "--bare", remote)
+	git("init", "-q")
+	git("commit", "-q", "--allow-empty", "-m", "Initial commit")
+	git("remote", "add", "origin", remote)
+	git("push", "-q", "-u", "origin", "HEAD")
+
+	t.Setenv("POCKET_PROMPT_DIR", dir)
+	service, err := NewService()
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+	service.gitSync.Initialize()
+
+	if err := service.CreatePrompt(&models.Prompt{ID: "live", Name: "Live", Content: "x"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := service.CreatePrompt(&models.Prompt{ID: "wip", Name: "WIP", Content: "x", Draft: true}); err != nil {
+		t.Fatal(err)
+	}
+	if err := service.SyncChanges("Sync everything"); err != nil {
+		t.Fatal(err)
+	}
+
+	if files := git("ls-tree", "-r", "--name-only", "HEAD", "prompts"); files != "prompts/live.md" {
+		t.Errorf("Expected only the published prompt on the current branch, got %q", files)
+	}
+	if files := git("ls-tree", "-r", "--name-only", "drafts", "prompts"); files != "prompts/live.md\nprompts/wip.md" {
+		t.Errorf("Expected the draft on top of the current branch, got %q", files)
+	}
+
+	published, err := service.PublishDrafts(nil)
+	if err != nil {
+		t.Fatalf("PublishDrafts failed: %v", err)
+	}
+	if len(published) != 1 || published[0].Draft {
+		t.Fatalf("Expected wip to be published, got %v", published)
+	}
+	if parents := strings.Fields(git("log", "-1", "--format=%P")); len(parents) != 2 {
+		t.Errorf("Expected publishing to merge the drafts branch, got parents %v", parents)
+	}
+	if git("rev-parse", "drafts") != git("rev-parse", "HEAD") {
+		t.Error("Expected the drafts branch to start over from the merge")
+	}
+	if content := git("show", "HEAD:prompts/wip.md"); strings.Contains(content, "draft: true") {
+		t.Errorf("Expected the published file to drop the draft mark:\n%s", content)
+	}
+	if drafts, _ := service.ListDrafts(); len(drafts) != 0 {
+		t.Errorf("Expected no drafts left, got %v", drafts)
+	}
+}
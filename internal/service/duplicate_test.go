@@ -0,0 +1,58 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/dpshade/pocket-prompt/internal/models"
+)
+
+func TestDuplicatePrompt(t *testing.T) {
+	t.Setenv("POCKET_PROMPT_DIR", t.TempDir())
+
+	service, err := NewService()
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+	original := &models.Prompt{
+		ID:       "team/launch",
+		Version:  "2.3.0",
+		Name:     "Launch",
+		Content:  "Announce {{product}}",
+		Tags:     []string{"marketing"},
+		Metadata: map[string]interface{}{"owner": "ana"},
+	}
+	if err := service.SavePrompt(original); err != nil {
+		t.Fatalf("Failed to save prompt: %v", err)
+	}
+
+	duplicate, err := service.DuplicatePrompt("launch", "")
+	if err != nil {
+		t.Fatalf("DuplicatePrompt failed: %v", err)
+	}
+	if duplicate.ID != "team/launch-copy" || duplicate.Version != "1.0.0" || duplicate.Name != "Launch (copy)" {
+		t.Errorf("Unexpected duplicate: %+v", duplicate)
+	}
+	if duplicate.Metadata["copied_from"] != "team/launch" || duplicate.Metadata["copied_from_version"] != "2.3.0" || duplicate.Metadata["owner"] != "ana" {
+		t.Errorf("Unexpected metadata: %v", duplicate.Metadata)
+	}
+
+	saved, err := service.GetPrompt("team/launch-copy")
+	if err != nil {
+		t.Fatalf("Expected the copy to be saved: %v", err)
+	}
+	if saved.Content != original.Content || len(saved.Tags) != 1 {
+		t.Errorf("Expected content and tags to be copied, got %+v", saved)
+	}
+
+	second, err := service.DuplicatePrompt("team/launch", "")
+	if err != nil {
+		t.Fatalf("DuplicatePrompt failed: %v", err)
+	}
+	if second.ID != "team/launch-copy-2" {
+		t.Errorf("Expected a numbered copy, got %s", second.ID)
+	}
+
+	if _, err := service.DuplicatePrompt("team/launch", "team/launch-copy"); err == nil {
+		t.Error("Expected an error when the new ID is taken")
+	}
+}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dpshade/pocket-prompt/internal/models"
+	"github.com/dpshade/pocket-prompt/internal/renderer"
+)
+
+func TestUpdatePromptFromSource(t *testing.T) {
+	t.Setenv("POCKET_PROMPT_DIR", t.TempDir())
+
+	service, err := NewService()
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+	if err := service.CreatePrompt(&models.Prompt{ID: "team/hello", Version: "1.0.0", Name: "Hello", Content: "Hi"}); err != nil {
+		t.Fatalf("Failed to create prompt: %v", err)
+	}
+	prompt, err := service.GetPrompt("team/hello")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	source, err := service.PromptSource(prompt)
+	if err != nil {
+		t.Fatalf("PromptSource failed: %v", err)
+	}
+
+	unchanged, err := service.UpdatePromptFromSource(prompt, source)
+	if err != nil || unchanged != nil {
+		t.Fatalf("Expected no update for an unchanged file, got %v, %v", unchanged, err)
+	}
+
+	if _, err := service.UpdatePromptFromSource(prompt, []byte("---\ntitle: [oops\n---\nHi\n")); err == nil {
+		t.Error("Expected invalid frontmatter to be rejected")
+	}
+
+	edited := strings.Replace(string(source), "\nHi", "\nHi there", 1)
+	updated, err := service.UpdatePromptFromSource(prompt, []byte(edited))
+	if err != nil {
+		t.Fatalf("UpdatePromptFromSource failed: %v", err)
+	}
+	if updated == nil || updated.ID != "team/hello" || updated.Version != "1.0.1" {
+		t.Fatalf("Unexpected update: %+v", updated)
+	}
+
+	saved, err := service.GetPrompt("team/hello")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.TrimSpace(saved.Content) != "Hi there" {
+		t.Errorf("Expected edited content, got %q", saved.Content)
+	}
+	archived, err := service.ListArchivedPrompts()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(archived) != 1 || archived[0].Version != "1.0.0" {
+		t.Errorf("Expected the old version to be archived, got %d archived prompts", len(archived))
+	}
+}
+
+func TestPromptNotes(t *testing.T) {
+	t.Setenv("POCKET_PROMPT_DIR", t.TempDir())
+
+	service, err := NewService()
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+	notes := "Works best with long inputs.\nv1.1: shorter output"
+	if err := service.CreatePrompt(&models.Prompt{ID: "noted", Version: "1.0.0", Name: "Noted", Notes: notes, Content: "Summarize this"}); err != nil {
+		t.Fatalf("Failed to create prompt: %v", err)
+	}
+
+	prompt, err := service.GetPrompt("noted")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if prompt.Notes != notes {
+		t.Errorf("Expected notes to be saved, got %q", prompt.Notes)
+	}
+	rendered, err := renderer.NewRenderer(prompt, nil).RenderText(nil)
+	if err != nil {
+		t.Fatalf("Failed to render prompt: %v", err)
+	}
+	if strings.Contains(rendered, "long inputs") {
+		t.Errorf("Expected notes to be left out of the rendered prompt, got %q", rendered)
+	}
+
+	// Editors that don't show the notes keep them
+	edited := *prompt
+	edited.Notes = ""
+	edited.Content = "Summarize this briefly"
+	if err := service.SavePrompt(&edited); err != nil {
+		t.Fatalf("Failed to save prompt: %v", err)
+	}
+	if saved, _ := service.GetPrompt("noted"); saved.Notes != notes {
+		t.Errorf("Expected notes to survive an edit, got %q", saved.Notes)
+	}
+}
@@ -0,0 +1,51 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// maxGeneratedIDLength keeps IDs made from titles, which name the prompt
+// files, short. It counts characters.
+const maxGeneratedIDLength = 50
+
+// idSlugRe matches the runs of characters a generated ID replaces with a
+// hyphen
+var idSlugRe = regexp.MustCompile(`[^\p{L}\p{N}]+`)
+
+// SlugifyTitle turns a title into a file-safe ID of lowercase letters and
+// digits joined by hyphens, or "untitled-prompt" when nothing is left.
+// Letters outside ASCII are kept.
+func SlugifyTitle(title string) string {
+	id := strings.Trim(idSlugRe.ReplaceAllString(strings.ToLower(title), "-"), "-")
+	if runes := []rune(id); len(runes) > maxGeneratedIDLength {
+		id = strings.TrimRight(string(runes[:maxGeneratedIDLength]), "-")
+	}
+	if id == "" {
+		return "untitled-prompt"
+	}
+	return id
+}
+
+// GeneratePromptID returns an ID for a new prompt made from its title,
+// adding -2, -3 and so on when a prompt already uses it
+func (s *Service) GeneratePromptID(title string) string {
+	base := SlugifyTitle(title)
+	id := base
+	for n := 2; s.promptIDTaken(id); n++ {
+		id = fmt.Sprintf("%s-%d", base, n)
+	}
+	return id
+}
+
+// promptIDTaken reports whether a prompt has an ID, or its file exists
+func (s *Service) promptIDTaken(id string) bool {
+	if _, err := s.findPrompt(id, false); err == nil {
+		return true
+	}
+	_, err := os.Stat(filepath.Join(s.storage.GetBaseDir(), "prompts", id+".md"))
+	return err == nil
+}
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dpshade/pocket-prompt/internal/models"
+)
+
+func TestSlugifyTitle(t *testing.T) {
+	tests := map[string]string{
+		"Code Review!":            "code-review",
+		"  Draft: v2 / notes  ":   "draft-v2-notes",
+		"Résumé Écrit":            "résumé-écrit",
+		"!!!":                     "untitled-prompt",
+		"":                        "untitled-prompt",
+		strings.Repeat("ab ", 20): strings.Repeat("ab-", 16) + "ab",
+	}
+	for title, want := range tests {
+		if got := SlugifyTitle(title); got != want {
+			t.Errorf("SlugifyTitle(%q) = %q, want %q", title, got, want)
+		}
+	}
+}
+
+func TestCreatePromptGeneratesID(t *testing.T) {
+	t.Setenv("POCKET_PROMPT_DIR", t.TempDir())
+	service, err := NewService()
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+
+	var ids []string
+	for _, content := range []string{"first", "second", "third"} {
+		prompt := &models.Prompt{Name: "Code Review", Content: content}
+		if err := service.CreatePrompt(prompt); err != nil {
+			t.Fatalf("Failed to create prompt: %v", err)
+		}
+		ids = append(ids, prompt.ID)
+	}
+	if strings.Join(ids, " ") != "code-review code-review-2 code-review-3" {
+		t.Errorf("Expected suffixed IDs on collision, got %v", ids)
+	}
+
+	// A new prompt from the TUI goes through SavePrompt and must not
+	// replace the one that has its title's ID
+	prompt := &models.Prompt{Name: "Code Review", Content: "fourth"}
+	if err := service.SavePrompt(prompt); err != nil {
+		t.Fatalf("Failed to save prompt: %v", err)
+	}
+	first, err := service.GetPrompt("code-review")
+	if err != nil || first.Content != "first" || prompt.ID != "code-review-4" {
+		t.Errorf("Expected code-review-4 beside the untouched original, got %s", prompt.ID)
+	}
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/dpshade/pocket-prompt/internal/importer"
+	"github.com/dpshade/pocket-prompt/internal/models"
+)
+
+func TestImportFromLangChain(t *testing.T) {
+	t.Setenv("POCKET_PROMPT_DIR", t.TempDir())
+	dir := t.TempDir()
+
+	files := map[string]string{
+		"summarize.yaml": "_type: prompt\ninput_variables: [text, words]\ntemplate: |\n  Summarize {text} in {words:d} words.\n  Reply as JSON: {{\"summary\": ...}}\n",
+		"greet.json":     `{"_type": "prompt", "input_variables": ["name"], "template": "Hello {{ name }}!", "template_format": "jinja2"}`,
+		"hub.json":       `{"lc": 1, "type": "constructor", "id": ["langchain", "prompts", "prompt", "PromptTemplate"], "kwargs": {"input_variables": ["q"], "template": "Answer: {q}", "template_format": "f-string"}}`,
+		"chat.json":      `{"lc": 1, "type": "constructor", "id": ["langchain", "prompts", "chat", "ChatPromptTemplate"], "kwargs": {}}`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	service, err := NewService()
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+
+	result, err := service.ImportFromLangChain(importer.ImportOptions{Path: dir})
+	if err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	if len(result.Prompts) != 3 || len(result.Errors) != 1 {
+		t.Fatalf("Expected 3 prompts and the chat template rejected, got %d prompts, errors %v", len(result.Prompts), result.Errors)
+	}
+
+	want := map[string]string{
+		"summarize": "Summarize {{text}} in {{words}} words.\nReply as JSON: {\"summary\": ...}",
+		"greet":     "Hello {{name}}!",
+		"hub":       "Answer: {{q}}",
+	}
+	for id, content := range want {
+		prompt, err := service.GetPrompt(id)
+		if err != nil {
+			t.Fatalf("Expected prompt %s: %v", id, err)
+		}
+		if strings.TrimSpace(prompt.Content) != content {
+			t.Errorf("%s: expected content %q, got %q", id, content, prompt.Content)
+		}
+	}
+}
+
+func TestLangChainRoundTrip(t *testing.T) {
+	prompt := &models.Prompt{
+		ID:      "team/review",
+		Name:    "Review",
+		Content: "Review {{code}} in {{.language}}. Output {\"ok\": true} for {{code}}.",
+		Tags:    []string{"coding"},
+	}
+
+	lc := importer.ToLangChainPrompt(prompt)
+	if lc.Template != "Review {code} in {language}. Output {{\"ok\": true}} for {code}." {
+		t.Errorf("Unexpected f-string template: %q", lc.Template)
+	}
+	if !reflect.DeepEqual(lc.InputVariables, []string{"code", "language"}) {
+		t.Errorf("Unexpected input variables: %v", lc.InputVariables)
+	}
+
+	for _, format := range []string{"yaml", "json"} {
+		data, err := importer.MarshalLangChainPrompt(lc, format)
+		if err != nil {
+			t.Fatalf("Failed to marshal %s: %v", format, err)
+		}
+		parsed, err := importer.ParseLangChainPrompt(data)
+		if err != nil {
+			t.Fatalf("Failed to parse %s:\n%s\n%v", format, data, err)
+		}
+		content, err := importer.FromLangChainTemplate(parsed.Template, parsed.TemplateFormat)
+		if err != nil {
+			t.Fatalf("Failed to convert %s: %v", format, err)
+		}
+		if content != "Review {{code}} in {{language}}. Output {\"ok\": true} for {{code}}." {
+			t.Errorf("%s: unexpected round-trip content %q", format, content)
+		}
+		if parsed.Metadata["id"] != "team/review" {
+			t.Errorf("%s: expected the prompt ID kept in metadata, got %v", format, parsed.Metadata)
+		}
+	}
+}
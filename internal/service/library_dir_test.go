@@ -0,0 +1,24 @@
+package service
+
+import "testing"
+
+func TestNewServiceAtOverridesEnvironment(t *testing.T) {
+	envDir, dir := t.TempDir(), t.TempDir()
+	t.Setenv("POCKET_PROMPT_DIR", envDir)
+
+	service, err := NewServiceAt(dir)
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+	if got := service.LibraryDir(); got != dir {
+		t.Errorf("Expected the library in %s, got %s", dir, got)
+	}
+
+	service, err = NewServiceAt("")
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+	if got := service.LibraryDir(); got != envDir {
+		t.Errorf("Expected the library from POCKET_PROMPT_DIR in %s, got %s", envDir, got)
+	}
+}
@@ -0,0 +1,217 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/dpshade/pocket-prompt/internal/models"
+	"github.com/dpshade/pocket-prompt/internal/storage"
+)
+
+// Lint severities. Errors break a prompt or template; warnings are worth a
+// look but don't stop it rendering.
+const (
+	LintError   = "error"
+	LintWarning = "warning"
+)
+
+// LintIssue is a problem Lint found in a prompt or template file
+type LintIssue struct {
+	FilePath string `json:"file"`
+	Line     int    `json:"line,omitempty"`
+	ID       string `json:"id,omitempty"`
+	Rule     string `json:"rule"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+}
+
+// String formats the issue as path:line: severity: message (rule)
+func (i LintIssue) String() string {
+	location := i.FilePath
+	if i.Line > 0 {
+		location = fmt.Sprintf("%s:%d", location, i.Line)
+	}
+	return fmt.Sprintf("%s: %s: %s (%s)", location, i.Severity, i.Message, i.Rule)
+}
+
+// versionRe matches the major.minor.patch versions saving bumps
+var versionRe = regexp.MustCompile(`^\d+\.\d+\.\d+$`)
+
+// variableRe matches the {{name}}, {{.name}} and ${name} placeholders
+// rendering fills
+var variableRe = regexp.MustCompile(`\{\{-?\s*\.?([A-Za-z_][A-Za-z0-9_]*)\s*-?\}\}|\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// templateKeywords are Go template actions the variable pattern also matches
+var templateKeywords = map[string]bool{"end": true, "else": true, "break": true, "continue": true}
+
+// Lint checks every active prompt and template for problems: files that
+// don't load, missing titles, empty content, invalid versions, duplicate
+// IDs, missing attachments and snippets, unknown templates, unfilled or unknown slots,
+// and variables a template doesn't declare. Issues are sorted by file.
+func (s *Service) Lint() ([]LintIssue, error) {
+	if err := s.loadPrompts(); err != nil {
+		return nil, fmt.Errorf("failed to load prompts: %w", err)
+	}
+	prompts, err := s.ListPrompts()
+	if err != nil {
+		return nil, err
+	}
+	if prompts, err = s.WithContent(prompts); err != nil {
+		return nil, err
+	}
+	templates, err := s.ListTemplates()
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("failed to list templates: %w", err)
+	}
+	snippets, err := s.ListSnippets()
+	if err != nil {
+		return nil, err
+	}
+	snippetNames := make(map[string]bool, len(snippets))
+	for _, snippet := range snippets {
+		snippetNames[snippet.Name] = true
+	}
+
+	var issues []LintIssue
+	for _, issue := range s.ValidationIssues() {
+		issues = append(issues, LintIssue{FilePath: issue.FilePath, Line: issue.Line, Rule: "invalid-file",
+			Severity: LintError, Message: strings.TrimPrefix(issue.Field+": "+issue.Message, ": ")})
+	}
+	for _, snippet := range snippets {
+		for _, name := range models.SnippetRefs(snippet.Content) {
+			if !snippetNames[name] {
+				issues = append(issues, LintIssue{FilePath: snippet.FilePath, Rule: "missing-snippet",
+					Severity: LintError, Message: fmt.Sprintf("snippet %s not found in %s/", name, storage.SnippetsDir)})
+			}
+		}
+	}
+
+	byID := make(map[string]*models.Template, len(templates))
+	seen := make(map[string]string)
+	for _, template := range templates {
+		issue := func(rule, severity, format string, args ...interface{}) {
+			issues = append(issues, LintIssue{FilePath: template.FilePath, ID: template.ID, Rule: rule,
+				Severity: severity, Message: fmt.Sprintf(format, args...)})
+		}
+		if first, ok := seen[template.ID]; ok {
+			issue("duplicate-id", LintError, "template ID %s is also used by %s", template.ID, first)
+			continue
+		}
+		seen[template.ID] = template.FilePath
+		byID[template.ID] = template
+
+		if strings.TrimSpace(template.Name) == "" {
+			issue("missing-title", LintWarning, "template has no name")
+		}
+		if strings.TrimSpace(template.Content) == "" {
+			issue("empty-content", LintError, "template has no content")
+		}
+		if !versionRe.MatchString(template.Version) {
+			issue("invalid-version", LintError, "version %q is not major.minor.patch", template.Version)
+		}
+
+		declared := map[string]bool{contentSlot: true}
+		for _, slot := range template.Slots {
+			declared[slot.Name] = true
+		}
+		for _, name := range contentVariables(template.Content) {
+			if !declared[name] {
+				issue("undeclared-variable", LintWarning, "variable %s is not a declared slot", name)
+			}
+		}
+		for _, name := range models.SnippetRefs(template.Content) {
+			if !snippetNames[name] {
+				issue("missing-snippet", LintError, "snippet %s not found in %s/", name, storage.SnippetsDir)
+			}
+		}
+	}
+
+	seen = make(map[string]string)
+	for _, prompt := range prompts {
+		issue := func(rule, severity, format string, args ...interface{}) {
+			issues = append(issues, LintIssue{FilePath: prompt.FilePath, ID: prompt.ID, Rule: rule,
+				Severity: severity, Message: fmt.Sprintf(format, args...)})
+		}
+		if first, ok := seen[prompt.ID]; ok {
+			issue("duplicate-id", LintError, "prompt ID %s is also used by %s", prompt.ID, first)
+			continue
+		}
+		seen[prompt.ID] = prompt.FilePath
+
+		if strings.TrimSpace(prompt.Name) == "" {
+			issue("missing-title", LintWarning, "prompt has no title")
+		}
+		// The body of an encrypted prompt can't be checked without its key
+		if !prompt.Encrypted && strings.TrimSpace(prompt.Content) == "" {
+			issue("empty-content", LintError, "prompt has no content")
+		}
+		if !versionRe.MatchString(prompt.Version) {
+			issue("invalid-version", LintError, "version %q is not major.minor.patch", prompt.Version)
+		}
+		for _, name := range PromptAttachments(prompt) {
+			if _, err := s.storage.LoadAttachment(name); err != nil {
+				issue("missing-attachment", LintError, "attachment %s not found in %s/", name, storage.AttachmentsDir)
+			}
+		}
+		for _, name := range models.SnippetRefs(prompt.Content) {
+			if !snippetNames[name] {
+				issue("missing-snippet", LintError, "snippet %s not found in %s/", name, storage.SnippetsDir)
+			}
+		}
+
+		if prompt.TemplateRef == "" {
+			continue
+		}
+		template, ok := byID[prompt.TemplateRef]
+		if !ok {
+			issue("unknown-template", LintError, "template %s not found", prompt.TemplateRef)
+			continue
+		}
+		warnings, err := checkSlots(prompt, template)
+		var slotErr *SlotError
+		if errors.As(err, &slotErr) {
+			issue("missing-slot", LintError, "required slots of template %s have no value: %s",
+				template.ID, strings.Join(slotErr.Missing, ", "))
+		}
+		for _, warning := range warnings {
+			issue("unknown-slot", LintWarning, "%s", warning)
+		}
+		if !prompt.Encrypted {
+			declared := make(map[string]bool, len(template.Slots))
+			for _, slot := range template.Slots {
+				declared[slot.Name] = true
+			}
+			for _, name := range contentVariables(prompt.Content) {
+				if !declared[name] {
+					issue("undeclared-variable", LintWarning, "variable %s is not a slot of template %s", name, template.ID)
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool {
+		if issues[i].FilePath != issues[j].FilePath {
+			return issues[i].FilePath < issues[j].FilePath
+		}
+		return issues[i].Line < issues[j].Line
+	})
+	return issues, nil
+}
+
+// contentVariables returns the variables content uses, in order of first use
+func contentVariables(content string) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, match := range variableRe.FindAllStringSubmatch(content, -1) {
+		name := match[1] + match[2]
+		if !seen[name] && !templateKeywords[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,62 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLint(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("POCKET_PROMPT_DIR", dir)
+	service, err := NewService()
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+
+	files := map[string]string{
+		"templates/review.md": "---\nid: review\nversion: 1.0.0\nname: Review\nslots:\n  - name: language\n    required: true\n---\nReview {{.language}} for {{.focus}}:\n{{.content}}\n",
+		"prompts/good.md":     "---\nid: good\nversion: 1.0.0\ntitle: Good\ntemplate: review\nslots:\n  language: Go\n---\nfunc main() {}\n",
+		"prompts/a.md":        "---\nid: a\nversion: \"1.0\"\ntemplate: review\n---\nHello {{.name}}\n",
+		"prompts/b.md":        "---\nid: a\nversion: 1.0.0\ntitle: B\n---\nbody\n",
+		"prompts/c.md":        "---\nid: c\nversion: 1.0.0\ntitle: C\ntemplate: missing\n---\n\n",
+		"prompts/d.md":        "---\nid: [d\n---\nbody\n",
+	}
+	for path, content := range files {
+		full := filepath.Join(dir, path)
+		if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(full, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	issues, err := service.Lint()
+	if err != nil {
+		t.Fatalf("Failed to lint: %v", err)
+	}
+	found := make(map[string]string)
+	for _, issue := range issues {
+		found[filepath.ToSlash(issue.FilePath)+" "+issue.Rule] = issue.Severity
+	}
+	expected := map[string]string{
+		"prompts/a.md missing-title":              LintWarning,
+		"prompts/a.md invalid-version":            LintError,
+		"prompts/a.md missing-slot":               LintError,
+		"prompts/a.md undeclared-variable":        LintWarning,
+		"prompts/b.md duplicate-id":               LintError,
+		"prompts/c.md empty-content":              LintError,
+		"prompts/c.md unknown-template":           LintError,
+		"prompts/d.md invalid-file":               LintError,
+		"templates/review.md undeclared-variable": LintWarning,
+	}
+	for key, severity := range expected {
+		if found[key] != severity {
+			t.Errorf("Expected %s %s, got %q", key, severity, found[key])
+		}
+	}
+	if len(issues) != len(expected) {
+		t.Errorf("Expected %d issues, got %d: %v", len(expected), len(issues), issues)
+	}
+}
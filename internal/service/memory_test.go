@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dpshade/pocket-prompt/internal/models"
+	"github.com/dpshade/pocket-prompt/internal/storage"
+)
+
+func TestServiceWithMemoryBackend(t *testing.T) {
+	svc := NewServiceWithBackend(storage.NewMemoryStorage(""))
+
+	prompt := &models.Prompt{ID: "review", Name: "Code Review", Version: "1.0.0", Tags: []string{"code"}, Content: "Review this"}
+	if err := svc.CreatePrompt(prompt); err != nil {
+		t.Fatalf("Failed to create prompt: %v", err)
+	}
+	prompt.Content = "Review this carefully"
+	if err := svc.UpdatePrompt(prompt); err != nil {
+		t.Fatalf("Failed to update prompt: %v", err)
+	}
+
+	got, err := svc.GetPrompt("review")
+	if err != nil {
+		t.Fatalf("Failed to get prompt: %v", err)
+	}
+	if got.Version != "1.0.1" {
+		t.Errorf("Expected the updated version, got %s", got.Version)
+	}
+	results, err := svc.SearchPrompts("review")
+	if err != nil || len(results) != 1 {
+		t.Errorf("Expected the prompt found, got %v, %v", results, err)
+	}
+	archived, err := svc.ListPromptsInScope(ArchivedOnly)
+	if err != nil || len(archived) != 1 || archived[0].Version != "1.0.0" {
+		t.Errorf("Expected the old version archived, got %v, %v", archived, err)
+	}
+
+	if err := svc.SetSecret("api_key", "secret"); err != nil {
+		t.Fatalf("Failed to set secret: %v", err)
+	}
+	if value, err := svc.LoadSecret(context.Background(), "api_key"); err != nil || value != "secret" {
+		t.Errorf("Expected the secret back, got %q, %v", value, err)
+	}
+
+	if err := svc.SaveBooleanSearch(models.SavedSearch{Name: "code", Expression: models.NewTagExpression("code")}); err != nil {
+		t.Fatalf("Failed to save search: %v", err)
+	}
+	searches, err := svc.ListSavedSearches()
+	if err != nil || len(searches) != 1 || searches[0].Name != "code" {
+		t.Errorf("Expected the saved search back, got %v, %v", searches, err)
+	}
+
+	if err := svc.RecordUsage("review"); err != nil {
+		t.Fatalf("Failed to record usage: %v", err)
+	}
+	usage, err := svc.GetUsage()
+	if err != nil || usage["review"].Count != 1 {
+		t.Errorf("Expected one use recorded, got %v, %v", usage, err)
+	}
+}
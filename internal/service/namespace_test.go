@@ -0,0 +1,72 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dpshade/pocket-prompt/internal/models"
+)
+
+func TestNamespacedPrompts(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("POCKET_PROMPT_DIR", tmpDir)
+
+	service, err := NewService()
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+
+	for _, id := range []string{"team/marketing/launch", "team/sales/launch", "team/sales/followup"} {
+		if err := service.SavePrompt(&models.Prompt{ID: id, Name: id, Content: "body"}); err != nil {
+			t.Fatalf("Failed to save %s: %v", id, err)
+		}
+	}
+
+	// Files land in subdirectories and keep only the local ID in frontmatter
+	data, err := os.ReadFile(filepath.Join(tmpDir, "prompts", "team", "marketing", "launch.md"))
+	if err != nil {
+		t.Fatalf("Expected prompt in a namespace directory: %v", err)
+	}
+	if !strings.Contains(string(data), "id: launch\n") {
+		t.Errorf("Expected local ID in frontmatter:\n%s", data)
+	}
+
+	// Reload from disk to check IDs are derived from the directory
+	service, err = NewService()
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+
+	prompt, err := service.GetPrompt("team/marketing/launch")
+	if err != nil || prompt.ID != "team/marketing/launch" {
+		t.Fatalf("Expected full ID lookup to work, got %v, %v", prompt, err)
+	}
+
+	prompt, err = service.GetPrompt("followup")
+	if err != nil || prompt.ID != "team/sales/followup" {
+		t.Fatalf("Expected unambiguous short ID to resolve, got %v, %v", prompt, err)
+	}
+
+	prompt, err = service.GetPrompt("sales/launch")
+	if err != nil || prompt.ID != "team/sales/launch" {
+		t.Fatalf("Expected partial namespace to resolve, got %v, %v", prompt, err)
+	}
+
+	if _, err := service.GetPrompt("launch"); err == nil || !strings.Contains(err.Error(), "ambiguous") {
+		t.Errorf("Expected ambiguous short ID error, got %v", err)
+	}
+
+	// Saving a new top-level prompt must not be mistaken for a short ID match
+	if err := service.SavePrompt(&models.Prompt{ID: "launch", Name: "Top level", Content: "body"}); err != nil {
+		t.Fatalf("Failed to save top-level prompt: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "prompts", "launch.md")); err != nil {
+		t.Errorf("Expected a new top-level file: %v", err)
+	}
+
+	if err := service.CreatePrompt(&models.Prompt{ID: "../escape"}); err == nil {
+		t.Error("Expected IDs escaping the prompts directory to be rejected")
+	}
+}
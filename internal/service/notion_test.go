@@ -0,0 +1,76 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dpshade/pocket-prompt/internal/importer"
+)
+
+func TestImportFromNotion(t *testing.T) {
+	t.Setenv("POCKET_PROMPT_DIR", t.TempDir())
+	export := t.TempDir()
+
+	files := map[string]string{
+		"Prompts 0123456789abcdef0123456789abcdef.csv":                                             "\ufeffName,Tags,Description,Model\nCode Review,\"coding, review\",Review a diff,gpt-4\n",
+		"Prompts 0123456789abcdef0123456789abcdef_all.csv":                                         "\ufeffName,Tags,Description,Model,Created\nCode Review,\"coding, review\",Review a diff,gpt-4,\"January 5, 2024 3:04 PM\"\n",
+		"Prompts 0123456789abcdef0123456789abcdef/Code Review fedcba9876543210fedcba9876543210.md": "# Code Review\n\nTags: coding, review\nModel: gpt-4\n\nReview this diff: {{diff}}\n",
+		"Standalone aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa.md":                                           "# Standalone\n\nJust a page.\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(export, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	service, err := NewService()
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+
+	options := importer.NotionOptions{}
+	options.Path = export
+	result, err := service.ImportFromNotion(options)
+	if err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	if len(result.Errors) > 0 {
+		t.Fatalf("Unexpected import errors: %v", result.Errors)
+	}
+	if len(result.Prompts) != 2 {
+		t.Fatalf("Expected 2 pages, got %d", len(result.Prompts))
+	}
+
+	review, err := service.GetPrompt("prompts/code-review")
+	if err != nil {
+		t.Fatalf("Expected Notion IDs stripped from the namespace and name: %v", err)
+	}
+	if review.Content != "Review this diff: {{diff}}" {
+		t.Errorf("Expected title and property block removed from content, got %q", review.Content)
+	}
+	if review.Summary != "Review a diff" {
+		t.Errorf("Expected description from the database row, got %q", review.Summary)
+	}
+	tags := make(map[string]bool)
+	for _, tag := range review.Tags {
+		tags[tag] = true
+	}
+	if !tags["coding"] || !tags["review"] || !tags["notion"] {
+		t.Errorf("Expected tags from the Tags property, got %v", review.Tags)
+	}
+	if review.Metadata["model"] != "gpt-4" || review.Metadata["notion_id"] != "fedcba9876543210fedcba9876543210" {
+		t.Errorf("Expected properties in metadata, got %v", review.Metadata)
+	}
+	if review.CreatedAt.Year() != 2024 {
+		t.Errorf("Expected created date from the _all.csv database, got %v", review.CreatedAt)
+	}
+
+	if _, err := service.GetPrompt("standalone"); err != nil {
+		t.Errorf("Expected standalone page to be imported: %v", err)
+	}
+}
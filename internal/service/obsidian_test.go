@@ -0,0 +1,79 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dpshade/pocket-prompt/internal/importer"
+)
+
+func TestImportFromObsidian(t *testing.T) {
+	t.Setenv("POCKET_PROMPT_DIR", t.TempDir())
+	vault := t.TempDir()
+
+	notes := map[string]string{
+		"Prompts/Blog Outline.md":     "---\ntitle: Blog outline\ndescription: Outline a post\ntags: [prompt, writing]\naliases: [outline]\n---\n\nUse [[Tone Guide|the tone guide]] and [[Missing Note]].\n",
+		"Prompts/Style/Tone Guide.md": "Keep it friendly. #prompt/style\n",
+		"Journal/2024-01-01.md":       "Not a prompt, mentions `#prompt` in code.\n",
+		".obsidian/workspace.md":      "#prompt\n",
+	}
+	for name, content := range notes {
+		path := filepath.Join(vault, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	service, err := NewService()
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+
+	options := importer.ObsidianOptions{Tag: "prompt"}
+	options.Path = vault
+	result, err := service.ImportFromObsidian(options)
+	if err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	if len(result.Errors) > 0 {
+		t.Fatalf("Unexpected import errors: %v", result.Errors)
+	}
+	if len(result.Prompts) != 2 {
+		t.Fatalf("Expected 2 tagged notes, got %d", len(result.Prompts))
+	}
+
+	outline, err := service.GetPrompt("prompts/blog-outline")
+	if err != nil {
+		t.Fatalf("Expected namespaced prompt from vault folder: %v", err)
+	}
+	if outline.Name != "Blog outline" || outline.Summary != "Outline a post" {
+		t.Errorf("Frontmatter not mapped: %+v", outline)
+	}
+	if outline.Metadata["aliases"] == nil {
+		t.Errorf("Expected unmapped fields in metadata: %v", outline.Metadata)
+	}
+	refs, _ := outline.Metadata["references"].([]interface{})
+	if len(refs) != 1 || refs[0] != "prompts/style/tone-guide" {
+		t.Errorf("Expected wikilink resolved to imported prompt, got %v", outline.Metadata["references"])
+	}
+	links, _ := outline.Metadata["wikilinks"].([]interface{})
+	if len(links) != 2 {
+		t.Errorf("Expected both wikilinks recorded, got %v", outline.Metadata["wikilinks"])
+	}
+
+	// Folder selection narrows to the folder, IDs are relative to it
+	options = importer.ObsidianOptions{Folder: "Prompts/Style"}
+	options.Path = vault
+	options.DryRun = true
+	result, err = service.ImportFromObsidian(options)
+	if err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	if len(result.Prompts) != 1 || result.Prompts[0].ID != "tone-guide" {
+		t.Errorf("Expected only the Style folder note, got %v", result.Prompts)
+	}
+}
@@ -0,0 +1,44 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/dpshade/pocket-prompt/internal/models"
+)
+
+func TestSetSavedSearchPinned(t *testing.T) {
+	t.Setenv("POCKET_PROMPT_DIR", t.TempDir())
+
+	service, err := NewService()
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+	for _, name := range []string{"production", "needs-review"} {
+		search := models.SavedSearch{Name: name, Expression: models.NewTagExpression(name)}
+		if err := service.SaveBooleanSearch(search); err != nil {
+			t.Fatalf("Failed to save search: %v", err)
+		}
+	}
+
+	if err := service.SetSavedSearchPinned("needs-review", true); err != nil {
+		t.Fatalf("SetSavedSearchPinned failed: %v", err)
+	}
+	pinned, err := service.PinnedSearches()
+	if err != nil {
+		t.Fatalf("PinnedSearches failed: %v", err)
+	}
+	if len(pinned) != 1 || pinned[0].Name != "needs-review" {
+		t.Fatalf("Expected needs-review to be pinned, got %+v", pinned)
+	}
+
+	if err := service.SetSavedSearchPinned("needs-review", false); err != nil {
+		t.Fatalf("SetSavedSearchPinned failed: %v", err)
+	}
+	if pinned, _ := service.PinnedSearches(); len(pinned) != 0 {
+		t.Errorf("Expected no pinned searches, got %+v", pinned)
+	}
+
+	if err := service.SetSavedSearchPinned("missing", true); err == nil {
+		t.Error("Expected an error for an unknown search")
+	}
+}
@@ -0,0 +1,157 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/dpshade/pocket-prompt/internal/models"
+)
+
+// PluginPrefix starts the file name of every plugin executable
+const PluginPrefix = "pocket-prompt-"
+
+// PluginInputVersion is bumped when PluginInput changes incompatibly
+const PluginInputVersion = 1
+
+// Plugin is an executable named pocket-prompt-<name> that adds <name> as a
+// subcommand
+type Plugin struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+}
+
+// PluginInput is the JSON a plugin reads from stdin: its arguments and the
+// library, with the bodies of the prompts loaded
+type PluginInput struct {
+	Version   int                `json:"version"`
+	Command   string             `json:"command"`
+	Args      []string           `json:"args"`
+	Library   string             `json:"library"`
+	Prompts   []*models.Prompt   `json:"prompts"`
+	Templates []*models.Template `json:"templates"`
+}
+
+// PluginDir returns the plugins directory in the library root
+func (s *Service) PluginDir() string {
+	return filepath.Join(s.storage.GetBaseDir(), "plugins")
+}
+
+// ListPlugins returns the plugins in the plugins directory and on PATH,
+// sorted by name. Where two share a name the one found first is used, the
+// plugins directory coming before PATH.
+func (s *Service) ListPlugins() []Plugin {
+	dirs := append([]string{s.PluginDir()}, filepath.SplitList(os.Getenv("PATH"))...)
+	seen := make(map[string]bool)
+	var plugins []Plugin
+	for _, dir := range dirs {
+		for _, plugin := range pluginsIn(dir) {
+			if !seen[plugin.Name] {
+				seen[plugin.Name] = true
+				plugins = append(plugins, plugin)
+			}
+		}
+	}
+	sort.Slice(plugins, func(i, j int) bool { return plugins[i].Name < plugins[j].Name })
+	return plugins
+}
+
+// FindPlugin returns the plugin providing a subcommand
+func (s *Service) FindPlugin(name string) (Plugin, bool) {
+	for _, plugin := range s.ListPlugins() {
+		if plugin.Name == name {
+			return plugin, true
+		}
+	}
+	return Plugin{}, false
+}
+
+// pluginsIn returns the plugin executables in a directory, which may not
+// exist
+func pluginsIn(dir string) []Plugin {
+	if dir == "" {
+		return nil
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+
+	var plugins []Plugin
+	for _, entry := range entries {
+		name, ok := strings.CutPrefix(entry.Name(), PluginPrefix)
+		if !ok || entry.IsDir() {
+			continue
+		}
+		if runtime.GOOS == "windows" {
+			ext := strings.ToLower(filepath.Ext(name))
+			if ext != ".exe" && ext != ".bat" && ext != ".cmd" {
+				continue
+			}
+			name = strings.TrimSuffix(name, filepath.Ext(name))
+		} else if info, err := os.Stat(filepath.Join(dir, entry.Name())); err != nil || !info.Mode().IsRegular() || info.Mode()&0111 == 0 {
+			continue
+		}
+		if name != "" {
+			plugins = append(plugins, Plugin{Name: name, Path: filepath.Join(dir, entry.Name())})
+		}
+	}
+	return plugins
+}
+
+// RunPlugin runs a plugin with args, writing PluginInput to its stdin. The
+// plugin can call back into pocket-prompt through POCKET_PROMPT_BIN, and
+// finds the library in POCKET_PROMPT_DIR.
+func (s *Service) RunPlugin(plugin Plugin, args []string, stdout, stderr io.Writer) error {
+	prompts, err := s.ListPrompts()
+	if err != nil {
+		return fmt.Errorf("failed to list prompts: %w", err)
+	}
+	if prompts, err = s.WithContent(prompts); err != nil {
+		return fmt.Errorf("failed to load prompts: %w", err)
+	}
+	// A library without a templates directory has no templates
+	templates, err := s.ListTemplates()
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to list templates: %w", err)
+	}
+
+	input, err := json.Marshal(PluginInput{
+		Version:   PluginInputVersion,
+		Command:   plugin.Name,
+		Args:      append([]string{}, args...),
+		Library:   s.storage.GetBaseDir(),
+		Prompts:   prompts,
+		Templates: templates,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode plugin input: %w", err)
+	}
+
+	cmd := exec.Command(plugin.Path, args...)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	cmd.Env = append(os.Environ(), "POCKET_PROMPT_DIR="+s.storage.GetBaseDir())
+	if exe, err := os.Executable(); err == nil {
+		cmd.Env = append(cmd.Env, "POCKET_PROMPT_BIN="+exe)
+	}
+
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return fmt.Errorf("plugin %s exited with status %d", plugin.Name, exitErr.ExitCode())
+		}
+		return fmt.Errorf("failed to run plugin %s: %w", plugin.Name, err)
+	}
+	return nil
+}
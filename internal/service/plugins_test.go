@@ -0,0 +1,75 @@
+package service
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/dpshade/pocket-prompt/internal/models"
+)
+
+func TestRunPlugin(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugins here are shell scripts")
+	}
+	dir := t.TempDir()
+	t.Setenv("POCKET_PROMPT_DIR", dir)
+	service, err := NewService()
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+	if err := service.CreatePrompt(&models.Prompt{ID: "greeting", Name: "Greeting", Content: "Hello there"}); err != nil {
+		t.Fatalf("Failed to create prompt: %v", err)
+	}
+
+	// The plugins directory wins over PATH, and other files are ignored
+	pathDir := t.TempDir()
+	t.Setenv("PATH", pathDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	writeScript := func(dir, name, body string, mode os.FileMode) {
+		t.Helper()
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("#!/bin/sh\n"+body), mode); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeScript(service.PluginDir(), "pocket-prompt-dump", `echo "$1 $POCKET_PROMPT_DIR"; cat`, 0755)
+	writeScript(pathDir, "pocket-prompt-dump", "echo shadowed", 0755)
+	writeScript(pathDir, "pocket-prompt-stats", "exit 2", 0755)
+	writeScript(pathDir, "pocket-prompt-notes", "", 0644)
+
+	plugins := service.ListPlugins()
+	if len(plugins) != 2 || plugins[0].Name != "dump" || plugins[1].Name != "stats" {
+		t.Fatalf("Expected the dump and stats plugins, got %v", plugins)
+	}
+	if filepath.Dir(plugins[0].Path) != service.PluginDir() {
+		t.Errorf("Expected the plugins directory to come first, got %s", plugins[0].Path)
+	}
+
+	var out strings.Builder
+	if err := service.RunPlugin(plugins[0], []string{"--all"}, &out, os.Stderr); err != nil {
+		t.Fatalf("Failed to run plugin: %v", err)
+	}
+	header, body, _ := strings.Cut(out.String(), "\n")
+	if header != "--all "+dir {
+		t.Errorf("Expected the arguments and library, got %q", header)
+	}
+	var input PluginInput
+	if err := json.Unmarshal([]byte(body), &input); err != nil {
+		t.Fatalf("Expected JSON on stdin, got %q: %v", body, err)
+	}
+	if input.Version != PluginInputVersion || input.Command != "dump" || len(input.Args) != 1 || input.Library != dir {
+		t.Errorf("Unexpected plugin input %+v", input)
+	}
+	if len(input.Prompts) != 1 || input.Prompts[0].Content != "Hello there" {
+		t.Errorf("Expected the prompt with its content, got %+v", input.Prompts)
+	}
+
+	if err := service.RunPlugin(plugins[1], nil, &out, os.Stderr); err == nil || !strings.Contains(err.Error(), "status 2") {
+		t.Errorf("Expected the plugin's exit status, got %v", err)
+	}
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"fmt"
+	"log/slog"
+	"maps"
+
+	"github.com/dpshade/pocket-prompt/internal/models"
+)
+
+// SavePreset saves variable values as a named preset of a prompt,
+// replacing any preset of that name. Like a rating, a preset doesn't change
+// the prompt itself, so it is saved in place without a new version.
+func (s *Service) SavePreset(id, name string, values map[string]string) (*models.Prompt, error) {
+	if name == "" {
+		return nil, fmt.Errorf("preset name must not be empty")
+	}
+	if len(values) == 0 {
+		return nil, fmt.Errorf("preset %s has no values: give them with --var name=value", name)
+	}
+	found, err := s.findPrompt(id, true)
+	if err != nil {
+		return nil, err
+	}
+
+	prompt, err := s.storage.LoadPrompt(found.FilePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load prompt %s: %w", id, err)
+	}
+	if maps.Equal(prompt.Presets[name], values) {
+		return prompt, nil
+	}
+	if prompt.Presets == nil {
+		prompt.Presets = make(map[string]map[string]string)
+	}
+	prompt.Presets[name] = maps.Clone(values)
+	if err := s.storage.SavePrompt(prompt); err != nil {
+		return nil, fmt.Errorf("failed to save preset: %w", err)
+	}
+
+	if s.gitSync.IsEnabled() {
+		message := fmt.Sprintf("Save preset %s of prompt %s", name, prompt.ID)
+		if err := s.syncPrompt(prompt, message, prompt.FilePath); err != nil {
+			slog.Warn("Git sync failed after saving preset", "error", err)
+		}
+	}
+
+	if err := s.cachePrompt(prompt); err != nil {
+		return nil, err
+	}
+	return prompt, nil
+}
+
+// clonePresets deep-copies a prompt's presets
+func clonePresets(presets map[string]map[string]string) map[string]map[string]string {
+	if presets == nil {
+		return nil
+	}
+	clone := make(map[string]map[string]string, len(presets))
+	for name, values := range presets {
+		clone[name] = maps.Clone(values)
+	}
+	return clone
+}
@@ -0,0 +1,49 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/dpshade/pocket-prompt/internal/models"
+	"github.com/dpshade/pocket-prompt/internal/renderer"
+)
+
+func TestSavePreset(t *testing.T) {
+	t.Setenv("POCKET_PROMPT_DIR", t.TempDir())
+	service, err := NewService()
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+
+	prompt := &models.Prompt{ID: "report", Name: "Report", Version: "1.0.0",
+		Defaults: map[string]string{"team": "Everyone", "tone": "friendly"},
+		Content:  "Write an update for {{.team}} in a {{.tone}} tone."}
+	if err := service.CreatePrompt(prompt); err != nil {
+		t.Fatalf("Failed to create prompt: %v", err)
+	}
+
+	if _, err := service.SavePreset("report", "sales", nil); err == nil {
+		t.Error("Expected a preset without values to be rejected")
+	}
+	saved, err := service.SavePreset("report", "sales", map[string]string{"team": "Sales"})
+	if err != nil {
+		t.Fatalf("Failed to save preset: %v", err)
+	}
+	if saved.Version != "1.0.0" {
+		t.Errorf("Expected saving a preset to keep the version, got %s", saved.Version)
+	}
+
+	reloaded, err := service.GetPrompt("report")
+	if err != nil {
+		t.Fatalf("Failed to get prompt: %v", err)
+	}
+	r := renderer.NewRenderer(reloaded, nil)
+	text, err := r.RenderText(nil)
+	if err != nil || text != "Write an update for Everyone in a friendly tone." {
+		t.Errorf("Expected the defaults to be used, got %q, %v", text, err)
+	}
+	r.SetPreset("sales")
+	text, err = r.RenderText(map[string]interface{}{"tone": "casual"})
+	if err != nil || text != "Write an update for Sales in a casual tone." {
+		t.Errorf("Expected the preset over the defaults and variables over both, got %q, %v", text, err)
+	}
+}
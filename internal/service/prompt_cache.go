@@ -0,0 +1,43 @@
+package service
+
+import (
+	"slices"
+
+	"github.com/dpshade/pocket-prompt/internal/models"
+)
+
+// cachePrompt puts a prompt just saved into the prompts cache in place of
+// the version it replaces, so saves don't list the whole library again.
+// The cache keeps what a listing would: the frontmatter, without the body.
+func (s *Service) cachePrompt(prompt *models.Prompt) error {
+	if len(s.prompts) == 0 {
+		// Nothing listed yet, so there is nothing to keep current
+		return s.loadPrompts()
+	}
+	cached, err := s.storage.LoadPromptMetadata(prompt.FilePath)
+	if err != nil {
+		return s.loadPrompts()
+	}
+
+	// Callers may hold the cached slice, so it is copied rather than changed
+	prompts := slices.Clone(s.prompts)
+	if i := slices.IndexFunc(prompts, func(p *models.Prompt) bool { return p.FilePath == cached.FilePath }); i >= 0 {
+		prompts[i] = cached
+	} else {
+		// Keep the order of a listing, which is by path
+		i = slices.IndexFunc(prompts, func(p *models.Prompt) bool { return p.FilePath > cached.FilePath })
+		if i < 0 {
+			i = len(prompts)
+		}
+		prompts = slices.Insert(prompts, i, cached)
+	}
+	s.prompts = prompts
+	return nil
+}
+
+// uncachePrompts drops deleted prompts from the prompts cache
+func (s *Service) uncachePrompts(prompts ...*models.Prompt) {
+	s.prompts = slices.DeleteFunc(slices.Clone(s.prompts), func(cached *models.Prompt) bool {
+		return slices.ContainsFunc(prompts, func(p *models.Prompt) bool { return p.FilePath == cached.FilePath })
+	})
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dpshade/pocket-prompt/internal/models"
+)
+
+func TestPromptCacheUpdatesIncrementally(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("POCKET_PROMPT_DIR", dir)
+	service, err := NewService()
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+
+	for _, id := range []string{"alpha", "gamma"} {
+		if err := service.CreatePrompt(&models.Prompt{ID: id, Name: id, Version: "1.0.0", Content: "Body of " + id}); err != nil {
+			t.Fatalf("Failed to create %s: %v", id, err)
+		}
+	}
+	if _, err := service.ListPrompts(); err != nil {
+		t.Fatalf("Failed to list prompts: %v", err)
+	}
+
+	// A file added behind the service's back only shows up on a full reload,
+	// so seeing it would mean a save listed the library again
+	outside := "---\nid: outside\nname: Outside\nversion: 1.0.0\n---\n\nAdded elsewhere\n"
+	if err := os.WriteFile(filepath.Join(dir, "prompts", "outside.md"), []byte(outside), 0644); err != nil {
+		t.Fatalf("Failed to write prompt: %v", err)
+	}
+
+	if err := service.CreatePrompt(&models.Prompt{ID: "beta", Name: "beta", Version: "1.0.0", Content: "Body of beta"}); err != nil {
+		t.Fatalf("Failed to create beta: %v", err)
+	}
+	if err := service.UpdatePrompt(&models.Prompt{ID: "alpha", Name: "Alpha", Content: "New body"}); err != nil {
+		t.Fatalf("Failed to update alpha: %v", err)
+	}
+	if err := service.DeletePrompt("gamma"); err != nil {
+		t.Fatalf("Failed to delete gamma: %v", err)
+	}
+
+	prompts, err := service.ListPrompts()
+	if err != nil {
+		t.Fatalf("Failed to list prompts: %v", err)
+	}
+	var ids []string
+	for _, p := range prompts {
+		ids = append(ids, p.ID)
+		if p.Content != "" {
+			t.Errorf("Expected %s cached without its body", p.ID)
+		}
+	}
+	if len(ids) != 2 || ids[0] != "alpha" || ids[1] != "beta" {
+		t.Fatalf("Expected alpha and beta in path order, got %v", ids)
+	}
+	if prompts[0].Name != "Alpha" || prompts[0].Version != "1.0.1" {
+		t.Errorf("Expected the new version of alpha cached, got %s v%s", prompts[0].Name, prompts[0].Version)
+	}
+
+	alpha, err := service.GetPrompt("alpha")
+	if err != nil || alpha.Content != "New body" {
+		t.Errorf("Expected the new body loaded on demand, got %v", err)
+	}
+
+	if err := service.ReloadPrompts(); err != nil {
+		t.Fatalf("Failed to reload prompts: %v", err)
+	}
+	if prompts, _ := service.ListPrompts(); len(prompts) != 3 {
+		t.Errorf("Expected a reload to find the outside prompt, got %d prompts", len(prompts))
+	}
+}
@@ -0,0 +1,161 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/dpshade/pocket-prompt/internal/models"
+	"github.com/sahilm/fuzzy"
+)
+
+// Search result orders
+const (
+	SortRelevance = "relevance"
+	SortUpdated   = "updated"
+	SortUsage     = "usage"
+)
+
+// recencyHalfLife is the age at which a prompt's recency score halves
+const recencyHalfLife = 30 * 24 * time.Hour
+
+// RecordUsage counts a copy or render of a prompt, for ranking by usage
+func (s *Service) RecordUsage(id string) error {
+	usage, err := s.usage.LoadUsage()
+	if err != nil {
+		return err
+	}
+	entry := usage[id]
+	entry.Count++
+	entry.LastUsed = time.Now()
+	usage[id] = entry
+	return s.usage.SaveUsage(usage)
+}
+
+// GetUsage returns the recorded usage of every prompt, keyed by ID
+func (s *Service) GetUsage() (map[string]models.PromptUsage, error) {
+	return s.usage.LoadUsage()
+}
+
+// SearchSort returns the configured default order of search results
+func (s *Service) SearchSort() string {
+	if s.search.Sort == "" {
+		return SortRelevance
+	}
+	return s.search.Sort
+}
+
+// SortPrompts orders search results: by relevance (left as ranked), by
+// most recently updated, or by most used
+func (s *Service) SortPrompts(prompts []*models.Prompt, by string) error {
+	switch by {
+	case "", SortRelevance:
+		return nil
+	case SortUpdated:
+		sort.SliceStable(prompts, func(i, j int) bool {
+			return prompts[i].UpdatedAt.After(prompts[j].UpdatedAt)
+		})
+		return nil
+	case SortUsage:
+		usage, err := s.GetUsage()
+		if err != nil {
+			return err
+		}
+		sort.SliceStable(prompts, func(i, j int) bool {
+			a, b := usage[prompts[i].ID], usage[prompts[j].ID]
+			if a.Count != b.Count {
+				return a.Count > b.Count
+			}
+			return a.LastUsed.After(b.LastUsed)
+		})
+		return nil
+	default:
+		return fmt.Errorf("unknown sort %q: use relevance, updated or usage", by)
+	}
+}
+
+// fuzzyFind returns the prompts whose search strings match a fuzzy query,
+// best matches first. Negated terms such as -draft or NOT "first draft"
+// exclude prompts whose search string contains them. The configured
+// search weights blend recency and usage into the order.
+func (s *Service) fuzzyFind(query string, prompts []*models.Prompt, searchStrings []string) []*models.Prompt {
+	query, excluded := models.ExtractExcludedTerms(query)
+
+	var indexes []int
+	scores := make(map[int]int)
+	if query == "" {
+		for i := range prompts {
+			indexes = append(indexes, i)
+		}
+	} else {
+		for _, match := range fuzzy.Find(query, searchStrings) {
+			indexes = append(indexes, match.Index)
+			scores[match.Index] = match.Score
+		}
+	}
+
+	var kept []int
+	for _, i := range indexes {
+		if !models.ContainsAnyTerm(searchStrings[i], excluded) {
+			kept = append(kept, i)
+		}
+	}
+	s.rankIndexes(kept, prompts, scores)
+
+	results := make([]*models.Prompt, 0, len(kept))
+	for _, i := range kept {
+		results = append(results, prompts[i])
+	}
+	return results
+}
+
+// rankIndexes reorders fuzzy matches by the weighted sum of their match
+// score, recency and usage, each scaled to 0..1. Without recency or usage
+// weights the fuzzy order is kept.
+func (s *Service) rankIndexes(indexes []int, prompts []*models.Prompt, scores map[int]int) {
+	weights := s.search.Weights
+	if weights.Recency == 0 && weights.Usage == 0 {
+		return
+	}
+
+	var usage map[string]models.PromptUsage
+	if weights.Usage != 0 {
+		// Rank without usage rather than fail the search
+		usage, _ = s.GetUsage()
+	}
+
+	minScore, maxScore, maxCount := 0, 0, 0
+	for n, i := range indexes {
+		if n == 0 || scores[i] < minScore {
+			minScore = scores[i]
+		}
+		if n == 0 || scores[i] > maxScore {
+			maxScore = scores[i]
+		}
+		if count := usage[prompts[i].ID].Count; count > maxCount {
+			maxCount = count
+		}
+	}
+
+	now := time.Now()
+	rank := make(map[int]float64, len(indexes))
+	for _, i := range indexes {
+		relevance := 1.0
+		if maxScore > minScore {
+			relevance = float64(scores[i]-minScore) / float64(maxScore-minScore)
+		}
+		var recency float64
+		if updated := prompts[i].UpdatedAt; !updated.IsZero() {
+			recency = 1 / (1 + float64(now.Sub(updated))/float64(recencyHalfLife))
+		}
+		var used float64
+		if maxCount > 0 {
+			used = float64(usage[prompts[i].ID].Count) / float64(maxCount)
+		}
+		rank[i] = weights.Relevance*relevance + weights.Recency*recency + weights.Usage*used
+	}
+
+	sort.SliceStable(indexes, func(a, b int) bool {
+		return rank[indexes[a]] > rank[indexes[b]]
+	})
+}
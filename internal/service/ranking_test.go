@@ -0,0 +1,81 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dpshade/pocket-prompt/internal/models"
+)
+
+func TestSortPromptsByUsage(t *testing.T) {
+	t.Setenv("POCKET_PROMPT_DIR", t.TempDir())
+
+	service, err := NewService()
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+	for _, id := range []string{"alpha", "beta", "gamma"} {
+		if err := service.CreatePrompt(&models.Prompt{ID: id, Name: id, Content: "x"}); err != nil {
+			t.Fatalf("Failed to create prompt: %v", err)
+		}
+	}
+	for _, id := range []string{"gamma", "gamma", "beta"} {
+		if err := service.RecordUsage(id); err != nil {
+			t.Fatalf("RecordUsage failed: %v", err)
+		}
+	}
+
+	prompts, err := service.SearchPrompts("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := service.SortPrompts(prompts, SortUsage); err != nil {
+		t.Fatalf("SortPrompts failed: %v", err)
+	}
+	if prompts[0].ID != "gamma" || prompts[1].ID != "beta" || prompts[2].ID != "alpha" {
+		t.Errorf("Unexpected usage order: %s, %s, %s", prompts[0].ID, prompts[1].ID, prompts[2].ID)
+	}
+
+	if err := service.SortPrompts(prompts, "popularity"); err == nil {
+		t.Error("Expected an unknown sort to be rejected")
+	}
+}
+
+func TestSearchWeightsFavorUsage(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("POCKET_PROMPT_DIR", dir)
+	config := "search:\n  weights:\n    relevance: 1\n    usage: 2\n"
+	if err := os.WriteFile(filepath.Join(dir, "config.yaml"), []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	service, err := NewService()
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+	for _, id := range []string{"review", "code-review-checklist"} {
+		if err := service.CreatePrompt(&models.Prompt{ID: id, Name: id, Content: "x"}); err != nil {
+			t.Fatalf("Failed to create prompt: %v", err)
+		}
+	}
+
+	results, err := service.SearchPrompts("review")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 2 || results[0].ID != "review" {
+		t.Fatalf("Expected the closest match first, got %v", results)
+	}
+
+	if err := service.RecordUsage("code-review-checklist"); err != nil {
+		t.Fatal(err)
+	}
+	results, err = service.SearchPrompts("review")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if results[0].ID != "code-review-checklist" {
+		t.Errorf("Expected the used prompt first, got %s", results[0].ID)
+	}
+}
@@ -0,0 +1,48 @@
+package service
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/dpshade/pocket-prompt/internal/models"
+)
+
+// SetRating gives a prompt a star rating from 1 to models.MaxRating, or
+// clears it with 0. A rating says how well the prompt works rather than
+// changing it, so the prompt is saved in place without a new version.
+func (s *Service) SetRating(id string, rating int) (*models.Prompt, error) {
+	if rating < 0 || rating > models.MaxRating {
+		return nil, fmt.Errorf("invalid rating %d: use a number from 1 to %d, or 0 to clear it", rating, models.MaxRating)
+	}
+	found, err := s.findPrompt(id, true)
+	if err != nil {
+		return nil, err
+	}
+
+	prompt, err := s.storage.LoadPrompt(found.FilePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load prompt %s: %w", id, err)
+	}
+	if prompt.Rating == rating {
+		return prompt, nil
+	}
+	prompt.Rating = rating
+	if err := s.storage.SavePrompt(prompt); err != nil {
+		return nil, fmt.Errorf("failed to save rating: %w", err)
+	}
+
+	if s.gitSync.IsEnabled() {
+		message := fmt.Sprintf("Rate prompt %s %d/%d", prompt.ID, rating, models.MaxRating)
+		if rating == 0 {
+			message = fmt.Sprintf("Clear rating of prompt %s", prompt.ID)
+		}
+		if err := s.syncPrompt(prompt, message, prompt.FilePath); err != nil {
+			slog.Warn("Git sync failed after rating prompt", "error", err)
+		}
+	}
+
+	if err := s.cachePrompt(prompt); err != nil {
+		return nil, err
+	}
+	return prompt, nil
+}
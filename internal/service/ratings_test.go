@@ -0,0 +1,68 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/dpshade/pocket-prompt/internal/models"
+)
+
+func TestSetRating(t *testing.T) {
+	t.Setenv("POCKET_PROMPT_DIR", t.TempDir())
+	service, err := NewService()
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+
+	for _, prompt := range []*models.Prompt{
+		{ID: "good", Name: "Good", Version: "1.0.0", Content: "Works well"},
+		{ID: "meh", Name: "Meh", Version: "1.0.0", Content: "Works sometimes"},
+	} {
+		if err := service.CreatePrompt(prompt); err != nil {
+			t.Fatalf("Failed to create prompt: %v", err)
+		}
+	}
+
+	if _, err := service.SetRating("good", 5); err != nil {
+		t.Fatalf("Failed to rate prompt: %v", err)
+	}
+	if _, err := service.SetRating("meh", 2); err != nil {
+		t.Fatalf("Failed to rate prompt: %v", err)
+	}
+	if _, err := service.SetRating("good", models.MaxRating+1); err == nil {
+		t.Error("Expected a rating above the maximum to be rejected")
+	}
+
+	good, err := service.GetPrompt("good")
+	if err != nil {
+		t.Fatalf("Failed to get prompt: %v", err)
+	}
+	if good.Rating != 5 || good.Version != "1.0.0" || good.Content != "Works well" {
+		t.Errorf("Expected rating 5 on the same version, got rating %d on %s with content %q", good.Rating, good.Version, good.Content)
+	}
+
+	prompts, err := service.ListPrompts()
+	if err != nil {
+		t.Fatalf("Failed to list prompts: %v", err)
+	}
+	rated := models.FilterMinRating(prompts, 3)
+	if len(rated) != 1 || rated[0].ID != "good" {
+		t.Errorf("Expected only good to be rated 3 or more, got %v", rated)
+	}
+
+	// Editors that don't show the rating keep it
+	good.Rating = 0
+	good.Content = "Works very well"
+	if err := service.SavePrompt(good); err != nil {
+		t.Fatalf("Failed to save prompt: %v", err)
+	}
+	if saved, _ := service.GetPrompt("good"); saved.Rating != 5 {
+		t.Errorf("Expected the rating to survive an edit, got %d", saved.Rating)
+	}
+
+	if _, err := service.SetRating("good", 0); err != nil {
+		t.Fatalf("Failed to clear rating: %v", err)
+	}
+	if cleared, _ := service.GetPrompt("good"); cleared.Rating != 0 {
+		t.Errorf("Expected the rating to be cleared, got %d", cleared.Rating)
+	}
+}
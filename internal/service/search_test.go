@@ -0,0 +1,45 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/dpshade/pocket-prompt/internal/models"
+)
+
+func TestSearchPromptsExcludesNegatedTerms(t *testing.T) {
+	t.Setenv("POCKET_PROMPT_DIR", t.TempDir())
+
+	service, err := NewService()
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+	for _, prompt := range []*models.Prompt{
+		{ID: "agent", Name: "Agent", Content: "x"},
+		{ID: "agent-draft", Name: "Agent Draft", Content: "x"},
+		{ID: "agent-eval", Name: "Agent eval", Tags: []string{"test"}, Content: "x"},
+	} {
+		if err := service.CreatePrompt(prompt); err != nil {
+			t.Fatalf("Failed to create prompt: %v", err)
+		}
+	}
+
+	tests := []struct {
+		query string
+		want  int
+	}{
+		{"agent", 3},
+		{"agent -draft", 2},
+		{"agent -draft -test", 1},
+		{`agent NOT "agent eval"`, 2},
+		{"-draft", 2},
+	}
+	for _, tt := range tests {
+		results, err := service.SearchPrompts(tt.query)
+		if err != nil {
+			t.Fatalf("%q: %v", tt.query, err)
+		}
+		if len(results) != tt.want {
+			t.Errorf("%q: expected %d results, got %d", tt.query, tt.want, len(results))
+		}
+	}
+}
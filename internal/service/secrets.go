@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+)
+
+// secretNameRe matches the variable names secrets are stored under
+var secretNameRe = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// validateSecretName checks that a secret is named like a variable
+func validateSecretName(name string) error {
+	if !secretNameRe.MatchString(name) {
+		return fmt.Errorf("invalid secret name %q: use the variable's name, e.g. api_key", name)
+	}
+	return nil
+}
+
+// LoadSecret returns the value of a secret variable, for
+// renderer.Renderer.SetSecretLoader. A keychain lookup is killed when ctx
+// is cancelled.
+func (s *Service) LoadSecret(ctx context.Context, name string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	if err := validateSecretName(name); err != nil {
+		return "", err
+	}
+	value, err := s.secrets.Get(ctx, name)
+	if err != nil {
+		if ctx.Err() != nil {
+			return "", err
+		}
+		return "", fmt.Errorf("failed to read secret: %w (set it with: pocket-prompt secret set %s)", err, name)
+	}
+	return value, nil
+}
+
+// SetSecret stores the value of a secret variable
+func (s *Service) SetSecret(name, value string) error {
+	if err := validateSecretName(name); err != nil {
+		return err
+	}
+	if err := s.secrets.Set(name, value); err != nil {
+		return fmt.Errorf("failed to store secret: %w", err)
+	}
+	return nil
+}
+
+// DeleteSecret removes the value of a secret variable
+func (s *Service) DeleteSecret(name string) error {
+	if err := validateSecretName(name); err != nil {
+		return err
+	}
+	if err := s.secrets.Delete(name); err != nil {
+		return fmt.Errorf("failed to delete secret: %w", err)
+	}
+	return nil
+}
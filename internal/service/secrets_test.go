@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/dpshade/pocket-prompt/internal/models"
+	"github.com/dpshade/pocket-prompt/internal/renderer"
+	"github.com/dpshade/pocket-prompt/internal/secrets"
+)
+
+// memorySecrets is a secrets store that never touches the keychain
+type memorySecrets map[string]string
+
+func (m memorySecrets) Get(ctx context.Context, name string) (string, error) {
+	if value, ok := m[name]; ok {
+		return value, nil
+	}
+	return "", secrets.ErrNotFound
+}
+
+func (m memorySecrets) Set(name, value string) error {
+	m[name] = value
+	return nil
+}
+
+func (m memorySecrets) Delete(name string) error {
+	delete(m, name)
+	return nil
+}
+
+func TestSecretVariables(t *testing.T) {
+	t.Setenv("POCKET_PROMPT_DIR", t.TempDir())
+	service, err := NewService()
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+	service.secrets = memorySecrets{}
+
+	prompt := &models.Prompt{ID: "call-api", Name: "Call API", Version: "1.0.0",
+		Variables: []models.Slot{{Name: "api_key", Secret: true}},
+		Content:   "Authorization: Bearer {{.api_key}}"}
+	if err := service.CreatePrompt(prompt); err != nil {
+		t.Fatalf("Failed to create prompt: %v", err)
+	}
+	saved, err := service.GetPrompt("call-api")
+	if err != nil {
+		t.Fatalf("Failed to get prompt: %v", err)
+	}
+	if len(saved.Variables) != 1 || !saved.Variables[0].Secret {
+		t.Fatalf("Expected the secret variable to be saved, got %+v", saved.Variables)
+	}
+
+	r := renderer.NewRenderer(saved, nil)
+	if _, err := r.RenderText(nil); err == nil || !strings.Contains(err.Error(), "not available") {
+		t.Errorf("Expected rendering without a secret loader to fail, got %v", err)
+	}
+	r.SetSecretLoader(service.LoadSecret)
+	if _, err := r.RenderText(nil); err == nil || !strings.Contains(err.Error(), "secret set api_key") {
+		t.Errorf("Expected an unset secret to say how to set it, got %v", err)
+	}
+
+	if err := service.SetSecret("bad name", "x"); err == nil {
+		t.Error("Expected a secret name that isn't a variable name to be rejected")
+	}
+	if err := service.SetSecret("api_key", "sk-12345"); err != nil {
+		t.Fatalf("Failed to set secret: %v", err)
+	}
+	text, err := r.RenderText(nil)
+	if err != nil || text != "Authorization: Bearer sk-12345" {
+		t.Errorf("Expected the secret to be filled in, got %q, %v", text, err)
+	}
+	text, err = r.RenderText(map[string]interface{}{"api_key": "override"})
+	if err != nil || text != "Authorization: Bearer override" {
+		t.Errorf("Expected an explicit value to take precedence, got %q, %v", text, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	r.SetContext(ctx)
+	if _, err := r.RenderText(nil); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected a cancelled render to stop before reading the secret, got %v", err)
+	}
+}
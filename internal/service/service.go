@@ -3,38 +3,99 @@ package service
 import (
 	"context"
 	"fmt"
+	"maps"
+	"log/slog"
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/dpshade/pocket-prompt/internal/clipboard"
+	"github.com/dpshade/pocket-prompt/internal/config"
+	"github.com/dpshade/pocket-prompt/internal/encryption"
 	"github.com/dpshade/pocket-prompt/internal/git"
+	"github.com/dpshade/pocket-prompt/internal/i18n"
 	"github.com/dpshade/pocket-prompt/internal/importer"
 	"github.com/dpshade/pocket-prompt/internal/models"
+	"github.com/dpshade/pocket-prompt/internal/secrets"
 	"github.com/dpshade/pocket-prompt/internal/storage"
-	"github.com/sahilm/fuzzy"
 )
 
 // Service provides business logic for prompt management
 type Service struct {
-	storage       *storage.Storage
+	storage       storage.Backend
 	prompts       []*models.Prompt // Cached prompts for fast access
 	gitSync       *git.GitSync     // Git synchronization
 	savedSearches *storage.SavedSearchesStorage // Saved boolean searches
+	backup        config.BackupConfig           // Backup location and rotation
+	tagColors     map[string]string             // Tag badge colors for the TUI
+	search        config.SearchConfig           // Search result ordering
+	usage         *storage.UsageStorage         // How often prompts are used
+	draftBranch   string                        // Branch drafts are committed to
+	sync          config.SyncConfig             // Periodic sync provider
+	secrets       secrets.Store                 // Values of secret variables
+	ui            config.UIConfig               // TUI layout preferences
 }
 
 // NewService creates a new service instance
 func NewService() (*Service, error) {
+	return NewServiceAt("")
+}
+
+// NewServiceAt creates a service for the library at dir. When dir is empty
+// the library is the one in POCKET_PROMPT_DIR, or ~/.pocket-prompt.
+func NewServiceAt(dir string) (*Service, error) {
 	// Check for custom directory from environment
-	rootPath := os.Getenv("POCKET_PROMPT_DIR")
-	store, err := storage.NewStorage(rootPath)
+	if dir == "" {
+		dir = os.Getenv("POCKET_PROMPT_DIR")
+	}
+	rootPath, err := storage.ResolveRootPath(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize storage: %w", err)
+	}
+
+	// Pick the storage backend from the library config
+	cfg, err := config.Load(rootPath)
+	if err != nil {
+		return nil, err
+	}
+
+	backend, err := storage.Open(rootPath, cfg.Storage)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize storage: %w", err)
 	}
 
+	// Prompts marked encrypted have their bodies encrypted at rest
+	enc, err := encryption.New(cfg.Encryption)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize encryption: %w", err)
+	}
+	store := storage.NewEncryptedBackend(backend, enc)
+
+	secretStore, err := secrets.New(cfg.Secrets, rootPath, enc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize secrets: %w", err)
+	}
+
 	// Initialize git sync
 	gitSync := git.NewGitSync(store.GetBaseDir())
+	gitSync.SetCommitMessage(cfg.Git.CommitMessage)
+	gitSync.SetAuth(git.Auth{
+		SSHKey:       cfg.Git.SSHKey,
+		Username:     cfg.Git.Username,
+		TokenEnv:     cfg.Git.TokenEnv,
+		TokenCommand: cfg.Git.TokenCommand,
+	})
+	gitSync.SetFileMerger(mergeLibraryFile)
+
+	// Clipboard commands apply to the CLI and TUI, which both start here
+	clipboard.SetCommands(cfg.Clipboard.CopyCommand, cfg.Clipboard.PasteCommand)
+	// So does the language of their messages
+	if err := i18n.Load(filepath.Join(rootPath, i18n.Dir), i18n.Resolve(cfg.Locale)); err != nil {
+		slog.Warn("Failed to load translation", "error", err)
+	}
 	// Don't block on git initialization - it will be done in background
 
 	// Initialize saved searches storage
@@ -44,7 +105,16 @@ func NewService() (*Service, error) {
 		storage:       store,
 		gitSync:       gitSync,
 		savedSearches: savedSearches,
+		backup:        cfg.Backup,
+		tagColors:     cfg.Tags.Colors,
+		search:        cfg.Search,
+		usage:         storage.NewUsageStorage(store.GetBaseDir()),
+		draftBranch:   cfg.Git.DraftBranch,
+		sync:          cfg.Sync,
+		secrets:       secretStore,
+		ui:            cfg.UI,
 	}
+	gitSync.SetExcludedPaths(svc.draftPaths)
 
 	// Initialize git sync in background to avoid blocking startup
 	go func() {
@@ -60,6 +130,23 @@ func NewService() (*Service, error) {
 	return svc, nil
 }
 
+// NewServiceWithBackend creates a service over the given storage backend
+// with the default settings and git sync off. Saved searches, usage and
+// secrets are kept in memory. With
+// storage.NewMemoryStorage it lets the CLI, TUI and servers be tested
+// without a library on disk.
+func NewServiceWithBackend(backend storage.Backend) *Service {
+	svc := &Service{
+		storage:       backend,
+		gitSync:       git.NewGitSync(backend.GetBaseDir()),
+		savedSearches: storage.NewMemorySavedSearchesStorage(),
+		usage:         storage.NewMemoryUsageStorage(),
+		secrets:       secrets.NewMemoryStore(),
+	}
+	svc.gitSync.SetExcludedPaths(svc.draftPaths)
+	return svc
+}
+
 // LoadPromptsAsync loads prompts asynchronously and returns a function to check completion
 func (s *Service) LoadPromptsAsync() func() ([]*models.Prompt, bool, error) {
 	resultChan := make(chan struct {
@@ -68,7 +155,7 @@ func (s *Service) LoadPromptsAsync() func() ([]*models.Prompt, bool, error) {
 	}, 1)
 
 	go func() {
-		prompts, err := s.storage.ListPrompts()
+		prompts, err := s.storage.ListPrompts(context.Background())
 		if err == nil {
 			s.prompts = prompts
 		}
@@ -92,7 +179,7 @@ func (s *Service) LoadPromptsAsync() func() ([]*models.Prompt, bool, error) {
 func (s *Service) LoadPromptsIncremental(callback func([]*models.Prompt, bool, error)) {
 	go func() {
 		// Load prompts in the background
-		prompts, err := s.storage.ListPrompts()
+		prompts, err := s.storage.ListPrompts(context.Background())
 		if err == nil {
 			s.prompts = prompts
 		}
@@ -108,7 +195,13 @@ func (s *Service) InitLibrary() error {
 
 // loadPrompts loads all prompts into memory for fast access
 func (s *Service) loadPrompts() error {
-	prompts, err := s.storage.ListPrompts()
+	return s.loadPromptsContext(context.Background())
+}
+
+// loadPromptsContext loads all prompts into memory, unless ctx is cancelled
+// first
+func (s *Service) loadPromptsContext(ctx context.Context) error {
+	prompts, err := s.storage.ListPrompts(ctx)
 	if err != nil {
 		return err
 	}
@@ -118,8 +211,14 @@ func (s *Service) loadPrompts() error {
 
 // ListPrompts returns all non-archived prompts
 func (s *Service) ListPrompts() ([]*models.Prompt, error) {
+	return s.ListPromptsContext(context.Background())
+}
+
+// ListPromptsContext returns all non-archived prompts. Listing the library
+// the first time stops when ctx is cancelled.
+func (s *Service) ListPromptsContext(ctx context.Context) ([]*models.Prompt, error) {
 	if len(s.prompts) == 0 {
-		if err := s.loadPrompts(); err != nil {
+		if err := s.loadPromptsContext(ctx); err != nil {
 			return nil, err
 		}
 	}
@@ -134,12 +233,69 @@ func (s *Service) ListPrompts() ([]*models.Prompt, error) {
 	return activePrompts, nil
 }
 
+// ArchiveScope selects whether listings and searches see archived prompts
+type ArchiveScope int
+
+const (
+	// ActiveOnly excludes archived prompts (the default)
+	ActiveOnly ArchiveScope = iota
+	// IncludeArchived includes archived versions alongside active prompts
+	IncludeArchived
+	// ArchivedOnly returns only archived prompts
+	ArchivedOnly
+)
+
+// ListPromptsInScope returns the active prompts, the archived prompts or
+// both
+func (s *Service) ListPromptsInScope(scope ArchiveScope) ([]*models.Prompt, error) {
+	return s.ListPromptsInScopeContext(context.Background(), scope)
+}
+
+// ListPromptsInScopeContext is ListPromptsInScope, stopping when ctx is
+// cancelled
+func (s *Service) ListPromptsInScopeContext(ctx context.Context, scope ArchiveScope) ([]*models.Prompt, error) {
+	switch scope {
+	case IncludeArchived:
+		active, err := s.ListPromptsContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		archived, err := s.storage.ListArchivedPrompts(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return append(append([]*models.Prompt(nil), active...), archived...), nil
+	case ArchivedOnly:
+		return s.storage.ListArchivedPrompts(ctx)
+	default:
+		return s.ListPromptsContext(ctx)
+	}
+}
+
 // SearchPrompts searches prompts by query string
 func (s *Service) SearchPrompts(query string) ([]*models.Prompt, error) {
-	prompts, err := s.ListPrompts()
+	return s.SearchPromptsInScope(query, ActiveOnly)
+}
+
+// SearchPromptsInScope searches the prompts of an archive scope by query
+// string. Terms such as "updated:>2024-01-01" filter by date.
+func (s *Service) SearchPromptsInScope(query string, scope ArchiveScope) ([]*models.Prompt, error) {
+	return s.SearchPromptsInScopeContext(context.Background(), query, scope)
+}
+
+// SearchPromptsInScopeContext is SearchPromptsInScope, stopping when ctx is
+// cancelled
+func (s *Service) SearchPromptsInScopeContext(ctx context.Context, query string, scope ArchiveScope) ([]*models.Prompt, error) {
+	query, dates, err := models.ExtractDateFilters(query)
+	if err != nil {
+		return nil, err
+	}
+
+	prompts, err := s.ListPromptsInScopeContext(ctx, scope)
 	if err != nil {
 		return nil, err
 	}
+	prompts = dates.Filter(prompts)
 
 	if query == "" {
 		return prompts, nil
@@ -156,40 +312,98 @@ func (s *Service) SearchPrompts(query string) ([]*models.Prompt, error) {
 		searchStrings = append(searchStrings, searchStr)
 	}
 
-	// Perform fuzzy search
-	matches := fuzzy.Find(query, searchStrings)
-	
-	// Build result list
-	var results []*models.Prompt
-	for _, match := range matches {
-		results = append(results, prompts[match.Index])
-	}
-
-	return results, nil
+	return s.fuzzyFind(query, prompts, searchStrings), nil
 }
 
-// GetPrompt returns a prompt by ID with full content loaded
+
+// GetPrompt returns a prompt by ID with full content loaded. Prompts in
+// subdirectories can also be found by the end of their namespaced ID (e.g.
+// "launch" for "team/marketing/launch") as long as that is unambiguous.
 func (s *Service) GetPrompt(id string) (*models.Prompt, error) {
+	return s.findPrompt(id, true)
+}
+
+// findPrompt looks a prompt up by ID, optionally accepting short IDs
+func (s *Service) findPrompt(id string, allowShort bool) (*models.Prompt, error) {
 	prompts, err := s.ListPrompts()
 	if err != nil {
 		return nil, err
 	}
 
+	var match *models.Prompt
+	var candidates []string
 	for _, p := range prompts {
 		if p.ID == id {
-			// If content is empty (from cache), load it from storage
-			if p.Content == "" && p.FilePath != "" {
-				fullPrompt, err := s.storage.LoadPrompt(p.FilePath)
-				if err != nil {
-					return nil, fmt.Errorf("failed to load prompt content: %w", err)
-				}
-				return fullPrompt, nil
-			}
-			return p, nil
+			match = p
+			candidates = nil
+			break
+		}
+		if allowShort && strings.HasSuffix(p.ID, "/"+id) {
+			match = p
+			candidates = append(candidates, p.ID)
 		}
 	}
 
-	return nil, fmt.Errorf("prompt not found: %s", id)
+	if len(candidates) > 1 {
+		return nil, fmt.Errorf("ambiguous prompt ID %q matches: %s", id, strings.Join(candidates, ", "))
+	}
+	if match == nil {
+		return nil, fmt.Errorf("prompt not found: %s", id)
+	}
+
+	// If content is empty (from cache), load it from storage
+	if match.Content == "" && match.FilePath != "" {
+		fullPrompt, err := s.storage.LoadPrompt(match.FilePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load prompt content: %w", err)
+		}
+		return fullPrompt, nil
+	}
+	return match, nil
+}
+
+// promptContent returns the body of a prompt, reading it from disk when the
+// listing only carried frontmatter. The body is not retained on the prompt.
+// Encrypted bodies are never decrypted just for searching.
+func (s *Service) promptContent(p *models.Prompt) string {
+	if p.Encrypted {
+		return ""
+	}
+	if p.Content != "" || p.FilePath == "" {
+		return p.Content
+	}
+	fullPrompt, err := s.storage.LoadPrompt(p.FilePath)
+	if err != nil {
+		return ""
+	}
+	return fullPrompt.Content
+}
+
+// WithContent returns copies of the given prompts with their bodies loaded,
+// for callers such as exports that need complete documents
+func (s *Service) WithContent(prompts []*models.Prompt) ([]*models.Prompt, error) {
+	return s.WithContentContext(context.Background(), prompts)
+}
+
+// WithContentContext is WithContent, stopping between prompts when ctx is
+// cancelled
+func (s *Service) WithContentContext(ctx context.Context, prompts []*models.Prompt) ([]*models.Prompt, error) {
+	result := make([]*models.Prompt, 0, len(prompts))
+	for _, p := range prompts {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if p.Content != "" || p.FilePath == "" {
+			result = append(result, p)
+			continue
+		}
+		fullPrompt, err := s.storage.LoadPrompt(p.FilePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load prompt content for %s: %w", p.ID, err)
+		}
+		result = append(result, fullPrompt)
+	}
+	return result, nil
 }
 
 // CreatePrompt creates a new prompt
@@ -199,9 +413,22 @@ func (s *Service) CreatePrompt(prompt *models.Prompt) error {
 	prompt.CreatedAt = now
 	prompt.UpdatedAt = now
 
-	// Generate file path if not set
+	// Prompts created without an ID get one from their title
+	if prompt.ID == "" && prompt.FilePath == "" {
+		prompt.ID = s.GeneratePromptID(prompt.Name)
+	}
+
+	// Generate file path if not set; namespaced IDs map to subdirectories
 	if prompt.FilePath == "" {
-		prompt.FilePath = filepath.Join("prompts", fmt.Sprintf("%s.md", prompt.ID))
+		if err := validatePromptID(prompt.ID); err != nil {
+			return err
+		}
+		prompt.FilePath = filepath.Join("prompts", filepath.FromSlash(prompt.ID)+".md")
+	}
+
+	// The template's required slots must be filled
+	if _, err := s.CheckSlots(prompt); err != nil {
+		return err
 	}
 
 	// Save to storage
@@ -211,29 +438,35 @@ func (s *Service) CreatePrompt(prompt *models.Prompt) error {
 
 	// Sync to git if enabled
 	if s.gitSync.IsEnabled() {
-		if err := s.gitSync.SyncChanges(fmt.Sprintf("Create prompt: %s", prompt.Title())); err != nil {
+		message := fmt.Sprintf("Create prompt %s", prompt.ID)
+		if err := s.syncPrompt(prompt, message, prompt.FilePath); err != nil {
 			// Don't fail the operation if git sync fails, just log it
 			// The prompt was saved successfully to local storage
-			fmt.Printf("Warning: Git sync failed after creating prompt: %v\n", err)
+			slog.Warn("Git sync failed after creating prompt", "error", err)
 		}
 	}
 
-	// Reload prompts cache
-	return s.loadPrompts()
+	return s.cachePrompt(prompt)
 }
 
 // UpdatePrompt updates an existing prompt with version management
 func (s *Service) UpdatePrompt(prompt *models.Prompt) error {
 	// Get the existing prompt to check current version
-	existing, err := s.GetPrompt(prompt.ID)
+	existing, err := s.findPrompt(prompt.ID, false)
 	if err != nil {
 		return fmt.Errorf("cannot update non-existent prompt: %w", err)
 	}
 
+	// The template's required slots must be filled
+	if _, err := s.CheckSlots(prompt); err != nil {
+		return err
+	}
+
 	// Archive the old version by adding 'archive' tag and saving it
 	if err := s.archivePromptByTag(existing); err != nil {
 		return fmt.Errorf("failed to archive old version: %w", err)
 	}
+	changedPaths := []string{archivePath(existing), existing.FilePath}
 
 	// Increment version
 	newVersion, err := s.incrementVersion(existing.Version)
@@ -256,14 +489,54 @@ func (s *Service) UpdatePrompt(prompt *models.Prompt) error {
 
 	// Sync to git if enabled
 	if s.gitSync.IsEnabled() {
-		if err := s.gitSync.SyncChanges(fmt.Sprintf("Update prompt: %s (v%s)", prompt.Title(), prompt.Version)); err != nil {
+		message := fmt.Sprintf("Update prompt %s to v%s", prompt.ID, prompt.Version)
+		if err := s.syncPrompt(prompt, message, append(changedPaths, prompt.FilePath)...); err != nil {
 			// Don't fail the operation if git sync fails, just log it
-			fmt.Printf("Warning: Git sync failed after updating prompt: %v\n", err)
+			slog.Warn("Git sync failed after updating prompt", "error", err)
 		}
 	}
 
-	// Reload prompts cache
-	return s.loadPrompts()
+	return s.cachePrompt(prompt)
+}
+
+// PromptSource returns a prompt as a markdown file for editing outside
+// the app. Encrypted bodies are decrypted.
+func (s *Service) PromptSource(prompt *models.Prompt) ([]byte, error) {
+	data, err := storage.MarshalPrompt(prompt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal prompt: %w", err)
+	}
+	return data, nil
+}
+
+// UpdatePromptFromSource validates an edited copy of a prompt's markdown
+// file and saves it as a new version, archiving the old one. It returns nil
+// when the file is unchanged. The prompt ID cannot be changed this way.
+func (s *Service) UpdatePromptFromSource(original *models.Prompt, data []byte) (*models.Prompt, error) {
+	if issues := storage.ValidatePrompt(original.FilePath, data); len(issues) > 0 {
+		var messages []string
+		for _, issue := range issues {
+			messages = append(messages, issue.String())
+		}
+		return nil, fmt.Errorf("invalid prompt file:\n  %s", strings.Join(messages, "\n  "))
+	}
+
+	edited, err := storage.UnmarshalPrompt(data, original.FilePath)
+	if err != nil {
+		return nil, err
+	}
+	if edited.ID != original.ID {
+		return nil, fmt.Errorf("changing the ID from %s to %s is not supported; use duplicate instead", original.ID, edited.ID)
+	}
+
+	if len(promptDiff(original, edited)) == 0 && edited.Encrypted == original.Encrypted && edited.Draft == original.Draft {
+		return nil, nil
+	}
+
+	if err := s.UpdatePrompt(edited); err != nil {
+		return nil, err
+	}
+	return edited, nil
 }
 
 // DeletePrompt deletes a prompt by ID
@@ -280,17 +553,105 @@ func (s *Service) DeletePrompt(id string) error {
 
 	// Sync to git if enabled
 	if s.gitSync.IsEnabled() {
-		if err := s.gitSync.SyncChanges(fmt.Sprintf("Delete prompt: %s", prompt.Title())); err != nil {
+		message := fmt.Sprintf("Delete prompt %s", prompt.ID)
+		if err := s.syncPrompt(prompt, message, prompt.FilePath); err != nil {
 			// Don't fail the operation if git sync fails, just log it
-			fmt.Printf("Warning: Git sync failed after deleting prompt: %v\n", err)
+			slog.Warn("Git sync failed after deleting prompt", "error", err)
 		}
 	}
 
-	// Reload prompts cache
-	return s.loadPrompts()
+	s.uncachePrompts(prompt)
+	return nil
 }
 
-// FilterPromptsByTag returns prompts that have the specified tag
+// DeletePrompts deletes several prompts and syncs the change to git in a
+// single commit. It stops at the first prompt that cannot be deleted and
+// returns the prompts deleted so far.
+func (s *Service) DeletePrompts(prompts []*models.Prompt) ([]*models.Prompt, error) {
+	if len(prompts) == 0 {
+		return nil, nil
+	}
+	if err := s.autoBackup(); err != nil {
+		return nil, err
+	}
+
+	var deleted []*models.Prompt
+	var deleteErr error
+	for _, prompt := range prompts {
+		if err := s.storage.DeletePrompt(prompt); err != nil {
+			deleteErr = fmt.Errorf("failed to delete prompt %s: %w", prompt.ID, err)
+			break
+		}
+		deleted = append(deleted, prompt)
+	}
+
+	if len(deleted) > 0 && s.gitSync.IsEnabled() {
+		if err := s.gitSync.SyncChanges(fmt.Sprintf("Delete %d prompts", len(deleted))); err != nil {
+			slog.Warn("Git sync failed after deleting prompts", "error", err)
+		}
+	}
+
+	s.uncachePrompts(deleted...)
+	return deleted, deleteErr
+}
+
+// DuplicatePrompt copies a prompt under a new ID as a fresh 1.0.0 version,
+// recording the original in copied_from metadata. An empty newID picks
+// "<id>-copy", numbered when that is taken.
+func (s *Service) DuplicatePrompt(id, newID string) (*models.Prompt, error) {
+	original, err := s.GetPrompt(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if newID == "" {
+		newID = original.ID + "-copy"
+		for n := 2; ; n++ {
+			if _, err := s.findPrompt(newID, false); err != nil {
+				break
+			}
+			newID = fmt.Sprintf("%s-copy-%d", original.ID, n)
+		}
+	} else if _, err := s.findPrompt(newID, false); err == nil {
+		return nil, fmt.Errorf("prompt already exists: %s", newID)
+	}
+
+	duplicate := &models.Prompt{
+		ID:          newID,
+		Version:     "1.0.0",
+		Name:        original.Name,
+		Summary:     original.Summary,
+		Tags:        append([]string(nil), original.Tags...),
+		TemplateRef: original.TemplateRef,
+		Slots:       maps.Clone(original.Slots),
+		Variables:   append([]models.Slot(nil), original.Variables...),
+		Defaults:    maps.Clone(original.Defaults),
+		Presets:     clonePresets(original.Presets),
+		Metadata:    make(map[string]interface{}, len(original.Metadata)+2),
+		Encrypted:   original.Encrypted,
+		Draft:       original.Draft,
+		Attachments: append([]string(nil), original.Attachments...),
+		Rating:      original.Rating,
+		Notes:       original.Notes,
+		Content:     original.Content,
+	}
+	if duplicate.Name != "" {
+		duplicate.Name += " (copy)"
+	}
+	for k, v := range original.Metadata {
+		duplicate.Metadata[k] = v
+	}
+	duplicate.Metadata["copied_from"] = original.ID
+	duplicate.Metadata["copied_from_version"] = original.Version
+
+	if err := s.CreatePrompt(duplicate); err != nil {
+		return nil, fmt.Errorf("failed to duplicate prompt: %w", err)
+	}
+	return duplicate, nil
+}
+
+// FilterPromptsByTag returns prompts that have the specified tag; "ai/*"
+// also matches every tag beneath ai
 func (s *Service) FilterPromptsByTag(tag string) ([]*models.Prompt, error) {
 	prompts, err := s.ListPrompts()
 	if err != nil {
@@ -299,11 +660,8 @@ func (s *Service) FilterPromptsByTag(tag string) ([]*models.Prompt, error) {
 
 	var filtered []*models.Prompt
 	for _, p := range prompts {
-		for _, t := range p.Tags {
-			if t == tag {
-				filtered = append(filtered, p)
-				break
-			}
+		if p.HasTag(tag) {
+			filtered = append(filtered, p)
 		}
 	}
 
@@ -332,6 +690,183 @@ func (s *Service) GetAllTags() ([]string, error) {
 	return tags, nil
 }
 
+// GetTagCounts returns every tag with the number of prompts carrying it
+func (s *Service) GetTagCounts() (map[string]int, error) {
+	prompts, err := s.ListPrompts()
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int)
+	for _, p := range prompts {
+		for _, tag := range p.Tags {
+			counts[tag]++
+		}
+	}
+	return counts, nil
+}
+
+// TagColors returns the tag colors configured under tags.colors
+func (s *Service) TagColors() map[string]string {
+	return s.tagColors
+}
+
+// UIConfig returns the TUI layout preferences configured under ui
+func (s *Service) UIConfig() config.UIConfig {
+	return s.ui
+}
+
+// SaveUIConfig stores the TUI layout preferences in config.yaml, keeping
+// the rest of the file's settings
+func (s *Service) SaveUIConfig(ui config.UIConfig) error {
+	baseDir := s.storage.GetBaseDir()
+	cfg, err := config.Load(baseDir)
+	if err != nil {
+		return err
+	}
+	cfg.UI = ui
+	if err := config.Save(baseDir, cfg); err != nil {
+		return err
+	}
+	s.ui = ui
+	return nil
+}
+
+// RetagPrompts adds and removes tags on the given prompts and returns the
+// prompts that changed. Retagging does not archive or bump versions, and
+// the changes are synced to git in a single commit.
+func (s *Service) RetagPrompts(prompts []*models.Prompt, add, remove []string, dryRun bool) ([]*models.Prompt, error) {
+	removeSet := make(map[string]bool, len(remove))
+	for _, tag := range remove {
+		removeSet[tag] = true
+	}
+
+	return s.rewriteTags(prompts, func(current []string) []string {
+		var tags []string
+		for _, tag := range current {
+			if !removeSet[tag] {
+				tags = append(tags, tag)
+			}
+		}
+		for _, tag := range add {
+			if !slices.Contains(tags, tag) {
+				tags = append(tags, tag)
+			}
+		}
+		return tags
+	}, dryRun, "Retag")
+}
+
+// RenameTags replaces the from tags with to in every prompt and saved
+// search using them, so "tags merge a b --into c" is RenameTags([a b], c).
+// Tags match case-insensitively and prompts keep their versions. It
+// returns the changed prompts and the names of the changed saved searches.
+func (s *Service) RenameTags(from []string, to string, dryRun bool) ([]*models.Prompt, []string, error) {
+	to = strings.TrimSpace(to)
+	if to == "" {
+		return nil, nil, fmt.Errorf("new tag name is required")
+	}
+	rename := make(map[string]string, len(from))
+	for _, tag := range from {
+		rename[strings.ToLower(tag)] = to
+	}
+
+	prompts, err := s.ListPrompts()
+	if err != nil {
+		return nil, nil, err
+	}
+	changed, err := s.rewriteTags(prompts, func(current []string) []string {
+		var tags []string
+		for _, tag := range current {
+			if renamed, ok := rename[strings.ToLower(tag)]; ok {
+				tag = renamed
+			}
+			if !slices.Contains(tags, tag) {
+				tags = append(tags, tag)
+			}
+		}
+		return tags
+	}, dryRun, fmt.Sprintf("Rename tags %s to %s:", strings.Join(from, ", "), to))
+	if err != nil {
+		return changed, nil, err
+	}
+
+	searches, err := s.savedSearches.LoadSavedSearches()
+	if err != nil {
+		return changed, nil, err
+	}
+	var renamedSearches []string
+	now := time.Now().Format(time.RFC3339)
+	for i := range searches {
+		if searches[i].Expression.RenameTags(rename) {
+			searches[i].UpdatedAt = now
+			renamedSearches = append(renamedSearches, searches[i].Name)
+		}
+	}
+	if len(renamedSearches) > 0 && !dryRun {
+		if err := s.savedSearches.SaveSearches(searches); err != nil {
+			return changed, nil, err
+		}
+	}
+
+	return changed, renamedSearches, nil
+}
+
+// rewriteTags applies rewrite to the tags of each prompt, saves the prompts
+// whose tags changed and syncs them to git in one commit
+func (s *Service) rewriteTags(prompts []*models.Prompt, rewrite func([]string) []string, dryRun bool, commitPrefix string) ([]*models.Prompt, error) {
+	var changed []*models.Prompt
+	for _, p := range prompts {
+		tags := rewrite(p.Tags)
+		if slices.Equal(tags, p.Tags) {
+			continue
+		}
+
+		if dryRun {
+			changed = append(changed, p)
+			continue
+		}
+
+		// Listings may only carry frontmatter, so load the whole file
+		full, err := s.storage.LoadPrompt(p.FilePath)
+		if err != nil {
+			return changed, fmt.Errorf("failed to load prompt %s: %w", p.ID, err)
+		}
+		full.Tags = tags
+		full.UpdatedAt = time.Now()
+		if err := s.storage.SavePrompt(full); err != nil {
+			return changed, fmt.Errorf("failed to save prompt %s: %w", p.ID, err)
+		}
+		changed = append(changed, full)
+	}
+
+	if dryRun || len(changed) == 0 {
+		return changed, nil
+	}
+
+	if err := s.loadPrompts(); err != nil {
+		return changed, fmt.Errorf("failed to refresh prompts cache: %w", err)
+	}
+	if s.gitSync.IsEnabled() {
+		if err := s.gitSync.SyncChanges(fmt.Sprintf("%s %d prompts", commitPrefix, len(changed))); err != nil {
+			slog.Warn("Git sync failed after retagging prompts", "error", err)
+		}
+	}
+	return changed, nil
+}
+
+// LibraryDir returns the root of the library, which prompt file paths are
+// relative to
+func (s *Service) LibraryDir() string {
+	return s.storage.GetBaseDir()
+}
+
+// ValidationIssues returns problems found in prompt and template files
+// during the last load, so broken files are reported instead of vanishing
+func (s *Service) ValidationIssues() []storage.ValidationIssue {
+	return s.storage.ValidationIssues()
+}
+
 // ListTemplates returns all available templates
 func (s *Service) ListTemplates() ([]*models.Template, error) {
 	return s.storage.ListTemplates()
@@ -356,8 +891,37 @@ func (s *Service) GetTemplate(id string) (*models.Template, error) {
 // SavePrompt saves a prompt (create or update)
 func (s *Service) SavePrompt(prompt *models.Prompt) error {
 	// Check if this is an existing prompt
-	existing, err := s.GetPrompt(prompt.ID)
+	existing, err := s.findPrompt(prompt.ID, false)
 	if err == nil {
+		// Editors that don't know about encryption must not downgrade it
+		if existing.Encrypted {
+			prompt.Encrypted = true
+		}
+		// Nor publish drafts
+		if existing.Draft {
+			prompt.Draft = true
+		}
+		// Nor clear ratings or notes
+		if prompt.Rating == 0 {
+			prompt.Rating = existing.Rating
+		}
+		if prompt.Notes == "" {
+			prompt.Notes = existing.Notes
+		}
+		// Nor drop slot values or variable declarations they don't show
+		if prompt.Slots == nil && prompt.TemplateRef == existing.TemplateRef {
+			prompt.Slots = existing.Slots
+		}
+		if prompt.Variables == nil {
+			prompt.Variables = existing.Variables
+		}
+		// Nor saved variable values
+		if prompt.Defaults == nil {
+			prompt.Defaults = existing.Defaults
+		}
+		if prompt.Presets == nil {
+			prompt.Presets = existing.Presets
+		}
 		// Update existing prompt
 		prompt.CreatedAt = existing.CreatedAt // Keep original creation time
 		prompt.UpdatedAt = time.Now()
@@ -370,22 +934,40 @@ func (s *Service) SavePrompt(prompt *models.Prompt) error {
 
 // SaveTemplate saves a template (create or update)
 func (s *Service) SaveTemplate(template *models.Template) error {
-	// Set file path if not set
-	if template.FilePath == "" {
-		template.FilePath = filepath.Join("templates", fmt.Sprintf("%s.md", template.ID))
-	}
-
 	// Check if this is an existing template
 	existing, err := s.GetTemplate(template.ID)
+	changedPaths := []string{}
 	if err == nil {
-		// Update existing template
+		if !templateChanged(existing, template) {
+			*template = *existing
+			return nil
+		}
+
+		// Archive the old version and save the edit as the next one
+		if err := s.archiveTemplate(existing); err != nil {
+			return fmt.Errorf("failed to archive old version: %w", err)
+		}
+		changedPaths = append(changedPaths, templateArchivePath(existing))
+		if template.Version, err = s.incrementVersion(existing.Version); err != nil {
+			return fmt.Errorf("failed to increment version: %w", err)
+		}
 		template.CreatedAt = existing.CreatedAt // Keep original creation time
 		template.UpdatedAt = time.Now()
+		template.FilePath = existing.FilePath
 	} else {
 		// Create new template
+		existing = nil
 		now := time.Now()
 		template.CreatedAt = now
 		template.UpdatedAt = now
+		if template.Version == "" {
+			template.Version = "1.0.0"
+		}
+	}
+
+	// Set file path if not set
+	if template.FilePath == "" {
+		template.FilePath = filepath.Join("templates", fmt.Sprintf("%s.md", template.ID))
 	}
 
 	// Save to storage
@@ -399,9 +981,10 @@ func (s *Service) SaveTemplate(template *models.Template) error {
 		if existing != nil {
 			action = "Update"
 		}
-		if err := s.gitSync.SyncChanges(fmt.Sprintf("%s template: %s", action, template.Name)); err != nil {
+		message := fmt.Sprintf("%s template %s to v%s", action, template.ID, template.Version)
+		if err := s.gitSync.SyncPaths(message, append(changedPaths, template.FilePath)...); err != nil {
 			// Don't fail the operation if git sync fails, just log it
-			fmt.Printf("Warning: Git sync failed after saving template: %v\n", err)
+			slog.Warn("Git sync failed after saving template", "error", err)
 		}
 	}
 
@@ -422,9 +1005,10 @@ func (s *Service) DeleteTemplate(id string) error {
 
 	// Sync to git if enabled
 	if s.gitSync.IsEnabled() {
-		if err := s.gitSync.SyncChanges(fmt.Sprintf("Delete template: %s", template.Name)); err != nil {
+		message := fmt.Sprintf("Delete template %s", template.ID)
+		if err := s.gitSync.SyncPaths(message, template.FilePath); err != nil {
 			// Don't fail the operation if git sync fails, just log it
-			fmt.Printf("Warning: Git sync failed after deleting template: %v\n", err)
+			slog.Warn("Git sync failed after deleting template", "error", err)
 		}
 	}
 
@@ -469,24 +1053,45 @@ func (s *Service) SetupGitRepository(repoURL string) error {
 	// Perform initial sync
 	if err := s.gitSync.SyncChanges("Initial sync after repository setup"); err != nil {
 		// Non-fatal, just warn
-		fmt.Printf("Warning: Initial sync failed: %v\n", err)
+		slog.Warn("Initial sync failed", "error", err)
 	}
 	
 	return nil
 }
 
+// SetupGitHubRepository creates a GitHub repository for the library and
+// configures Git sync with it. It returns the repository's clone URL.
+func (s *Service) SetupGitHubRepository(name, description string, private bool) (string, error) {
+	repoURL, err := git.CreateGitHubRepository(name, description, private)
+	if err != nil {
+		return "", err
+	}
+	fmt.Printf("Created GitHub repository: %s\n", repoURL)
+
+	if err := s.SetupGitRepository(repoURL); err != nil {
+		return repoURL, err
+	}
+	return repoURL, nil
+}
+
 // PullGitChanges manually pulls changes from remote repository
 func (s *Service) PullGitChanges() error {
+	return s.PullGitChangesContext(context.Background())
+}
+
+// PullGitChangesContext pulls changes from the remote repository, killing
+// git when ctx is cancelled
+func (s *Service) PullGitChangesContext(ctx context.Context) error {
 	if !s.gitSync.IsEnabled() {
 		return fmt.Errorf("git sync is not enabled")
 	}
 	
-	if err := s.gitSync.PullChanges(); err != nil {
+	if err := s.gitSync.PullChangesContext(ctx); err != nil {
 		return fmt.Errorf("failed to pull changes: %w", err)
 	}
 	
 	// Reload prompts cache after pulling changes
-	return s.loadPrompts()
+	return s.loadPromptsContext(ctx)
 }
 
 // ForceGitSync attempts to re-enable git sync and recover from errors
@@ -532,13 +1137,34 @@ func (s *Service) archivePromptByTag(prompt *models.Prompt) error {
 	}
 	
 	// Move to archive folder with version in filename
-	archiveFilename := fmt.Sprintf("%s-v%s.md", prompt.ID, prompt.Version)
-	archivedPrompt.FilePath = filepath.Join("archive", archiveFilename)
+	archivedPrompt.FilePath = archivePath(prompt)
 	
 	// Save the archived version to archive folder
 	return s.storage.SavePrompt(&archivedPrompt)
 }
 
+// archivePath returns the file an old version of a prompt is archived to
+func archivePath(prompt *models.Prompt) string {
+	archiveFilename := fmt.Sprintf("%s-v%s.md", prompt.ID, prompt.Version)
+	return filepath.Join("archive", filepath.FromSlash(archiveFilename))
+}
+
+// validatePromptID rejects IDs that would place files outside the prompts directory
+func validatePromptID(id string) error {
+	if id == "" {
+		return fmt.Errorf("prompt ID is required")
+	}
+	if strings.HasPrefix(id, "/") || strings.HasSuffix(id, "/") || strings.Contains(id, "\\") {
+		return fmt.Errorf("invalid prompt ID %q: namespaces are separated by single slashes", id)
+	}
+	for _, part := range strings.Split(id, "/") {
+		if part == "" || part == "." || part == ".." {
+			return fmt.Errorf("invalid prompt ID %q: namespaces are separated by single slashes", id)
+		}
+	}
+	return nil
+}
+
 // incrementVersion increments a semantic version string
 func (s *Service) incrementVersion(currentVersion string) (string, error) {
 	if currentVersion == "" {
@@ -571,14 +1197,26 @@ func (s *Service) isArchived(prompt *models.Prompt) bool {
 
 // ListArchivedPrompts returns only archived prompts from the archive folder
 func (s *Service) ListArchivedPrompts() ([]*models.Prompt, error) {
-	return s.storage.ListArchivedPrompts()
+	return s.storage.ListArchivedPrompts(context.Background())
 }
 
 // Boolean Search Methods
 
 // SearchPromptsByBooleanExpression searches prompts using a boolean expression
 func (s *Service) SearchPromptsByBooleanExpression(expression *models.BooleanExpression) ([]*models.Prompt, error) {
-	prompts, err := s.ListPrompts()
+	return s.SearchPromptsByBooleanExpressionInScope(expression, ActiveOnly)
+}
+
+// SearchPromptsByBooleanExpressionInScope searches the prompts of an archive
+// scope using a boolean expression
+func (s *Service) SearchPromptsByBooleanExpressionInScope(expression *models.BooleanExpression, scope ArchiveScope) ([]*models.Prompt, error) {
+	return s.SearchPromptsByBooleanExpressionInScopeContext(context.Background(), expression, scope)
+}
+
+// SearchPromptsByBooleanExpressionInScopeContext is
+// SearchPromptsByBooleanExpressionInScope, stopping when ctx is cancelled
+func (s *Service) SearchPromptsByBooleanExpressionInScopeContext(ctx context.Context, expression *models.BooleanExpression, scope ArchiveScope) ([]*models.Prompt, error) {
+	prompts, err := s.ListPromptsInScopeContext(ctx, scope)
 	if err != nil {
 		return nil, err
 	}
@@ -597,6 +1235,29 @@ func (s *Service) SearchPromptsByBooleanExpression(expression *models.BooleanExp
 	return results, nil
 }
 
+// TemplatesUsedBy returns the templates referenced by any of the prompts
+func (s *Service) TemplatesUsedBy(prompts []*models.Prompt) ([]*models.Template, error) {
+	templates, err := s.ListTemplates()
+	if err != nil {
+		return nil, err
+	}
+
+	used := make(map[string]bool)
+	for _, p := range prompts {
+		if p.TemplateRef != "" {
+			used[p.TemplateRef] = true
+		}
+	}
+
+	var results []*models.Template
+	for _, t := range templates {
+		if used[t.ID] {
+			results = append(results, t)
+		}
+	}
+	return results, nil
+}
+
 // Saved Search Methods
 
 // ListSavedSearches returns all saved boolean searches
@@ -619,7 +1280,7 @@ func (s *Service) SaveBooleanSearch(search models.SavedSearch) error {
 	if s.gitSync.IsEnabled() {
 		if err := s.gitSync.SyncChanges(fmt.Sprintf("Save boolean search: %s", search.Name)); err != nil {
 			// Don't fail the operation if git sync fails, just log it
-			fmt.Printf("Warning: Git sync failed after saving boolean search: %v\n", err)
+			slog.Warn("Git sync failed after saving boolean search", "error", err)
 		}
 	}
 
@@ -636,13 +1297,53 @@ func (s *Service) DeleteSavedSearch(name string) error {
 	if s.gitSync.IsEnabled() {
 		if err := s.gitSync.SyncChanges(fmt.Sprintf("Delete boolean search: %s", name)); err != nil {
 			// Don't fail the operation if git sync fails, just log it
-			fmt.Printf("Warning: Git sync failed after deleting boolean search: %v\n", err)
+			slog.Warn("Git sync failed after deleting boolean search", "error", err)
 		}
 	}
 
 	return nil
 }
 
+// SetSavedSearchPinned pins a saved search to the top of the library, or
+// unpins it
+func (s *Service) SetSavedSearchPinned(name string, pinned bool) error {
+	search, err := s.GetSavedSearch(name)
+	if err != nil {
+		return err
+	}
+	search.Pinned = pinned
+	if err := s.savedSearches.AddSavedSearch(*search); err != nil {
+		return err
+	}
+
+	action := "Pin"
+	if !pinned {
+		action = "Unpin"
+	}
+	if s.gitSync.IsEnabled() {
+		if err := s.gitSync.SyncChanges(fmt.Sprintf("%s saved search: %s", action, name)); err != nil {
+			slog.Warn("Git sync failed after pinning saved search", "error", err)
+		}
+	}
+
+	return nil
+}
+
+// PinnedSearches returns the pinned saved searches, in saved order
+func (s *Service) PinnedSearches() ([]models.SavedSearch, error) {
+	searches, err := s.ListSavedSearches()
+	if err != nil {
+		return nil, err
+	}
+	var pinned []models.SavedSearch
+	for _, search := range searches {
+		if search.Pinned {
+			pinned = append(pinned, search)
+		}
+	}
+	return pinned, nil
+}
+
 // ExecuteSavedSearch executes a saved search by name
 func (s *Service) ExecuteSavedSearch(name string) ([]*models.Prompt, error) {
 	return s.ExecuteSavedSearchWithText(name, "")
@@ -650,13 +1351,20 @@ func (s *Service) ExecuteSavedSearch(name string) ([]*models.Prompt, error) {
 
 // ExecuteSavedSearchWithText executes a saved search with an optional text query override
 func (s *Service) ExecuteSavedSearchWithText(name string, textQueryOverride string) ([]*models.Prompt, error) {
+	return s.ExecuteSavedSearchWithTextContext(context.Background(), name, textQueryOverride)
+}
+
+// ExecuteSavedSearchWithTextContext is ExecuteSavedSearchWithText, stopping
+// when ctx is cancelled. Matching text reads prompt bodies, which takes a
+// while in large libraries.
+func (s *Service) ExecuteSavedSearchWithTextContext(ctx context.Context, name string, textQueryOverride string) ([]*models.Prompt, error) {
 	savedSearch, err := s.GetSavedSearch(name)
 	if err != nil {
 		return nil, err
 	}
 
 	// First apply boolean expression filter
-	results, err := s.SearchPromptsByBooleanExpression(savedSearch.Expression)
+	results, err := s.SearchPromptsByBooleanExpressionInScopeContext(ctx, savedSearch.Expression, ActiveOnly)
 	if err != nil {
 		return nil, err
 	}
@@ -673,37 +1381,31 @@ func (s *Service) ExecuteSavedSearchWithText(name string, textQueryOverride stri
 	}
 
 	// Apply text search filter on the boolean results
-	return s.filterPromptsByText(results, textQuery), nil
+	return s.filterPromptsByText(ctx, results, textQuery)
 }
 
 // filterPromptsByText filters prompts using fuzzy text search
-func (s *Service) filterPromptsByText(prompts []*models.Prompt, query string) []*models.Prompt {
+func (s *Service) filterPromptsByText(ctx context.Context, prompts []*models.Prompt, query string) ([]*models.Prompt, error) {
 	if query == "" {
-		return prompts
+		return prompts, nil
 	}
 
 	// Create searchable strings for each prompt
 	var searchStrings []string
 	for _, p := range prompts {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		searchStr := fmt.Sprintf("%s %s %s %s", 
 			p.Name, 
 			p.Summary,
 			strings.Join(p.Tags, " "),
-			p.Content,
+			s.promptContent(p),
 		)
 		searchStrings = append(searchStrings, searchStr)
 	}
 
-	// Perform fuzzy search
-	matches := fuzzy.Find(query, searchStrings)
-	
-	// Build results from matches
-	var results []*models.Prompt
-	for _, match := range matches {
-		results = append(results, prompts[match.Index])
-	}
-
-	return results
+	return s.fuzzyFind(query, prompts, searchStrings), nil
 }
 
 // Claude Code Import Methods
@@ -719,6 +1421,10 @@ func (s *Service) ImportFromClaudeCode(options importer.ImportOptions) (*importe
 
 	// Save imported items to storage if not a dry run
 	if !options.DryRun {
+		if err := s.backupBeforeOverwrite(options); err != nil {
+			return nil, err
+		}
+
 		// Save prompts (agents, commands) and workflows
 		allPrompts := append(result.Prompts, result.Workflows...)
 		
@@ -748,6 +1454,95 @@ func (s *Service) ImportFromClaudeCode(options importer.ImportOptions) (*importe
 	return result, nil
 }
 
+// ImportFromObsidian converts notes selected by tag or folder in an Obsidian vault into prompts
+func (s *Service) ImportFromObsidian(options importer.ObsidianOptions) (*importer.ImportResult, error) {
+	result, err := importer.NewObsidianImporter().Import(options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to import from Obsidian: %w", err)
+	}
+
+	if !options.DryRun {
+		if err := s.backupBeforeOverwrite(options.ImportOptions); err != nil {
+			return nil, err
+		}
+		s.saveImportedPrompts(result, options.ImportOptions, "Obsidian")
+	}
+
+	return result, nil
+}
+
+// ImportFromLangChain converts LangChain prompt template files into prompts
+func (s *Service) ImportFromLangChain(options importer.ImportOptions) (*importer.ImportResult, error) {
+	result, err := importer.NewLangChainImporter().Import(options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to import from LangChain: %w", err)
+	}
+
+	if !options.DryRun {
+		if err := s.backupBeforeOverwrite(options); err != nil {
+			return nil, err
+		}
+		s.saveImportedPrompts(result, options, "LangChain")
+	}
+
+	return result, nil
+}
+
+// ImportFromNotion converts the pages of a Notion markdown/CSV export into prompts
+func (s *Service) ImportFromNotion(options importer.NotionOptions) (*importer.ImportResult, error) {
+	result, err := importer.NewNotionImporter().Import(options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to import from Notion: %w", err)
+	}
+
+	if !options.DryRun {
+		if err := s.backupBeforeOverwrite(options.ImportOptions); err != nil {
+			return nil, err
+		}
+		s.saveImportedPrompts(result, options.ImportOptions, "Notion")
+	}
+
+	return result, nil
+}
+
+// ImportFromCSV converts the rows of a CSV file into prompts
+func (s *Service) ImportFromCSV(options importer.CSVOptions) (*importer.ImportResult, error) {
+	result, err := importer.NewCSVImporter().Import(options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to import from CSV: %w", err)
+	}
+
+	if !options.DryRun {
+		if err := s.backupBeforeOverwrite(options.ImportOptions); err != nil {
+			return nil, err
+		}
+		s.saveImportedPrompts(result, options.ImportOptions, "CSV")
+	}
+
+	return result, nil
+}
+
+// saveImportedPrompts saves the prompts of an import, recording failures in the result
+func (s *Service) saveImportedPrompts(result *importer.ImportResult, options importer.ImportOptions, source string) {
+	for _, prompt := range result.Prompts {
+		if err := s.savePromptWithConflictResolution(prompt, options); err != nil {
+			result.Errors = append(result.Errors, fmt.Errorf("failed to save prompt %s: %w", prompt.ID, err))
+		}
+	}
+
+	// Refresh the prompts cache after import
+	if err := s.loadPrompts(); err != nil {
+		result.Errors = append(result.Errors, fmt.Errorf("failed to refresh prompts cache: %w", err))
+	}
+
+	// Sync to git if enabled and no errors occurred
+	if s.gitSync.IsEnabled() && len(result.Errors) == 0 {
+		if err := s.gitSync.SyncChanges(fmt.Sprintf("Import from %s: %d prompts", source, len(result.Prompts))); err != nil {
+			result.Errors = append(result.Errors, fmt.Errorf("git sync failed after import: %w", err))
+		}
+	}
+}
+
 // PreviewClaudeCodeImport shows what would be imported without actually importing
 func (s *Service) PreviewClaudeCodeImport(options importer.ImportOptions) (*importer.ImportResult, error) {
 	options.DryRun = true
@@ -758,7 +1553,7 @@ func (s *Service) PreviewClaudeCodeImport(options importer.ImportOptions) (*impo
 // savePromptWithConflictResolution handles conflict resolution when saving imported prompts
 func (s *Service) savePromptWithConflictResolution(prompt *models.Prompt, options importer.ImportOptions) error {
 	// Check if prompt already exists
-	existing, err := s.GetPrompt(prompt.ID)
+	existing, err := s.findPrompt(prompt.ID, false)
 	if err == nil {
 		// Prompt exists, check if content has changed
 		contentChanged := existing.Content != prompt.Content
@@ -842,8 +1637,12 @@ func (s *Service) saveTemplateWithConflictResolution(template *models.Template,
 			return fmt.Errorf("template %s already exists (use --overwrite to overwrite or --skip-existing to skip)", template.ID)
 		}
 		
-		// Content has changed, increment version
+		// Content has changed, archive old version and increment version
 		if contentChanged || slotsChanged {
+			if err := s.archiveTemplate(existing); err != nil {
+				return fmt.Errorf("failed to archive old version: %w", err)
+			}
+			
 			// Increment version
 			newVersion, err := s.incrementVersion(existing.Version)
 			if err != nil {
@@ -916,7 +1715,8 @@ func equalTemplateSlots(a, b []models.Slot) bool {
 		if aSlot, ok := aMap[slot.Name]; !ok || 
 			aSlot.Required != slot.Required || 
 			aSlot.Description != slot.Description || 
-			aSlot.Default != slot.Default {
+			aSlot.Default != slot.Default ||
+			aSlot.Secret != slot.Secret {
 			return false
 		}
 	}
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dpshade/pocket-prompt/internal/models"
+)
+
+// contentSlot is the slot a template's prompts fill with their body
+const contentSlot = "content"
+
+// SlotError reports the required slots a prompt leaves empty
+type SlotError struct {
+	PromptID string
+	Template string
+	Missing  []string
+}
+
+func (e *SlotError) Error() string {
+	return fmt.Sprintf("prompt %s is missing required slots of template %s: %s",
+		e.PromptID, e.Template, strings.Join(e.Missing, ", "))
+}
+
+// CheckSlots checks a prompt against the slots of the template it uses. It
+// returns a *SlotError when a required slot without a default has no value,
+// and warnings for values the template doesn't use or a template that
+// can't be found, which don't stop the prompt rendering.
+func (s *Service) CheckSlots(prompt *models.Prompt) ([]string, error) {
+	if prompt.TemplateRef == "" {
+		if len(prompt.Slots) > 0 {
+			return []string{"slot values are set but the prompt uses no template"}, nil
+		}
+		return nil, nil
+	}
+	template, err := s.GetTemplate(prompt.TemplateRef)
+	if err != nil {
+		return []string{fmt.Sprintf("template %s not found", prompt.TemplateRef)}, nil
+	}
+	return checkSlots(prompt, template)
+}
+
+// checkSlots checks a prompt's slot values against its template
+func checkSlots(prompt *models.Prompt, template *models.Template) ([]string, error) {
+	var warnings, missing []string
+	declared := make(map[string]bool, len(template.Slots))
+	for _, slot := range template.Slots {
+		declared[slot.Name] = true
+		if slot.Name == contentSlot {
+			if slot.Required && strings.TrimSpace(prompt.Content) == "" {
+				missing = append(missing, slot.Name)
+			}
+			continue
+		}
+		if _, ok := prompt.Slots[slot.Name]; !ok && slot.Required && slot.Default == "" {
+			missing = append(missing, slot.Name)
+		}
+	}
+
+	for name := range prompt.Slots {
+		switch {
+		case name == contentSlot:
+			warnings = append(warnings, "slot content is filled by the prompt body; its value is ignored")
+		case !declared[name]:
+			warnings = append(warnings, fmt.Sprintf("unknown slot %s: template %s doesn't declare it", name, template.ID))
+		}
+	}
+	sort.Strings(warnings)
+
+	if len(missing) > 0 {
+		return warnings, &SlotError{PromptID: prompt.ID, Template: template.ID, Missing: missing}
+	}
+	return warnings, nil
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dpshade/pocket-prompt/internal/models"
+)
+
+func TestCheckSlots(t *testing.T) {
+	t.Setenv("POCKET_PROMPT_DIR", t.TempDir())
+	service, err := NewService()
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+	template := &models.Template{
+		ID:      "review",
+		Name:    "Review",
+		Content: "Review this {{.language}} code for {{.focus}}:\n{{.content}}",
+		Slots: []models.Slot{
+			{Name: "language", Required: true},
+			{Name: "focus", Required: true, Default: "bugs"},
+			{Name: "content", Required: true},
+		},
+	}
+	if err := service.SaveTemplate(template); err != nil {
+		t.Fatalf("Failed to save template: %v", err)
+	}
+
+	// A required slot without a default must be filled, as must the body
+	err = service.CreatePrompt(&models.Prompt{ID: "pr", TemplateRef: "review"})
+	var slotErr *SlotError
+	if !errors.As(err, &slotErr) {
+		t.Fatalf("Expected a slot error, got %v", err)
+	}
+	if len(slotErr.Missing) != 2 || slotErr.Missing[0] != "language" || slotErr.Missing[1] != "content" {
+		t.Errorf("Expected language and content to be missing, got %v", slotErr.Missing)
+	}
+	if _, err := service.GetPrompt("pr"); err == nil {
+		t.Error("Expected the invalid prompt not to be saved")
+	}
+
+	prompt := &models.Prompt{
+		ID:          "pr",
+		TemplateRef: "review",
+		Slots:       map[string]string{"language": "Go", "tone": "dry"},
+		Content:     "func main() {}",
+	}
+	if err := service.CreatePrompt(prompt); err != nil {
+		t.Fatalf("Failed to create prompt: %v", err)
+	}
+	warnings, err := service.CheckSlots(prompt)
+	if err != nil || len(warnings) != 1 {
+		t.Errorf("Expected one warning for the unknown slot, got %v, %v", warnings, err)
+	}
+
+	// Editors that don't show slots keep them
+	edit := &models.Prompt{ID: "pr", Name: "PR", TemplateRef: "review", Content: "func main() {}"}
+	if err := service.SavePrompt(edit); err != nil {
+		t.Fatalf("Failed to save prompt: %v", err)
+	}
+	if edit.Slots["language"] != "Go" {
+		t.Errorf("Expected the slot values to be kept, got %v", edit.Slots)
+	}
+
+	// Clearing a required slot is refused before anything is archived
+	cleared := *edit
+	cleared.Slots = map[string]string{}
+	if err := service.UpdatePrompt(&cleared); !errors.As(err, &slotErr) {
+		t.Errorf("Expected a slot error, got %v", err)
+	}
+	if current, _ := service.GetPrompt("pr"); current.Version != edit.Version {
+		t.Errorf("Expected the refused update to keep v%s, got v%s", edit.Version, current.Version)
+	}
+
+	warnings, err = service.CheckSlots(&models.Prompt{ID: "x", TemplateRef: "missing"})
+	if err != nil || len(warnings) != 1 {
+		t.Errorf("Expected a warning for an unknown template, got %v, %v", warnings, err)
+	}
+}
@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/dpshade/pocket-prompt/internal/models"
+	"github.com/dpshade/pocket-prompt/internal/storage"
+)
+
+// ListSnippets returns all snippets in the library, sorted by name
+func (s *Service) ListSnippets() ([]*models.Snippet, error) {
+	return s.storage.ListSnippets()
+}
+
+// GetSnippet returns a snippet by name
+func (s *Service) GetSnippet(name string) (*models.Snippet, error) {
+	snippet, err := s.storage.LoadSnippet(name)
+	if err != nil {
+		return nil, fmt.Errorf("snippet not found: %s", name)
+	}
+	return snippet, nil
+}
+
+// LoadSnippet returns the content of a snippet, for
+// renderer.Renderer.SetSnippetLoader
+func (s *Service) LoadSnippet(ctx context.Context, name string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	snippet, err := s.storage.LoadSnippet(name)
+	if err != nil {
+		return "", err
+	}
+	return snippet.Content, nil
+}
+
+// SaveSnippet creates or replaces a snippet
+func (s *Service) SaveSnippet(snippet *models.Snippet) error {
+	if err := storage.ValidateSnippetName(snippet.Name); err != nil {
+		return err
+	}
+	_, err := s.storage.LoadSnippet(snippet.Name)
+	exists := err == nil
+
+	if err := s.storage.SaveSnippet(snippet); err != nil {
+		return err
+	}
+
+	if s.gitSync.IsEnabled() {
+		action := "Create"
+		if exists {
+			action = "Update"
+		}
+		message := fmt.Sprintf("%s snippet %s", action, snippet.Name)
+		if err := s.gitSync.SyncPaths(message, snippet.FilePath); err != nil {
+			slog.Warn("Git sync failed after saving snippet", "error", err)
+		}
+	}
+	return nil
+}
+
+// DeleteSnippet deletes a snippet by name
+func (s *Service) DeleteSnippet(name string) error {
+	snippet, err := s.GetSnippet(name)
+	if err != nil {
+		return err
+	}
+
+	if err := s.storage.DeleteSnippet(name); err != nil {
+		return fmt.Errorf("failed to delete snippet: %w", err)
+	}
+
+	if s.gitSync.IsEnabled() {
+		message := fmt.Sprintf("Delete snippet %s", name)
+		if err := s.gitSync.SyncPaths(message, snippet.FilePath); err != nil {
+			slog.Warn("Git sync failed after deleting snippet", "error", err)
+		}
+	}
+	return nil
+}
+
+// SnippetUsers returns the prompts and templates whose content references
+// a snippet, directly or through another snippet
+func (s *Service) SnippetUsers(name string) ([]*models.Prompt, []*models.Template, error) {
+	snippets, err := s.ListSnippets()
+	if err != nil {
+		return nil, nil, err
+	}
+	byName := make(map[string]*models.Snippet, len(snippets))
+	for _, snippet := range snippets {
+		byName[snippet.Name] = snippet
+	}
+	uses := func(content string) bool {
+		return snippetReachable(content, name, byName, map[string]bool{})
+	}
+
+	prompts, err := s.ListPrompts()
+	if err != nil {
+		return nil, nil, err
+	}
+	if prompts, err = s.WithContent(prompts); err != nil {
+		return nil, nil, err
+	}
+	var promptUsers []*models.Prompt
+	for _, prompt := range prompts {
+		if uses(prompt.Content) {
+			promptUsers = append(promptUsers, prompt)
+		}
+	}
+
+	// A library without a templates directory has no templates
+	templates, _ := s.ListTemplates()
+	var templateUsers []*models.Template
+	for _, template := range templates {
+		if uses(template.Content) {
+			templateUsers = append(templateUsers, template)
+		}
+	}
+	return promptUsers, templateUsers, nil
+}
+
+// snippetReachable reports whether content references target, following
+// references through the snippets in byName
+func snippetReachable(content, target string, byName map[string]*models.Snippet, visited map[string]bool) bool {
+	for _, ref := range models.SnippetRefs(content) {
+		if ref == target {
+			return true
+		}
+		if visited[ref] {
+			continue
+		}
+		visited[ref] = true
+		if snippet, ok := byName[ref]; ok && snippetReachable(snippet.Content, target, byName, visited) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dpshade/pocket-prompt/internal/models"
+	"github.com/dpshade/pocket-prompt/internal/renderer"
+)
+
+func TestSnippets(t *testing.T) {
+	library := t.TempDir()
+	t.Setenv("POCKET_PROMPT_DIR", library)
+	if err := os.MkdirAll(filepath.Join(library, "templates"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	service, err := NewService()
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+
+	if err := service.SaveSnippet(&models.Snippet{Name: "../escape", Content: "x"}); err == nil {
+		t.Error("Expected a snippet outside the snippets directory to be rejected")
+	}
+	for _, snippet := range []*models.Snippet{
+		{Name: "personas/reviewer", Description: "Code reviewer", Content: "You are a careful reviewer of {{.language}} code."},
+		{Name: "formats/checklist", Content: "{{snippet:personas/reviewer}}\nAnswer as a checklist.\n"},
+	} {
+		if err := service.SaveSnippet(snippet); err != nil {
+			t.Fatalf("Failed to save snippet: %v", err)
+		}
+	}
+
+	snippets, err := service.ListSnippets()
+	if err != nil {
+		t.Fatalf("Failed to list snippets: %v", err)
+	}
+	if len(snippets) != 2 || snippets[0].Name != "formats/checklist" || snippets[1].Description != "Code reviewer" {
+		t.Fatalf("Expected both snippets sorted by name, got %+v", snippets)
+	}
+	if snippets[0].Content != "{{snippet:personas/reviewer}}\nAnswer as a checklist." {
+		t.Errorf("Expected trailing newlines to be dropped, got %q", snippets[0].Content)
+	}
+
+	prompt := &models.Prompt{ID: "review", Name: "Review", Version: "1.0.0", Content: "{{snippet:formats/checklist}}\n\nReview this diff."}
+	if err := service.CreatePrompt(prompt); err != nil {
+		t.Fatalf("Failed to create prompt: %v", err)
+	}
+
+	r := renderer.NewRenderer(prompt, nil)
+	r.SetSnippetLoader(service.LoadSnippet)
+	text, err := r.RenderText(map[string]interface{}{"language": "Go"})
+	if err != nil {
+		t.Fatalf("Failed to render: %v", err)
+	}
+	want := "You are a careful reviewer of Go code.\nAnswer as a checklist.\n\nReview this diff."
+	if text != want {
+		t.Errorf("Expected nested snippets to be expanded, got %q", text)
+	}
+
+	prompts, _, err := service.SnippetUsers("personas/reviewer")
+	if err != nil {
+		t.Fatalf("Failed to find snippet users: %v", err)
+	}
+	if len(prompts) != 1 || prompts[0].ID != "review" {
+		t.Errorf("Expected the prompt to use the snippet through another, got %v", prompts)
+	}
+
+	// A snippet that includes itself is reported instead of looping
+	if err := service.SaveSnippet(&models.Snippet{Name: "personas/reviewer", Content: "{{snippet:formats/checklist}}"}); err != nil {
+		t.Fatalf("Failed to save snippet: %v", err)
+	}
+	var snippetErr *renderer.SnippetError
+	if _, err := r.RenderText(nil); !errors.As(err, &snippetErr) || !strings.Contains(err.Error(), "includes itself") {
+		t.Errorf("Expected a snippet cycle error, got %v", err)
+	}
+
+	if err := service.DeleteSnippet("personas/reviewer"); err != nil {
+		t.Fatalf("Failed to delete snippet: %v", err)
+	}
+	issues, err := service.Lint()
+	if err != nil {
+		t.Fatalf("Failed to lint: %v", err)
+	}
+	var missing []string
+	for _, issue := range issues {
+		if issue.Rule == "missing-snippet" {
+			missing = append(missing, issue.FilePath)
+		}
+	}
+	if len(missing) != 1 || missing[0] != filepath.Join("snippets", "formats", "checklist.md") {
+		t.Errorf("Expected the snippet referencing the deleted one to be reported, got %v", missing)
+	}
+	if _, err := r.RenderText(nil); !errors.As(err, &snippetErr) || snippetErr.Name != "personas/reviewer" {
+		t.Errorf("Expected a missing snippet error, got %v", err)
+	}
+}
@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+
+	"github.com/dpshade/pocket-prompt/internal/git"
+	"github.com/dpshade/pocket-prompt/internal/storage"
+	"github.com/dpshade/pocket-prompt/internal/syncer"
+)
+
+// Syncer returns the provider configured to keep the library in sync, git
+// unless config.yaml selects another
+func (s *Service) Syncer() (syncer.Syncer, error) {
+	return syncer.New(s.sync, s.storage.GetBaseDir(), syncer.NewFunc("git", s.pullGit))
+}
+
+// pullGit pulls git changes for the periodic sync
+func (s *Service) pullGit(ctx context.Context) error {
+	if !s.gitSync.IsEnabled() {
+		// Startup checks git in the background; it may not have finished
+		s.gitSync.Initialize()
+		if !s.gitSync.IsEnabled() {
+			return syncer.ErrNotConfigured
+		}
+	}
+	return s.PullGitChangesContext(ctx)
+}
+
+// ReloadPrompts rereads the prompts, e.g. after a sync changed the files
+func (s *Service) ReloadPrompts() error {
+	return s.loadPrompts()
+}
+
+// mergeLibraryFile merges a file both this device and the remote changed.
+// Prompts are merged field by field; other files, such as templates, line
+// by line.
+func mergeLibraryFile(path string, base, ours, theirs []byte) ([]byte, bool, error) {
+	dir := strings.SplitN(filepath.ToSlash(path), "/", 2)[0]
+	if dir == "prompts" || dir == "archive" {
+		return storage.MergePromptFile(base, ours, theirs, git.MergeText)
+	}
+	merged, clean, err := git.MergeText(string(base), string(ours), string(theirs))
+	return []byte(merged), clean, err
+}
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/dpshade/pocket-prompt/internal/models"
+)
+
+func TestRetagPrompts(t *testing.T) {
+	t.Setenv("POCKET_PROMPT_DIR", t.TempDir())
+
+	service, err := NewService()
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+	for _, p := range []*models.Prompt{
+		{ID: "ship", Version: "1.2.0", Name: "Ship", Content: "Ship it", Tags: []string{"ai", "draft"}},
+		{ID: "done", Version: "1.0.0", Name: "Done", Content: "Done", Tags: []string{"ai", "reviewed"}},
+	} {
+		if err := service.SavePrompt(p); err != nil {
+			t.Fatalf("Failed to save prompt: %v", err)
+		}
+	}
+
+	prompts, err := service.FilterPromptsByTag("ai")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	preview, err := service.RetagPrompts(prompts, []string{"reviewed"}, []string{"draft"}, true)
+	if err != nil {
+		t.Fatalf("RetagPrompts failed: %v", err)
+	}
+	if len(preview) != 1 || preview[0].ID != "ship" {
+		t.Fatalf("Expected only ship to change, got %v", preview)
+	}
+	if p, _ := service.GetPrompt("ship"); !p.HasTag("draft") {
+		t.Fatal("Dry run changed the prompt")
+	}
+
+	changed, err := service.RetagPrompts(prompts, []string{"reviewed"}, []string{"draft"}, false)
+	if err != nil {
+		t.Fatalf("RetagPrompts failed: %v", err)
+	}
+	if len(changed) != 1 {
+		t.Fatalf("Expected one changed prompt, got %d", len(changed))
+	}
+
+	ship, err := service.GetPrompt("ship")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !equalStringSlices(ship.Tags, []string{"ai", "reviewed"}) || ship.Version != "1.2.0" || ship.Content != "Ship it" {
+		t.Errorf("Unexpected prompt after retag: %+v", ship)
+	}
+	if archived, _ := service.ListArchivedPrompts(); len(archived) != 0 {
+		t.Errorf("Expected no archived versions, got %d", len(archived))
+	}
+}
+
+func TestRenameTags(t *testing.T) {
+	t.Setenv("POCKET_PROMPT_DIR", t.TempDir())
+
+	service, err := NewService()
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+	for _, p := range []*models.Prompt{
+		{ID: "a", Name: "A", Content: "a", Tags: []string{"LLM", "writing"}},
+		{ID: "b", Name: "B", Content: "b", Tags: []string{"gpt", "llm"}},
+		{ID: "c", Name: "C", Content: "c", Tags: []string{"writing"}},
+	} {
+		if err := service.SavePrompt(p); err != nil {
+			t.Fatalf("Failed to save prompt: %v", err)
+		}
+	}
+	search := models.SavedSearch{
+		Name:       "models",
+		Expression: models.NewAndExpression(models.NewTagExpression("gpt"), models.NewNotExpression(models.NewTagExpression("writing"))),
+	}
+	if err := service.SaveBooleanSearch(search); err != nil {
+		t.Fatalf("Failed to save search: %v", err)
+	}
+
+	prompts, searches, err := service.RenameTags([]string{"llm", "gpt"}, "ai", false)
+	if err != nil {
+		t.Fatalf("RenameTags failed: %v", err)
+	}
+	if len(prompts) != 2 || len(searches) != 1 || searches[0] != "models" {
+		t.Fatalf("Unexpected result: %d prompts, searches %v", len(prompts), searches)
+	}
+
+	a, _ := service.GetPrompt("a")
+	b, _ := service.GetPrompt("b")
+	if len(a.Tags) != 2 || a.Tags[0] != "ai" || a.Tags[1] != "writing" {
+		t.Errorf("Expected the tag renamed in place, got %v", a.Tags)
+	}
+	if len(b.Tags) != 1 || b.Tags[0] != "ai" {
+		t.Errorf("Expected merged tags to collapse, got %v", b.Tags)
+	}
+
+	saved, err := service.GetSavedSearch("models")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := saved.Expression.String(); got != "([ai] AND NOT [writing])" {
+		t.Errorf("Unexpected saved search: %s", got)
+	}
+}
@@ -0,0 +1,85 @@
+package service
+
+import (
+	"fmt"
+	"maps"
+	"path/filepath"
+	"reflect"
+	"sort"
+
+	"github.com/dpshade/pocket-prompt/internal/models"
+	"github.com/dpshade/pocket-prompt/internal/storage"
+)
+
+// archiveTemplate saves a copy of a template's current version to the
+// archive, as prompts are archived before each update
+func (s *Service) archiveTemplate(template *models.Template) error {
+	archived := *template
+	archived.FilePath = templateArchivePath(template)
+	return s.storage.SaveTemplate(&archived)
+}
+
+// templateArchivePath returns the file an old version of a template is
+// archived to
+func templateArchivePath(template *models.Template) string {
+	archiveFilename := fmt.Sprintf("%s-v%s.md", template.ID, template.Version)
+	return filepath.Join(filepath.FromSlash(storage.ArchivedTemplatesDir), filepath.FromSlash(archiveFilename))
+}
+
+// templateChanged reports whether saving edited over existing changes
+// anything that deserves a new version
+func templateChanged(existing, edited *models.Template) bool {
+	return existing.Content != edited.Content ||
+		existing.Name != edited.Name ||
+		existing.Description != edited.Description ||
+		!equalTemplateSlots(existing.Slots, edited.Slots) ||
+		!reflect.DeepEqual(existing.Constraints, edited.Constraints) ||
+		!maps.Equal(existing.Metadata, edited.Metadata)
+}
+
+// TemplateHistory returns the archived versions of a template, newest first
+func (s *Service) TemplateHistory(id string) ([]*models.Template, error) {
+	archived, err := s.storage.ListArchivedTemplates()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list archived templates: %w", err)
+	}
+
+	var history []*models.Template
+	for _, template := range archived {
+		if template.ID == id {
+			history = append(history, template)
+		}
+	}
+	sort.Slice(history, func(i, j int) bool {
+		return history[i].UpdatedAt.After(history[j].UpdatedAt)
+	})
+	return history, nil
+}
+
+// RollbackTemplate restores an archived version of a template. The restored
+// content is saved as a new version, so the version it replaces is archived
+// and the rollback can itself be undone.
+func (s *Service) RollbackTemplate(id, version string) (*models.Template, error) {
+	current, err := s.GetTemplate(id)
+	if err != nil {
+		return nil, err
+	}
+	history, err := s.TemplateHistory(id)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, old := range history {
+		if old.Version != version {
+			continue
+		}
+		restored := *old
+		restored.Version = current.Version
+		restored.FilePath = current.FilePath
+		if err := s.SaveTemplate(&restored); err != nil {
+			return nil, fmt.Errorf("failed to restore template: %w", err)
+		}
+		return &restored, nil
+	}
+	return nil, fmt.Errorf("template %s has no archived version %s", id, version)
+}
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/dpshade/pocket-prompt/internal/models"
+)
+
+func TestTemplateHistory(t *testing.T) {
+	t.Setenv("POCKET_PROMPT_DIR", t.TempDir())
+	service, err := NewService()
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+
+	template := &models.Template{ID: "review", Name: "Review", Content: "first"}
+	if err := service.SaveTemplate(template); err != nil {
+		t.Fatalf("Failed to save template: %v", err)
+	}
+	if template.Version != "1.0.0" {
+		t.Errorf("Expected a new template to start at 1.0.0, got %s", template.Version)
+	}
+
+	// Saving unchanged content is not a new version
+	for _, content := range []string{"second", "second", "third"} {
+		edited := &models.Template{ID: "review", Name: "Review", Content: content}
+		if err := service.SaveTemplate(edited); err != nil {
+			t.Fatalf("Failed to save template: %v", err)
+		}
+	}
+	current, err := service.GetTemplate("review")
+	if err != nil {
+		t.Fatalf("Failed to get template: %v", err)
+	}
+	if current.Version != "1.0.2" || current.Content != "third" {
+		t.Errorf("Expected v1.0.2 with the latest content, got v%s %q", current.Version, current.Content)
+	}
+
+	history, err := service.TemplateHistory("review")
+	if err != nil {
+		t.Fatalf("Failed to get history: %v", err)
+	}
+	if len(history) != 2 {
+		t.Fatalf("Expected 2 archived versions, got %d", len(history))
+	}
+	versions := map[string]string{}
+	for _, old := range history {
+		versions[old.Version] = old.Content
+	}
+	if versions["1.0.0"] != "first" || versions["1.0.1"] != "second" {
+		t.Errorf("Expected the archive to keep each version's content, got %v", versions)
+	}
+
+	// Archived versions are not listed as templates
+	templates, err := service.ListTemplates()
+	if err != nil {
+		t.Fatalf("Failed to list templates: %v", err)
+	}
+	if len(templates) != 1 {
+		t.Errorf("Expected only the current template to be listed, got %d", len(templates))
+	}
+
+	restored, err := service.RollbackTemplate("review", "1.0.0")
+	if err != nil {
+		t.Fatalf("Failed to roll back: %v", err)
+	}
+	if restored.Version != "1.0.3" || restored.Content != "first" {
+		t.Errorf("Expected v1.0.0's content as v1.0.3, got v%s %q", restored.Version, restored.Content)
+	}
+	if history, _ := service.TemplateHistory("review"); len(history) != 3 {
+		t.Errorf("Expected the rolled back version to be archived, got %d versions", len(history))
+	}
+
+	if _, err := service.RollbackTemplate("review", "9.9.9"); err == nil {
+		t.Error("Expected an error rolling back to an unknown version")
+	}
+}
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"slices"
+
+	"github.com/dpshade/pocket-prompt/internal/models"
+)
+
+// PromptVariables returns the variables that can be filled in when a
+// prompt is rendered: those it declares or uses in its content, then the
+// slots of its template it doesn't fill itself. Secret variables are left
+// out, as rendering reads them from the secrets store. Each variable's
+// Default is the value rendering uses when none is given, from the preset,
+// the prompt's defaults or the template slot.
+func (s *Service) PromptVariables(prompt *models.Prompt, preset string) ([]models.Slot, error) {
+	values, err := prompt.VariableValues(preset)
+	if err != nil {
+		return nil, err
+	}
+
+	var variables []models.Slot
+	skip := map[string]bool{contentSlot: true}
+	add := func(variable models.Slot) {
+		if skip[variable.Name] {
+			return
+		}
+		skip[variable.Name] = true
+		if variable.Secret {
+			return
+		}
+		if value, ok := values[variable.Name]; ok {
+			variable.Default = value
+		}
+		variables = append(variables, variable)
+	}
+
+	declared := slices.Clone(prompt.Variables)
+	for _, name := range contentVariables(prompt.Content) {
+		if !slices.ContainsFunc(declared, func(v models.Slot) bool { return v.Name == name }) {
+			declared = append(declared, models.Slot{Name: name})
+		}
+	}
+	for _, variable := range declared {
+		add(variable)
+	}
+
+	if prompt.TemplateRef != "" {
+		if template, err := s.GetTemplate(prompt.TemplateRef); err == nil {
+			for name := range prompt.Slots {
+				skip[name] = true
+			}
+			for _, slot := range template.Slots {
+				add(slot)
+			}
+		}
+	}
+	return variables, nil
+}
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/dpshade/pocket-prompt/internal/models"
+)
+
+func TestPromptVariables(t *testing.T) {
+	t.Setenv("POCKET_PROMPT_DIR", t.TempDir())
+	service, err := NewService()
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+
+	template := &models.Template{
+		ID: "report", Name: "Report", Version: "1.0.0",
+		Content: "{{.content}} for {{.audience}} in {{.tone}}",
+		Slots: []models.Slot{
+			{Name: "audience", Required: true},
+			{Name: "tone", Default: "plain English"},
+		},
+	}
+	if err := service.SaveTemplate(template); err != nil {
+		t.Fatalf("Failed to save template: %v", err)
+	}
+
+	prompt := &models.Prompt{
+		ID: "weekly", Name: "Weekly", Version: "1.0.0", TemplateRef: "report",
+		Content:   "Summarize {{.team}}'s week using {{.api_key}}. {{if .team}}ok{{end}}",
+		Variables: []models.Slot{{Name: "api_key", Secret: true}},
+		Slots:     map[string]string{"audience": "executives"},
+		Defaults:  map[string]string{"team": "platform"},
+		Presets:   map[string]map[string]string{"data": {"team": "data"}},
+	}
+
+	variables, err := service.PromptVariables(prompt, "")
+	if err != nil {
+		t.Fatalf("Failed to list variables: %v", err)
+	}
+	var names []string
+	for _, v := range variables {
+		names = append(names, v.Name+"="+v.Default)
+	}
+	if len(names) != 2 || names[0] != "team=platform" || names[1] != "tone=plain English" {
+		t.Errorf("Expected team and tone with their defaults, got %v", names)
+	}
+
+	variables, err = service.PromptVariables(prompt, "data")
+	if err != nil {
+		t.Fatalf("Failed to list variables with a preset: %v", err)
+	}
+	if variables[0].Default != "data" {
+		t.Errorf("Expected the preset's value as the default, got %q", variables[0].Default)
+	}
+
+	if _, err := service.PromptVariables(prompt, "missing"); err == nil {
+		t.Error("Expected an unknown preset to be rejected")
+	}
+}
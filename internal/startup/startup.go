@@ -0,0 +1,112 @@
+// Package startup times the phases of starting the application, for
+// --profile-startup. Recording is off until Enable is called, so the
+// phases cost nothing in normal runs.
+package startup
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// phase is the time spent in one part of startup
+type phase struct {
+	name     string
+	duration time.Duration
+	at       time.Duration // For marks, when the moment came from the start
+	mark     bool
+	count    int
+}
+
+var (
+	mu      sync.Mutex
+	enabled bool
+	start   time.Time
+	phases  []*phase
+)
+
+// Enable starts recording phases, timing marks from start
+func Enable(at time.Time) {
+	mu.Lock()
+	defer mu.Unlock()
+	enabled = true
+	start = at
+	phases = nil
+}
+
+// Enabled reports whether phases are being recorded
+func Enabled() bool {
+	mu.Lock()
+	defer mu.Unlock()
+	return enabled
+}
+
+// Phase starts timing a phase and returns the function that ends it.
+// Phases of the same name add up, such as the parsing of each file.
+func Phase(name string) func() {
+	if !Enabled() {
+		return func() {}
+	}
+	begin := time.Now()
+	return func() {
+		end := time.Now()
+		mu.Lock()
+		defer mu.Unlock()
+		p := find(name)
+		p.duration += end.Sub(begin)
+		p.count++
+	}
+}
+
+// Mark records the time from the start to a moment such as the first
+// paint. Only the first mark of a name is kept.
+func Mark(name string) {
+	if !Enabled() {
+		return
+	}
+	now := time.Now()
+	mu.Lock()
+	defer mu.Unlock()
+	if p := find(name); !p.mark {
+		p.at = now.Sub(start)
+		p.mark = true
+	}
+}
+
+// find returns the phase of a name, adding it when new. mu must be held.
+func find(name string) *phase {
+	for _, p := range phases {
+		if p.name == name {
+			return p
+		}
+	}
+	p := &phase{name: name}
+	phases = append(phases, p)
+	return p
+}
+
+// Report writes the phases in the order they were first recorded
+func Report(w io.Writer) {
+	mu.Lock()
+	defer mu.Unlock()
+	if !enabled {
+		return
+	}
+	fmt.Fprintln(w, "Startup profile:")
+	for _, p := range phases {
+		switch {
+		case p.mark:
+			fmt.Fprintf(w, "  %-16s at %s\n", p.name, round(p.at))
+		case p.count > 1:
+			fmt.Fprintf(w, "  %-16s %s (%d times)\n", p.name, round(p.duration), p.count)
+		default:
+			fmt.Fprintf(w, "  %-16s %s\n", p.name, round(p.duration))
+		}
+	}
+}
+
+// round keeps timings readable
+func round(d time.Duration) time.Duration {
+	return d.Round(time.Microsecond)
+}
@@ -0,0 +1,37 @@
+package startup
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReport(t *testing.T) {
+	t.Cleanup(func() { enabled = false })
+
+	Phase("ignored")()
+	Enable(time.Now())
+	for i := 0; i < 3; i++ {
+		Phase("parsing")()
+	}
+	end := Phase("storage walk")
+	end()
+	Mark("first paint")
+	Mark("first paint")
+
+	var out bytes.Buffer
+	Report(&out)
+	report := out.String()
+	if strings.Contains(report, "ignored") {
+		t.Errorf("Expected phases before Enable to be left out:\n%s", report)
+	}
+	for _, want := range []string{"parsing", "(3 times)", "storage walk", "first paint      at "} {
+		if !strings.Contains(report, want) {
+			t.Errorf("Expected %q in the report:\n%s", want, report)
+		}
+	}
+	if strings.Index(report, "parsing") > strings.Index(report, "storage walk") {
+		t.Errorf("Expected phases in the order they were recorded:\n%s", report)
+	}
+}
@@ -0,0 +1,92 @@
+package storage
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// AttachmentsDir holds the auxiliary files prompts refer to, such as
+// few-shot examples and schemas. Attachments are named by their path within
+// it, e.g. "examples/review.md".
+const AttachmentsDir = "attachments"
+
+// ValidateAttachmentName checks that an attachment name is a relative
+// slash-separated path that stays inside the attachments directory
+func ValidateAttachmentName(name string) error {
+	if name == "" {
+		return fmt.Errorf("attachment name must not be empty")
+	}
+	if strings.Contains(name, `\`) || path.IsAbs(name) || path.Clean(name) != name || name == ".." || strings.HasPrefix(name, "../") {
+		return fmt.Errorf("invalid attachment name %q: use a relative path such as examples/review.md", name)
+	}
+	return nil
+}
+
+// attachmentPath returns the path of an attachment relative to the library root
+func attachmentPath(name string) string {
+	return filepath.Join(AttachmentsDir, filepath.FromSlash(name))
+}
+
+// LoadAttachment reads an attachment
+func (s *Storage) LoadAttachment(name string) ([]byte, error) {
+	if err := ValidateAttachmentName(name); err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(filepath.Join(s.rootPath, attachmentPath(name)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read attachment: %w", err)
+	}
+	return data, nil
+}
+
+// SaveAttachment writes an attachment, creating its directory
+func (s *Storage) SaveAttachment(name string, data []byte) error {
+	if err := ValidateAttachmentName(name); err != nil {
+		return err
+	}
+	fullPath := filepath.Join(s.rootPath, attachmentPath(name))
+	if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	if err := os.WriteFile(fullPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write attachment: %w", err)
+	}
+	return nil
+}
+
+// ListAttachments returns the names of all attachments, sorted
+func (s *Storage) ListAttachments() ([]string, error) {
+	dir := filepath.Join(s.rootPath, AttachmentsDir)
+	var names []string
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && p == dir {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if d.IsDir() {
+			if p != dir && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if strings.HasPrefix(d.Name(), ".") {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		names = append(names, filepath.ToSlash(rel))
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list attachments: %w", err)
+	}
+	return names, nil
+}
@@ -0,0 +1,117 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/dpshade/pocket-prompt/internal/config"
+	"github.com/dpshade/pocket-prompt/internal/models"
+)
+
+// ArchivedTemplatesDir holds the old versions of templates, apart from the
+// archived prompts in the rest of archive/
+const ArchivedTemplatesDir = "archive/templates"
+
+// Backend is the persistence layer used by the service. Paths are relative
+// to the library root, e.g. "prompts/foo.md" or "archive/foo-v1.0.0.md".
+type Backend interface {
+	InitLibrary() error
+	GetBaseDir() string
+
+	LoadPrompt(path string) (*models.Prompt, error)
+	LoadPromptMetadata(path string) (*models.Prompt, error)
+	SavePrompt(prompt *models.Prompt) error
+	DeletePrompt(prompt *models.Prompt) error
+	// Listings stop with ctx's error when it is cancelled part way through
+	ListPrompts(ctx context.Context) ([]*models.Prompt, error)
+	ListArchivedPrompts(ctx context.Context) ([]*models.Prompt, error)
+
+	LoadTemplate(path string) (*models.Template, error)
+	SaveTemplate(template *models.Template) error
+	DeleteTemplate(template *models.Template) error
+	ListTemplates() ([]*models.Template, error)
+	ListArchivedTemplates() ([]*models.Template, error)
+
+	// Attachments are named by their path within AttachmentsDir
+	LoadAttachment(name string) ([]byte, error)
+	SaveAttachment(name string, data []byte) error
+	ListAttachments() ([]string, error)
+
+	// Snippets are named by their path within SnippetsDir, without .md
+	LoadSnippet(name string) (*models.Snippet, error)
+	SaveSnippet(snippet *models.Snippet) error
+	DeleteSnippet(name string) error
+	ListSnippets() ([]*models.Snippet, error)
+
+	// ValidationIssues reports files that could not be loaded cleanly
+	ValidationIssues() []ValidationIssue
+}
+
+var (
+	_ Backend = (*Storage)(nil)
+	_ Backend = (*SQLiteStorage)(nil)
+	_ Backend = (*RemoteStorage)(nil)
+	_ Backend = (*MemoryStorage)(nil)
+)
+
+// ResolveRootPath returns rootPath, or ~/.pocket-prompt when it is empty
+func ResolveRootPath(rootPath string) (string, error) {
+	if rootPath != "" {
+		return rootPath, nil
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, ".pocket-prompt"), nil
+}
+
+// Open creates the backend selected by the storage config
+func Open(rootPath string, cfg config.StorageConfig) (Backend, error) {
+	rootPath, err := ResolveRootPath(rootPath)
+	if err != nil {
+		return nil, err
+	}
+
+	format, err := ParseFrontmatterFormat(cfg.Frontmatter)
+	if err != nil {
+		return nil, err
+	}
+
+	switch cfg.Backend {
+	case "", "files":
+		s, err := NewStorage(rootPath)
+		if err != nil {
+			return nil, err
+		}
+		s.SetFrontmatterFormat(format)
+		return s, nil
+	case "sqlite":
+		return NewSQLiteStorage(rootPath, cfg.SQLite.Path)
+	case "s3":
+		store, err := NewS3Store(cfg.S3)
+		if err != nil {
+			return nil, err
+		}
+		return newRemoteWithFormat(rootPath, store, format)
+	case "webdav":
+		store, err := NewWebDAVStore(cfg.WebDAV)
+		if err != nil {
+			return nil, err
+		}
+		return newRemoteWithFormat(rootPath, store, format)
+	default:
+		return nil, fmt.Errorf("unknown storage backend: %s", cfg.Backend)
+	}
+}
+
+func newRemoteWithFormat(rootPath string, store ObjectStore, format FrontmatterFormat) (*RemoteStorage, error) {
+	r, err := NewRemoteStorage(rootPath, store)
+	if err != nil {
+		return nil, err
+	}
+	r.local.SetFrontmatterFormat(format)
+	return r, nil
+}
@@ -1,38 +1,60 @@
 package storage
 
 import (
-	"crypto/sha256"
-	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/dpshade/pocket-prompt/internal/models"
 )
 
+// cacheVersion is bumped whenever the on-disk index format changes so stale
+// indexes are discarded instead of being misread
+const cacheVersion = 11
+
 // PromptMetadata represents cached metadata for a prompt
 type PromptMetadata struct {
-	ID          string            `json:"id"`
-	Version     string            `json:"version"`
-	Name        string            `json:"name"`
-	Summary     string            `json:"summary"`
-	Tags        []string          `json:"tags"`
-	TemplateRef string            `json:"template_ref,omitempty"`
-	CreatedAt   time.Time         `json:"created_at"`
-	UpdatedAt   time.Time         `json:"updated_at"`
-	FilePath    string            `json:"file_path"`
-	ModTime     time.Time         `json:"mod_time"`
-	FileHash    string            `json:"file_hash"`
+	ID          string                       `json:"id"`
+	Version     string                       `json:"version"`
+	Name        string                       `json:"name"`
+	Summary     string                       `json:"summary"`
+	Tags        []string                     `json:"tags"`
+	TemplateRef string                       `json:"template_ref,omitempty"`
+	Slots       map[string]string            `json:"slots,omitempty"`
+	Variables   []models.Slot                `json:"variables,omitempty"`
+	Defaults    map[string]string            `json:"defaults,omitempty"`
+	Presets     map[string]map[string]string `json:"presets,omitempty"`
+	Metadata    map[string]interface{}       `json:"metadata,omitempty"`
+	Attachments []string                     `json:"attachments,omitempty"`
+	Encrypted   bool                         `json:"encrypted,omitempty"`
+	Draft       bool                         `json:"draft,omitempty"`
+	Rating      int                          `json:"rating,omitempty"`
+	Notes       string                       `json:"notes,omitempty"`
+	CreatedAt   time.Time                    `json:"created_at"`
+	UpdatedAt   time.Time                    `json:"updated_at"`
+	FilePath    string                       `json:"file_path"`
+	ModTime     time.Time                    `json:"mod_time"`
+	Size        int64                        `json:"size"`
+	FileHash    string                       `json:"file_hash"`
+}
+
+// cacheIndex is the on-disk representation of the metadata cache
+type cacheIndex struct {
+	Version int                        `json:"version"`
+	Entries map[string]*PromptMetadata `json:"entries"`
 }
 
 // MetadataCache handles caching of prompt metadata
 type MetadataCache struct {
 	cacheDir  string
 	cacheFile string
+	mu        sync.Mutex
 	metadata  map[string]*PromptMetadata
+	modified  bool
 }
 
 // NewMetadataCache creates a new metadata cache
@@ -40,7 +62,7 @@ func NewMetadataCache(baseDir string) *MetadataCache {
 	cacheDir := filepath.Join(baseDir, ".pocket-prompt", "cache")
 	return &MetadataCache{
 		cacheDir:  cacheDir,
-		cacheFile: filepath.Join(cacheDir, "metadata.json"),
+		cacheFile: filepath.Join(cacheDir, "index.json"),
 		metadata:  make(map[string]*PromptMetadata),
 	}
 }
@@ -52,6 +74,9 @@ func (c *MetadataCache) Load() error {
 		return fmt.Errorf("failed to create cache directory: %w", err)
 	}
 
+	// Remove the unversioned cache written by older releases
+	os.Remove(filepath.Join(c.cacheDir, "metadata.json"))
+
 	// Load existing cache if it exists
 	if _, err := os.Stat(c.cacheFile); os.IsNotExist(err) {
 		return nil // No cache file exists yet
@@ -62,53 +87,99 @@ func (c *MetadataCache) Load() error {
 		return fmt.Errorf("failed to read cache file: %w", err)
 	}
 
-	if err := json.Unmarshal(data, &c.metadata); err != nil {
-		// If cache is corrupted, start fresh
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var index cacheIndex
+	if err := json.Unmarshal(data, &index); err != nil || index.Version != cacheVersion || index.Entries == nil {
+		// If cache is corrupted or outdated, start fresh
 		c.metadata = make(map[string]*PromptMetadata)
+		c.modified = true
 		return nil
 	}
 
+	c.metadata = index.Entries
 	return nil
 }
 
 // Save saves the metadata cache to disk
 func (c *MetadataCache) Save() error {
-	data, err := json.MarshalIndent(c.metadata, "", "  ")
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	data, err := json.MarshalIndent(cacheIndex{Version: cacheVersion, Entries: c.metadata}, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal cache: %w", err)
 	}
 
-	if err := os.WriteFile(c.cacheFile, data, 0644); err != nil {
+	if err := os.MkdirAll(c.cacheDir, 0755); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	// Write to a temporary file first so a crash never leaves a truncated index
+	tmpFile := c.cacheFile + ".tmp"
+	if err := os.WriteFile(tmpFile, data, 0644); err != nil {
+		return fmt.Errorf("failed to write cache file: %w", err)
+	}
+	if err := os.Rename(tmpFile, c.cacheFile); err != nil {
+		os.Remove(tmpFile)
 		return fmt.Errorf("failed to write cache file: %w", err)
 	}
 
+	c.modified = false
 	return nil
 }
 
-// Get retrieves metadata for a file, checking if cache is valid
-func (c *MetadataCache) Get(filePath string, fileInfo os.FileInfo) (*PromptMetadata, bool) {
-	cached, exists := c.metadata[filePath]
+// Modified reports whether the cache has changes that have not been saved
+func (c *MetadataCache) Modified() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.modified
+}
+
+// Get retrieves metadata for a file, checking if cache is valid. When the
+// modification time changed but the size did not (e.g. after a git checkout),
+// the content hash decides whether the entry is still usable, if the hash is
+// known from loading the body.
+func (c *MetadataCache) Get(relPath string, fullPath string, fileInfo os.FileInfo) (*PromptMetadata, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	cached, exists := c.metadata[relPath]
 	if !exists {
 		return nil, false
 	}
 
+	if fileInfo.Size() != cached.Size {
+		return nil, false
+	}
+
 	// Check if file has been modified
-	if !fileInfo.ModTime().Equal(cached.ModTime) {
+	if fileInfo.ModTime().Equal(cached.ModTime) {
+		return cached, true
+	}
+
+	// Touched but possibly unchanged - compare content hashes. Without a
+	// hash, parsing the frontmatter again is cheaper than hashing the file.
+	if cached.FileHash == "" {
+		return nil, false
+	}
+	data, err := os.ReadFile(fullPath)
+	if err != nil || calculateHash(data) != cached.FileHash {
 		return nil, false
 	}
 
-	return cached, true
+	// Entries may be in use by earlier callers, so they are replaced rather
+	// than updated in place
+	touched := *cached
+	touched.ModTime = fileInfo.ModTime()
+	c.metadata[relPath] = &touched
+	c.modified = true
+	return &touched, true
 }
 
-// Set stores metadata in the cache
+// Set stores metadata in the cache. The file hash is only known when the
+// prompt was loaded with its body; SetHash records it later otherwise.
 func (c *MetadataCache) Set(relPath string, fullPath string, fileInfo os.FileInfo, prompt *models.Prompt) {
-	// Calculate file hash for additional validation
-	fileHash := ""
-	if data, err := os.ReadFile(fullPath); err == nil {
-		hash := sha256.Sum256(data)
-		fileHash = hex.EncodeToString(hash[:])
-	}
-
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	c.metadata[relPath] = &PromptMetadata{
 		ID:          prompt.ID,
 		Version:     prompt.Version,
@@ -116,12 +187,39 @@ func (c *MetadataCache) Set(relPath string, fullPath string, fileInfo os.FileInf
 		Summary:     prompt.Summary,
 		Tags:        prompt.Tags,
 		TemplateRef: prompt.TemplateRef,
+		Slots:       prompt.Slots,
+		Variables:   prompt.Variables,
+		Defaults:    prompt.Defaults,
+		Presets:     prompt.Presets,
+		Metadata:    prompt.Metadata,
+		Attachments: prompt.Attachments,
+		Encrypted:   prompt.Encrypted,
+		Draft:       prompt.Draft,
+		Rating:      prompt.Rating,
+		Notes:       prompt.Notes,
 		CreatedAt:   prompt.CreatedAt,
 		UpdatedAt:   prompt.UpdatedAt,
 		FilePath:    prompt.FilePath,
 		ModTime:     fileInfo.ModTime(),
-		FileHash:    fileHash,
+		Size:        fileInfo.Size(),
+		FileHash:    prompt.ContentHash,
+	}
+	c.modified = true
+}
+
+// SetHash records the hash of a file whose body was loaded, if its entry is
+// still current
+func (c *MetadataCache) SetHash(relPath string, fileInfo os.FileInfo, hash string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	cached, exists := c.metadata[relPath]
+	if !exists || cached.FileHash == hash || cached.Size != fileInfo.Size() || !cached.ModTime.Equal(fileInfo.ModTime()) {
+		return
 	}
+	hashed := *cached
+	hashed.FileHash = hash
+	c.metadata[relPath] = &hashed
+	c.modified = true
 }
 
 // ToPrompt converts cached metadata back to a Prompt (without content)
@@ -133,18 +231,34 @@ func (m *PromptMetadata) ToPrompt() *models.Prompt {
 		Summary:     m.Summary,
 		Tags:        m.Tags,
 		TemplateRef: m.TemplateRef,
+		Slots:       m.Slots,
+		Variables:   m.Variables,
+		Defaults:    m.Defaults,
+		Presets:     m.Presets,
+		Metadata:    m.Metadata,
+		Attachments: m.Attachments,
+		Encrypted:   m.Encrypted,
+		Draft:       m.Draft,
+		Rating:      m.Rating,
+		Notes:       m.Notes,
 		CreatedAt:   m.CreatedAt,
 		UpdatedAt:   m.UpdatedAt,
 		FilePath:    m.FilePath,
+		ContentHash: m.FileHash,
 		Content:     "", // Content loaded on demand
 	}
 }
 
-// Cleanup removes cache entries for files that no longer exist
-func (c *MetadataCache) Cleanup(existingFiles map[string]bool) {
+// Cleanup removes cache entries for files that no longer exist under the
+// given directory prefix
+func (c *MetadataCache) Cleanup(dir string, existingFiles map[string]bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	prefix := dir + string(filepath.Separator)
 	for filePath := range c.metadata {
-		if !existingFiles[filePath] {
+		if strings.HasPrefix(filePath, prefix) && !existingFiles[filePath] {
 			delete(c.metadata, filePath)
+			c.modified = true
 		}
 	}
 }
@@ -152,4 +266,4 @@ func (c *MetadataCache) Cleanup(existingFiles map[string]bool) {
 // IsArchived checks if a metadata entry represents an archived prompt
 func (m *PromptMetadata) IsArchived() bool {
 	return strings.HasPrefix(m.FilePath, "archive/")
-}
\ No newline at end of file
+}
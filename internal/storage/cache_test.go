@@ -0,0 +1,140 @@
+package storage
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dpshade/pocket-prompt/internal/models"
+)
+
+func TestMetadataCachePersistsAcrossInstances(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "pocket-prompt-cache-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	store, err := NewStorage(tmpDir)
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+	if err := store.InitLibrary(); err != nil {
+		t.Fatalf("Failed to init library: %v", err)
+	}
+
+	prompt := &models.Prompt{
+		ID:       "cached",
+		Version:  "1.0.0",
+		Name:     "Cached Prompt",
+		Tags:     []string{"one"},
+		Metadata: map[string]interface{}{"model": "test"},
+		Content:  "Body",
+		FilePath: filepath.Join("prompts", "cached.md"),
+	}
+	if err := store.SavePrompt(prompt); err != nil {
+		t.Fatalf("Failed to save prompt: %v", err)
+	}
+	if _, err := store.ListPrompts(context.Background()); err != nil {
+		t.Fatalf("Failed to list prompts: %v", err)
+	}
+	// Loading the body records its hash, saved with the next listing
+	if _, err := store.LoadPrompt(prompt.FilePath); err != nil {
+		t.Fatalf("Failed to load prompt: %v", err)
+	}
+	if _, err := store.ListPrompts(context.Background()); err != nil {
+		t.Fatalf("Failed to list prompts: %v", err)
+	}
+
+	// A fresh storage instance should serve the prompt from the index
+	reopened, err := NewStorage(tmpDir)
+	if err != nil {
+		t.Fatalf("Failed to reopen storage: %v", err)
+	}
+	fullPath := filepath.Join(tmpDir, prompt.FilePath)
+	info, err := os.Stat(fullPath)
+	if err != nil {
+		t.Fatalf("Failed to stat prompt: %v", err)
+	}
+	cached, ok := reopened.cache.Get(prompt.FilePath, fullPath, info)
+	if !ok {
+		t.Fatal("Expected cache hit after reopening storage")
+	}
+	if cached.Name != "Cached Prompt" || cached.Metadata["model"] != "test" {
+		t.Errorf("Unexpected cached metadata: %+v", cached)
+	}
+
+	// Touching the file without changing content should still hit via hash
+	later := info.ModTime().Add(time.Hour)
+	if err := os.Chtimes(fullPath, later, later); err != nil {
+		t.Fatalf("Failed to touch prompt: %v", err)
+	}
+	info, _ = os.Stat(fullPath)
+	if _, ok := reopened.cache.Get(prompt.FilePath, fullPath, info); !ok {
+		t.Error("Expected cache hit for touched but unchanged file")
+	}
+
+	// Changing content must invalidate the entry
+	prompt.Content = "Changed body"
+	if err := reopened.SavePrompt(prompt); err != nil {
+		t.Fatalf("Failed to update prompt: %v", err)
+	}
+	info, _ = os.Stat(fullPath)
+	if _, ok := reopened.cache.Get(prompt.FilePath, fullPath, info); ok {
+		t.Error("Expected cache miss for modified file")
+	}
+}
+
+func TestMetadataCacheCleanupKeepsOtherDirectories(t *testing.T) {
+	cache := NewMetadataCache(t.TempDir())
+	cache.metadata[filepath.Join("prompts", "a.md")] = &PromptMetadata{ID: "a"}
+	cache.metadata[filepath.Join("archive", "b-v1.0.0.md")] = &PromptMetadata{ID: "b"}
+
+	cache.Cleanup("prompts", map[string]bool{})
+
+	if _, ok := cache.metadata[filepath.Join("prompts", "a.md")]; ok {
+		t.Error("Expected removed prompt to be cleaned up")
+	}
+	if _, ok := cache.metadata[filepath.Join("archive", "b-v1.0.0.md")]; !ok {
+		t.Error("Expected archived entry to survive cleanup of prompts directory")
+	}
+	if !cache.Modified() {
+		t.Error("Expected cache to be marked modified after cleanup")
+	}
+}
+
+func TestMetadataCacheReloadWhileInUse(t *testing.T) {
+	dir := t.TempDir()
+	cache := NewMetadataCache(dir)
+	if err := cache.Save(); err != nil {
+		t.Fatalf("Failed to save cache: %v", err)
+	}
+	path := filepath.Join(dir, "prompts", "a.md")
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	if err := os.WriteFile(path, []byte("body"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Failed to stat file: %v", err)
+	}
+
+	// A reload racing a listing must not touch the index unlocked
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 50; i++ {
+			cache.Load()
+		}
+	}()
+	for i := 0; i < 50; i++ {
+		cache.Set(filepath.Join("prompts", "a.md"), path, info, &models.Prompt{ID: "a"})
+		cache.Get(filepath.Join("prompts", "a.md"), path, info)
+		cache.Modified()
+	}
+	<-done
+}
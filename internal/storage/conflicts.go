@@ -0,0 +1,94 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ConflictCopy is a duplicate of a library file made by a file sync service
+// such as Dropbox, iCloud or Syncthing when two devices changed the file at
+// the same time
+type ConflictCopy struct {
+	Path     string    // The copy, relative to the library root
+	Original string    // The file it duplicates, relative to the library root
+	Source   string    // The sync service that made it
+	ModTime  time.Time // When the copy was last written
+}
+
+// ConflictLister is implemented by backends that keep the library in plain
+// files, where sync services can leave conflict copies
+type ConflictLister interface {
+	ListConflicts() ([]ConflictCopy, error)
+}
+
+var (
+	// Syncthing: "review.sync-conflict-20240102-150405-ABCDEFG.md"
+	syncthingConflict = regexp.MustCompile(`^(.+)\.sync-conflict-\d{8}-\d{6}(-[A-Za-z0-9]+)?$`)
+	// Dropbox: "review (Ana's conflicted copy 2024-01-02).md", Nextcloud:
+	// "review (conflicted copy 2024-01-02 150405).md"
+	conflictedCopy = regexp.MustCompile(`^(.+) \([^()]*[Cc]onflicted [Cc]opy[^()]*\)$`)
+	// iCloud Drive: "review 2.md"
+	numberedCopy = regexp.MustCompile(`^(.+) \d+$`)
+)
+
+// conflictCopyOf reports whether a file is a conflict copy and which file
+// it duplicates. Numbered copies only count when the original exists, since
+// a number is a legitimate end of a file name.
+func conflictCopyOf(rootPath, relPath string) (ConflictCopy, bool) {
+	dir, name := filepath.Split(relPath)
+	ext := filepath.Ext(name)
+	stem := strings.TrimSuffix(name, ext)
+
+	conflict := ConflictCopy{Path: relPath}
+	if m := syncthingConflict.FindStringSubmatch(stem); m != nil {
+		conflict.Original, conflict.Source = dir+m[1]+ext, "Syncthing"
+	} else if m := conflictedCopy.FindStringSubmatch(stem); m != nil {
+		conflict.Original, conflict.Source = dir+m[1]+ext, "Dropbox"
+	} else if m := numberedCopy.FindStringSubmatch(stem); m != nil {
+		original := dir + m[1] + ext
+		if _, err := os.Stat(filepath.Join(rootPath, original)); err != nil {
+			return ConflictCopy{}, false
+		}
+		conflict.Original, conflict.Source = original, "iCloud"
+	} else {
+		return ConflictCopy{}, false
+	}
+	return conflict, true
+}
+
+// ListConflicts returns the conflict copies of prompts, archived prompts and
+// templates, oldest first
+func (s *Storage) ListConflicts() ([]ConflictCopy, error) {
+	var conflicts []ConflictCopy
+	for _, dir := range []string{"prompts", "archive", "templates"} {
+		err := filepath.Walk(filepath.Join(s.rootPath, dir), func(path string, info os.FileInfo, err error) error {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			if info.IsDir() || !strings.HasSuffix(path, ".md") {
+				return nil
+			}
+			relPath, _ := filepath.Rel(s.rootPath, path)
+			if conflict, ok := conflictCopyOf(s.rootPath, relPath); ok {
+				conflict.ModTime = info.ModTime()
+				conflicts = append(conflicts, conflict)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sort.SliceStable(conflicts, func(i, j int) bool {
+		return conflicts[i].ModTime.Before(conflicts[j].ModTime)
+	})
+	return conflicts, nil
+}
@@ -0,0 +1,77 @@
+package storage
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestListConflicts(t *testing.T) {
+	tmpDir := t.TempDir()
+	store, err := NewStorage(tmpDir)
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+	if err := store.InitLibrary(); err != nil {
+		t.Fatalf("Failed to init library: %v", err)
+	}
+
+	files := []string{
+		"prompts/review.md",
+		"prompts/review (Ana's conflicted copy 2024-01-02).md",
+		"prompts/review.sync-conflict-20240102-150405-ABCDEFG.md",
+		"prompts/review 2.md",
+		"prompts/chapter 3.md",
+		"templates/base.md",
+		"templates/base (conflicted copy 2024-01-02 150405).md",
+	}
+	for _, file := range files {
+		if err := os.WriteFile(filepath.Join(tmpDir, file), []byte("---\nid: x\n---\nbody\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	conflicts, err := store.ListConflicts()
+	if err != nil {
+		t.Fatalf("Failed to list conflicts: %v", err)
+	}
+	found := make(map[string]ConflictCopy)
+	for _, conflict := range conflicts {
+		found[filepath.ToSlash(conflict.Path)] = conflict
+	}
+	expected := map[string]string{
+		"prompts/review (Ana's conflicted copy 2024-01-02).md":    "Dropbox",
+		"prompts/review.sync-conflict-20240102-150405-ABCDEFG.md": "Syncthing",
+		"prompts/review 2.md": "iCloud",
+		"templates/base (conflicted copy 2024-01-02 150405).md": "Dropbox",
+	}
+	if len(found) != len(expected) {
+		t.Errorf("Expected %d conflicts, got %+v", len(expected), conflicts)
+	}
+	for path, source := range expected {
+		conflict, ok := found[path]
+		if !ok {
+			t.Errorf("Expected %s to be a conflict copy", path)
+			continue
+		}
+		if conflict.Source != source {
+			t.Errorf("Expected %s to come from %s, got %s", path, source, conflict.Source)
+		}
+		if filepath.Base(conflict.Original) != "review.md" && filepath.Base(conflict.Original) != "base.md" {
+			t.Errorf("Unexpected original %s for %s", conflict.Original, path)
+		}
+	}
+
+	// Conflict copies are not loaded as prompts
+	prompts, _ := store.ListPrompts(context.Background())
+	loaded := 0
+	for _, prompt := range prompts {
+		if filepath.Base(prompt.FilePath) == "review.md" || filepath.Base(prompt.FilePath) == "chapter 3.md" {
+			loaded++
+		}
+	}
+	if len(prompts) != 2 || loaded != 2 {
+		t.Errorf("Expected only review.md and chapter 3.md to load, got %d prompts", len(prompts))
+	}
+}
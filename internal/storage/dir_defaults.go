@@ -0,0 +1,177 @@
+package storage
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/dpshade/pocket-prompt/internal/models"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultsFile in prompts/ or one of its subdirectories holds frontmatter
+// fields every prompt under it gets, so a category doesn't repeat the same
+// tags, metadata and template in each file. Fields a prompt sets win, and
+// its tags are added to. A subdirectory's defaults win over its parent's.
+const DefaultsFile = "_defaults.yaml"
+
+// DirDefaults are the fields a DefaultsFile can set
+type DirDefaults struct {
+	Tags        []string               `yaml:"tags,omitempty"`
+	TemplateRef string                 `yaml:"template,omitempty"`
+	Metadata    map[string]interface{} `yaml:"metadata,omitempty"`
+}
+
+// defaultsDirs returns the directories whose defaults apply to a prompt
+// file, from the top down, e.g. prompts and prompts/team for
+// prompts/team/launch.md. Only prompts under prompts/ have defaults.
+func defaultsDirs(relPath string) []string {
+	dir := path.Dir(filepath.ToSlash(relPath))
+	if dir != "prompts" && !strings.HasPrefix(dir, "prompts/") {
+		return nil
+	}
+	var dirs []string
+	for d := dir; ; d = path.Dir(d) {
+		dirs = append(dirs, d)
+		if d == "prompts" {
+			break
+		}
+	}
+	slices.Reverse(dirs)
+	return dirs
+}
+
+// defaultsLoader reads the defaults of directories, remembering them for
+// the length of one listing
+type defaultsLoader struct {
+	read  func(relPath string) ([]byte, error)
+	dirs  map[string]*DirDefaults
+	onErr func(relPath string, err error) // Told about each invalid file once
+}
+
+func newDefaultsLoader(read func(relPath string) ([]byte, error), onErr func(relPath string, err error)) *defaultsLoader {
+	return &defaultsLoader{read: read, dirs: make(map[string]*DirDefaults), onErr: onErr}
+}
+
+// dir returns the defaults of one directory, nil when it has none
+func (l *defaultsLoader) dir(dir string) *DirDefaults {
+	if d, ok := l.dirs[dir]; ok {
+		return d
+	}
+	var defaults *DirDefaults
+	relPath := path.Join(dir, DefaultsFile)
+	if data, err := l.read(relPath); err == nil {
+		defaults = &DirDefaults{}
+		if err := yaml.Unmarshal(data, defaults); err != nil {
+			defaults = nil
+			if l.onErr != nil {
+				l.onErr(relPath, fmt.Errorf("invalid defaults: %w", err))
+			}
+		}
+	}
+	l.dirs[dir] = defaults
+	return defaults
+}
+
+// forPrompt returns the defaults that apply to a prompt file, nearer
+// directories winning, or nil when there are none
+func (l *defaultsLoader) forPrompt(relPath string) *DirDefaults {
+	var merged *DirDefaults
+	for _, dir := range defaultsDirs(relPath) {
+		d := l.dir(dir)
+		if d == nil {
+			continue
+		}
+		if merged == nil {
+			merged = &DirDefaults{}
+		}
+		for _, tag := range d.Tags {
+			if !slices.Contains(merged.Tags, tag) {
+				merged.Tags = append(merged.Tags, tag)
+			}
+		}
+		if d.TemplateRef != "" {
+			merged.TemplateRef = d.TemplateRef
+		}
+		for key, value := range d.Metadata {
+			if merged.Metadata == nil {
+				merged.Metadata = make(map[string]interface{})
+			}
+			merged.Metadata[key] = value
+		}
+	}
+	return merged
+}
+
+// apply fills in the fields the prompt leaves out. The prompt's tags and
+// metadata are copied rather than changed, as listings may share them with
+// the metadata cache.
+func (d *DirDefaults) apply(prompt *models.Prompt) {
+	if d == nil {
+		return
+	}
+	for _, tag := range d.Tags {
+		if !slices.Contains(prompt.Tags, tag) {
+			prompt.Tags = append(slices.Clip(prompt.Tags), tag)
+		}
+	}
+	if prompt.TemplateRef == "" {
+		prompt.TemplateRef = d.TemplateRef
+	}
+	if len(d.Metadata) > 0 {
+		metadata := make(map[string]interface{}, len(prompt.Metadata)+len(d.Metadata))
+		for key, value := range d.Metadata {
+			metadata[key] = value
+		}
+		for key, value := range prompt.Metadata {
+			metadata[key] = value
+		}
+		prompt.Metadata = metadata
+	}
+}
+
+// strip returns a copy of the prompt without the fields it has from the
+// defaults, for writing to its file
+func (d *DirDefaults) strip(prompt *models.Prompt) *models.Prompt {
+	if d == nil {
+		return prompt
+	}
+	own := *prompt
+	own.Tags = slices.DeleteFunc(slices.Clone(prompt.Tags), func(tag string) bool {
+		return slices.Contains(d.Tags, tag)
+	})
+	if own.TemplateRef == d.TemplateRef {
+		own.TemplateRef = ""
+	}
+	if len(prompt.Metadata) > 0 && len(d.Metadata) > 0 {
+		own.Metadata = make(map[string]interface{}, len(prompt.Metadata))
+		for key, value := range prompt.Metadata {
+			if dv, ok := d.Metadata[key]; !ok || !reflect.DeepEqual(dv, value) {
+				own.Metadata[key] = value
+			}
+		}
+		if len(own.Metadata) == 0 {
+			own.Metadata = nil
+		}
+	}
+	return &own
+}
+
+// fileDefaults returns the defaults of a prompt file in the library at
+// rootPath, reporting invalid defaults files in the log
+func fileDefaults(rootPath, relPath string) *DirDefaults {
+	loader := newDefaultsLoader(func(p string) ([]byte, error) {
+		return os.ReadFile(filepath.Join(rootPath, filepath.FromSlash(p)))
+	}, warnInvalidDefaults)
+	return loader.forPrompt(relPath)
+}
+
+// warnInvalidDefaults logs a defaults file that couldn't be read
+func warnInvalidDefaults(relPath string, err error) {
+	slog.Warn("Ignored invalid defaults file", "path", relPath, "error", err)
+}
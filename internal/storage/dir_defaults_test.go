@@ -0,0 +1,124 @@
+package storage
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/dpshade/pocket-prompt/internal/models"
+)
+
+func TestDirDefaults(t *testing.T) {
+	tmpDir := t.TempDir()
+	store, err := NewStorage(tmpDir)
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+	if err := store.InitLibrary(); err != nil {
+		t.Fatalf("Failed to init library: %v", err)
+	}
+	writeFile := func(relPath, data string) {
+		t.Helper()
+		fullPath := filepath.Join(tmpDir, filepath.FromSlash(relPath))
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(fullPath, []byte(data), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", relPath, err)
+		}
+	}
+	writeFile("prompts/_defaults.yaml", "tags: [library]\nmetadata:\n  owner: everyone\n  tone: plain\n")
+	writeFile("prompts/marketing/_defaults.yaml", "tags: [marketing]\ntemplate: campaign\nmetadata:\n  owner: marketing\n")
+
+	prompt := &models.Prompt{
+		ID:       "launch",
+		Version:  "1.0.0",
+		Name:     "Launch",
+		Tags:     []string{"email"},
+		Metadata: map[string]interface{}{"tone": "upbeat"},
+		Content:  "Announce the launch",
+		FilePath: "prompts/marketing/launch.md",
+	}
+	if err := store.SavePrompt(prompt); err != nil {
+		t.Fatalf("Failed to save prompt: %v", err)
+	}
+
+	check := func(what string, got *models.Prompt) {
+		t.Helper()
+		if want := []string{"email", "library", "marketing"}; !slices.Equal(got.Tags, want) {
+			t.Errorf("%s: expected tags %v, got %v", what, want, got.Tags)
+		}
+		if got.TemplateRef != "campaign" {
+			t.Errorf("%s: expected template from the nearest defaults, got %q", what, got.TemplateRef)
+		}
+		if got.Metadata["owner"] != "marketing" || got.Metadata["tone"] != "upbeat" {
+			t.Errorf("%s: expected nearer defaults and the prompt's own metadata to win, got %v", what, got.Metadata)
+		}
+	}
+
+	loaded, err := store.LoadPrompt(prompt.FilePath)
+	if err != nil {
+		t.Fatalf("Failed to load prompt: %v", err)
+	}
+	check("load", loaded)
+
+	// The second listing comes from the metadata cache
+	for i := 0; i < 2; i++ {
+		listed, err := store.ListPrompts(context.Background())
+		if err != nil || len(listed) != 1 {
+			t.Fatalf("Expected one prompt listed, got %v, %v", listed, err)
+		}
+		check("list", listed[0])
+	}
+
+	// Saving what was loaded doesn't copy the defaults into the file
+	loaded.Tags = append(loaded.Tags, "q3")
+	if err := store.SavePrompt(loaded); err != nil {
+		t.Fatalf("Failed to save prompt: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(tmpDir, "prompts", "marketing", "launch.md"))
+	if err != nil {
+		t.Fatalf("Failed to read prompt file: %v", err)
+	}
+	for _, inherited := range []string{"library", "campaign", "owner"} {
+		if strings.Contains(string(data), inherited) {
+			t.Errorf("Expected %q left to the defaults, got:\n%s", inherited, data)
+		}
+	}
+	if !strings.Contains(string(data), "q3") || !strings.Contains(string(data), "upbeat") {
+		t.Errorf("Expected the prompt's own fields kept, got:\n%s", data)
+	}
+
+	// Changed defaults show up even for cached prompts
+	writeFile("prompts/marketing/_defaults.yaml", "tags: [campaigns]\n")
+	listed, err := store.ListPrompts(context.Background())
+	if err != nil || len(listed) != 1 {
+		t.Fatalf("Expected one prompt listed, got %v, %v", listed, err)
+	}
+	if !slices.Contains(listed[0].Tags, "campaigns") || slices.Contains(listed[0].Tags, "marketing") {
+		t.Errorf("Expected the new defaults, got tags %v", listed[0].Tags)
+	}
+	if listed[0].TemplateRef != "" {
+		t.Errorf("Expected no template, got %q", listed[0].TemplateRef)
+	}
+}
+
+func TestDirDefaultsInvalidFile(t *testing.T) {
+	store := NewMemoryStorage(t.TempDir())
+	store.WriteFile("prompts/_defaults.yaml", []byte("tags: [unclosed\n"))
+	if err := store.SavePrompt(&models.Prompt{ID: "a", Version: "1.0.0", Name: "A", FilePath: "prompts/a.md"}); err != nil {
+		t.Fatalf("Failed to save prompt: %v", err)
+	}
+
+	listed, err := store.ListPrompts(context.Background())
+	if err != nil || len(listed) != 1 {
+		t.Fatalf("Expected the prompt listed anyway, got %v, %v", listed, err)
+	}
+	issues := store.ValidationIssues()
+	if len(issues) != 1 || issues[0].FilePath != "prompts/_defaults.yaml" {
+		t.Errorf("Expected an issue for the defaults file, got %+v", issues)
+	}
+}
@@ -0,0 +1,68 @@
+package storage
+
+import (
+	"fmt"
+
+	"github.com/dpshade/pocket-prompt/internal/encryption"
+	"github.com/dpshade/pocket-prompt/internal/models"
+)
+
+// EncryptedBackend wraps a Backend and encrypts the body of prompts marked
+// `encrypted: true`. Frontmatter stays in plain text so listing and search
+// keep working without keys; bodies are decrypted only when loaded in full.
+type EncryptedBackend struct {
+	Backend
+	enc encryption.Encryptor
+}
+
+// NewEncryptedBackend wraps backend. enc may be nil, in which case saving an
+// encrypted prompt fails rather than writing its body in plain text.
+func NewEncryptedBackend(backend Backend, enc encryption.Encryptor) *EncryptedBackend {
+	return &EncryptedBackend{
+		Backend: backend,
+		enc:     enc,
+	}
+}
+
+// LoadPrompt loads a prompt and decrypts its body if needed
+func (e *EncryptedBackend) LoadPrompt(path string) (*models.Prompt, error) {
+	prompt, err := e.Backend.LoadPrompt(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if !prompt.Encrypted || !encryption.IsEncrypted(prompt.Content) {
+		return prompt, nil
+	}
+
+	if e.enc == nil {
+		return nil, fmt.Errorf("prompt %s is encrypted but no encryption is configured", prompt.ID)
+	}
+	plaintext, err := e.enc.Decrypt([]byte(prompt.Content))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt prompt %s: %w", prompt.ID, err)
+	}
+	prompt.Content = string(plaintext)
+
+	return prompt, nil
+}
+
+// SavePrompt encrypts the body of encrypted prompts before saving. The
+// caller's prompt keeps its plain text content.
+func (e *EncryptedBackend) SavePrompt(prompt *models.Prompt) error {
+	if !prompt.Encrypted || prompt.Content == "" || encryption.IsEncrypted(prompt.Content) {
+		return e.Backend.SavePrompt(prompt)
+	}
+
+	if e.enc == nil {
+		return fmt.Errorf("prompt %s is marked encrypted but no encryption is configured", prompt.ID)
+	}
+	ciphertext, err := e.enc.Encrypt([]byte(prompt.Content))
+	if err != nil {
+		return fmt.Errorf("failed to encrypt prompt %s: %w", prompt.ID, err)
+	}
+
+	encrypted := *prompt
+	encrypted.Content = string(ciphertext)
+	return e.Backend.SavePrompt(&encrypted)
+}
@@ -0,0 +1,84 @@
+package storage
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dpshade/pocket-prompt/internal/models"
+)
+
+// reverseEncryptor is a stand-in for gpg/age that produces armored output
+type reverseEncryptor struct{}
+
+const testArmor = "-----BEGIN AGE ENCRYPTED FILE-----\n"
+
+func (reverseEncryptor) Encrypt(plaintext []byte) ([]byte, error) {
+	out := []byte(testArmor)
+	for i := len(plaintext) - 1; i >= 0; i-- {
+		out = append(out, plaintext[i])
+	}
+	return out, nil
+}
+
+func (reverseEncryptor) Decrypt(ciphertext []byte) ([]byte, error) {
+	body := bytes.TrimPrefix(ciphertext, []byte(testArmor))
+	out := make([]byte, 0, len(body))
+	for i := len(body) - 1; i >= 0; i-- {
+		out = append(out, body[i])
+	}
+	return out, nil
+}
+
+func TestEncryptedBackend(t *testing.T) {
+	tmpDir := t.TempDir()
+	files, err := NewStorage(tmpDir)
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+	if err := files.InitLibrary(); err != nil {
+		t.Fatalf("Failed to init library: %v", err)
+	}
+	backend := NewEncryptedBackend(files, reverseEncryptor{})
+
+	prompt := &models.Prompt{
+		ID:        "secret",
+		Version:   "1.0.0",
+		Name:      "Secret Prompt",
+		Encrypted: true,
+		Content:   "top secret body",
+		FilePath:  filepath.Join("prompts", "secret.md"),
+	}
+	if err := backend.SavePrompt(prompt); err != nil {
+		t.Fatalf("Failed to save prompt: %v", err)
+	}
+	if prompt.Content != "top secret body" {
+		t.Errorf("Caller's prompt should keep plain text, got %q", prompt.Content)
+	}
+
+	raw, err := os.ReadFile(filepath.Join(tmpDir, prompt.FilePath))
+	if err != nil {
+		t.Fatalf("Failed to read prompt file: %v", err)
+	}
+	if strings.Contains(string(raw), "top secret body") {
+		t.Error("Body was written in plain text")
+	}
+	if !strings.Contains(string(raw), "title: Secret Prompt") {
+		t.Error("Frontmatter should stay readable")
+	}
+
+	loaded, err := backend.LoadPrompt(prompt.FilePath)
+	if err != nil {
+		t.Fatalf("Failed to load prompt: %v", err)
+	}
+	if loaded.Content != "top secret body" {
+		t.Errorf("Expected decrypted body, got %q", loaded.Content)
+	}
+
+	// Without an encryptor, encrypted prompts must not be written
+	if err := NewEncryptedBackend(files, nil).SavePrompt(prompt); err == nil {
+		t.Error("Expected error saving encrypted prompt without encryption configured")
+	}
+}
@@ -0,0 +1,406 @@
+package storage
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FrontmatterFormat is the syntax of the metadata block at the top of a file
+type FrontmatterFormat string
+
+const (
+	// FormatYAML is delimited by --- lines (the default)
+	FormatYAML FrontmatterFormat = "yaml"
+	// FormatTOML is delimited by +++ lines, as used by Hugo and Zola
+	FormatTOML FrontmatterFormat = "toml"
+	// FormatJSON is a JSON object starting on the first line
+	FormatJSON FrontmatterFormat = "json"
+)
+
+// ParseFrontmatterFormat converts a config value into a format, defaulting to YAML
+func ParseFrontmatterFormat(value string) (FrontmatterFormat, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "", "yaml", "yml":
+		return FormatYAML, nil
+	case "toml":
+		return FormatTOML, nil
+	case "json":
+		return FormatJSON, nil
+	default:
+		return "", fmt.Errorf("unknown frontmatter format %q (expected yaml, toml or json)", value)
+	}
+}
+
+// detectFrontmatterFormat identifies the format from the first line of a file
+func detectFrontmatterFormat(firstLine string) (FrontmatterFormat, bool) {
+	switch {
+	case firstLine == "---":
+		return FormatYAML, true
+	case firstLine == "+++":
+		return FormatTOML, true
+	case strings.HasPrefix(strings.TrimSpace(firstLine), "{"):
+		return FormatJSON, true
+	default:
+		return "", false
+	}
+}
+
+// frontmatterBlock is the raw metadata block of a file
+type frontmatterBlock struct {
+	format FrontmatterFormat
+	raw    string
+	// startLine is the file line holding the first line of raw
+	startLine int
+	// closed is false when the file ended before the closing delimiter
+	closed bool
+}
+
+// fileLine converts a line within raw into a line within the file
+func (b frontmatterBlock) fileLine(line int) int {
+	if line <= 0 {
+		return 0
+	}
+	return line + b.startLine - 1
+}
+
+var errMissingFrontmatter = fmt.Errorf("missing frontmatter delimiter")
+
+// readLineFrom reads one line without its terminator
+func readLineFrom(reader *bufio.Reader) (string, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// readFrontmatter reads the metadata block at the start of a file, stopping
+// right after its closing delimiter
+func readFrontmatter(reader *bufio.Reader) (frontmatterBlock, error) {
+	first, err := readLineFrom(reader)
+	if err != nil {
+		return frontmatterBlock{}, errMissingFrontmatter
+	}
+	format, ok := detectFrontmatterFormat(first)
+	if !ok {
+		return frontmatterBlock{}, errMissingFrontmatter
+	}
+
+	block := frontmatterBlock{format: format, startLine: 2}
+	var lines []string
+
+	if format == FormatJSON {
+		// The object spans lines until its braces balance
+		block.startLine = 1
+		depth, inString, escaped := 0, false, false
+		line := first
+		for {
+			lines = append(lines, line)
+			for _, r := range line {
+				switch {
+				case escaped:
+					escaped = false
+				case inString && r == '\\':
+					escaped = true
+				case r == '"':
+					inString = !inString
+				case !inString && r == '{':
+					depth++
+				case !inString && r == '}':
+					depth--
+				}
+			}
+			if depth <= 0 {
+				block.closed = true
+				break
+			}
+			if line, err = readLineFrom(reader); err != nil {
+				break
+			}
+		}
+		block.raw = strings.Join(lines, "\n")
+		return block, nil
+	}
+
+	delimiter := first
+	for {
+		line, err := readLineFrom(reader)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return frontmatterBlock{}, err
+		}
+		if line == delimiter {
+			block.closed = true
+			break
+		}
+		lines = append(lines, line)
+	}
+	block.raw = strings.Join(lines, "\n")
+	return block, nil
+}
+
+// node parses the block into a YAML node tree so every format shares the
+// same decoding and validation. Node lines are relative to raw.
+func (b frontmatterBlock) node() (*yaml.Node, error) {
+	switch b.format {
+	case FormatTOML:
+		return parseTOML(b.raw)
+	case FormatJSON:
+		return parseJSONNode(b.raw)
+	default:
+		var doc yaml.Node
+		if err := yaml.Unmarshal([]byte(b.raw), &doc); err != nil {
+			return nil, err
+		}
+		if len(doc.Content) == 0 {
+			return &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}, nil
+		}
+		return doc.Content[0], nil
+	}
+}
+
+// decode unmarshals the block into v, which uses yaml struct tags
+func (b frontmatterBlock) decode(v interface{}) error {
+	n, err := b.node()
+	if err != nil {
+		return err
+	}
+	return n.Decode(v)
+}
+
+// splitFrontmatter separates the metadata block from the markdown body
+func splitFrontmatter(content []byte) (frontmatterBlock, string, error) {
+	reader := bufio.NewReader(bytes.NewReader(content))
+	block, err := readFrontmatter(reader)
+	if err != nil {
+		return block, "", err
+	}
+
+	var bodyLines []string
+	for {
+		line, err := readLineFrom(reader)
+		if err != nil {
+			break
+		}
+		bodyLines = append(bodyLines, line)
+	}
+	// Join content preserving original formatting, trimming only leading whitespace/newlines
+	body := strings.TrimLeft(strings.Join(bodyLines, "\n"), " \t\n")
+	return block, body, nil
+}
+
+// fileFrontmatterFormat returns the format of an existing file, if any
+func fileFrontmatterFormat(path string) (FrontmatterFormat, bool) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", false
+	}
+	defer file.Close()
+
+	first, err := readLineFrom(bufio.NewReader(file))
+	if err != nil {
+		return "", false
+	}
+	return detectFrontmatterFormat(first)
+}
+
+// encodeFrontmatter renders v, which uses yaml struct tags, as a delimited
+// metadata block in the given format
+func encodeFrontmatter(format FrontmatterFormat, v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+
+	if format == FormatYAML || format == "" {
+		buf.WriteString("---\n")
+		encoder := yaml.NewEncoder(&buf)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(v); err != nil {
+			return nil, fmt.Errorf("failed to encode frontmatter: %w", err)
+		}
+		buf.WriteString("---\n")
+		return buf.Bytes(), nil
+	}
+
+	// Round-trip through YAML so field names, order and omitempty match
+	data, err := yaml.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode frontmatter: %w", err)
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil || len(doc.Content) == 0 {
+		return nil, fmt.Errorf("failed to encode frontmatter: %v", err)
+	}
+	root := doc.Content[0]
+
+	switch format {
+	case FormatTOML:
+		buf.WriteString("+++\n")
+		if err := writeTOML(&buf, root); err != nil {
+			return nil, fmt.Errorf("failed to encode frontmatter: %w", err)
+		}
+		buf.WriteString("+++\n")
+	case FormatJSON:
+		writeJSONNode(&buf, root, "")
+		buf.WriteString("\n")
+	default:
+		return nil, fmt.Errorf("unknown frontmatter format %q", format)
+	}
+	return buf.Bytes(), nil
+}
+
+// parseJSONNode converts a JSON object into a YAML node tree, keeping key
+// order and line numbers
+func parseJSONNode(raw string) (*yaml.Node, error) {
+	dec := json.NewDecoder(strings.NewReader(raw))
+	dec.UseNumber()
+
+	lineAt := func(offset int64) int {
+		if offset > int64(len(raw)) {
+			offset = int64(len(raw))
+		}
+		return strings.Count(raw[:offset], "\n") + 1
+	}
+
+	var build func() (*yaml.Node, error)
+	build = func() (*yaml.Node, error) {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, jsonError(raw, err, lineAt(dec.InputOffset()))
+		}
+		// JSON tokens never span lines, so the offset after it is on its line
+		line := lineAt(dec.InputOffset())
+		switch t := tok.(type) {
+		case json.Delim:
+			switch t {
+			case '{':
+				n := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Line: line}
+				for dec.More() {
+					keyLine := lineAt(dec.InputOffset())
+					keyTok, err := dec.Token()
+					if err != nil {
+						return nil, jsonError(raw, err, keyLine)
+					}
+					key, _ := keyTok.(string)
+					value, err := build()
+					if err != nil {
+						return nil, err
+					}
+					n.Content = append(n.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key, Line: value.Line}, value)
+				}
+				if _, err := dec.Token(); err != nil {
+					return nil, jsonError(raw, err, lineAt(dec.InputOffset()))
+				}
+				return n, nil
+			case '[':
+				n := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Line: line}
+				for dec.More() {
+					item, err := build()
+					if err != nil {
+						return nil, err
+					}
+					n.Content = append(n.Content, item)
+				}
+				if _, err := dec.Token(); err != nil {
+					return nil, jsonError(raw, err, lineAt(dec.InputOffset()))
+				}
+				return n, nil
+			}
+			return nil, fmt.Errorf("line %d: unexpected %q", line, t)
+		case string:
+			return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: t, Line: line}, nil
+		case json.Number:
+			tag := "!!int"
+			if _, err := t.Int64(); err != nil {
+				tag = "!!float"
+			}
+			return &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: t.String(), Line: line}, nil
+		case bool:
+			return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: fmt.Sprint(t), Line: line}, nil
+		default:
+			return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null", Line: line}, nil
+		}
+	}
+
+	root, err := build()
+	if err != nil {
+		return nil, err
+	}
+	if root.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("line %d: frontmatter must be a JSON object", root.Line)
+	}
+	return root, nil
+}
+
+func jsonError(raw string, err error, line int) error {
+	if syntaxErr, ok := err.(*json.SyntaxError); ok {
+		offset := syntaxErr.Offset
+		if offset > int64(len(raw)) {
+			offset = int64(len(raw))
+		}
+		line = strings.Count(raw[:offset], "\n") + 1
+	}
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	return fmt.Errorf("line %d: %v", line, err)
+}
+
+// writeJSONNode writes a YAML node tree as indented JSON
+func writeJSONNode(buf *bytes.Buffer, n *yaml.Node, indent string) {
+	inner := indent + "  "
+	switch n.Kind {
+	case yaml.MappingNode:
+		if len(n.Content) == 0 {
+			buf.WriteString("{}")
+			return
+		}
+		buf.WriteString("{\n")
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			key, _ := json.Marshal(n.Content[i].Value)
+			buf.WriteString(inner)
+			buf.Write(key)
+			buf.WriteString(": ")
+			writeJSONNode(buf, n.Content[i+1], inner)
+			if i+2 < len(n.Content) {
+				buf.WriteString(",")
+			}
+			buf.WriteString("\n")
+		}
+		buf.WriteString(indent + "}")
+	case yaml.SequenceNode:
+		if len(n.Content) == 0 {
+			buf.WriteString("[]")
+			return
+		}
+		buf.WriteString("[\n")
+		for i, item := range n.Content {
+			buf.WriteString(inner)
+			writeJSONNode(buf, item, inner)
+			if i+1 < len(n.Content) {
+				buf.WriteString(",")
+			}
+			buf.WriteString("\n")
+		}
+		buf.WriteString(indent + "]")
+	case yaml.AliasNode:
+		writeJSONNode(buf, n.Alias, indent)
+	default:
+		switch n.ShortTag() {
+		case "!!int", "!!float", "!!bool":
+			buf.WriteString(n.Value)
+		case "!!null":
+			buf.WriteString("null")
+		default:
+			value, _ := json.Marshal(n.Value)
+			buf.Write(value)
+		}
+	}
+}
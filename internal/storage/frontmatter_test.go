@@ -0,0 +1,193 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dpshade/pocket-prompt/internal/models"
+)
+
+func testPrompt() *models.Prompt {
+	created := time.Date(2024, 1, 31, 9, 0, 0, 0, time.UTC)
+	return &models.Prompt{
+		ID:        "round-trip",
+		Version:   "1.0",
+		Name:      `Quotes "and" \ slashes`,
+		Summary:   "Line one\nline two",
+		Tags:      []string{"a", "b c"},
+		Metadata:  map[string]interface{}{"model": "gpt-4", "temperature": 0.7, "max_tokens": 100},
+		CreatedAt: created,
+		UpdatedAt: created.Add(time.Hour),
+		Content:   "# Body\n\n+++ not a delimiter +++",
+	}
+}
+
+func TestFrontmatterRoundTrip(t *testing.T) {
+	for _, format := range []FrontmatterFormat{FormatYAML, FormatTOML, FormatJSON} {
+		t.Run(string(format), func(t *testing.T) {
+			want := testPrompt()
+			data, err := serializePrompt(want, format)
+			if err != nil {
+				t.Fatalf("Failed to serialize: %v", err)
+			}
+
+			got, err := parsePromptFile(data)
+			if err != nil {
+				t.Fatalf("Failed to parse:\n%s\n%v", data, err)
+			}
+			if got.ID != want.ID || got.Version != want.Version || got.Name != want.Name || got.Summary != want.Summary {
+				t.Errorf("Scalar fields changed: %+v", got)
+			}
+			if !reflect.DeepEqual(got.Tags, want.Tags) {
+				t.Errorf("Tags changed: %v", got.Tags)
+			}
+			if !got.CreatedAt.Equal(want.CreatedAt) || !got.UpdatedAt.Equal(want.UpdatedAt) {
+				t.Errorf("Timestamps changed: %v %v", got.CreatedAt, got.UpdatedAt)
+			}
+			if got.Metadata["model"] != "gpt-4" || got.Metadata["max_tokens"] != 100 {
+				t.Errorf("Metadata changed: %v", got.Metadata)
+			}
+			if got.Content != want.Content {
+				t.Errorf("Content changed: %q", got.Content)
+			}
+			if issues := ValidatePrompt("p.md", data); len(issues) != 0 {
+				t.Errorf("Serialized file does not validate: %v", issues)
+			}
+		})
+	}
+}
+
+func TestTemplateTOMLArrayOfTables(t *testing.T) {
+	want := &models.Template{
+		ID:      "tmpl",
+		Version: "1",
+		Name:    "Template",
+		Slots: []models.Slot{
+			{Name: "topic", Required: true},
+			{Name: "tone", Default: "friendly"},
+		},
+		Constraints: models.TemplateRules{MaxWordCount: 200},
+		Content:     "Write about {{topic}}",
+	}
+
+	data, err := serializeTemplate(want, FormatTOML)
+	if err != nil {
+		t.Fatalf("Failed to serialize: %v", err)
+	}
+	if !strings.Contains(string(data), "[[slots]]") {
+		t.Errorf("Expected slots as an array of tables:\n%s", data)
+	}
+
+	got, err := parseTemplateFile(data)
+	if err != nil {
+		t.Fatalf("Failed to parse:\n%s\n%v", data, err)
+	}
+	if !reflect.DeepEqual(got.Slots, want.Slots) {
+		t.Errorf("Slots changed: %+v", got.Slots)
+	}
+	if got.Constraints.MaxWordCount != 200 {
+		t.Errorf("Constraints changed: %+v", got.Constraints)
+	}
+}
+
+func TestParseHandWrittenFrontmatter(t *testing.T) {
+	toml := `+++
+# Written by another tool
+id = "hugo"
+title = 'Literal \title'
+tags = [
+  "one",
+  "two", # trailing comma
+]
+created_at = 2024-01-31T09:00:00Z
+metadata.source = "hugo"
+
+[metadata.extra]
+stars = 1_000
++++
+
+Body`
+	prompt, err := parsePromptFile([]byte(toml))
+	if err != nil {
+		t.Fatalf("Failed to parse TOML: %v", err)
+	}
+	if prompt.ID != "hugo" || prompt.Name != `Literal \title` || len(prompt.Tags) != 2 || prompt.Content != "Body" {
+		t.Errorf("Unexpected prompt: %+v", prompt)
+	}
+	if prompt.Metadata["source"] != "hugo" {
+		t.Errorf("Dotted key not applied: %v", prompt.Metadata)
+	}
+	if extra, ok := prompt.Metadata["extra"].(map[string]interface{}); !ok || extra["stars"] != 1000 {
+		t.Errorf("Sub-table not applied: %v", prompt.Metadata)
+	}
+
+	json := "{\n  \"id\": \"json\",\n  \"title\": \"Braces } in { strings\",\n  \"tags\": [\"x\"]\n}\n\nBody"
+	prompt, err = parsePromptFile([]byte(json))
+	if err != nil {
+		t.Fatalf("Failed to parse JSON: %v", err)
+	}
+	if prompt.ID != "json" || prompt.Name != "Braces } in { strings" || prompt.Content != "Body" {
+		t.Errorf("Unexpected prompt: %+v", prompt)
+	}
+}
+
+func TestValidateTOMLReportsFileLine(t *testing.T) {
+	content := "+++\nid = \"x\"\ntags = \"not-a-list\"\n+++\n"
+	issues := ValidatePrompt("p.md", []byte(content))
+	if len(issues) != 1 || issues[0].Field != "tags" || issues[0].Line != 3 {
+		t.Fatalf("Expected a tags issue on line 3, got %v", issues)
+	}
+
+	content = "+++\nid = \"x\"\ntitle = unquoted\n+++\n"
+	issues = ValidatePrompt("p.md", []byte(content))
+	if len(issues) != 1 || issues[0].Line != 3 {
+		t.Fatalf("Expected a syntax issue on line 3, got %v", issues)
+	}
+
+	content = "{\n  \"id\": \"x\",\n  \"tags\": 5\n}\n"
+	issues = ValidatePrompt("p.md", []byte(content))
+	if len(issues) != 1 || issues[0].Field != "tags" || issues[0].Line != 3 {
+		t.Fatalf("Expected a tags issue on line 3, got %v", issues)
+	}
+}
+
+func TestSavePromptFormats(t *testing.T) {
+	tmpDir := t.TempDir()
+	store, err := NewStorage(tmpDir)
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+	store.SetFrontmatterFormat(FormatTOML)
+
+	prompt := testPrompt()
+	prompt.FilePath = filepath.Join("prompts", "new.md")
+	if err := store.SavePrompt(prompt); err != nil {
+		t.Fatalf("Failed to save prompt: %v", err)
+	}
+	data, _ := os.ReadFile(filepath.Join(tmpDir, prompt.FilePath))
+	if !strings.HasPrefix(string(data), "+++\n") {
+		t.Errorf("New file should use the configured format:\n%s", data)
+	}
+
+	// Existing files keep the format they were written in
+	existing := filepath.Join(tmpDir, "prompts", "existing.md")
+	if err := os.WriteFile(existing, []byte("{\"id\": \"existing\"}\n\nBody\n"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	loaded, err := store.LoadPrompt(filepath.Join("prompts", "existing.md"))
+	if err != nil {
+		t.Fatalf("Failed to load prompt: %v", err)
+	}
+	loaded.Name = "Renamed"
+	if err := store.SavePrompt(loaded); err != nil {
+		t.Fatalf("Failed to save prompt: %v", err)
+	}
+	data, _ = os.ReadFile(existing)
+	if !strings.HasPrefix(string(data), "{\n") || !strings.Contains(string(data), `"title": "Renamed"`) {
+		t.Errorf("Existing JSON file should stay JSON:\n%s", data)
+	}
+}
@@ -0,0 +1,45 @@
+package storage
+
+import (
+	"fmt"
+
+	"github.com/dpshade/pocket-prompt/internal/models"
+)
+
+// MarshalPrompt encodes a prompt as a markdown file with YAML frontmatter,
+// exactly as the file backend would write it
+func MarshalPrompt(prompt *models.Prompt) ([]byte, error) {
+	return serializePrompt(withLocalID(prompt), FormatYAML)
+}
+
+// UnmarshalPrompt decodes a prompt file in any supported frontmatter
+// format. relPath is the file's path relative to the library root and
+// determines the prompt's namespace.
+func UnmarshalPrompt(data []byte, relPath string) (*models.Prompt, error) {
+	prompt, err := parsePromptFile(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse prompt: %w", err)
+	}
+	if prompt.ID == "" {
+		prompt.ID = idFromPath(relPath)
+	}
+	prompt.FilePath = relPath
+	prompt.ContentHash = calculateHash(data)
+	applyNamespace(prompt, relPath)
+	return prompt, nil
+}
+
+// MarshalTemplate encodes a template as a markdown file with YAML frontmatter
+func MarshalTemplate(template *models.Template) ([]byte, error) {
+	return serializeTemplate(template, FormatYAML)
+}
+
+// UnmarshalTemplate decodes a template file in any supported frontmatter format
+func UnmarshalTemplate(data []byte, relPath string) (*models.Template, error) {
+	template, err := parseTemplateFile(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template: %w", err)
+	}
+	template.FilePath = relPath
+	return template, nil
+}
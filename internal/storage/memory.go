@@ -0,0 +1,363 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/dpshade/pocket-prompt/internal/models"
+)
+
+// MemoryStorage keeps the library in memory, as the files the file backend
+// would write, so it parses and serializes prompts the same way without
+// touching the disk. It backs tests and is a reference for new backends.
+type MemoryStorage struct {
+	rootPath string
+	mu       sync.RWMutex
+	files    map[string][]byte // File contents by slash-separated path
+	issues   *issueLog
+}
+
+// NewMemoryStorage creates an empty in-memory library. rootPath is only
+// reported by GetBaseDir and may be empty.
+func NewMemoryStorage(rootPath string) *MemoryStorage {
+	return &MemoryStorage{
+		rootPath: rootPath,
+		files:    make(map[string][]byte),
+		issues:   newIssueLog(),
+	}
+}
+
+// InitLibrary has nothing to create, directories being implied by paths
+func (m *MemoryStorage) InitLibrary() error {
+	return nil
+}
+
+// GetBaseDir returns the root path the storage was created with
+func (m *MemoryStorage) GetBaseDir() string {
+	return m.rootPath
+}
+
+// read returns a copy of a file's contents
+func (m *MemoryStorage) read(relPath string) ([]byte, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	data, ok := m.files[filepath.ToSlash(relPath)]
+	if !ok {
+		return nil, &os.PathError{Op: "open", Path: relPath, Err: os.ErrNotExist}
+	}
+	return append([]byte(nil), data...), nil
+}
+
+// write stores a copy of a file's contents
+func (m *MemoryStorage) write(relPath string, data []byte) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.files[filepath.ToSlash(relPath)] = append([]byte(nil), data...)
+}
+
+// remove deletes a file
+func (m *MemoryStorage) remove(relPath string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key := filepath.ToSlash(relPath)
+	if _, ok := m.files[key]; !ok {
+		return &os.PathError{Op: "remove", Path: relPath, Err: os.ErrNotExist}
+	}
+	delete(m.files, key)
+	return nil
+}
+
+// list returns the paths under dir with the given extension, sorted like a
+// directory walk. Paths under skip are left out.
+func (m *MemoryStorage) list(dir, ext, skip string) []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var paths []string
+	for key := range m.files {
+		if !strings.HasPrefix(key, dir+"/") || !strings.HasSuffix(key, ext) {
+			continue
+		}
+		if skip != "" && strings.HasPrefix(key, skip+"/") {
+			continue
+		}
+		paths = append(paths, key)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// LoadPrompt loads a prompt
+func (m *MemoryStorage) LoadPrompt(relPath string) (*models.Prompt, error) {
+	prompt, err := m.loadPrompt(relPath)
+	if err != nil {
+		return nil, err
+	}
+	m.defaults(warnInvalidDefaults).forPrompt(relPath).apply(prompt)
+	return prompt, nil
+}
+
+// loadPrompt loads a prompt as it is in its file, without directory defaults
+func (m *MemoryStorage) loadPrompt(relPath string) (*models.Prompt, error) {
+	content, err := m.read(relPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open prompt file: %w", err)
+	}
+	prompt, err := parsePromptFile(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse prompt: %w", err)
+	}
+	prompt.FilePath = relPath
+	prompt.ContentHash = calculateHash(content)
+	applyNamespace(prompt, relPath)
+	return prompt, nil
+}
+
+// LoadPromptMetadata loads a prompt without its body
+func (m *MemoryStorage) LoadPromptMetadata(relPath string) (*models.Prompt, error) {
+	prompt, err := m.LoadPrompt(relPath)
+	if err != nil {
+		return nil, err
+	}
+	prompt.Content = ""
+	return prompt, nil
+}
+
+// defaults returns a loader for the directory defaults of the library
+func (m *MemoryStorage) defaults(onErr func(relPath string, err error)) *defaultsLoader {
+	return newDefaultsLoader(m.read, onErr)
+}
+
+// SavePrompt stores a prompt
+func (m *MemoryStorage) SavePrompt(prompt *models.Prompt) error {
+	own := m.defaults(warnInvalidDefaults).forPrompt(prompt.FilePath).strip(prompt)
+	content, err := serializePrompt(withLocalID(own), FormatYAML)
+	if err != nil {
+		return fmt.Errorf("failed to serialize prompt: %w", err)
+	}
+	m.write(prompt.FilePath, content)
+	return nil
+}
+
+// DeletePrompt removes a prompt
+func (m *MemoryStorage) DeletePrompt(prompt *models.Prompt) error {
+	if err := m.remove(prompt.FilePath); err != nil {
+		return fmt.Errorf("prompt file does not exist: %s", prompt.FilePath)
+	}
+	return nil
+}
+
+// ListPrompts returns all prompts in the library (excluding archived prompts)
+func (m *MemoryStorage) ListPrompts(ctx context.Context) ([]*models.Prompt, error) {
+	return m.listPrompts(ctx, "prompts")
+}
+
+// ListArchivedPrompts returns all archived prompts
+func (m *MemoryStorage) ListArchivedPrompts(ctx context.Context) ([]*models.Prompt, error) {
+	return m.listPrompts(ctx, "archive")
+}
+
+// listPrompts loads the prompts under dir, recording the ones that don't
+// load as validation issues like the file backend
+func (m *MemoryStorage) listPrompts(ctx context.Context, dir string) ([]*models.Prompt, error) {
+	m.issues.clear(dir)
+	defaults := m.defaults(func(relPath string, err error) {
+		m.issues.add(ValidationIssue{FilePath: relPath, Message: err.Error()})
+	})
+	prompts := []*models.Prompt{}
+	for _, relPath := range m.list(dir, ".md", ArchivedTemplatesDir) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		prompt, err := m.loadPrompt(relPath)
+		if err != nil {
+			m.recordIssues(relPath, err, ValidatePrompt)
+			continue
+		}
+		if prompt.ID == "" {
+			prompt.ID = idFromPath(relPath)
+			m.issues.add(ValidationIssue{FilePath: relPath, Line: 2, Field: "id",
+				Message: fmt.Sprintf("required field is missing, using %q from the file name", prompt.ID)})
+		}
+		prompt.Content = ""
+		defaults.forPrompt(relPath).apply(prompt)
+		prompts = append(prompts, prompt)
+	}
+	return prompts, nil
+}
+
+// LoadTemplate loads a template
+func (m *MemoryStorage) LoadTemplate(relPath string) (*models.Template, error) {
+	content, err := m.read(relPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open template file: %w", err)
+	}
+	template, err := parseTemplateFile(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template: %w", err)
+	}
+	template.FilePath = relPath
+	return template, nil
+}
+
+// SaveTemplate stores a template
+func (m *MemoryStorage) SaveTemplate(template *models.Template) error {
+	content, err := serializeTemplate(template, FormatYAML)
+	if err != nil {
+		return fmt.Errorf("failed to serialize template: %w", err)
+	}
+	m.write(template.FilePath, content)
+	return nil
+}
+
+// DeleteTemplate removes a template
+func (m *MemoryStorage) DeleteTemplate(template *models.Template) error {
+	return m.remove(template.FilePath)
+}
+
+// ListTemplates returns all templates in the library
+func (m *MemoryStorage) ListTemplates() ([]*models.Template, error) {
+	return m.listTemplates("templates"), nil
+}
+
+// ListArchivedTemplates returns the old versions of templates
+func (m *MemoryStorage) ListArchivedTemplates() ([]*models.Template, error) {
+	return m.listTemplates(ArchivedTemplatesDir), nil
+}
+
+// listTemplates loads the templates under dir
+func (m *MemoryStorage) listTemplates(dir string) []*models.Template {
+	m.issues.clear(dir)
+	templates := []*models.Template{}
+	for _, relPath := range m.list(dir, ".md", "") {
+		template, err := m.LoadTemplate(relPath)
+		if err != nil {
+			m.recordIssues(relPath, err, ValidateTemplate)
+			continue
+		}
+		templates = append(templates, template)
+	}
+	return templates
+}
+
+// LoadAttachment reads an attachment
+func (m *MemoryStorage) LoadAttachment(name string) ([]byte, error) {
+	if err := ValidateAttachmentName(name); err != nil {
+		return nil, err
+	}
+	data, err := m.read(attachmentPath(name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read attachment: %w", err)
+	}
+	return data, nil
+}
+
+// SaveAttachment stores an attachment
+func (m *MemoryStorage) SaveAttachment(name string, data []byte) error {
+	if err := ValidateAttachmentName(name); err != nil {
+		return err
+	}
+	m.write(attachmentPath(name), data)
+	return nil
+}
+
+// ListAttachments returns the names of all attachments, sorted. Hidden
+// files and folders are left out, as on disk.
+func (m *MemoryStorage) ListAttachments() ([]string, error) {
+	var names []string
+	for _, key := range m.list(AttachmentsDir, "", "") {
+		name := strings.TrimPrefix(key, AttachmentsDir+"/")
+		if strings.HasPrefix(name, ".") || strings.Contains(name, "/.") {
+			continue
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+// LoadSnippet reads a snippet
+func (m *MemoryStorage) LoadSnippet(name string) (*models.Snippet, error) {
+	if err := ValidateSnippetName(name); err != nil {
+		return nil, err
+	}
+	data, err := m.read(snippetPath(name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snippet: %w", err)
+	}
+	return parseSnippet(name, data)
+}
+
+// SaveSnippet stores a snippet
+func (m *MemoryStorage) SaveSnippet(snippet *models.Snippet) error {
+	if err := ValidateSnippetName(snippet.Name); err != nil {
+		return err
+	}
+	snippet.FilePath = snippetPath(snippet.Name)
+	data, err := serializeSnippet(snippet)
+	if err != nil {
+		return err
+	}
+	m.write(snippet.FilePath, data)
+	return nil
+}
+
+// DeleteSnippet removes a snippet
+func (m *MemoryStorage) DeleteSnippet(name string) error {
+	if err := ValidateSnippetName(name); err != nil {
+		return err
+	}
+	return m.remove(snippetPath(name))
+}
+
+// ListSnippets returns all snippets, sorted by name
+func (m *MemoryStorage) ListSnippets() ([]*models.Snippet, error) {
+	m.issues.clear(SnippetsDir)
+	var snippets []*models.Snippet
+	for _, key := range m.list(SnippetsDir, ".md", "") {
+		name := strings.TrimSuffix(strings.TrimPrefix(key, SnippetsDir+"/"), ".md")
+		if !models.ValidSnippetName(name) {
+			continue
+		}
+		data, err := m.read(key)
+		if err != nil {
+			return nil, err
+		}
+		snippet, err := parseSnippet(name, data)
+		if err != nil {
+			m.issues.add(ValidationIssue{FilePath: path.Join(SnippetsDir, name+".md"), Message: err.Error()})
+			continue
+		}
+		snippets = append(snippets, snippet)
+	}
+	return snippets, nil
+}
+
+// ValidationIssues returns the problems found during the last listing
+func (m *MemoryStorage) ValidationIssues() []ValidationIssue {
+	return m.issues.all()
+}
+
+// recordIssues validates a file that failed to load and logs its problems
+func (m *MemoryStorage) recordIssues(relPath string, loadErr error, validate func(string, []byte) []ValidationIssue) {
+	var issues []ValidationIssue
+	if data, err := m.read(relPath); err == nil {
+		issues = validate(relPath, data)
+	}
+	if len(issues) == 0 {
+		issues = []ValidationIssue{{FilePath: relPath, Message: loadErr.Error()}}
+	}
+	for _, issue := range issues {
+		m.issues.add(issue)
+	}
+}
+
+// WriteFile stores raw file contents at a path relative to the library
+// root, e.g. to set up a library with hand-written or invalid files
+func (m *MemoryStorage) WriteFile(relPath string, data []byte) {
+	m.write(relPath, data)
+}
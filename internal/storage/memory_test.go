@@ -0,0 +1,83 @@
+package storage
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/dpshade/pocket-prompt/internal/models"
+)
+
+func TestMemoryStorage(t *testing.T) {
+	store := NewMemoryStorage("")
+
+	prompt := &models.Prompt{
+		ID:       "team/launch",
+		Version:  "1.0.0",
+		Name:     "Launch",
+		Content:  "Plan the launch",
+		FilePath: filepath.Join("prompts", "team", "launch.md"),
+	}
+	archived := &models.Prompt{ID: "launch", Version: "0.9.0", Name: "Launch", FilePath: filepath.Join("archive", "launch-v0.9.0.md")}
+	for _, p := range []*models.Prompt{prompt, archived} {
+		if err := store.SavePrompt(p); err != nil {
+			t.Fatalf("Failed to save prompt: %v", err)
+		}
+	}
+	if err := store.SaveTemplate(&models.Template{ID: "old", Version: "1.0.0", FilePath: ArchivedTemplatesDir + "/old-v1.0.0.md"}); err != nil {
+		t.Fatalf("Failed to save template: %v", err)
+	}
+	store.WriteFile("prompts/broken.md", []byte("---\nid: [\n---\n"))
+
+	loaded, err := store.LoadPrompt(prompt.FilePath)
+	if err != nil {
+		t.Fatalf("Failed to load prompt: %v", err)
+	}
+	if loaded.ID != "team/launch" || loaded.Content != "Plan the launch" || loaded.ContentHash == "" {
+		t.Errorf("Expected the namespaced prompt with its body, got %+v", loaded)
+	}
+
+	listed, _ := store.ListPrompts(context.Background())
+	if len(listed) != 1 || listed[0].ID != "team/launch" || listed[0].Content != "" {
+		t.Errorf("Expected the valid prompt listed without its body, got %+v", listed)
+	}
+	if issues := store.ValidationIssues(); len(issues) == 0 || issues[0].FilePath != "prompts/broken.md" {
+		t.Errorf("Expected the broken file reported, got %+v", issues)
+	}
+	listed, _ = store.ListArchivedPrompts(context.Background())
+	if len(listed) != 1 || listed[0].Version != "0.9.0" {
+		t.Errorf("Expected only the archived prompt, not the archived template, got %+v", listed)
+	}
+	if templates, _ := store.ListArchivedTemplates(); len(templates) != 1 {
+		t.Errorf("Expected one archived template, got %+v", templates)
+	}
+
+	if err := store.DeletePrompt(prompt); err != nil {
+		t.Fatalf("Failed to delete prompt: %v", err)
+	}
+	if _, err := store.LoadPrompt(prompt.FilePath); err == nil {
+		t.Error("Expected the deleted prompt to be gone")
+	}
+	if err := store.DeletePrompt(prompt); err == nil {
+		t.Error("Expected deleting a missing prompt to fail")
+	}
+
+	if err := store.SaveAttachment("examples/a.md", []byte("A")); err != nil {
+		t.Fatalf("Failed to save attachment: %v", err)
+	}
+	store.WriteFile("attachments/.hidden", []byte("x"))
+	if names, _ := store.ListAttachments(); len(names) != 1 || names[0] != "examples/a.md" {
+		t.Errorf("Expected one visible attachment, got %v", names)
+	}
+
+	if err := store.SaveSnippet(&models.Snippet{Name: "personas/reviewer", Content: "You review code."}); err != nil {
+		t.Fatalf("Failed to save snippet: %v", err)
+	}
+	snippet, err := store.LoadSnippet("personas/reviewer")
+	if err != nil || snippet.Content != "You review code." {
+		t.Errorf("Expected the snippet back, got %+v, %v", snippet, err)
+	}
+	if snippets, _ := store.ListSnippets(); len(snippets) != 1 {
+		t.Errorf("Expected one snippet, got %+v", snippets)
+	}
+}
@@ -0,0 +1,174 @@
+package storage
+
+import (
+	"bufio"
+	"bytes"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/dpshade/pocket-prompt/internal/models"
+)
+
+// TextMerger runs a line-based three-way merge, returning whether the result
+// is free of conflict markers
+type TextMerger func(base, ours, theirs string) (merged string, clean bool, err error)
+
+// MergePromptFile combines two edits of a prompt file made since base, which
+// is nil for a prompt both sides created. Frontmatter is merged field by
+// field: tag lists are combined, keeping removals, a field changed on one
+// side takes that change, and one changed on both takes the side updated
+// last. The body is merged with mergeText, which leaves conflict markers
+// only where both changed the same lines. Encrypted bodies cannot be merged
+// by line, so the one updated last wins. The file keeps the local
+// frontmatter format.
+func MergePromptFile(base, ours, theirs []byte, mergeText TextMerger) ([]byte, bool, error) {
+	local, err := parsePromptFile(ours)
+	if err != nil {
+		return nil, false, err
+	}
+	remote, err := parsePromptFile(theirs)
+	if err != nil {
+		return nil, false, err
+	}
+	ancestor := &models.Prompt{}
+	if base != nil {
+		if parsed, err := parsePromptFile(base); err == nil {
+			ancestor = parsed
+		}
+	}
+
+	remoteNewer := remote.UpdatedAt.After(local.UpdatedAt)
+	pick := func(base, ours, theirs interface{}) interface{} {
+		switch {
+		case reflect.DeepEqual(ours, base):
+			return theirs
+		case reflect.DeepEqual(theirs, base), !remoteNewer:
+			return ours
+		default:
+			return theirs
+		}
+	}
+
+	merged := *local
+	merged.ID = pick(ancestor.ID, local.ID, remote.ID).(string)
+	merged.Name = pick(ancestor.Name, local.Name, remote.Name).(string)
+	merged.Summary = pick(ancestor.Summary, local.Summary, remote.Summary).(string)
+	merged.TemplateRef = pick(ancestor.TemplateRef, local.TemplateRef, remote.TemplateRef).(string)
+	merged.Slots = pick(ancestor.Slots, local.Slots, remote.Slots).(map[string]string)
+	merged.Variables = pick(ancestor.Variables, local.Variables, remote.Variables).([]models.Slot)
+	merged.Defaults = pick(ancestor.Defaults, local.Defaults, remote.Defaults).(map[string]string)
+	merged.Presets = pick(ancestor.Presets, local.Presets, remote.Presets).(map[string]map[string]string)
+	merged.Draft = pick(ancestor.Draft, local.Draft, remote.Draft).(bool)
+	merged.Rating = pick(ancestor.Rating, local.Rating, remote.Rating).(int)
+	merged.Notes = pick(ancestor.Notes, local.Notes, remote.Notes).(string)
+	merged.Tags = mergeTags(ancestor.Tags, local.Tags, remote.Tags)
+	merged.Attachments = mergeTags(ancestor.Attachments, local.Attachments, remote.Attachments)
+	merged.Metadata = mergeMetadata(ancestor.Metadata, local.Metadata, remote.Metadata, remoteNewer)
+	merged.Version = newerVersion(local.Version, remote.Version)
+	if remote.CreatedAt.Before(local.CreatedAt) && !remote.CreatedAt.IsZero() {
+		merged.CreatedAt = remote.CreatedAt
+	}
+	if remoteNewer {
+		merged.UpdatedAt = remote.UpdatedAt
+	}
+
+	clean := true
+	switch {
+	case local.Content == ancestor.Content:
+		merged.Content, merged.Encrypted = remote.Content, remote.Encrypted
+	case remote.Content == ancestor.Content, remote.Content == local.Content:
+		// Keep the local body
+	case local.Encrypted || remote.Encrypted:
+		if remoteNewer {
+			merged.Content, merged.Encrypted = remote.Content, remote.Encrypted
+		}
+	default:
+		merged.Content, clean, err = mergeText(ancestor.Content, local.Content, remote.Content)
+		if err != nil {
+			return nil, false, err
+		}
+	}
+
+	format := FormatYAML
+	if first, err := readLineFrom(bufio.NewReader(bytes.NewReader(ours))); err == nil {
+		if detected, ok := detectFrontmatterFormat(first); ok {
+			format = detected
+		}
+	}
+	content, err := serializePrompt(&merged, format)
+	if err != nil {
+		return nil, false, err
+	}
+	return content, clean, nil
+}
+
+// mergeTags keeps the tags of either side, in local order, except those one
+// side removed
+func mergeTags(base, ours, theirs []string) []string {
+	var merged []string
+	for _, tags := range [][]string{ours, theirs} {
+		for _, tag := range tags {
+			if slices.Contains(merged, tag) {
+				continue
+			}
+			removed := slices.Contains(base, tag) && (!slices.Contains(ours, tag) || !slices.Contains(theirs, tag))
+			if !removed {
+				merged = append(merged, tag)
+			}
+		}
+	}
+	return merged
+}
+
+// mergeMetadata merges metadata key by key like the other fields
+func mergeMetadata(base, ours, theirs map[string]interface{}, remoteNewer bool) map[string]interface{} {
+	merged := make(map[string]interface{})
+	keys := make(map[string]bool)
+	for _, m := range []map[string]interface{}{base, ours, theirs} {
+		for k := range m {
+			keys[k] = true
+		}
+	}
+	for k := range keys {
+		baseValue, inBase := base[k]
+		ourValue, inOurs := ours[k]
+		theirValue, inTheirs := theirs[k]
+
+		value, present := ourValue, inOurs
+		ourChanged := inOurs != inBase || !reflect.DeepEqual(ourValue, baseValue)
+		theirChanged := inTheirs != inBase || !reflect.DeepEqual(theirValue, baseValue)
+		if theirChanged && (!ourChanged || remoteNewer) {
+			value, present = theirValue, inTheirs
+		}
+		if present {
+			merged[k] = value
+		}
+	}
+	if len(merged) == 0 {
+		return nil
+	}
+	return merged
+}
+
+// newerVersion returns the higher of two dotted versions
+func newerVersion(a, b string) string {
+	aParts, bParts := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(aParts) || i < len(bParts); i++ {
+		var x, y int
+		if i < len(aParts) {
+			x, _ = strconv.Atoi(aParts[i])
+		}
+		if i < len(bParts) {
+			y, _ = strconv.Atoi(bParts[i])
+		}
+		if x != y {
+			if y > x {
+				return b
+			}
+			return a
+		}
+	}
+	return a
+}
@@ -0,0 +1,104 @@
+package storage
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMergePromptFile(t *testing.T) {
+	base := []byte(`---
+id: review
+version: 1.0.0
+title: Review
+description: Review code
+tags: [code, old]
+metadata:
+  owner: ana
+created_at: 2024-01-01T00:00:00Z
+updated_at: 2024-01-01T00:00:00Z
+---
+Intro
+
+Review this code.
+`)
+	// Local renames the prompt and edits the first line
+	ours := []byte(`---
+id: review
+version: 1.0.1
+title: Code Review
+description: Review code
+tags: [code, old, local]
+metadata:
+  owner: ana
+created_at: 2024-01-01T00:00:00Z
+updated_at: 2024-01-02T00:00:00Z
+---
+Introduction
+
+Review this code.
+`)
+	// Remote drops a tag, changes the description and edits the last line
+	theirs := []byte(`---
+id: review
+version: 1.0.2
+title: Review
+description: Review a pull request
+tags: [code, remote]
+metadata:
+  owner: bo
+created_at: 2024-01-01T00:00:00Z
+updated_at: 2024-01-03T00:00:00Z
+---
+Intro
+
+Review this pull request.
+`)
+
+	var gotBase, gotOurs, gotTheirs string
+	mergeText := func(base, ours, theirs string) (string, bool, error) {
+		gotBase, gotOurs, gotTheirs = base, ours, theirs
+		return "Introduction\n\nReview this pull request.", true, nil
+	}
+
+	merged, clean, err := MergePromptFile(base, ours, theirs, mergeText)
+	if err != nil || !clean {
+		t.Fatalf("Expected a clean merge, got %v %v", clean, err)
+	}
+	if !strings.HasPrefix(gotBase, "Intro\n") || !strings.HasPrefix(gotOurs, "Introduction") || !strings.HasSuffix(gotTheirs, "pull request.") {
+		t.Errorf("Unexpected bodies passed to the text merge: %q %q %q", gotBase, gotOurs, gotTheirs)
+	}
+
+	prompt, err := parsePromptFile(merged)
+	if err != nil {
+		t.Fatalf("Failed to parse merged prompt: %v\n%s", err, merged)
+	}
+	if prompt.Name != "Code Review" || prompt.Summary != "Review a pull request" {
+		t.Errorf("Expected each side's field changes, got %q %q", prompt.Name, prompt.Summary)
+	}
+	if strings.Join(prompt.Tags, ",") != "code,local,remote" {
+		t.Errorf("Expected combined tags without the removed one, got %v", prompt.Tags)
+	}
+	if prompt.Version != "1.0.2" || prompt.Metadata["owner"] != "bo" || prompt.UpdatedAt.Day() != 3 {
+		t.Errorf("Expected the newer version, metadata and timestamp, got %+v", prompt)
+	}
+	if prompt.Content != "Introduction\n\nReview this pull request." {
+		t.Errorf("Unexpected merged body %q", prompt.Content)
+	}
+
+	// A body only one side changed needs no text merge
+	calls := 0
+	countingMerge := func(base, ours, theirs string) (string, bool, error) {
+		calls++
+		return "", false, nil
+	}
+	if _, clean, err := MergePromptFile(base, base, theirs, countingMerge); err != nil || !clean || calls != 0 {
+		t.Errorf("Expected the remote body without a text merge, got %v %v %d calls", clean, err, calls)
+	}
+}
+
+func TestMergeTags(t *testing.T) {
+	got := mergeTags([]string{"a", "b", "c"}, []string{"a", "c", "x"}, []string{"b", "c", "y", "x"})
+	if strings.Join(got, ",") != "c,x,y" {
+		t.Errorf("Expected removals on either side to win, got %v", got)
+	}
+}
@@ -0,0 +1,54 @@
+package storage
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/dpshade/pocket-prompt/internal/models"
+)
+
+// Prompts in subdirectories are namespaced by their directory, so
+// prompts/team/marketing/launch.md has the ID team/marketing/launch. The
+// frontmatter keeps only the local part ("launch"), which lets a directory be
+// moved or renamed without editing every file in it.
+
+// pathNamespace returns the namespace of a prompt file relative to the
+// library root, e.g. "team/marketing" for prompts/team/marketing/launch.md
+func pathNamespace(relPath string) string {
+	dir := filepath.ToSlash(filepath.Dir(relPath))
+	if i := strings.Index(dir, "/"); i >= 0 {
+		return dir[i+1:]
+	}
+	return ""
+}
+
+// applyNamespace prefixes the prompt ID with its directory namespace
+func applyNamespace(prompt *models.Prompt, relPath string) {
+	ns := pathNamespace(relPath)
+	if ns == "" || prompt.ID == "" || strings.HasPrefix(prompt.ID, ns+"/") {
+		return
+	}
+	prompt.ID = ns + "/" + prompt.ID
+}
+
+// withLocalID returns a copy of the prompt whose ID has the directory
+// namespace removed, for writing to frontmatter
+func withLocalID(prompt *models.Prompt) *models.Prompt {
+	ns := pathNamespace(prompt.FilePath)
+	if ns == "" || !strings.HasPrefix(prompt.ID, ns+"/") {
+		return prompt
+	}
+	local := *prompt
+	local.ID = strings.TrimPrefix(prompt.ID, ns+"/")
+	return &local
+}
+
+// idFromPath derives a namespaced ID from a file name, for files whose
+// frontmatter has none
+func idFromPath(relPath string) string {
+	id := strings.TrimSuffix(filepath.Base(relPath), filepath.Ext(relPath))
+	if ns := pathNamespace(relPath); ns != "" {
+		return ns + "/" + id
+	}
+	return id
+}
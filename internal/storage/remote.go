@@ -0,0 +1,389 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/dpshade/pocket-prompt/internal/models"
+)
+
+// ObjectStore is a flat key/value store of library files. Keys are
+// slash-separated paths relative to the library root, e.g. "prompts/foo.md".
+type ObjectStore interface {
+	List(prefix string) ([]ObjectInfo, error)
+	Get(key string) ([]byte, error)
+	// Put uploads data and returns the new ETag, if the store reports one
+	Put(key string, data []byte) (string, error)
+	Delete(key string) error
+}
+
+// ObjectInfo describes an object in an ObjectStore
+type ObjectInfo struct {
+	Key  string
+	ETag string
+}
+
+// RemoteStorage keeps the library in an ObjectStore and mirrors it into a
+// local file cache. Listings pull only objects whose ETag changed; writes go
+// to the cache first and are then uploaded. When the remote is unreachable
+// the cached copy is served so the library keeps working offline.
+type RemoteStorage struct {
+	rootPath     string
+	cacheDir     string
+	manifestFile string
+	store        ObjectStore
+	local        *Storage
+
+	// mu guards etags and the manifest file, which concurrent listings
+	// and writes both update
+	mu    sync.Mutex
+	etags map[string]string
+}
+
+// NewRemoteStorage creates a remote-backed storage with its cache under rootPath
+func NewRemoteStorage(rootPath string, store ObjectStore) (*RemoteStorage, error) {
+	cacheDir := filepath.Join(rootPath, ".pocket-prompt", "remote")
+	local, err := NewStorage(cacheDir)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &RemoteStorage{
+		rootPath:     rootPath,
+		cacheDir:     cacheDir,
+		manifestFile: filepath.Join(cacheDir, ".pocket-prompt", "manifest.json"),
+		store:        store,
+		local:        local,
+		etags:        make(map[string]string),
+	}
+
+	if data, err := os.ReadFile(r.manifestFile); err == nil {
+		if err := json.Unmarshal(data, &r.etags); err != nil {
+			// Corrupted manifest - everything is re-downloaded on next pull
+			r.etags = make(map[string]string)
+		}
+	}
+
+	return r, nil
+}
+
+// InitLibrary creates the local cache structure
+func (r *RemoteStorage) InitLibrary() error {
+	if err := os.MkdirAll(r.rootPath, 0755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", r.rootPath, err)
+	}
+	return r.local.InitLibrary()
+}
+
+// GetBaseDir returns the library root (not the cache directory)
+func (r *RemoteStorage) GetBaseDir() string {
+	return r.rootPath
+}
+
+// LoadPrompt loads a prompt, fetching it from the remote if not cached
+func (r *RemoteStorage) LoadPrompt(path string) (*models.Prompt, error) {
+	if err := r.ensureCached(path); err != nil {
+		return nil, err
+	}
+	return r.local.LoadPrompt(path)
+}
+
+// LoadPromptMetadata loads a prompt's frontmatter, fetching it if not cached
+func (r *RemoteStorage) LoadPromptMetadata(path string) (*models.Prompt, error) {
+	if err := r.ensureCached(path); err != nil {
+		return nil, err
+	}
+	return r.local.LoadPromptMetadata(path)
+}
+
+// SavePrompt writes the prompt to the cache and uploads it
+func (r *RemoteStorage) SavePrompt(prompt *models.Prompt) error {
+	if err := r.local.SavePrompt(prompt); err != nil {
+		return err
+	}
+	return r.push(prompt.FilePath)
+}
+
+// DeletePrompt removes the prompt from the cache and the remote
+func (r *RemoteStorage) DeletePrompt(prompt *models.Prompt) error {
+	if err := r.local.DeletePrompt(prompt); err != nil {
+		return err
+	}
+	return r.remove(prompt.FilePath)
+}
+
+// ListPrompts pulls changed prompts and returns all non-archived prompts
+func (r *RemoteStorage) ListPrompts(ctx context.Context) ([]*models.Prompt, error) {
+	r.pullOrWarn(ctx, "prompts")
+	return r.local.ListPrompts(ctx)
+}
+
+// ListArchivedPrompts pulls changed archived prompts and returns them
+func (r *RemoteStorage) ListArchivedPrompts(ctx context.Context) ([]*models.Prompt, error) {
+	r.pullOrWarn(ctx, "archive")
+	return r.local.ListArchivedPrompts(ctx)
+}
+
+// LoadTemplate loads a template, fetching it from the remote if not cached
+func (r *RemoteStorage) LoadTemplate(path string) (*models.Template, error) {
+	if err := r.ensureCached(path); err != nil {
+		return nil, err
+	}
+	return r.local.LoadTemplate(path)
+}
+
+// SaveTemplate writes the template to the cache and uploads it
+func (r *RemoteStorage) SaveTemplate(template *models.Template) error {
+	if err := r.local.SaveTemplate(template); err != nil {
+		return err
+	}
+	return r.push(template.FilePath)
+}
+
+// DeleteTemplate removes the template from the cache and the remote
+func (r *RemoteStorage) DeleteTemplate(template *models.Template) error {
+	if err := r.local.DeleteTemplate(template); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return r.remove(template.FilePath)
+}
+
+// ListTemplates pulls changed templates and returns all templates
+func (r *RemoteStorage) ListTemplates() ([]*models.Template, error) {
+	r.pullOrWarn(context.Background(), "templates")
+	return r.local.ListTemplates()
+}
+
+// ListArchivedTemplates pulls changed archived templates and returns them
+func (r *RemoteStorage) ListArchivedTemplates() ([]*models.Template, error) {
+	r.pullOrWarn(context.Background(), ArchivedTemplatesDir)
+	return r.local.ListArchivedTemplates()
+}
+
+// ValidationIssues returns problems found in the cached files
+func (r *RemoteStorage) ValidationIssues() []ValidationIssue {
+	return r.local.ValidationIssues()
+}
+
+// LoadAttachment reads an attachment, fetching it from the remote if not
+// cached
+func (r *RemoteStorage) LoadAttachment(name string) ([]byte, error) {
+	if err := ValidateAttachmentName(name); err != nil {
+		return nil, err
+	}
+	if err := r.ensureCached(attachmentPath(name)); err != nil {
+		return nil, err
+	}
+	return r.local.LoadAttachment(name)
+}
+
+// SaveAttachment writes an attachment to the cache and uploads it
+func (r *RemoteStorage) SaveAttachment(name string, data []byte) error {
+	if err := r.local.SaveAttachment(name, data); err != nil {
+		return err
+	}
+	return r.push(attachmentPath(name))
+}
+
+// ListAttachments returns the names of the attachments on the remote, or
+// in the cache when the remote is unreachable
+func (r *RemoteStorage) ListAttachments() ([]string, error) {
+	prefix := AttachmentsDir + "/"
+	objects, err := r.store.List(prefix)
+	if err != nil {
+		slog.Warn("Failed to list remote attachments, using cached copy", "error", err)
+		return r.local.ListAttachments()
+	}
+	var names []string
+	for _, obj := range objects {
+		if name := strings.TrimPrefix(obj.Key, prefix); name != "" && !strings.HasSuffix(name, "/") {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// LoadSnippet reads a snippet, fetching it from the remote if not cached
+func (r *RemoteStorage) LoadSnippet(name string) (*models.Snippet, error) {
+	if err := ValidateSnippetName(name); err != nil {
+		return nil, err
+	}
+	if err := r.ensureCached(snippetPath(name)); err != nil {
+		return nil, err
+	}
+	return r.local.LoadSnippet(name)
+}
+
+// SaveSnippet writes a snippet to the cache and uploads it
+func (r *RemoteStorage) SaveSnippet(snippet *models.Snippet) error {
+	if err := r.local.SaveSnippet(snippet); err != nil {
+		return err
+	}
+	return r.push(snippet.FilePath)
+}
+
+// DeleteSnippet removes a snippet from the cache and the remote
+func (r *RemoteStorage) DeleteSnippet(name string) error {
+	if err := r.local.DeleteSnippet(name); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return r.remove(snippetPath(name))
+}
+
+// ListSnippets pulls changed snippets and returns all snippets
+func (r *RemoteStorage) ListSnippets() ([]*models.Snippet, error) {
+	r.pullOrWarn(context.Background(), SnippetsDir)
+	return r.local.ListSnippets()
+}
+
+// pullOrWarn pulls a directory, falling back to the cache on failure. A
+// cancelled pull is left for the listing to report.
+func (r *RemoteStorage) pullOrWarn(ctx context.Context, dir string) {
+	if err := r.pull(ctx, dir); err != nil && !errors.Is(err, ctx.Err()) {
+		slog.Warn("Failed to sync from remote storage, using cached copy", "dir", dir, "error", err)
+	}
+	// Make sure the cache directory exists so listing an empty library works
+	os.MkdirAll(filepath.Join(r.cacheDir, dir), 0755)
+}
+
+// pull downloads new or changed objects under dir and drops deleted ones.
+// Pulls run one at a time, so overlapping listings don't download the same
+// objects twice.
+func (r *RemoteStorage) pull(ctx context.Context, dir string) error {
+	prefix := dir + "/"
+	objects, err := r.store.List(prefix)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	remote := make(map[string]bool)
+	for _, obj := range objects {
+		if !strings.HasSuffix(obj.Key, ".md") && path.Base(obj.Key) != DefaultsFile {
+			continue
+		}
+		remote[obj.Key] = true
+
+		localPath := r.localPath(obj.Key)
+		if _, err := os.Stat(localPath); err == nil && obj.ETag != "" && r.etags[obj.Key] == obj.ETag {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := r.fetch(obj.Key, obj.ETag); err != nil {
+			return err
+		}
+	}
+
+	// Remove cached files that were deleted remotely
+	for key := range r.etags {
+		if strings.HasPrefix(key, prefix) && !remote[key] {
+			os.Remove(r.localPath(key))
+			delete(r.etags, key)
+		}
+	}
+
+	return r.saveManifest()
+}
+
+// ensureCached fetches a single object if it is missing from the cache
+func (r *RemoteStorage) ensureCached(path string) error {
+	if _, err := os.Stat(filepath.Join(r.cacheDir, path)); err == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.fetch(filepath.ToSlash(path), ""); err != nil {
+		return err
+	}
+	return r.saveManifest()
+}
+
+// fetch downloads one object into the cache. r.mu must be held.
+func (r *RemoteStorage) fetch(key, etag string) error {
+	data, err := r.store.Get(key)
+	if err != nil {
+		return fmt.Errorf("failed to download %s: %w", key, err)
+	}
+
+	localPath := r.localPath(key)
+	if err := os.MkdirAll(filepath.Dir(localPath), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	if err := os.WriteFile(localPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", localPath, err)
+	}
+
+	if etag != "" {
+		r.etags[key] = etag
+	} else {
+		delete(r.etags, key)
+	}
+	return nil
+}
+
+// push uploads a cached file to the remote
+func (r *RemoteStorage) push(path string) error {
+	key := filepath.ToSlash(path)
+	data, err := os.ReadFile(r.localPath(key))
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	etag, err := r.store.Put(key, data)
+	if err != nil {
+		return fmt.Errorf("failed to upload %s: %w", key, err)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if etag != "" {
+		r.etags[key] = etag
+	} else {
+		delete(r.etags, key)
+	}
+	return r.saveManifest()
+}
+
+// remove deletes an object from the remote
+func (r *RemoteStorage) remove(path string) error {
+	key := filepath.ToSlash(path)
+	if err := r.store.Delete(key); err != nil {
+		return fmt.Errorf("failed to delete %s from remote storage: %w", key, err)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.etags, key)
+	return r.saveManifest()
+}
+
+func (r *RemoteStorage) localPath(key string) string {
+	return filepath.Join(r.cacheDir, filepath.FromSlash(key))
+}
+
+// saveManifest writes the ETags of the cached objects. r.mu must be held.
+func (r *RemoteStorage) saveManifest() error {
+	data, err := json.MarshalIndent(r.etags, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(r.manifestFile), 0755); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	if err := os.WriteFile(r.manifestFile, data, 0644); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	return nil
+}
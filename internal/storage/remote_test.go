@@ -0,0 +1,243 @@
+package storage
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/dpshade/pocket-prompt/internal/config"
+	"github.com/dpshade/pocket-prompt/internal/models"
+)
+
+// fakeS3 is a minimal path-style S3 server holding objects in memory
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+	gets    int
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 ") {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	key := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, "/bucket"), "/")
+	switch {
+	case r.Method == http.MethodGet && key == "":
+		type content struct {
+			Key  string `xml:"Key"`
+			ETag string `xml:"ETag"`
+		}
+		var result struct {
+			XMLName  xml.Name  `xml:"ListBucketResult"`
+			Contents []content `xml:"Contents"`
+		}
+		prefix := r.URL.Query().Get("prefix")
+		var keys []string
+		for k := range f.objects {
+			if strings.HasPrefix(k, prefix) {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			result.Contents = append(result.Contents, content{Key: k, ETag: `"` + etagOf(f.objects[k]) + `"`})
+		}
+		xml.NewEncoder(w).Encode(result)
+	case r.Method == http.MethodGet:
+		data, ok := f.objects[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		f.gets++
+		w.Write(data)
+	case r.Method == http.MethodPut:
+		data, _ := io.ReadAll(r.Body)
+		f.objects[key] = data
+		w.Header().Set("ETag", `"`+etagOf(data)+`"`)
+	case r.Method == http.MethodDelete:
+		delete(f.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func etagOf(data []byte) string {
+	sum := md5.Sum(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func TestRemoteStorageWithS3(t *testing.T) {
+	fake := &fakeS3{objects: make(map[string][]byte)}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	store, err := NewS3Store(config.S3Config{
+		Endpoint:        server.URL,
+		Bucket:          "bucket",
+		Prefix:          "team",
+		AccessKeyID:     "key",
+		SecretAccessKey: "secret",
+		PathStyle:       true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create S3 store: %v", err)
+	}
+
+	remote, err := NewRemoteStorage(t.TempDir(), store)
+	if err != nil {
+		t.Fatalf("Failed to create remote storage: %v", err)
+	}
+	if err := remote.InitLibrary(); err != nil {
+		t.Fatalf("Failed to init library: %v", err)
+	}
+
+	prompt := &models.Prompt{
+		ID:       "shared",
+		Version:  "1.0.0",
+		Name:     "Shared Prompt",
+		Content:  "Shared body",
+		FilePath: filepath.Join("prompts", "shared.md"),
+	}
+	if err := remote.SavePrompt(prompt); err != nil {
+		t.Fatalf("Failed to save prompt: %v", err)
+	}
+	if _, ok := fake.objects["team/prompts/shared.md"]; !ok {
+		t.Fatalf("Expected object to be uploaded under prefix, got %v", fake.objects)
+	}
+
+	// A second client with an empty cache sees the prompt
+	other, err := NewRemoteStorage(t.TempDir(), store)
+	if err != nil {
+		t.Fatalf("Failed to create second remote storage: %v", err)
+	}
+	prompts, err := other.ListPrompts(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to list prompts: %v", err)
+	}
+	if len(prompts) != 1 || prompts[0].ID != "shared" {
+		t.Fatalf("Expected shared prompt, got %+v", prompts)
+	}
+
+	// Unchanged objects are not downloaded again
+	gets := fake.gets
+	if _, err := other.ListPrompts(context.Background()); err != nil {
+		t.Fatalf("Failed to list prompts: %v", err)
+	}
+	if fake.gets != gets {
+		t.Errorf("Expected no downloads for unchanged objects, got %d", fake.gets-gets)
+	}
+
+	// Remote deletions are reflected in the cache
+	if err := remote.DeletePrompt(prompt); err != nil {
+		t.Fatalf("Failed to delete prompt: %v", err)
+	}
+	prompts, err = other.ListPrompts(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to list prompts: %v", err)
+	}
+	if len(prompts) != 0 {
+		t.Errorf("Expected no prompts after remote delete, got %d", len(prompts))
+	}
+}
+
+// memoryObjects is an ObjectStore holding objects in memory
+type memoryObjects struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+func (m *memoryObjects) List(prefix string) ([]ObjectInfo, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var infos []ObjectInfo
+	for key, data := range m.objects {
+		if strings.HasPrefix(key, prefix) {
+			infos = append(infos, ObjectInfo{Key: key, ETag: etagOf(data)})
+		}
+	}
+	return infos, nil
+}
+
+func (m *memoryObjects) Get(key string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	data, ok := m.objects[key]
+	if !ok {
+		return nil, fmt.Errorf("%s not found", key)
+	}
+	return data, nil
+}
+
+func (m *memoryObjects) Put(key string, data []byte) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.objects[key] = data
+	return etagOf(data), nil
+}
+
+func (m *memoryObjects) Delete(key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.objects, key)
+	return nil
+}
+
+func TestRemoteStorageConcurrentListing(t *testing.T) {
+	objects := &memoryObjects{objects: make(map[string][]byte)}
+	remote, err := NewRemoteStorage(t.TempDir(), objects)
+	if err != nil {
+		t.Fatalf("Failed to create remote storage: %v", err)
+	}
+	for i := 0; i < 10; i++ {
+		prompt := &models.Prompt{ID: fmt.Sprintf("p%d", i), Version: "1.0.0", Name: "Prompt", Content: "Body",
+			FilePath: filepath.Join("prompts", fmt.Sprintf("p%d.md", i))}
+		if err := remote.SavePrompt(prompt); err != nil {
+			t.Fatalf("Failed to save prompt: %v", err)
+		}
+	}
+
+	// Listings from several clients of the same library, such as
+	// overlapping server requests, each pull and update the manifest
+	other, err := NewRemoteStorage(t.TempDir(), objects)
+	if err != nil {
+		t.Fatalf("Failed to create second remote storage: %v", err)
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if _, err := other.ListPrompts(context.Background()); err != nil {
+				t.Errorf("Failed to list prompts: %v", err)
+			}
+			if i%2 == 0 {
+				prompt := &models.Prompt{ID: "extra", Version: "1.0.0", Name: "Extra", Content: fmt.Sprint(i),
+					FilePath: filepath.Join("prompts", fmt.Sprintf("extra%d.md", i))}
+				if err := other.SavePrompt(prompt); err != nil {
+					t.Errorf("Failed to save prompt: %v", err)
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	prompts, err := other.ListPrompts(context.Background())
+	if err != nil || len(prompts) != 14 {
+		t.Errorf("Expected all 14 prompts after concurrent listings, got %d, %v", len(prompts), err)
+	}
+}
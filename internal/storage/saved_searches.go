@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"sync"
 	"time"
 
 	"github.com/dpshade/pocket-prompt/internal/models"
@@ -15,6 +17,10 @@ const savedSearchesFile = "saved_searches.json"
 // SavedSearchesStorage handles persistence of saved boolean searches
 type SavedSearchesStorage struct {
 	filePath string
+
+	// Without a file path the searches are kept in memory
+	mu       sync.Mutex
+	searches []models.SavedSearch
 }
 
 // NewSavedSearchesStorage creates a new saved searches storage
@@ -24,6 +30,12 @@ func NewSavedSearchesStorage(baseDir string) *SavedSearchesStorage {
 	}
 }
 
+// NewMemorySavedSearchesStorage creates a saved searches storage that keeps
+// the searches in memory only, for libraries that aren't on disk
+func NewMemorySavedSearchesStorage() *SavedSearchesStorage {
+	return &SavedSearchesStorage{}
+}
+
 // SavedSearchesData represents the JSON structure for saved searches
 type SavedSearchesData struct {
 	Searches []models.SavedSearch `json:"searches"`
@@ -32,6 +44,12 @@ type SavedSearchesData struct {
 
 // LoadSavedSearches loads all saved searches from disk
 func (s *SavedSearchesStorage) LoadSavedSearches() ([]models.SavedSearch, error) {
+	if s.filePath == "" {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return append([]models.SavedSearch{}, s.searches...), nil
+	}
+
 	// Check if file exists
 	if _, err := os.Stat(s.filePath); os.IsNotExist(err) {
 		return []models.SavedSearch{}, nil
@@ -54,6 +72,13 @@ func (s *SavedSearchesStorage) LoadSavedSearches() ([]models.SavedSearch, error)
 
 // SaveSearches saves all searches to disk
 func (s *SavedSearchesStorage) SaveSearches(searches []models.SavedSearch) error {
+	if s.filePath == "" {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.searches = slices.Clone(searches)
+		return nil
+	}
+
 	// Ensure directory exists
 	if err := os.MkdirAll(filepath.Dir(s.filePath), 0755); err != nil {
 		return fmt.Errorf("failed to create saved searches directory: %w", err)
@@ -0,0 +1,146 @@
+package storage
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dpshade/pocket-prompt/internal/models"
+)
+
+// SnippetsDir holds the reusable fragments prompts include with
+// {{snippet:name}}. A snippet is a markdown file named after it, e.g.
+// snippets/personas/reviewer.md, with optional YAML frontmatter.
+const SnippetsDir = "snippets"
+
+// ValidateSnippetName checks that a snippet name can be referenced from
+// prompt content and stays inside the snippets directory
+func ValidateSnippetName(name string) error {
+	if !models.ValidSnippetName(name) {
+		return fmt.Errorf("invalid snippet name %q: use letters, digits, - and _, with / between folders", name)
+	}
+	return nil
+}
+
+// snippetPath returns the path of a snippet relative to the library root
+func snippetPath(name string) string {
+	return filepath.Join(SnippetsDir, filepath.FromSlash(name)+".md")
+}
+
+// LoadSnippet reads a snippet
+func (s *Storage) LoadSnippet(name string) (*models.Snippet, error) {
+	if err := ValidateSnippetName(name); err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(filepath.Join(s.rootPath, snippetPath(name)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snippet: %w", err)
+	}
+	return parseSnippet(name, data)
+}
+
+// SaveSnippet writes a snippet, creating its directory
+func (s *Storage) SaveSnippet(snippet *models.Snippet) error {
+	if err := ValidateSnippetName(snippet.Name); err != nil {
+		return err
+	}
+	snippet.FilePath = snippetPath(snippet.Name)
+	fullPath := filepath.Join(s.rootPath, snippet.FilePath)
+	if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	data, err := serializeSnippet(snippet)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(fullPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write snippet: %w", err)
+	}
+	return nil
+}
+
+// DeleteSnippet removes a snippet
+func (s *Storage) DeleteSnippet(name string) error {
+	if err := ValidateSnippetName(name); err != nil {
+		return err
+	}
+	return os.Remove(filepath.Join(s.rootPath, snippetPath(name)))
+}
+
+// ListSnippets returns all snippets, sorted by name
+func (s *Storage) ListSnippets() ([]*models.Snippet, error) {
+	dir := filepath.Join(s.rootPath, SnippetsDir)
+	s.issues.clear(SnippetsDir)
+	var snippets []*models.Snippet
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && p == dir {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(p, ".md") {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		name := strings.TrimSuffix(filepath.ToSlash(rel), ".md")
+		if !models.ValidSnippetName(name) {
+			return nil
+		}
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		snippet, err := parseSnippet(name, data)
+		if err != nil {
+			s.issues.add(ValidationIssue{FilePath: filepath.Join(SnippetsDir, rel), Message: err.Error()})
+			return nil
+		}
+		snippets = append(snippets, snippet)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list snippets: %w", err)
+	}
+	return snippets, nil
+}
+
+// parseSnippet reads a snippet file, which only has frontmatter when it
+// starts with a --- line. Trailing newlines are dropped so snippets can be
+// included mid-line.
+func parseSnippet(name string, data []byte) (*models.Snippet, error) {
+	snippet := &models.Snippet{Name: name, FilePath: snippetPath(name)}
+	body := string(data)
+	if first, _, _ := strings.Cut(body, "\n"); strings.TrimRight(first, "\r") == "---" {
+		block, rest, err := splitFrontmatter(data)
+		if err != nil {
+			return nil, err
+		}
+		if err := block.decode(snippet); err != nil {
+			return nil, fmt.Errorf("failed to parse frontmatter: %w", err)
+		}
+		body = rest
+	}
+	snippet.Content = strings.TrimRight(body, "\r\n")
+	return snippet, nil
+}
+
+// serializeSnippet writes a snippet as plain markdown, adding YAML
+// frontmatter only when it has a description
+func serializeSnippet(snippet *models.Snippet) ([]byte, error) {
+	if snippet.Description != "" {
+		return serializeWithBody(snippet, snippet.Content, FormatYAML)
+	}
+	var buf bytes.Buffer
+	buf.WriteString(snippet.Content)
+	if !strings.HasSuffix(snippet.Content, "\n") {
+		buf.WriteString("\n")
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,468 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dpshade/pocket-prompt/internal/models"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// sqliteDriverName is registered by sqlite_driver.go
+	sqliteDriverName  = "sqlite"
+	defaultSQLiteFile = "library.db"
+)
+
+const sqliteSchema = `
+CREATE TABLE IF NOT EXISTS prompts (
+	path         TEXT PRIMARY KEY,
+	frontmatter  TEXT NOT NULL,
+	content      TEXT NOT NULL,
+	content_hash TEXT NOT NULL
+);
+CREATE TABLE IF NOT EXISTS templates (
+	path        TEXT PRIMARY KEY,
+	frontmatter TEXT NOT NULL,
+	content     TEXT NOT NULL
+);
+CREATE TABLE IF NOT EXISTS attachments (
+	name TEXT PRIMARY KEY,
+	data BLOB NOT NULL
+);
+CREATE TABLE IF NOT EXISTS snippets (
+	name        TEXT PRIMARY KEY,
+	description TEXT NOT NULL,
+	content     TEXT NOT NULL
+);
+`
+
+// SQLiteStorage keeps prompts, templates and archived versions in a single
+// SQLite database file. Rows are keyed by the same relative paths the file
+// backend uses, so archived versions live under "archive/".
+type SQLiteStorage struct {
+	rootPath string
+	db       *sql.DB
+	issues   *issueLog
+}
+
+// NewSQLiteStorage opens (or creates) the database at dbPath, which is
+// resolved relative to rootPath unless absolute
+func NewSQLiteStorage(rootPath, dbPath string) (*SQLiteStorage, error) {
+	if dbPath == "" {
+		dbPath = defaultSQLiteFile
+	}
+	if !filepath.IsAbs(dbPath) {
+		dbPath = filepath.Join(rootPath, dbPath)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(dbPath), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create database directory: %w", err)
+	}
+
+	db, err := sql.Open(sqliteDriverName, dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open sqlite database: %w", err)
+	}
+
+	s := &SQLiteStorage{
+		rootPath: rootPath,
+		db:       db,
+		issues:   newIssueLog(),
+	}
+	if err := s.migrate(); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// migrate creates the schema if it does not exist yet
+func (s *SQLiteStorage) migrate() error {
+	if _, err := s.db.Exec(sqliteSchema); err != nil {
+		return fmt.Errorf("failed to create sqlite schema: %w", err)
+	}
+	return nil
+}
+
+// Close closes the underlying database
+func (s *SQLiteStorage) Close() error {
+	return s.db.Close()
+}
+
+// InitLibrary creates the library root and database schema
+func (s *SQLiteStorage) InitLibrary() error {
+	if err := os.MkdirAll(s.rootPath, 0755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", s.rootPath, err)
+	}
+	return s.migrate()
+}
+
+// GetBaseDir returns the root path of the storage
+func (s *SQLiteStorage) GetBaseDir() string {
+	return s.rootPath
+}
+
+// LoadPrompt loads a prompt including its content
+func (s *SQLiteStorage) LoadPrompt(path string) (*models.Prompt, error) {
+	var frontmatter, content, hash string
+	err := s.db.QueryRow(
+		`SELECT frontmatter, content, content_hash FROM prompts WHERE path = ?`,
+		sqliteKey(path),
+	).Scan(&frontmatter, &content, &hash)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("failed to open prompt file: %s does not exist", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read prompt: %w", err)
+	}
+
+	prompt, err := decodePromptRow(path, frontmatter, hash)
+	if err != nil {
+		return nil, err
+	}
+	prompt.Content = content
+
+	return prompt, nil
+}
+
+// LoadPromptMetadata loads a prompt without its content
+func (s *SQLiteStorage) LoadPromptMetadata(path string) (*models.Prompt, error) {
+	var frontmatter, hash string
+	err := s.db.QueryRow(
+		`SELECT frontmatter, content_hash FROM prompts WHERE path = ?`,
+		sqliteKey(path),
+	).Scan(&frontmatter, &hash)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("failed to open prompt file: %s does not exist", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read prompt: %w", err)
+	}
+
+	return decodePromptRow(path, frontmatter, hash)
+}
+
+// SavePrompt inserts or replaces a prompt
+func (s *SQLiteStorage) SavePrompt(prompt *models.Prompt) error {
+	frontmatter, err := yaml.Marshal(withLocalID(prompt))
+	if err != nil {
+		return fmt.Errorf("failed to serialize prompt: %w", err)
+	}
+
+	serialized, err := serializePrompt(withLocalID(prompt), FormatYAML)
+	if err != nil {
+		return fmt.Errorf("failed to serialize prompt: %w", err)
+	}
+
+	_, err = s.db.Exec(
+		`INSERT INTO prompts (path, frontmatter, content, content_hash) VALUES (?, ?, ?, ?)
+		 ON CONFLICT(path) DO UPDATE SET frontmatter = excluded.frontmatter,
+		 content = excluded.content, content_hash = excluded.content_hash`,
+		sqliteKey(prompt.FilePath), string(frontmatter), prompt.Content, calculateHash(serialized),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to write prompt: %w", err)
+	}
+
+	return nil
+}
+
+// DeletePrompt removes a prompt
+func (s *SQLiteStorage) DeletePrompt(prompt *models.Prompt) error {
+	result, err := s.db.Exec(`DELETE FROM prompts WHERE path = ?`, sqliteKey(prompt.FilePath))
+	if err != nil {
+		return fmt.Errorf("failed to delete prompt: %w", err)
+	}
+	if n, err := result.RowsAffected(); err == nil && n == 0 {
+		return fmt.Errorf("prompt does not exist: %s", prompt.FilePath)
+	}
+	return nil
+}
+
+// ListPrompts returns all prompts in the library (excluding archived prompts)
+func (s *SQLiteStorage) ListPrompts(ctx context.Context) ([]*models.Prompt, error) {
+	return s.listPromptsWithPrefix(ctx, "prompts/")
+}
+
+// ListArchivedPrompts returns all archived prompts
+func (s *SQLiteStorage) ListArchivedPrompts(ctx context.Context) ([]*models.Prompt, error) {
+	return s.listPromptsWithPrefix(ctx, "archive/")
+}
+
+// listPromptsWithPrefix lists prompt metadata for paths under prefix
+func (s *SQLiteStorage) listPromptsWithPrefix(ctx context.Context, prefix string) ([]*models.Prompt, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT path, frontmatter, content_hash FROM prompts WHERE substr(path, 1, ?) = ? ORDER BY path`,
+		len(prefix), prefix,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list prompts: %w", err)
+	}
+	defer rows.Close()
+
+	s.issues.clear(strings.TrimSuffix(prefix, "/"))
+	var prompts []*models.Prompt
+	for rows.Next() {
+		var path, frontmatter, hash string
+		if err := rows.Scan(&path, &frontmatter, &hash); err != nil {
+			return nil, fmt.Errorf("failed to list prompts: %w", err)
+		}
+		prompt, err := decodePromptRow(filepath.FromSlash(path), frontmatter, hash)
+		if err != nil {
+			s.recordIssues(path, frontmatter, err, ValidatePrompt)
+			continue
+		}
+		prompts = append(prompts, prompt)
+	}
+
+	return prompts, rows.Err()
+}
+
+// LoadTemplate loads a template
+func (s *SQLiteStorage) LoadTemplate(path string) (*models.Template, error) {
+	var frontmatter, content string
+	err := s.db.QueryRow(
+		`SELECT frontmatter, content FROM templates WHERE path = ?`,
+		sqliteKey(path),
+	).Scan(&frontmatter, &content)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("failed to open template file: %s does not exist", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template: %w", err)
+	}
+
+	return decodeTemplateRow(path, frontmatter, content)
+}
+
+// SaveTemplate inserts or replaces a template
+func (s *SQLiteStorage) SaveTemplate(template *models.Template) error {
+	frontmatter, err := yaml.Marshal(template)
+	if err != nil {
+		return fmt.Errorf("failed to serialize template: %w", err)
+	}
+
+	_, err = s.db.Exec(
+		`INSERT INTO templates (path, frontmatter, content) VALUES (?, ?, ?)
+		 ON CONFLICT(path) DO UPDATE SET frontmatter = excluded.frontmatter, content = excluded.content`,
+		sqliteKey(template.FilePath), string(frontmatter), template.Content,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to write template: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteTemplate removes a template
+func (s *SQLiteStorage) DeleteTemplate(template *models.Template) error {
+	if _, err := s.db.Exec(`DELETE FROM templates WHERE path = ?`, sqliteKey(template.FilePath)); err != nil {
+		return fmt.Errorf("failed to delete template: %w", err)
+	}
+	return nil
+}
+
+// ListTemplates returns all templates in the library
+func (s *SQLiteStorage) ListTemplates() ([]*models.Template, error) {
+	return s.listTemplates("templates", false)
+}
+
+// ListArchivedTemplates returns the old versions of templates
+func (s *SQLiteStorage) ListArchivedTemplates() ([]*models.Template, error) {
+	return s.listTemplates(ArchivedTemplatesDir, true)
+}
+
+// listTemplates lists the current or the archived templates, recording
+// issues under dir
+func (s *SQLiteStorage) listTemplates(dir string, archived bool) ([]*models.Template, error) {
+	prefix := ArchivedTemplatesDir + "/"
+	rows, err := s.db.Query(
+		`SELECT path, frontmatter, content FROM templates WHERE (substr(path, 1, ?) = ?) = ? ORDER BY path`,
+		len(prefix), prefix, archived,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list templates: %w", err)
+	}
+	defer rows.Close()
+
+	s.issues.clear(dir)
+	var templates []*models.Template
+	for rows.Next() {
+		var path, frontmatter, content string
+		if err := rows.Scan(&path, &frontmatter, &content); err != nil {
+			return nil, fmt.Errorf("failed to list templates: %w", err)
+		}
+		template, err := decodeTemplateRow(filepath.FromSlash(path), frontmatter, content)
+		if err != nil {
+			s.recordIssues(path, frontmatter, err, ValidateTemplate)
+			continue
+		}
+		templates = append(templates, template)
+	}
+
+	return templates, rows.Err()
+}
+
+// ValidationIssues returns the problems found in rows during the last listing
+func (s *SQLiteStorage) ValidationIssues() []ValidationIssue {
+	return s.issues.all()
+}
+
+// recordIssues validates a row that failed to decode, reusing the file
+// validator on the equivalent markdown document
+func (s *SQLiteStorage) recordIssues(path, frontmatter string, loadErr error, validate func(string, []byte) []ValidationIssue) {
+	issues := validate(path, []byte("---\n"+frontmatter+"\n---\n"))
+	if len(issues) == 0 {
+		issues = []ValidationIssue{{FilePath: path, Message: loadErr.Error()}}
+	}
+	for _, issue := range issues {
+		s.issues.add(issue)
+		slog.Warn("Skipped invalid file", "issue", issue)
+	}
+}
+
+// LoadAttachment reads an attachment
+func (s *SQLiteStorage) LoadAttachment(name string) ([]byte, error) {
+	if err := ValidateAttachmentName(name); err != nil {
+		return nil, err
+	}
+	var data []byte
+	err := s.db.QueryRow(`SELECT data FROM attachments WHERE name = ?`, name).Scan(&data)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("failed to read attachment: %s does not exist", name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read attachment: %w", err)
+	}
+	return data, nil
+}
+
+// SaveAttachment inserts or replaces an attachment
+func (s *SQLiteStorage) SaveAttachment(name string, data []byte) error {
+	if err := ValidateAttachmentName(name); err != nil {
+		return err
+	}
+	_, err := s.db.Exec(
+		`INSERT INTO attachments (name, data) VALUES (?, ?)
+		 ON CONFLICT(name) DO UPDATE SET data = excluded.data`,
+		name, data,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to write attachment: %w", err)
+	}
+	return nil
+}
+
+// ListAttachments returns the names of all attachments, sorted
+func (s *SQLiteStorage) ListAttachments() ([]string, error) {
+	rows, err := s.db.Query(`SELECT name FROM attachments ORDER BY name`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list attachments: %w", err)
+	}
+	defer rows.Close()
+
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("failed to list attachments: %w", err)
+		}
+		names = append(names, name)
+	}
+	return names, rows.Err()
+}
+
+// LoadSnippet reads a snippet
+func (s *SQLiteStorage) LoadSnippet(name string) (*models.Snippet, error) {
+	if err := ValidateSnippetName(name); err != nil {
+		return nil, err
+	}
+	snippet := &models.Snippet{Name: name, FilePath: snippetPath(name)}
+	err := s.db.QueryRow(`SELECT description, content FROM snippets WHERE name = ?`, name).
+		Scan(&snippet.Description, &snippet.Content)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("failed to read snippet: %s does not exist", name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snippet: %w", err)
+	}
+	return snippet, nil
+}
+
+// SaveSnippet inserts or replaces a snippet
+func (s *SQLiteStorage) SaveSnippet(snippet *models.Snippet) error {
+	if err := ValidateSnippetName(snippet.Name); err != nil {
+		return err
+	}
+	snippet.FilePath = snippetPath(snippet.Name)
+	_, err := s.db.Exec(
+		`INSERT INTO snippets (name, description, content) VALUES (?, ?, ?)
+		 ON CONFLICT(name) DO UPDATE SET description = excluded.description, content = excluded.content`,
+		snippet.Name, snippet.Description, snippet.Content,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to write snippet: %w", err)
+	}
+	return nil
+}
+
+// DeleteSnippet removes a snippet
+func (s *SQLiteStorage) DeleteSnippet(name string) error {
+	if _, err := s.db.Exec(`DELETE FROM snippets WHERE name = ?`, name); err != nil {
+		return fmt.Errorf("failed to delete snippet: %w", err)
+	}
+	return nil
+}
+
+// ListSnippets returns all snippets, sorted by name
+func (s *SQLiteStorage) ListSnippets() ([]*models.Snippet, error) {
+	rows, err := s.db.Query(`SELECT name, description, content FROM snippets ORDER BY name`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list snippets: %w", err)
+	}
+	defer rows.Close()
+
+	var snippets []*models.Snippet
+	for rows.Next() {
+		snippet := &models.Snippet{}
+		if err := rows.Scan(&snippet.Name, &snippet.Description, &snippet.Content); err != nil {
+			return nil, fmt.Errorf("failed to list snippets: %w", err)
+		}
+		snippet.FilePath = snippetPath(snippet.Name)
+		snippets = append(snippets, snippet)
+	}
+	return snippets, rows.Err()
+}
+
+// sqliteKey normalizes a relative path so databases are portable across platforms
+func sqliteKey(path string) string {
+	return strings.TrimPrefix(filepath.ToSlash(filepath.Clean(path)), "./")
+}
+
+func decodePromptRow(path, frontmatter, hash string) (*models.Prompt, error) {
+	var prompt models.Prompt
+	if err := yaml.Unmarshal([]byte(frontmatter), &prompt); err != nil {
+		return nil, fmt.Errorf("failed to parse prompt: failed to parse frontmatter: %w", err)
+	}
+	prompt.FilePath = path
+	prompt.ContentHash = hash
+	applyNamespace(&prompt, path)
+	return &prompt, nil
+}
+
+func decodeTemplateRow(path, frontmatter, content string) (*models.Template, error) {
+	var template models.Template
+	if err := yaml.Unmarshal([]byte(frontmatter), &template); err != nil {
+		return nil, fmt.Errorf("failed to parse template: failed to parse frontmatter: %w", err)
+	}
+	template.FilePath = path
+	template.Content = content
+	return &template, nil
+}
@@ -0,0 +1,5 @@
+package storage
+
+// modernc.org/sqlite is a pure-Go driver, so the SQLite backend needs no
+// cgo or system library
+import _ "modernc.org/sqlite"
@@ -0,0 +1,81 @@
+package storage
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dpshade/pocket-prompt/internal/config"
+	"github.com/dpshade/pocket-prompt/internal/models"
+)
+
+func TestSQLiteStorage(t *testing.T) {
+	root := t.TempDir()
+	backend, err := Open(root, config.StorageConfig{Backend: "sqlite"})
+	if err != nil {
+		t.Fatalf("Failed to open sqlite backend: %v", err)
+	}
+	store, ok := backend.(*SQLiteStorage)
+	if !ok {
+		t.Fatalf("Expected a SQLiteStorage, got %T", backend)
+	}
+	defer store.Close()
+	if err := store.InitLibrary(); err != nil {
+		t.Fatalf("Failed to init library: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, defaultSQLiteFile)); err != nil {
+		t.Errorf("Expected the library in one database file: %v", err)
+	}
+
+	prompt := &models.Prompt{
+		ID:       "team/launch",
+		Version:  "1.1.0",
+		Name:     "Launch",
+		Tags:     []string{"planning"},
+		Content:  "Plan the launch",
+		FilePath: filepath.Join("prompts", "team", "launch.md"),
+	}
+	archived := &models.Prompt{ID: "team/launch", Version: "1.0.0", Name: "Launch", Content: "Plan it", FilePath: filepath.Join("archive", "launch-v1.0.0.md")}
+	for _, p := range []*models.Prompt{prompt, archived} {
+		if err := store.SavePrompt(p); err != nil {
+			t.Fatalf("Failed to save prompt: %v", err)
+		}
+	}
+	template := &models.Template{ID: "brief", Version: "1.0.0", Name: "Brief", Content: "{{.content}}", FilePath: filepath.Join("templates", "brief.md")}
+	if err := store.SaveTemplate(template); err != nil {
+		t.Fatalf("Failed to save template: %v", err)
+	}
+
+	loaded, err := store.LoadPrompt(prompt.FilePath)
+	if err != nil {
+		t.Fatalf("Failed to load prompt: %v", err)
+	}
+	if loaded.ID != "team/launch" || loaded.Version != "1.1.0" || loaded.Content != "Plan the launch" || len(loaded.Tags) != 1 {
+		t.Errorf("Expected the saved prompt back, got %+v", loaded)
+	}
+
+	listed, err := store.ListPrompts(context.Background())
+	if err != nil || len(listed) != 1 || listed[0].ID != "team/launch" || listed[0].Content != "" {
+		t.Errorf("Expected the active prompt listed without its body, got %+v, %v", listed, err)
+	}
+	versions, err := store.ListArchivedPrompts(context.Background())
+	if err != nil || len(versions) != 1 || versions[0].Version != "1.0.0" {
+		t.Errorf("Expected the archived version listed, got %+v, %v", versions, err)
+	}
+	templates, err := store.ListTemplates()
+	if err != nil || len(templates) != 1 || templates[0].ID != "brief" || templates[0].Content != "{{.content}}" {
+		t.Errorf("Expected the saved template listed, got %+v, %v", templates, err)
+	}
+
+	// The library survives reopening the database
+	store.Close()
+	reopened, err := Open(root, config.StorageConfig{Backend: "sqlite"})
+	if err != nil {
+		t.Fatalf("Failed to reopen sqlite backend: %v", err)
+	}
+	defer reopened.(*SQLiteStorage).Close()
+	if listed, err := reopened.ListPrompts(context.Background()); err != nil || len(listed) != 1 {
+		t.Errorf("Expected the prompt after reopening, got %+v, %v", listed, err)
+	}
+}
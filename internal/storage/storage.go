@@ -3,46 +3,64 @@ package storage
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
 	"io"
+	"log/slog"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/dpshade/pocket-prompt/internal/models"
-	"gopkg.in/yaml.v3"
+	"github.com/dpshade/pocket-prompt/internal/startup"
 )
 
 // Storage handles all file system operations for prompts, templates, and packs
 type Storage struct {
 	rootPath string
 	cache    *MetadataCache
+	issues   *issueLog
+	format   FrontmatterFormat // Format for new files; existing files keep theirs
 }
 
 // NewStorage creates a new storage instance
 func NewStorage(rootPath string) (*Storage, error) {
-	if rootPath == "" {
-		homeDir, err := os.UserHomeDir()
-		if err != nil {
-			return nil, err
-		}
-		rootPath = filepath.Join(homeDir, ".pocket-prompt")
+	rootPath, err := ResolveRootPath(rootPath)
+	if err != nil {
+		return nil, err
 	}
 
 	cache := NewMetadataCache(rootPath)
 	if err := cache.Load(); err != nil {
 		// Log error but don't fail - cache is optional
-		fmt.Fprintf(os.Stderr, "Warning: failed to load metadata cache: %v\n", err)
+		slog.Warn("Failed to load metadata cache", "error", err)
 	}
 
 	return &Storage{
 		rootPath: rootPath,
 		cache:    cache,
+		issues:   newIssueLog(),
+		format:   FormatYAML,
 	}, nil
 }
 
+// SetFrontmatterFormat selects the frontmatter format used for new files
+func (s *Storage) SetFrontmatterFormat(format FrontmatterFormat) {
+	s.format = format
+}
+
+// formatFor returns the format of the file at fullPath, or the configured
+// format when the file is new
+func (s *Storage) formatFor(fullPath string) FrontmatterFormat {
+	if format, ok := fileFrontmatterFormat(fullPath); ok {
+		return format
+	}
+	return s.format
+}
+
 // InitLibrary creates the directory structure for a prompt library
 func (s *Storage) InitLibrary() error {
 	dirs := []string{
@@ -93,10 +111,58 @@ func (s *Storage) LoadPrompt(path string) (*models.Prompt, error) {
 
 	prompt.FilePath = path
 	prompt.ContentHash = calculateHash(content)
+	applyNamespace(prompt, path)
+	fileDefaults(s.rootPath, path).apply(prompt)
+
+	// Listings don't hash bodies, so remember the hash now it is known
+	if info, err := file.Stat(); err == nil {
+		s.cache.SetHash(path, info, prompt.ContentHash)
+	}
+
+	return prompt, nil
+}
 
+// LoadPromptMetadata loads only the frontmatter of a prompt, leaving
+// Content and ContentHash empty. The body is never read, so listing large
+// libraries stays cheap.
+func (s *Storage) LoadPromptMetadata(path string) (*models.Prompt, error) {
+	prompt, err := s.loadPromptMetadata(path)
+	if err != nil {
+		return nil, err
+	}
+	fileDefaults(s.rootPath, path).apply(prompt)
 	return prompt, nil
 }
 
+// loadPromptMetadata loads the frontmatter as it is in the file, without
+// directory defaults, which is what the metadata cache keeps
+func (s *Storage) loadPromptMetadata(path string) (*models.Prompt, error) {
+	fullPath := filepath.Join(s.rootPath, path)
+
+	file, err := os.Open(fullPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open prompt file: %w", err)
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+
+	block, err := readFrontmatter(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse prompt: %w", err)
+	}
+
+	var prompt models.Prompt
+	if err := block.decode(&prompt); err != nil {
+		return nil, fmt.Errorf("failed to parse prompt: failed to parse frontmatter: %w", err)
+	}
+
+	prompt.FilePath = path
+	applyNamespace(&prompt, path)
+
+	return &prompt, nil
+}
+
 // SavePrompt saves a prompt to a markdown file with YAML frontmatter
 func (s *Storage) SavePrompt(prompt *models.Prompt) error {
 	fullPath := filepath.Join(s.rootPath, prompt.FilePath)
@@ -107,8 +173,9 @@ func (s *Storage) SavePrompt(prompt *models.Prompt) error {
 		return fmt.Errorf("failed to create directory: %w", err)
 	}
 
-	// Serialize prompt to YAML frontmatter + markdown
-	content, err := serializePrompt(prompt)
+	// Serialize prompt to frontmatter + markdown
+	own := fileDefaults(s.rootPath, prompt.FilePath).strip(prompt)
+	content, err := serializePrompt(withLocalID(own), s.formatFor(fullPath))
 	if err != nil {
 		return fmt.Errorf("failed to serialize prompt: %w", err)
 	}
@@ -148,8 +215,8 @@ func (s *Storage) SaveTemplate(template *models.Template) error {
 		return fmt.Errorf("failed to create directory: %w", err)
 	}
 	
-	// Serialize template to YAML frontmatter + markdown
-	content, err := serializeTemplate(template)
+	// Serialize template to frontmatter + markdown
+	content, err := serializeTemplate(template, s.formatFor(fullPath))
 	if err != nil {
 		return fmt.Errorf("failed to serialize template: %w", err)
 	}
@@ -163,73 +230,104 @@ func (s *Storage) SaveTemplate(template *models.Template) error {
 }
 
 // ListPrompts returns all prompts in the library (excluding archived prompts)
-func (s *Storage) ListPrompts() ([]*models.Prompt, error) {
-	return s.listPromptsFromDir("prompts")
+func (s *Storage) ListPrompts(ctx context.Context) ([]*models.Prompt, error) {
+	return s.listPromptsFromDir(ctx, "prompts")
 }
 
 // listPromptsFromDir returns prompts from a specific directory with caching
-func (s *Storage) listPromptsFromDir(dir string) ([]*models.Prompt, error) {
+func (s *Storage) listPromptsFromDir(ctx context.Context, dir string) ([]*models.Prompt, error) {
+	defer startup.Phase("storage walk")()
 	promptsDir := filepath.Join(s.rootPath, dir)
 	
 	var prompts []*models.Prompt
 	existingFiles := make(map[string]bool)
-	cacheModified := false
+	s.issues.clear(dir)
+	start := time.Now()
+	cached := 0
+	defaults := newDefaultsLoader(func(relPath string) ([]byte, error) {
+		return os.ReadFile(filepath.Join(s.rootPath, filepath.FromSlash(relPath)))
+	}, s.recordInvalidDefaults)
 	
 	err := filepath.Walk(promptsDir, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 
+		relPath, _ := filepath.Rel(s.rootPath, path)
+		if info.IsDir() && filepath.ToSlash(relPath) == ArchivedTemplatesDir {
+			return filepath.SkipDir
+		}
 		if !info.IsDir() && strings.HasSuffix(path, ".md") {
-			relPath, _ := filepath.Rel(s.rootPath, path)
+			if _, ok := conflictCopyOf(s.rootPath, relPath); ok {
+				// Listed by ListConflicts instead of loaded as a duplicate
+				return nil
+			}
 			existingFiles[relPath] = true
 			
 			// Try to get from cache first
-			if cached, valid := s.cache.Get(relPath, info); valid {
-				prompts = append(prompts, cached.ToPrompt())
+			if entry, valid := s.cache.Get(relPath, path, info); valid {
+				prompt := entry.ToPrompt()
+				defaults.forPrompt(relPath).apply(prompt)
+				prompts = append(prompts, prompt)
+				cached++
 				return nil
 			}
 			
-			// Cache miss - load and parse the prompt
-			prompt, err := s.LoadPrompt(relPath)
+			// Cache miss - parse the frontmatter, the body is loaded on demand
+			endParse := startup.Phase("parsing")
+			prompt, err := s.loadPromptMetadata(relPath)
+			endParse()
 			if err != nil {
-				// Log error but continue walking
-				fmt.Fprintf(os.Stderr, "Warning: failed to load prompt %s: %v\n", relPath, err)
+				// Record what is wrong so the file isn't dropped silently
+				s.recordIssues(relPath, err, ValidatePrompt)
 				return nil
 			}
 			
-			// Cache the loaded prompt metadata
-			s.cache.Set(relPath, filepath.Join(s.rootPath, relPath), info, prompt)
-			cacheModified = true
+			if prompt.ID == "" {
+				// Still list it under its file name, but keep reporting it
+				prompt.ID = idFromPath(relPath)
+				s.issues.add(ValidationIssue{FilePath: relPath, Line: 2, Field: "id",
+					Message: fmt.Sprintf("required field is missing, using %q from the file name", prompt.ID)})
+			} else {
+				// Cache the loaded prompt metadata
+				s.cache.Set(relPath, path, info, prompt)
+			}
 			
+			defaults.forPrompt(relPath).apply(prompt)
 			prompts = append(prompts, prompt)
 		}
 
 		return nil
 	})
 	
-	// Cleanup cache entries for deleted files
-	s.cache.Cleanup(existingFiles)
+	// Cleanup cache entries for deleted files, but only after a complete walk
+	if err == nil {
+		s.cache.Cleanup(dir, existingFiles)
+	}
 	
 	// Save cache if it was modified
-	if cacheModified {
+	if s.cache.Modified() {
 		if err := s.cache.Save(); err != nil {
-			fmt.Fprintf(os.Stderr, "Warning: failed to save metadata cache: %v\n", err)
+			slog.Warn("Failed to save metadata cache", "error", err)
 		}
 	}
 
+	slog.Debug("Loaded prompts", "dir", dir, "count", len(prompts), "cached", cached, "duration", time.Since(start))
 	return prompts, err
 }
 
 // ListArchivedPrompts returns all archived prompts
-func (s *Storage) ListArchivedPrompts() ([]*models.Prompt, error) {
+func (s *Storage) ListArchivedPrompts(ctx context.Context) ([]*models.Prompt, error) {
 	// Check if archive directory exists
 	archiveDir := filepath.Join(s.rootPath, "archive")
 	if _, err := os.Stat(archiveDir); os.IsNotExist(err) {
 		return []*models.Prompt{}, nil // Return empty slice if archive doesn't exist
 	}
 	
-	return s.listPromptsFromDir("archive")
+	return s.listPromptsFromDir(ctx, "archive")
 }
 
 // DeleteTemplate deletes a template file
@@ -264,9 +362,23 @@ func (s *Storage) LoadTemplate(path string) (*models.Template, error) {
 
 // ListTemplates returns all templates in the library
 func (s *Storage) ListTemplates() ([]*models.Template, error) {
-	templatesDir := filepath.Join(s.rootPath, "templates")
+	return s.listTemplatesFromDir("templates")
+}
+
+// ListArchivedTemplates returns the old versions of templates
+func (s *Storage) ListArchivedTemplates() ([]*models.Template, error) {
+	if _, err := os.Stat(filepath.Join(s.rootPath, ArchivedTemplatesDir)); os.IsNotExist(err) {
+		return []*models.Template{}, nil
+	}
+	return s.listTemplatesFromDir(ArchivedTemplatesDir)
+}
+
+// listTemplatesFromDir returns the templates in a directory
+func (s *Storage) listTemplatesFromDir(dir string) ([]*models.Template, error) {
+	templatesDir := filepath.Join(s.rootPath, dir)
 	
 	var templates []*models.Template
+	s.issues.clear(dir)
 	err := filepath.Walk(templatesDir, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
@@ -274,9 +386,12 @@ func (s *Storage) ListTemplates() ([]*models.Template, error) {
 
 		if !info.IsDir() && strings.HasSuffix(path, ".md") {
 			relPath, _ := filepath.Rel(s.rootPath, path)
+			if _, ok := conflictCopyOf(s.rootPath, relPath); ok {
+				return nil
+			}
 			template, err := s.LoadTemplate(relPath)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Warning: failed to load template %s: %v\n", relPath, err)
+				s.recordIssues(relPath, err, ValidateTemplate)
 				return nil
 			}
 			templates = append(templates, template)
@@ -288,136 +403,91 @@ func (s *Storage) ListTemplates() ([]*models.Template, error) {
 	return templates, err
 }
 
-// Helper functions
+// ValidationIssues returns the problems found in files during the last listing
+func (s *Storage) ValidationIssues() []ValidationIssue {
+	return s.issues.all()
+}
 
-func parsePromptFile(content []byte) (*models.Prompt, error) {
-	scanner := bufio.NewScanner(bytes.NewReader(content))
-	
-	// Check for frontmatter delimiter
-	if !scanner.Scan() || scanner.Text() != "---" {
-		return nil, fmt.Errorf("missing frontmatter delimiter")
+// recordIssues validates a file that failed to load and logs the precise problems
+func (s *Storage) recordIssues(relPath string, loadErr error, validate func(string, []byte) []ValidationIssue) {
+	var issues []ValidationIssue
+	if data, err := os.ReadFile(filepath.Join(s.rootPath, relPath)); err == nil {
+		issues = validate(relPath, data)
+	}
+	if len(issues) == 0 {
+		issues = []ValidationIssue{{FilePath: relPath, Message: loadErr.Error()}}
 	}
 
-	// Read frontmatter
-	var frontmatterLines []string
-	for scanner.Scan() {
-		line := scanner.Text()
-		if line == "---" {
-			break
-		}
-		frontmatterLines = append(frontmatterLines, line)
+	for _, issue := range issues {
+		s.issues.add(issue)
+		slog.Warn("Skipped invalid file", "issue", issue)
 	}
+}
 
-	// Parse YAML frontmatter
-	frontmatter := strings.Join(frontmatterLines, "\n")
-	var prompt models.Prompt
-	if err := yaml.Unmarshal([]byte(frontmatter), &prompt); err != nil {
-		return nil, fmt.Errorf("failed to parse frontmatter: %w", err)
+// recordInvalidDefaults reports a defaults file that couldn't be read, which
+// is ignored so the prompts under it still list
+func (s *Storage) recordInvalidDefaults(relPath string, err error) {
+	s.issues.add(ValidationIssue{FilePath: relPath, Message: err.Error()})
+	warnInvalidDefaults(relPath, err)
+}
+
+// Helper functions
+
+func parsePromptFile(content []byte) (*models.Prompt, error) {
+	block, body, err := splitFrontmatter(content)
+	if err != nil {
+		return nil, err
 	}
 
-	// Read remaining content
-	var contentLines []string
-	for scanner.Scan() {
-		contentLines = append(contentLines, scanner.Text())
+	var prompt models.Prompt
+	if err := block.decode(&prompt); err != nil {
+		return nil, fmt.Errorf("failed to parse frontmatter: %w", err)
 	}
-	// Join content preserving original formatting
-	prompt.Content = strings.Join(contentLines, "\n")
-	// Trim only leading whitespace/newlines
-	prompt.Content = strings.TrimLeft(prompt.Content, " \t\n")
+	prompt.Content = body
 
 	return &prompt, nil
 }
 
 func parseTemplateFile(content []byte) (*models.Template, error) {
-	scanner := bufio.NewScanner(bytes.NewReader(content))
-	
-	// Check for frontmatter delimiter
-	if !scanner.Scan() || scanner.Text() != "---" {
-		return nil, fmt.Errorf("missing frontmatter delimiter")
-	}
-
-	// Read frontmatter
-	var frontmatterLines []string
-	for scanner.Scan() {
-		line := scanner.Text()
-		if line == "---" {
-			break
-		}
-		frontmatterLines = append(frontmatterLines, line)
+	block, body, err := splitFrontmatter(content)
+	if err != nil {
+		return nil, err
 	}
 
-	// Parse YAML frontmatter
-	frontmatter := strings.Join(frontmatterLines, "\n")
 	var template models.Template
-	if err := yaml.Unmarshal([]byte(frontmatter), &template); err != nil {
+	if err := block.decode(&template); err != nil {
 		return nil, fmt.Errorf("failed to parse frontmatter: %w", err)
 	}
-
-	// Read remaining content
-	var contentLines []string
-	for scanner.Scan() {
-		contentLines = append(contentLines, scanner.Text())
-	}
-	// Join content preserving original formatting
-	template.Content = strings.Join(contentLines, "\n")
-	// Trim only leading whitespace/newlines
-	template.Content = strings.TrimLeft(template.Content, " \t\n")
+	template.Content = body
 
 	return &template, nil
 }
 
-func serializePrompt(prompt *models.Prompt) ([]byte, error) {
-	var buf bytes.Buffer
-
-	// Write frontmatter delimiter
-	buf.WriteString("---\n")
-
-	// Serialize prompt metadata to YAML
-	encoder := yaml.NewEncoder(&buf)
-	encoder.SetIndent(2)
-	if err := encoder.Encode(prompt); err != nil {
-		return nil, fmt.Errorf("failed to encode frontmatter: %w", err)
-	}
-
-	// Write closing delimiter
-	buf.WriteString("---\n")
-
-	// Write content with proper spacing
-	if prompt.Content != "" {
-		buf.WriteString("\n")
-		buf.WriteString(prompt.Content)
-		// Ensure file ends with newline
-		if !strings.HasSuffix(prompt.Content, "\n") {
-			buf.WriteString("\n")
-		}
-	}
-
-	return buf.Bytes(), nil
+// serializePrompt converts a prompt to frontmatter in the given format + markdown content
+func serializePrompt(prompt *models.Prompt, format FrontmatterFormat) ([]byte, error) {
+	return serializeWithBody(prompt, prompt.Content, format)
 }
 
-// serializeTemplate converts a template to YAML frontmatter + markdown content
-func serializeTemplate(template *models.Template) ([]byte, error) {
-	var buf bytes.Buffer
-
-	// Write frontmatter delimiter
-	buf.WriteString("---\n")
+// serializeTemplate converts a template to frontmatter in the given format + markdown content
+func serializeTemplate(template *models.Template, format FrontmatterFormat) ([]byte, error) {
+	return serializeWithBody(template, template.Content, format)
+}
 
-	// Serialize template metadata to YAML
-	encoder := yaml.NewEncoder(&buf)
-	encoder.SetIndent(2)
-	if err := encoder.Encode(template); err != nil {
-		return nil, fmt.Errorf("failed to encode frontmatter: %w", err)
+func serializeWithBody(v interface{}, body string, format FrontmatterFormat) ([]byte, error) {
+	frontmatter, err := encodeFrontmatter(format, v)
+	if err != nil {
+		return nil, err
 	}
 
-	// Write closing delimiter
-	buf.WriteString("---\n")
+	var buf bytes.Buffer
+	buf.Write(frontmatter)
 
 	// Write content with proper spacing
-	if template.Content != "" {
+	if body != "" {
 		buf.WriteString("\n")
-		buf.WriteString(template.Content)
+		buf.WriteString(body)
 		// Ensure file ends with newline
-		if !strings.HasSuffix(template.Content, "\n") {
+		if !strings.HasSuffix(body, "\n") {
 			buf.WriteString("\n")
 		}
 	}
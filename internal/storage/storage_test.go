@@ -0,0 +1,101 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dpshade/pocket-prompt/internal/models"
+)
+
+func TestLoadPromptMetadataSkipsBody(t *testing.T) {
+	tmpDir := t.TempDir()
+	store, err := NewStorage(tmpDir)
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+	if err := store.InitLibrary(); err != nil {
+		t.Fatalf("Failed to init library: %v", err)
+	}
+
+	prompt := &models.Prompt{
+		ID:       "lazy",
+		Version:  "1.0.0",
+		Name:     "Lazy Prompt",
+		Tags:     []string{"lazy"},
+		Content:  "A very long body\n\n---\n\nwith a horizontal rule",
+		FilePath: filepath.Join("prompts", "lazy.md"),
+	}
+	if err := store.SavePrompt(prompt); err != nil {
+		t.Fatalf("Failed to save prompt: %v", err)
+	}
+
+	meta, err := store.LoadPromptMetadata(prompt.FilePath)
+	if err != nil {
+		t.Fatalf("Failed to load metadata: %v", err)
+	}
+	if meta.Name != "Lazy Prompt" || meta.Content != "" || meta.ContentHash != "" {
+		t.Errorf("Expected frontmatter only, got name %q content %q hash %q", meta.Name, meta.Content, meta.ContentHash)
+	}
+
+	listed, err := store.ListPrompts(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to list prompts: %v", err)
+	}
+	if len(listed) != 1 || listed[0].Content != "" {
+		t.Errorf("Expected one prompt listed without body, got %+v", listed)
+	}
+	if hash := store.cache.metadata[prompt.FilePath].FileHash; hash != "" {
+		t.Errorf("Expected the listing not to hash the body, got %s", hash)
+	}
+
+	full, err := store.LoadPrompt(prompt.FilePath)
+	if err != nil {
+		t.Fatalf("Failed to load prompt: %v", err)
+	}
+	if full.Content != prompt.Content {
+		t.Errorf("Expected full content %q, got %q", prompt.Content, full.Content)
+	}
+	if hash := store.cache.metadata[prompt.FilePath].FileHash; hash != full.ContentHash {
+		t.Errorf("Expected the hash of the loaded body cached, got %q and %q", hash, full.ContentHash)
+	}
+}
+
+func TestLoadPromptMetadataRequiresFrontmatter(t *testing.T) {
+	tmpDir := t.TempDir()
+	store, err := NewStorage(tmpDir)
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, "plain.md"), []byte("no frontmatter"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	if _, err := store.LoadPromptMetadata("plain.md"); err == nil {
+		t.Error("Expected error for file without frontmatter")
+	}
+}
+
+func TestListPromptsStopsWhenCancelled(t *testing.T) {
+	store, err := NewStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+	if err := store.InitLibrary(); err != nil {
+		t.Fatalf("Failed to init library: %v", err)
+	}
+	prompt := &models.Prompt{ID: "a", Version: "1.0.0", Name: "A", FilePath: filepath.Join("prompts", "a.md")}
+	if err := store.SavePrompt(prompt); err != nil {
+		t.Fatalf("Failed to save prompt: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := store.ListPrompts(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the listing to stop, got %v", err)
+	}
+	if prompts, err := store.ListPrompts(context.Background()); err != nil || len(prompts) != 1 {
+		t.Errorf("Expected the prompt listed afterwards, got %v, %v", prompts, err)
+	}
+}
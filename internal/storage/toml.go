@@ -0,0 +1,679 @@
+package storage
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+)
+
+// TOML frontmatter is parsed straight into a YAML node tree so it decodes
+// and validates exactly like YAML frontmatter. The parser covers the subset
+// of TOML used for metadata: key/value pairs, dotted keys, [tables],
+// [[arrays of tables]], strings, numbers, booleans, datetimes, arrays and
+// inline tables.
+
+type tomlParser struct {
+	src  string
+	pos  int
+	line int
+}
+
+func parseTOML(raw string) (*yaml.Node, error) {
+	p := &tomlParser{src: raw, line: 1}
+	root := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Line: 1}
+	current := root
+
+	for {
+		p.skipBlank()
+		if p.eof() {
+			return root, nil
+		}
+
+		if p.peek() == '[' {
+			array := strings.HasPrefix(p.src[p.pos:], "[[")
+			if array {
+				p.pos += 2
+			} else {
+				p.pos++
+			}
+			p.skipSpace()
+			keys, err := p.parseKey()
+			if err != nil {
+				return nil, err
+			}
+			p.skipSpace()
+			closing := "]"
+			if array {
+				closing = "]]"
+			}
+			if !strings.HasPrefix(p.src[p.pos:], closing) {
+				return nil, p.errorf("expected %s to close the table header", closing)
+			}
+			p.pos += len(closing)
+			if err := p.endOfLine(); err != nil {
+				return nil, err
+			}
+
+			parent, err := p.descend(root, keys[:len(keys)-1])
+			if err != nil {
+				return nil, err
+			}
+			last := keys[len(keys)-1]
+			if array {
+				seq := mappingValue(parent, last)
+				if seq == nil {
+					seq = &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Line: p.line}
+					setMappingValue(parent, last, seq, p.line)
+				} else if seq.Kind != yaml.SequenceNode {
+					return nil, p.errorf("%s is already defined and is not an array of tables", strings.Join(keys, "."))
+				}
+				table := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Line: p.line}
+				seq.Content = append(seq.Content, table)
+				current = table
+			} else {
+				table := mappingValue(parent, last)
+				if table == nil {
+					table = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Line: p.line}
+					setMappingValue(parent, last, table, p.line)
+				} else if table.Kind != yaml.MappingNode {
+					return nil, p.errorf("%s is already defined and is not a table", strings.Join(keys, "."))
+				}
+				current = table
+			}
+			continue
+		}
+
+		if err := p.parseKeyValue(current); err != nil {
+			return nil, err
+		}
+		if err := p.endOfLine(); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (p *tomlParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("line %d: %s", p.line, fmt.Sprintf(format, args...))
+}
+
+func (p *tomlParser) eof() bool  { return p.pos >= len(p.src) }
+func (p *tomlParser) peek() byte { return p.src[p.pos] }
+
+// skipSpace skips spaces and tabs on the current line
+func (p *tomlParser) skipSpace() {
+	for !p.eof() && (p.peek() == ' ' || p.peek() == '\t') {
+		p.pos++
+	}
+}
+
+// skipBlank skips whitespace, newlines and comments
+func (p *tomlParser) skipBlank() {
+	for !p.eof() {
+		switch p.peek() {
+		case ' ', '\t', '\r':
+			p.pos++
+		case '\n':
+			p.pos++
+			p.line++
+		case '#':
+			for !p.eof() && p.peek() != '\n' {
+				p.pos++
+			}
+		default:
+			return
+		}
+	}
+}
+
+// endOfLine consumes trailing whitespace and an optional comment
+func (p *tomlParser) endOfLine() error {
+	p.skipSpace()
+	if !p.eof() && p.peek() == '#' {
+		for !p.eof() && p.peek() != '\n' {
+			p.pos++
+		}
+	}
+	if !p.eof() && p.peek() == '\r' {
+		p.pos++
+	}
+	if p.eof() {
+		return nil
+	}
+	if p.peek() != '\n' {
+		return p.errorf("unexpected %q after value", p.peek())
+	}
+	return nil
+}
+
+// descend walks dotted keys from a table, creating tables as needed. An
+// array of tables resolves to its last element.
+func (p *tomlParser) descend(table *yaml.Node, keys []string) (*yaml.Node, error) {
+	for _, key := range keys {
+		next := mappingValue(table, key)
+		switch {
+		case next == nil:
+			next = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Line: p.line}
+			setMappingValue(table, key, next, p.line)
+		case next.Kind == yaml.SequenceNode && len(next.Content) > 0 && next.Content[len(next.Content)-1].Kind == yaml.MappingNode:
+			next = next.Content[len(next.Content)-1]
+		case next.Kind != yaml.MappingNode:
+			return nil, p.errorf("%s is already defined as a value", key)
+		}
+		table = next
+	}
+	return table, nil
+}
+
+func (p *tomlParser) parseKeyValue(table *yaml.Node) error {
+	keys, err := p.parseKey()
+	if err != nil {
+		return err
+	}
+	p.skipSpace()
+	if p.eof() || p.peek() != '=' {
+		return p.errorf("expected = after key %s", strings.Join(keys, "."))
+	}
+	p.pos++
+	p.skipSpace()
+
+	line := p.line
+	value, err := p.parseValue()
+	if err != nil {
+		return err
+	}
+
+	parent, err := p.descend(table, keys[:len(keys)-1])
+	if err != nil {
+		return err
+	}
+	last := keys[len(keys)-1]
+	if mappingValue(parent, last) != nil {
+		return fmt.Errorf("line %d: duplicate key %s", line, strings.Join(keys, "."))
+	}
+	setMappingValue(parent, last, value, line)
+	return nil
+}
+
+// parseKey reads a possibly dotted, possibly quoted key
+func (p *tomlParser) parseKey() ([]string, error) {
+	var keys []string
+	for {
+		p.skipSpace()
+		if p.eof() {
+			return nil, p.errorf("expected a key")
+		}
+		var key string
+		switch p.peek() {
+		case '"':
+			s, err := p.parseBasicString()
+			if err != nil {
+				return nil, err
+			}
+			key = s
+		case '\'':
+			s, err := p.parseLiteralString()
+			if err != nil {
+				return nil, err
+			}
+			key = s
+		default:
+			start := p.pos
+			for !p.eof() && isBareKeyChar(p.peek()) {
+				p.pos++
+			}
+			if start == p.pos {
+				return nil, p.errorf("unexpected %q where a key was expected", p.peek())
+			}
+			key = p.src[start:p.pos]
+		}
+		keys = append(keys, key)
+		p.skipSpace()
+		if p.eof() || p.peek() != '.' {
+			return keys, nil
+		}
+		p.pos++
+	}
+}
+
+func isBareKeyChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '-'
+}
+
+func (p *tomlParser) parseValue() (*yaml.Node, error) {
+	if p.eof() {
+		return nil, p.errorf("expected a value")
+	}
+	line := p.line
+	scalar := func(tag, value string) *yaml.Node {
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: value, Line: line}
+	}
+
+	switch c := p.peek(); {
+	case strings.HasPrefix(p.src[p.pos:], `"""`):
+		s, err := p.parseMultilineString(`"""`)
+		return scalar("!!str", s), err
+	case strings.HasPrefix(p.src[p.pos:], `'''`):
+		s, err := p.parseMultilineString(`'''`)
+		return scalar("!!str", s), err
+	case c == '"':
+		s, err := p.parseBasicString()
+		return scalar("!!str", s), err
+	case c == '\'':
+		s, err := p.parseLiteralString()
+		return scalar("!!str", s), err
+	case c == '[':
+		return p.parseArray()
+	case c == '{':
+		return p.parseInlineTable()
+	}
+
+	start := p.pos
+	for !p.eof() && !strings.ContainsRune(",]}#\r\n", rune(p.peek())) {
+		p.pos++
+	}
+	token := strings.TrimSpace(p.src[start:p.pos])
+	// A datetime may contain a single space between date and time
+	p.pos = start + len(strings.TrimRight(p.src[start:p.pos], " \t"))
+
+	switch {
+	case token == "true" || token == "false":
+		return scalar("!!bool", token), nil
+	case token == "inf" || token == "+inf":
+		return scalar("!!float", ".inf"), nil
+	case token == "-inf":
+		return scalar("!!float", "-.inf"), nil
+	case token == "nan" || token == "+nan" || token == "-nan":
+		return scalar("!!float", ".nan"), nil
+	case tomlDateTimeRe.MatchString(token):
+		return scalar("!!timestamp", strings.Replace(token, " ", "T", 1)), nil
+	case tomlTimeRe.MatchString(token):
+		return scalar("!!str", token), nil
+	}
+
+	number := strings.ReplaceAll(token, "_", "")
+	if _, err := strconv.ParseInt(number, 0, 64); err == nil {
+		return scalar("!!int", number), nil
+	}
+	if _, err := strconv.ParseFloat(number, 64); err == nil && !strings.HasPrefix(number, "0x") {
+		return scalar("!!float", number), nil
+	}
+	if token == "" {
+		return nil, p.errorf("expected a value")
+	}
+	return nil, p.errorf("invalid value %q (strings must be quoted)", token)
+}
+
+var (
+	tomlDateTimeRe = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}([Tt ]\d{2}:\d{2}:\d{2}(\.\d+)?([Zz]|[+-]\d{2}:\d{2})?)?$`)
+	tomlTimeRe     = regexp.MustCompile(`^\d{2}:\d{2}:\d{2}(\.\d+)?$`)
+)
+
+func (p *tomlParser) parseArray() (*yaml.Node, error) {
+	seq := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Line: p.line}
+	p.pos++ // [
+	for {
+		p.skipBlank()
+		if p.eof() {
+			return nil, p.errorf("array is never closed")
+		}
+		if p.peek() == ']' {
+			p.pos++
+			return seq, nil
+		}
+		item, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		seq.Content = append(seq.Content, item)
+		p.skipBlank()
+		if p.eof() {
+			return nil, p.errorf("array is never closed")
+		}
+		switch p.peek() {
+		case ',':
+			p.pos++
+		case ']':
+		default:
+			return nil, p.errorf("expected , or ] in array, got %q", p.peek())
+		}
+	}
+}
+
+func (p *tomlParser) parseInlineTable() (*yaml.Node, error) {
+	table := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Line: p.line}
+	p.pos++ // {
+	p.skipSpace()
+	if !p.eof() && p.peek() == '}' {
+		p.pos++
+		return table, nil
+	}
+	for {
+		if err := p.parseKeyValue(table); err != nil {
+			return nil, err
+		}
+		p.skipSpace()
+		if p.eof() {
+			return nil, p.errorf("inline table is never closed")
+		}
+		switch p.peek() {
+		case ',':
+			p.pos++
+		case '}':
+			p.pos++
+			return table, nil
+		default:
+			return nil, p.errorf("expected , or } in inline table, got %q", p.peek())
+		}
+	}
+}
+
+func (p *tomlParser) parseLiteralString() (string, error) {
+	p.pos++ // '
+	end := strings.IndexAny(p.src[p.pos:], "'\n")
+	if end < 0 || p.src[p.pos+end] != '\'' {
+		return "", p.errorf("string is never closed")
+	}
+	s := p.src[p.pos : p.pos+end]
+	p.pos += end + 1
+	return s, nil
+}
+
+func (p *tomlParser) parseBasicString() (string, error) {
+	p.pos++ // "
+	var b strings.Builder
+	for {
+		if p.eof() || p.peek() == '\n' {
+			return "", p.errorf("string is never closed")
+		}
+		c := p.peek()
+		if c == '"' {
+			p.pos++
+			return b.String(), nil
+		}
+		if c == '\\' {
+			if err := p.parseEscape(&b); err != nil {
+				return "", err
+			}
+			continue
+		}
+		b.WriteByte(c)
+		p.pos++
+	}
+}
+
+func (p *tomlParser) parseMultilineString(delim string) (string, error) {
+	p.pos += len(delim)
+	// A newline right after the opening delimiter is trimmed
+	if strings.HasPrefix(p.src[p.pos:], "\r\n") {
+		p.pos += 2
+		p.line++
+	} else if strings.HasPrefix(p.src[p.pos:], "\n") {
+		p.pos++
+		p.line++
+	}
+
+	var b strings.Builder
+	for {
+		if p.eof() {
+			return "", p.errorf("multi-line string is never closed")
+		}
+		if strings.HasPrefix(p.src[p.pos:], delim) {
+			p.pos += len(delim)
+			return b.String(), nil
+		}
+		c := p.peek()
+		if c == '\\' && delim == `"""` {
+			// A backslash at the end of a line trims the following whitespace
+			rest := strings.TrimLeft(p.src[p.pos+1:], " \t\r")
+			if strings.HasPrefix(rest, "\n") {
+				p.pos++
+				for !p.eof() && strings.ContainsRune(" \t\r\n", rune(p.peek())) {
+					if p.peek() == '\n' {
+						p.line++
+					}
+					p.pos++
+				}
+				continue
+			}
+			if err := p.parseEscape(&b); err != nil {
+				return "", err
+			}
+			continue
+		}
+		if c == '\n' {
+			p.line++
+		}
+		b.WriteByte(c)
+		p.pos++
+	}
+}
+
+func (p *tomlParser) parseEscape(b *strings.Builder) error {
+	p.pos++ // backslash
+	if p.eof() {
+		return p.errorf("unfinished escape sequence")
+	}
+	c := p.peek()
+	p.pos++
+	switch c {
+	case 'b':
+		b.WriteByte('\b')
+	case 't':
+		b.WriteByte('\t')
+	case 'n':
+		b.WriteByte('\n')
+	case 'f':
+		b.WriteByte('\f')
+	case 'r':
+		b.WriteByte('\r')
+	case 'e':
+		b.WriteByte(0x1b)
+	case '"', '\\':
+		b.WriteByte(c)
+	case 'u', 'U':
+		n := 4
+		if c == 'U' {
+			n = 8
+		}
+		if p.pos+n > len(p.src) {
+			return p.errorf("invalid unicode escape")
+		}
+		code, err := strconv.ParseUint(p.src[p.pos:p.pos+n], 16, 32)
+		if err != nil || !utf8.ValidRune(rune(code)) {
+			return p.errorf("invalid unicode escape \\%c%s", c, p.src[p.pos:p.pos+n])
+		}
+		b.WriteRune(rune(code))
+		p.pos += n
+	default:
+		return p.errorf("invalid escape sequence \\%c", c)
+	}
+	return nil
+}
+
+// mappingValue returns the value stored under key in a mapping node
+func mappingValue(m *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			return m.Content[i+1]
+		}
+	}
+	return nil
+}
+
+func setMappingValue(m *yaml.Node, key string, value *yaml.Node, line int) {
+	keyNode := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key, Line: line}
+	m.Content = append(m.Content, keyNode, value)
+}
+
+// writeTOML writes a mapping node as a TOML document. Plain values come
+// first, followed by [tables] and [[arrays of tables]].
+func writeTOML(buf *bytes.Buffer, root *yaml.Node) error {
+	return writeTOMLTable(buf, root, nil)
+}
+
+func writeTOMLTable(buf *bytes.Buffer, table *yaml.Node, path []string) error {
+	type deferred struct {
+		key   string
+		value *yaml.Node
+	}
+	var tables []deferred
+
+	for i := 0; i+1 < len(table.Content); i += 2 {
+		key, value := table.Content[i].Value, table.Content[i+1]
+		if value.Kind == yaml.AliasNode {
+			value = value.Alias
+		}
+		switch {
+		case value.ShortTag() == "!!null":
+			continue // TOML has no null; omitting the key is equivalent
+		case value.Kind == yaml.MappingNode && len(value.Content) > 0:
+			tables = append(tables, deferred{key, value})
+			continue
+		case isArrayOfTables(value):
+			tables = append(tables, deferred{key, value})
+			continue
+		}
+		inline, err := tomlInline(value)
+		if err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+		buf.WriteString(tomlKey(key) + " = " + inline + "\n")
+	}
+
+	for _, t := range tables {
+		childPath := append(append([]string{}, path...), tomlKey(t.key))
+		header := strings.Join(childPath, ".")
+		if t.value.Kind == yaml.MappingNode {
+			buf.WriteString("\n[" + header + "]\n")
+			if err := writeTOMLTable(buf, t.value, childPath); err != nil {
+				return err
+			}
+			continue
+		}
+		for _, item := range t.value.Content {
+			buf.WriteString("\n[[" + header + "]]\n")
+			if err := writeTOMLTable(buf, item, childPath); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func isArrayOfTables(n *yaml.Node) bool {
+	if n.Kind != yaml.SequenceNode || len(n.Content) == 0 {
+		return false
+	}
+	for _, item := range n.Content {
+		if item.Kind != yaml.MappingNode {
+			return false
+		}
+	}
+	return true
+}
+
+// tomlInline renders a node as an inline TOML value
+func tomlInline(n *yaml.Node) (string, error) {
+	switch n.Kind {
+	case yaml.AliasNode:
+		return tomlInline(n.Alias)
+	case yaml.SequenceNode:
+		items := make([]string, 0, len(n.Content))
+		for _, item := range n.Content {
+			if item.ShortTag() == "!!null" {
+				continue
+			}
+			s, err := tomlInline(item)
+			if err != nil {
+				return "", err
+			}
+			items = append(items, s)
+		}
+		return "[" + strings.Join(items, ", ") + "]", nil
+	case yaml.MappingNode:
+		pairs := make([]string, 0, len(n.Content)/2)
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			if n.Content[i+1].ShortTag() == "!!null" {
+				continue
+			}
+			s, err := tomlInline(n.Content[i+1])
+			if err != nil {
+				return "", err
+			}
+			pairs = append(pairs, tomlKey(n.Content[i].Value)+" = "+s)
+		}
+		if len(pairs) == 0 {
+			return "{}", nil
+		}
+		return "{ " + strings.Join(pairs, ", ") + " }", nil
+	}
+
+	switch n.ShortTag() {
+	case "!!int", "!!bool":
+		return n.Value, nil
+	case "!!float":
+		switch n.Value {
+		case ".inf", ".Inf", ".INF", "+.inf":
+			return "inf", nil
+		case "-.inf", "-.Inf", "-.INF":
+			return "-inf", nil
+		case ".nan", ".NaN", ".NAN":
+			return "nan", nil
+		}
+		return n.Value, nil
+	case "!!timestamp":
+		if tomlDateTimeRe.MatchString(n.Value) {
+			return n.Value, nil
+		}
+		return tomlString(n.Value), nil
+	case "!!str", "!!binary":
+		return tomlString(n.Value), nil
+	default:
+		return "", fmt.Errorf("cannot represent %s in TOML", n.ShortTag())
+	}
+}
+
+func tomlKey(key string) string {
+	if key == "" {
+		return `""`
+	}
+	for i := 0; i < len(key); i++ {
+		if !isBareKeyChar(key[i]) {
+			return tomlString(key)
+		}
+	}
+	return key
+}
+
+// tomlString quotes s as a TOML basic string
+func tomlString(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '\r':
+			b.WriteString(`\r`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				fmt.Fprintf(&b, `\u%04X`, r)
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
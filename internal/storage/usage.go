@@ -0,0 +1,93 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"maps"
+	"path/filepath"
+	"sync"
+
+	"github.com/dpshade/pocket-prompt/internal/models"
+)
+
+// usageFile lives in the per-library state directory next to backups
+const usageFile = ".pocket-prompt/usage.json"
+
+// UsageStorage persists how often each prompt has been copied or rendered
+type UsageStorage struct {
+	filePath string
+
+	// Without a file path the usage is kept in memory
+	mu      sync.Mutex
+	prompts map[string]models.PromptUsage
+}
+
+// NewUsageStorage creates a new usage storage
+func NewUsageStorage(baseDir string) *UsageStorage {
+	return &UsageStorage{
+		filePath: filepath.Join(baseDir, usageFile),
+	}
+}
+
+// NewMemoryUsageStorage creates a usage storage that keeps the usage in
+// memory only, for libraries that aren't on disk
+func NewMemoryUsageStorage() *UsageStorage {
+	return &UsageStorage{prompts: map[string]models.PromptUsage{}}
+}
+
+// usageData represents the JSON structure of the usage file
+type usageData struct {
+	Prompts map[string]models.PromptUsage `json:"prompts"`
+	Version string                        `json:"version"`
+}
+
+// LoadUsage loads the usage of every prompt, keyed by prompt ID
+func (s *UsageStorage) LoadUsage() (map[string]models.PromptUsage, error) {
+	if s.filePath == "" {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return maps.Clone(s.prompts), nil
+	}
+
+	data, err := os.ReadFile(s.filePath)
+	if os.IsNotExist(err) {
+		return map[string]models.PromptUsage{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read usage file: %w", err)
+	}
+
+	var usage usageData
+	if err := json.Unmarshal(data, &usage); err != nil {
+		return nil, fmt.Errorf("failed to parse usage file: %w", err)
+	}
+	if usage.Prompts == nil {
+		usage.Prompts = map[string]models.PromptUsage{}
+	}
+	return usage.Prompts, nil
+}
+
+// SaveUsage writes the usage of every prompt to disk
+func (s *UsageStorage) SaveUsage(prompts map[string]models.PromptUsage) error {
+	if s.filePath == "" {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.prompts = maps.Clone(prompts)
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.filePath), 0755); err != nil {
+		return fmt.Errorf("failed to create usage directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(usageData{Prompts: prompts, Version: "1.0"}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal usage: %w", err)
+	}
+
+	if err := os.WriteFile(s.filePath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write usage file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,288 @@
+package storage
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dpshade/pocket-prompt/internal/models"
+	"gopkg.in/yaml.v3"
+)
+
+// ValidationIssue describes a problem found in a prompt or template file
+type ValidationIssue struct {
+	FilePath string `json:"file_path"`
+	Line     int    `json:"line,omitempty"` // 1-based line in the file, 0 if unknown
+	Field    string `json:"field,omitempty"`
+	Message  string `json:"message"`
+}
+
+// String formats the issue as path:line: field: message
+func (i ValidationIssue) String() string {
+	var b strings.Builder
+	b.WriteString(i.FilePath)
+	if i.Line > 0 {
+		fmt.Fprintf(&b, ":%d", i.Line)
+	}
+	b.WriteString(": ")
+	if i.Field != "" {
+		b.WriteString(i.Field + ": ")
+	}
+	b.WriteString(i.Message)
+	return b.String()
+}
+
+// LogValue logs the parts of the issue as separate attributes
+func (i ValidationIssue) LogValue() slog.Value {
+	attrs := []slog.Attr{slog.String("file", i.FilePath)}
+	if i.Line > 0 {
+		attrs = append(attrs, slog.Int("line", i.Line))
+	}
+	if i.Field != "" {
+		attrs = append(attrs, slog.String("field", i.Field))
+	}
+	return slog.GroupValue(append(attrs, slog.String("problem", i.Message))...)
+}
+
+// fieldKind is the expected YAML shape of a frontmatter field
+type fieldKind int
+
+const (
+	kindString fieldKind = iota
+	kindStringList
+	kindMapping
+	kindTimestamp
+	kindBool
+	kindRating
+	kindAny
+)
+
+// frontmatterSchema lists the known fields of a file type
+type frontmatterSchema struct {
+	required []string
+	fields   map[string]fieldKind
+}
+
+var promptSchema = frontmatterSchema{
+	required: []string{"id"},
+	fields: map[string]fieldKind{
+		"id":          kindString,
+		"version":     kindString,
+		"title":       kindString,
+		"description": kindString,
+		"tags":        kindStringList,
+		"template":    kindString,
+		"slots":       kindMapping,
+		"variables":   kindAny,
+		"defaults":    kindMapping,
+		"presets":     kindMapping,
+		"metadata":    kindMapping,
+		"attachments": kindStringList,
+		"encrypted":   kindBool,
+		"rating":      kindRating,
+		"notes":       kindString,
+		"created_at":  kindTimestamp,
+		"updated_at":  kindTimestamp,
+	},
+}
+
+var templateSchema = frontmatterSchema{
+	required: []string{"id"},
+	fields: map[string]fieldKind{
+		"id":          kindString,
+		"version":     kindString,
+		"name":        kindString,
+		"description": kindString,
+		"slots":       kindAny,
+		"constraints": kindMapping,
+		"metadata":    kindMapping,
+		"created_at":  kindTimestamp,
+		"updated_at":  kindTimestamp,
+	},
+}
+
+// ValidatePrompt checks a prompt file and returns every problem found
+func ValidatePrompt(path string, content []byte) []ValidationIssue {
+	return validateFrontmatter(path, content, promptSchema)
+}
+
+// ValidateTemplate checks a template file and returns every problem found
+func ValidateTemplate(path string, content []byte) []ValidationIssue {
+	return validateFrontmatter(path, content, templateSchema)
+}
+
+var frontmatterLineRe = regexp.MustCompile(`line (\d+): (.*)`)
+
+func validateFrontmatter(path string, content []byte, schema frontmatterSchema) []ValidationIssue {
+	issue := func(line int, field, format string, args ...interface{}) ValidationIssue {
+		return ValidationIssue{FilePath: path, Line: line, Field: field, Message: fmt.Sprintf(format, args...)}
+	}
+
+	block, err := readFrontmatter(bufio.NewReader(bytes.NewReader(content)))
+	if err != nil {
+		return []ValidationIssue{issue(1, "", "missing frontmatter: the file must start with a line containing only --- (YAML), +++ (TOML) or { (JSON)")}
+	}
+	if !block.closed {
+		if block.format == FormatJSON {
+			return []ValidationIssue{issue(1, "", "frontmatter is never closed: the JSON object needs a matching }")}
+		}
+		return []ValidationIssue{issue(1, "", "frontmatter is never closed: add a line containing only %s after the metadata", delimiterFor(block.format))}
+	}
+
+	syntax := strings.ToUpper(string(block.format))
+	root, err := block.node()
+	if err != nil {
+		if m := frontmatterLineRe.FindStringSubmatch(err.Error()); m != nil {
+			n, _ := strconv.Atoi(m[1])
+			return []ValidationIssue{issue(block.fileLine(n), "", "invalid %s: %s", syntax, m[2])}
+		}
+		return []ValidationIssue{issue(0, "", "invalid %s: %s", syntax, strings.TrimPrefix(err.Error(), "yaml: "))}
+	}
+
+	if root.Kind != yaml.MappingNode {
+		return []ValidationIssue{issue(block.fileLine(root.Line), "", "frontmatter must be a set of key: value pairs")}
+	}
+	if len(root.Content) == 0 && strings.TrimSpace(block.raw) == "" {
+		return []ValidationIssue{issue(block.startLine, "", "frontmatter is empty")}
+	}
+
+	var issues []ValidationIssue
+	seen := make(map[string]bool)
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key, value := root.Content[i], root.Content[i+1]
+		seen[key.Value] = true
+		line := block.fileLine(value.Line)
+
+		if isRequired(schema, key.Value) && value.Kind == yaml.ScalarNode && strings.TrimSpace(value.Value) == "" {
+			issues = append(issues, issue(line, key.Value, "required field must not be empty"))
+			continue
+		}
+
+		kind, known := schema.fields[key.Value]
+		if !known {
+			continue // Unknown fields are preserved by other tools, don't complain
+		}
+
+		switch kind {
+		case kindString:
+			if value.Kind != yaml.ScalarNode {
+				issues = append(issues, issue(line, key.Value, "expected a single value, got %s", describeNode(value)))
+			}
+		case kindStringList:
+			if value.Kind == yaml.ScalarNode && value.Tag == "!!null" {
+				continue
+			}
+			if value.Kind != yaml.SequenceNode {
+				issues = append(issues, issue(line, key.Value, "expected a list such as [one, two], got %s", describeNode(value)))
+				continue
+			}
+			for _, item := range value.Content {
+				if item.Kind != yaml.ScalarNode {
+					issues = append(issues, issue(block.fileLine(item.Line), key.Value, "list items must be plain values, got %s", describeNode(item)))
+				}
+			}
+		case kindMapping:
+			if value.Kind != yaml.MappingNode && value.Tag != "!!null" {
+				issues = append(issues, issue(line, key.Value, "expected key: value pairs, got %s", describeNode(value)))
+			}
+		case kindTimestamp:
+			var t time.Time
+			if value.Tag != "!!null" && value.Decode(&t) != nil {
+				issues = append(issues, issue(line, key.Value, "%q is not a valid timestamp (use RFC 3339, e.g. 2024-01-31T09:00:00Z)", value.Value))
+			}
+		case kindBool:
+			var b bool
+			if value.Decode(&b) != nil {
+				issues = append(issues, issue(line, key.Value, "expected true or false, got %q", value.Value))
+			}
+		case kindRating:
+			var n int
+			if value.Decode(&n) != nil || n < 0 || n > models.MaxRating {
+				issues = append(issues, issue(line, key.Value, "expected a number from 1 to %d, got %q", models.MaxRating, value.Value))
+			}
+		}
+	}
+
+	for _, field := range schema.required {
+		if !seen[field] {
+			issues = append(issues, issue(block.startLine, field, "required field is missing"))
+		}
+	}
+
+	return issues
+}
+
+// delimiterFor returns the line that opens and closes a frontmatter block
+func delimiterFor(format FrontmatterFormat) string {
+	if format == FormatTOML {
+		return "+++"
+	}
+	return "---"
+}
+
+func isRequired(schema frontmatterSchema, field string) bool {
+	for _, f := range schema.required {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// describeNode names a YAML node kind for error messages
+func describeNode(n *yaml.Node) string {
+	switch n.Kind {
+	case yaml.SequenceNode:
+		return "a list"
+	case yaml.MappingNode:
+		return "key: value pairs"
+	case yaml.AliasNode:
+		return "an alias"
+	default:
+		return fmt.Sprintf("%q", n.Value)
+	}
+}
+
+// issueLog collects validation issues per directory; listings may run in
+// background goroutines while the UI reads the log
+type issueLog struct {
+	mu     sync.Mutex
+	issues []ValidationIssue
+}
+
+func newIssueLog() *issueLog {
+	return &issueLog{}
+}
+
+func (l *issueLog) add(issue ValidationIssue) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.issues = append(l.issues, issue)
+}
+
+// clear drops issues for files under dir before it is listed again
+func (l *issueLog) clear(dir string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	prefix := filepath.ToSlash(dir) + "/"
+	kept := l.issues[:0]
+	for _, issue := range l.issues {
+		if !strings.HasPrefix(filepath.ToSlash(issue.FilePath), prefix) {
+			kept = append(kept, issue)
+		}
+	}
+	l.issues = kept
+}
+
+func (l *issueLog) all() []ValidationIssue {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]ValidationIssue(nil), l.issues...)
+}
@@ -0,0 +1,57 @@
+package storage
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestValidatePromptReportsLineAndField(t *testing.T) {
+	content := "---\nid: broken\ntitle: Broken\ntags: not-a-list\n---\n\nBody\n"
+
+	issues := ValidatePrompt("prompts/broken.md", []byte(content))
+	if len(issues) != 1 {
+		t.Fatalf("Expected 1 issue, got %d: %v", len(issues), issues)
+	}
+	if issues[0].Field != "tags" {
+		t.Errorf("Expected field tags, got %q", issues[0].Field)
+	}
+	if issues[0].Line != 4 {
+		t.Errorf("Expected line 4, got %d", issues[0].Line)
+	}
+}
+
+func TestValidatePromptMissingID(t *testing.T) {
+	issues := ValidatePrompt("prompts/noid.md", []byte("---\ntitle: No ID\n---\n\nBody\n"))
+	if len(issues) != 1 || issues[0].Field != "id" {
+		t.Fatalf("Expected a missing id issue, got %v", issues)
+	}
+}
+
+func TestListPromptsRecordsBrokenFiles(t *testing.T) {
+	tmpDir := t.TempDir()
+	store, err := NewStorage(tmpDir)
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+	if err := store.InitLibrary(); err != nil {
+		t.Fatalf("Failed to init library: %v", err)
+	}
+
+	broken := filepath.Join(tmpDir, "prompts", "broken.md")
+	if err := os.WriteFile(broken, []byte("---\nid: [unclosed\n---\n\nBody\n"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	if _, err := store.ListPrompts(context.Background()); err != nil {
+		t.Fatalf("ListPrompts failed: %v", err)
+	}
+	issues := store.ValidationIssues()
+	if len(issues) == 0 {
+		t.Fatal("Expected the broken file to be reported")
+	}
+	if issues[0].FilePath != filepath.Join("prompts", "broken.md") {
+		t.Errorf("Unexpected file path %q", issues[0].FilePath)
+	}
+}
@@ -0,0 +1,247 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/dpshade/pocket-prompt/internal/config"
+)
+
+// WebDAVStore is an ObjectStore backed by a WebDAV collection, e.g. a
+// Nextcloud or ownCloud folder
+type WebDAVStore struct {
+	baseURL  *url.URL
+	username string
+	password string
+	client   *http.Client
+}
+
+// NewWebDAVStore creates a WebDAV object store. A password missing from the
+// config is read from POCKET_PROMPT_WEBDAV_PASSWORD.
+func NewWebDAVStore(cfg config.WebDAVConfig) (*WebDAVStore, error) {
+	if cfg.URL == "" {
+		return nil, fmt.Errorf("webdav backend requires a url")
+	}
+	u, err := url.Parse(cfg.URL)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("invalid webdav url: %s", cfg.URL)
+	}
+	if !strings.HasSuffix(u.Path, "/") {
+		u.Path += "/"
+	}
+
+	password := cfg.Password
+	if password == "" {
+		password = os.Getenv("POCKET_PROMPT_WEBDAV_PASSWORD")
+	}
+
+	return &WebDAVStore{
+		baseURL:  u,
+		username: cfg.Username,
+		password: password,
+		client:   &http.Client{Timeout: 30 * time.Second},
+	}, nil
+}
+
+// multistatus is the subset of a PROPFIND response we use
+type multistatus struct {
+	Responses []struct {
+		Href     string `xml:"href"`
+		Propstat []struct {
+			Prop struct {
+				ETag         string `xml:"getetag"`
+				ResourceType struct {
+					Collection *struct{} `xml:"collection"`
+				} `xml:"resourcetype"`
+			} `xml:"prop"`
+		} `xml:"propstat"`
+	} `xml:"response"`
+}
+
+const propfindBody = `<?xml version="1.0" encoding="utf-8"?>
+<d:propfind xmlns:d="DAV:"><d:prop><d:getetag/><d:resourcetype/></d:prop></d:propfind>`
+
+// List returns all files below prefix, descending into sub-collections
+func (w *WebDAVStore) List(prefix string) ([]ObjectInfo, error) {
+	var objects []ObjectInfo
+	if err := w.list(prefix, &objects); err != nil {
+		return nil, err
+	}
+	return objects, nil
+}
+
+func (w *WebDAVStore) list(dir string, objects *[]ObjectInfo) error {
+	resp, err := w.do("PROPFIND", dir, bytes.NewReader([]byte(propfindBody)), map[string]string{
+		"Depth":        "1",
+		"Content-Type": "application/xml",
+	})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil // Collection does not exist yet
+	}
+	if resp.StatusCode != http.StatusMultiStatus {
+		return fmt.Errorf("webdav PROPFIND %s: %s", dir, resp.Status)
+	}
+
+	var result multistatus
+	if err := xml.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("failed to parse webdav listing: %w", err)
+	}
+
+	for _, r := range result.Responses {
+		key, ok := w.keyFromHref(r.Href)
+		if !ok || strings.TrimSuffix(key, "/") == strings.TrimSuffix(dir, "/") {
+			continue // The collection itself
+		}
+
+		isCollection := false
+		etag := ""
+		for _, ps := range r.Propstat {
+			if ps.Prop.ResourceType.Collection != nil {
+				isCollection = true
+			}
+			if ps.Prop.ETag != "" {
+				etag = strings.Trim(strings.TrimPrefix(ps.Prop.ETag, "W/"), `"`)
+			}
+		}
+
+		if isCollection {
+			if err := w.list(strings.TrimSuffix(key, "/")+"/", objects); err != nil {
+				return err
+			}
+			continue
+		}
+		*objects = append(*objects, ObjectInfo{Key: key, ETag: etag})
+	}
+
+	return nil
+}
+
+// Get downloads a file
+func (w *WebDAVStore) Get(key string) ([]byte, error) {
+	resp, err := w.do(http.MethodGet, key, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("webdav GET %s: %s", key, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// Put uploads a file, creating parent collections as needed
+func (w *WebDAVStore) Put(key string, data []byte) (string, error) {
+	resp, err := w.do(http.MethodPut, key, bytes.NewReader(data), nil)
+	if err != nil {
+		return "", err
+	}
+	resp.Body.Close()
+
+	// Missing parent collection - create it and retry once
+	if resp.StatusCode == http.StatusConflict || resp.StatusCode == http.StatusNotFound {
+		if err := w.mkcolAll(path.Dir(key)); err != nil {
+			return "", err
+		}
+		resp, err = w.do(http.MethodPut, key, bytes.NewReader(data), nil)
+		if err != nil {
+			return "", err
+		}
+		resp.Body.Close()
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", fmt.Errorf("webdav PUT %s: %s", key, resp.Status)
+	}
+	return strings.Trim(strings.TrimPrefix(resp.Header.Get("ETag"), "W/"), `"`), nil
+}
+
+// Delete removes a file; deleting a missing file is not an error
+func (w *WebDAVStore) Delete(key string) error {
+	resp, err := w.do(http.MethodDelete, key, nil, nil)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webdav DELETE %s: %s", key, resp.Status)
+	}
+	return nil
+}
+
+// mkcolAll creates a collection and all of its parents, including the
+// library collection itself
+func (w *WebDAVStore) mkcolAll(dir string) error {
+	key := ""
+	if dir != "." && dir != "/" && dir != "" {
+		if err := w.mkcolAll(path.Dir(dir)); err != nil {
+			return err
+		}
+		key = dir + "/"
+	}
+
+	resp, err := w.do("MKCOL", key, nil, nil)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	// 405 means the collection already exists
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusMethodNotAllowed {
+		return fmt.Errorf("webdav MKCOL %s: %s", dir, resp.Status)
+	}
+	return nil
+}
+
+func (w *WebDAVStore) do(method, key string, body io.Reader, headers map[string]string) (*http.Response, error) {
+	u := *w.baseURL
+	u.Path = w.baseURL.Path + key
+	u.RawPath = ""
+
+	req, err := http.NewRequest(method, u.String(), body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create webdav request: %w", err)
+	}
+	if w.username != "" {
+		req.SetBasicAuth(w.username, w.password)
+	}
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("webdav request failed: %w", err)
+	}
+	return resp, nil
+}
+
+// keyFromHref converts a response href into a key relative to the base URL
+func (w *WebDAVStore) keyFromHref(href string) (string, bool) {
+	u, err := url.Parse(href)
+	if err != nil {
+		return "", false
+	}
+	p := u.Path
+	if !strings.HasPrefix(p, w.baseURL.Path) {
+		return "", false
+	}
+	return strings.TrimPrefix(p, w.baseURL.Path), true
+}
@@ -0,0 +1,71 @@
+package storage
+
+import (
+	"context"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/dpshade/pocket-prompt/internal/config"
+	"github.com/dpshade/pocket-prompt/internal/models"
+	"golang.org/x/net/webdav"
+)
+
+func TestRemoteStorageWithWebDAV(t *testing.T) {
+	handler := &webdav.Handler{
+		FileSystem: webdav.NewMemFS(),
+		LockSystem: webdav.NewMemLS(),
+	}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	store, err := NewWebDAVStore(config.WebDAVConfig{URL: server.URL + "/library"})
+	if err != nil {
+		t.Fatalf("Failed to create WebDAV store: %v", err)
+	}
+
+	remote, err := NewRemoteStorage(t.TempDir(), store)
+	if err != nil {
+		t.Fatalf("Failed to create remote storage: %v", err)
+	}
+
+	// Listing before anything exists should not fail
+	prompts, err := remote.ListPrompts(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to list empty library: %v", err)
+	}
+	if len(prompts) != 0 {
+		t.Fatalf("Expected empty library, got %d prompts", len(prompts))
+	}
+
+	prompt := &models.Prompt{
+		ID:       "dav",
+		Version:  "1.0.0",
+		Name:     "DAV Prompt",
+		Content:  "Synced through WebDAV",
+		FilePath: filepath.Join("prompts", "dav.md"),
+	}
+	if err := remote.SavePrompt(prompt); err != nil {
+		t.Fatalf("Failed to save prompt: %v", err)
+	}
+
+	other, err := NewRemoteStorage(t.TempDir(), store)
+	if err != nil {
+		t.Fatalf("Failed to create second remote storage: %v", err)
+	}
+	prompts, err = other.ListPrompts(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to list prompts: %v", err)
+	}
+	if len(prompts) != 1 || prompts[0].ID != "dav" {
+		t.Fatalf("Expected dav prompt, got %+v", prompts)
+	}
+
+	full, err := other.LoadPrompt(prompts[0].FilePath)
+	if err != nil {
+		t.Fatalf("Failed to load prompt: %v", err)
+	}
+	if full.Content != "Synced through WebDAV" {
+		t.Errorf("Unexpected content: %q", full.Content)
+	}
+}
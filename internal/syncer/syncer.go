@@ -0,0 +1,163 @@
+// Package syncer runs the periodic synchronization of the library with a
+// remote, through git or a file copy tool such as rsync or rclone.
+package syncer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/dpshade/pocket-prompt/internal/config"
+	"github.com/dpshade/pocket-prompt/internal/git"
+)
+
+// ErrNotConfigured is returned by Sync when the provider has nothing to
+// sync with yet, such as git without a remote
+var ErrNotConfigured = errors.New("sync is not configured")
+
+// Syncer synchronizes the library with a remote
+type Syncer interface {
+	// Name identifies the provider, e.g. "git" or "rsync"
+	Name() string
+	// Sync brings the library up to date with the remote, stopping when
+	// ctx is cancelled
+	Sync(ctx context.Context) error
+}
+
+// Sync directions
+const (
+	DirectionBoth = "both" // Pull newer files, then push newer files
+	DirectionPull = "pull"
+	DirectionPush = "push"
+)
+
+// commandTimeout bounds a single rsync or rclone run
+const commandTimeout = 10 * time.Minute
+
+// funcSyncer adapts a function to the Syncer interface
+type funcSyncer struct {
+	name string
+	sync func(ctx context.Context) error
+}
+
+// NewFunc returns a Syncer that calls sync
+func NewFunc(name string, sync func(ctx context.Context) error) Syncer {
+	return funcSyncer{name: name, sync: sync}
+}
+
+func (f funcSyncer) Name() string                   { return f.name }
+func (f funcSyncer) Sync(ctx context.Context) error { return f.sync(ctx) }
+
+// CommandSyncer syncs the library with a target by running rsync or
+// rclone. In both directions, files newer on either side win; deletions
+// are not propagated.
+type CommandSyncer struct {
+	tool      string
+	baseDir   string
+	target    string
+	direction string
+	args      []string
+}
+
+// New returns the Syncer configured in config.yaml. git is used when no
+// other provider is configured.
+func New(cfg config.SyncConfig, baseDir string, git Syncer) (Syncer, error) {
+	switch cfg.Provider {
+	case "", "git":
+		return git, nil
+	case "rsync", "rclone":
+		return NewCommandSyncer(cfg.Provider, baseDir, cfg.Target, cfg.Direction, cfg.Args)
+	default:
+		return nil, fmt.Errorf("unknown sync provider %q: use git, rsync or rclone", cfg.Provider)
+	}
+}
+
+// NewCommandSyncer returns a Syncer that runs tool ("rsync" or "rclone")
+// between the library and target, e.g. "host:prompts" for rsync or
+// "drive:prompts" for rclone
+func NewCommandSyncer(tool, baseDir, target, direction string, args []string) (*CommandSyncer, error) {
+	if target == "" {
+		return nil, fmt.Errorf("sync.target is required for the %s provider", tool)
+	}
+	switch direction {
+	case "":
+		direction = DirectionBoth
+	case DirectionBoth, DirectionPull, DirectionPush:
+	default:
+		return nil, fmt.Errorf("invalid sync direction %q: use both, pull or push", direction)
+	}
+	if _, err := exec.LookPath(tool); err != nil {
+		return nil, fmt.Errorf("%s is not installed", tool)
+	}
+	return &CommandSyncer{tool: tool, baseDir: baseDir, target: target, direction: direction, args: args}, nil
+}
+
+// Name returns the tool the syncer runs
+func (c *CommandSyncer) Name() string {
+	return c.tool
+}
+
+// Sync pulls files that are newer on the target and pushes files that are
+// newer locally, as configured by the direction
+func (c *CommandSyncer) Sync(ctx context.Context) error {
+	local := strings.TrimSuffix(c.baseDir, "/") + "/"
+	remote := strings.TrimSuffix(c.target, "/") + "/"
+	if c.direction != DirectionPush {
+		if err := c.run(ctx, remote, local); err != nil {
+			return fmt.Errorf("failed to pull from %s: %w", c.target, err)
+		}
+	}
+	if c.direction != DirectionPull {
+		if err := c.run(ctx, local, remote); err != nil {
+			return fmt.Errorf("failed to push to %s: %w", c.target, err)
+		}
+	}
+	return nil
+}
+
+// run copies newer files from src to dst
+func (c *CommandSyncer) run(parent context.Context, src, dst string) error {
+	ctx, cancel := context.WithTimeout(parent, commandTimeout)
+	defer cancel()
+
+	args := append(c.commandArgs(), c.args...)
+	start := time.Now()
+	output, err := exec.CommandContext(ctx, c.tool, append(args, src, dst)...).CombinedOutput()
+	slog.Debug("Ran "+c.tool, "src", src, "dst", dst, "duration", time.Since(start), "error", err)
+	if err != nil {
+		if err := parent.Err(); err != nil {
+			return fmt.Errorf("%s cancelled: %w", c.tool, err)
+		}
+		if ctx.Err() == context.DeadlineExceeded {
+			return fmt.Errorf("%s timed out after %v", c.tool, commandTimeout)
+		}
+		return fmt.Errorf("%s failed: %s", c.tool, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// commandArgs returns the options of a run, leaving out the git directory
+// and the files that belong to this device
+func (c *CommandSyncer) commandArgs() []string {
+	excluded := append([]string{".git/"}, git.LocalStatePatterns...)
+	if c.tool == "rclone" {
+		args := []string{"copy", "--update"}
+		for _, pattern := range excluded {
+			if strings.HasSuffix(pattern, "/") {
+				pattern += "**"
+			}
+			args = append(args, "--exclude", "/"+pattern)
+		}
+		return args
+	}
+
+	args := []string{"-a", "--update"}
+	for _, pattern := range excluded {
+		args = append(args, "--exclude", "/"+pattern)
+	}
+	return args
+}
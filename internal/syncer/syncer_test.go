@@ -0,0 +1,84 @@
+package syncer
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dpshade/pocket-prompt/internal/config"
+)
+
+// fakeTool installs a script named tool on PATH that logs its arguments
+func fakeTool(t *testing.T, tool string) string {
+	dir := t.TempDir()
+	logFile := filepath.Join(dir, "calls.log")
+	script := "#!/bin/sh\necho \"$@\" >> " + logFile + "\n"
+	if err := os.WriteFile(filepath.Join(dir, tool), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	return logFile
+}
+
+func TestNew(t *testing.T) {
+	git := NewFunc("git", func(context.Context) error { return nil })
+
+	if s, err := New(config.SyncConfig{}, "/lib", git); err != nil || s.Name() != "git" {
+		t.Errorf("Expected git by default, got %v %v", s, err)
+	}
+	if _, err := New(config.SyncConfig{Provider: "ftp"}, "/lib", git); err == nil {
+		t.Error("Expected an unknown provider to be rejected")
+	}
+
+	fakeTool(t, "rsync")
+	if _, err := New(config.SyncConfig{Provider: "rsync"}, "/lib", git); err == nil || !strings.Contains(err.Error(), "sync.target") {
+		t.Errorf("Expected a missing target error, got %v", err)
+	}
+	if _, err := New(config.SyncConfig{Provider: "rsync", Target: "host:prompts", Direction: "sideways"}, "/lib", git); err == nil {
+		t.Error("Expected an invalid direction to be rejected")
+	}
+	if s, err := New(config.SyncConfig{Provider: "rsync", Target: "host:prompts"}, "/lib", git); err != nil || s.Name() != "rsync" {
+		t.Errorf("Expected rsync, got %v %v", s, err)
+	}
+}
+
+func TestCommandSyncerPullsThenPushes(t *testing.T) {
+	logFile := fakeTool(t, "rsync")
+
+	s, err := NewCommandSyncer("rsync", "/lib", "host:prompts", "", []string{"--compress"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Sync(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(logFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	calls := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(calls) != 2 {
+		t.Fatalf("Expected a pull and a push, got %q", calls)
+	}
+	if !strings.HasSuffix(calls[0], "--compress host:prompts/ /lib/") || !strings.HasSuffix(calls[1], "/lib/ host:prompts/") {
+		t.Errorf("Unexpected rsync calls %q", calls)
+	}
+	if !strings.Contains(calls[0], "--update") || !strings.Contains(calls[0], "--exclude /.pocket-prompt/cache/") {
+		t.Errorf("Expected newer files to win and local state to be excluded, got %q", calls[0])
+	}
+}
+
+func TestRcloneExcludes(t *testing.T) {
+	fakeTool(t, "rclone")
+	s, err := NewCommandSyncer("rclone", "/lib", "drive:prompts", DirectionPull, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	args := strings.Join(s.commandArgs(), " ")
+	if !strings.HasPrefix(args, "copy --update") || !strings.Contains(args, "--exclude /.git/**") || !strings.Contains(args, "--exclude /.pocket-prompt/usage.json") {
+		t.Errorf("Unexpected rclone arguments %q", args)
+	}
+}
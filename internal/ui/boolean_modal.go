@@ -1,21 +1,41 @@
 package ui
 
 import (
+	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/dpshade/pocket-prompt/internal/i18n"
 	"github.com/dpshade/pocket-prompt/internal/models"
 )
 
+// booleanSearchDebounce is how long typing must pause before the live
+// search runs
+const booleanSearchDebounce = 150 * time.Millisecond
+
+// booleanSearchTickMsg fires once typing has paused for a live search
+type booleanSearchTickMsg struct {
+	generation int
+}
+
+// booleanSearchResultMsg carries the results of a live search
+type booleanSearchResultMsg struct {
+	generation int
+	results    []*models.Prompt
+	err        error
+}
+
 // BooleanSearchModal provides a modal interface for boolean search
 type BooleanSearchModal struct {
 	booleanInput   textinput.Model  // Changed from textarea to textinput for autocomplete
 	textInput      textinput.Model
 	availableTags  []string
+	tagColors      map[string]string
 	searchResults  []*models.Prompt
 	currentQuery   string
 	textQuery      string
@@ -27,18 +47,21 @@ type BooleanSearchModal struct {
 	focusTextInput bool // Whether text input has focus
 	resultsCursor  int
 	showHelp       bool
-	searchFunc     func(*models.BooleanExpression) ([]*models.Prompt, error) // Callback for live search
+	searchFunc     func(context.Context, *models.BooleanExpression) ([]*models.Prompt, error) // Callback for live search
 	saveFunc       func(models.SavedSearch) error // Callback for saving searches
 	saveRequested  bool // Flag to indicate save was requested
 	applyRequested bool // Flag to indicate apply search and return to list was requested
 	editMode       bool // Flag to indicate edit mode
 	originalSearch *models.SavedSearch // Original search being edited
+	searchGeneration int  // Counts query edits, so results of stale searches are dropped
+	searching        bool // A live search is waiting or running
+	cancelSearch     context.CancelFunc // Stops the running live search
 }
 
 // NewBooleanSearchModal creates a new modal boolean search
 func NewBooleanSearchModal(availableTags []string) *BooleanSearchModal {
 	bi := textinput.New()
-	bi.Placeholder = "Enter boolean search (tag1 AND tag2 OR tag3, NOT tag4)"
+	bi.Placeholder = i18n.T("boolean.placeholder")
 	bi.Focus()
 	bi.CharLimit = 500
 	bi.Width = 70
@@ -53,7 +76,7 @@ func NewBooleanSearchModal(availableTags []string) *BooleanSearchModal {
 	bi.KeyMap = customKeyMap
 
 	ti := textinput.New()
-	ti.Placeholder = "Optional: text search within boolean results"
+	ti.Placeholder = i18n.T("boolean.text_placeholder")
 	ti.CharLimit = 200
 	ti.Width = 70
 
@@ -75,10 +98,37 @@ func (m *BooleanSearchModal) Update(msg tea.Msg) tea.Cmd {
 	var cmd tea.Cmd
 
 	switch msg := msg.(type) {
+	case booleanSearchTickMsg:
+		// Only the search for the latest query runs
+		if msg.generation != m.searchGeneration || m.expression == nil || m.searchFunc == nil {
+			return nil
+		}
+		m.stopSearch()
+		ctx, cancel := context.WithCancel(context.Background())
+		m.cancelSearch = cancel
+		search, expr := m.searchFunc, m.expression
+		return func() tea.Msg {
+			defer cancel()
+			results, err := search(ctx, expr)
+			return booleanSearchResultMsg{generation: msg.generation, results: results, err: err}
+		}
+
+	case booleanSearchResultMsg:
+		if msg.generation != m.searchGeneration {
+			return nil
+		}
+		m.searching = false
+		if msg.err == nil {
+			m.searchResults = msg.results
+			m.resultsCursor = 0
+		}
+		return nil
+
 	case tea.KeyMsg:
 		switch {
 		case key.Matches(msg, key.NewBinding(key.WithKeys("esc"))):
 			m.isActive = false
+			m.stopSearch()
 			m.focusResults = false
 			m.resultsCursor = 0
 			m.applyRequested = false
@@ -164,20 +214,19 @@ func (m *BooleanSearchModal) Update(msg tea.Msg) tea.Cmd {
 			// Update autocomplete suggestions based on current cursor position
 			m.updateAutocomplete()
 			
-			// Trigger live search if query changed
+			// Search again once typing pauses if the query changed
 			if newQuery != oldQuery {
 				m.currentQuery = newQuery
+				m.searchGeneration++
+				m.searching = false
+				m.stopSearch()
 				if newQuery != "" {
 					expr, err := m.parseQuery(newQuery)
 					if err == nil {
 						m.expression = expr
-						// Perform live search if callback is set
 						if m.searchFunc != nil {
-							results, err := m.searchFunc(expr)
-							if err == nil {
-								m.searchResults = results
-								m.resultsCursor = 0
-							}
+							m.searching = true
+							cmd = tea.Batch(cmd, debounceBooleanSearch(m.searchGeneration))
 						}
 					}
 				} else {
@@ -204,6 +253,21 @@ func (m *BooleanSearchModal) Update(msg tea.Msg) tea.Cmd {
 	return cmd
 }
 
+// stopSearch cancels the running live search, whose results are stale
+func (m *BooleanSearchModal) stopSearch() {
+	if m.cancelSearch != nil {
+		m.cancelSearch()
+		m.cancelSearch = nil
+	}
+}
+
+// debounceBooleanSearch waits for typing to pause before a live search
+func debounceBooleanSearch(generation int) tea.Cmd {
+	return tea.Tick(booleanSearchDebounce, func(time.Time) tea.Msg {
+		return booleanSearchTickMsg{generation: generation}
+	})
+}
+
 // updateAutocomplete updates the autocomplete suggestions based on current input context
 func (m *BooleanSearchModal) updateAutocomplete() {
 	if len(m.availableTags) == 0 {
@@ -343,7 +407,7 @@ func (m *BooleanSearchModal) View() string {
 
 	// Modal styles - use terminal default colors
 	modalStyle := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
+		Border(boxBorder(lipgloss.RoundedBorder())).
 		Padding(1, 2).
 		Width(80)
 
@@ -368,26 +432,26 @@ func (m *BooleanSearchModal) View() string {
 	var content []string
 
 	// Title
-	title := "Boolean Tag Search"
+	title := i18n.T("boolean.title")
 	if m.editMode && m.originalSearch != nil {
-		title = fmt.Sprintf("Edit Search: %s", m.originalSearch.Name)
+		title = i18n.T("boolean.edit_title", m.originalSearch.Name)
 	}
 	content = append(content, titleStyle.Render(title))
 	content = append(content, "")
 
 	// Available tags hint
 	if len(m.availableTags) > 0 {
-		tagsPreview := strings.Join(m.availableTags[:min(8, len(m.availableTags))], ", ")
-		if len(m.availableTags) > 8 {
-			tagsPreview += "..."
-		}
 		tagHintStyle := lipgloss.NewStyle().
 			Italic(true)
-		content = append(content, tagHintStyle.Render("Available tags: "+tagsPreview))
+		tagsPreview := renderTags(m.availableTags[:min(8, len(m.availableTags))], m.tagColors, tagHintStyle)
+		if len(m.availableTags) > 8 {
+			tagsPreview += tagHintStyle.Render("...")
+		}
+		content = append(content, tagHintStyle.Render(i18n.T("boolean.available_tags"))+tagsPreview)
 	}
 
 	// Boolean search input
-	booleanInputTitle := "Boolean Expression:"
+	booleanInputTitle := i18n.T("search.expression_label")
 	if !m.focusTextInput && !m.focusResults {
 		booleanInputTitle = "▶ " + booleanInputTitle
 	}
@@ -395,7 +459,7 @@ func (m *BooleanSearchModal) View() string {
 	content = append(content, m.booleanInput.View())
 
 	// Text search input
-	textInputTitle := "Text Filter (optional):"
+	textInputTitle := i18n.T("search.text_label")
 	if m.focusTextInput {
 		textInputTitle = "▶ " + textInputTitle
 	}
@@ -413,12 +477,12 @@ func (m *BooleanSearchModal) View() string {
 			exprText += fmt.Sprintf(" + text:\"%s\"", m.textQuery)
 		}
 		content = append(content, "")
-		content = append(content, "Expression: "+exprStyle.Render(exprText))
+		content = append(content, i18n.T("boolean.expression")+exprStyle.Render(exprText))
 	}
 
 	// Results
 	if len(m.searchResults) > 0 {
-		resultsTitle := fmt.Sprintf("Results (%d):", len(m.searchResults))
+		resultsTitle := i18n.T("boolean.results", len(m.searchResults))
 		if m.focusResults {
 			resultsTitle = "▶ " + resultsTitle
 		}
@@ -439,8 +503,10 @@ func (m *BooleanSearchModal) View() string {
 			}
 			content = append(content, style.Render(promptLine))
 		}
+	} else if m.searching {
+		content = append(content, resultStyle.Render(i18n.T("boolean.searching")))
 	} else if m.currentQuery != "" && m.expression != nil {
-		content = append(content, resultStyle.Render("No results found"))
+		content = append(content, resultStyle.Render(i18n.T("boolean.no_results")))
 	}
 
 	// Save prompt if requested
@@ -454,24 +520,24 @@ func (m *BooleanSearchModal) View() string {
 
 	// Help - always show essential commands, Ctrl+g expands for more
 	content = append(content, "")
-	essential := "Tab: cycle focus • Enter: search • Esc: close"
-	autocompleteHelp := "Ctrl+Space/→: accept suggestion • ↑/↓: navigate suggestions"
+	essential := i18n.T("boolean.help")
+	autocompleteHelp := i18n.T("search.autocomplete_help")
 	if m.showHelp {
 		// Show expanded help with examples and additional commands
-		content = append(content, headerStyle.Render("Examples:"))
+		content = append(content, headerStyle.Render(i18n.T("boolean.examples")))
 		content = append(content, "  tag1 AND tag2")
 		content = append(content, "  tag3 OR tag4") 
 		content = append(content, "  NOT tag5")
 		content = append(content, "")
-		content = append(content, helpStyle.Render("Text filter searches within boolean results using fuzzy matching"))
+		content = append(content, helpStyle.Render(i18n.T("boolean.text_help")))
 		content = append(content, "")
 		content = append(content, helpStyle.Render(essential))
-		content = append(content, helpStyle.Render("↑/↓: navigate results • Ctrl+s: save search • Ctrl+g: less help"))
+		content = append(content, helpStyle.Render(i18n.T("boolean.more_help_expanded")))
 		content = append(content, helpStyle.Render(autocompleteHelp))
 	} else {
 		// Show only essential commands with expand hint
 		content = append(content, helpStyle.Render(essential))
-		content = append(content, helpStyle.Render("Ctrl+g: more help • "+autocompleteHelp))
+		content = append(content, helpStyle.Render(i18n.T("boolean.more_help")+autocompleteHelp))
 	}
 
 	// Join content and apply modal styling
@@ -482,6 +548,9 @@ func (m *BooleanSearchModal) View() string {
 // SetActive sets the modal active state
 func (m *BooleanSearchModal) SetActive(active bool) {
 	m.isActive = active
+	if !active {
+		m.stopSearch()
+	}
 	if active {
 		m.booleanInput.Focus()
 		m.focusResults = false
@@ -506,7 +575,7 @@ func (m *BooleanSearchModal) SetEditMode(savedSearch *models.SavedSearch) {
 	
 	// Trigger search to show current results
 	if m.searchFunc != nil {
-		results, err := m.searchFunc(savedSearch.Expression)
+		results, err := m.searchFunc(context.Background(), savedSearch.Expression)
 		if err == nil {
 			m.searchResults = results
 			m.resultsCursor = 0
@@ -535,7 +604,7 @@ func (m *BooleanSearchModal) GetOriginalSearch() *models.SavedSearch {
 }
 
 // SetSearchFunc sets the callback function for live search
-func (m *BooleanSearchModal) SetSearchFunc(searchFunc func(*models.BooleanExpression) ([]*models.Prompt, error)) {
+func (m *BooleanSearchModal) SetSearchFunc(searchFunc func(context.Context, *models.BooleanExpression) ([]*models.Prompt, error)) {
 	m.searchFunc = searchFunc
 }
 
@@ -544,6 +613,11 @@ func (m *BooleanSearchModal) SetSaveFunc(saveFunc func(models.SavedSearch) error
 	m.saveFunc = saveFunc
 }
 
+// SetTagColors sets the colors used for tag badges
+func (m *BooleanSearchModal) SetTagColors(colors map[string]string) {
+	m.tagColors = colors
+}
+
 // IsSaveRequested returns whether a save was requested
 func (m *BooleanSearchModal) IsSaveRequested() bool {
 	return m.saveRequested
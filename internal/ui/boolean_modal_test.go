@@ -0,0 +1,78 @@
+package ui
+
+import (
+	"context"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/dpshade/pocket-prompt/internal/models"
+)
+
+func TestBooleanSearchModal_DebouncesLiveSearch(t *testing.T) {
+	searches := 0
+	modal := NewBooleanSearchModal([]string{"ai", "code"})
+	modal.SetSearchFunc(func(_ context.Context, expr *models.BooleanExpression) ([]*models.Prompt, error) {
+		searches++
+		return []*models.Prompt{{ID: expr.String()}}, nil
+	})
+	modal.SetActive(true)
+
+	for _, r := range "ai" {
+		modal.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	if searches != 0 || !modal.searching {
+		t.Fatalf("Expected the search to wait for typing to pause, got %d searches", searches)
+	}
+
+	// The pause after the first keystroke is stale by now
+	if cmd := modal.Update(booleanSearchTickMsg{generation: modal.searchGeneration - 1}); cmd != nil {
+		t.Error("Expected no search for a stale query")
+	}
+
+	cmd := modal.Update(booleanSearchTickMsg{generation: modal.searchGeneration})
+	if cmd == nil {
+		t.Fatal("Expected a search once typing paused")
+	}
+	result := cmd()
+	if searches != 1 {
+		t.Errorf("Expected one search, got %d", searches)
+	}
+
+	// Results arriving after the query changed again are dropped
+	modal.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	modal.Update(result)
+	if len(modal.searchResults) != 0 {
+		t.Errorf("Expected stale results to be dropped, got %d", len(modal.searchResults))
+	}
+
+	modal.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'i'}})
+	modal.Update(modal.Update(booleanSearchTickMsg{generation: modal.searchGeneration})())
+	if len(modal.searchResults) != 1 || modal.searching {
+		t.Errorf("Expected the latest results shown, got %d", len(modal.searchResults))
+	}
+}
+
+func TestBooleanSearchModal_CancelsStaleSearch(t *testing.T) {
+	var ctxs []context.Context
+	modal := NewBooleanSearchModal([]string{"ai"})
+	modal.SetSearchFunc(func(ctx context.Context, expr *models.BooleanExpression) ([]*models.Prompt, error) {
+		ctxs = append(ctxs, ctx)
+		return nil, nil
+	})
+	modal.SetActive(true)
+
+	modal.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+	search := modal.Update(booleanSearchTickMsg{generation: modal.searchGeneration})
+	modal.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'i'}})
+	search()
+	if len(ctxs) != 1 || ctxs[0].Err() == nil {
+		t.Fatal("Expected the search to be cancelled once the query changed")
+	}
+
+	search = modal.Update(booleanSearchTickMsg{generation: modal.searchGeneration})
+	modal.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	search()
+	if ctxs[1].Err() == nil {
+		t.Error("Expected closing the modal to cancel the search")
+	}
+}
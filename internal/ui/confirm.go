@@ -0,0 +1,118 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/dpshade/pocket-prompt/internal/i18n"
+)
+
+// confirmDialog asks before an action that can't be undone, such as a
+// delete or an overwrite. No is selected until the user picks Yes.
+type confirmDialog struct {
+	title   string
+	message string
+	label   string // Names the action on the Yes button, e.g. "Delete"
+	yes     bool   // Yes is selected
+	action  func(Model) (Model, tea.Cmd)
+}
+
+// askConfirm opens a dialog that runs action once the user confirms
+func (m *Model) askConfirm(title, message, label string, action func(Model) (Model, tea.Cmd)) {
+	m.confirm = &confirmDialog{
+		title:   title,
+		message: message,
+		label:   label,
+		action:  action,
+	}
+}
+
+// updateConfirm handles keys in the confirmation dialog: y or Enter on Yes
+// runs the action, while n, Esc or Enter on No cancel it
+func (m Model) updateConfirm(msg tea.KeyMsg) (Model, tea.Cmd) {
+	dialog := m.confirm
+	switch msg.String() {
+	case "left", "right", "h", "l", "tab", "shift+tab":
+		dialog.yes = !dialog.yes
+		return m, nil
+	case "y", "Y":
+		dialog.yes = true
+	case "n", "N", "esc", "q":
+		dialog.yes = false
+	case "enter":
+	default:
+		return m, nil
+	}
+
+	m.confirm = nil
+	if !dialog.yes {
+		m.statusMsg = i18n.T("status.cancelled")
+		m.statusTimeout = 2
+		return m, clearStatusCmd()
+	}
+	return dialog.action(m)
+}
+
+// renderConfirmModal draws the confirmation dialog with the action styled
+// as destructive
+func (m Model) renderConfirmModal() string {
+	dialog := m.confirm
+	width := min(60, m.width-4)
+
+	modalStyle := lipgloss.NewStyle().
+		Border(boxBorder(lipgloss.RoundedBorder())).
+		BorderForeground(ColorError).
+		Padding(1, 2).
+		Width(width)
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(ColorError).
+		MarginBottom(1)
+
+	buttonStyle := lipgloss.NewStyle().
+		Padding(0, 2).
+		MarginRight(2).
+		Border(boxBorder(lipgloss.NormalBorder())).
+		BorderForeground(ColorBorder)
+
+	helpStyle := lipgloss.NewStyle().
+		Foreground(ColorTextDim).
+		Italic(true).
+		MarginTop(1)
+
+	yesLabel, noLabel := dialog.label, i18n.T("confirm.cancel")
+	// Without color the focused button is marked
+	if plain && dialog.yes {
+		yesLabel = "> " + yesLabel
+	} else if plain {
+		noLabel = "> " + noLabel
+	}
+
+	yes := buttonStyle.Render(yesLabel)
+	no := buttonStyle.Render(noLabel)
+	if dialog.yes {
+		yes = buttonStyle.
+			Bold(true).
+			Foreground(lipgloss.Color("15")).
+			Background(ColorError).
+			BorderForeground(ColorError).
+			Render(yesLabel)
+	} else {
+		no = buttonStyle.
+			Bold(true).
+			BorderForeground(ColorPrimary).
+			Render(noLabel)
+	}
+
+	content := []string{
+		titleStyle.Render(dialog.title),
+		lipgloss.NewStyle().Width(width - 4).Render(dialog.message),
+		"",
+		lipgloss.JoinHorizontal(lipgloss.Top, no, yes),
+		helpStyle.Render(i18n.T("confirm.help")),
+	}
+
+	modal := modalStyle.Render(lipgloss.JoinVertical(lipgloss.Left, content...))
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, modal)
+}
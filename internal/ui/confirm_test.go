@@ -0,0 +1,55 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestConfirmDialog(t *testing.T) {
+	ran := 0
+	action := func(m Model) (Model, tea.Cmd) {
+		ran++
+		return m, nil
+	}
+
+	var m Model
+	m.askConfirm("Delete prompt?", "Delete review?", "Delete", action)
+	m, _ = m.updateConfirm(tea.KeyMsg{Type: tea.KeyEnter})
+	if ran != 0 || m.confirm != nil {
+		t.Fatalf("Expected Enter on the default No to cancel, ran %d times", ran)
+	}
+
+	m.askConfirm("Delete prompt?", "Delete review?", "Delete", action)
+	m, _ = m.updateConfirm(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	if m.confirm == nil {
+		t.Fatal("Expected other keys to leave the dialog open")
+	}
+	m, _ = m.updateConfirm(tea.KeyMsg{Type: tea.KeyRight})
+	m, _ = m.updateConfirm(tea.KeyMsg{Type: tea.KeyEnter})
+	if ran != 1 || m.confirm != nil {
+		t.Errorf("Expected Enter on Yes to run the action once, ran %d times", ran)
+	}
+
+	m.askConfirm("Delete prompt?", "Delete review?", "Delete", action)
+	m, _ = m.updateConfirm(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	if ran != 2 {
+		t.Errorf("Expected y to run the action, ran %d times", ran)
+	}
+}
+
+func TestConfirmDialogPlain(t *testing.T) {
+	SetPlain(true)
+	t.Cleanup(func() { SetPlain(false) })
+
+	m := Model{width: 80, height: 24}
+	m.askConfirm("Delete prompt?", "Delete review?", "Delete", func(m Model) (Model, tea.Cmd) { return m, nil })
+	view := m.renderConfirmModal()
+	if strings.ContainsAny(view, "╭╮╰╯─│┌┐└┘") {
+		t.Errorf("Expected no box-drawing in plain mode, got:\n%s", view)
+	}
+	if !strings.Contains(view, "> Cancel") || strings.Contains(view, "> Delete") {
+		t.Errorf("Expected the focused Cancel button marked, got:\n%s", view)
+	}
+}
@@ -0,0 +1,124 @@
+package ui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/dpshade/pocket-prompt/internal/i18n"
+	"github.com/dpshade/pocket-prompt/internal/service"
+)
+
+// updateConflicts handles keys in the sync conflicts modal: moving between
+// conflict copies and resolving the selected one
+func (m Model) updateConflicts(msg tea.KeyMsg) (Model, tea.Cmd) {
+	var resolution string
+	switch msg.String() {
+	case "C", "esc", "q":
+		m.showConflicts = false
+		return m, nil
+	case "up", "k":
+		if m.conflictCursor > 0 {
+			m.conflictCursor--
+		}
+		return m, nil
+	case "down", "j":
+		if m.conflictCursor < len(m.conflicts)-1 {
+			m.conflictCursor++
+		}
+		return m, nil
+	case "n":
+		resolution = service.KeepNewer
+	case "o":
+		resolution = service.KeepOriginal
+	case "c":
+		resolution = service.KeepCopy
+	case "m":
+		resolution = service.MergeCopies
+	default:
+		return m, nil
+	}
+
+	// Keeping the copy overwrites the original file
+	if resolution == service.KeepCopy {
+		conflict := m.conflicts[m.conflictCursor]
+		m.askConfirm(i18n.T("conflicts.confirm_title"), i18n.T("conflicts.confirm", conflict.Original, conflict.Path), i18n.T("conflicts.overwrite"), func(m Model) (Model, tea.Cmd) {
+			return m.resolveConflict(resolution)
+		})
+		return m, nil
+	}
+	return m.resolveConflict(resolution)
+}
+
+// resolveConflict resolves the selected conflict copy
+func (m Model) resolveConflict(resolution string) (Model, tea.Cmd) {
+	conflict := m.conflicts[m.conflictCursor]
+	applied, err := m.service.ResolveConflict(conflict, resolution)
+	if err != nil {
+		m.setError(i18n.T("error.resolve_conflict"), err)
+		m.statusTimeout = 5
+		return m, clearStatusCmd()
+	}
+	switch applied {
+	case service.KeepOriginal:
+		m.statusMsg = i18n.T("conflicts.kept", conflict.Original)
+	case service.KeepCopy:
+		m.statusMsg = i18n.T("conflicts.replaced", conflict.Original)
+	case service.MergeCopies:
+		m.statusMsg = i18n.T("conflicts.merged", conflict.Original)
+	}
+	m.statusTimeout = 3
+
+	if err := m.refreshPromptList(); err != nil {
+		m.err = err
+	}
+	if m.conflictCursor >= len(m.conflicts) {
+		m.conflictCursor = max(0, len(m.conflicts)-1)
+	}
+	if len(m.conflicts) == 0 {
+		m.showConflicts = false
+	}
+	return m, clearStatusCmd()
+}
+
+// renderConflictsModal lists the conflict copies left by file sync services
+// with the ways to resolve the selected one
+func (m Model) renderConflictsModal() string {
+	width := min(90, m.width-4)
+
+	modalStyle := lipgloss.NewStyle().
+		Border(boxBorder(lipgloss.RoundedBorder())).
+		Padding(1, 2).
+		Width(width)
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		MarginBottom(1)
+
+	selectedStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(ColorPrimary)
+
+	detailStyle := lipgloss.NewStyle().
+		Foreground(ColorTextDim)
+
+	helpStyle := lipgloss.NewStyle().
+		Foreground(ColorTextDim).
+		Italic(true).
+		MarginTop(1)
+
+	content := []string{titleStyle.Render(i18n.T("conflicts.title", len(m.conflicts)))}
+	for i, conflict := range m.conflicts {
+		line := "  " + conflict.Path
+		if i == m.conflictCursor {
+			line = selectedStyle.Render("▶ " + conflict.Path)
+		}
+		detail := fmt.Sprintf("    copy of %s by %s, %s", conflict.Original, conflict.Source, conflict.ModTime.Format("2006-01-02 15:04"))
+		content = append(content, line, detailStyle.Render(detail))
+	}
+	content = append(content, helpStyle.Render(i18n.T("conflicts.help")))
+
+	modal := modalStyle.Render(lipgloss.JoinVertical(lipgloss.Left, content...))
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, modal)
+}
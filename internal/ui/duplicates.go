@@ -0,0 +1,142 @@
+package ui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/dpshade/pocket-prompt/internal/i18n"
+	"github.com/dpshade/pocket-prompt/internal/service"
+)
+
+// openDuplicates looks for duplicate prompts and opens the report, or says
+// there are none
+func (m Model) openDuplicates() (Model, tea.Cmd) {
+	groups, err := m.service.FindDuplicates(service.DefaultDuplicateThreshold)
+	if err != nil {
+		m.setError(i18n.T("error.find_duplicates"), err)
+		m.statusTimeout = 3
+		return m, clearStatusCmd()
+	}
+	if len(groups) == 0 {
+		m.statusMsg = i18n.T("duplicates.none")
+		m.statusTimeout = 2
+		return m, clearStatusCmd()
+	}
+	m.duplicates = groups
+	m.duplicateCursor = 0
+	m.showDuplicates = true
+	return m, nil
+}
+
+// updateDuplicates handles keys in the duplicates report: moving between
+// groups and merging or deleting the selected one
+func (m Model) updateDuplicates(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch msg.String() {
+	case "=", "esc", "q":
+		m.showDuplicates = false
+		return m, nil
+	case "up", "k":
+		if m.duplicateCursor > 0 {
+			m.duplicateCursor--
+		}
+		return m, nil
+	case "down", "j":
+		if m.duplicateCursor < len(m.duplicates)-1 {
+			m.duplicateCursor++
+		}
+		return m, nil
+	case "m":
+		return m.removeDuplicates(true)
+	case "d":
+		group := m.duplicates[m.duplicateCursor]
+		m.askConfirm(i18n.T("duplicates.confirm_title"), i18n.T("duplicates.confirm", len(group.Duplicates()), group.Keep().ID, group.Keep().ID), i18n.T("confirm.delete"), func(m Model) (Model, tea.Cmd) {
+			return m.removeDuplicates(false)
+		})
+	}
+	return m, nil
+}
+
+// removeDuplicates merges the selected group's duplicates into the prompt
+// kept, or deletes them
+func (m Model) removeDuplicates(merge bool) (Model, tea.Cmd) {
+	group := m.duplicates[m.duplicateCursor]
+	var err error
+	if merge {
+		if _, err = m.service.MergeDuplicates(group); err == nil {
+			m.statusMsg = i18n.T("duplicates.merged", len(group.Duplicates()), group.Keep().ID)
+		}
+	} else {
+		if _, err = m.service.DeletePrompts(group.Duplicates()); err == nil {
+			m.statusMsg = i18n.T("duplicates.deleted", len(group.Duplicates()), group.Keep().ID)
+		}
+	}
+	if err != nil {
+		m.setError(i18n.T("error.remove_duplicates"), err)
+		m.statusTimeout = 5
+		return m, clearStatusCmd()
+	}
+	m.statusTimeout = 3
+
+	if err := m.refreshPromptList(); err != nil {
+		m.err = err
+	}
+	m.duplicates = append(m.duplicates[:m.duplicateCursor:m.duplicateCursor], m.duplicates[m.duplicateCursor+1:]...)
+	if m.duplicateCursor >= len(m.duplicates) {
+		m.duplicateCursor = max(0, len(m.duplicates)-1)
+	}
+	if len(m.duplicates) == 0 {
+		m.showDuplicates = false
+	}
+	return m, clearStatusCmd()
+}
+
+// renderDuplicatesModal lists the groups of duplicate prompts with the ways
+// to resolve the selected one
+func (m Model) renderDuplicatesModal() string {
+	width := min(90, m.width-4)
+
+	modalStyle := lipgloss.NewStyle().
+		Border(boxBorder(lipgloss.RoundedBorder())).
+		Padding(1, 2).
+		Width(width)
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		MarginBottom(1)
+
+	selectedStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(ColorPrimary)
+
+	detailStyle := lipgloss.NewStyle().
+		Foreground(ColorTextDim)
+
+	helpStyle := lipgloss.NewStyle().
+		Foreground(ColorTextDim).
+		Italic(true).
+		MarginTop(1)
+
+	content := []string{titleStyle.Render(i18n.T("duplicates.title", len(m.duplicates)))}
+	for i, group := range m.duplicates {
+		label := i18n.T("duplicates.identical")
+		if group.Similarity < 1 {
+			label = i18n.T("duplicates.similar", group.Similarity*100)
+		}
+		line := fmt.Sprintf("%s (%s)", group.Keep().Title(), label)
+		if i == m.duplicateCursor {
+			line = selectedStyle.Render("▶ " + line)
+		} else {
+			line = "  " + line
+		}
+		content = append(content, line)
+		for _, duplicate := range group.Duplicates() {
+			content = append(content, detailStyle.Render("    "+duplicate.ID+" - "+duplicate.Title()))
+		}
+	}
+	content = append(content, helpStyle.Render(i18n.T("duplicates.help")))
+
+	modal := modalStyle.Render(lipgloss.JoinVertical(lipgloss.Left, content...))
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, modal)
+}
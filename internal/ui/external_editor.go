@@ -0,0 +1,121 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/dpshade/pocket-prompt/internal/i18n"
+	"github.com/dpshade/pocket-prompt/internal/models"
+)
+
+// editorFinishedMsg reports that the external editor exited
+type editorFinishedMsg struct {
+	prompt *models.Prompt // The prompt as it was before editing
+	path   string         // The temporary copy that was edited
+	err    error
+}
+
+// editorCommand runs $VISUAL or $EDITOR on a file, falling back to vi.
+// The editor may carry arguments, e.g. "code --wait".
+func editorCommand(path string) *exec.Cmd {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+	parts := strings.Fields(editor)
+	return exec.Command(parts[0], append(parts[1:], path)...)
+}
+
+// openInEditor suspends the TUI to edit the selected prompt's markdown file
+// in the user's editor. Like the CLI's edit command, a temporary copy is
+// edited, so it works with every storage backend and encrypted prompts.
+func (m Model) openInEditor() (Model, tea.Cmd) {
+	path, err := m.writePromptSource(m.selectedPrompt)
+	if err != nil {
+		m.setError(i18n.T("error.open_editor"), err)
+		m.statusTimeout = 3
+		return m, clearStatusCmd()
+	}
+	return m, editPromptFileCmd(m.selectedPrompt, path)
+}
+
+// writePromptSource writes a prompt's markdown file to a temporary copy
+func (m Model) writePromptSource(prompt *models.Prompt) (string, error) {
+	data, err := m.service.PromptSource(prompt)
+	if err != nil {
+		return "", err
+	}
+
+	tmp, err := os.CreateTemp("", "pocket-prompt-*.md")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return "", fmt.Errorf("failed to write temporary file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return "", fmt.Errorf("failed to write temporary file: %w", err)
+	}
+	return tmp.Name(), nil
+}
+
+// editPromptFileCmd runs the editor on a prompt's temporary copy
+func editPromptFileCmd(prompt *models.Prompt, path string) tea.Cmd {
+	return tea.ExecProcess(editorCommand(path), func(err error) tea.Msg {
+		return editorFinishedMsg{prompt: prompt, path: path, err: err}
+	})
+}
+
+// finishExternalEdit saves the edited file as a new version of the prompt,
+// archiving the old one, and shows the result. An invalid file is kept so
+// the edits aren't lost.
+func (m Model) finishExternalEdit(msg editorFinishedMsg) (Model, tea.Cmd) {
+	if msg.err != nil {
+		os.Remove(msg.path)
+		m.setError(i18n.T("error.editor"), msg.err)
+		m.statusTimeout = 5
+		return m, clearStatusCmd()
+	}
+
+	data, err := os.ReadFile(msg.path)
+	if err != nil {
+		os.Remove(msg.path)
+		m.setError(i18n.T("error.read_edited"), err)
+		m.statusTimeout = 5
+		return m, clearStatusCmd()
+	}
+	updated, err := m.service.UpdatePromptFromSource(msg.prompt, data)
+	if err != nil {
+		m.setError(i18n.T("error.edit_not_saved"), fmt.Errorf("%w (your changes are in %s)", err, msg.path))
+		m.statusTimeout = 10
+		return m, clearStatusCmd()
+	}
+	os.Remove(msg.path)
+
+	if updated == nil {
+		m.statusMsg = i18n.T("status.no_changes")
+		m.statusTimeout = 2
+		return m, clearStatusCmd()
+	}
+	if m.selectedPrompt != nil && m.selectedPrompt.ID == updated.ID {
+		m.selectedPrompt = updated
+		if err := m.renderPreview(); err != nil {
+			m.err = err
+		}
+	}
+	if err := m.showSavedPrompt(updated); err != nil {
+		m.err = err
+	}
+	m.statusMsg = i18n.T("status.updated_version", updated.ID, updated.Version)
+	m.statusTimeout = 3
+	return m, clearStatusCmd()
+}
@@ -0,0 +1,178 @@
+package ui
+
+import (
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/dpshade/pocket-prompt/internal/clipboard"
+	"github.com/dpshade/pocket-prompt/internal/i18n"
+	"github.com/dpshade/pocket-prompt/internal/models"
+	"github.com/dpshade/pocket-prompt/internal/renderer"
+)
+
+// variableFill asks for the prompt's variables one at a time before the
+// prompt is rendered and copied. An empty answer keeps the default.
+type variableFill struct {
+	fields []models.Slot
+	values []string // Answers so far, one per field
+	index  int      // The field being asked for
+	input  textinput.Model
+}
+
+// openVariableFill starts filling in the selected prompt's variables, or
+// copies it right away when it has none
+func (m Model) openVariableFill() (Model, tea.Cmd) {
+	fields, err := m.service.PromptVariables(m.selectedPrompt, "")
+	if err != nil {
+		m.setError(i18n.T("error.load_variables"), err)
+		m.statusTimeout = 3
+		return m, clearStatusCmd()
+	}
+	if len(fields) == 0 {
+		return m.copyFilled(nil)
+	}
+
+	fill := &variableFill{
+		fields: fields,
+		values: make([]string, len(fields)),
+		input:  textinput.New(),
+	}
+	fill.input.Focus()
+	fill.ask(0)
+	m.variableFill = fill
+	return m, textinput.Blink
+}
+
+// ask moves to a field, showing its answer so far and its default
+func (f *variableFill) ask(index int) {
+	f.index = index
+	field := f.fields[index]
+	f.input.Prompt = field.Name + ": "
+	f.input.Placeholder = field.Default
+	f.input.SetValue(f.values[index])
+	f.input.CursorEnd()
+}
+
+// updateVariableFill handles keys while variables are filled in: Enter
+// moves to the next variable and copies after the last, Shift+Tab goes
+// back to the previous one and Esc cancels
+func (m Model) updateVariableFill(msg tea.KeyMsg) (Model, tea.Cmd) {
+	fill := m.variableFill
+	switch msg.String() {
+	case "esc", "ctrl+c":
+		m.variableFill = nil
+		m.statusMsg = i18n.T("status.cancelled")
+		m.statusTimeout = 2
+		return m, clearStatusCmd()
+	case "shift+tab", "up":
+		fill.values[fill.index] = fill.input.Value()
+		if fill.index > 0 {
+			fill.ask(fill.index - 1)
+		}
+		return m, nil
+	case "enter", "tab", "down":
+		fill.values[fill.index] = fill.input.Value()
+		if fill.index < len(fill.fields)-1 {
+			fill.ask(fill.index + 1)
+			return m, nil
+		}
+		if msg.String() != "enter" {
+			return m, nil
+		}
+		m.variableFill = nil
+		variables := make(map[string]interface{})
+		for i, field := range fill.fields {
+			if fill.values[i] != "" {
+				variables[field.Name] = fill.values[i]
+			}
+		}
+		return m.copyFilled(variables)
+	}
+
+	var cmd tea.Cmd
+	fill.input, cmd = fill.input.Update(msg)
+	return m, cmd
+}
+
+// copyFilled renders the selected prompt through its template with the
+// variables given, the rest keeping their defaults, and copies it
+func (m Model) copyFilled(variables map[string]interface{}) (Model, tea.Cmd) {
+	var template *models.Template
+	if m.selectedPrompt.TemplateRef != "" {
+		template, _ = m.service.GetTemplate(m.selectedPrompt.TemplateRef)
+	}
+
+	r := renderer.NewRenderer(m.selectedPrompt, template)
+	r.SetAttachmentLoader(m.service.LoadAttachment)
+	r.SetSnippetLoader(m.service.LoadSnippet)
+	r.SetSecretLoader(m.service.LoadSecret)
+
+	content, err := r.RenderText(variables)
+	if err == nil {
+		var statusMsg string
+		if statusMsg, err = clipboard.CopyWithFallback(content); err == nil {
+			m.statusMsg = statusMsg
+			m.statusTimeout = 2
+			m.recordUsage()
+		}
+	}
+	if err != nil {
+		m.setError(i18n.T("error.copy"), err)
+		m.statusTimeout = 3
+	}
+	return m, clearStatusCmd()
+}
+
+// renderVariableFill draws the variable being asked for as a modal, with
+// the answers so far
+func (m Model) renderVariableFill() string {
+	fill := m.variableFill
+	width := min(70, m.width-4)
+
+	modalStyle := lipgloss.NewStyle().
+		Border(boxBorder(lipgloss.RoundedBorder())).
+		BorderForeground(ColorPrimary).
+		Padding(1, 2).
+		Width(width)
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(ColorPrimary).
+		MarginBottom(1)
+
+	dimStyle := lipgloss.NewStyle().
+		Foreground(ColorTextDim)
+
+	helpStyle := lipgloss.NewStyle().
+		Foreground(ColorTextDim).
+		Italic(true).
+		MarginTop(1)
+
+	field := fill.fields[fill.index]
+	content := []string{
+		titleStyle.Render(i18n.T("fill.title", m.selectedPrompt.Name, fill.index+1, len(fill.fields))),
+	}
+	for i := 0; i < fill.index; i++ {
+		value := fill.values[i]
+		if value == "" {
+			value = fill.fields[i].Default
+		}
+		content = append(content, dimStyle.Render(fill.fields[i].Name+": "+value))
+	}
+	content = append(content, fill.input.View())
+	if field.Description != "" {
+		content = append(content, dimStyle.Render(field.Description))
+	}
+	if field.Required && field.Default == "" {
+		content = append(content, dimStyle.Render(i18n.T("fill.required")))
+	}
+
+	help := i18n.T("fill.help")
+	if fill.index == len(fill.fields)-1 {
+		help = i18n.T("fill.help_last")
+	}
+	content = append(content, helpStyle.Render(help))
+
+	modal := modalStyle.Render(lipgloss.JoinVertical(lipgloss.Left, content...))
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, modal)
+}
@@ -1,7 +1,8 @@
 package ui
 
 import (
-	"regexp"
+	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -9,40 +10,10 @@ import (
 	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/textarea"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/dpshade/pocket-prompt/internal/i18n"
 	"github.com/dpshade/pocket-prompt/internal/models"
 )
 
-// generateIDFromTitle creates a URL-safe ID from a title
-func generateIDFromTitle(title string) string {
-	if title == "" {
-		return "untitled-prompt"
-	}
-	
-	// Convert to lowercase
-	id := strings.ToLower(title)
-	
-	// Replace spaces and special characters with hyphens
-	reg := regexp.MustCompile(`[^a-z0-9]+`)
-	id = reg.ReplaceAllString(id, "-")
-	
-	// Remove leading and trailing hyphens
-	id = strings.Trim(id, "-")
-	
-	// Ensure it's not empty
-	if id == "" {
-		return "untitled-prompt"
-	}
-	
-	// Limit length to 50 characters
-	if len(id) > 50 {
-		id = id[:50]
-		// Remove trailing hyphen if trimming created one
-		id = strings.TrimSuffix(id, "-")
-	}
-	
-	return id
-}
-
 // CreateForm handles prompt creation
 type CreateForm struct {
 	inputs        []textinput.Model
@@ -51,6 +22,9 @@ type CreateForm struct {
 	submitted     bool
 	fromScratch   bool // True for simplified "from scratch" form
 	availableTags []string // Added for tag autocomplete
+	metadata      map[string]interface{} // Metadata of the prompt being edited
+	width         int                    // Width of the content field, 0 until resized
+	preview       contentPreview         // Rendered markdown of the content
 }
 
 // Form field indices
@@ -61,12 +35,13 @@ const (
 	descriptionField
 	tagsField
 	templateRefField
+	metadataField
 	contentField
 )
 
 // NewCreateFormFromScratch creates a simplified empty form for starting from scratch
 func NewCreateFormFromScratch() *CreateForm {
-	inputs := make([]textinput.Model, 6) // Reduced from 7 after removing variables field
+	inputs := make([]textinput.Model, 7)
 
 	// ID field - will be auto-generated from title
 	inputs[idField] = textinput.New()
@@ -100,6 +75,11 @@ func NewCreateFormFromScratch() *CreateForm {
 	inputs[templateRefField].CharLimit = 100
 	inputs[templateRefField].Width = 40
 
+	// Metadata field
+	inputs[metadataField] = textinput.New()
+	inputs[metadataField].CharLimit = 1000
+	inputs[metadataField].Width = 60
+
 	// Content textarea - completely empty
 	ta := textarea.New()
 	ta.CharLimit = 0 // Remove character limit (0 = unlimited)
@@ -118,11 +98,11 @@ func NewCreateFormFromScratch() *CreateForm {
 
 // NewCreateForm creates a new prompt creation form with helpful placeholders
 func NewCreateForm() *CreateForm {
-	inputs := make([]textinput.Model, 6) // Reduced from 7 after removing variables field
+	inputs := make([]textinput.Model, 7)
 
 	// ID field
 	inputs[idField] = textinput.New()
-	inputs[idField].Placeholder = "prompt-id"
+	inputs[idField].Placeholder = i18n.T("form.id_placeholder")
 	inputs[idField].Focus()
 	inputs[idField].CharLimit = 50
 	inputs[idField].Width = 40
@@ -135,31 +115,37 @@ func NewCreateForm() *CreateForm {
 
 	// Title field
 	inputs[titleField] = textinput.New()
-	inputs[titleField].Placeholder = "Prompt Title"
+	inputs[titleField].Placeholder = i18n.T("form.title_placeholder")
 	inputs[titleField].CharLimit = 100
 	inputs[titleField].Width = 40
 
 	// Description field
 	inputs[descriptionField] = textinput.New()
-	inputs[descriptionField].Placeholder = "Brief description of the prompt"
+	inputs[descriptionField].Placeholder = i18n.T("form.description_placeholder")
 	inputs[descriptionField].CharLimit = 255
 	inputs[descriptionField].Width = 60
 
 	// Tags field - enhanced with better UX
 	inputs[tagsField] = textinput.New()
-	inputs[tagsField].Placeholder = "ai, prompt-engineering, productivity (comma-separated)"
+	inputs[tagsField].Placeholder = i18n.T("form.tags_placeholder")
 	inputs[tagsField].CharLimit = 300
 	inputs[tagsField].Width = 60
 
 	// Template reference field
 	inputs[templateRefField] = textinput.New()
-	inputs[templateRefField].Placeholder = "template-id (optional)"
+	inputs[templateRefField].Placeholder = i18n.T("form.template_placeholder")
 	inputs[templateRefField].CharLimit = 100
 	inputs[templateRefField].Width = 40
 
+	// Metadata field
+	inputs[metadataField] = textinput.New()
+	inputs[metadataField].Placeholder = i18n.T("form.metadata_placeholder")
+	inputs[metadataField].CharLimit = 1000
+	inputs[metadataField].Width = 60
+
 	// Content textarea
 	ta := textarea.New()
-	ta.Placeholder = "Enter your prompt content here..."
+	ta.Placeholder = i18n.T("form.content_placeholder")
 	ta.CharLimit = 0 // Remove character limit (0 = unlimited)
 	ta.MaxHeight = 0 // Remove line limit (0 = unlimited)
 	ta.ShowLineNumbers = false // Disable line numbers to prevent double spacing
@@ -189,6 +175,10 @@ func (f *CreateForm) Update(msg tea.Msg) tea.Cmd {
 		case "ctrl+s":
 			f.submitted = true
 			return nil
+		case "ctrl+o":
+			// Show or hide the rendered content, from any field
+			f.TogglePreview()
+			return nil
 		case "down":
 			// Only handle down for field navigation when NOT in content field
 			if f.focused != contentField {
@@ -207,6 +197,16 @@ func (f *CreateForm) Update(msg tea.Msg) tea.Cmd {
 				f.nextField()
 				return nil
 			}
+		case "ctrl+r":
+			// Start the next message of a multi-message prompt
+			if f.focused == contentField {
+				marker := fmt.Sprintf("<!-- role: %s -->\n", nextMessageRole(f.textarea.Value()))
+				if f.textarea.Value() != "" {
+					marker = "\n" + marker
+				}
+				f.textarea.InsertString(marker)
+				return nil
+			}
 		case "alt+up", "ctrl+home":
 			// Jump to beginning of content (ALT+UP or CTRL+HOME)
 			if f.focused == contentField {
@@ -256,19 +256,34 @@ func (f *CreateForm) Update(msg tea.Msg) tea.Cmd {
 	return nil
 }
 
+// nextMessageRole picks the role of the next message of a multi-message
+// body: system to start with, then user and assistant in turn
+func nextMessageRole(content string) string {
+	messages := models.ParseMessages(content)
+	switch {
+	case len(messages) == 0:
+		return models.RoleSystem
+	case messages[len(messages)-1].Role == models.RoleUser:
+		return models.RoleAssistant
+	default:
+		return models.RoleUser
+	}
+}
+
 // Resize updates form dimensions based on window size
 func (f *CreateForm) Resize(width, height int) {
 	// Calculate available height for textarea
-	// Reserve space for: title (2), form fields (8-10), help text (4), margins (6)
-	reservedHeight := 20
+	// Reserve space for: title (2), form fields (11-13), length line (1), help text (4), margins (6)
+	reservedHeight := 25
 	availableHeight := height - reservedHeight
 	if availableHeight < 5 {
 		availableHeight = 5 // Minimum height
 	}
 	
-	// Update textarea size
-	f.textarea.SetWidth(width - 10) // Account for padding
+	// Update textarea size, accounting for padding
+	f.width = width - 10
 	f.textarea.SetHeight(availableHeight)
+	f.layoutContent()
 }
 
 // nextField moves to the next form field
@@ -280,7 +295,7 @@ func (f *CreateForm) nextField() {
 	}
 	
 	if f.fromScratch {
-		// Navigation for scratch form: Version -> Title -> Description -> Tags -> Template Ref -> Content
+		// Navigation for scratch form: Version -> Title -> Description -> Tags -> Template Ref -> Metadata -> Content
 		switch f.focused {
 		case versionField:
 			f.focused = titleField
@@ -291,6 +306,8 @@ func (f *CreateForm) nextField() {
 		case tagsField:
 			f.focused = templateRefField
 		case templateRefField:
+			f.focused = metadataField
+		case metadataField:
 			f.focused = contentField
 		case contentField:
 			f.focused = versionField
@@ -321,7 +338,7 @@ func (f *CreateForm) prevField() {
 	}
 	
 	if f.fromScratch {
-		// Navigation for scratch form: Content -> Template Ref -> Tags -> Description -> Title -> Version
+		// Navigation for scratch form: Content -> Metadata -> Template Ref -> Tags -> Description -> Title -> Version
 		switch f.focused {
 		case versionField:
 			f.focused = contentField
@@ -333,8 +350,10 @@ func (f *CreateForm) prevField() {
 			f.focused = descriptionField
 		case templateRefField:
 			f.focused = tagsField
-		case contentField:
+		case metadataField:
 			f.focused = templateRefField
+		case contentField:
+			f.focused = metadataField
 		default:
 			f.focused = versionField // Fallback to version field
 		}
@@ -380,6 +399,8 @@ func (f *CreateForm) GetFocusedFieldType() string {
 		return "tags"
 	case templateRefField:
 		return "templateRef"
+	case metadataField:
+		return "metadata"
 	case contentField:
 		return "content"
 	default:
@@ -392,9 +413,8 @@ func (f *CreateForm) ToPrompt() *models.Prompt {
 	now := time.Now()
 	
 	if f.fromScratch {
-		// From scratch form: auto-generate ID from title, use all form fields
+		// From scratch form: the service generates the ID from the title
 		title := f.inputs[titleField].Value()
-		id := generateIDFromTitle(title)
 		
 		// Parse tags from comma-separated string
 		tags := []string{}
@@ -409,12 +429,12 @@ func (f *CreateForm) ToPrompt() *models.Prompt {
 		}
 		
 		return &models.Prompt{
-			ID:          id,
 			Version:     f.inputs[versionField].Value(),
 			Name:        title,
 			Summary:     f.inputs[descriptionField].Value(),
 			Tags:        tags,
 			TemplateRef: f.inputs[templateRefField].Value(),
+			Metadata:    parseMetadata(f.inputs[metadataField].Value(), f.metadata),
 			CreatedAt:   now,
 			UpdatedAt:   now,
 			Content:     f.textarea.Value(),
@@ -444,6 +464,7 @@ func (f *CreateForm) ToPrompt() *models.Prompt {
 		Summary:     f.inputs[descriptionField].Value(),
 		Tags:        tags,
 		TemplateRef: f.inputs[templateRefField].Value(),
+		Metadata:    parseMetadata(f.inputs[metadataField].Value(), f.metadata),
 		CreatedAt:   now,
 		UpdatedAt:   now,
 		Content:     f.textarea.Value(),
@@ -468,9 +489,11 @@ func (f *CreateForm) Reset() {
 
 // SetAvailableTags sets the available tags for autocomplete
 func (f *CreateForm) SetAvailableTags(tags []string) {
-	f.availableTags = tags
+	f.availableTags = append([]string(nil), tags...)
+	sort.Slice(f.availableTags, func(i, j int) bool {
+		return strings.ToLower(f.availableTags[i]) < strings.ToLower(f.availableTags[j])
+	})
 	if len(tags) > 0 {
-		f.inputs[tagsField].SetSuggestions(tags)
 		f.inputs[tagsField].ShowSuggestions = true
 		
 		// Customize keybindings to avoid Tab conflict
@@ -478,66 +501,83 @@ func (f *CreateForm) SetAvailableTags(tags []string) {
 		customKeyMap.AcceptSuggestion = key.NewBinding(key.WithKeys("ctrl+space", "right"))
 		f.inputs[tagsField].KeyMap = customKeyMap
 	}
+	f.updateTagAutocomplete()
 }
 
 // updateTagAutocomplete updates tag autocomplete suggestions based on current input
 func (f *CreateForm) updateTagAutocomplete() {
-	if len(f.availableTags) == 0 {
-		return
+	f.inputs[tagsField].SetSuggestions(tagCompletions(f.inputs[tagsField].Value(), f.availableTags))
+}
+
+// tagCompletions completes the last comma-separated tag in value. The text
+// input matches suggestions against its whole value, so each completion
+// repeats the tags already entered. Tags already in the list are skipped.
+func tagCompletions(value string, available []string) []string {
+	head := ""
+	current := value
+	if i := strings.LastIndex(value, ","); i >= 0 {
+		head = value[:i+1]
+		current = value[i+1:]
 	}
-	
-	value := f.inputs[tagsField].Value()
-	cursorPos := f.inputs[tagsField].Position()
-	
-	// Find the current tag being typed (comma-separated)
-	currentTag := f.getCurrentTagForCompletion(value, cursorPos)
-	
-	if currentTag == "" {
-		// Show all tags if no current tag
-		f.inputs[tagsField].SetSuggestions(f.availableTags)
-	} else {
-		// Filter tags that start with the current tag (case insensitive)
-		var filteredTags []string
-		currentTagLower := strings.ToLower(currentTag)
-		for _, tag := range f.availableTags {
-			if strings.HasPrefix(strings.ToLower(tag), currentTagLower) {
-				filteredTags = append(filteredTags, tag)
-			}
+	trimmed := strings.TrimLeft(current, " ")
+	if trimmed == "" {
+		return nil
+	}
+	head += current[:len(current)-len(trimmed)]
+
+	entered := make(map[string]bool)
+	for _, tag := range strings.Split(head, ",") {
+		entered[strings.ToLower(strings.TrimSpace(tag))] = true
+	}
+
+	prefix := strings.ToLower(trimmed)
+	var completions []string
+	for _, tag := range available {
+		lower := strings.ToLower(tag)
+		if lower == prefix || entered[lower] || !strings.HasPrefix(lower, prefix) {
+			continue
 		}
-		f.inputs[tagsField].SetSuggestions(filteredTags)
+		completions = append(completions, head+tag)
 	}
+	return completions
 }
 
-// getCurrentTagForCompletion extracts the tag at the cursor position in comma-separated input
-func (f *CreateForm) getCurrentTagForCompletion(text string, cursorPos int) string {
-	if cursorPos < 0 || cursorPos > len(text) {
+// TagSuggestionHint lists the tags matching the one being typed, for display
+// under the tags field. It is empty when nothing matches.
+func (f *CreateForm) TagSuggestionHint() string {
+	if f.focused != tagsField {
 		return ""
 	}
-	
-	// Find the start and end of current tag (comma-separated)
-	tagStart := 0
-	for i := cursorPos - 1; i >= 0; i-- {
-		if text[i] == ',' {
-			tagStart = i + 1
-			break
-		}
+	completions := f.inputs[tagsField].AvailableSuggestions()
+	if len(completions) == 0 {
+		return ""
 	}
-	
-	tagEnd := len(text)
-	for i := cursorPos; i < len(text); i++ {
-		if text[i] == ',' {
-			tagEnd = i
+
+	const maxShown = 5
+	var names []string
+	for i, completion := range completions {
+		if i == maxShown {
+			names = append(names, fmt.Sprintf("+%d more", len(completions)-maxShown))
 			break
 		}
+		names = append(names, strings.TrimSpace(completion[strings.LastIndex(completion, ",")+1:]))
 	}
-	
-	// Extract and trim the current tag
-	if tagEnd <= len(text) {
-		tag := strings.TrimSpace(text[tagStart:tagEnd])
-		return tag
+	return i18n.T("form.matching_tags", strings.Join(names, ", "))
+}
+
+// LengthSummary describes the length of the content for the status line
+// below it
+func (f *CreateForm) LengthSummary() string {
+	length := models.MeasureText(f.textarea.Value())
+	return fmt.Sprintf("%s • %s • ~%s", plural(length.Words, "word"), plural(length.Characters, "character"), plural(length.Tokens, "token"))
+}
+
+// plural formats a count with its noun
+func plural(count int, noun string) string {
+	if count == 1 {
+		return "1 " + noun
 	}
-	
-	return ""
+	return fmt.Sprintf("%d %ss", count, noun)
 }
 
 // LoadPrompt loads an existing prompt into the form for editing
@@ -559,9 +599,78 @@ func (f *CreateForm) LoadPrompt(prompt *models.Prompt) {
 	
 	
 	f.inputs[templateRefField].SetValue(prompt.TemplateRef)
+	f.metadata = prompt.Metadata
+	f.inputs[metadataField].SetValue(formatMetadata(prompt.Metadata))
 	f.textarea.SetValue(prompt.Content)
 }
 
+// editableMetadata reports whether a metadata value can be edited as text in
+// the form; lists and mappings can't
+func editableMetadata(value interface{}) bool {
+	switch value.(type) {
+	case map[string]interface{}, map[interface{}]interface{}, []interface{}:
+		return false
+	}
+	return true
+}
+
+// formatMetadata shows the editable metadata of a prompt as comma-separated
+// key=value pairs
+func formatMetadata(metadata map[string]interface{}) string {
+	keys := make([]string, 0, len(metadata))
+	for key, value := range metadata {
+		if editableMetadata(value) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, len(keys))
+	for i, key := range keys {
+		pairs[i] = fmt.Sprintf("%s=%v", key, metadata[key])
+	}
+	return strings.Join(pairs, ", ")
+}
+
+// parseMetadata reads the key=value pairs of the metadata field back. A
+// comma not followed by a key= is part of the value before it. Values left
+// unchanged keep their type, and values the form doesn't show are kept.
+func parseMetadata(value string, original map[string]interface{}) map[string]interface{} {
+	metadata := make(map[string]interface{})
+	for key, v := range original {
+		if !editableMetadata(v) {
+			metadata[key] = v
+		}
+	}
+
+	var keys []string
+	values := make(map[string]string)
+	for _, part := range strings.Split(value, ",") {
+		key, v, ok := strings.Cut(part, "=")
+		if key = strings.TrimSpace(key); ok && key != "" && !strings.ContainsAny(key, " \t") {
+			if _, seen := values[key]; !seen {
+				keys = append(keys, key)
+			}
+			values[key] = strings.TrimSpace(v)
+		} else if len(keys) > 0 {
+			last := keys[len(keys)-1]
+			values[last] = strings.TrimSpace(values[last] + "," + part)
+		}
+	}
+	for _, key := range keys {
+		if old, ok := original[key]; ok && fmt.Sprint(old) == values[key] {
+			metadata[key] = old
+		} else {
+			metadata[key] = values[key]
+		}
+	}
+
+	if len(metadata) == 0 {
+		return nil
+	}
+	return metadata
+}
+
 // TemplateForm handles template creation and editing
 type TemplateForm struct {
 	inputs    []textinput.Model
@@ -644,25 +753,25 @@ func NewTemplateForm() *TemplateForm {
 
 	// Name field
 	inputs[templateNameField] = textinput.New()
-	inputs[templateNameField].Placeholder = "Template Name"
+	inputs[templateNameField].Placeholder = i18n.T("template_form.name_placeholder")
 	inputs[templateNameField].CharLimit = 100
 	inputs[templateNameField].Width = 40
 
 	// Description field
 	inputs[templateDescField] = textinput.New()
-	inputs[templateDescField].Placeholder = "Brief description of the template"
+	inputs[templateDescField].Placeholder = i18n.T("template_form.description_placeholder")
 	inputs[templateDescField].CharLimit = 255
 	inputs[templateDescField].Width = 60
 
 	// Slots field
 	inputs[templateSlotsField] = textinput.New()
-	inputs[templateSlotsField].Placeholder = "name:description:required:default, ..."
+	inputs[templateSlotsField].Placeholder = i18n.T("template_form.slots_placeholder")
 	inputs[templateSlotsField].CharLimit = 500
 	inputs[templateSlotsField].Width = 60
 
 	// Content textarea
 	ta := textarea.New()
-	ta.Placeholder = "Enter template content with {{slots}}..."
+	ta.Placeholder = i18n.T("template_form.content_placeholder")
 	ta.CharLimit = 0 // Remove character limit (0 = unlimited)
 	ta.MaxHeight = 0 // Remove line limit (0 = unlimited)
 	ta.ShowLineNumbers = false // Disable line numbers to prevent double spacing
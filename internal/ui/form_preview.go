@@ -0,0 +1,127 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/glamour"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/dpshade/pocket-prompt/internal/i18n"
+)
+
+// contentPreviewMinWidth is the narrowest form that shows the content
+// preview beside the textarea; narrower forms show it in the textarea's
+// place, where typing still edits the content
+const contentPreviewMinWidth = 100
+
+// defaultContentWidth is the textarea width until the form is resized
+const defaultContentWidth = 80
+
+// contentPreview renders the content textarea as markdown while a prompt
+// is being written
+type contentPreview struct {
+	shown    bool
+	renderer *glamour.TermRenderer
+	width    int    // Width the renderer wraps at
+	source   string // Content last rendered
+	rendered string
+}
+
+// TogglePreview shows or hides the rendered preview of the content
+func (f *CreateForm) TogglePreview() {
+	f.preview.shown = !f.preview.shown
+	f.layoutContent()
+}
+
+// PreviewShown reports whether the content preview is shown
+func (f *CreateForm) PreviewShown() bool {
+	return f.preview.shown
+}
+
+// previewBeside reports whether the preview fits beside the textarea
+func (f *CreateForm) previewBeside() bool {
+	return f.contentWidth() >= contentPreviewMinWidth && !plain
+}
+
+// contentWidth is the width of the content field, shared by the textarea
+// and the preview beside it
+func (f *CreateForm) contentWidth() int {
+	if f.width == 0 {
+		return defaultContentWidth
+	}
+	return f.width
+}
+
+// layoutContent gives the textarea half the width while the preview is
+// beside it
+func (f *CreateForm) layoutContent() {
+	width := f.contentWidth()
+	if f.preview.shown && f.previewBeside() {
+		width = width/2 - 1
+	}
+	f.textarea.SetWidth(width)
+}
+
+// ContentView renders the content field: the textarea, the preview in its
+// place, or both side by side
+func (f *CreateForm) ContentView() string {
+	if !f.preview.shown {
+		return f.textarea.View()
+	}
+	if !f.previewBeside() {
+		return f.renderContentPreview(f.contentWidth())
+	}
+	width := f.contentWidth() - f.textarea.Width() - 2
+	return lipgloss.JoinHorizontal(lipgloss.Top, f.textarea.View(), "  ", f.renderContentPreview(width))
+}
+
+// renderContentPreview renders the content as markdown in a box the
+// textarea's height, scrolled along with the cursor
+func (f *CreateForm) renderContentPreview(width int) string {
+	// Leave room for the box's border and padding
+	wrap := max(10, width-3)
+	if f.preview.renderer == nil || f.preview.width != wrap {
+		renderer, err := createGlamourRenderer(wrap)
+		if err != nil {
+			return f.textarea.View()
+		}
+		f.preview.renderer = renderer
+		f.preview.width = wrap
+		f.preview.source = ""
+		f.preview.rendered = ""
+	}
+
+	content := f.textarea.Value()
+	if content != f.preview.source || f.preview.rendered == "" {
+		rendered, err := f.preview.renderer.Render(content)
+		if err != nil {
+			rendered = content
+		}
+		f.preview.source = content
+		f.preview.rendered = strings.Trim(rendered, "\n")
+	}
+
+	height := f.textarea.Height()
+	lines := strings.Split(f.preview.rendered, "\n")
+	if strings.TrimSpace(content) == "" {
+		lines = []string{lipgloss.NewStyle().Foreground(ColorTextDim).Render(i18n.T("form.nothing_to_preview"))}
+	}
+	if len(lines) > height {
+		// Keep the part being edited in view
+		offset := 0
+		if count := f.textarea.LineCount(); count > 1 {
+			offset = f.textarea.Line() * (len(lines) - height) / (count - 1)
+		}
+		offset = max(0, min(offset, len(lines)-height))
+		lines = lines[offset : offset+height]
+	}
+
+	return lipgloss.NewStyle().
+		Border(boxBorder(lipgloss.NormalBorder()), false, false, false, true).
+		BorderForeground(ColorBorder).
+		PaddingLeft(1).
+		Width(width - 1).
+		Height(height).
+		MaxHeight(height).
+		Render(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,112 @@
+package ui
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestTagCompletions(t *testing.T) {
+	available := []string{"ai", "ai-tools", "ai/agents", "coding", "Writing"}
+
+	tests := []struct {
+		value string
+		want  []string
+	}{
+		{"", nil},
+		{"ai", []string{"ai-tools", "ai/agents"}},
+		{"coding, a", []string{"coding, ai", "coding, ai-tools", "coding, ai/agents"}},
+		{"ai, ai-", []string{"ai, ai-tools"}},
+		{"ai-tools,AI", []string{"ai-tools,ai/agents"}},
+		{"coding, w", []string{"coding, Writing"}},
+		{"coding, ", nil},
+		{"zzz", nil},
+	}
+
+	for _, tt := range tests {
+		if got := tagCompletions(tt.value, available); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("tagCompletions(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestCreateFormTagSuggestionHint(t *testing.T) {
+	form := NewCreateForm()
+	form.SetAvailableTags([]string{"coding", "ai", "ai-tools"})
+	form.focused = tagsField
+	form.inputs[tagsField].SetValue("coding, a")
+	form.updateTagAutocomplete()
+
+	want := "Matching tags: ai, ai-tools (→ to accept, ctrl+n/ctrl+p to cycle)"
+	if hint := form.TagSuggestionHint(); hint != want {
+		t.Errorf("Unexpected hint %q", hint)
+	}
+}
+
+func TestParseMetadata(t *testing.T) {
+	original := map[string]interface{}{
+		"rating":  3,
+		"author":  "jane",
+		"sources": []interface{}{"a", "b"},
+	}
+	if got := formatMetadata(original); got != "author=jane, rating=3" {
+		t.Errorf("formatMetadata() = %q, lists should be left out", got)
+	}
+
+	got := parseMetadata("author=sam, rating=3, note=short, sweet, tone = dry", original)
+	want := map[string]interface{}{
+		"author":  "sam",
+		"rating":  3,
+		"note":    "short, sweet",
+		"tone":    "dry",
+		"sources": []interface{}{"a", "b"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseMetadata() = %v, want %v", got, want)
+	}
+
+	if got := parseMetadata("", nil); got != nil {
+		t.Errorf("parseMetadata(\"\") = %v, want nil", got)
+	}
+}
+
+func TestCreateFormContentPreview(t *testing.T) {
+	form := NewCreateForm()
+	form.textarea.SetValue("# Heading\n\nSome **bold** text")
+	form.Resize(140, 40)
+
+	form.Update(tea.KeyMsg{Type: tea.KeyCtrlO})
+	if !form.PreviewShown() {
+		t.Fatal("Expected ctrl+o to show the preview")
+	}
+	if form.textarea.Width() >= 130 {
+		t.Errorf("Expected the textarea to share the width with the preview, got %d", form.textarea.Width())
+	}
+	if view := form.ContentView(); strings.Count(view, "Heading") != 2 {
+		t.Errorf("Expected the preview beside the textarea, got:\n%s", view)
+	}
+
+	// Narrow forms show the preview in the textarea's place
+	form.Resize(60, 40)
+	if view := form.ContentView(); form.previewBeside() || strings.Count(view, "Heading") != 1 {
+		t.Errorf("Expected the preview to replace the textarea, got:\n%s", view)
+	}
+
+	form.TogglePreview()
+	if form.PreviewShown() || form.textarea.Width() < 40 {
+		t.Errorf("Expected the textarea back at full width, got %d", form.textarea.Width())
+	}
+}
+
+func TestCreateFormLengthSummary(t *testing.T) {
+	form := NewCreateForm()
+	if got := form.LengthSummary(); got != "0 words • 0 characters • ~0 tokens" {
+		t.Errorf("Unexpected summary for an empty form %q", got)
+	}
+	form.textarea.SetValue("Review this code")
+	if got := form.LengthSummary(); got != "3 words • 16 characters • ~4 tokens" {
+		t.Errorf("Unexpected summary %q", got)
+	}
+}
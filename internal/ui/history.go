@@ -0,0 +1,234 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/dpshade/pocket-prompt/internal/i18n"
+	"github.com/dpshade/pocket-prompt/internal/models"
+)
+
+// maxHistory bounds how many screens back navigation remembers
+const maxHistory = 50
+
+// screen is a view and what it shows, kept in the navigation history.
+// Menus are rebuilt when they are returned to so they list current data,
+// while forms are kept as they were, unsaved changes included.
+type screen struct {
+	mode         ViewMode
+	prompt       *models.Prompt
+	template     *models.Template
+	createForm   *CreateForm
+	templateForm *TemplateForm
+	editMode     bool
+	selected     int // The highlighted option of a menu
+}
+
+// currentScreen captures the view on screen for the history
+func (m Model) currentScreen() screen {
+	s := screen{
+		mode:         m.viewMode,
+		prompt:       m.selectedPrompt,
+		template:     m.selectedTemplate,
+		createForm:   m.createForm,
+		templateForm: m.templateForm,
+		editMode:     m.editMode,
+	}
+	if m.selectForm != nil {
+		s.selected = m.selectForm.selected
+	}
+	return s
+}
+
+// navigate switches to a view, remembering the current one for back
+// navigation. Opening a view forgets the screens gone back from.
+func (m *Model) navigate(mode ViewMode) {
+	m.history = append(m.history, m.currentScreen())
+	if len(m.history) > maxHistory {
+		m.history = m.history[len(m.history)-maxHistory:]
+	}
+	m.future = nil
+	m.leaveSavedSearches(mode)
+	m.viewMode = mode
+}
+
+// goBack returns to the previous screen, keeping the current one for
+// forward navigation. It reports false when there is nothing to go back to.
+func (m *Model) goBack() (tea.Cmd, bool) {
+	current := m.currentScreen()
+	cmd, ok := m.popScreen(&m.history)
+	if ok {
+		m.future = append(m.future, current)
+	}
+	return cmd, ok
+}
+
+// goForward returns to the screen last gone back from
+func (m *Model) goForward() (tea.Cmd, bool) {
+	current := m.currentScreen()
+	cmd, ok := m.popScreen(&m.future)
+	if ok {
+		m.history = append(m.history, current)
+	}
+	return cmd, ok
+}
+
+// closeScreen leaves a screen whose work is done, such as a saved form, for
+// the one it was opened from. The closed screen can't be gone forward to,
+// and neither can the menus that led to it.
+func (m *Model) closeScreen() tea.Cmd {
+	m.future = nil
+	for {
+		cmd, ok := m.popScreen(&m.history)
+		if !ok {
+			m.restoreScreen(screen{mode: ViewLibrary})
+			return nil
+		}
+		if m.viewMode != ViewCreateMenu && m.viewMode != ViewTemplateList {
+			return cmd
+		}
+	}
+}
+
+// popScreen restores the last screen of a history stack, skipping screens
+// of prompts and templates deleted since
+func (m *Model) popScreen(stack *[]screen) (tea.Cmd, bool) {
+	for len(*stack) > 0 {
+		s := (*stack)[len(*stack)-1]
+		*stack = (*stack)[:len(*stack)-1]
+		if cmd, ok := m.restoreScreen(s); ok {
+			return cmd, true
+		}
+	}
+	return nil, false
+}
+
+// restoreScreen shows a screen from the history again, reloading what it
+// shows. It reports false when the screen can't be shown anymore.
+func (m *Model) restoreScreen(s screen) (tea.Cmd, bool) {
+	var savedSearches []models.SavedSearch
+	switch s.mode {
+	case ViewPromptDetail:
+		if s.prompt == nil {
+			return nil, false
+		}
+		prompt, err := m.service.GetPrompt(s.prompt.ID)
+		if err != nil {
+			return nil, false
+		}
+		s.prompt = prompt
+	case ViewTemplateDetail:
+		if s.template == nil {
+			return nil, false
+		}
+		template, err := m.service.GetTemplate(s.template.ID)
+		if err != nil {
+			return nil, false
+		}
+		s.template = template
+	case ViewEditPrompt, ViewCreateFromScratch:
+		if s.createForm == nil {
+			return nil, false
+		}
+	case ViewEditTemplate:
+		if s.templateForm == nil {
+			return nil, false
+		}
+	case ViewSavedSearches:
+		searches, err := m.service.ListSavedSearches()
+		if err != nil || len(searches) == 0 {
+			return nil, false
+		}
+		savedSearches = searches
+	}
+
+	m.leaveSavedSearches(s.mode)
+	m.viewMode = s.mode
+	m.selectedPrompt = s.prompt
+	m.selectedTemplate = s.template
+	m.createForm = s.createForm
+	m.templateForm = s.templateForm
+	m.editMode = s.editMode
+	m.selectForm = nil
+	m.renderedContent = ""
+	m.renderedContentJSON = ""
+
+	var cmd tea.Cmd
+	switch s.mode {
+	case ViewPromptDetail:
+		if err := m.renderPreview(); err != nil {
+			m.err = err
+		}
+	case ViewCreateMenu:
+		m.selectForm = NewSelectForm(createMenuOptions())
+	case ViewTemplateList:
+		m.selectForm = NewSelectForm(m.templateListOptions())
+	case ViewTemplateManagement:
+		m.selectForm = NewSelectForm(m.templateManagementOptions())
+	case ViewSavedSearches:
+		cmd = m.showSavedSearches(savedSearches)
+	}
+	if m.selectForm != nil {
+		m.selectForm.selected = min(s.selected, max(0, len(m.selectForm.options)-1))
+	}
+	return cmd, true
+}
+
+// leaveSavedSearches stops counting saved search results once the saved
+// searches screen is left for another view
+func (m *Model) leaveSavedSearches(next ViewMode) {
+	if m.viewMode == ViewSavedSearches && next != ViewSavedSearches {
+		m.savedSearches = nil
+		m.savedSearchCountGeneration++
+	}
+}
+
+// showSavedSearches lists saved searches right away and returns the
+// command counting their results in the background
+func (m *Model) showSavedSearches(searches []models.SavedSearch) tea.Cmd {
+	m.savedSearchCounts = make(map[string]int)
+	m.savedSearchCountGeneration++
+	m.selectForm = NewSelectForm(m.savedSearchOptions(searches))
+	m.savedSearches = searches
+	return countSavedSearchesCmd(m.service, m.savedSearchCountGeneration, searches)
+}
+
+// createMenuOptions are the ways to create a prompt
+func createMenuOptions() []SelectOption {
+	return []SelectOption{
+		{
+			Label:       i18n.T("create.scratch"),
+			Description: i18n.T("create.scratch_description"),
+			Value:       "scratch",
+		},
+		{
+			Label:       i18n.T("create.template"),
+			Description: i18n.T("create.template_description"),
+			Value:       "template",
+		},
+	}
+}
+
+// templateListOptions are the templates a prompt can be created from
+func (m Model) templateListOptions() []SelectOption {
+	options := make([]SelectOption, len(m.templates))
+	for i, template := range m.templates {
+		options[i] = SelectOption{
+			Label:       template.Name,
+			Description: template.Description,
+			Value:       template,
+		}
+	}
+	return options
+}
+
+// templateManagementOptions offer a new template followed by the existing
+// ones
+func (m Model) templateManagementOptions() []SelectOption {
+	options := []SelectOption{
+		{
+			Label:       i18n.T("create.new_template"),
+			Description: i18n.T("create.new_template_description"),
+			Value:       "new",
+		},
+	}
+	return append(options, m.templateListOptions()...)
+}
@@ -0,0 +1,49 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/dpshade/pocket-prompt/internal/i18n"
+)
+
+// renderIssuesModal lists files that could not be loaded, with the exact
+// line and field at fault, so broken prompts don't silently disappear
+func (m Model) renderIssuesModal() string {
+	width := min(90, m.width-4)
+
+	modalStyle := lipgloss.NewStyle().
+		Border(boxBorder(lipgloss.RoundedBorder())).
+		Padding(1, 2).
+		Width(width)
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		MarginBottom(1)
+
+	pathStyle := lipgloss.NewStyle().
+		Bold(true)
+
+	helpStyle := lipgloss.NewStyle().
+		Foreground(ColorTextDim).
+		Italic(true).
+		MarginTop(1)
+
+	content := []string{titleStyle.Render(i18n.T("issues.title"))}
+	for _, issue := range m.loadIssues {
+		location := issue.FilePath
+		if issue.Line > 0 {
+			location = fmt.Sprintf("%s:%d", location, issue.Line)
+		}
+		message := issue.Message
+		if issue.Field != "" {
+			message = issue.Field + ": " + message
+		}
+		content = append(content, pathStyle.Render(location), "  "+StyleWarning.Render(message))
+	}
+	content = append(content, helpStyle.Render(i18n.T("issues.help")))
+
+	modal := modalStyle.Render(lipgloss.JoinVertical(lipgloss.Left, content...))
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, modal)
+}
@@ -0,0 +1,59 @@
+package ui
+
+import (
+	"context"
+
+	"github.com/dpshade/pocket-prompt/internal/config"
+	"github.com/dpshade/pocket-prompt/internal/models"
+	"github.com/dpshade/pocket-prompt/internal/service"
+	"github.com/dpshade/pocket-prompt/internal/storage"
+)
+
+// Library is the part of the service the TUI and the prompt picker use.
+// *service.Service implements it over any storage backend.
+type Library interface {
+	CheckSlots(prompt *models.Prompt) ([]string, error)
+	CreatePrompt(prompt *models.Prompt) error
+	DeletePrompt(id string) error
+	DeletePrompts(prompts []*models.Prompt) ([]*models.Prompt, error)
+	DeleteSavedSearch(name string) error
+	DeleteSnippet(name string) error
+	DuplicatePrompt(id, newID string) (*models.Prompt, error)
+	FindDuplicates(threshold float64) ([]service.DuplicateGroup, error)
+	GetAllTags() ([]string, error)
+	GetGitSyncStatus() (string, error)
+	GetPrompt(id string) (*models.Prompt, error)
+	GetTagCounts() (map[string]int, error)
+	GetTemplate(id string) (*models.Template, error)
+	ListConflicts() ([]storage.ConflictCopy, error)
+	ListPrompts() ([]*models.Prompt, error)
+	ListSavedSearches() ([]models.SavedSearch, error)
+	ListSnippets() ([]*models.Snippet, error)
+	ListTemplates() ([]*models.Template, error)
+	LoadAttachment(ctx context.Context, name string) (string, error)
+	LoadSecret(ctx context.Context, name string) (string, error)
+	LoadSnippet(ctx context.Context, name string) (string, error)
+	MergeDuplicates(group service.DuplicateGroup) (*models.Prompt, error)
+	PinnedSearches() ([]models.SavedSearch, error)
+	PromptSource(prompt *models.Prompt) ([]byte, error)
+	PromptVariables(prompt *models.Prompt, preset string) ([]models.Slot, error)
+	RecordUsage(id string) error
+	ReloadPrompts() error
+	ResolveConflict(conflict storage.ConflictCopy, resolution string) (string, error)
+	SaveBooleanSearch(search models.SavedSearch) error
+	SavePrompt(prompt *models.Prompt) error
+	SaveTemplate(template *models.Template) error
+	SaveUIConfig(ui config.UIConfig) error
+	SearchPrompts(query string) ([]*models.Prompt, error)
+	SearchPromptsByBooleanExpression(expression *models.BooleanExpression) ([]*models.Prompt, error)
+	SearchPromptsByBooleanExpressionInScopeContext(ctx context.Context, expression *models.BooleanExpression, scope service.ArchiveScope) ([]*models.Prompt, error)
+	SetRating(id string, rating int) (*models.Prompt, error)
+	SetSavedSearchPinned(name string, pinned bool) error
+	SyncChanges(message string) error
+	TagColors() map[string]string
+	UIConfig() config.UIConfig
+	UpdatePromptFromSource(original *models.Prompt, data []byte) (*models.Prompt, error)
+	ValidationIssues() []storage.ValidationIssue
+}
+
+var _ Library = (*service.Service)(nil)
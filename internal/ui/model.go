@@ -1,26 +1,54 @@
 package ui
 
 import (
+	"context"
 	"fmt"
+	"log/slog"
 	"os"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/glamour"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/muesli/termenv"
 	"github.com/dpshade/pocket-prompt/internal/clipboard"
+	"github.com/dpshade/pocket-prompt/internal/i18n"
+	"github.com/dpshade/pocket-prompt/internal/logging"
 	"github.com/dpshade/pocket-prompt/internal/models"
 	"github.com/dpshade/pocket-prompt/internal/renderer"
 	"github.com/dpshade/pocket-prompt/internal/service"
+	"github.com/dpshade/pocket-prompt/internal/startup"
+	"github.com/dpshade/pocket-prompt/internal/storage"
 )
 
 // createGlamourRenderer creates a glamour renderer with improved contrast handling
 func createGlamourRenderer(wordWrap int) (*glamour.TermRenderer, error) {
+	defer startup.Phase("glamour init")()
+
+	// Plain mode keeps to ASCII, without color
+	if plain {
+		return glamour.NewTermRenderer(
+			glamour.WithStandardStyle("ascii"),
+			glamour.WithWordWrap(wordWrap),
+		)
+	}
+
+	// The color profile honors NO_COLOR, which wins over any style
+	profile := lipgloss.ColorProfile()
+	if profile == termenv.Ascii {
+		return glamour.NewTermRenderer(
+			glamour.WithStandardStyle("notty"),
+			glamour.WithWordWrap(wordWrap),
+		)
+	}
+
 	// Check for environment variable override first
 	if style := os.Getenv("GLAMOUR_STYLE"); style != "" {
 		return glamour.NewTermRenderer(
@@ -28,36 +56,16 @@ func createGlamourRenderer(wordWrap int) (*glamour.TermRenderer, error) {
 			glamour.WithWordWrap(wordWrap),
 		)
 	}
-
-	// Detect terminal capabilities and background
-	profile := termenv.ColorProfile()
-	hasDarkBg := lipgloss.HasDarkBackground()
 	
-	// Choose appropriate style based on background detection and capabilities
+	// Choose the style for the background the palette was picked for
 	var styleOption glamour.TermRendererOption
 	
-	if hasDarkBg {
-		// Dark background detected - use high contrast light text styles
-		switch profile {
-		case termenv.TrueColor:
-			// Use "dark" style for best contrast on dark terminals
-			styleOption = glamour.WithStandardStyle("dark")
-		case termenv.ANSI256:
-			styleOption = glamour.WithStandardStyle("dark")
-		default:
-			// Fallback to auto-style for limited color terminals
-			styleOption = glamour.WithAutoStyle()
-		}
+	if darkTheme {
+		// Dark background - use high contrast light text styles
+		styleOption = glamour.WithStandardStyle("dark")
 	} else {
-		// Light background detected - use dark text styles
-		switch profile {
-		case termenv.TrueColor:
-			styleOption = glamour.WithStandardStyle("light")
-		case termenv.ANSI256:
-			styleOption = glamour.WithStandardStyle("light")
-		default:
-			styleOption = glamour.WithAutoStyle()
-		}
+		// Light background - use dark text styles
+		styleOption = glamour.WithStandardStyle("light")
 	}
 
 	return glamour.NewTermRenderer(
@@ -67,10 +75,16 @@ func createGlamourRenderer(wordWrap int) (*glamour.TermRenderer, error) {
 	)
 }
 
+// defaultGlamourWidth is the width prompts are rendered at until the
+// window size is known
+const defaultGlamourWidth = 60
+
 // Commands for async operations
 type loadCompleteMsg struct {
 	prompts   []*models.Prompt
 	templates []*models.Template
+	issues    []storage.ValidationIssue
+	conflicts []storage.ConflictCopy
 	err       error
 }
 
@@ -80,7 +94,7 @@ type gitSyncStatusMsg struct {
 }
 
 // loadPromptsCmd loads prompts and templates synchronously (should be fast with cache)
-func loadPromptsCmd(svc *service.Service) tea.Cmd {
+func loadPromptsCmd(svc Library) tea.Cmd {
 	return func() tea.Msg {
 		// Load prompts (should be fast with cache)
 		prompts, promptErr := svc.ListPrompts()
@@ -102,26 +116,76 @@ func loadPromptsCmd(svc *service.Service) tea.Cmd {
 			err = templateErr
 		}
 		
+		conflicts, _ := svc.ListConflicts()
+
 		return loadCompleteMsg{
 			prompts:   prompts,
 			templates: templates,
+			issues:    svc.ValidationIssues(),
+			conflicts: conflicts,
 			err:       err,
 		}
 	}
 }
 
-
-// gitSyncStatusCmd gets the current git sync status (disabled for performance)
-func gitSyncStatusCmd(svc *service.Service) tea.Cmd {
+// reloadPromptsCmd reads the library from disk again, so files fixed or
+// broken outside the TUI are revalidated
+func reloadPromptsCmd(svc Library) tea.Cmd {
+	load := loadPromptsCmd(svc)
 	return func() tea.Msg {
-		// Skip git operations entirely for startup performance
-		return gitSyncStatusMsg{
-			status: "Git sync disabled for startup performance",
-			err:    nil,
+		err := svc.ReloadPrompts()
+		msg := load().(loadCompleteMsg)
+		if msg.err == nil {
+			msg.err = err
 		}
+		return msg
+	}
+}
+
+// gitStatusInterval is how often the library view refreshes the git status
+const gitStatusInterval = 30 * time.Second
+
+// gitStatusTickMsg triggers a periodic git status refresh
+type gitStatusTickMsg time.Time
+
+// gitSyncStatusCmd gets the current git sync status in the background
+func gitSyncStatusCmd(svc Library) tea.Cmd {
+	return func() tea.Msg {
+		status, err := svc.GetGitSyncStatus()
+		return gitSyncStatusMsg{status: status, err: err}
+	}
+}
+
+// gitStatusTickCmd schedules the next periodic git status refresh
+func gitStatusTickCmd() tea.Cmd {
+	return tea.Tick(gitStatusInterval, func(t time.Time) tea.Msg {
+		return gitStatusTickMsg(t)
+	})
+}
+
+// gitSyncDoneMsg reports the result of a manual sync
+type gitSyncDoneMsg struct {
+	err error
+}
+
+// gitSyncCmd commits and pushes the library in the background
+func gitSyncCmd(svc Library) tea.Cmd {
+	return func() tea.Msg {
+		return gitSyncDoneMsg{err: svc.SyncChanges("Manual sync from TUI")}
 	}
 }
 
+// refreshGitStatus returns a command fetching the git status, or nil while
+// a fetch is already running
+func (m *Model) refreshGitStatus() tea.Cmd {
+	m.gitStatusStale = false
+	if m.gitStatusPending {
+		return nil
+	}
+	m.gitStatusPending = true
+	return gitSyncStatusCmd(m.service)
+}
+
 // ViewMode represents the current view in the TUI
 type ViewMode int
 
@@ -141,12 +205,13 @@ const (
 
 // Model represents the TUI application state
 type Model struct {
-	service  *service.Service
+	service  Library
 	viewMode ViewMode
 
 	// UI components
 	promptList list.Model
 	viewport   viewport.Model
+	preview    previewPane // The selected prompt beside the library list
 	help       help.Model
 	keys       KeyMap
 
@@ -163,12 +228,12 @@ type Model struct {
 	templateForm   *TemplateForm
 	selectForm     *SelectForm
 	editMode       bool
-	deleteConfirm  bool
 
 	// Rendered content
 	renderedContent     string
 	renderedContentJSON string
-	glamourRenderer     *glamour.TermRenderer
+	glamourRenderer     *glamour.TermRenderer // Built when a prompt is first shown
+	glamourWidth        int                   // Width glamourRenderer wraps at
 
 	// Window dimensions
 	width  int
@@ -187,15 +252,51 @@ type Model struct {
 	showExpandedHelp bool // Whether to show expanded help in current view
 	helpViewport   viewport.Model // Viewport for scrollable help modal
 	modalContent   string // Plain text content for copying
+	showIssues     bool   // Whether the load problems modal is open
+	showConflicts  bool   // Whether the sync conflicts modal is open
+	showDuplicates bool   // Whether the duplicate prompts report is open
+	showSnippets   bool   // Whether the snippet library is open
+	confirm        *confirmDialog // Asks before a delete or overwrite
+	tagChooser     *tagChooser    // Picks the tag to filter the library by
+	variableFill   *variableFill  // Asks for variables before copying
 	
-	// Git sync state
-	gitSyncStatus string
+	// Files that failed validation during the last load
+	loadIssues []storage.ValidationIssue
+	
+	// Conflict copies left by file sync services
+	conflicts      []storage.ConflictCopy
+	conflictCursor int
+
+	// Groups of prompts with duplicate content, found on request
+	duplicates      []service.DuplicateGroup
+	duplicateCursor int
+
+	// The snippet library, loaded when it is opened
+	snippets             []*models.Snippet
+	snippetCursor        int
+	
+	// Git sync state, fetched in the background
+	gitSyncStatus    string
+	gitStatusPending bool // A status fetch is running
+	gitStatusStale   bool // The library changed since the last fetch
+	syncing          bool // A manual sync is running
+	syncSpinner      spinner.Model
 
 	// Boolean search state
 	booleanSearchModal *BooleanSearchModal
 	currentExpression  *models.BooleanExpression
+	tagFilter          string // Tag the library is narrowed to
 	savedSearches      []models.SavedSearch
 	saveSearchModal    *SaveSearchModal
+
+	// Result counts of saved searches, counted in the background each time
+	// the saved searches screen opens
+	savedSearchCounts          map[string]int
+	savedSearchCountGeneration int
+
+	// Screens to go back and forward to
+	history []screen
+	future  []screen
 }
 
 // KeyMap defines all key bindings
@@ -206,20 +307,36 @@ type KeyMap struct {
 	Right  key.Binding
 	Enter  key.Binding
 	Back   key.Binding
+	Forward key.Binding
 	Quit   key.Binding
 	Help   key.Binding
 	ExpandHelp key.Binding
 	Search key.Binding
 	Copy     key.Binding
 	CopyJSON key.Binding
+	CopyRich key.Binding
+	CopyFilled key.Binding
+	OpenEditor key.Binding
 	Export   key.Binding
 	New      key.Binding
 	Edit     key.Binding
 	Delete   key.Binding
+	Duplicate key.Binding
+	Sync      key.Binding
 	Templates key.Binding
 	GHSyncInfo key.Binding
 	BooleanSearch key.Binding
 	SavedSearches key.Binding
+	PinSearch     key.Binding
+	Issues        key.Binding
+	Conflicts     key.Binding
+	Duplicates    key.Binding
+	Snippets      key.Binding
+	Rate          key.Binding
+	TagFilter     key.Binding
+	TogglePreview key.Binding
+	ShrinkPreview key.Binding
+	GrowPreview   key.Binding
 }
 
 // ShortHelp returns keybindings to show in the mini help view
@@ -231,9 +348,9 @@ func (k KeyMap) ShortHelp() []key.Binding {
 func (k KeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
 		{k.Up, k.Down, k.Left, k.Right},
-		{k.Enter, k.Back, k.Search, k.New},
-		{k.Edit, k.Delete, k.Templates, k.Copy},
-		{k.CopyJSON, k.Export, k.BooleanSearch, k.SavedSearches},
+		{k.Enter, k.Back, k.Forward, k.Search, k.New},
+		{k.Edit, k.Delete, k.Duplicate, k.Templates, k.Copy},
+		{k.CopyJSON, k.CopyRich, k.Export, k.BooleanSearch, k.SavedSearches, k.Sync},
 		{k.Help, k.Quit},
 	}
 }
@@ -263,6 +380,10 @@ var keys = KeyMap{
 		key.WithKeys("esc"),
 		key.WithHelp("Esc", "back"),
 	),
+	Forward: key.NewBinding(
+		key.WithKeys("alt+right", "]"),
+		key.WithHelp("]", "forward"),
+	),
 	Quit: key.NewBinding(
 		key.WithKeys("q", "ctrl+c"),
 		key.WithHelp("q", "quit"),
@@ -287,6 +408,14 @@ var keys = KeyMap{
 		key.WithKeys("y"),
 		key.WithHelp("y", "copy as JSON"),
 	),
+	CopyRich: key.NewBinding(
+		key.WithKeys("r"),
+		key.WithHelp("r", "copy as rich text"),
+	),
+	CopyFilled: key.NewBinding(
+		key.WithKeys("C"),
+		key.WithHelp("C", "fill in variables and copy"),
+	),
 	Export: key.NewBinding(
 		key.WithKeys("x"),
 		key.WithHelp("x", "export"),
@@ -299,10 +428,22 @@ var keys = KeyMap{
 		key.WithKeys("e"),
 		key.WithHelp("e", "edit"),
 	),
+	OpenEditor: key.NewBinding(
+		key.WithKeys("E"),
+		key.WithHelp("E", "open in $EDITOR"),
+	),
 	Delete: key.NewBinding(
 		key.WithKeys("d"),
 		key.WithHelp("d", "delete"),
 	),
+	Duplicate: key.NewBinding(
+		key.WithKeys("D"),
+		key.WithHelp("D", "duplicate"),
+	),
+	Sync: key.NewBinding(
+		key.WithKeys("S"),
+		key.WithHelp("S", "sync with git"),
+	),
 	Templates: key.NewBinding(
 		key.WithKeys("t"),
 		key.WithHelp("t", "templates"),
@@ -319,12 +460,54 @@ var keys = KeyMap{
 		key.WithKeys("f"),
 		key.WithHelp("f", "saved searches"),
 	),
+	PinSearch: key.NewBinding(
+		key.WithKeys("p"),
+		key.WithHelp("p", "pin saved search to library"),
+	),
+	Issues: key.NewBinding(
+		key.WithKeys("!"),
+		key.WithHelp("!", "show files that failed to load"),
+	),
+	Conflicts: key.NewBinding(
+		key.WithKeys("C"),
+		key.WithHelp("C", "resolve sync conflicts"),
+	),
+	Duplicates: key.NewBinding(
+		key.WithKeys("="),
+		key.WithHelp("=", "find duplicate prompts"),
+	),
+	Snippets: key.NewBinding(
+		key.WithKeys("s"),
+		key.WithHelp("s", "snippets"),
+	),
+	Rate: key.NewBinding(
+		key.WithKeys("0", "1", "2", "3", "4", "5"),
+		key.WithHelp("1-5", "rate prompt (0 clears)"),
+	),
+	TagFilter: key.NewBinding(
+		key.WithKeys("#"),
+		key.WithHelp("#", "filter by tag"),
+	),
+	TogglePreview: key.NewBinding(
+		key.WithKeys("v"),
+		key.WithHelp("v", "toggle preview pane"),
+	),
+	ShrinkPreview: key.NewBinding(
+		key.WithKeys("<"),
+		key.WithHelp("<", "narrow preview pane"),
+	),
+	GrowPreview: key.NewBinding(
+		key.WithKeys(">"),
+		key.WithHelp(">", "widen preview pane"),
+	),
 }
 
 // NewModel creates a new TUI model
-func NewModel(svc *service.Service) (*Model, error) {
+func NewModel(svc Library) (*Model, error) {
 	// Initialize adaptive colors based on terminal background
-	initializeColors()
+	endColors := startup.Phase("color detect")
+	initializeColors(svc.UIConfig().Theme, lipgloss.DefaultRenderer())
+	endColors()
 	
 	// Start with empty data for immediate UI responsiveness
 	// Data will be loaded asynchronously
@@ -338,7 +521,7 @@ func NewModel(svc *service.Service) (*Model, error) {
 	}
 
 	// Create list with loading placeholder
-	l := list.New(items, list.NewDefaultDelegate(), 80, 20) // Default size, will be updated on first WindowSizeMsg
+	l := list.New(items, newPromptDelegate(svc.TagColors()), 80, 20) // Default size, will be updated on first WindowSizeMsg
 	l.Title = ""  // We'll handle title in the view
 	l.SetShowStatusBar(false) // We'll handle status in our custom view
 	l.SetFilteringEnabled(true) // Enable filtering from start
@@ -361,38 +544,52 @@ func NewModel(svc *service.Service) (*Model, error) {
 	helpVp := viewport.New(56, 23) // Smaller size for help modal
 	helpVp.Style = lipgloss.NewStyle()
 
-	// Create glamour renderer for markdown with improved contrast
-	// Start with a conservative default width for better wrapping
-	renderer, err := createGlamourRenderer(60)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create glamour renderer: %w", err)
-	}
-
 	return &Model{
 		service:         svc,
 		viewMode:        ViewLibrary,
 		promptList:      l,
 		viewport:        vp,
+		preview:         newPreviewPane(svc.UIConfig()),
 		helpViewport:    helpVp,
 		help:            help.New(),
 		keys:            keys,
 		prompts:         prompts,
 		templates:       templates,
 		loading:         true, // Start in loading state
-		glamourRenderer: renderer,
+		glamourWidth:    defaultGlamourWidth,
+		syncSpinner:     spinner.New(spinner.WithSpinner(spinner.Dot)),
 	}, nil
 }
 
 // Init initializes the model
 func (m Model) Init() tea.Cmd {
 	// Simple approach: just load data synchronously (cache should make it fast)
-	// Skip git entirely for startup
+	// The git status is fetched in the background once the prompts are shown
 	return loadPromptsCmd(m.service)
 }
 
 // tickMsg is sent to clear the status message
 type tickMsg time.Time
 
+// addSlotWarnings adds the problems with a saved prompt's template slots to
+// the status message
+func (m *Model) addSlotWarnings(prompt *models.Prompt) {
+	if warnings, _ := m.service.CheckSlots(prompt); len(warnings) > 0 {
+		m.statusMsg += " Warning: " + strings.Join(warnings, "; ")
+		m.statusTimeout = 4
+	}
+}
+
+// setError shows a failed action in the status bar and logs the error in
+// full, which in debug mode includes each error it wraps
+func (m *Model) setError(action string, err error) {
+	slog.Error(action, logging.Err(err))
+	m.statusMsg = action + ": " + logging.ShortError(err)
+	if logging.Debug() {
+		m.statusMsg += " (details in debug log)"
+	}
+}
+
 // clearStatusCmd returns a command that clears the status message after a delay
 func clearStatusCmd() tea.Cmd {
 	return tea.Tick(time.Second, func(t time.Time) tea.Msg {
@@ -400,8 +597,17 @@ func clearStatusCmd() tea.Cmd {
 	})
 }
 
-// Update handles messages and updates the model
+// Update handles messages and updates the model. When the library changed,
+// it also refreshes the git status in the background.
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	m, cmd := m.update(msg)
+	if m.gitStatusStale {
+		return m, tea.Batch(cmd, m.refreshGitStatus())
+	}
+	return m, cmd
+}
+
+func (m Model) update(msg tea.Msg) (Model, tea.Cmd) {
 	var cmds []tea.Cmd
 
 	switch msg := msg.(type) {
@@ -419,21 +625,59 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.loading = false
 		m.prompts = msg.prompts
 		m.templates = msg.templates
+		m.loadIssues = msg.issues
+		m.conflicts = msg.conflicts
+		if len(m.loadIssues) == 0 {
+			m.showIssues = false
+		}
+		models.SortByNamespace(m.prompts)
 		
 		// Update prompt list with loaded data
-		items := make([]list.Item, len(m.prompts))
-		for i, p := range m.prompts {
-			items[i] = p
-		}
-		m.promptList.SetItems(items)
+		m.setLibraryItems(m.prompts)
+		m.preview.promptID = ""
+		m.updateLibraryPreview()
 		
 		if msg.err != nil {
-			m.statusMsg = fmt.Sprintf("Warning: %v", msg.err)
+			m.setError(i18n.T("error.warning"), msg.err)
 			m.statusTimeout = 100 // Show for ~5 seconds
 		}
+		
+		// The list is on screen now, so fetch the git status without delaying it
+		cmds = append(cmds, m.refreshGitStatus(), gitStatusTickCmd())
+	case savedSearchCountMsg:
+		return m, m.updateSavedSearchCount(msg)
+	case booleanSearchTickMsg, booleanSearchResultMsg:
+		if m.booleanSearchModal != nil {
+			return m, m.booleanSearchModal.Update(msg)
+		}
+	case editorFinishedMsg:
+		return m.finishExternalEdit(msg)
 	case gitSyncStatusMsg:
-		// Update git sync status (skip to avoid any blocking)
-		m.gitSyncStatus = "Git sync disabled for startup performance"
+		m.gitStatusPending = false
+		if msg.err != nil {
+			m.gitSyncStatus = i18n.T("status.git_unknown")
+		} else {
+			m.gitSyncStatus = msg.status
+		}
+	case gitSyncDoneMsg:
+		m.syncing = false
+		if msg.err != nil {
+			m.setError(i18n.T("error.sync"), msg.err)
+			m.statusTimeout = 5
+		} else {
+			m.statusMsg = i18n.T("status.synced")
+			m.statusTimeout = 3
+		}
+		m.gitStatusStale = true
+		cmds = append(cmds, clearStatusCmd())
+	case spinner.TickMsg:
+		if m.syncing {
+			var cmd tea.Cmd
+			m.syncSpinner, cmd = m.syncSpinner.Update(msg)
+			cmds = append(cmds, cmd)
+		}
+	case gitStatusTickMsg:
+		cmds = append(cmds, m.refreshGitStatus(), gitStatusTickCmd())
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
@@ -449,8 +693,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Update component sizes based on current view
 		switch m.viewMode {
 		case ViewLibrary:
-			// Library takes available height with consistent reservations
-			m.promptList.SetSize(msg.Width, availableHeight)
+			// Library takes available height with consistent reservations,
+			// shared with the preview pane when it is shown
+			m.resizeLibrary()
 		case ViewPromptDetail:
 			// Viewport takes most of available height, account for scroll indicators and container
 			// Be more conservative with width to ensure proper wrapping
@@ -460,11 +705,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			m.viewport.Width = viewportWidth
 			m.viewport.Height = availableHeight + 1 // Reserve space for scroll indicators
-			// Also update the glamour renderer immediately with the new width
-			if viewportWidth > 0 {
-				if renderer, err := createGlamourRenderer(viewportWidth); err == nil {
-					m.glamourRenderer = renderer
-				}
+			// Rebuild the glamour renderer for the new width when next needed
+			if viewportWidth != m.glamourWidth {
+				m.glamourWidth = viewportWidth
+				m.glamourRenderer = nil
 			}
 		case ViewCreateFromScratch, ViewCreateFromTemplate, ViewEditPrompt:
 			if m.createForm != nil {
@@ -496,6 +740,19 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 	case tea.KeyMsg:
+		// A confirmation dialog takes every key until it is answered
+		if m.confirm != nil {
+			return m.updateConfirm(msg)
+		}
+
+		if m.tagChooser != nil {
+			return m.updateTagChooser(msg)
+		}
+
+		if m.variableFill != nil {
+			return m.updateVariableFill(msg)
+		}
+
 		// Handle save search modal first (highest priority)
 		if m.saveSearchModal != nil && m.saveSearchModal.IsActive() {
 			cmd := m.saveSearchModal.Update(msg)
@@ -507,8 +764,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						// Delete the old search and save the new one
 						original := m.saveSearchModal.GetOriginalSearch()
 						if original != nil {
+							savedSearch.Pinned = original.Pinned
 							if err := m.service.DeleteSavedSearch(original.Name); err != nil {
-								m.statusMsg = fmt.Sprintf("Failed to delete original search: %v", err)
+								m.setError(i18n.T("error.delete_original_search"), err)
 								m.statusTimeout = 3
 								m.saveSearchModal.SetActive(false)
 								m.saveSearchModal.ClearEditMode()
@@ -516,19 +774,19 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 							}
 						}
 						if err := m.service.SaveBooleanSearch(*savedSearch); err != nil {
-							m.statusMsg = fmt.Sprintf("Failed to save updated search: %v", err)
+							m.setError(i18n.T("error.save_updated_search"), err)
 							m.statusTimeout = 3
 						} else {
-							m.statusMsg = fmt.Sprintf("Search '%s' updated successfully!", savedSearch.Name)
+							m.statusMsg = i18n.T("saved.updated", savedSearch.Name)
 							m.statusTimeout = 3
 						}
 					} else {
 						// Regular save
 						if err := m.service.SaveBooleanSearch(*savedSearch); err != nil {
-							m.statusMsg = fmt.Sprintf("Failed to save search: %v", err)
+							m.setError(i18n.T("error.save_search"), err)
 							m.statusTimeout = 3
 						} else {
-							m.statusMsg = fmt.Sprintf("Search '%s' saved successfully!", savedSearch.Name)
+							m.statusMsg = i18n.T("saved.saved", savedSearch.Name)
 							m.statusTimeout = 3
 						}
 					}
@@ -587,18 +845,14 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					results, err := m.service.SearchPromptsByBooleanExpression(expr)
 					if err == nil {
 						// Update prompt list with search results
-						items := make([]list.Item, len(results))
-						for i, p := range results {
-							items[i] = p
-						}
-						m.promptList.SetItems(items)
 						m.prompts = results
 						m.currentExpression = expr
+						m.setLibraryItems(results)
 						
-						m.statusMsg = fmt.Sprintf("Found %d prompts", len(results))
+						m.statusMsg = i18n.T("search.found", len(results))
 						m.statusTimeout = 2
 					} else {
-						m.statusMsg = fmt.Sprintf("Search failed: %v", err)
+						m.setError(i18n.T("error.search"), err)
 						m.statusTimeout = 3
 					}
 				}
@@ -614,8 +868,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.err = err
 					return m, nil
 				}
+				m.navigate(ViewPromptDetail)
 				m.selectedPrompt = fullPrompt
-				m.viewMode = ViewPromptDetail
 				m.booleanSearchModal.SetActive(false)
 				// Render the prompt preview
 				if err := m.renderPreview(); err != nil {
@@ -639,30 +893,22 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					results, err := m.service.SearchPromptsByBooleanExpression(expr)
 					if err == nil {
 						// Update prompt list with search results
-						items := make([]list.Item, len(results))
-						for i, p := range results {
-							items[i] = p
-						}
-						m.promptList.SetItems(items)
 						m.prompts = results
 						m.currentExpression = expr
+						m.setLibraryItems(results)
 						
-						m.statusMsg = fmt.Sprintf("Found %d prompts", len(results))
+						m.statusMsg = i18n.T("search.found", len(results))
 						m.statusTimeout = 2
 						cmd = clearStatusCmd()
 					}
 				} else {
 					// No expression means search was cleared - restore full list
 					if allPrompts, err := m.service.ListPrompts(); err == nil {
-						items := make([]list.Item, len(allPrompts))
-						for i, p := range allPrompts {
-							items[i] = p
-						}
-						m.promptList.SetItems(items)
-						m.prompts = allPrompts
 						m.currentExpression = nil
+						m.setLibraryItems(allPrompts)
+						m.prompts = allPrompts
 						
-						m.statusMsg = "Search cleared - showing all prompts"
+						m.statusMsg = i18n.T("search.cleared")
 						m.statusTimeout = 2
 						cmd = clearStatusCmd()
 					}
@@ -698,7 +944,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				// Copy modal content to clipboard
 				if m.modalContent != "" {
 					if statusMsg, err := clipboard.CopyWithFallback(m.modalContent); err != nil {
-						m.statusMsg = fmt.Sprintf("Copy failed: %v", err)
+						m.setError(i18n.T("error.copy"), err)
 						m.statusTimeout = 3
 					} else {
 						m.statusMsg = statusMsg
@@ -711,7 +957,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.showHelpModal = false
 				m.modalContent = ""
 				// Clear copy status message when closing
-				if m.statusMsg == "Copied to clipboard!" {
+				if m.statusMsg == i18n.T("clipboard.copied") {
 					m.statusMsg = ""
 					m.statusTimeout = 0
 				}
@@ -719,6 +965,33 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
+		// Handle the load problems modal
+		if m.showIssues {
+			switch msg.String() {
+			case "!", "esc", "q":
+				m.showIssues = false
+			case "r":
+				return m, reloadPromptsCmd(m.service)
+			}
+			// Don't process other keys when modal is open
+			return m, nil
+		}
+
+		// Handle the sync conflicts modal
+		if m.showConflicts {
+			return m.updateConflicts(msg)
+		}
+
+		// Handle the duplicate prompts report
+		if m.showDuplicates {
+			return m.updateDuplicates(msg)
+		}
+
+		// Handle the snippet library
+		if m.showSnippets {
+			return m.updateSnippets(msg)
+		}
+
 		// Handle modal-specific keys for GitHub sync
 		if m.showGHSyncInfo {
 			switch msg.String() {
@@ -726,7 +999,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				// Copy modal content to clipboard
 				if m.modalContent != "" {
 					if statusMsg, err := clipboard.CopyWithFallback(m.modalContent); err != nil {
-						m.statusMsg = fmt.Sprintf("Copy failed: %v", err)
+						m.setError(i18n.T("error.copy"), err)
 						m.statusTimeout = 3
 					} else {
 						m.statusMsg = statusMsg
@@ -739,7 +1012,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.showGHSyncInfo = false
 				m.modalContent = ""
 				// Clear copy status message when closing
-				if m.statusMsg == "Copied to clipboard!" {
+				if m.statusMsg == i18n.T("clipboard.copied") {
 					m.statusMsg = ""
 					m.statusTimeout = 0
 				}
@@ -750,17 +1023,18 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 
-		// Reset delete confirmation for any key except Ctrl+D
-		if msg.String() != "ctrl+d" {
-			m.deleteConfirm = false
-		}
-
 		switch {
 		case key.Matches(msg, m.keys.Quit):
 			return m, tea.Quit
 
 		case key.Matches(msg, m.keys.Enter):
 			if m.viewMode == ViewLibrary && !m.loading {
+				if pinned, ok := m.promptList.SelectedItem().(pinnedSearchItem); ok && !m.promptList.SettingFilter() {
+					// Open the pinned search like a folder
+					m.promptList.ResetFilter()
+					m.applySavedSearch(pinned.search)
+					return m, clearStatusCmd()
+				}
 				if i, ok := m.promptList.SelectedItem().(*models.Prompt); ok {
 					// Load full prompt with content from service
 					fullPrompt, err := m.service.GetPrompt(i.ID)
@@ -768,8 +1042,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						m.err = err
 						return m, nil
 					}
+					m.navigate(ViewPromptDetail)
 					m.selectedPrompt = fullPrompt
-					m.viewMode = ViewPromptDetail
 					// Render the prompt preview
 					if err := m.renderPreview(); err != nil {
 						m.err = err
@@ -790,24 +1064,22 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 							prompt.ID = m.selectedPrompt.ID // Ensure we're updating the same prompt
 						}
 						if err := m.service.SavePrompt(prompt); err != nil {
-							m.statusMsg = fmt.Sprintf("Save failed: %v", err)
+							m.setError(i18n.T("error.save"), err)
 							m.statusTimeout = 3
 						} else {
 							if m.editMode {
-								m.statusMsg = "Prompt updated! Previous version archived."
+								m.statusMsg = i18n.T("status.prompt_updated")
 							} else {
-								m.statusMsg = "Prompt saved successfully!"
+								m.statusMsg = i18n.T("status.prompt_saved")
 							}
 							m.statusTimeout = 2
-							// Refresh prompt list (respects active boolean search filter)
-							if err := m.refreshPromptList(); err != nil {
-								m.statusMsg = fmt.Sprintf("Failed to refresh list: %v", err)
+							m.addSlotWarnings(prompt)
+							if err := m.showSavedPrompt(prompt); err != nil {
+								m.setError(i18n.T("error.refresh"), err)
 								m.statusTimeout = 3
 							}
-							// Go back to library
-							m.viewMode = ViewLibrary
-							m.createForm = nil
-							m.editMode = false
+							// Go back to where the form was opened
+							return m, tea.Batch(m.closeScreen(), clearStatusCmd())
 						}
 						return m, clearStatusCmd()
 					}
@@ -822,19 +1094,17 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 							template.CreatedAt = m.selectedTemplate.CreatedAt
 						}
 						if err := m.service.SaveTemplate(template); err != nil {
-							m.statusMsg = fmt.Sprintf("Save failed: %v", err)
+							m.setError(i18n.T("error.save"), err)
 							m.statusTimeout = 3
 						} else {
-							m.statusMsg = "Template saved successfully!"
+							m.statusMsg = i18n.T("status.template_saved")
 							m.statusTimeout = 2
 							// Refresh template list
 							if templates, err := m.service.ListTemplates(); err == nil {
 								m.templates = templates
 							}
-							// Go back to template management
-							m.viewMode = ViewTemplateManagement
-							m.templateForm = nil
-							m.editMode = false
+							// Go back to where the form was opened
+							return m, tea.Batch(m.closeScreen(), clearStatusCmd())
 						}
 						return m, clearStatusCmd()
 					}
@@ -844,38 +1114,24 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				switch m.viewMode {
 				case ViewEditPrompt:
 					if m.selectedPrompt != nil {
-						if !m.deleteConfirm {
-							// First press: show confirmation
-							m.deleteConfirm = true
-							m.statusMsg = "Press Ctrl+D again to confirm deletion"
-							m.statusTimeout = 100 // Keep showing until next action
-							return m, nil
-						} else {
-							// Second press: actually delete
-							m.deleteConfirm = false
-							if err := m.service.DeletePrompt(m.selectedPrompt.ID); err != nil {
-								m.statusMsg = fmt.Sprintf("Delete failed: %v", err)
+						id := m.selectedPrompt.ID
+						m.askConfirm(i18n.T("confirm.delete_prompt_title"), i18n.T("confirm.delete_prompt", id), i18n.T("confirm.delete"), func(m Model) (Model, tea.Cmd) {
+							if err := m.service.DeletePrompt(id); err != nil {
+								m.setError(i18n.T("error.delete"), err)
 								m.statusTimeout = 3
-							} else {
-								m.statusMsg = "Prompt deleted successfully!"
-								m.statusTimeout = 2
-								// Refresh prompt list (respects active boolean search filter)
-								if err := m.refreshPromptList(); err != nil {
-									m.statusMsg = fmt.Sprintf("Failed to refresh list: %v", err)
-									m.statusTimeout = 3
-								}
-								// Go back to library
-								m.viewMode = ViewLibrary
-								m.createForm = nil
-								m.editMode = false
-								m.selectedPrompt = nil
+								return m, clearStatusCmd()
 							}
-							return m, clearStatusCmd()
-						}
+							m.statusMsg = i18n.T("status.prompt_deleted")
+							m.statusTimeout = 2
+							m.removeDeletedPrompts(id)
+							// Go back past the deleted prompt's screens
+							return m, tea.Batch(m.closeScreen(), clearStatusCmd())
+						})
+						return m, nil
 					}
 				case ViewEditTemplate:
 					// Template deletion could be added here if needed
-					m.statusMsg = "Template deletion not yet implemented"
+					m.statusMsg = i18n.T("status.template_delete_unsupported")
 					m.statusTimeout = 2
 					return m, clearStatusCmd()
 				case ViewSavedSearches:
@@ -884,57 +1140,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						selected := m.selectForm.GetSelected()
 						if selected != nil {
 							if savedSearch, ok := selected.Value.(models.SavedSearch); ok {
-								if !m.deleteConfirm {
-									// First press: show confirmation
-									m.deleteConfirm = true
-									m.statusMsg = fmt.Sprintf("Press Ctrl+D again to delete '%s'", savedSearch.Name)
-									m.statusTimeout = 100 // Keep showing until next action
-									return m, nil
-								} else {
-									// Second press: actually delete
-									m.deleteConfirm = false
-									if err := m.service.DeleteSavedSearch(savedSearch.Name); err != nil {
-										m.statusMsg = fmt.Sprintf("Delete failed: %v", err)
-										m.statusTimeout = 3
-									} else {
-										m.statusMsg = fmt.Sprintf("Search '%s' deleted!", savedSearch.Name)
-										m.statusTimeout = 2
-										// Refresh saved searches list
-										savedSearches, err := m.service.ListSavedSearches()
-										if err == nil {
-											m.savedSearches = savedSearches
-											// Update select form options with result counts
-											options := []SelectOption{}
-											for _, search := range savedSearches {
-												// Calculate result count for this search
-												results, err := m.service.SearchPromptsByBooleanExpression(search.Expression)
-												resultCount := 0
-												if err == nil {
-													resultCount = len(results)
-												}
-												
-												// Format description with expression and count
-												description := fmt.Sprintf("%s (%d results)", search.Expression.String(), resultCount)
-												
-												options = append(options, SelectOption{
-													Label:       search.Name,
-													Description: description,
-													Value:       search,
-												})
-											}
-											if len(options) == 0 {
-												// No more searches - go back to library
-												m.viewMode = ViewLibrary
-												m.selectForm = nil
-												m.savedSearches = nil
-											} else {
-												// Update the select form with remaining searches
-												m.selectForm = NewSelectForm(options)
-											}
-										}
-									}
-									return m, clearStatusCmd()
-								}
+								m.askConfirm(i18n.T("confirm.delete_search_title"), i18n.T("confirm.delete_search", savedSearch.Name), i18n.T("confirm.delete"), func(m Model) (Model, tea.Cmd) {
+									return m.deleteSavedSearch(savedSearch.Name)
+								})
+								return m, nil
 							}
 						}
 					}
@@ -965,52 +1174,51 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			
 			switch m.viewMode {
-			case ViewCreateMenu, ViewCreateFromScratch, ViewCreateFromTemplate, ViewTemplateList:
-				if m.viewMode == ViewTemplateList || m.viewMode == ViewCreateFromTemplate {
-					m.viewMode = ViewCreateMenu
-				} else {
-					m.viewMode = ViewLibrary
+			case ViewLibrary:
+				// Clear the tag filter, then leave a pinned or saved search,
+				// once no list filter is left to clear
+				if m.tagFilter != "" && m.promptList.FilterState() == list.Unfiltered {
+					m.setTagFilter("")
+					return m, clearStatusCmd()
 				}
-				m.newPrompt = nil
-				m.createForm = nil
-				m.selectForm = nil
-			case ViewEditPrompt, ViewEditTemplate:
-				m.viewMode = ViewLibrary
-				m.createForm = nil
-				m.templateForm = nil
-				m.editMode = false
-			case ViewTemplateManagement, ViewTemplateDetail:
-				if m.viewMode == ViewTemplateDetail {
-					m.viewMode = ViewTemplateManagement
-				} else {
-					m.viewMode = ViewLibrary
+				if m.currentExpression != nil && m.promptList.FilterState() == list.Unfiltered {
+					m.currentExpression = nil
+					if err := m.refreshPromptList(); err != nil {
+						m.err = err
+					}
+					m.promptList.Select(0)
+					m.statusMsg = i18n.T("search.cleared")
+					m.statusTimeout = 2
+					return m, clearStatusCmd()
+				}
+			default:
+				// Return to the screen this one was opened from
+				cmd, ok := m.goBack()
+				if !ok {
+					cmd = m.closeScreen()
+				}
+				return m, cmd
+			}
+
+		case key.Matches(msg, m.keys.Forward):
+			// Forms take typed brackets as text, and the list its filter
+			switch m.viewMode {
+			case ViewEditPrompt, ViewEditTemplate, ViewCreateFromScratch:
+			default:
+				if !m.promptList.SettingFilter() {
+					if cmd, ok := m.goForward(); ok {
+						return m, cmd
+					}
 				}
-				m.selectedTemplate = nil
-				m.selectForm = nil
-			case ViewSavedSearches:
-				m.viewMode = ViewLibrary
-				m.selectForm = nil
-				m.savedSearches = nil
 			}
 
 
+
 		case key.Matches(msg, m.keys.New):
 			if m.viewMode == ViewLibrary && !m.loading {
 				// Initialize the create menu select form
-				options := []SelectOption{
-					{
-						Label:       "Create from scratch",
-						Description: "Start with a blank prompt",
-						Value:       "scratch",
-					},
-					{
-						Label:       "Use a template",
-						Description: "Start from an existing template",
-						Value:       "template",
-					},
-				}
-				m.selectForm = NewSelectForm(options)
-				m.viewMode = ViewCreateMenu
+				m.navigate(ViewCreateMenu)
+				m.selectForm = NewSelectForm(createMenuOptions())
 				return m, nil
 			}
 
@@ -1025,6 +1233,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 							m.err = err
 							return m, nil
 						}
+						m.navigate(ViewEditPrompt)
 						m.selectedPrompt = fullPrompt
 						m.createForm = NewCreateForm()
 						// Set available tags for autocomplete
@@ -1033,11 +1242,11 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						}
 						m.createForm.LoadPrompt(fullPrompt)
 						m.editMode = true
-						m.viewMode = ViewEditPrompt
 					}
 				}
 			case ViewPromptDetail:
 				if m.selectedPrompt != nil {
+					m.navigate(ViewEditPrompt)
 					m.createForm = NewCreateForm()
 					// Set available tags for autocomplete
 					if tags, err := m.service.GetAllTags(); err == nil {
@@ -1045,14 +1254,13 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					}
 					m.createForm.LoadPrompt(m.selectedPrompt)
 					m.editMode = true
-					m.viewMode = ViewEditPrompt
 				}
 			case ViewTemplateDetail:
 				if m.selectedTemplate != nil {
+					m.navigate(ViewEditTemplate)
 					m.templateForm = NewTemplateForm()
 					m.templateForm.LoadTemplate(m.selectedTemplate)
 					m.editMode = true
-					m.viewMode = ViewEditTemplate
 				}
 			case ViewSavedSearches:
 				// Edit saved search
@@ -1079,26 +1287,50 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 
 
+		case key.Matches(msg, m.keys.Sync):
+			if m.viewMode == ViewLibrary && !m.loading && !m.promptList.SettingFilter() && !m.syncing {
+				m.syncing = true
+				return m, tea.Batch(gitSyncCmd(m.service), m.syncSpinner.Tick)
+			}
+
+		case key.Matches(msg, m.keys.Duplicate):
+			var source *models.Prompt
+			switch m.viewMode {
+			case ViewLibrary:
+				if !m.loading && !m.promptList.SettingFilter() {
+					source, _ = m.promptList.SelectedItem().(*models.Prompt)
+				}
+			case ViewPromptDetail:
+				source = m.selectedPrompt
+			}
+			if source != nil {
+				duplicate, err := m.service.DuplicatePrompt(source.ID, "")
+				if err != nil {
+					m.setError(i18n.T("error.duplicate"), err)
+					m.statusTimeout = 3
+					return m, clearStatusCmd()
+				}
+				if err := m.showSavedPrompt(duplicate); err != nil {
+					m.setError(i18n.T("error.refresh"), err)
+					m.statusTimeout = 3
+					return m, clearStatusCmd()
+				}
+				// Show the copy so it can be edited right away
+				m.navigate(ViewPromptDetail)
+				m.selectedPrompt = duplicate
+				if err := m.renderPreview(); err != nil {
+					m.err = err
+				}
+				m.statusMsg = i18n.T("status.duplicated", duplicate.ID)
+				m.statusTimeout = 3
+				return m, clearStatusCmd()
+			}
+
 		case key.Matches(msg, m.keys.Templates):
 			if m.viewMode == ViewLibrary && !m.loading {
 				// Create template management select form
-				options := []SelectOption{
-					{
-						Label:       "Create new template",
-						Description: "Start with a blank template",
-						Value:       "new",
-					},
-				}
-				// Add existing templates as options
-				for _, template := range m.templates {
-					options = append(options, SelectOption{
-						Label:       template.Name,
-						Description: template.Description,
-						Value:       template,
-					})
-				}
-				m.selectForm = NewSelectForm(options)
-				m.viewMode = ViewTemplateManagement
+				m.navigate(ViewTemplateManagement)
+				m.selectForm = NewSelectForm(m.templateManagementOptions())
 				return m, nil
 			}
 
@@ -1112,6 +1344,38 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.showExpandedHelp = !m.showExpandedHelp
 			return m, nil
 
+		case key.Matches(msg, m.keys.Issues):
+			if m.viewMode == ViewLibrary && len(m.loadIssues) > 0 && !m.promptList.SettingFilter() {
+				m.showIssues = true
+				return m, reloadPromptsCmd(m.service)
+			}
+
+		case key.Matches(msg, m.keys.OpenEditor):
+			if m.viewMode == ViewPromptDetail && m.selectedPrompt != nil {
+				return m.openInEditor()
+			}
+
+		// C resolves conflicts in the library, so match it by view first
+		case m.viewMode == ViewPromptDetail && m.selectedPrompt != nil && key.Matches(msg, m.keys.CopyFilled):
+			return m.openVariableFill()
+
+		case key.Matches(msg, m.keys.Conflicts):
+			if m.viewMode == ViewLibrary && len(m.conflicts) > 0 && !m.promptList.SettingFilter() {
+				m.showConflicts = true
+				m.conflictCursor = 0
+				return m, nil
+			}
+
+		case key.Matches(msg, m.keys.Duplicates):
+			if m.viewMode == ViewLibrary && !m.promptList.SettingFilter() {
+				return m.openDuplicates()
+			}
+
+		case key.Matches(msg, m.keys.Snippets):
+			if m.viewMode == ViewLibrary && !m.promptList.SettingFilter() {
+				return m.openSnippets()
+			}
+
 		case key.Matches(msg, m.keys.GHSyncInfo):
 			// Toggle GitHub sync info modal
 			m.showGHSyncInfo = !m.showGHSyncInfo
@@ -1122,7 +1386,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				// Get available tags for boolean search
 				tags, err := m.service.GetAllTags()
 				if err != nil {
-					m.statusMsg = fmt.Sprintf("Failed to load tags: %v", err)
+					m.setError(i18n.T("error.load_tags"), err)
 					m.statusTimeout = 3
 					return m, clearStatusCmd()
 				}
@@ -1130,8 +1394,11 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				// Initialize boolean search modal
 				if m.booleanSearchModal == nil {
 					m.booleanSearchModal = NewBooleanSearchModal(tags)
+					m.booleanSearchModal.SetTagColors(m.service.TagColors())
 					// Set up live search callback
-					m.booleanSearchModal.SetSearchFunc(m.service.SearchPromptsByBooleanExpression)
+					m.booleanSearchModal.SetSearchFunc(func(ctx context.Context, expr *models.BooleanExpression) ([]*models.Prompt, error) {
+						return m.service.SearchPromptsByBooleanExpressionInScopeContext(ctx, expr, service.ActiveOnly)
+					})
 					// Set up save callback
 					m.booleanSearchModal.SetSaveFunc(m.service.SaveBooleanSearch)
 				}
@@ -1145,51 +1412,107 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				// Load saved searches
 				savedSearches, err := m.service.ListSavedSearches()
 				if err != nil {
-					m.statusMsg = fmt.Sprintf("Failed to load saved searches: %v", err)
+					m.setError(i18n.T("error.load_saved_searches"), err)
 					m.statusTimeout = 3
 					return m, clearStatusCmd()
 				}
 				
-				// Create saved searches select form with result counts
-				options := []SelectOption{}
-				for _, search := range savedSearches {
-					// Calculate result count for this search
-					results, err := m.service.SearchPromptsByBooleanExpression(search.Expression)
-					resultCount := 0
-					if err == nil {
-						resultCount = len(results)
-					}
-					
-					// Format description with expression and count
-					description := fmt.Sprintf("%s (%d results)", search.Expression.String(), resultCount)
-					
-					options = append(options, SelectOption{
-						Label:       search.Name,
-						Description: description,
-						Value:       search,
-					})
+				if len(savedSearches) == 0 {
+					m.statusMsg = i18n.T("saved.none")
+					m.statusTimeout = 3
+					return m, clearStatusCmd()
 				}
 				
-				if len(options) == 0 {
-					m.statusMsg = "No saved searches found. Create one with 'b' for boolean search."
+				// Show the searches right away and count their results in the background
+				m.navigate(ViewSavedSearches)
+				return m, m.showSavedSearches(savedSearches)
+			}
+
+		case key.Matches(msg, m.keys.PinSearch):
+			if m.viewMode == ViewSavedSearches && m.selectForm != nil {
+				selected := m.selectForm.GetSelected()
+				if selected == nil {
+					return m, nil
+				}
+				savedSearch, ok := selected.Value.(models.SavedSearch)
+				if !ok {
+					return m, nil
+				}
+				if err := m.service.SetSavedSearchPinned(savedSearch.Name, !savedSearch.Pinned); err != nil {
+					m.setError(i18n.T("error.pin"), err)
 					m.statusTimeout = 3
 					return m, clearStatusCmd()
 				}
-				
-				m.selectForm = NewSelectForm(options)
-				m.savedSearches = savedSearches
-				m.viewMode = ViewSavedSearches
-				return m, nil
+				if savedSearch.Pinned {
+					m.statusMsg = i18n.T("pinned.unpinned", savedSearch.Name)
+				} else {
+					m.statusMsg = i18n.T("pinned.pinned", savedSearch.Name)
+				}
+				m.statusTimeout = 2
+				if savedSearches, err := m.service.ListSavedSearches(); err == nil {
+					index := m.selectForm.selected
+					m.savedSearches = savedSearches
+					m.selectForm = NewSelectForm(m.savedSearchOptions(savedSearches))
+					m.selectForm.selected = index
+				}
+				if err := m.refreshPromptList(); err != nil {
+					m.err = err
+				}
+				return m, clearStatusCmd()
+			}
+
+		case key.Matches(msg, m.keys.Rate):
+			if m.viewMode == ViewPromptDetail && m.selectedPrompt != nil {
+				rating, _ := models.ParseRating(msg.String())
+				rated, err := m.service.SetRating(m.selectedPrompt.ID, rating)
+				if err != nil {
+					m.setError(i18n.T("error.rate"), err)
+					m.statusTimeout = 3
+					return m, clearStatusCmd()
+				}
+				m.selectedPrompt.Rating = rating
+				if err := m.showSavedPrompt(rated); err != nil {
+					m.err = err
+				}
+				if rating == 0 {
+					m.statusMsg = i18n.T("status.rating_cleared")
+				} else {
+					m.statusMsg = i18n.T("status.rated") + models.Stars(rating)
+				}
+				m.statusTimeout = 2
+				return m, clearStatusCmd()
+			}
+
+		case key.Matches(msg, m.keys.TagFilter):
+			if m.viewMode == ViewLibrary && !m.loading && !m.promptList.SettingFilter() {
+				return m.openTagChooser()
+			}
+
+		case key.Matches(msg, m.keys.TogglePreview):
+			if m.viewMode == ViewLibrary && !m.promptList.SettingFilter() {
+				m.togglePreview()
+				return m, clearStatusCmd()
+			}
+
+		case key.Matches(msg, m.keys.ShrinkPreview), key.Matches(msg, m.keys.GrowPreview):
+			if m.viewMode == ViewLibrary && !m.promptList.SettingFilter() {
+				if key.Matches(msg, m.keys.GrowPreview) {
+					m.resizePreview(1)
+				} else {
+					m.resizePreview(-1)
+				}
+				return m, clearStatusCmd()
 			}
 
 		case key.Matches(msg, m.keys.Copy):
 			if m.viewMode == ViewPromptDetail && m.renderedContent != "" {
 				if statusMsg, err := clipboard.CopyWithFallback(m.renderedContent); err != nil {
-					m.statusMsg = fmt.Sprintf("Copy failed: %v", err)
+					m.setError(i18n.T("error.copy"), err)
 					m.statusTimeout = 3
 				} else {
 					m.statusMsg = statusMsg
 					m.statusTimeout = 2
+					m.recordUsage()
 				}
 				return m, clearStatusCmd()
 			}
@@ -1197,11 +1520,30 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case key.Matches(msg, m.keys.CopyJSON):
 			if m.viewMode == ViewPromptDetail && m.renderedContentJSON != "" {
 				if _, err := clipboard.CopyWithFallback(m.renderedContentJSON); err != nil {
-					m.statusMsg = fmt.Sprintf("JSON copy failed: %v", err)
+					m.setError(i18n.T("error.copy_json"), err)
 					m.statusTimeout = 3
 				} else {
-					m.statusMsg = "Copied as JSON messages!"
+					m.statusMsg = i18n.T("status.copied_json")
 					m.statusTimeout = 2
+					m.recordUsage()
+				}
+				return m, clearStatusCmd()
+			}
+
+		case key.Matches(msg, m.keys.CopyRich):
+			if m.viewMode == ViewPromptDetail && m.renderedContent != "" {
+				html, err := renderer.MarkdownToHTML(m.renderedContent)
+				if err == nil {
+					var statusMsg string
+					if statusMsg, err = clipboard.CopyHTMLWithFallback(html, m.renderedContent); err == nil {
+						m.statusMsg = statusMsg
+						m.statusTimeout = 2
+						m.recordUsage()
+					}
+				}
+				if err != nil {
+					m.setError(i18n.T("error.copy_rich"), err)
+					m.statusTimeout = 3
 				}
 				return m, clearStatusCmd()
 			}
@@ -1239,28 +1581,13 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		newListModel, cmd := m.promptList.Update(msg)
 		m.promptList = newListModel
 		cmds = append(cmds, cmd)
+		m.updateLibraryPreview()
 
 	case ViewPromptDetail:
-		// Handle back navigation keys before passing to viewport
-		if keyMsg, ok := msg.(tea.KeyMsg); ok {
-			if key.Matches(keyMsg, m.keys.Back) || key.Matches(keyMsg, m.keys.Left) {
-				m.viewMode = ViewLibrary
-				m.selectedPrompt = nil
-				m.renderedContent = ""
-				m.renderedContentJSON = ""
-				// Don't pass to viewport, navigation handled
-			} else {
-				// Only pass other keys to viewport
-				newViewport, cmd := m.viewport.Update(msg)
-				m.viewport = newViewport
-				cmds = append(cmds, cmd)
-			}
-		} else {
-			// Non-key messages still go to viewport
-			newViewport, cmd := m.viewport.Update(msg)
-			m.viewport = newViewport
-			cmds = append(cmds, cmd)
-		}
+		// Back navigation was handled above, so the viewport scrolls
+		newViewport, cmd := m.viewport.Update(msg)
+		m.viewport = newViewport
+		cmds = append(cmds, cmd)
 
 	case ViewCreateMenu:
 		if m.selectForm != nil {
@@ -1272,7 +1599,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				if selected != nil {
 					switch selected.Value {
 					case "scratch":
-						m.viewMode = ViewCreateFromScratch
+						m.navigate(ViewCreateFromScratch)
 						m.createForm = NewCreateFormFromScratch()
 						// Set available tags for autocomplete
 						if tags, err := m.service.GetAllTags(); err == nil {
@@ -1281,21 +1608,12 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					case "template":
 						// Initialize template selection
 						if len(m.templates) > 0 {
-							templateOptions := make([]SelectOption, len(m.templates))
-							for i, template := range m.templates {
-								templateOptions[i] = SelectOption{
-									Label:       template.Name,
-									Description: template.Description,
-									Value:       template,
-								}
-							}
-							m.selectForm = NewSelectForm(templateOptions)
-							m.viewMode = ViewTemplateList
+							m.navigate(ViewTemplateList)
+							m.selectForm = NewSelectForm(m.templateListOptions())
 						} else {
-							m.statusMsg = "No templates available"
+							m.statusMsg = i18n.T("templates.none")
 							m.statusTimeout = 2
-							m.viewMode = ViewLibrary
-							cmds = append(cmds, clearStatusCmd())
+							cmds = append(cmds, m.closeScreen(), clearStatusCmd())
 						}
 					}
 				}
@@ -1311,8 +1629,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				selected := m.selectForm.GetSelected()
 				if selected != nil {
 					if template, ok := selected.Value.(*models.Template); ok {
+						m.navigate(ViewCreateFromTemplate)
 						m.selectedTemplate = template
-						m.viewMode = ViewCreateFromTemplate
 						// TODO: Initialize form with template
 					}
 				}
@@ -1339,19 +1657,18 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if m.createForm.IsSubmitted() {
 				prompt := m.createForm.ToPrompt()
 				if err := m.service.SavePrompt(prompt); err != nil {
-					m.statusMsg = fmt.Sprintf("Save failed: %v", err)
+					m.setError(i18n.T("error.save"), err)
 					m.statusTimeout = 3
 				} else {
-					m.statusMsg = "Prompt created successfully!"
+					m.statusMsg = i18n.T("status.created", prompt.ID)
 					m.statusTimeout = 2
-					// Refresh prompt list (respects active boolean search filter)
-					if err := m.refreshPromptList(); err != nil {
-						m.statusMsg = fmt.Sprintf("Failed to refresh list: %v", err)
+					m.addSlotWarnings(prompt)
+					if err := m.showSavedPrompt(prompt); err != nil {
+						m.setError(i18n.T("error.refresh"), err)
 						m.statusTimeout = 3
 					}
-					// Go back to library
-					m.viewMode = ViewLibrary
-					m.createForm = nil
+					// Go back to where creating started
+					cmds = append(cmds, m.closeScreen())
 				}
 				cmds = append(cmds, clearStatusCmd())
 			}
@@ -1367,15 +1684,15 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				if selected != nil {
 					switch selected.Value {
 					case "new":
+						m.navigate(ViewEditTemplate)
 						m.templateForm = NewTemplateFormFromScratch()
 						m.editMode = false
-						m.viewMode = ViewEditTemplate
 						m.selectForm = nil
 					default:
 						// Selected an existing template
 						if template, ok := selected.Value.(*models.Template); ok {
+							m.navigate(ViewTemplateDetail)
 							m.selectedTemplate = template
-							m.viewMode = ViewTemplateDetail
 							m.selectForm = nil
 						}
 					}
@@ -1393,30 +1710,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				if selected != nil {
 					if savedSearch, ok := selected.Value.(models.SavedSearch); ok {
 						// Execute the saved search
-						results, err := m.service.SearchPromptsByBooleanExpression(savedSearch.Expression)
-						if err != nil {
-							m.statusMsg = fmt.Sprintf("Search failed: %v", err)
-							m.statusTimeout = 3
-						} else {
-							// Update prompt list with search results
-							items := make([]list.Item, len(results))
-							for i, p := range results {
-								items[i] = p
-							}
-							m.promptList.SetItems(items)
-							m.prompts = results
-							m.currentExpression = savedSearch.Expression
-							
-							m.statusMsg = fmt.Sprintf("'%s': Found %d prompts", savedSearch.Name, len(results))
-							m.statusTimeout = 2
-						}
+						m.applySavedSearch(savedSearch)
 						
-						// Return to library view
-						m.viewMode = ViewLibrary
-						m.selectForm = nil
-						m.savedSearches = nil
-						
-						cmds = append(cmds, clearStatusCmd())
+						// Return to the library
+						cmds = append(cmds, m.closeScreen(), clearStatusCmd())
 					}
 				}
 			}
@@ -1428,17 +1725,56 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 // View renders the UI
 func (m Model) View() string {
+	startup.Mark("first paint")
+	if !m.loading {
+		startup.Mark("library shown")
+	}
 	if m.err != nil {
 		return fmt.Sprintf("\n  Error: %v\n\n  Press 'q' to quit.\n", m.err)
 	}
 
 	var mainView string
 
+	// A confirmation dialog is drawn over everything else
+	if m.confirm != nil {
+		return m.renderConfirmModal()
+	}
+
+	// If the tag chooser is open, render it on top
+	if m.tagChooser != nil {
+		return m.renderTagChooser()
+	}
+
+	// If variables are being filled in, render the form on top
+	if m.variableFill != nil {
+		return m.renderVariableFill()
+	}
+
 	// If the help modal is showing, render it on top
 	if m.showHelpModal {
 		return m.renderHelpModal()
 	}
 
+	// If the load problems modal is showing, render it on top
+	if m.showIssues {
+		return m.renderIssuesModal()
+	}
+
+	// If the sync conflicts modal is showing, render it on top
+	if m.showConflicts {
+		return m.renderConflictsModal()
+	}
+
+	// If the duplicate prompts report is showing, render it on top
+	if m.showDuplicates {
+		return m.renderDuplicatesModal()
+	}
+
+	// If the snippet library is showing, render it on top
+	if m.showSnippets {
+		return m.renderSnippetsModal()
+	}
+
 	// If the GitHub sync info modal is showing, render it on top
 	if m.showGHSyncInfo {
 		return m.renderGHSyncInfoModal()
@@ -1504,12 +1840,16 @@ func (m Model) View() string {
 		mainView = m.renderSavedSearchesView()
 
 	default:
-		mainView = "Unknown view mode"
+		mainView = i18n.T("view.unknown")
 	}
 
-	// Add status message at the bottom if present
+	// Add status message at the bottom if present, or in plain mode at the
+	// top where a screen reader reaches it first
 	if m.statusMsg != "" {
 		statusBar := CreateStatus(m.statusMsg, "success") // Default to success styling
+		if plain {
+			return AddMainPadding(lipgloss.JoinVertical(lipgloss.Left, statusBar, mainView))
+		}
 		return AddMainPadding(lipgloss.JoinVertical(lipgloss.Left, mainView, statusBar))
 	}
 
@@ -1518,7 +1858,7 @@ func (m Model) View() string {
 
 // renderLibraryView renders the prompt library list
 func (m Model) renderLibraryView() string {
-	title := CreateMainHeader("Pocket Prompt Library")
+	title := CreateMainHeader(i18n.T("library.title"))
 	
 	// Add boolean search indicator if active
 	var searchIndicator string
@@ -1528,22 +1868,24 @@ func (m Model) renderLibraryView() string {
 	
 	var help string
 	if m.loading {
-		help = CreateGuaranteedHelp("Loading prompts... • q quit", m.width)
+		help = CreateGuaranteedHelp(i18n.T("library.help_loading"), m.width)
 	} else {
 		if m.currentExpression != nil {
-			essential := []string{"enter view • e edit • n create"}
-			additional := []string{"Ctrl+f modify search • q quit"}
+			essential := []string{i18n.T("library.help")}
+			additional := []string{i18n.T("library.help_search")}
 			help = CreateContextualHelp(essential, additional, m.showExpandedHelp, m.width)
 		} else {
-			essential := []string{"enter view • e edit • n create"}
-			additional := []string{"/ search • t templates • f saved searches", "Ctrl+f boolean search • ? help • q quit"}
+			essential := []string{i18n.T("library.help")}
+			additional := []string{i18n.T("library.help_more"), i18n.T("library.help_more2")}
 			help = CreateContextualHelp(essential, additional, m.showExpandedHelp, m.width)
 		}
 	}
 	
 	// Add git sync status if available
 	var gitStatus string
-	if m.gitSyncStatus != "" {
+	if m.syncing {
+		gitStatus = CreateGitStatus(m.syncSpinner.View() + " Syncing...")
+	} else if m.gitSyncStatus != "" {
 		gitStatus = CreateGitStatus(m.gitSyncStatus)
 	}
 
@@ -1554,13 +1896,22 @@ func (m Model) renderLibraryView() string {
 	if searchIndicator != "" {
 		elements = append(elements, searchIndicator)
 	}
+	if m.tagFilter != "" {
+		elements = append(elements, m.renderTagChip())
+	}
+	if len(m.loadIssues) > 0 && !m.loading {
+		elements = append(elements, CreateStatus(i18n.T("library.problems", len(m.loadIssues)), "warning"))
+	}
+	if len(m.conflicts) > 0 && !m.loading {
+		elements = append(elements, CreateStatus(i18n.T("library.conflicts", len(m.conflicts)), "warning"))
+	}
 	
 	// Show loading indicator or prompt list
 	if m.loading {
 		loadingIndicator := StyleLoading.Render("⏳ Loading prompts...")
 		elements = append(elements, loadingIndicator)
 	} else {
-		elements = append(elements, m.promptList.View())
+		elements = append(elements, m.renderLibraryPanes())
 	}
 	
 	elements = append(elements, help)
@@ -1571,37 +1922,45 @@ func (m Model) renderLibraryView() string {
 // renderPromptDetailView renders the selected prompt in full-page view
 func (m Model) renderPromptDetailView() string {
 	if m.selectedPrompt == nil {
-		return "No prompt selected"
+		return i18n.T("detail.no_prompt")
 	}
 
 	// Create header with consistent styling
 	headerLine := CreateSubPageHeader(m.selectedPrompt.Title())
 
 	// Create metadata line
-	metadata := fmt.Sprintf("ID: %s • Version: %s", m.selectedPrompt.ID, m.selectedPrompt.Version)
+	metadata := i18n.T("detail.id_version", m.selectedPrompt.ID, m.selectedPrompt.Version)
+	if m.selectedPrompt.Rating > 0 {
+		metadata += " • " + models.Stars(m.selectedPrompt.Rating)
+	}
 	if !m.selectedPrompt.UpdatedAt.IsZero() {
-		metadata += fmt.Sprintf(" • Last edited: %s", m.selectedPrompt.UpdatedAt.Format("2006-01-02 15:04"))
+		metadata += i18n.T("detail.last_edited", m.selectedPrompt.UpdatedAt.Format("2006-01-02 15:04"))
 	}
 	if len(m.selectedPrompt.Tags) > 0 {
-		tags := ""
-		for i, tag := range m.selectedPrompt.Tags {
-			if i > 0 {
-				tags += ", "
-			}
-			tags += tag
-		}
-		metadata += fmt.Sprintf(" • Tags: %s", tags)
+		metadata += " • " + i18n.T("detail.tags") + renderTags(m.selectedPrompt.Tags, m.service.TagColors(), lipgloss.NewStyle().Foreground(ColorTextDim))
 	}
 	metadataLine := CreateMetadata(metadata)
 
+	// Custom metadata goes on a line of its own, taken from the content
+	viewport := m.viewport
+	if len(m.selectedPrompt.Metadata) > 0 {
+		var fields []string
+		for _, key := range m.selectedPrompt.MetadataKeys() {
+			fields = append(fields, fmt.Sprintf("%s: %v", key, m.selectedPrompt.Metadata[key]))
+		}
+		fieldsLine := lipgloss.NewStyle().MaxWidth(max(20, m.width-8)).Render(strings.Join(fields, " • "))
+		metadataLine += "\n" + CreateMetadata(fieldsLine)
+		viewport.Height = max(1, viewport.Height-1)
+	}
+
 	// Help text
-	essential := []string{"c copy • e edit"}
-	additional := []string{"y copy JSON • x export • Esc back"}
+	essential := []string{i18n.T("detail.help")}
+	additional := []string{i18n.T("detail.help_more")}
 	help := CreateContextualHelp(essential, additional, m.showExpandedHelp, m.width)
 
 	// Check scroll state and create indicators
-	canScrollUp := !m.viewport.AtTop()
-	canScrollDown := !m.viewport.AtBottom()
+	canScrollUp := !viewport.AtTop()
+	canScrollDown := !viewport.AtBottom()
 	topIndicator, bottomIndicator := CreateScrollIndicators(canScrollUp, canScrollDown, m.width-4)
 	
 	// Build content with scroll indicators
@@ -1611,7 +1970,7 @@ func (m Model) renderPromptDetailView() string {
 	contentElements = append(contentElements, topIndicator)
 	
 	// Add main content
-	contentElements = append(contentElements, m.viewport.View())
+	contentElements = append(contentElements, viewport.View())
 	
 	// Add bottom scroll indicator  
 	contentElements = append(contentElements, bottomIndicator)
@@ -1632,10 +1991,10 @@ func (m Model) renderPromptDetailView() string {
 // renderCreateMenuView renders the create menu using SelectForm
 func (m Model) renderCreateMenuView() string {
 	// Create header with consistent styling
-	headerLine := CreateSubPageHeader("Create New Prompt")
+	headerLine := CreateSubPageHeader(i18n.T("create.title"))
 
 	if m.selectForm == nil {
-		return AddMainPadding(lipgloss.JoinVertical(lipgloss.Left, headerLine, "", "No options available"))
+		return AddMainPadding(lipgloss.JoinVertical(lipgloss.Left, headerLine, "", i18n.T("create.no_options")))
 	}
 
 	// Render options with consistent styling
@@ -1646,8 +2005,8 @@ func (m Model) renderCreateMenuView() string {
 		optionLines = append(optionLines, lines...)
 	}
 
-	essential := []string{"↑/↓ navigate • enter select"}
-	additional := []string{"Esc back"}
+	essential := []string{i18n.T("menu.help")}
+	additional := []string{i18n.T("menu.help_back")}
 	help := CreateContextualHelp(essential, additional, m.showExpandedHelp, m.width)
 
 	// Join all elements
@@ -1661,42 +2020,52 @@ func (m Model) renderCreateMenuView() string {
 // renderCreateFromScratchView renders the create from scratch form
 func (m Model) renderCreateFromScratchView() string {
 	// Create header with consistent styling
-	headerLine := CreateSubPageHeader( "Create from Scratch")
+	headerLine := CreateSubPageHeader( i18n.T("create.scratch_title"))
 
 	if m.createForm == nil {
-		return AddMainPadding(lipgloss.JoinVertical(lipgloss.Left, headerLine, "", "No form available"))
+		return AddMainPadding(lipgloss.JoinVertical(lipgloss.Left, headerLine, "", i18n.T("form.unavailable")))
 	}
 
 	// Build form fields (same as edit form but without ID field)
 	var formFields []string
 
 	// Version field
-	versionLabel := StyleFormLabel.Render("Version:")
+	versionLabel := StyleFormLabel.Render(i18n.T("form.version"))
 	formFields = append(formFields, versionLabel, m.createForm.inputs[versionField].View(), "")
 
 	// Title field
-	titleLabel := StyleFormLabel.Render("Title:")
+	titleLabel := StyleFormLabel.Render(i18n.T("form.title"))
 	formFields = append(formFields, titleLabel, m.createForm.inputs[titleField].View(), "")
 
 	// Description field
-	descLabel := StyleFormLabel.Render("Description:")
+	descLabel := StyleFormLabel.Render(i18n.T("form.description"))
 	formFields = append(formFields, descLabel, m.createForm.inputs[descriptionField].View(), "")
 
 	// Tags field
-	tagsLabel := StyleFormLabel.Render("Tags:")
-	tagsHelp := StyleFormHelp.Render("Use comma-separated values for organization and discovery")
+	tagsLabel := StyleFormLabel.Render(i18n.T("form.tags"))
+	tagsHelp := StyleFormHelp.Render(i18n.T("form.tags_help"))
+	if hint := m.createForm.TagSuggestionHint(); hint != "" {
+		tagsHelp = StyleFormHelp.Render(hint)
+	}
 	formFields = append(formFields, tagsLabel, m.createForm.inputs[tagsField].View(), tagsHelp, "")
 
 	// Template reference field
-	templateRefLabel := StyleFormLabel.Render("Template Ref:")
+	templateRefLabel := StyleFormLabel.Render(i18n.T("form.template"))
 	formFields = append(formFields, templateRefLabel, m.createForm.inputs[templateRefField].View(), "")
 
+	// Metadata field
+	metadataLabel := StyleFormLabel.Render(i18n.T("form.metadata"))
+	metadataHelp := StyleFormHelp.Render(i18n.T("form.metadata_help"))
+	formFields = append(formFields, metadataLabel, m.createForm.inputs[metadataField].View(), metadataHelp, "")
+
 	// Content field
-	contentLabel := StyleFormLabel.Render("Content:")
-	formFields = append(formFields, contentLabel, m.createForm.textarea.View(), "")
+	contentLabel := StyleFormLabel.Render(i18n.T("form.content"))
+	contentHelp := StyleFormHelp.Render(i18n.T("form.content_help"))
+	contentLength := StyleFormHelp.Render(m.createForm.LengthSummary())
+	formFields = append(formFields, contentLabel, m.createForm.ContentView(), contentLength, contentHelp, "")
 
 	// Help text
-	help := CreateGuaranteedHelp("Tab next field • Ctrl+s save • Esc cancel", m.width)
+	help := CreateGuaranteedHelp(i18n.T("form.help"), m.width)
 
 	// Join all elements
 	allElements := []string{headerLine, ""}
@@ -1709,9 +2078,9 @@ func (m Model) renderCreateFromScratchView() string {
 // renderCreateFromTemplateView renders template-based creation
 func (m Model) renderCreateFromTemplateView() string {
 	// Create header with consistent styling
-	headerLine := CreateSubPageHeader( "Create from Template")
+	headerLine := CreateSubPageHeader( i18n.T("create.from_template_title"))
 
-	content := "Template creation form will go here...\n\nPress Esc to go back"
+	content := i18n.T("create.from_template_placeholder")
 
 	return lipgloss.JoinVertical(
 		lipgloss.Left,
@@ -1724,10 +2093,10 @@ func (m Model) renderCreateFromTemplateView() string {
 // renderTemplateListView renders the template selection list using SelectForm
 func (m Model) renderTemplateListView() string {
 	// Create header with consistent styling
-	headerLine := CreateSubPageHeader( "Select Template")
+	headerLine := CreateSubPageHeader( i18n.T("templates.select_title"))
 
 	if m.selectForm == nil || len(m.selectForm.options) == 0 {
-		return lipgloss.JoinVertical(lipgloss.Left, headerLine, "", "No templates available")
+		return lipgloss.JoinVertical(lipgloss.Left, headerLine, "", i18n.T("templates.none"))
 	}
 
 	// Render template options with consistent styling
@@ -1738,8 +2107,8 @@ func (m Model) renderTemplateListView() string {
 		optionLines = append(optionLines, lines...)
 	}
 
-	essential := []string{"↑/↓ navigate • enter select"}
-	additional := []string{"Esc back"}
+	essential := []string{i18n.T("menu.help")}
+	additional := []string{i18n.T("menu.help_back")}
 	help := CreateContextualHelp(essential, additional, m.showExpandedHelp, m.width)
 
 	// Join all elements
@@ -1753,42 +2122,52 @@ func (m Model) renderTemplateListView() string {
 // renderEditPromptView renders the prompt editing form
 func (m Model) renderEditPromptView() string {
 	// Create header with consistent styling
-	headerLine := CreateSubPageHeader( "Edit Prompt")
+	headerLine := CreateSubPageHeader( i18n.T("edit.title"))
 
 	if m.createForm == nil {
-		return lipgloss.JoinVertical(lipgloss.Left, headerLine, "", "No form available")
+		return lipgloss.JoinVertical(lipgloss.Left, headerLine, "", i18n.T("form.unavailable"))
 	}
 
 	// Build form fields
 	var formFields []string
 
 	// Version field
-	versionLabel := StyleFormLabel.Render("Version:")
+	versionLabel := StyleFormLabel.Render(i18n.T("form.version"))
 	formFields = append(formFields, versionLabel, m.createForm.inputs[versionField].View(), "")
 
 	// Title field
-	titleLabel := StyleFormLabel.Render("Title:")
+	titleLabel := StyleFormLabel.Render(i18n.T("form.title"))
 	formFields = append(formFields, titleLabel, m.createForm.inputs[titleField].View(), "")
 
 	// Description field
-	descLabel := StyleFormLabel.Render("Description:")
+	descLabel := StyleFormLabel.Render(i18n.T("form.description"))
 	formFields = append(formFields, descLabel, m.createForm.inputs[descriptionField].View(), "")
 
 	// Tags field
-	tagsLabel := StyleFormLabel.Render("Tags:")
-	tagsHelp := StyleFormHelp.Render("Use comma-separated values for organization and discovery")
+	tagsLabel := StyleFormLabel.Render(i18n.T("form.tags"))
+	tagsHelp := StyleFormHelp.Render(i18n.T("form.tags_help"))
+	if hint := m.createForm.TagSuggestionHint(); hint != "" {
+		tagsHelp = StyleFormHelp.Render(hint)
+	}
 	formFields = append(formFields, tagsLabel, m.createForm.inputs[tagsField].View(), tagsHelp, "")
 
 	// Template reference field
-	templateRefLabel := StyleFormLabel.Render("Template Ref:")
+	templateRefLabel := StyleFormLabel.Render(i18n.T("form.template"))
 	formFields = append(formFields, templateRefLabel, m.createForm.inputs[templateRefField].View(), "")
 
+	// Metadata field
+	metadataLabel := StyleFormLabel.Render(i18n.T("form.metadata"))
+	metadataHelp := StyleFormHelp.Render(i18n.T("form.metadata_help"))
+	formFields = append(formFields, metadataLabel, m.createForm.inputs[metadataField].View(), metadataHelp, "")
+
 	// Content field
-	contentLabel := StyleFormLabel.Render("Content:")
-	formFields = append(formFields, contentLabel, m.createForm.textarea.View(), "")
+	contentLabel := StyleFormLabel.Render(i18n.T("form.content"))
+	contentHelp := StyleFormHelp.Render(i18n.T("form.content_help"))
+	contentLength := StyleFormHelp.Render(m.createForm.LengthSummary())
+	formFields = append(formFields, contentLabel, m.createForm.ContentView(), contentLength, contentHelp, "")
 
 	// Help text
-	help := CreateGuaranteedHelp("Tab next field • Ctrl+s save • Ctrl+d delete • Esc cancel", m.width)
+	help := CreateGuaranteedHelp(i18n.T("edit.help"), m.width)
 
 	// Join all elements
 	allElements := []string{headerLine, ""}
@@ -1801,37 +2180,37 @@ func (m Model) renderEditPromptView() string {
 // renderEditTemplateView renders the template editing form
 func (m Model) renderEditTemplateView() string {
 	// Create header with consistent styling
-	headerLine := CreateSubPageHeader( "Edit Template")
+	headerLine := CreateSubPageHeader( i18n.T("template_edit.title"))
 
 	if m.templateForm == nil {
-		return lipgloss.JoinVertical(lipgloss.Left, headerLine, "", "No form available")
+		return lipgloss.JoinVertical(lipgloss.Left, headerLine, "", i18n.T("form.unavailable"))
 	}
 
 	// Build form fields
 	var formFields []string
 
 	// Version field
-	versionLabel := StyleFormLabel.Render("Version:")
+	versionLabel := StyleFormLabel.Render(i18n.T("form.version"))
 	formFields = append(formFields, versionLabel, m.templateForm.inputs[templateVersionField].View(), "")
 
 	// Name field
-	nameLabel := StyleFormLabel.Render("Name:")
+	nameLabel := StyleFormLabel.Render(i18n.T("form.name"))
 	formFields = append(formFields, nameLabel, m.templateForm.inputs[templateNameField].View(), "")
 
 	// Description field
-	descLabel := StyleFormLabel.Render("Description:")
+	descLabel := StyleFormLabel.Render(i18n.T("form.description"))
 	formFields = append(formFields, descLabel, m.templateForm.inputs[templateDescField].View(), "")
 
 	// Slots field
-	slotsLabel := StyleFormLabel.Render("Slots:")
+	slotsLabel := StyleFormLabel.Render(i18n.T("form.slots"))
 	formFields = append(formFields, slotsLabel, m.templateForm.inputs[templateSlotsField].View(), "")
 
 	// Content field
-	contentLabel := StyleFormLabel.Render("Content:")
+	contentLabel := StyleFormLabel.Render(i18n.T("form.content"))
 	formFields = append(formFields, contentLabel, m.templateForm.textarea.View(), "")
 
 	// Help text
-	help := CreateGuaranteedHelp("Tab next field • arrows navigate • Ctrl+s save • Esc cancel", m.width)
+	help := CreateGuaranteedHelp(i18n.T("template_edit.help"), m.width)
 
 	// Join all elements
 	allElements := []string{headerLine, ""}
@@ -1844,19 +2223,19 @@ func (m Model) renderEditTemplateView() string {
 // renderTemplateDetailView renders template details
 func (m Model) renderTemplateDetailView() string {
 	if m.selectedTemplate == nil {
-		return "No template selected"
+		return i18n.T("template.no_template")
 	}
 
 	// Create header with consistent styling
 	headerLine := CreateSubPageHeader(m.selectedTemplate.Name)
 
 	// Create metadata line
-	metadata := fmt.Sprintf("ID: %s • Version: %s", m.selectedTemplate.ID, m.selectedTemplate.Version)
+	metadata := i18n.T("detail.id_version", m.selectedTemplate.ID, m.selectedTemplate.Version)
 	metadataLine := CreateMetadata(metadata)
 
 	// Help text
-	essential := []string{"e edit"}
-	additional := []string{"Esc back"}
+	essential := []string{i18n.T("detail.template_help")}
+	additional := []string{i18n.T("menu.help_back")}
 	help := CreateContextualHelp(essential, additional, m.showExpandedHelp, m.width)
 
 	// Content (template preview)
@@ -1876,10 +2255,10 @@ func (m Model) renderTemplateDetailView() string {
 // renderTemplateManagementView renders template management menu using SelectForm
 func (m Model) renderTemplateManagementView() string {
 	// Create header with consistent styling
-	headerLine := CreateSubPageHeader( "Template Management")
+	headerLine := CreateSubPageHeader( i18n.T("templates.title"))
 
 	if m.selectForm == nil {
-		return lipgloss.JoinVertical(lipgloss.Left, headerLine, "", "No options available")
+		return lipgloss.JoinVertical(lipgloss.Left, headerLine, "", i18n.T("create.no_options"))
 	}
 
 	// Render options with consistent styling
@@ -1890,8 +2269,8 @@ func (m Model) renderTemplateManagementView() string {
 		optionLines = append(optionLines, lines...)
 	}
 
-	essential := []string{"↑/↓ navigate • enter select"}
-	additional := []string{"Esc back"}
+	essential := []string{i18n.T("menu.help")}
+	additional := []string{i18n.T("menu.help_back")}
 	help := CreateContextualHelp(essential, additional, m.showExpandedHelp, m.width)
 
 	// Join all elements
@@ -1906,32 +2285,32 @@ func (m Model) renderTemplateManagementView() string {
 func (m *Model) renderGHSyncInfoModal() string {
 	// Modal styles
 	modalStyle := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("205")).
+		Border(boxBorder(lipgloss.RoundedBorder())).
+		BorderForeground(ColorPrimary).
 		Padding(1, 2).
 		Width(80).
-		Background(lipgloss.Color("235"))
+		Background(ColorBackground)
 
 	titleStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("205")).
+		Foreground(ColorPrimary).
 		Bold(true).
 		MarginBottom(1)
 
 	headerStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("33")).
+		Foreground(ColorSecondary).
 		Bold(true).
 		MarginTop(1)
 
 	contentStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("252"))
+		Foreground(ColorText)
 
 	codeStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("214")).
-		Background(lipgloss.Color("236")).
+		Foreground(ColorAccent).
+		Background(ColorSurface).
 		Padding(0, 1)
 
 	helpStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("240")).
+		Foreground(ColorTextDim).
 		Italic(true).
 		MarginTop(1)
 
@@ -1940,66 +2319,48 @@ func (m *Model) renderGHSyncInfoModal() string {
 	var plainText []string
 
 	// Title
-	content = append(content, titleStyle.Render("GitHub Sync Information"))
-	plainText = append(plainText, "GitHub Sync Information")
+	content = append(content, titleStyle.Render(i18n.T("sync_info.title")))
+	plainText = append(plainText, i18n.T("sync_info.title"))
 	content = append(content, "")
 	plainText = append(plainText, "")
 
 	// Overview
-	content = append(content, headerStyle.Render("Overview"))
-	plainText = append(plainText, "Overview")
-	content = append(content, contentStyle.Render("Backup and sync your personal prompt library with GitHub."))
-	plainText = append(plainText, "Backup and sync your personal prompt library with GitHub.")
-	content = append(content, contentStyle.Render("This creates a separate repository for YOUR prompts and templates."))
-	plainText = append(plainText, "This creates a separate repository for YOUR prompts and templates.")
+	content = append(content, headerStyle.Render(i18n.T("help.overview")))
+	plainText = append(plainText, i18n.T("help.overview"))
+	content = append(content, contentStyle.Render(i18n.T("sync_info.overview1")))
+	plainText = append(plainText, i18n.T("sync_info.overview1"))
+	content = append(content, contentStyle.Render(i18n.T("sync_info.overview2")))
+	plainText = append(plainText, i18n.T("sync_info.overview2"))
 	content = append(content, "")
 	plainText = append(plainText, "")
 
 	// Setup instructions
-	content = append(content, headerStyle.Render("Setup"))
-	plainText = append(plainText, "Setup")
-	content = append(content, contentStyle.Render("Create a private repo for your prompt library:"))
-	plainText = append(plainText, "Create a private repo for your prompt library:")
-	content = append(content, "   "+codeStyle.Render("cd ~/.pocket-prompt  # Your prompt storage directory"))
-	plainText = append(plainText, "   cd ~/.pocket-prompt  # Your prompt storage directory")
-	content = append(content, "   "+codeStyle.Render("git init"))
-	plainText = append(plainText, "   git init")
-	content = append(content, "   "+codeStyle.Render("gh repo create my-pocket-prompts --private --source=. --remote=origin --push"))
-	plainText = append(plainText, "   gh repo create my-pocket-prompts --private --source=. --remote=origin --push")
-	content = append(content, "")
-	plainText = append(plainText, "")
-	content = append(content, contentStyle.Render("If 'origin' remote already exists:"))
-	plainText = append(plainText, "If 'origin' remote already exists:")
-	content = append(content, "   "+codeStyle.Render("gh repo create your-prompts --private"))
-	plainText = append(plainText, "   gh repo create your-prompts --private")
-	content = append(content, "   "+codeStyle.Render("git remote set-url origin https://github.com/YOUR_USERNAME/your-prompts"))
-	plainText = append(plainText, "   git remote set-url origin https://github.com/YOUR_USERNAME/your-prompts")
-	content = append(content, "   "+codeStyle.Render("git push -u origin main"))
-	plainText = append(plainText, "   git push -u origin main")
+	content = append(content, headerStyle.Render(i18n.T("sync_info.setup")))
+	plainText = append(plainText, i18n.T("sync_info.setup"))
+	content = append(content, contentStyle.Render(i18n.T("sync_info.create")))
+	plainText = append(plainText, i18n.T("sync_info.create"))
+	content = append(content, "   "+codeStyle.Render("pocket-prompt git setup --create my-pocket-prompts --private"))
+	plainText = append(plainText, "   pocket-prompt git setup --create my-pocket-prompts --private")
+	content = append(content, contentStyle.Render(i18n.T("sync_info.create_note")))
+	plainText = append(plainText, i18n.T("sync_info.create_note"))
 	content = append(content, "")
 	plainText = append(plainText, "")
-	content = append(content, contentStyle.Render("Or manually:"))
-	plainText = append(plainText, "Or manually:")
-	content = append(content, contentStyle.Render("1. Create a GitHub repository for your prompts"))
-	plainText = append(plainText, "1. Create a GitHub repository for your prompts")
-	content = append(content, contentStyle.Render("2. Add or update your GitHub repository as remote:"))
-	plainText = append(plainText, "2. Add or update your GitHub repository as remote:")
-	content = append(content, "   "+codeStyle.Render("git remote add origin <your-repo-url>  # or"))
-	plainText = append(plainText, "   git remote add origin <your-repo-url>  # or")
-	content = append(content, "   "+codeStyle.Render("git remote set-url origin <your-repo-url>"))
-	plainText = append(plainText, "   git remote set-url origin <your-repo-url>")
+	content = append(content, contentStyle.Render(i18n.T("sync_info.existing")))
+	plainText = append(plainText, i18n.T("sync_info.existing"))
+	content = append(content, "   "+codeStyle.Render("pocket-prompt git setup <your-repo-url>"))
+	plainText = append(plainText, "   pocket-prompt git setup <your-repo-url>")
 	content = append(content, "")
 	plainText = append(plainText, "")
 
 	// Usage
-	content = append(content, headerStyle.Render("Usage"))
-	plainText = append(plainText, "Usage")
-	content = append(content, contentStyle.Render("• YOUR prompts are stored in ~/.pocket-prompt/prompts/"))
-	plainText = append(plainText, "• YOUR prompts are stored in ~/.pocket-prompt/prompts/")
-	content = append(content, contentStyle.Render("• YOUR templates are stored in ~/.pocket-prompt/templates/"))
-	plainText = append(plainText, "• YOUR templates are stored in ~/.pocket-prompt/templates/")
-	content = append(content, contentStyle.Render("• Sync your prompt library to GitHub:"))
-	plainText = append(plainText, "• Sync your prompt library to GitHub:")
+	content = append(content, headerStyle.Render(i18n.T("sync_info.usage")))
+	plainText = append(plainText, i18n.T("sync_info.usage"))
+	content = append(content, contentStyle.Render(i18n.T("sync_info.prompts_dir")))
+	plainText = append(plainText, i18n.T("sync_info.prompts_dir"))
+	content = append(content, contentStyle.Render(i18n.T("sync_info.templates_dir")))
+	plainText = append(plainText, i18n.T("sync_info.templates_dir"))
+	content = append(content, contentStyle.Render(i18n.T("sync_info.sync")))
+	plainText = append(plainText, i18n.T("sync_info.sync"))
 	content = append(content, "   "+codeStyle.Render("cd ~/.pocket-prompt"))
 	plainText = append(plainText, "   cd ~/.pocket-prompt")
 	content = append(content, "   "+codeStyle.Render("git add -A && git commit -m 'Update prompts'"))
@@ -2010,26 +2371,26 @@ func (m *Model) renderGHSyncInfoModal() string {
 	plainText = append(plainText, "")
 
 	// Benefits
-	content = append(content, headerStyle.Render("Benefits"))
-	plainText = append(plainText, "Benefits")
-	content = append(content, contentStyle.Render("✓ Version history for all prompts"))
-	plainText = append(plainText, "✓ Version history for all prompts")
-	content = append(content, contentStyle.Render("✓ Collaborate with team members"))
-	plainText = append(plainText, "✓ Collaborate with team members")
-	content = append(content, contentStyle.Render("✓ Backup and restore capability"))
-	plainText = append(plainText, "✓ Backup and restore capability")
-	content = append(content, contentStyle.Render("✓ Review changes before committing"))
-	plainText = append(plainText, "✓ Review changes before committing")
+	content = append(content, headerStyle.Render(i18n.T("sync_info.benefits")))
+	plainText = append(plainText, i18n.T("sync_info.benefits"))
+	content = append(content, contentStyle.Render(i18n.T("sync_info.benefit_history")))
+	plainText = append(plainText, i18n.T("sync_info.benefit_history"))
+	content = append(content, contentStyle.Render(i18n.T("sync_info.benefit_team")))
+	plainText = append(plainText, i18n.T("sync_info.benefit_team"))
+	content = append(content, contentStyle.Render(i18n.T("sync_info.benefit_backup")))
+	plainText = append(plainText, i18n.T("sync_info.benefit_backup"))
+	content = append(content, contentStyle.Render(i18n.T("sync_info.benefit_review")))
+	plainText = append(plainText, i18n.T("sync_info.benefit_review"))
 	content = append(content, "")
 	plainText = append(plainText, "")
 
 	// Help text
-	content = append(content, helpStyle.Render("Press c to copy • ESC or ? to close"))
+	content = append(content, helpStyle.Render(i18n.T("sync_info.help")))
 	
 	// Add status message if present
 	if m.statusMsg != "" {
 		statusStyle := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("2")).
+			Foreground(ColorSuccess).
 			Bold(true).
 			MarginTop(1)
 		content = append(content, statusStyle.Render(m.statusMsg))
@@ -2061,7 +2422,7 @@ func (m *Model) renderHelpModal() string {
 	maxHeight := min(25, m.height-4) // Constrained height to enable scrolling
 	
 	modalStyle := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
+		Border(boxBorder(lipgloss.RoundedBorder())).
 		Padding(1, 2).
 		Width(maxWidth).
 		Height(maxHeight)
@@ -2091,31 +2452,34 @@ func (m *Model) renderHelpModal() string {
 	var plainText []string
 
 	// Title
-	content = append(content, titleStyle.Render("Pocket Prompt - Help"))
-	plainText = append(plainText, "Pocket Prompt - Help")
+	content = append(content, titleStyle.Render(i18n.T("help.title")))
+	plainText = append(plainText, i18n.T("help.title"))
 	content = append(content, "")
 	plainText = append(plainText, "")
 
 	// Overview
-	content = append(content, headerStyle.Render("Overview"))
-	plainText = append(plainText, "Overview")
-	content = append(content, contentStyle.Render("A fast, keyboard-driven terminal app for managing AI prompts and templates."))
-	plainText = append(plainText, "A fast, keyboard-driven terminal app for managing AI prompts and templates.")
-	content = append(content, contentStyle.Render("Store, organize, search, and copy prompts with powerful tagging and templates."))
-	plainText = append(plainText, "Store, organize, search, and copy prompts with powerful tagging and templates.")
+	content = append(content, headerStyle.Render(i18n.T("help.overview")))
+	plainText = append(plainText, i18n.T("help.overview"))
+	content = append(content, contentStyle.Render(i18n.T("help.overview1")))
+	plainText = append(plainText, i18n.T("help.overview1"))
+	content = append(content, contentStyle.Render(i18n.T("help.overview2")))
+	plainText = append(plainText, i18n.T("help.overview2"))
 	content = append(content, "")
 	plainText = append(plainText, "")
 
 	// Navigation & Basic Commands
-	content = append(content, headerStyle.Render("Navigation & Basic Commands"))
-	plainText = append(plainText, "Navigation & Basic Commands")
+	content = append(content, headerStyle.Render(i18n.T("help.navigation")))
+	plainText = append(plainText, i18n.T("help.navigation"))
 	
 	keys := [][]string{
-		{"↑/↓", "Navigate lists and prompts"},
-		{"Enter", "Select item / View prompt details"},
-		{"b", "Go back / Close modals"},
-		{"q", "Quit application"},
-		{"?", "Toggle this help modal"},
+		{"↑/↓", i18n.T("help.key_navigate")},
+		{"Enter", i18n.T("help.key_select")},
+		{"Esc/←", i18n.T("help.key_back")},
+		{"]", i18n.T("help.key_forward")},
+		{"q", i18n.T("help.key_quit")},
+		{"?", i18n.T("help.key_help")},
+		{"v", i18n.T("help.key_preview")},
+		{"< / >", i18n.T("help.key_resize")},
 	}
 	
 	for _, kv := range keys {
@@ -2127,16 +2491,26 @@ func (m *Model) renderHelpModal() string {
 	plainText = append(plainText, "")
 
 	// Prompt Management
-	content = append(content, headerStyle.Render("Prompt Management"))
-	plainText = append(plainText, "Prompt Management")
+	content = append(content, headerStyle.Render(i18n.T("help.prompts")))
+	plainText = append(plainText, i18n.T("help.prompts"))
 	
 	promptKeys := [][]string{
-		{"n", "Create new prompt (from scratch or template)"},
-		{"e", "Edit selected prompt"},
-		{"c", "Copy prompt as plain text"},
-		{"y", "Copy prompt as JSON messages for LLM APIs"},
-		{"Ctrl+s", "Save prompt when editing"},
-		{"Ctrl+d", "Delete prompt (press twice to confirm)"},
+		{"n", i18n.T("help.key_new")},
+		{"e", i18n.T("help.key_edit")},
+		{"E", i18n.T("help.key_editor")},
+		{"D", i18n.T("help.key_duplicate")},
+		{"c", i18n.T("help.key_copy")},
+		{"y", i18n.T("help.key_copy_json")},
+		{"r", i18n.T("help.key_copy_rich")},
+		{"C", i18n.T("help.key_fill")},
+		{"Ctrl+s", i18n.T("help.key_save")},
+		{"Ctrl+o", i18n.T("help.key_form_preview")},
+		{"Ctrl+d", i18n.T("help.key_delete")},
+		{"S", i18n.T("help.key_sync")},
+		{"C", i18n.T("help.key_conflicts")},
+		{"=", i18n.T("help.key_duplicates")},
+		{"s", i18n.T("help.key_snippets")},
+		{"1-5", i18n.T("help.key_rate")},
 	}
 	
 	for _, kv := range promptKeys {
@@ -2148,15 +2522,17 @@ func (m *Model) renderHelpModal() string {
 	plainText = append(plainText, "")
 
 	// Search & Discovery
-	content = append(content, headerStyle.Render("Search & Discovery"))
-	plainText = append(plainText, "Search & Discovery")
+	content = append(content, headerStyle.Render(i18n.T("help.search")))
+	plainText = append(plainText, i18n.T("help.search"))
 	
 	searchKeys := [][]string{
-		{"/", "Start fuzzy search (type to filter prompts)"},
-		{"Ctrl+f", "Advanced boolean search with tags"},
-		{"f", "View and execute saved searches"},
-		{"Tab", "Switch focus in boolean search"},
-		{"Ctrl+s", "Save current boolean search"},
+		{"/", i18n.T("help.key_search")},
+		{"#", i18n.T("help.key_tag_filter")},
+		{"Ctrl+f", i18n.T("help.key_boolean")},
+		{"f", i18n.T("help.key_saved")},
+		{"p", i18n.T("help.key_pin")},
+		{"Tab", i18n.T("help.key_tab")},
+		{"Ctrl+s", i18n.T("help.key_save_search")},
 	}
 	
 	for _, kv := range searchKeys {
@@ -2168,27 +2544,27 @@ func (m *Model) renderHelpModal() string {
 	plainText = append(plainText, "")
 
 	// Templates
-	content = append(content, headerStyle.Render("Templates"))
-	plainText = append(plainText, "Templates")
+	content = append(content, headerStyle.Render(i18n.T("help.templates")))
+	plainText = append(plainText, i18n.T("help.templates"))
 	
-	content = append(content, contentStyle.Render(keyStyle.Render("t")+" Manage templates (create, edit, view)"))
-	plainText = append(plainText, "t Manage templates (create, edit, view)")
-	content = append(content, contentStyle.Render("Templates are reusable prompt scaffolds with variable slots"))
-	plainText = append(plainText, "Templates are reusable prompt scaffolds with variable slots")
-	content = append(content, contentStyle.Render("Use {{variable_name}} syntax for substitution"))
-	plainText = append(plainText, "Use {{variable_name}} syntax for substitution")
+	content = append(content, contentStyle.Render(keyStyle.Render("t")+" "+i18n.T("help.key_templates")))
+	plainText = append(plainText, "t "+i18n.T("help.key_templates"))
+	content = append(content, contentStyle.Render(i18n.T("help.templates1")))
+	plainText = append(plainText, i18n.T("help.templates1"))
+	content = append(content, contentStyle.Render(i18n.T("help.templates2")))
+	plainText = append(plainText, i18n.T("help.templates2"))
 	content = append(content, "")
 	plainText = append(plainText, "")
 
 	// Boolean Search Examples
-	content = append(content, headerStyle.Render("Boolean Search Examples"))
-	plainText = append(plainText, "Boolean Search Examples")
+	content = append(content, headerStyle.Render(i18n.T("help.boolean_examples")))
+	plainText = append(plainText, i18n.T("help.boolean_examples"))
 	
 	examples := []string{
-		"ai AND writing    - Find prompts tagged with both 'ai' and 'writing'",
-		"code OR python    - Find prompts with either 'code' or 'python' tags",
-		"NOT draft         - Exclude prompts tagged as 'draft'",
-		"(ai OR ml) AND analysis - Complex expressions with parentheses",
+		"ai AND writing    - " + i18n.T("help.example_and"),
+		"code OR python    - " + i18n.T("help.example_or"),
+		"NOT draft         - " + i18n.T("help.example_not"),
+		"(ai OR ml) AND analysis - " + i18n.T("help.example_group"),
 	}
 	
 	for _, example := range examples {
@@ -2199,15 +2575,15 @@ func (m *Model) renderHelpModal() string {
 	plainText = append(plainText, "")
 
 	// File Organization
-	content = append(content, headerStyle.Render("File Organization"))
-	plainText = append(plainText, "File Organization")
+	content = append(content, headerStyle.Render(i18n.T("help.files")))
+	plainText = append(plainText, i18n.T("help.files"))
 	
 	orgInfo := []string{
-		"Storage: ~/.pocket-prompt/ (or POCKET_PROMPT_DIR)",
-		"Prompts: Stored as Markdown files with YAML frontmatter",
-		"Templates: Reusable scaffolds in templates/ directory", 
-		"Archives: Old versions kept in archive/ for history",
-		"Sync: Optional Git integration for backup and collaboration",
+		i18n.T("help.files_storage"),
+		i18n.T("help.files_prompts"),
+		i18n.T("help.files_templates"), 
+		i18n.T("help.files_archives"),
+		i18n.T("help.files_sync"),
 	}
 	
 	for _, info := range orgInfo {
@@ -2218,16 +2594,16 @@ func (m *Model) renderHelpModal() string {
 	plainText = append(plainText, "")
 
 	// Tips
-	content = append(content, headerStyle.Render("Pro Tips"))
-	plainText = append(plainText, "Pro Tips")
+	content = append(content, headerStyle.Render(i18n.T("help.tips")))
+	plainText = append(plainText, i18n.T("help.tips"))
 	
 	tips := []string{
-		"• Use descriptive tags for better organization and search",
-		"• Templates save time for similar prompt structures",
-		"• Boolean search is powerful for large prompt libraries",
-		"• JSON copy format works directly with LLM API calls",
-		"• All operations are keyboard-driven for speed",
-		"• Version history preserved when editing prompts",
+		i18n.T("help.tip_tags"),
+		i18n.T("help.tip_templates"),
+		i18n.T("help.tip_boolean"),
+		i18n.T("help.tip_json"),
+		i18n.T("help.tip_keyboard"),
+		i18n.T("help.tip_history"),
 	}
 	
 	for _, tip := range tips {
@@ -2238,7 +2614,7 @@ func (m *Model) renderHelpModal() string {
 	plainText = append(plainText, "")
 
 	// Help text
-	content = append(content, descStyle.Render("Press c to copy • ↑/↓ to scroll • ESC or ? to close"))
+	content = append(content, descStyle.Render(i18n.T("help.close")))
 	
 	// Add status message if present
 	if m.statusMsg != "" {
@@ -2271,6 +2647,14 @@ func (m *Model) renderHelpModal() string {
 	)
 }
 
+// recordUsage counts a copy of the selected prompt for ranking by usage.
+// Usage is best-effort, so failures don't replace the copy status.
+func (m Model) recordUsage() {
+	if m.selectedPrompt != nil {
+		_ = m.service.RecordUsage(m.selectedPrompt.ID)
+	}
+}
+
 // refreshPromptList refreshes the prompt list, respecting any active boolean search filter
 func (m *Model) refreshPromptList() error {
 	var prompts []*models.Prompt
@@ -2291,18 +2675,82 @@ func (m *Model) refreshPromptList() error {
 	}
 
 	// Update the model state
+	models.SortByNamespace(prompts)
 	m.prompts = prompts
+	m.loadIssues = m.service.ValidationIssues()
+	m.conflicts, _ = m.service.ListConflicts()
 	
 	// Update list items
-	items := make([]list.Item, len(prompts))
-	for i, p := range prompts {
-		items[i] = p
-	}
-	m.promptList.SetItems(items)
+	m.setLibraryItems(prompts)
+	m.preview.promptID = ""
+	m.updateLibraryPreview()
+	m.gitStatusStale = true
 	
 	return nil
 }
 
+// showSavedPrompt updates the library for a prompt just created or
+// updated, without listing the library again. Under a boolean search the
+// prompt may have started or stopped matching, so the list is refreshed.
+func (m *Model) showSavedPrompt(prompt *models.Prompt) error {
+	if m.currentExpression != nil {
+		return m.refreshPromptList()
+	}
+
+	prompts := slices.Clone(m.prompts)
+	if i := slices.IndexFunc(prompts, func(p *models.Prompt) bool { return p.ID == prompt.ID }); i >= 0 {
+		prompts[i] = prompt
+	} else {
+		// Listings are ordered by namespace, then by file
+		i = slices.IndexFunc(prompts, func(p *models.Prompt) bool {
+			if p.Namespace() != prompt.Namespace() {
+				return p.Namespace() > prompt.Namespace()
+			}
+			return p.FilePath > prompt.FilePath
+		})
+		if i < 0 {
+			i = len(prompts)
+		}
+		prompts = slices.Insert(prompts, i, prompt)
+	}
+	m.prompts = prompts
+
+	if i := m.promptItemIndex(prompt.ID); i >= 0 && m.matchesTagFilter(prompt) {
+		m.promptList.SetItem(i, prompt)
+	} else {
+		m.setLibraryItems(prompts)
+	}
+	m.preview.promptID = ""
+	m.updateLibraryPreview()
+	m.gitStatusStale = true
+	return nil
+}
+
+// removeDeletedPrompts takes deleted prompts out of the library without
+// listing it again
+func (m *Model) removeDeletedPrompts(ids ...string) {
+	m.prompts = slices.DeleteFunc(slices.Clone(m.prompts), func(p *models.Prompt) bool {
+		return slices.Contains(ids, p.ID)
+	})
+	for _, id := range ids {
+		if i := m.promptItemIndex(id); i >= 0 {
+			m.promptList.RemoveItem(i)
+		}
+	}
+	m.preview.promptID = ""
+	m.updateLibraryPreview()
+	m.gitStatusStale = true
+}
+
+// promptItemIndex returns the position of a prompt among the list items,
+// or -1 when it isn't listed
+func (m Model) promptItemIndex(id string) int {
+	return slices.IndexFunc(m.promptList.Items(), func(item list.Item) bool {
+		p, ok := item.(*models.Prompt)
+		return ok && p.ID == id
+	})
+}
+
 // renderPreview renders the selected prompt for preview
 func (m *Model) renderPreview() error {
 	if m.selectedPrompt == nil {
@@ -2311,6 +2759,9 @@ func (m *Model) renderPreview() error {
 
 	// Create a renderer for the prompt
 	r := renderer.NewRenderer(m.selectedPrompt, nil)
+	r.SetAttachmentLoader(m.service.LoadAttachment)
+	r.SetSnippetLoader(m.service.LoadSnippet)
+	r.SetSecretLoader(m.service.LoadSecret)
 
 	// Render with no variables
 	rendered, err := r.RenderText(nil)
@@ -2325,10 +2776,32 @@ func (m *Model) renderPreview() error {
 		renderedJSON = ""
 	}
 
+	// Secret values are copied but masked on screen
+	display := rendered
+	if len(r.SecretVariables()) > 0 {
+		r.SetSecretLoader(func(context.Context, string) (string, error) { return "••••••", nil })
+		if masked, err := r.RenderText(nil); err == nil {
+			display = masked
+		}
+	}
+
+	// Notes are shown after the prompt but left out of what is copied
+	if notes := strings.TrimSpace(m.selectedPrompt.Notes); notes != "" {
+		display += "\n\n---\n\n## Notes\n\n" + notes
+	}
+
 	// Format with glamour for display
-	formatted, err := m.glamourRenderer.Render(rendered)
-	if err != nil {
-		formatted = rendered
+	formatted := display
+	if m.glamourRenderer == nil {
+		// Built on first use, as it takes a while and isn't needed to show the library
+		if renderer, err := createGlamourRenderer(m.glamourWidth); err == nil {
+			m.glamourRenderer = renderer
+		}
+	}
+	if m.glamourRenderer != nil {
+		if rendered, err := m.glamourRenderer.Render(display); err == nil {
+			formatted = rendered
+		}
 	}
 
 	m.renderedContent = rendered
@@ -2341,10 +2814,10 @@ func (m *Model) renderPreview() error {
 // renderSavedSearchesView renders the saved searches interface
 func (m Model) renderSavedSearchesView() string {
 	// Create header with consistent styling
-	headerLine := CreateSubPageHeader( "Saved Boolean Searches")
+	headerLine := CreateSubPageHeader( i18n.T("saved.title"))
 
 	if m.selectForm == nil || len(m.selectForm.options) == 0 {
-		return lipgloss.JoinVertical(lipgloss.Left, headerLine, "", "No saved searches available")
+		return lipgloss.JoinVertical(lipgloss.Left, headerLine, "", i18n.T("saved.no_searches"))
 	}
 
 	// Render saved search options with consistent styling
@@ -2355,8 +2828,8 @@ func (m Model) renderSavedSearchesView() string {
 		optionLines = append(optionLines, lines...)
 	}
 
-	essential := []string{"↑/↓ navigate • enter execute • e edit"}
-	additional := []string{"Ctrl+d delete • Esc back"}
+	essential := []string{i18n.T("saved.help")}
+	additional := []string{i18n.T("saved.help_more")}
 	help := CreateContextualHelp(essential, additional, m.showExpandedHelp, m.width)
 
 	// Join all elements
@@ -0,0 +1,363 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/paginator"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/dpshade/pocket-prompt/internal/models"
+
+	"github.com/dpshade/pocket-prompt/internal/service"
+	"github.com/dpshade/pocket-prompt/internal/storage"
+)
+
+func TestRefreshGitStatusRunsOneFetchAtATime(t *testing.T) {
+	t.Setenv("POCKET_PROMPT_DIR", t.TempDir())
+
+	svc, err := service.NewService()
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+
+	m := Model{service: svc, gitStatusStale: true}
+	cmd := m.refreshGitStatus()
+	if cmd == nil || !m.gitStatusPending || m.gitStatusStale {
+		t.Fatalf("Expected a pending fetch, got pending=%v stale=%v", m.gitStatusPending, m.gitStatusStale)
+	}
+	if m.refreshGitStatus() != nil {
+		t.Error("Expected no second fetch while one is running")
+	}
+
+	msg, ok := cmd().(gitSyncStatusMsg)
+	if !ok || msg.err != nil || msg.status != "Git not initialized" {
+		t.Errorf("Unexpected status message %+v", msg)
+	}
+}
+
+func TestPreviewPaneLayoutIsSaved(t *testing.T) {
+	t.Setenv("POCKET_PROMPT_DIR", t.TempDir())
+
+	svc, err := service.NewService()
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+
+	m := Model{service: svc, promptList: list.New(nil, list.NewDefaultDelegate(), 0, 0), preview: newPreviewPane(svc.UIConfig()), width: 120, height: 40}
+	if !m.previewVisible() || m.preview.percent != defaultPreviewPercent {
+		t.Fatalf("Expected the preview shown at %d%%, got shown=%v at %d%%", defaultPreviewPercent, m.preview.show, m.preview.percent)
+	}
+
+	m.resizePreview(10)
+	if m.preview.percent != maxPreviewPercent {
+		t.Errorf("Expected the preview to stop at %d%%, got %d%%", maxPreviewPercent, m.preview.percent)
+	}
+	m.togglePreview()
+	if m.previewVisible() {
+		t.Error("Expected the preview to be hidden")
+	}
+	if listWidth, previewWidth := m.libraryPaneWidths(); previewWidth != 0 || listWidth != 116 {
+		t.Errorf("Expected the list to take the whole width, got %d and %d", listWidth, previewWidth)
+	}
+
+	reloaded, err := service.NewService()
+	if err != nil {
+		t.Fatalf("Failed to reload service: %v", err)
+	}
+	if ui := reloaded.UIConfig(); !ui.HidePreview || ui.PreviewWidth != maxPreviewPercent {
+		t.Errorf("Expected the layout to be saved, got %+v", ui)
+	}
+
+	m.width = 60
+	m.togglePreview()
+	if m.previewVisible() {
+		t.Error("Expected no preview in a narrow window")
+	}
+}
+
+func TestNavigationHistory(t *testing.T) {
+	t.Setenv("POCKET_PROMPT_DIR", t.TempDir())
+
+	svc, err := service.NewService()
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+	prompt := &models.Prompt{ID: "review", Name: "Code Review", Version: "1.0.0", Content: "Review this code"}
+	if err := svc.CreatePrompt(prompt); err != nil {
+		t.Fatalf("Failed to create prompt: %v", err)
+	}
+	renderer, err := createGlamourRenderer(60)
+	if err != nil {
+		t.Fatalf("Failed to create renderer: %v", err)
+	}
+
+	m := Model{service: svc, glamourRenderer: renderer}
+	m.navigate(ViewPromptDetail)
+	m.selectedPrompt = prompt
+	m.navigate(ViewEditPrompt)
+	m.createForm = NewCreateForm()
+	m.createForm.LoadPrompt(prompt)
+	form := m.createForm
+
+	if _, ok := m.goBack(); !ok || m.viewMode != ViewPromptDetail || m.selectedPrompt.ID != "review" {
+		t.Fatalf("Expected to go back to the prompt, got view %d", m.viewMode)
+	}
+	if _, ok := m.goBack(); !ok || m.viewMode != ViewLibrary {
+		t.Fatalf("Expected to go back to the library, got view %d", m.viewMode)
+	}
+	if _, ok := m.goBack(); ok {
+		t.Error("Expected nothing before the library")
+	}
+	m.goForward()
+	if _, ok := m.goForward(); !ok || m.viewMode != ViewEditPrompt || m.createForm != form {
+		t.Fatalf("Expected to go forward to the same form, got view %d", m.viewMode)
+	}
+
+	// Closing the form after deleting its prompt skips the prompt's screen
+	if err := svc.DeletePrompt("review"); err != nil {
+		t.Fatalf("Failed to delete prompt: %v", err)
+	}
+	m.closeScreen()
+	if m.viewMode != ViewLibrary || len(m.future) != 0 {
+		t.Errorf("Expected to land on the library with nothing to go forward to, got view %d", m.viewMode)
+	}
+}
+
+func TestTagQuickFilter(t *testing.T) {
+	t.Setenv("POCKET_PROMPT_DIR", t.TempDir())
+
+	svc, err := service.NewService()
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+	for _, prompt := range []*models.Prompt{
+		{ID: "review", Name: "Code Review", Version: "1.0.0", Content: "Review this code", Tags: []string{"code", "review"}},
+		{ID: "summary", Name: "Summary", Version: "1.0.0", Content: "Summarize this", Tags: []string{"writing"}},
+	} {
+		if err := svc.CreatePrompt(prompt); err != nil {
+			t.Fatalf("Failed to create prompt: %v", err)
+		}
+	}
+	prompts, err := svc.ListPrompts()
+	if err != nil {
+		t.Fatalf("Failed to list prompts: %v", err)
+	}
+
+	m := Model{service: svc, prompts: prompts, promptList: list.New(nil, list.NewDefaultDelegate(), 0, 0)}
+	m, _ = m.openTagChooser()
+	if m.tagChooser == nil {
+		t.Fatal("Expected the tag chooser to open")
+	}
+	for _, r := range "writ" {
+		m, _ = m.updateTagChooser(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	if len(m.tagChooser.matches) != 1 || m.tagChooser.matches[0].tag != "writing" {
+		t.Fatalf("Expected typing to narrow the tags to writing, got %v", m.tagChooser.matches)
+	}
+
+	m, _ = m.updateTagChooser(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.tagChooser != nil || m.tagFilter != "writing" {
+		t.Fatalf("Expected the writing filter applied, got %q", m.tagFilter)
+	}
+	items := m.promptList.Items()
+	if len(items) != 1 || items[0].(*models.Prompt).ID != "summary" {
+		t.Errorf("Expected only the summary prompt, got %d items", len(items))
+	}
+
+	m, _ = m.openTagChooser()
+	m, _ = m.updateTagChooser(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("writing")})
+	m, _ = m.updateTagChooser(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.tagFilter != "" || len(m.promptList.Items()) != 2 {
+		t.Errorf("Expected choosing the applied tag to clear the filter, got %q with %d items", m.tagFilter, len(m.promptList.Items()))
+	}
+}
+
+func TestVariableFillWalksVariables(t *testing.T) {
+	t.Setenv("POCKET_PROMPT_DIR", t.TempDir())
+
+	svc, err := service.NewService()
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+	prompt := &models.Prompt{
+		ID:        "greet",
+		Name:      "Greeting",
+		Version:   "1.0.0",
+		Content:   "Hello {{name}}, welcome to {{place}}",
+		Variables: []models.Slot{{Name: "place", Default: "home"}},
+	}
+	if err := svc.CreatePrompt(prompt); err != nil {
+		t.Fatalf("Failed to create prompt: %v", err)
+	}
+
+	m := Model{service: svc, viewMode: ViewPromptDetail, selectedPrompt: prompt}
+	m, _ = m.openVariableFill()
+	if m.variableFill == nil || len(m.variableFill.fields) != 2 || m.variableFill.fields[0].Name != "place" {
+		t.Fatalf("Expected to be asked for place, then name")
+	}
+	if m.variableFill.input.Placeholder != "home" {
+		t.Errorf("Expected the default as placeholder, got %q", m.variableFill.input.Placeholder)
+	}
+
+	m, _ = m.updateVariableFill(tea.KeyMsg{Type: tea.KeyEnter})
+	m, _ = m.updateVariableFill(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("Ada")})
+	m, _ = m.updateVariableFill(tea.KeyMsg{Type: tea.KeyShiftTab})
+	if m.variableFill.index != 0 {
+		t.Fatalf("Expected Shift+Tab to go back to place, got field %d", m.variableFill.index)
+	}
+	m, _ = m.updateVariableFill(tea.KeyMsg{Type: tea.KeyEnter})
+	if got := m.variableFill.input.Value(); got != "Ada" {
+		t.Errorf("Expected the answer kept when going back, got %q", got)
+	}
+
+	m, _ = m.updateVariableFill(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.variableFill != nil || m.statusMsg != "Cancelled" {
+		t.Errorf("Expected Esc to cancel, got status %q", m.statusMsg)
+	}
+}
+
+func TestExternalEditSavesNewVersion(t *testing.T) {
+	t.Setenv("POCKET_PROMPT_DIR", t.TempDir())
+
+	svc, err := service.NewService()
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+	prompt := &models.Prompt{ID: "review", Name: "Code Review", Version: "1.0.0", Content: "Review this code"}
+	if err := svc.CreatePrompt(prompt); err != nil {
+		t.Fatalf("Failed to create prompt: %v", err)
+	}
+	prompt, err = svc.GetPrompt("review")
+	if err != nil {
+		t.Fatalf("Failed to get prompt: %v", err)
+	}
+	renderer, err := createGlamourRenderer(60)
+	if err != nil {
+		t.Fatalf("Failed to create renderer: %v", err)
+	}
+
+	m := Model{service: svc, glamourRenderer: renderer, promptList: list.New(nil, list.NewDefaultDelegate(), 0, 0), viewMode: ViewPromptDetail, selectedPrompt: prompt}
+	path, err := m.writePromptSource(prompt)
+	if err != nil {
+		t.Fatalf("Failed to write prompt source: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read prompt source: %v", err)
+	}
+	edited := strings.Replace(string(data), "Review this code", "Review this code carefully", 1)
+	if err := os.WriteFile(path, []byte(edited), 0644); err != nil {
+		t.Fatalf("Failed to edit prompt source: %v", err)
+	}
+
+	m, _ = m.finishExternalEdit(editorFinishedMsg{prompt: prompt, path: path})
+	if m.selectedPrompt.Version == "1.0.0" || !strings.Contains(m.renderedContent, "carefully") {
+		t.Errorf("Expected a new version with the edit shown, got v%s: %q", m.selectedPrompt.Version, m.renderedContent)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("Expected the temporary copy to be removed")
+	}
+}
+
+func TestSavedPromptsUpdateListInPlace(t *testing.T) {
+	t.Setenv("POCKET_PROMPT_DIR", t.TempDir())
+
+	svc, err := service.NewService()
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+	review := &models.Prompt{ID: "review", Name: "Code Review", Version: "1.0.0", FilePath: "prompts/review.md"}
+	summary := &models.Prompt{ID: "summary", Name: "Summary", Version: "1.0.0", FilePath: "prompts/summary.md"}
+	m := Model{service: svc, prompts: []*models.Prompt{review, summary}, promptList: list.New(nil, list.NewDefaultDelegate(), 0, 0)}
+	m.promptList.SetItems(m.libraryItems(m.prompts))
+	m.promptList.Select(1)
+
+	updated := &models.Prompt{ID: "summary", Name: "Short Summary", Version: "1.0.1", FilePath: "prompts/summary.md"}
+	if err := m.showSavedPrompt(updated); err != nil {
+		t.Fatalf("Failed to show saved prompt: %v", err)
+	}
+	if m.promptList.Index() != 1 || m.promptList.SelectedItem().(*models.Prompt).Name != "Short Summary" {
+		t.Errorf("Expected the updated prompt in place and still selected")
+	}
+
+	created := &models.Prompt{ID: "plan", Name: "Plan", Version: "1.0.0", FilePath: "prompts/plan.md"}
+	if err := m.showSavedPrompt(created); err != nil {
+		t.Fatalf("Failed to show created prompt: %v", err)
+	}
+	if len(m.prompts) != 3 || m.prompts[0].ID != "plan" || m.promptItemIndex("plan") != 0 {
+		t.Errorf("Expected the new prompt listed in file order")
+	}
+
+	m.removeDeletedPrompts("review")
+	if len(m.prompts) != 2 || m.promptItemIndex("review") != -1 || len(m.promptList.Items()) != 2 {
+		t.Errorf("Expected the deleted prompt gone from the list")
+	}
+}
+
+func TestLargeLibraryPagesByNumber(t *testing.T) {
+	t.Setenv("POCKET_PROMPT_DIR", t.TempDir())
+
+	svc, err := service.NewService()
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+	m := Model{service: svc, promptList: list.New(nil, list.NewDefaultDelegate(), 0, 0), width: 80, height: 30}
+
+	few := []*models.Prompt{{ID: "a", Name: "A"}, {ID: "b", Name: "B"}}
+	m.setLibraryItems(few)
+	if m.promptList.Paginator.Type != paginator.Dots {
+		t.Error("Expected a small library paged with dots")
+	}
+
+	many := make([]*models.Prompt, 10000)
+	for i := range many {
+		many[i] = &models.Prompt{ID: fmt.Sprintf("p%d", i), Name: fmt.Sprintf("Prompt %d", i)}
+	}
+	m.setLibraryItems(many)
+	m.resizeLibrary()
+	if m.promptList.Paginator.Type != paginator.Arabic {
+		t.Error("Expected a large library paged by number")
+	}
+	if view := m.promptList.View(); !strings.Contains(view, "/") {
+		t.Errorf("Expected a page count in the list view")
+	}
+}
+
+func TestPaginationDotsFitPages(t *testing.T) {
+	m := Model{promptList: list.New(nil, list.NewDefaultDelegate(), 0, 0), width: 40, height: 30}
+	m.promptList.Paginator.PerPage = 10
+
+	m.fitPagination(100)
+	if m.promptList.Paginator.Type != paginator.Dots {
+		t.Error("Expected 10 pages to fit as dots in 40 columns")
+	}
+	m.fitPagination(500)
+	if m.promptList.Paginator.Type != paginator.Arabic {
+		t.Error("Expected 50 pages to be paged by number in 40 columns")
+	}
+}
+
+func TestIssuesRevalidate(t *testing.T) {
+	store := storage.NewMemoryStorage("")
+	store.WriteFile("prompts/broken.md", []byte("---\nid: broken\ntags: [unclosed\n---\nBody"))
+	svc := service.NewServiceWithBackend(store)
+
+	loaded := loadPromptsCmd(svc)().(loadCompleteMsg)
+	if len(loaded.issues) != 1 {
+		t.Fatalf("Expected one problem, got %v", loaded.issues)
+	}
+
+	store.WriteFile("prompts/broken.md", []byte("---\nid: broken\ntags: [fixed]\n---\nBody"))
+	m := Model{service: svc, showIssues: true, loadIssues: loaded.issues}
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	if cmd == nil {
+		t.Fatal("Expected r to check the files again")
+	}
+	reloaded, ok := cmd().(loadCompleteMsg)
+	if !ok || len(reloaded.issues) != 0 || len(reloaded.prompts) != 1 {
+		t.Errorf("Expected the fixed prompt without problems, got %+v", reloaded)
+	}
+}
@@ -0,0 +1,176 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+
+	"github.com/dpshade/pocket-prompt/internal/i18n"
+	"github.com/dpshade/pocket-prompt/internal/models"
+)
+
+// ErrPickCancelled is returned by RunPicker when the picker is closed
+// without choosing a prompt
+var ErrPickCancelled = errors.New("no prompt picked")
+
+// maxPickerRows is how many matches the picker shows at once
+const maxPickerRows = 10
+
+// Picker is a minimal fuzzy prompt selector drawn inline, for the pick
+// command and other tools that only need a prompt chosen
+type Picker struct {
+	service Library
+	input   textinput.Model
+	matches []*models.Prompt
+	cursor  int
+	offset  int
+	rows    int
+	chosen  *models.Prompt
+	done    bool
+	err     error
+
+	selectedStyle lipgloss.Style
+	dimStyle      lipgloss.Style
+}
+
+// NewPicker creates a picker listing the prompts matching query, drawing
+// its styles for the terminal it writes to
+func NewPicker(svc Library, query string, out io.Writer) *Picker {
+	input := textinput.New()
+	input.Prompt = "> "
+	input.Placeholder = i18n.T("picker.placeholder")
+	input.SetValue(query)
+	input.Focus()
+
+	style := lipgloss.NewRenderer(out)
+	if lipgloss.ColorProfile() == termenv.Ascii {
+		// --no-color and --plain hold for the picker's terminal too
+		style.SetColorProfile(termenv.Ascii)
+	}
+	initializeColors(svc.UIConfig().Theme, style)
+	p := &Picker{
+		service:       svc,
+		input:         input,
+		rows:          maxPickerRows,
+		selectedStyle: style.NewStyle().Bold(true).Foreground(ColorPrimary),
+		dimStyle:      style.NewStyle().Foreground(ColorTextDim),
+	}
+	p.search()
+	return p
+}
+
+// search refreshes the matches for the current query
+func (p *Picker) search() {
+	p.matches, p.err = p.service.SearchPrompts(p.input.Value())
+	p.cursor, p.offset = 0, 0
+}
+
+// Init starts the cursor blinking
+func (p *Picker) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+// Update moves through the matches, picks one with enter and refines the
+// query with anything typed. Fewer matches are shown in short terminals,
+// such as a tmux popup.
+func (p *Picker) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.WindowSizeMsg); ok {
+		p.rows = max(1, min(maxPickerRows, msg.Height-2))
+		p.offset = max(0, min(p.offset, p.cursor), p.cursor-p.rows+1)
+		return p, nil
+	}
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "esc", "ctrl+c":
+			p.done = true
+			return p, tea.Quit
+		case "enter":
+			if len(p.matches) > 0 {
+				p.chosen = p.matches[p.cursor]
+			}
+			p.done = true
+			return p, tea.Quit
+		case "up", "ctrl+p", "ctrl+k":
+			if p.cursor > 0 {
+				p.cursor--
+				p.offset = min(p.offset, p.cursor)
+			}
+			return p, nil
+		case "down", "ctrl+n", "ctrl+j", "tab":
+			if p.cursor < len(p.matches)-1 {
+				p.cursor++
+				p.offset = max(p.offset, p.cursor-p.rows+1)
+			}
+			return p, nil
+		}
+	}
+
+	query := p.input.Value()
+	var cmd tea.Cmd
+	p.input, cmd = p.input.Update(msg)
+	if p.input.Value() != query {
+		p.search()
+	}
+	return p, cmd
+}
+
+// View shows the query, a page of matches and how many there are. It is
+// cleared once the picker closes so nothing is left on the terminal.
+func (p *Picker) View() string {
+	if p.done {
+		return ""
+	}
+	lines := []string{p.input.View()}
+	if p.err != nil {
+		lines = append(lines, p.dimStyle.Render("  "+p.err.Error()))
+	}
+
+	end := min(len(p.matches), p.offset+p.rows)
+	for i := p.offset; i < end; i++ {
+		prompt := p.matches[i]
+		line := prompt.ID
+		if prompt.Name != "" && prompt.Name != prompt.ID {
+			line += p.dimStyle.Render(" - " + prompt.Name)
+		}
+		if i == p.cursor {
+			lines = append(lines, p.selectedStyle.Render("▶ "+prompt.ID)+strings.TrimPrefix(line, prompt.ID))
+		} else {
+			lines = append(lines, "  "+line)
+		}
+	}
+	lines = append(lines, p.dimStyle.Render(i18n.T("picker.help", min(p.cursor+1, len(p.matches)), len(p.matches))))
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// Chosen returns the prompt picked, or nil when the picker was cancelled
+func (p *Picker) Chosen() *models.Prompt {
+	return p.chosen
+}
+
+// RunPicker lets the user pick a prompt on the terminal and returns it.
+// The picker reads from and draws on /dev/tty so it works when stdout is
+// piped, falling back to stdin and stderr without one.
+func RunPicker(svc Library, query string) (*models.Prompt, error) {
+	var in io.Reader = os.Stdin
+	var out io.Writer = os.Stderr
+	if tty, err := os.OpenFile("/dev/tty", os.O_RDWR, 0); err == nil {
+		defer tty.Close()
+		in, out = tty, tty
+	}
+
+	picker := NewPicker(svc, query, out)
+	if _, err := tea.NewProgram(picker, tea.WithInput(in), tea.WithOutput(out)).Run(); err != nil {
+		return nil, fmt.Errorf("failed to run picker: %w", err)
+	}
+	if picker.Chosen() == nil {
+		return nil, ErrPickCancelled
+	}
+	return picker.Chosen(), nil
+}
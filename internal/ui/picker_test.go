@@ -0,0 +1,68 @@
+package ui
+
+import (
+	"io"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/dpshade/pocket-prompt/internal/models"
+	"github.com/dpshade/pocket-prompt/internal/service"
+)
+
+func TestPickerNarrowsAndPicks(t *testing.T) {
+	t.Setenv("POCKET_PROMPT_DIR", t.TempDir())
+
+	svc, err := service.NewService()
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+	for _, prompt := range []*models.Prompt{
+		{ID: "code-review", Name: "Code Review", Version: "1.0.0", Content: "Review this"},
+		{ID: "weekly-report", Name: "Weekly Report", Version: "1.0.0", Content: "Summarize the week"},
+	} {
+		if err := svc.CreatePrompt(prompt); err != nil {
+			t.Fatalf("Failed to create prompt: %v", err)
+		}
+	}
+
+	picker := NewPicker(svc, "", io.Discard)
+	if len(picker.matches) != 2 {
+		t.Fatalf("Expected every prompt before typing, got %d", len(picker.matches))
+	}
+
+	for _, r := range "weekly" {
+		picker.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	if len(picker.matches) != 1 || picker.matches[0].ID != "weekly-report" {
+		t.Fatalf("Expected only weekly-report to match, got %v", picker.matches)
+	}
+
+	if _, cmd := picker.Update(tea.KeyMsg{Type: tea.KeyEnter}); cmd == nil {
+		t.Error("Expected enter to quit the picker")
+	}
+	if picker.Chosen() == nil || picker.Chosen().ID != "weekly-report" {
+		t.Errorf("Expected weekly-report to be picked, got %v", picker.Chosen())
+	}
+	if picker.View() != "" {
+		t.Error("Expected the picker to clear itself once closed")
+	}
+}
+
+func TestPickerCancel(t *testing.T) {
+	t.Setenv("POCKET_PROMPT_DIR", t.TempDir())
+
+	svc, err := service.NewService()
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+	if err := svc.CreatePrompt(&models.Prompt{ID: "only", Name: "Only", Version: "1.0.0", Content: "x"}); err != nil {
+		t.Fatalf("Failed to create prompt: %v", err)
+	}
+
+	picker := NewPicker(svc, "", io.Discard)
+	picker.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if picker.Chosen() != nil {
+		t.Errorf("Expected nothing picked after esc, got %s", picker.Chosen().ID)
+	}
+}
@@ -0,0 +1,185 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/dpshade/pocket-prompt/internal/i18n"
+	"github.com/dpshade/pocket-prompt/internal/models"
+)
+
+// pinnedSearchIcon marks pinned saved searches in lists
+const pinnedSearchIcon = "◆ "
+
+// pinnedSearchItem shows a pinned saved search as a folder at the top of
+// the library
+type pinnedSearchItem struct {
+	search models.SavedSearch
+}
+
+// FilterValue satisfies the list.Item interface
+func (p pinnedSearchItem) FilterValue() string {
+	return p.Title()
+}
+
+// Title satisfies the list.DefaultItem interface
+func (p pinnedSearchItem) Title() string {
+	return pinnedSearchIcon + p.search.Name
+}
+
+// Description satisfies the list.DefaultItem interface
+func (p pinnedSearchItem) Description() string {
+	if p.search.Description != "" {
+		return i18n.T("pinned.description") + p.search.Description
+	}
+	return i18n.T("pinned.description") + p.search.Expression.String()
+}
+
+// libraryItems returns the list items for prompts under the tag filter,
+// headed by the pinned saved searches when the whole library is shown
+func (m Model) libraryItems(prompts []*models.Prompt) []list.Item {
+	var items []list.Item
+	if m.currentExpression == nil && m.tagFilter == "" {
+		if pinned, err := m.service.PinnedSearches(); err == nil {
+			for _, search := range pinned {
+				items = append(items, pinnedSearchItem{search: search})
+			}
+		}
+	}
+	for _, p := range prompts {
+		if m.matchesTagFilter(p) {
+			items = append(items, p)
+		}
+	}
+	return items
+}
+
+// setLibraryItems lists prompts in the library, paging them to suit how
+// many there are
+func (m *Model) setLibraryItems(prompts []*models.Prompt) {
+	items := m.libraryItems(prompts)
+	m.fitPagination(len(items))
+	m.promptList.SetItems(items)
+}
+
+// applySavedSearch narrows the library to the results of a saved search
+func (m *Model) applySavedSearch(search models.SavedSearch) {
+	results, err := m.service.SearchPromptsByBooleanExpression(search.Expression)
+	if err != nil {
+		m.setError(i18n.T("error.search"), err)
+		m.statusTimeout = 3
+		return
+	}
+
+	m.currentExpression = search.Expression
+	m.prompts = results
+	m.setLibraryItems(results)
+	m.statusMsg = i18n.T("pinned.found", search.Name, len(results))
+	m.statusTimeout = 2
+}
+
+// savedSearchOptions returns the options of the saved searches screen, with
+// the result counts counted so far and pinned searches marked
+func (m Model) savedSearchOptions(searches []models.SavedSearch) []SelectOption {
+	options := []SelectOption{}
+	for _, search := range searches {
+		label := search.Name
+		if search.Pinned {
+			label = pinnedSearchIcon + label
+		}
+		options = append(options, SelectOption{
+			Label:       label,
+			Description: m.savedSearchDescription(search),
+			Value:       search,
+		})
+	}
+	return options
+}
+
+// savedSearchDescription describes a saved search with its result count,
+// once it has been counted
+func (m Model) savedSearchDescription(search models.SavedSearch) string {
+	count, ok := m.savedSearchCounts[search.Name]
+	switch {
+	case !ok:
+		return fmt.Sprintf("%s (counting...)", search.Expression.String())
+	case count < 0:
+		return fmt.Sprintf("%s (search failed)", search.Expression.String())
+	default:
+		return fmt.Sprintf("%s (%d results)", search.Expression.String(), count)
+	}
+}
+
+// savedSearchCountMsg carries the result count of one saved search
+type savedSearchCountMsg struct {
+	generation int
+	name       string
+	count      int // -1 when the search failed
+	pending    []models.SavedSearch
+}
+
+// countSavedSearchesCmd counts the results of saved searches in the
+// background, one search per command so counts show up as they arrive.
+// generation identifies the saved searches screen the counts are for.
+func countSavedSearchesCmd(svc Library, generation int, searches []models.SavedSearch) tea.Cmd {
+	if len(searches) == 0 {
+		return nil
+	}
+	return func() tea.Msg {
+		count := -1
+		if results, err := svc.SearchPromptsByBooleanExpression(searches[0].Expression); err == nil {
+			count = len(results)
+		}
+		return savedSearchCountMsg{
+			generation: generation,
+			name:       searches[0].Name,
+			count:      count,
+			pending:    searches[1:],
+		}
+	}
+}
+
+// updateSavedSearchCount records a result count and shows it on the saved
+// searches screen. It returns the command counting the next search.
+func (m *Model) updateSavedSearchCount(msg savedSearchCountMsg) tea.Cmd {
+	if msg.generation != m.savedSearchCountGeneration {
+		// The screen these counts were started for has been closed
+		return nil
+	}
+	m.savedSearchCounts[msg.name] = msg.count
+	if m.selectForm != nil && m.viewMode == ViewSavedSearches {
+		for i, option := range m.selectForm.options {
+			if search, ok := option.Value.(models.SavedSearch); ok && search.Name == msg.name {
+				m.selectForm.options[i].Description = m.savedSearchDescription(search)
+			}
+		}
+	}
+	return countSavedSearchesCmd(m.service, msg.generation, msg.pending)
+}
+
+// deleteSavedSearch deletes a saved search and updates the saved searches
+// screen, leaving it once none are left
+func (m Model) deleteSavedSearch(name string) (Model, tea.Cmd) {
+	if err := m.service.DeleteSavedSearch(name); err != nil {
+		m.setError(i18n.T("error.delete"), err)
+		m.statusTimeout = 3
+		return m, clearStatusCmd()
+	}
+	m.statusMsg = i18n.T("saved.deleted", name)
+	m.statusTimeout = 2
+
+	savedSearches, err := m.service.ListSavedSearches()
+	if err != nil {
+		return m, clearStatusCmd()
+	}
+	if len(savedSearches) == 0 {
+		// No more searches - go back
+		return m, tea.Batch(m.closeScreen(), clearStatusCmd())
+	}
+	index := m.selectForm.selected
+	m.savedSearches = savedSearches
+	m.selectForm = NewSelectForm(m.savedSearchOptions(savedSearches))
+	m.selectForm.selected = min(index, len(savedSearches)-1)
+	return m, clearStatusCmd()
+}
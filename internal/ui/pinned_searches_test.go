@@ -0,0 +1,51 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dpshade/pocket-prompt/internal/models"
+	"github.com/dpshade/pocket-prompt/internal/service"
+)
+
+func TestSavedSearchCountsArriveInBackground(t *testing.T) {
+	t.Setenv("POCKET_PROMPT_DIR", t.TempDir())
+
+	svc, err := service.NewService()
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+	if err := svc.SavePrompt(&models.Prompt{ID: "a", Name: "A", Content: "x", Tags: []string{"prod"}}); err != nil {
+		t.Fatalf("Failed to save prompt: %v", err)
+	}
+	searches := []models.SavedSearch{
+		{Name: "production", Expression: models.NewTagExpression("prod")},
+		{Name: "drafts", Expression: models.NewTagExpression("draft")},
+	}
+
+	m := Model{service: svc, viewMode: ViewSavedSearches, savedSearchCounts: make(map[string]int), savedSearchCountGeneration: 1}
+	m.selectForm = NewSelectForm(m.savedSearchOptions(searches))
+	if desc := m.selectForm.options[0].Description; !strings.Contains(desc, "counting") {
+		t.Fatalf("Expected counts to be pending, got %q", desc)
+	}
+
+	cmd := countSavedSearchesCmd(svc, 1, searches)
+	for cmd != nil {
+		cmd = m.updateSavedSearchCount(cmd().(savedSearchCountMsg))
+	}
+	if desc := m.selectForm.options[0].Description; !strings.HasSuffix(desc, "(1 results)") {
+		t.Errorf("Unexpected description %q", desc)
+	}
+	if desc := m.selectForm.options[1].Description; !strings.HasSuffix(desc, "(0 results)") {
+		t.Errorf("Unexpected description %q", desc)
+	}
+
+	// Counts for a screen that has been closed are dropped
+	m.savedSearchCountGeneration++
+	if next := m.updateSavedSearchCount(savedSearchCountMsg{generation: 1, name: "production", count: 5, pending: searches}); next != nil {
+		t.Error("Expected stale counts to stop counting")
+	}
+	if m.savedSearchCounts["production"] != 1 {
+		t.Errorf("Expected the stale count to be ignored, got %d", m.savedSearchCounts["production"])
+	}
+}
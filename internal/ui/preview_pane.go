@@ -0,0 +1,204 @@
+package ui
+
+import (
+	"github.com/charmbracelet/bubbles/paginator"
+	"github.com/charmbracelet/bubbles/viewport"
+	"github.com/charmbracelet/glamour"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/dpshade/pocket-prompt/internal/config"
+	"github.com/dpshade/pocket-prompt/internal/i18n"
+	"github.com/dpshade/pocket-prompt/internal/models"
+)
+
+// Limits of the preview pane beside the library list, as a percentage of
+// the width
+const (
+	defaultPreviewPercent = 40
+	minPreviewPercent     = 20
+	maxPreviewPercent     = 70
+	previewPercentStep    = 10
+)
+
+// minSplitWidth is the narrowest terminal the preview pane is shown in;
+// below it the list gets the whole width
+const minSplitWidth = 90
+
+// fitPagination shows the library's pages as a dot each while the dots fit
+// on a line, and as a page count beyond that. The list draws every dot on
+// each frame, which made large libraries sluggish.
+func (m *Model) fitPagination(items int) {
+	listWidth, _ := m.libraryPaneWidths()
+	perPage := max(1, m.promptList.Paginator.PerPage)
+	pages := (items + perPage - 1) / perPage
+	if pages > listWidth {
+		m.promptList.Paginator.Type = paginator.Arabic
+	} else {
+		m.promptList.Paginator.Type = paginator.Dots
+	}
+}
+
+// libraryReservedHeight is the space the library view keeps for its title,
+// help, status and git status lines
+const libraryReservedHeight = 8
+
+// previewPane shows the prompt selected in the library beside the list
+type previewPane struct {
+	show     bool
+	percent  int
+	viewport viewport.Model
+	renderer *glamour.TermRenderer
+	promptID string // The prompt shown, so it is only loaded when the selection changes
+}
+
+// newPreviewPane creates the preview pane with the saved layout
+func newPreviewPane(cfg config.UIConfig) previewPane {
+	vp := viewport.New(0, 0)
+	vp.Style = lipgloss.NewStyle()
+	return previewPane{
+		show:     !cfg.HidePreview,
+		percent:  clampPreviewPercent(cfg.PreviewWidth),
+		viewport: vp,
+	}
+}
+
+// clampPreviewPercent keeps a preview width within its limits, using the
+// default when none is set
+func clampPreviewPercent(percent int) int {
+	if percent == 0 {
+		return defaultPreviewPercent
+	}
+	return max(minPreviewPercent, min(maxPreviewPercent, percent))
+}
+
+// previewVisible reports whether the library shows the preview pane
+func (m Model) previewVisible() bool {
+	return m.preview.show && m.width >= minSplitWidth && !plain
+}
+
+// libraryPaneWidths splits the width of the library view between the list
+// and the preview pane, which is 0 when hidden
+func (m Model) libraryPaneWidths() (listWidth, previewWidth int) {
+	// The library view is padded twice on the left
+	width := max(0, m.width-4)
+	if !m.previewVisible() {
+		return width, 0
+	}
+	previewWidth = width * m.preview.percent / 100
+	return width - previewWidth, previewWidth
+}
+
+// resizeLibrary fits the list and preview pane to the window
+func (m *Model) resizeLibrary() {
+	height := max(5, m.height-libraryReservedHeight)
+	listWidth, previewWidth := m.libraryPaneWidths()
+	m.promptList.SetSize(listWidth, height)
+	m.fitPagination(len(m.promptList.Items()))
+
+	if previewWidth == 0 {
+		return
+	}
+	// Leave room for the pane's border and padding
+	contentWidth := max(10, previewWidth-3)
+	if m.preview.viewport.Width != contentWidth {
+		// Rebuilt for the new width once there is a prompt to show
+		m.preview.renderer = nil
+		m.preview.promptID = ""
+	}
+	m.preview.viewport.Width = contentWidth
+	m.preview.viewport.Height = height
+	m.updateLibraryPreview()
+}
+
+// updateLibraryPreview shows the selected prompt in the preview pane,
+// loading it only when the selection changed. Content is shown without
+// rendering, so secret values never appear in it.
+func (m *Model) updateLibraryPreview() {
+	if !m.previewVisible() || m.preview.viewport.Width == 0 {
+		return
+	}
+	selected, ok := m.promptList.SelectedItem().(*models.Prompt)
+	if !ok {
+		m.preview.promptID = ""
+		m.preview.viewport.SetContent("")
+		return
+	}
+	if selected.ID == m.preview.promptID {
+		return
+	}
+
+	content := selected.Summary
+	if prompt, err := m.service.GetPrompt(selected.ID); err == nil {
+		content = prompt.Content
+	}
+	if m.preview.renderer == nil {
+		if renderer, err := createGlamourRenderer(m.preview.viewport.Width); err == nil {
+			m.preview.renderer = renderer
+		}
+	}
+	if m.preview.renderer != nil {
+		if formatted, err := m.preview.renderer.Render(content); err == nil {
+			content = formatted
+		}
+	}
+	m.preview.promptID = selected.ID
+	m.preview.viewport.SetContent(content)
+	m.preview.viewport.GotoTop()
+}
+
+// togglePreview shows or hides the preview pane and saves the choice
+func (m *Model) togglePreview() {
+	m.preview.show = !m.preview.show
+	m.preview.promptID = ""
+	m.resizeLibrary()
+	if m.preview.show && m.width < minSplitWidth {
+		m.statusMsg = i18n.T("preview.too_narrow")
+	} else if m.preview.show {
+		m.statusMsg = i18n.T("preview.shown")
+	} else {
+		m.statusMsg = i18n.T("preview.hidden")
+	}
+	m.statusTimeout = 2
+	m.savePaneLayout()
+}
+
+// resizePreview widens the preview pane by steps of previewPercentStep,
+// or narrows it for negative steps, and saves the new width
+func (m *Model) resizePreview(steps int) {
+	if !m.previewVisible() {
+		return
+	}
+	percent := clampPreviewPercent(m.preview.percent + steps*previewPercentStep)
+	if percent == m.preview.percent {
+		return
+	}
+	m.preview.percent = percent
+	m.resizeLibrary()
+	m.savePaneLayout()
+}
+
+// savePaneLayout stores the preview pane layout in config.yaml
+func (m *Model) savePaneLayout() {
+	ui := m.service.UIConfig()
+	ui.HidePreview = !m.preview.show
+	ui.PreviewWidth = m.preview.percent
+	if err := m.service.SaveUIConfig(ui); err != nil {
+		m.setError(i18n.T("error.save_layout"), err)
+		m.statusTimeout = 3
+	}
+}
+
+// renderLibraryPanes places the preview pane beside the list when shown
+func (m Model) renderLibraryPanes() string {
+	listView := m.promptList.View()
+	listWidth, previewWidth := m.libraryPaneWidths()
+	if previewWidth == 0 {
+		return listView
+	}
+	pane := lipgloss.NewStyle().
+		Border(boxBorder(lipgloss.NormalBorder()), false, false, false, true).
+		BorderForeground(ColorBorder).
+		PaddingLeft(1).
+		Width(previewWidth - 1).
+		Render(m.preview.viewport.View())
+	return lipgloss.JoinHorizontal(lipgloss.Top, lipgloss.NewStyle().Width(listWidth).Render(listView), pane)
+}
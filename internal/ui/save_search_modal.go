@@ -1,13 +1,13 @@
 package ui
 
 import (
-	"fmt"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/dpshade/pocket-prompt/internal/i18n"
 	"github.com/dpshade/pocket-prompt/internal/models"
 )
 
@@ -38,13 +38,13 @@ type SaveSearchModal struct {
 // NewSaveSearchModal creates a new save search modal
 func NewSaveSearchModal() *SaveSearchModal {
 	nameInput := textinput.New()
-	nameInput.Placeholder = "Enter search name"
+	nameInput.Placeholder = i18n.T("save_search.name_placeholder")
 	nameInput.Focus()
 	nameInput.CharLimit = 50
 	nameInput.Width = 50
 
 	expressionText := textinput.New()
-	expressionText.Placeholder = "Enter boolean expression (tag1 AND tag2 OR tag3)"
+	expressionText.Placeholder = i18n.T("save_search.expression_placeholder")
 	expressionText.CharLimit = 500
 	expressionText.Width = 50
 	
@@ -54,7 +54,7 @@ func NewSaveSearchModal() *SaveSearchModal {
 	expressionText.KeyMap = customKeyMap
 
 	textInput := textinput.New()
-	textInput.Placeholder = "Optional: text filter"
+	textInput.Placeholder = i18n.T("save_search.text_placeholder")
 	textInput.CharLimit = 200
 	textInput.Width = 50
 
@@ -232,7 +232,7 @@ func (m *SaveSearchModal) performLiveSearch(query string) {
 	// Parse the query
 	expr, err := m.parseQuery(query)
 	if err != nil {
-		m.searchError = "Invalid expression"
+		m.searchError = i18n.T("save_search.invalid")
 		m.matchCount = 0
 		m.expression = nil
 		return
@@ -245,7 +245,7 @@ func (m *SaveSearchModal) performLiveSearch(query string) {
 	if m.searchFunc != nil {
 		results, err := m.searchFunc(expr)
 		if err != nil {
-			m.searchError = "Search failed"
+			m.searchError = i18n.T("error.search")
 			m.matchCount = 0
 		} else {
 			m.matchCount = len(results)
@@ -365,7 +365,7 @@ func (m *SaveSearchModal) View() string {
 
 	// Modal styles - use terminal default colors
 	modalStyle := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
+		Border(boxBorder(lipgloss.RoundedBorder())).
 		Padding(1, 2).
 		Width(70)
 
@@ -378,7 +378,7 @@ func (m *SaveSearchModal) View() string {
 
 	focusedLabelStyle := lipgloss.NewStyle().
 		Bold(true).
-		Foreground(lipgloss.Color("12"))
+		Foreground(ColorInfo)
 
 	helpStyle := lipgloss.NewStyle().
 		Italic(true).
@@ -387,15 +387,15 @@ func (m *SaveSearchModal) View() string {
 	var content []string
 
 	// Title
-	title := "Save Boolean Search"
+	title := i18n.T("save_search.title")
 	if m.editMode {
-		title = "Edit Boolean Search"
+		title = i18n.T("save_search.edit_title")
 	}
 	content = append(content, titleStyle.Render(title))
 	content = append(content, "")
 
 	// Name field
-	nameLabel := "Name:"
+	nameLabel := i18n.T("save_search.name_label")
 	if m.focusIndex == 0 {
 		nameLabel = "▶ " + nameLabel
 		content = append(content, focusedLabelStyle.Render(nameLabel))
@@ -406,7 +406,7 @@ func (m *SaveSearchModal) View() string {
 	content = append(content, "")
 
 	// Boolean expression field
-	exprLabel := "Boolean Expression:"
+	exprLabel := i18n.T("search.expression_label")
 	if m.focusIndex == 1 {
 		exprLabel = "▶ " + exprLabel
 		content = append(content, focusedLabelStyle.Render(exprLabel))
@@ -419,23 +419,23 @@ func (m *SaveSearchModal) View() string {
 	if m.expressionText.Value() != "" {
 		matchStyle := lipgloss.NewStyle().
 			Italic(true).
-			Foreground(lipgloss.Color("8"))
+			Foreground(ColorTextDim)
 		
 		errorStyle := lipgloss.NewStyle().
 			Italic(true).
-			Foreground(lipgloss.Color("9"))
+			Foreground(ColorError)
 			
 		if m.searchError != "" {
 			content = append(content, errorStyle.Render("✗ "+m.searchError))
 		} else if m.expression != nil {
-			matchText := fmt.Sprintf("✓ %d matches", m.matchCount)
+			matchText := i18n.T("save_search.matches", m.matchCount)
 			content = append(content, matchStyle.Render(matchText))
 		}
 	}
 	content = append(content, "")
 
 	// Text filter field
-	textLabel := "Text Filter (optional):"
+	textLabel := i18n.T("search.text_label")
 	if m.focusIndex == 2 {
 		textLabel = "▶ " + textLabel
 		content = append(content, focusedLabelStyle.Render(textLabel))
@@ -446,11 +446,11 @@ func (m *SaveSearchModal) View() string {
 	content = append(content, "")
 
 	// Help
-	helpText := "Tab: next field • Enter: save • Esc: cancel"
+	helpText := i18n.T("save_search.help")
 	if m.editMode {
-		helpText = "Tab: next field • Enter: update • Esc: cancel"
+		helpText = i18n.T("save_search.edit_help")
 	}
-	autocompleteHelp := "Ctrl+Space/→: accept suggestion • ↑/↓: navigate suggestions"
+	autocompleteHelp := i18n.T("search.autocomplete_help")
 	content = append(content, helpStyle.Render(helpText))
 	content = append(content, helpStyle.Render(autocompleteHelp))
 
@@ -0,0 +1,145 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/dpshade/pocket-prompt/internal/clipboard"
+	"github.com/dpshade/pocket-prompt/internal/i18n"
+)
+
+// maxSnippetPreviewLines is how much of the selected snippet the modal shows
+const maxSnippetPreviewLines = 8
+
+// openSnippets loads the snippet library and opens it, or says it is empty
+func (m Model) openSnippets() (Model, tea.Cmd) {
+	snippets, err := m.service.ListSnippets()
+	if err != nil {
+		m.setError(i18n.T("error.list_snippets"), err)
+		m.statusTimeout = 3
+		return m, clearStatusCmd()
+	}
+	if len(snippets) == 0 {
+		m.statusMsg = i18n.T("snippets.none")
+		m.statusTimeout = 3
+		return m, clearStatusCmd()
+	}
+	m.snippets = snippets
+	m.snippetCursor = 0
+	m.showSnippets = true
+	return m, nil
+}
+
+// updateSnippets handles keys in the snippet library: moving between
+// snippets, copying a reference to the selected one and deleting it
+func (m Model) updateSnippets(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch msg.String() {
+	case "s", "esc", "q":
+		m.showSnippets = false
+		return m, nil
+	case "up", "k":
+		if m.snippetCursor > 0 {
+			m.snippetCursor--
+		}
+		return m, nil
+	case "down", "j":
+		if m.snippetCursor < len(m.snippets)-1 {
+			m.snippetCursor++
+		}
+		return m, nil
+	case "c", "enter":
+		snippet := m.snippets[m.snippetCursor]
+		if _, err := clipboard.CopyWithFallback(fmt.Sprintf("{{snippet:%s}}", snippet.Name)); err != nil {
+			m.setError(i18n.T("error.copy"), err)
+			m.statusTimeout = 3
+			return m, clearStatusCmd()
+		}
+		m.statusMsg = i18n.T("snippets.copied", snippet.Name)
+		m.statusTimeout = 3
+		m.showSnippets = false
+		return m, clearStatusCmd()
+	case "d":
+		name := m.snippets[m.snippetCursor].Name
+		m.askConfirm(i18n.T("snippets.confirm_title"), i18n.T("snippets.confirm", name), i18n.T("confirm.delete"), func(m Model) (Model, tea.Cmd) {
+			return m.deleteSnippet(name)
+		})
+	}
+	return m, nil
+}
+
+// deleteSnippet deletes a snippet from the library and the modal's list
+func (m Model) deleteSnippet(name string) (Model, tea.Cmd) {
+	if err := m.service.DeleteSnippet(name); err != nil {
+		m.setError(i18n.T("error.delete_snippet"), err)
+		m.statusTimeout = 5
+		return m, clearStatusCmd()
+	}
+	m.statusMsg = i18n.T("snippets.deleted", name)
+	m.statusTimeout = 3
+
+	m.snippets = append(m.snippets[:m.snippetCursor:m.snippetCursor], m.snippets[m.snippetCursor+1:]...)
+	if m.snippetCursor >= len(m.snippets) {
+		m.snippetCursor = max(0, len(m.snippets)-1)
+	}
+	if len(m.snippets) == 0 {
+		m.showSnippets = false
+	}
+	return m, clearStatusCmd()
+}
+
+// renderSnippetsModal lists the snippets with a preview of the selected one
+func (m Model) renderSnippetsModal() string {
+	width := min(90, m.width-4)
+
+	modalStyle := lipgloss.NewStyle().
+		Border(boxBorder(lipgloss.RoundedBorder())).
+		Padding(1, 2).
+		Width(width)
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		MarginBottom(1)
+
+	selectedStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(ColorPrimary)
+
+	detailStyle := lipgloss.NewStyle().
+		Foreground(ColorTextDim)
+
+	helpStyle := lipgloss.NewStyle().
+		Foreground(ColorTextDim).
+		Italic(true).
+		MarginTop(1)
+
+	content := []string{titleStyle.Render(i18n.T("snippets.title", len(m.snippets)))}
+	for i, snippet := range m.snippets {
+		line := snippet.Name
+		if snippet.Description != "" {
+			line += " - " + snippet.Description
+		}
+		if i == m.snippetCursor {
+			line = selectedStyle.Render("▶ " + line)
+		} else {
+			line = "  " + line
+		}
+		content = append(content, line)
+	}
+
+	preview := strings.Split(m.snippets[m.snippetCursor].Content, "\n")
+	if len(preview) > maxSnippetPreviewLines {
+		preview = append(preview[:maxSnippetPreviewLines], "…")
+	}
+	content = append(content, "")
+	for _, line := range preview {
+		content = append(content, detailStyle.Render("    "+line))
+	}
+
+	content = append(content, helpStyle.Render(i18n.T("snippets.help")))
+
+	modal := modalStyle.Render(lipgloss.JoinVertical(lipgloss.Left, content...))
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, modal)
+}
@@ -6,6 +6,8 @@ import (
 	"strings"
 	
 	"github.com/charmbracelet/lipgloss"
+
+	"github.com/dpshade/pocket-prompt/internal/i18n"
 )
 
 // Design System Colors - Adaptive based on terminal background
@@ -31,26 +33,56 @@ var (
 	ColorOverlay    lipgloss.Color
 )
 
-// initializeColors sets up adaptive colors based on terminal background
-func initializeColors() {
-	// Check for environment variable override
-	if os.Getenv("GLAMOUR_STYLE") == "light" {
-		// Force light theme
-		setLightThemeColors()
-		return
+// plain is set in the reduced-decoration mode for screen readers and dumb
+// terminals: blank borders instead of box-drawing, ASCII markers and the
+// parts of a view in reading order
+var plain bool
+
+// SetPlain turns the reduced-decoration mode on or off. Colors are left to
+// the color profile, which the caller sets to ASCII along with it.
+func SetPlain(on bool) {
+	plain = on
+	buildStyles()
+}
+
+// boxBorder returns border, or in plain mode a blank one taking the same
+// space
+func boxBorder(border lipgloss.Border) lipgloss.Border {
+	if plain {
+		return lipgloss.HiddenBorder()
 	}
-	if os.Getenv("GLAMOUR_STYLE") == "dark" {
-		// Force dark theme  
-		setDarkThemeColors()
-		return
+	return border
+}
+
+// darkTheme is set while the palette for dark backgrounds is in use, so the
+// markdown renderer matches it
+var darkTheme = true
+
+// initializeColors picks the palette for the background of the terminal r
+// draws on and builds the styles from it. GLAMOUR_STYLE=light or dark wins,
+// then the theme configured under ui in config.yaml, then the background
+// the terminal reports.
+func initializeColors(theme string, r *lipgloss.Renderer) {
+	switch {
+	case os.Getenv("GLAMOUR_STYLE") == "light":
+		darkTheme = false
+	case os.Getenv("GLAMOUR_STYLE") == "dark":
+		darkTheme = true
+	case theme == "light":
+		darkTheme = false
+	case theme == "dark":
+		darkTheme = true
+	default:
+		// Auto-detect based on terminal background
+		darkTheme = r.HasDarkBackground()
 	}
-	
-	// Auto-detect based on terminal background
-	if lipgloss.HasDarkBackground() {
+
+	if darkTheme {
 		setDarkThemeColors()
 	} else {
 		setLightThemeColors()
 	}
+	buildStyles()
 }
 
 func setDarkThemeColors() {
@@ -123,8 +155,45 @@ var (
 	SpacingXXL = 12 // 48px
 )
 
-// Component Styles
+// Component Styles, built from the palette by buildStyles
 var (
+	StyleTitle                 lipgloss.Style
+	StyleSubtitle              lipgloss.Style
+	StyleText                  lipgloss.Style
+	StyleTextMuted             lipgloss.Style
+	StyleTextDim               lipgloss.Style
+	StyleFocused               lipgloss.Style
+	StyleSelected              lipgloss.Style
+	StyleUnselected            lipgloss.Style
+	StyleButtonPrimary         lipgloss.Style
+	StyleButtonSecondary       lipgloss.Style
+	StyleBackButton            lipgloss.Style
+	StyleSuccess               lipgloss.Style
+	StyleWarning               lipgloss.Style
+	StyleError                 lipgloss.Style
+	StyleInfo                  lipgloss.Style
+	StyleModal                 lipgloss.Style
+	StyleCard                  lipgloss.Style
+	StyleContainer             lipgloss.Style
+	StyleContentContainer      lipgloss.Style
+	StyleFormLabel             lipgloss.Style
+	StyleFormHelp              lipgloss.Style
+	StyleLoading               lipgloss.Style
+	StyleSearchIndicator       lipgloss.Style
+	StyleMetadata              lipgloss.Style
+	StyleCode                  lipgloss.Style
+	StyleScrollIndicator       lipgloss.Style
+	StyleScrollIndicatorActive lipgloss.Style
+)
+
+// The styles are usable, uncolored, before NewModel picks the palette
+func init() {
+	buildStyles()
+}
+
+// buildStyles makes the component styles from the current palette, so they
+// can be rebuilt once the terminal's background is known
+func buildStyles() {
 	// Base text styles
 	StyleTitle = lipgloss.NewStyle().
 		Foreground(ColorPrimary).
@@ -205,7 +274,7 @@ var (
 	
 	// Layout styles
 	StyleModal = lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
+		Border(boxBorder(lipgloss.RoundedBorder())).
 		BorderForeground(ColorPrimary).
 		Padding(2, 3).
 		Background(ColorBackground).
@@ -213,7 +282,7 @@ var (
 		MarginBottom(1)
 	
 	StyleCard = lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
+		Border(boxBorder(lipgloss.RoundedBorder())).
 		BorderForeground(ColorBorder).
 		Padding(1, 2).
 		Background(ColorSurface).
@@ -224,7 +293,7 @@ var (
 	
 	// Content container for prompt previews
 	StyleContentContainer = lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
+		Border(boxBorder(lipgloss.RoundedBorder())).
 		BorderForeground(ColorBorder).
 		Padding(1, 2).
 		Background(ColorSurface).
@@ -273,7 +342,7 @@ var (
 		Foreground(ColorSecondary).
 		Bold(true).
 		Align(lipgloss.Center)
-)
+}
 
 // Helper functions for consistent styling
 func CreateHeader(backText, titleText string) string {
@@ -309,7 +378,7 @@ func CreateContextualHelp(essential []string, additional []string, showExpanded
 	// First row: essential keybinds + Ctrl+g hint if there are additional keys
 	firstRowParts := essential
 	if len(additional) > 0 && !showExpanded {
-		firstRowParts = append(firstRowParts, "Ctrl+g for more")
+		firstRowParts = append(firstRowParts, i18n.T("help.more"))
 	}
 	
 	essentialText := strings.Join(firstRowParts, " • ")
@@ -390,6 +459,9 @@ func CreateOption(label, description string, isSelected bool) []string {
 	if isSelected {
 		style = StyleFocused
 		prefix = "▶ "
+		if plain {
+			prefix = "> "
+		}
 	} else {
 		style = StyleUnselected
 		prefix = "  " // Two spaces to maintain alignment
@@ -411,14 +483,14 @@ func CreateOption(label, description string, isSelected bool) []string {
 
 // Git status styling
 func CreateGitStatus(status string) string {
-	return StyleMetadata.Render("Git: " + status)
+	return StyleMetadata.Render(i18n.T("status.git") + status)
 }
 
 // Search indicator styling
 func CreateSearchIndicator(expression string, count int) string {
 	text := lipgloss.JoinHorizontal(
 		lipgloss.Left,
-		"Boolean: ",
+		i18n.T("status.boolean"),
 		expression,
 		lipgloss.NewStyle().Foreground(ColorTextMuted).Render(" ("),
 		lipgloss.NewStyle().Foreground(ColorSuccess).Bold(true).Render(fmt.Sprintf("%d", count)),
@@ -454,12 +526,18 @@ func AddFormPadding(content string) string {
 
 // Create scroll indicators based on scroll state
 func CreateScrollIndicators(canScrollUp, canScrollDown bool, width int) (string, string) {
+	// Rules are box-drawing, so plain mode leaves the lines blank
+	rule := "─────────"
+	if plain {
+		rule = ""
+	}
+
 	// Top indicator
 	var topIndicator string
 	if canScrollUp {
 		topIndicator = StyleScrollIndicatorActive.Render("...")
 	} else {
-		topIndicator = StyleScrollIndicator.Render("─────────")
+		topIndicator = StyleScrollIndicator.Render(rule)
 	}
 	
 	// Bottom indicator  
@@ -467,7 +545,7 @@ func CreateScrollIndicators(canScrollUp, canScrollDown bool, width int) (string,
 	if canScrollDown {
 		bottomIndicator = StyleScrollIndicatorActive.Render("...")
 	} else {
-		bottomIndicator = StyleScrollIndicator.Render("─────────")
+		bottomIndicator = StyleScrollIndicator.Render(rule)
 	}
 	
 	return topIndicator, bottomIndicator
@@ -0,0 +1,26 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestInitializeColorsTheme(t *testing.T) {
+	t.Setenv("GLAMOUR_STYLE", "")
+	t.Cleanup(func() { initializeColors("dark", lipgloss.DefaultRenderer()) })
+
+	initializeColors("light", lipgloss.DefaultRenderer())
+	if darkTheme || ColorText != lipgloss.Color("232") {
+		t.Errorf("Expected the light palette, got text color %s", ColorText)
+	}
+	if StyleText.GetForeground() != ColorText {
+		t.Errorf("Expected the styles rebuilt from the light palette, got %v", StyleText.GetForeground())
+	}
+
+	t.Setenv("GLAMOUR_STYLE", "dark")
+	initializeColors("light", lipgloss.DefaultRenderer())
+	if !darkTheme || StyleText.GetForeground() != lipgloss.Color("252") {
+		t.Errorf("Expected GLAMOUR_STYLE to win over the configured theme, got %v", StyleText.GetForeground())
+	}
+}
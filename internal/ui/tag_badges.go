@@ -0,0 +1,79 @@
+package ui
+
+import (
+	"io"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/dpshade/pocket-prompt/internal/i18n"
+	"github.com/dpshade/pocket-prompt/internal/models"
+)
+
+// renderTags joins tags with commas, drawing tags that have a configured
+// color in that color. Everything else is drawn with plain, since the
+// color codes of a badge end any style the text is wrapped in.
+func renderTags(tags []string, colors map[string]string, plain lipgloss.Style) string {
+	var b strings.Builder
+	for i, tag := range tags {
+		if i > 0 {
+			b.WriteString(plain.Render(", "))
+		}
+		if color := models.TagColor(tag, colors); color != "" {
+			b.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color(color)).Bold(true).Render(tag))
+		} else {
+			b.WriteString(plain.Render(tag))
+		}
+	}
+	return b.String()
+}
+
+// promptDelegate renders library items like the default delegate, with the
+// tags of prompts drawn in their configured colors and filter matches in
+// titles drawn bold and underlined
+type promptDelegate struct {
+	list.DefaultDelegate
+	tagColors map[string]string
+}
+
+func newPromptDelegate(tagColors map[string]string) promptDelegate {
+	d := promptDelegate{DefaultDelegate: list.NewDefaultDelegate(), tagColors: tagColors}
+	d.Styles.FilterMatch = lipgloss.NewStyle().Bold(true).Underline(true)
+	if plain {
+		// The selected prompt is marked with > in place of a colored bar
+		marker := lipgloss.Border{Left: ">"}
+		d.Styles.SelectedTitle = d.Styles.SelectedTitle.Border(marker, false, false, false, true)
+		d.Styles.SelectedDesc = d.Styles.SelectedDesc.Border(marker, false, false, false, true)
+	}
+	return d
+}
+
+// Render satisfies the list.ItemDelegate interface
+func (d promptDelegate) Render(w io.Writer, m list.Model, index int, item list.Item) {
+	if prompt, ok := item.(*models.Prompt); ok && len(prompt.Tags) > 0 && len(d.tagColors) > 0 {
+		style := d.Styles.NormalDesc
+		if m.FilterState() == list.Filtering && m.FilterValue() == "" {
+			style = d.Styles.DimmedDesc
+		} else if index == m.Index() && m.FilterState() != list.Filtering {
+			style = d.Styles.SelectedDesc
+		}
+		item = coloredPrompt{Prompt: prompt, tags: renderTags(prompt.Tags, d.tagColors, lipgloss.NewStyle().Foreground(style.GetForeground()))}
+	}
+	d.DefaultDelegate.Render(w, m, index, item)
+}
+
+// coloredPrompt replaces the plain tag list in a prompt's description
+type coloredPrompt struct {
+	*models.Prompt
+	tags string
+}
+
+// Description satisfies the list.DefaultItem interface
+func (p coloredPrompt) Description() string {
+	untagged := *p.Prompt
+	untagged.Tags = nil
+	if desc := untagged.Description(); desc != "" {
+		return desc + " • Tags: " + p.tags
+	}
+	return i18n.T("detail.tags") + p.tags
+}
@@ -0,0 +1,197 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/dpshade/pocket-prompt/internal/i18n"
+	"github.com/dpshade/pocket-prompt/internal/models"
+)
+
+// maxTagChooserRows bounds how many tags the chooser lists at once
+const maxTagChooserRows = 12
+
+// tagCount is a tag with the number of prompts carrying it
+type tagCount struct {
+	tag   string
+	count int
+}
+
+// tagChooser picks a tag to narrow the library to, typing narrowing the
+// tags it lists
+type tagChooser struct {
+	input   textinput.Model
+	tags    []tagCount // Every tag, most used first
+	matches []tagCount
+	cursor  int
+}
+
+// openTagChooser lists the library's tags to filter by, or says there
+// are none
+func (m Model) openTagChooser() (Model, tea.Cmd) {
+	counts, err := m.service.GetTagCounts()
+	if err != nil {
+		m.setError(i18n.T("error.load_tags"), err)
+		m.statusTimeout = 3
+		return m, clearStatusCmd()
+	}
+	if len(counts) == 0 {
+		m.statusMsg = i18n.T("tags.none")
+		m.statusTimeout = 3
+		return m, clearStatusCmd()
+	}
+
+	chooser := &tagChooser{input: textinput.New()}
+	chooser.input.Prompt = "# "
+	chooser.input.Placeholder = i18n.T("tags.filter_placeholder")
+	chooser.input.Focus()
+	for tag, count := range counts {
+		chooser.tags = append(chooser.tags, tagCount{tag: tag, count: count})
+	}
+	sort.Slice(chooser.tags, func(i, j int) bool {
+		if chooser.tags[i].count != chooser.tags[j].count {
+			return chooser.tags[i].count > chooser.tags[j].count
+		}
+		return chooser.tags[i].tag < chooser.tags[j].tag
+	})
+	chooser.matches = chooser.tags
+	m.tagChooser = chooser
+	return m, textinput.Blink
+}
+
+// updateTagChooser handles keys in the tag chooser. Enter filters by the
+// selected tag, or clears the filter when that tag is already applied.
+func (m Model) updateTagChooser(msg tea.KeyMsg) (Model, tea.Cmd) {
+	chooser := m.tagChooser
+	switch msg.String() {
+	case "esc", "ctrl+c":
+		m.tagChooser = nil
+		return m, nil
+	case "up", "ctrl+p", "shift+tab":
+		if chooser.cursor > 0 {
+			chooser.cursor--
+		}
+		return m, nil
+	case "down", "ctrl+n", "tab":
+		if chooser.cursor < len(chooser.matches)-1 {
+			chooser.cursor++
+		}
+		return m, nil
+	case "enter":
+		m.tagChooser = nil
+		if len(chooser.matches) == 0 {
+			return m, nil
+		}
+		tag := chooser.matches[chooser.cursor].tag
+		if tag == m.tagFilter {
+			tag = ""
+		}
+		m.setTagFilter(tag)
+		return m, clearStatusCmd()
+	}
+
+	var cmd tea.Cmd
+	chooser.input, cmd = chooser.input.Update(msg)
+	query := strings.ToLower(strings.TrimSpace(chooser.input.Value()))
+	chooser.matches = nil
+	for _, tc := range chooser.tags {
+		if strings.Contains(strings.ToLower(tc.tag), query) {
+			chooser.matches = append(chooser.matches, tc)
+		}
+	}
+	chooser.cursor = 0
+	return m, cmd
+}
+
+// setTagFilter narrows the library to prompts carrying a tag, on top of
+// any boolean search and text filter, or shows them all again for ""
+func (m *Model) setTagFilter(tag string) {
+	m.tagFilter = tag
+	m.setLibraryItems(m.prompts)
+	m.promptList.Select(0)
+	if tag == "" {
+		m.statusMsg = i18n.T("tags.filter_cleared")
+	} else {
+		m.statusMsg = i18n.T("tags.filtered", tag)
+	}
+	m.statusTimeout = 2
+}
+
+// matchesTagFilter reports whether a prompt is shown under the tag filter
+func (m Model) matchesTagFilter(p *models.Prompt) bool {
+	return m.tagFilter == "" || p.HasTag(m.tagFilter)
+}
+
+// renderTagChip shows the applied tag filter above the library list
+func (m Model) renderTagChip() string {
+	count := 0
+	for _, p := range m.prompts {
+		if m.matchesTagFilter(p) {
+			count++
+		}
+	}
+	chip := lipgloss.NewStyle().
+		Foreground(ColorText).
+		Background(ColorSurface).
+		Bold(true).
+		Padding(0, 1).
+		Render("# " + m.tagFilter)
+	hint := lipgloss.NewStyle().Foreground(ColorTextMuted).Render(fmt.Sprintf(" %d prompts • # change • Esc clear", count))
+	return lipgloss.JoinHorizontal(lipgloss.Left, chip, hint)
+}
+
+// renderTagChooser draws the tag chooser as a compact modal
+func (m Model) renderTagChooser() string {
+	chooser := m.tagChooser
+	width := min(50, m.width-4)
+
+	modalStyle := lipgloss.NewStyle().
+		Border(boxBorder(lipgloss.RoundedBorder())).
+		Padding(1, 2).
+		Width(width)
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		MarginBottom(1)
+
+	selectedStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(ColorPrimary)
+
+	countStyle := lipgloss.NewStyle().
+		Foreground(ColorTextDim)
+
+	helpStyle := lipgloss.NewStyle().
+		Foreground(ColorTextDim).
+		Italic(true).
+		MarginTop(1)
+
+	content := []string{titleStyle.Render(i18n.T("tags.filter_title")), chooser.input.View(), ""}
+	if len(chooser.matches) == 0 {
+		content = append(content, countStyle.Render(i18n.T("tags.no_match")))
+	}
+
+	// Keep the selected tag in view
+	start := max(0, chooser.cursor-maxTagChooserRows+1)
+	end := min(len(chooser.matches), start+maxTagChooserRows)
+	for i := start; i < end; i++ {
+		tc := chooser.matches[i]
+		name := tc.tag
+		if name == m.tagFilter {
+			name += " (applied)"
+		}
+		line := "  " + name
+		if i == chooser.cursor {
+			line = selectedStyle.Render("▶ " + name)
+		}
+		content = append(content, line+countStyle.Render(fmt.Sprintf(" %d", tc.count)))
+	}
+	content = append(content, helpStyle.Render("enter apply (again to clear) • ↑/↓ select • Esc cancel"))
+
+	modal := modalStyle.Render(lipgloss.JoinVertical(lipgloss.Left, content...))
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, modal)
+}
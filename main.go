@@ -3,23 +3,42 @@ package main
 import (
 	"flag"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"syscall"
 	"time"
 
 	"github.com/dpshade/pocket-prompt/internal/cli"
+	"github.com/dpshade/pocket-prompt/internal/logging"
 	"github.com/dpshade/pocket-prompt/internal/server"
 	"github.com/dpshade/pocket-prompt/internal/service"
+	"github.com/dpshade/pocket-prompt/internal/startup"
 	"github.com/dpshade/pocket-prompt/internal/ui"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
 )
 
 var version = "0.1.0"
 
+// printError reports an error that ended the program, in debug mode with
+// each error it wraps and how long the program ran
+func printError(err error, start time.Time) {
+	fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+	if !logging.Debug() {
+		return
+	}
+	for _, line := range logging.ErrorChain(err) {
+		fmt.Fprintf(os.Stderr, "  %s\n", line)
+	}
+	fmt.Fprintf(os.Stderr, "  failed after %v\n", time.Since(start).Round(time.Microsecond))
+}
+
 // killExistingServers finds and kills any running pocket-prompt URL server processes
 func killExistingServers() error {
 	// Find processes running pocket-prompt with --url-server
@@ -72,8 +91,30 @@ OPTIONS:
     --url-server    Start URL server for iOS Shortcuts integration
     --restart       Kill any running URL server instances and restart
     --port          Port for URL server (default: 8080)
-    --sync-interval Git sync interval in minutes (default: 5, 0 to disable)
-    --no-git-sync   Disable periodic git synchronization
+    --bind          Address for URL server to listen on (default: 127.0.0.1,
+                    0.0.0.0 for all interfaces, unix:/path for a socket)
+    --verbose       Log debug details, such as the git commands run
+    --debug         Like --verbose, and errors show each wrapped error with
+                    its file path and how long the command took
+    --log-level     Least severe level logged: debug, info, warn or error
+                    (default: warn, info with --url-server)
+    --log-format    Log format: text or json (default: text)
+    --log-file      Append logs to a file instead of stderr (the TUI only
+                    logs to a file)
+    --sync-interval Sync interval in minutes (default: 5, 0 to disable)
+    --no-git-sync   Disable periodic synchronization (git, or sync.provider
+                    in config.yaml: rsync or rclone)
+    --profile-startup
+                    Print how long each phase of startup took on exit
+                    (storage walk, parsing, glamour init, first paint)
+    --plain         Plain output for screen readers and dumb terminals: no
+                    color or box-drawing, and the TUI puts status first and
+                    never lays out panes side by side (default with
+                    TERM=dumb)
+    --no-color      Output without color, keeping the layout (like setting
+                    NO_COLOR)
+    --dir           Library directory for this run, overriding
+                    POCKET_PROMPT_DIR (default: ~/.pocket-prompt)
 
 COMMANDS:
     (no command)       Start interactive TUI mode
@@ -85,6 +126,10 @@ COMMANDS:
     delete, rm <id>    Delete a prompt
     copy <id>          Copy prompt to clipboard
     render <id>        Render prompt with variables
+    pick [query]       Pick a prompt interactively and print its ID
+    tmux-popup         Pick and fill a prompt from a tmux popup
+    serve --stdio      JSON-RPC on stdin/stdout for editor extensions
+    query              Answer one JSON request on stdin
     templates          List templates
     template           Template management (create, edit, delete, show)
     tags               List all tags
@@ -102,7 +147,14 @@ EXAMPLES:
     pocket-prompt --url-server                       # Start URL server for iOS
     pocket-prompt --url-server --restart            # Kill existing servers and restart
     pocket-prompt --url-server --port 9000          # Start server on port 9000
+    pocket-prompt --url-server --bind 0.0.0.0       # Reachable from your phone on the LAN
+    pocket-prompt --url-server --bind unix:/run/pocket-prompt.sock  # Behind a reverse proxy
     pocket-prompt --url-server --sync-interval 1    # Sync every 1 minute
+    pocket-prompt --url-server --log-format json --log-file server.log  # JSON access logs
+    pocket-prompt --verbose git sync                # Show what sync is doing
+    pocket-prompt --debug edit my-prompt            # Full details when something fails
+    pocket-prompt --plain                           # TUI for a screen reader
+    pocket-prompt --dir ~/work-prompts list         # List another library
     pocket-prompt --url-server --no-git-sync        # Disable git sync
     pocket-prompt list --format table               # List prompts in table format
     pocket-prompt search "machine learning"         # Search prompts
@@ -116,7 +168,7 @@ EXAMPLES:
 
 STORAGE:
     Default directory: ~/.pocket-prompt
-    Override with: POCKET_PROMPT_DIR=<path>
+    Override with: POCKET_PROMPT_DIR=<path>, or --dir <path> for one run
 
 For more information, visit: https://github.com/dpshade/pocket-prompt
 `)
@@ -129,8 +181,18 @@ func main() {
 	var urlServer bool
 	var restartServer bool
 	var port int
+	var bind string
+	var verbose bool
+	var debug bool
+	var logLevel string
+	var logFormat string
+	var logFile string
 	var syncInterval int
 	var noGitSync bool
+	var profileStartup bool
+	var plain bool
+	var noColor bool
+	var dir string
 
 	flag.BoolVar(&showVersion, "version", false, "Print version information")
 	flag.BoolVar(&initLib, "init", false, "Initialize a new prompt library")
@@ -138,8 +200,18 @@ func main() {
 	flag.BoolVar(&urlServer, "url-server", false, "Start URL server for iOS Shortcuts integration")
 	flag.BoolVar(&restartServer, "restart", false, "Kill any running URL server instances and restart")
 	flag.IntVar(&port, "port", 8080, "Port for URL server")
-	flag.IntVar(&syncInterval, "sync-interval", 5, "Git sync interval in minutes (0 to disable)")
-	flag.BoolVar(&noGitSync, "no-git-sync", false, "Disable periodic git synchronization")
+	flag.StringVar(&bind, "bind", server.DefaultBindAddress, "Address for URL server to listen on, or unix:/path for a socket")
+	flag.BoolVar(&verbose, "verbose", false, "Log debug details")
+	flag.BoolVar(&debug, "debug", false, "Log debug details and show wrapped errors in full")
+	flag.StringVar(&logLevel, "log-level", "", "Least severe level logged: debug, info, warn or error")
+	flag.StringVar(&logFormat, "log-format", logging.FormatText, "Log format: text or json")
+	flag.StringVar(&logFile, "log-file", "", "Append logs to a file instead of stderr")
+	flag.IntVar(&syncInterval, "sync-interval", 5, "Sync interval in minutes (0 to disable)")
+	flag.BoolVar(&noGitSync, "no-git-sync", false, "Disable periodic synchronization")
+	flag.BoolVar(&profileStartup, "profile-startup", false, "Print startup phase timings on exit")
+	flag.BoolVar(&plain, "plain", false, "Plain output for screen readers and dumb terminals: no color or box-drawing")
+	flag.BoolVar(&noColor, "no-color", false, "Output without color")
+	flag.StringVar(&dir, "dir", "", "Library directory, overriding POCKET_PROMPT_DIR")
 	flag.Parse()
 
 	if showHelp {
@@ -158,10 +230,61 @@ func main() {
 		os.Exit(1)
 	}
 
+	// The server logs each request at info level; the TUI would be drawn
+	// over by anything written to the terminal
+	start := time.Now()
+	if profileStartup {
+		startup.Enable(start)
+		defer startup.Report(os.Stderr)
+	}
+	serverMode := urlServer || restartServer
+	tuiMode := !serverMode && !initLib && flag.NArg() == 0
+	logging.SetDebug(debug)
+	logOptions := logging.Options{Level: logLevel, Format: logFormat, File: logFile, Timestamps: serverMode}
+	if verbose || debug {
+		logOptions.Level = "debug"
+	} else if logLevel == "" && serverMode {
+		logOptions.Level = "info"
+	}
+	if tuiMode && debug && logFile == "" {
+		logOptions.File = filepath.Join(os.TempDir(), "pocket-prompt-debug.log")
+		defer fmt.Printf("Debug log written to %s\n", logOptions.File)
+	}
+	logOutput := io.Writer(os.Stderr)
+	if tuiMode {
+		logOutput = io.Discard
+	}
+	closeLog, err := logging.Setup(logOptions, logOutput)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	defer closeLog()
+
+	// A dumb terminal can't show color or move the cursor around
+	if os.Getenv("TERM") == "dumb" {
+		plain = true
+	}
+	if plain || noColor {
+		lipgloss.SetColorProfile(termenv.Ascii)
+	}
+	ui.SetPlain(plain)
+
+	// A relative --dir is taken from where the command runs, not from the
+	// directories plugins and git are started in
+	if dir != "" {
+		if dir, err = filepath.Abs(dir); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
 	// Initialize service with file storage
-	svc, err := service.NewService()
+	endService := startup.Phase("service")
+	svc, err := service.NewServiceAt(dir)
+	endService()
 	if err != nil {
-		fmt.Println(err)
+		printError(err, start)
 		return
 	}
 
@@ -174,7 +297,7 @@ func main() {
 		return
 	}
 
-	if urlServer || restartServer {
+	if serverMode {
 		// Handle restart flag - kill existing servers first
 		if restartServer {
 			fmt.Printf("Restarting URL server...\n")
@@ -185,12 +308,16 @@ func main() {
 		
 		fmt.Printf("Starting URL server for iOS Shortcuts integration...\n")
 		urlSrv := server.NewURLServer(svc, port)
+		urlSrv.SetBindAddress(bind)
 		
-		// Configure git sync
-		if noGitSync || syncInterval == 0 {
-			urlSrv.SetGitSync(false)
-		} else {
-			urlSrv.SetGitSync(true)
+		// Configure periodic sync with git or the provider in config.yaml
+		if !noGitSync && syncInterval > 0 {
+			syncer, err := svc.Syncer()
+			if err != nil {
+				fmt.Printf("Error configuring sync: %v\n", err)
+				os.Exit(1)
+			}
+			urlSrv.SetSyncer(syncer)
 			urlSrv.SetSyncInterval(time.Duration(syncInterval) * time.Minute)
 		}
 		
@@ -206,8 +333,10 @@ func main() {
 	if len(args) > 0 {
 		// CLI mode - execute command and exit
 		cliHandler := cli.NewCLI(svc)
+		cliHandler.SetPlain(plain)
 		if err := cliHandler.ExecuteCommand(args); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			printError(err, start)
+			closeLog()
 			os.Exit(1)
 		}
 		return